@@ -1,2 +0,0 @@
-// Package alu contains the ALU implementation
-package alu
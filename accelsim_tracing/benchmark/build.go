@@ -43,3 +43,19 @@ func (bm *BenchMark) Exec(gpu *gpu.GPU) error {
 	err := bm.trace.Exec(gpu)
 	return err
 }
+
+// KernelRecords returns the execution time of the kernels in the trace.
+func (bm *BenchMark) KernelRecords() []trace.KernelRecord {
+	if bm.trace == nil {
+		return nil
+	}
+	return bm.trace.KernelRecords()
+}
+
+// MemcpyRecords returns the transfer time of the memory copies in the trace.
+func (bm *BenchMark) MemcpyRecords() []trace.MemcpyRecord {
+	if bm.trace == nil {
+		return nil
+	}
+	return bm.trace.MemcpyRecords()
+}
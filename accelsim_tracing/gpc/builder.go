@@ -1,6 +1,9 @@
 package gpc
 
 import (
+	"fmt"
+
+	"github.com/sarchlab/akita/v3/sim"
 	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
 	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/sm"
 )
@@ -9,9 +12,15 @@ type GPC struct {
 	meta       *gpcMetaData
 	dispatcher gpcDispatcher
 	sms        []*sm.SM
+
+	threadBlockDoneHandler sm.ThreadBlockDoneHandler
 }
 
 type gpcMetaData struct {
+	name   string
+	engine sim.Engine
+	freq   sim.Freq
+
 	smNum     int32
 	smUnitNum int32
 
@@ -21,10 +30,6 @@ type gpcMetaData struct {
 
 	l2CacheSize int32
 	l1CacheSize int32
-	l0CacheSize int32
-
-	registerFileSize int32
-	laneSize         int32
 
 	alus []struct {
 		aluType string
@@ -35,6 +40,9 @@ type gpcMetaData struct {
 func NewGPC() *GPC {
 	return &GPC{
 		meta: &gpcMetaData{
+			name: "GPC",
+			freq: 1 * sim.GHz,
+
 			smNum:     0,
 			smUnitNum: 0,
 
@@ -44,10 +52,6 @@ func NewGPC() *GPC {
 
 			l2CacheSize: 0,
 			l1CacheSize: 0,
-			l0CacheSize: 0,
-
-			registerFileSize: 0,
-			laneSize:         0,
 
 			alus: nil,
 		},
@@ -56,6 +60,28 @@ func NewGPC() *GPC {
 	}
 }
 
+func (g *GPC) WithName(name string) *GPC {
+	g.meta.name = name
+	return g
+}
+
+func (g *GPC) WithEngine(engine sim.Engine) *GPC {
+	g.meta.engine = engine
+	return g
+}
+
+func (g *GPC) WithFreq(freq sim.Freq) *GPC {
+	g.meta.freq = freq
+	return g
+}
+
+// WithThreadBlockDoneHandler sets the function to call when a thread block
+// completes.
+func (g *GPC) WithThreadBlockDoneHandler(h sm.ThreadBlockDoneHandler) *GPC {
+	g.threadBlockDoneHandler = h
+	return g
+}
+
 func (g *GPC) WithSMNum(num int32) *GPC {
 	g.meta.smNum = num
 	return g
@@ -91,21 +117,6 @@ func (g *GPC) WithL1CacheSize(size int32) *GPC {
 	return g
 }
 
-func (g *GPC) WithL0CacheSize(size int32) *GPC {
-	g.meta.l0CacheSize = size
-	return g
-}
-
-func (g *GPC) WithRegisterFileSize(size int32) *GPC {
-	g.meta.registerFileSize = size
-	return g
-}
-
-func (g *GPC) WithLaneSize(size int32) *GPC {
-	g.meta.laneSize = size
-	return g
-}
-
 func (g *GPC) WithALU(aluType string, num int32) *GPC {
 	g.meta.alus = append(g.meta.alus, struct {
 		aluType string
@@ -119,13 +130,14 @@ func (g *GPC) Build() {
 	g.sms = make([]*sm.SM, g.meta.smNum)
 	for i := 0; i < int(g.meta.smNum); i++ {
 		g.sms[i] = sm.NewSM().
-		WithSMStrategy(g.meta.smStrategy).
+			WithName(fmt.Sprintf("%s.SM[%d]", g.meta.name, i)).
+			WithEngine(g.meta.engine).
+			WithFreq(g.meta.freq).
+			WithThreadBlockDoneHandler(g.handleThreadBlockDone).
+			WithSMStrategy(g.meta.smStrategy).
 			WithSMUnitNum(g.meta.smUnitNum).
 			WithSMUnitStrategy(g.meta.smUnitStrategy).
-			WithL1CacheSize(g.meta.l1CacheSize).
-			WithL0CacheSize(g.meta.l0CacheSize).
-			WithRegisterFileSize(g.meta.registerFileSize).
-			WithLaneSize(g.meta.laneSize)
+			WithL1CacheSize(g.meta.l1CacheSize)
 		for _, alu := range g.meta.alus {
 			g.sms[i].WithALU(alu.aluType, alu.aluNum)
 		}
@@ -133,10 +145,30 @@ func (g *GPC) Build() {
 	}
 }
 
-func (g *GPC) IsFree() bool {
-	return true
+// SMs returns the SMs of the GPC.
+func (g *GPC) SMs() []*sm.SM {
+	return g.sms
+}
+
+// IsFree checks if an SM of the GPC can run the thread block.
+func (g *GPC) IsFree(tb *nvidia.ThreadBlock) bool {
+	for _, s := range g.sms {
+		if s.IsFree(tb) {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *GPC) Execute(now sim.VTimeInSec, tb *nvidia.ThreadBlock) {
+	g.dispatcher.dispatch(now, tb)
 }
 
-func (g *GPC) Execute(tb *nvidia.ThreadBlock) {
-	g.dispatcher.dispatch(tb)
+func (g *GPC) handleThreadBlockDone(
+	now sim.VTimeInSec,
+	tb *nvidia.ThreadBlock,
+) {
+	if g.threadBlockDoneHandler != nil {
+		g.threadBlockDoneHandler(now, tb)
+	}
 }
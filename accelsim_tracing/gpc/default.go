@@ -1,9 +1,13 @@
 package gpc
 
-import "github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+import (
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+)
 
 type defaultDispatcher struct {
 	parent *GPC
+	next   int
 }
 
 func newDefaultDispatcher() *defaultDispatcher {
@@ -15,18 +19,21 @@ func (d *defaultDispatcher) withParent(gpc *GPC) gpcDispatcher {
 	return d
 }
 
-func (d *defaultDispatcher) dispatch(tb *nvidia.ThreadBlock) {
-	for {
-		flag := false
-		for _, sm := range d.parent.sms {
-			if sm.IsFree() {
-				sm.Execute(tb)
-				flag = true
-				break
-			}
-		}
-		if flag {
-			break
+// dispatch sends the thread block to the next SM that can run it, in a
+// round-robin fashion. The caller should check if the GPC is free first.
+func (d *defaultDispatcher) dispatch(
+	now sim.VTimeInSec,
+	tb *nvidia.ThreadBlock,
+) {
+	sms := d.parent.sms
+	for i := 0; i < len(sms); i++ {
+		index := (d.next + i) % len(sms)
+		if sms[index].IsFree(tb) {
+			sms[index].Execute(now, tb)
+			d.next = index + 1
+			return
 		}
 	}
+
+	panic("no SM can run the thread block")
 }
@@ -1,10 +1,13 @@
 package gpc
 
-import "github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+import (
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+)
 
 type gpcDispatcher interface {
 	withParent(gpc *GPC) gpcDispatcher
-	dispatch(tb *nvidia.ThreadBlock)
+	dispatch(now sim.VTimeInSec, tb *nvidia.ThreadBlock)
 }
 
 func (g *GPC) buildDispatcher() {
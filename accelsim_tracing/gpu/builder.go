@@ -1,17 +1,44 @@
 package gpu
 
 import (
+	"errors"
+	"fmt"
+
+	"github.com/sarchlab/akita/v3/mem/cache/writeback"
+	"github.com/sarchlab/akita/v3/mem/idealmemcontroller"
+	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/sim"
 	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/gpc"
 	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/smunit"
 )
 
 type GPU struct {
 	meta       *gpuMetaData
 	dispatcher gpuDispatcher
 	gpcs       []*gpc.GPC
+
+	engine   sim.Engine
+	l2Cache  *writeback.Cache
+	dram     *idealmemcontroller.Comp
+	l1ToL2   *sim.DirectConnection
+	l2ToDram *sim.DirectConnection
+
+	pendingThreadBlocks []*nvidia.ThreadBlock
+	numRunning          int
+	kernels             map[*nvidia.ThreadBlock]*kernelState
+
+	pcie *pcieLink
 }
 
 type gpuMetaData struct {
+	name        string
+	freq        sim.Freq
+	dramLatency int
+
+	pcieBandwidth float64
+	pcieLatency   sim.VTimeInSec
+
 	gpcNum    int32
 	smNum     int32
 	smUnitNum int32
@@ -23,10 +50,6 @@ type gpuMetaData struct {
 
 	l2CacheSize int32
 	l1CacheSize int32
-	l0CacheSize int32
-
-	registerFileSize int32
-	laneSize         int32
 
 	alus []struct {
 		aluType string
@@ -37,6 +60,13 @@ type gpuMetaData struct {
 func NewGPU() *GPU {
 	return &GPU{
 		meta: &gpuMetaData{
+			name:        "GPU",
+			freq:        1 * sim.GHz,
+			dramLatency: 200,
+
+			pcieBandwidth: 16e9,
+			pcieLatency:   1e-6,
+
 			gpcNum:    0,
 			smNum:     0,
 			smUnitNum: 0,
@@ -48,10 +78,6 @@ func NewGPU() *GPU {
 
 			l2CacheSize: 0,
 			l1CacheSize: 0,
-			l0CacheSize: 0,
-
-			registerFileSize: 0,
-			laneSize:         0,
 
 			alus: nil,
 		},
@@ -60,6 +86,44 @@ func NewGPU() *GPU {
 	}
 }
 
+func (g *GPU) WithName(name string) *GPU {
+	g.meta.name = name
+	return g
+}
+
+// WithEngine sets the engine that the GPU components use. A serial engine is
+// created if no engine is given.
+func (g *GPU) WithEngine(engine sim.Engine) *GPU {
+	g.engine = engine
+	return g
+}
+
+func (g *GPU) WithFreq(freq sim.Freq) *GPU {
+	g.meta.freq = freq
+	return g
+}
+
+// WithDRAMLatency sets the number of cycles that the DRAM takes to serve a
+// request.
+func (g *GPU) WithDRAMLatency(cycles int) *GPU {
+	g.meta.dramLatency = cycles
+	return g
+}
+
+// WithPCIeBandwidth sets the bandwidth of the link between the host and the
+// GPU in bytes per second, in each direction.
+func (g *GPU) WithPCIeBandwidth(bytesPerSecond float64) *GPU {
+	g.meta.pcieBandwidth = bytesPerSecond
+	return g
+}
+
+// WithPCIeLatency sets the time that the link between the host and the GPU
+// takes to start a transfer.
+func (g *GPU) WithPCIeLatency(latency sim.VTimeInSec) *GPU {
+	g.meta.pcieLatency = latency
+	return g
+}
+
 func (g *GPU) WithGPCNum(num int32) *GPU {
 	g.meta.gpcNum = num
 	return g
@@ -105,21 +169,6 @@ func (g *GPU) WithL1CacheSize(size int32) *GPU {
 	return g
 }
 
-func (g *GPU) WithL0CacheSize(size int32) *GPU {
-	g.meta.l0CacheSize = size
-	return g
-}
-
-func (g *GPU) WithRegisterFileSize(size int32) *GPU {
-	g.meta.registerFileSize = size
-	return g
-}
-
-func (g *GPU) WithLaneSize(size int32) *GPU {
-	g.meta.laneSize = size
-	return g
-}
-
 func (g *GPU) WithALU(aluType string, num int32) *GPU {
 	g.meta.alus = append(g.meta.alus, struct {
 		aluType string
@@ -129,29 +178,122 @@ func (g *GPU) WithALU(aluType string, num int32) *GPU {
 }
 
 func (g *GPU) Build() {
+	if g.engine == nil {
+		g.engine = sim.NewSerialEngine()
+	}
+
+	g.kernels = make(map[*nvidia.ThreadBlock]*kernelState)
 	g.buildDispatcher()
+	g.buildMemory()
+	g.buildPCIeLink()
 	g.gpcs = make([]*gpc.GPC, g.meta.gpcNum)
 	for i := 0; i < int(g.meta.gpcNum); i++ {
 		g.gpcs[i] = gpc.NewGPC().
+			WithName(fmt.Sprintf("%s.GPC[%d]", g.meta.name, i)).
+			WithEngine(g.engine).
+			WithFreq(g.meta.freq).
+			WithThreadBlockDoneHandler(g.handleThreadBlockDone).
 			WithSMNum(g.meta.smNum).
 			WithSMUnitNum(g.meta.smUnitNum).
 			WithGPCStrategy(g.meta.gpcStrategy).
 			WithSMStrategy(g.meta.smStrategy).
 			WithSMUnitStrategy(g.meta.smUnitStrategy).
 			WithL2CacheSize(g.meta.l2CacheSize).
-			WithL1CacheSize(g.meta.l1CacheSize).
-			WithL0CacheSize(g.meta.l0CacheSize).
-			WithRegisterFileSize(g.meta.registerFileSize).
-			WithLaneSize(g.meta.laneSize)
+			WithL1CacheSize(g.meta.l1CacheSize)
 		for _, alu := range g.meta.alus {
 			g.gpcs[i].WithALU(alu.aluType, alu.aluNum)
 		}
 		g.gpcs[i].Build()
 	}
+	g.connectL1s()
+}
+
+// buildMemory creates the L2 cache and the DRAM that all the SMs share. Trace
+// addresses are virtual addresses, so the DRAM storage covers the whole user
+// address space. The storage only allocates the pages that are touched.
+func (g *GPU) buildMemory() {
+	g.dram = idealmemcontroller.MakeBuilder().
+		WithEngine(g.engine).
+		WithFreq(g.meta.freq).
+		WithLatency(g.meta.dramLatency).
+		WithNewStorage(1 << 48).
+		Build(g.meta.name + ".DRAM")
+
+	l2ByteSize := uint64(g.meta.l2CacheSize) / nvidia.BYTE
+	if l2ByteSize < 64*mem.KB {
+		l2ByteSize = 64 * mem.KB
+	}
+	g.l2Cache = writeback.MakeBuilder().
+		WithEngine(g.engine).
+		WithFreq(g.meta.freq).
+		WithLog2BlockSize(7).
+		WithWayAssociativity(16).
+		WithByteSize(l2ByteSize).
+		WithNumMSHREntry(64).
+		WithNumReqPerCycle(16).
+		WithLowModuleFinder(&mem.SingleLowModuleFinder{
+			LowModule: g.dram.GetPortByName("Top"),
+		}).
+		Build(g.meta.name + ".L2Cache")
+
+	g.l2ToDram = sim.NewDirectConnection(
+		g.meta.name+".L2ToDRAM", g.engine, g.meta.freq)
+	g.l2ToDram.PlugIn(g.l2Cache.GetPortByName("Bottom"), 64)
+	g.l2ToDram.PlugIn(g.dram.GetPortByName("Top"), 64)
+
+	g.l1ToL2 = sim.NewDirectConnection(
+		g.meta.name+".L1ToL2", g.engine, g.meta.freq)
+	g.l1ToL2.PlugIn(g.l2Cache.GetPortByName("Top"), 64)
 }
 
-// RunThreadBlock runs a threadblock on the GPU
-// [todo] how to handle the relationship between trace.threadblock and truethreadblock
-func (g *GPU) RunThreadBlock(tb *nvidia.ThreadBlock) {
-	g.dispatcher.dispatch(tb)
+func (g *GPU) connectL1s() {
+	lowModuleFinder := &mem.SingleLowModuleFinder{
+		LowModule: g.l2Cache.GetPortByName("Top"),
+	}
+
+	for _, c := range g.gpcs {
+		for _, s := range c.SMs() {
+			l1 := s.L1Cache()
+			l1.SetLowModuleFinder(lowModuleFinder)
+			g.l1ToL2.PlugIn(l1.GetPortByName("Bottom"), 16)
+		}
+	}
+}
+
+// MemoryStats returns the memory transactions that all the SM units have
+// generated.
+func (g *GPU) MemoryStats() smunit.MemoryStats {
+	stats := smunit.MemoryStats{}
+	for _, c := range g.gpcs {
+		for _, s := range c.SMs() {
+			for _, u := range s.SMUnits() {
+				stats.Add(u.MemoryStats())
+			}
+		}
+	}
+	return stats
+}
+
+// Engine returns the engine that drives the GPU.
+func (g *GPU) Engine() sim.Engine {
+	return g.engine
+}
+
+// Freq returns the frequency that the GPU works at.
+func (g *GPU) Freq() sim.Freq {
+	return g.meta.freq
+}
+
+// Run simulates until all the launched kernels and memory copies complete.
+func (g *GPU) Run() error {
+	err := g.engine.Run()
+	if err != nil {
+		return err
+	}
+
+	if len(g.pendingThreadBlocks) > 0 || g.numRunning > 0 {
+		return errors.New("simulation stopped before all thread blocks complete")
+	}
+
+	return nil
 }
@@ -1,11 +1,14 @@
 package gpu
 
 import (
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/gpc"
 	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
 )
 
 type defaultDispatcher struct {
 	parent *GPU
+	next   int
 }
 
 func newDefaultDispatcher() *defaultDispatcher {
@@ -17,18 +20,33 @@ func (d *defaultDispatcher) withParent(gpu *GPU) gpuDispatcher {
 	return d
 }
 
-func (d *defaultDispatcher) dispatch(tb *nvidia.ThreadBlock) {
-	for {
-		flag := false
-		for _, gpc := range d.parent.gpcs {
-			if gpc.IsFree() {
-				gpc.Execute(tb)
-				flag = true
-				break
-			}
+// dispatch starts the pending thread blocks in order, sending each of them to
+// the next GPC that can run it, until no GPC has enough space.
+func (d *defaultDispatcher) dispatch(now sim.VTimeInSec) {
+	g := d.parent
+	for len(g.pendingThreadBlocks) > 0 {
+		tb := g.pendingThreadBlocks[0]
+		target := d.findGPC(tb)
+		if target == nil {
+			return
 		}
-		if flag {
-			break
+
+		// Executing the thread block may call back into the dispatcher, so
+		// the state is updated first.
+		g.pendingThreadBlocks = g.pendingThreadBlocks[1:]
+		g.numRunning++
+		target.Execute(now, tb)
+	}
+}
+
+func (d *defaultDispatcher) findGPC(tb *nvidia.ThreadBlock) *gpc.GPC {
+	gpcs := d.parent.gpcs
+	for i := 0; i < len(gpcs); i++ {
+		index := (d.next + i) % len(gpcs)
+		if gpcs[index].IsFree(tb) {
+			d.next = index + 1
+			return gpcs[index]
 		}
 	}
+	return nil
 }
@@ -1,10 +1,10 @@
 package gpu
 
-import "github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+import "github.com/sarchlab/akita/v3/sim"
 
 type gpuDispatcher interface {
 	withParent(gpu *GPU) gpuDispatcher
-	dispatch(tb *nvidia.ThreadBlock)
+	dispatch(now sim.VTimeInSec)
 }
 
 func (g *GPU) buildDispatcher() {
@@ -0,0 +1,57 @@
+package gpu
+
+import (
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+)
+
+// KernelDoneHandler is called when all the thread blocks of a kernel
+// complete.
+type KernelDoneHandler func(now sim.VTimeInSec)
+
+type kernelState struct {
+	numRunningThreadBlocks int
+	doneHandler            KernelDoneHandler
+}
+
+// LaunchKernel queues the thread blocks of a kernel to run on the GPU. The
+// thread blocks start as soon as an SM has enough space for them, so the
+// kernel can overlap with the kernels that are launched earlier. The handler
+// is called when all the thread blocks complete.
+func (g *GPU) LaunchKernel(
+	tbs []*nvidia.ThreadBlock,
+	doneHandler KernelDoneHandler,
+) {
+	now := g.engine.CurrentTime()
+	if len(tbs) == 0 {
+		doneHandler(now)
+		return
+	}
+
+	k := &kernelState{
+		numRunningThreadBlocks: len(tbs),
+		doneHandler:            doneHandler,
+	}
+	for _, tb := range tbs {
+		g.kernels[tb] = k
+	}
+
+	g.pendingThreadBlocks = append(g.pendingThreadBlocks, tbs...)
+	g.dispatcher.dispatch(now)
+}
+
+func (g *GPU) handleThreadBlockDone(
+	now sim.VTimeInSec,
+	tb *nvidia.ThreadBlock,
+) {
+	g.numRunning--
+
+	k := g.kernels[tb]
+	delete(g.kernels, tb)
+	k.numRunningThreadBlocks--
+	if k.numRunningThreadBlocks == 0 && k.doneHandler != nil {
+		k.doneHandler(now)
+	}
+
+	g.dispatcher.dispatch(now)
+}
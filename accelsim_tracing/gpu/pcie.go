@@ -0,0 +1,93 @@
+package gpu
+
+import (
+	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+// MemcpyDoneHandler is called when a memory copy completes.
+type MemcpyDoneHandler func(now sim.VTimeInSec)
+
+// pcieLink models the link between the host and the GPU. The two directions
+// of the link work independently, and the copies in the same direction are
+// serialized.
+type pcieLink struct {
+	engine    sim.Engine
+	bandwidth float64
+	latency   sim.VTimeInSec
+
+	h2dBusyUntil sim.VTimeInSec
+	d2hBusyUntil sim.VTimeInSec
+}
+
+type memcpyDoneEvent struct {
+	*sim.EventBase
+	handler MemcpyDoneHandler
+}
+
+func (l *pcieLink) Handle(e sim.Event) error {
+	evt := e.(*memcpyDoneEvent)
+	evt.handler(evt.Time())
+	return nil
+}
+
+func (g *GPU) buildPCIeLink() {
+	g.pcie = &pcieLink{
+		engine:    g.engine,
+		bandwidth: g.meta.pcieBandwidth,
+		latency:   g.meta.pcieLatency,
+	}
+}
+
+// Memcpy copies data between the host and the GPU DRAM over the PCIe link.
+// The handler is called when the last byte arrives.
+func (g *GPU) Memcpy(
+	h2d bool,
+	addr uint64,
+	length uint64,
+	doneHandler MemcpyDoneHandler,
+) {
+	l := g.pcie
+	now := g.engine.CurrentTime()
+
+	busyUntil := &l.d2hBusyUntil
+	if h2d {
+		busyUntil = &l.h2dBusyUntil
+	}
+
+	start := now
+	if *busyUntil > start {
+		start = *busyUntil
+	}
+	end := start + l.latency + sim.VTimeInSec(float64(length)/l.bandwidth)
+	*busyUntil = end
+
+	if h2d {
+		g.placeData(addr, length)
+	}
+
+	evt := &memcpyDoneEvent{
+		EventBase: sim.NewEventBase(end, l),
+		handler:   doneHandler,
+	}
+	l.engine.Schedule(evt)
+}
+
+// placeData makes the copied range resident in the GPU DRAM. The traces do
+// not record the data, so the range is filled with zeros.
+func (g *GPU) placeData(addr uint64, length uint64) {
+	const chunkSize = 4 * mem.KB
+	zeros := make([]byte, chunkSize)
+
+	for offset := uint64(0); offset < length; offset += chunkSize {
+		n := chunkSize
+		if length-offset < n {
+			n = length - offset
+		}
+
+		err := g.dram.Storage.Write(addr+offset, zeros[:n])
+		if err != nil {
+			panic(err)
+		}
+	}
+}
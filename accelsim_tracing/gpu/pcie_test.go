@@ -0,0 +1,70 @@
+package gpu
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+var _ = Describe("PCIe link", func() {
+	var (
+		g *GPU
+	)
+
+	BeforeEach(func() {
+		g = NewGPU().
+			WithGPCNum(1).
+			WithSMNum(1).
+			WithSMUnitNum(1).
+			WithPCIeBandwidth(1e9).
+			WithPCIeLatency(1e-6)
+		g.Build()
+	})
+
+	memcpy := func(h2d bool, addr, length uint64) *sim.VTimeInSec {
+		end := sim.VTimeInSec(-1)
+		g.Memcpy(h2d, addr, length, func(now sim.VTimeInSec) {
+			end = now
+		})
+		return &end
+	}
+
+	It("should take the latency and the transfer time", func() {
+		end := memcpy(true, 0x1000, 1000)
+
+		Expect(g.Run()).To(Succeed())
+
+		Expect(*end).To(BeNumerically("~", 2e-6, 1e-12))
+	})
+
+	It("should serialize the copies in the same direction", func() {
+		end1 := memcpy(true, 0x1000, 1000)
+		end2 := memcpy(true, 0x2000, 1000)
+
+		Expect(g.Run()).To(Succeed())
+
+		Expect(*end1).To(BeNumerically("~", 2e-6, 1e-12))
+		Expect(*end2).To(BeNumerically("~", 4e-6, 1e-12))
+	})
+
+	It("should run the two directions at the same time", func() {
+		h2d := memcpy(true, 0x1000, 1000)
+		d2h := memcpy(false, 0x2000, 1000)
+
+		Expect(g.Run()).To(Succeed())
+
+		Expect(*h2d).To(BeNumerically("~", 2e-6, 1e-12))
+		Expect(*d2h).To(BeNumerically("~", 2e-6, 1e-12))
+	})
+
+	It("should start a copy when the link becomes free", func() {
+		end1 := memcpy(false, 0x1000, 1000)
+		Expect(g.Run()).To(Succeed())
+
+		end2 := memcpy(false, 0x1000, 3000)
+		Expect(g.Run()).To(Succeed())
+
+		Expect(*end1).To(BeNumerically("~", 2e-6, 1e-12))
+		Expect(*end2).To(BeNumerically("~", 6e-6, 1e-12))
+	})
+})
@@ -1,8 +1,11 @@
 package nvidia
 
-import "log"
+import (
+	"log"
+	"strings"
+)
 
-// VariableType [todo] how to construct these?
+// VariableType is the type of the values that an instruction operates on.
 type VariableType int32
 
 const (
@@ -11,47 +14,620 @@ const (
 	VariableINT32
 	VariableFP32
 	VariableFP64
+	VariableFP16
+	VariableINT64
+	VariablePredicate
+)
+
+// FunctionalUnit is the class of the hardware unit that executes an
+// instruction.
+type FunctionalUnit int32
+
+const (
+	UnitDefault FunctionalUnit = iota
+	UnitError
+	UnitINT
+	UnitSP
+	UnitDP
+	UnitHalf
+	UnitSFU
+	UnitTensor
+	UnitLDST
+	UnitTexture
+	UnitBranch
+	UnitBarrier
+	UnitMisc
+)
+
+// MemorySpace is the memory space that an instruction accesses.
+type MemorySpace int32
+
+const (
+	MemoryNone MemorySpace = iota
+	MemoryGlobal
+	MemoryShared
+	MemoryLocal
+	MemoryConstant
+	MemoryGeneric
+	MemoryTexture
+)
+
+// MemoryAccess is the way an instruction accesses the memory.
+type MemoryAccess int32
+
+const (
+	AccessNone MemoryAccess = iota
+	AccessLoad
+	AccessStore
+	AccessAtomic
+	AccessReduction
 )
 
+// OpCodeType is the base opcode of an instruction, without modifiers.
 type OpCodeType int32
 
 const (
 	OpCodeDefault OpCodeType = iota
 	OpCodeError
-	IMADMOVU32
+
+	// FP32
+	FADD
+	FADD32I
+	FCHK
+	FCMP
+	FFMA
+	FFMA32I
+	FMNMX
+	FMUL
+	FMUL32I
+	FSEL
+	FSET
+	FSETP
+	FSWZADD
+	MUFU
+	RRO
+
+	// FP64
+	DADD
+	DFMA
+	DMNMX
+	DMUL
+	DSET
+	DSETP
+
+	// FP16
+	HADD2
+	HADD232I
+	HFMA2
+	HFMA232I
+	HMNMX2
+	HMUL2
+	HMUL232I
+	HSET2
+	HSETP2
+
+	// Tensor
+	HMMA
+	IMMA
+	DMMA
+	BMMA
+
+	// Integer
+	BMSK
+	BREV
+	FLO
+	IABS
+	IADD
+	IADD3
+	IADD32I
+	IDP
+	IDP4A
+	IMAD
+	IMADSP
+	IMNMX
+	IMUL
+	IMUL32I
+	ISCADD
+	ISCADD32I
+	ISET
+	ISETP
+	LEA
+	LOP
+	LOP3
+	LOP32I
+	POPC
+	SHF
+	SHL
+	SHR
+	VABSDIFF
+	VABSDIFF4
+
+	// Conversion
+	F2F
+	F2FP
+	F2I
+	FRND
+	I2F
+	I2I
+	I2IP
+
+	// Movement
+	MOV
+	MOV32I
+	MOVM
+	PRMT
+	SEL
+	SGXT
+	SHFL
+
+	// Predicate
+	P2R
+	PLOP3
+	PSETP
+	R2P
+
+	// Load and store
+	LD
+	LDC
+	LDG
+	LDGDEPBAR
+	LDGSTS
+	LDL
+	LDS
+	LDSM
+	ST
+	STG
+	STL
+	STS
+	MATCH
+	QSPC
+	ATOM
+	ATOMG
+	ATOMS
+	RED
+	CCTL
+	CCTLL
+	CCTLT
+	ERRBAR
+	MEMBAR
+
+	// Uniform datapath
+	R2UR
+	S2UR
+	UBMSK
+	UBREV
+	UCLEA
+	UFLO
+	UIADD3
+	UIMAD
+	UISETP
+	ULDC
+	ULEA
+	ULOP
+	ULOP3
+	ULOP32I
+	UMOV
+	UP2UR
+	UPLOP3
+	UPOPC
+	UPRMT
+	UPSETP
+	UR2UP
+	USEL
+	USGXT
+	USHF
+	USHL
+	USHR
+	VOTEU
+
+	// Texture and surface
+	TEX
+	TLD
+	TLD4
+	TMML
+	TXD
+	TXQ
+	SUATOM
+	SULD
+	SURED
+	SUST
+
+	// Control
+	BMOV
+	BPT
+	BRA
+	BREAK
+	BRX
+	BRXU
+	BSSY
+	BSYNC
+	CALL
+	EXIT
+	JMP
+	JMX
+	JMXU
+	KILL
+	NANOSLEEP
+	RET
+	RPCMOV
+	RTT
+	WARPSYNC
+	YIELD
+
+	// Miscellaneous
+	B2R
+	BAR
+	CS2R
+	DEPBAR
+	GETLMEMBASE
+	LEPC
+	NOP
+	PMTRIG
+	R2B
+	S2R
+	SETCTAID
+	SETLMEMBASE
+	VOTE
 )
 
-type Opcode struct {
-	rawText string
+type opcodeInfo struct {
 	opType  OpCodeType
+	unit    FunctionalUnit
 	varType VariableType
+	space   MemorySpace
+	access  MemoryAccess
 }
 
+// Opcode is the opcode of a SASS instruction, such as "LDG.E.64".
+type Opcode struct {
+	rawText   string
+	base      string
+	modifiers []string
+	info      opcodeInfo
+}
+
+// NewOpcode parses the opcode of a SASS instruction. The opcode is looked up
+// by its base name, so all the modifiers of a known opcode are accepted.
 func NewOpcode(rawText string) *Opcode {
-	op, ok := opcodeTable[rawText]
+	elems := strings.Split(rawText, ".")
+	info, ok := opcodeTable[elems[0]]
 	if !ok {
-		op = Opcode{rawText, OpCodeError, VariableError}
 		log.Panic("Unknown opcode: ", rawText)
 	}
-	return &op
+
+	op := &Opcode{
+		rawText:   rawText,
+		base:      elems[0],
+		modifiers: elems[1:],
+		info:      info,
+	}
+	op.info.varType = op.refineVariableType()
+
+	return op
+}
+
+// refineVariableType uses the modifiers to find the type of the values for the
+// opcodes that work on more than one type, such as "IADD3.64" or
+// "F2F.F64.F32". The destination type comes first in the modifiers.
+func (op *Opcode) refineVariableType() VariableType {
+	for _, m := range op.modifiers {
+		switch m {
+		case "F64":
+			return VariableFP64
+		case "F32":
+			return VariableFP32
+		case "F16", "F16x2":
+			return VariableFP16
+		case "S32", "U32", "S16", "U16", "S8", "U8":
+			return VariableINT32
+		case "64", "S64", "U64", "WIDE":
+			if op.info.varType == VariableINT32 {
+				return VariableINT64
+			}
+		}
+	}
+
+	return op.info.varType
 }
 
 func (op *Opcode) String() string {
 	return op.rawText
 }
 
+// Base returns the opcode without modifiers, such as "LDG".
+func (op *Opcode) Base() string {
+	return op.base
+}
+
+// Modifiers returns the modifiers of the opcode, such as ["E", "64"].
+func (op *Opcode) Modifiers() []string {
+	return op.modifiers
+}
+
+// HasModifier checks if the opcode carries the given modifier.
+func (op *Opcode) HasModifier(modifier string) bool {
+	for _, m := range op.modifiers {
+		if m == modifier {
+			return true
+		}
+	}
+	return false
+}
+
 func (op *Opcode) OpcodeType() OpCodeType {
-	return op.opType
+	return op.info.opType
 }
 
 func (op *Opcode) VariableType() VariableType {
-	return op.varType
+	return op.info.varType
+}
+
+// FunctionalUnit returns the class of the unit that executes the instruction.
+func (op *Opcode) FunctionalUnit() FunctionalUnit {
+	return op.info.unit
 }
 
-var opcodeTable map[string]Opcode
+// MemorySpace returns the memory space that the instruction accesses.
+func (op *Opcode) MemorySpace() MemorySpace {
+	return op.info.space
+}
+
+// MemoryAccess returns how the instruction accesses the memory.
+func (op *Opcode) MemoryAccess() MemoryAccess {
+	return op.info.access
+}
+
+// IsMemoryInst checks if the instruction reads or writes the memory.
+func (op *Opcode) IsMemoryInst() bool {
+	return op.info.access != AccessNone
+}
+
+var opcodeTable map[string]opcodeInfo
+
+func addOpcode(name string, opType OpCodeType, unit FunctionalUnit,
+	varType VariableType) {
+	opcodeTable[name] = opcodeInfo{opType, unit, varType, MemoryNone, AccessNone}
+}
+
+func addMemOpcode(name string, opType OpCodeType, space MemorySpace,
+	access MemoryAccess) {
+	opcodeTable[name] = opcodeInfo{
+		opType, UnitLDST, VariableDefault, space, access}
+}
 
 func init() {
-	opcodeTable = make(map[string]Opcode)
+	opcodeTable = make(map[string]opcodeInfo)
+
+	initFP32Opcodes()
+	initFP64Opcodes()
+	initFP16Opcodes()
+	initTensorOpcodes()
+	initIntegerOpcodes()
+	initConversionOpcodes()
+	initMovementOpcodes()
+	initPredicateOpcodes()
+	initMemoryOpcodes()
+	initUniformOpcodes()
+	initTextureOpcodes()
+	initControlOpcodes()
+	initMiscOpcodes()
+}
+
+func initFP32Opcodes() {
+	addOpcode("FADD", FADD, UnitSP, VariableFP32)
+	addOpcode("FADD32I", FADD32I, UnitSP, VariableFP32)
+	addOpcode("FCHK", FCHK, UnitSP, VariableFP32)
+	addOpcode("FCMP", FCMP, UnitSP, VariableFP32)
+	addOpcode("FFMA", FFMA, UnitSP, VariableFP32)
+	addOpcode("FFMA32I", FFMA32I, UnitSP, VariableFP32)
+	addOpcode("FMNMX", FMNMX, UnitSP, VariableFP32)
+	addOpcode("FMUL", FMUL, UnitSP, VariableFP32)
+	addOpcode("FMUL32I", FMUL32I, UnitSP, VariableFP32)
+	addOpcode("FSEL", FSEL, UnitSP, VariableFP32)
+	addOpcode("FSET", FSET, UnitSP, VariableFP32)
+	addOpcode("FSETP", FSETP, UnitSP, VariableFP32)
+	addOpcode("FSWZADD", FSWZADD, UnitSP, VariableFP32)
+	addOpcode("MUFU", MUFU, UnitSFU, VariableFP32)
+	addOpcode("RRO", RRO, UnitSFU, VariableFP32)
+}
+
+func initFP64Opcodes() {
+	addOpcode("DADD", DADD, UnitDP, VariableFP64)
+	addOpcode("DFMA", DFMA, UnitDP, VariableFP64)
+	addOpcode("DMNMX", DMNMX, UnitDP, VariableFP64)
+	addOpcode("DMUL", DMUL, UnitDP, VariableFP64)
+	addOpcode("DSET", DSET, UnitDP, VariableFP64)
+	addOpcode("DSETP", DSETP, UnitDP, VariableFP64)
+}
+
+func initFP16Opcodes() {
+	addOpcode("HADD2", HADD2, UnitHalf, VariableFP16)
+	addOpcode("HADD2_32I", HADD232I, UnitHalf, VariableFP16)
+	addOpcode("HFMA2", HFMA2, UnitHalf, VariableFP16)
+	addOpcode("HFMA2_32I", HFMA232I, UnitHalf, VariableFP16)
+	addOpcode("HMNMX2", HMNMX2, UnitHalf, VariableFP16)
+	addOpcode("HMUL2", HMUL2, UnitHalf, VariableFP16)
+	addOpcode("HMUL2_32I", HMUL232I, UnitHalf, VariableFP16)
+	addOpcode("HSET2", HSET2, UnitHalf, VariableFP16)
+	addOpcode("HSETP2", HSETP2, UnitHalf, VariableFP16)
+}
+
+func initTensorOpcodes() {
+	addOpcode("HMMA", HMMA, UnitTensor, VariableFP16)
+	addOpcode("IMMA", IMMA, UnitTensor, VariableINT32)
+	addOpcode("DMMA", DMMA, UnitTensor, VariableFP64)
+	addOpcode("BMMA", BMMA, UnitTensor, VariableINT32)
+}
+
+func initIntegerOpcodes() {
+	addOpcode("BMSK", BMSK, UnitINT, VariableINT32)
+	addOpcode("BREV", BREV, UnitINT, VariableINT32)
+	addOpcode("FLO", FLO, UnitINT, VariableINT32)
+	addOpcode("IABS", IABS, UnitINT, VariableINT32)
+	addOpcode("IADD", IADD, UnitINT, VariableINT32)
+	addOpcode("IADD3", IADD3, UnitINT, VariableINT32)
+	addOpcode("IADD32I", IADD32I, UnitINT, VariableINT32)
+	addOpcode("IDP", IDP, UnitINT, VariableINT32)
+	addOpcode("IDP4A", IDP4A, UnitINT, VariableINT32)
+	addOpcode("IMAD", IMAD, UnitINT, VariableINT32)
+	addOpcode("IMADSP", IMADSP, UnitINT, VariableINT32)
+	addOpcode("IMNMX", IMNMX, UnitINT, VariableINT32)
+	addOpcode("IMUL", IMUL, UnitINT, VariableINT32)
+	addOpcode("IMUL32I", IMUL32I, UnitINT, VariableINT32)
+	addOpcode("ISCADD", ISCADD, UnitINT, VariableINT32)
+	addOpcode("ISCADD32I", ISCADD32I, UnitINT, VariableINT32)
+	addOpcode("ISET", ISET, UnitINT, VariableINT32)
+	addOpcode("ISETP", ISETP, UnitINT, VariableINT32)
+	addOpcode("LEA", LEA, UnitINT, VariableINT32)
+	addOpcode("LOP", LOP, UnitINT, VariableINT32)
+	addOpcode("LOP3", LOP3, UnitINT, VariableINT32)
+	addOpcode("LOP32I", LOP32I, UnitINT, VariableINT32)
+	addOpcode("POPC", POPC, UnitINT, VariableINT32)
+	addOpcode("SHF", SHF, UnitINT, VariableINT32)
+	addOpcode("SHL", SHL, UnitINT, VariableINT32)
+	addOpcode("SHR", SHR, UnitINT, VariableINT32)
+	addOpcode("VABSDIFF", VABSDIFF, UnitINT, VariableINT32)
+	addOpcode("VABSDIFF4", VABSDIFF4, UnitINT, VariableINT32)
+}
+
+func initConversionOpcodes() {
+	addOpcode("F2F", F2F, UnitSFU, VariableFP32)
+	addOpcode("F2FP", F2FP, UnitHalf, VariableFP16)
+	addOpcode("F2I", F2I, UnitSFU, VariableINT32)
+	addOpcode("FRND", FRND, UnitSFU, VariableFP32)
+	addOpcode("I2F", I2F, UnitSFU, VariableFP32)
+	addOpcode("I2I", I2I, UnitSFU, VariableINT32)
+	addOpcode("I2IP", I2IP, UnitINT, VariableINT32)
+}
+
+func initMovementOpcodes() {
+	addOpcode("MOV", MOV, UnitINT, VariableINT32)
+	addOpcode("MOV32I", MOV32I, UnitINT, VariableINT32)
+	addOpcode("MOVM", MOVM, UnitLDST, VariableINT32)
+	addOpcode("PRMT", PRMT, UnitINT, VariableINT32)
+	addOpcode("SEL", SEL, UnitINT, VariableINT32)
+	addOpcode("SGXT", SGXT, UnitINT, VariableINT32)
+	addOpcode("SHFL", SHFL, UnitLDST, VariableINT32)
+}
+
+func initPredicateOpcodes() {
+	addOpcode("P2R", P2R, UnitINT, VariablePredicate)
+	addOpcode("PLOP3", PLOP3, UnitINT, VariablePredicate)
+	addOpcode("PSETP", PSETP, UnitINT, VariablePredicate)
+	addOpcode("R2P", R2P, UnitINT, VariablePredicate)
+}
+
+func initMemoryOpcodes() {
+	addMemOpcode("LD", LD, MemoryGeneric, AccessLoad)
+	addMemOpcode("LDC", LDC, MemoryConstant, AccessLoad)
+	addMemOpcode("LDG", LDG, MemoryGlobal, AccessLoad)
+	addMemOpcode("LDGSTS", LDGSTS, MemoryGlobal, AccessLoad)
+	addMemOpcode("LDL", LDL, MemoryLocal, AccessLoad)
+	addMemOpcode("LDS", LDS, MemoryShared, AccessLoad)
+	addMemOpcode("LDSM", LDSM, MemoryShared, AccessLoad)
+	addMemOpcode("ST", ST, MemoryGeneric, AccessStore)
+	addMemOpcode("STG", STG, MemoryGlobal, AccessStore)
+	addMemOpcode("STL", STL, MemoryLocal, AccessStore)
+	addMemOpcode("STS", STS, MemoryShared, AccessStore)
+	addMemOpcode("ATOM", ATOM, MemoryGeneric, AccessAtomic)
+	addMemOpcode("ATOMG", ATOMG, MemoryGlobal, AccessAtomic)
+	addMemOpcode("ATOMS", ATOMS, MemoryShared, AccessAtomic)
+	addMemOpcode("RED", RED, MemoryGlobal, AccessReduction)
+
+	addOpcode("LDGDEPBAR", LDGDEPBAR, UnitLDST, VariableDefault)
+	addOpcode("MATCH", MATCH, UnitLDST, VariableINT32)
+	addOpcode("QSPC", QSPC, UnitLDST, VariablePredicate)
+	addOpcode("CCTL", CCTL, UnitLDST, VariableDefault)
+	addOpcode("CCTLL", CCTLL, UnitLDST, VariableDefault)
+	addOpcode("CCTLT", CCTLT, UnitLDST, VariableDefault)
+	addOpcode("ERRBAR", ERRBAR, UnitBarrier, VariableDefault)
+	addOpcode("MEMBAR", MEMBAR, UnitBarrier, VariableDefault)
+}
+
+func initUniformOpcodes() {
+	addOpcode("R2UR", R2UR, UnitINT, VariableINT32)
+	addOpcode("S2UR", S2UR, UnitMisc, VariableINT32)
+	addOpcode("UBMSK", UBMSK, UnitINT, VariableINT32)
+	addOpcode("UBREV", UBREV, UnitINT, VariableINT32)
+	addOpcode("UCLEA", UCLEA, UnitINT, VariableINT32)
+	addOpcode("UFLO", UFLO, UnitINT, VariableINT32)
+	addOpcode("UIADD3", UIADD3, UnitINT, VariableINT32)
+	addOpcode("UIMAD", UIMAD, UnitINT, VariableINT32)
+	addOpcode("UISETP", UISETP, UnitINT, VariableINT32)
+	addOpcode("ULDC", ULDC, UnitINT, VariableINT32)
+	addOpcode("ULEA", ULEA, UnitINT, VariableINT32)
+	addOpcode("ULOP", ULOP, UnitINT, VariableINT32)
+	addOpcode("ULOP3", ULOP3, UnitINT, VariableINT32)
+	addOpcode("ULOP32I", ULOP32I, UnitINT, VariableINT32)
+	addOpcode("UMOV", UMOV, UnitINT, VariableINT32)
+	addOpcode("UP2UR", UP2UR, UnitINT, VariablePredicate)
+	addOpcode("UPLOP3", UPLOP3, UnitINT, VariablePredicate)
+	addOpcode("UPOPC", UPOPC, UnitINT, VariableINT32)
+	addOpcode("UPRMT", UPRMT, UnitINT, VariableINT32)
+	addOpcode("UPSETP", UPSETP, UnitINT, VariablePredicate)
+	addOpcode("UR2UP", UR2UP, UnitINT, VariablePredicate)
+	addOpcode("USEL", USEL, UnitINT, VariableINT32)
+	addOpcode("USGXT", USGXT, UnitINT, VariableINT32)
+	addOpcode("USHF", USHF, UnitINT, VariableINT32)
+	addOpcode("USHL", USHL, UnitINT, VariableINT32)
+	addOpcode("USHR", USHR, UnitINT, VariableINT32)
+	addOpcode("VOTEU", VOTEU, UnitINT, VariablePredicate)
+}
+
+func initTextureOpcodes() {
+	for name, opType := range map[string]OpCodeType{
+		"TEX": TEX, "TLD": TLD, "TLD4": TLD4, "TMML": TMML, "TXD": TXD,
+		"TXQ": TXQ,
+	} {
+		opcodeTable[name] = opcodeInfo{
+			opType, UnitTexture, VariableDefault, MemoryTexture, AccessLoad}
+	}
+
+	opcodeTable["SUATOM"] = opcodeInfo{
+		SUATOM, UnitTexture, VariableDefault, MemoryTexture, AccessAtomic}
+	opcodeTable["SULD"] = opcodeInfo{
+		SULD, UnitTexture, VariableDefault, MemoryTexture, AccessLoad}
+	opcodeTable["SURED"] = opcodeInfo{
+		SURED, UnitTexture, VariableDefault, MemoryTexture, AccessReduction}
+	opcodeTable["SUST"] = opcodeInfo{
+		SUST, UnitTexture, VariableDefault, MemoryTexture, AccessStore}
+}
+
+func initControlOpcodes() {
+	addOpcode("BMOV", BMOV, UnitBranch, VariableINT32)
+	addOpcode("BPT", BPT, UnitBranch, VariableDefault)
+	addOpcode("BRA", BRA, UnitBranch, VariableDefault)
+	addOpcode("BREAK", BREAK, UnitBranch, VariableDefault)
+	addOpcode("BRX", BRX, UnitBranch, VariableDefault)
+	addOpcode("BRXU", BRXU, UnitBranch, VariableDefault)
+	addOpcode("BSSY", BSSY, UnitBranch, VariableDefault)
+	addOpcode("BSYNC", BSYNC, UnitBranch, VariableDefault)
+	addOpcode("CALL", CALL, UnitBranch, VariableDefault)
+	addOpcode("EXIT", EXIT, UnitBranch, VariableDefault)
+	addOpcode("JMP", JMP, UnitBranch, VariableDefault)
+	addOpcode("JMX", JMX, UnitBranch, VariableDefault)
+	addOpcode("JMXU", JMXU, UnitBranch, VariableDefault)
+	addOpcode("KILL", KILL, UnitBranch, VariableDefault)
+	addOpcode("NANOSLEEP", NANOSLEEP, UnitBranch, VariableDefault)
+	addOpcode("RET", RET, UnitBranch, VariableDefault)
+	addOpcode("RPCMOV", RPCMOV, UnitBranch, VariableDefault)
+	addOpcode("RTT", RTT, UnitBranch, VariableDefault)
+	addOpcode("WARPSYNC", WARPSYNC, UnitBarrier, VariableDefault)
+	addOpcode("YIELD", YIELD, UnitBranch, VariableDefault)
+}
 
-	opcodeTable["IMAD.MOV.U32"] = Opcode{"IMAD.MOV.U32", IMADMOVU32, VariableINT32}
+func initMiscOpcodes() {
+	addOpcode("B2R", B2R, UnitMisc, VariableINT32)
+	addOpcode("BAR", BAR, UnitBarrier, VariableDefault)
+	addOpcode("CS2R", CS2R, UnitMisc, VariableINT32)
+	addOpcode("DEPBAR", DEPBAR, UnitBarrier, VariableDefault)
+	addOpcode("GETLMEMBASE", GETLMEMBASE, UnitMisc, VariableINT32)
+	addOpcode("LEPC", LEPC, UnitMisc, VariableINT64)
+	addOpcode("NOP", NOP, UnitMisc, VariableDefault)
+	addOpcode("PMTRIG", PMTRIG, UnitMisc, VariableDefault)
+	addOpcode("R2B", R2B, UnitMisc, VariableINT32)
+	addOpcode("S2R", S2R, UnitMisc, VariableINT32)
+	addOpcode("SETCTAID", SETCTAID, UnitMisc, VariableDefault)
+	addOpcode("SETLMEMBASE", SETLMEMBASE, UnitMisc, VariableDefault)
+	addOpcode("VOTE", VOTE, UnitINT, VariablePredicate)
 }
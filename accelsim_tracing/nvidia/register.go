@@ -6,15 +6,16 @@ import (
 )
 
 type Register struct {
-	rawText string
-	regID   int32
-	isZero  bool
+	rawText   string
+	regID     int32
+	isZero    bool
+	isUniform bool
 }
 
 func NewRegister(rawText string) *Register {
 	reg, ok := registerTable[rawText]
 	if !ok {
-		reg = Register{rawText, -1, false}
+		reg = Register{rawText, -1, false, false}
 		log.Panic("Unknown register: ", rawText)
 	}
 	return &reg
@@ -32,13 +33,28 @@ func (r *Register) IsZeroRegister() bool {
 	return r.isZero
 }
 
+// IsUniformRegister checks if the register is a uniform register (UR) that is
+// shared by all the threads of a warp.
+func (r *Register) IsUniformRegister() bool {
+	return r.isUniform
+}
+
 var registerTable map[string]Register
 
 func init() {
 	registerTable = make(map[string]Register)
 
-	for i := 0; i < 32; i++ {
-		registerTable[fmt.Sprintf("R%d", i)] = Register{fmt.Sprintf("R%d", i), int32(i), false}
+	for i := 0; i < 255; i++ {
+		name := fmt.Sprintf("R%d", i)
+		registerTable[name] = Register{name, int32(i), false, false}
+	}
+	registerTable["R255"] = Register{"R255", 255, true, false}
+	registerTable["RZ"] = Register{"RZ", 255, true, false}
+
+	for i := 0; i < 63; i++ {
+		name := fmt.Sprintf("UR%d", i)
+		registerTable[name] = Register{name, int32(i), false, true}
 	}
-	registerTable["R255"] = Register{"R255", 255, true}
+	registerTable["UR63"] = Register{"UR63", 63, true, true}
+	registerTable["URZ"] = Register{"URZ", 63, true, true}
 }
@@ -23,4 +23,11 @@ type Instruction struct {
 	MemAddress        int64
 	MemAddressSuffix1 int32
 	MemAddressSuffix2 []int32
+
+	// MemAddresses are the addresses accessed by the active threads, in the
+	// order of the lanes.
+	MemAddresses []uint64
+	// MemorySpace is the memory space that the instruction accesses, with
+	// generic addresses resolved to the shared, local, or global space.
+	MemorySpace MemorySpace
 }
@@ -1,17 +1,43 @@
 package sm
 
 import (
+	"fmt"
+
+	"github.com/sarchlab/akita/v3/mem/cache/writearound"
+	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/sim"
 	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
 	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/smunit"
 )
 
+// ThreadBlockDoneHandler is called when all the warps of a thread block
+// complete.
+type ThreadBlockDoneHandler func(now sim.VTimeInSec, tb *nvidia.ThreadBlock)
+
 type SM struct {
 	meta       *smMetaData
 	dispatcher smDispatcher
 	smUnits    []*smunit.SMUnit
+
+	l1Cache *writearound.Cache
+	l1Conn  *sim.DirectConnection
+
+	threadBlocks map[*nvidia.ThreadBlock]*threadBlockState
+
+	threadBlockDoneHandler ThreadBlockDoneHandler
+}
+
+type threadBlockState struct {
+	runningWarps int
+	warpsAtBar   int
+	units        []*smunit.SMUnit
 }
 
 type smMetaData struct {
+	name   string
+	engine sim.Engine
+	freq   sim.Freq
+
 	smUnitNum int32
 
 	smStrategy     string
@@ -19,10 +45,6 @@ type smMetaData struct {
 
 	l2CacheSize int32
 	l1CacheSize int32
-	l0CacheSize int32
-
-	registerFileSize int32
-	laneSize         int32
 
 	alus []struct {
 		aluType string
@@ -33,56 +55,56 @@ type smMetaData struct {
 func NewSM() *SM {
 	return &SM{
 		meta: &smMetaData{
+			name: "SM",
+			freq: 1 * sim.GHz,
+
 			smUnitNum: 0,
 
 			smStrategy:     "default",
 			smUnitStrategy: "default",
 
 			l1CacheSize: 0,
-			l0CacheSize: 0,
-
-			registerFileSize: 0,
-			laneSize:         0,
 
 			alus: nil,
 		},
-		dispatcher: nil,
-		smUnits:    nil,
+		dispatcher:   nil,
+		smUnits:      nil,
+		threadBlocks: make(map[*nvidia.ThreadBlock]*threadBlockState),
 	}
 }
 
-func (s *SM) WithSMStrategy(strategy string) *SM {
-	s.meta.smStrategy = strategy
+func (s *SM) WithName(name string) *SM {
+	s.meta.name = name
 	return s
 }
 
-func (s *SM) WithSMUnitNum(num int32) *SM {
-	s.meta.smUnitNum = num
+func (s *SM) WithEngine(engine sim.Engine) *SM {
+	s.meta.engine = engine
 	return s
 }
 
-func (s *SM) WithSMUnitStrategy(strategy string) *SM {
-	s.meta.smUnitStrategy = strategy
+func (s *SM) WithFreq(freq sim.Freq) *SM {
+	s.meta.freq = freq
 	return s
 }
 
-func (s *SM) WithL1CacheSize(size int32) *SM {
-	s.meta.l1CacheSize = size
+func (s *SM) WithSMStrategy(strategy string) *SM {
+	s.meta.smStrategy = strategy
 	return s
 }
 
-func (s *SM) WithL0CacheSize(size int32) *SM {
-	s.meta.l0CacheSize = size
+func (s *SM) WithSMUnitNum(num int32) *SM {
+	s.meta.smUnitNum = num
 	return s
 }
 
-func (s *SM) WithRegisterFileSize(size int32) *SM {
-	s.meta.registerFileSize = size
+func (s *SM) WithSMUnitStrategy(strategy string) *SM {
+	s.meta.smUnitStrategy = strategy
 	return s
 }
 
-func (s *SM) WithLaneSize(size int32) *SM {
-	s.meta.laneSize = size
+func (s *SM) WithL1CacheSize(size int32) *SM {
+	s.meta.l1CacheSize = size
 	return s
 }
 
@@ -94,26 +116,127 @@ func (s *SM) WithALU(aluType string, aluNum int32) *SM {
 	return s
 }
 
+// WithThreadBlockDoneHandler sets the function to call when a thread block
+// completes.
+func (s *SM) WithThreadBlockDoneHandler(h ThreadBlockDoneHandler) *SM {
+	s.threadBlockDoneHandler = h
+	return s
+}
+
 func (s *SM) Build() {
 	s.buildDispatcher()
+	s.buildL1Cache()
+	s.l1Conn = sim.NewDirectConnection(
+		s.meta.name+".L1Conn", s.meta.engine, s.meta.freq)
+	s.l1Conn.PlugIn(s.l1Cache.GetPortByName("Top"), 64)
+
 	s.smUnits = make([]*smunit.SMUnit, s.meta.smUnitNum)
 	for i := 0; i < int(s.meta.smUnitNum); i++ {
 		s.smUnits[i] = smunit.NewSMUnit().
-		WithSMUnitStrategy(s.meta.smUnitStrategy).
-			WithL0CacheSize(s.meta.l0CacheSize).
-			WithRegisterFileSize(s.meta.registerFileSize).
-			WithLaneSize(s.meta.laneSize)
+			WithName(fmt.Sprintf("%s.SMUnit[%d]", s.meta.name, i)).
+			WithEngine(s.meta.engine).
+			WithFreq(s.meta.freq).
+			WithSMUnitStrategy(s.meta.smUnitStrategy).
+			WithWarpDoneHandler(s.handleWarpDone).
+			WithBarrierHandler(s.handleBarrier)
 		for _, alu := range s.meta.alus {
 			s.smUnits[i].WithALU(alu.aluType, alu.aluNum)
 		}
 		s.smUnits[i].Build()
+		s.smUnits[i].SetLowModule(s.l1Cache.GetPortByName("Top"))
+		s.l1Conn.PlugIn(s.smUnits[i].ToMem(), 64)
+	}
+}
+
+func (s *SM) buildL1Cache() {
+	s.l1Cache = writearound.NewBuilder().
+		WithEngine(s.meta.engine).
+		WithFreq(s.meta.freq).
+		WithBankLatency(20).
+		WithNumBanks(1).
+		WithLog2BlockSize(7).
+		WithWayAssociativity(4).
+		WithNumMSHREntry(32).
+		WithTotalByteSize(cacheByteSize(s.meta.l1CacheSize)).
+		Build(s.meta.name + ".L1Cache")
+}
+
+// cacheByteSize converts the cache sizes, which are given in bits, to bytes.
+// The size is never smaller than one set of cache lines.
+func cacheByteSize(size int32) uint64 {
+	byteSize := uint64(size) / nvidia.BYTE
+	if byteSize < 4*mem.KB {
+		byteSize = 4 * mem.KB
 	}
+	return byteSize
 }
 
-func (s *SM) IsFree() bool {
-	return true
+// L1Cache returns the L1 cache of the SM.
+func (s *SM) L1Cache() *writearound.Cache {
+	return s.l1Cache
 }
 
-func (s *SM) Execute(tb *nvidia.ThreadBlock) {
-	s.dispatcher.dispatch(tb)
+// SMUnits returns the SM units of the SM.
+func (s *SM) SMUnits() []*smunit.SMUnit {
+	return s.smUnits
+}
+
+// IsFree checks if the SM has enough warp slots to run the thread block.
+func (s *SM) IsFree(tb *nvidia.ThreadBlock) bool {
+	free := 0
+	for _, u := range s.smUnits {
+		free += u.NumFreeWarpSlots()
+	}
+	return free >= len(tb.Warps)
+}
+
+func (s *SM) Execute(now sim.VTimeInSec, tb *nvidia.ThreadBlock) {
+	s.threadBlocks[tb] = &threadBlockState{runningWarps: len(tb.Warps)}
+	s.dispatcher.dispatch(now, tb)
+
+	if len(tb.Warps) == 0 {
+		s.finishThreadBlock(now, tb)
+	}
+}
+
+func (s *SM) handleWarpDone(now sim.VTimeInSec, tb *nvidia.ThreadBlock) {
+	state := s.threadBlocks[tb]
+	state.runningWarps--
+
+	if state.runningWarps == 0 {
+		s.finishThreadBlock(now, tb)
+		return
+	}
+
+	s.tryReleaseBarrier(now, tb, state)
+}
+
+func (s *SM) handleBarrier(now sim.VTimeInSec, tb *nvidia.ThreadBlock) {
+	state := s.threadBlocks[tb]
+	state.warpsAtBar++
+	s.tryReleaseBarrier(now, tb, state)
+}
+
+// tryReleaseBarrier lets the warps continue when all the warps of the thread
+// block that have not exited arrive at the barrier.
+func (s *SM) tryReleaseBarrier(
+	now sim.VTimeInSec,
+	tb *nvidia.ThreadBlock,
+	state *threadBlockState,
+) {
+	if state.warpsAtBar == 0 || state.warpsAtBar < state.runningWarps {
+		return
+	}
+
+	state.warpsAtBar = 0
+	for _, u := range state.units {
+		u.ReleaseBarrier(now, tb)
+	}
+}
+
+func (s *SM) finishThreadBlock(now sim.VTimeInSec, tb *nvidia.ThreadBlock) {
+	delete(s.threadBlocks, tb)
+	if s.threadBlockDoneHandler != nil {
+		s.threadBlockDoneHandler(now, tb)
+	}
 }
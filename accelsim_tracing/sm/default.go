@@ -1,9 +1,14 @@
 package sm
 
-import "github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+import (
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/smunit"
+)
 
 type defaultDispatcher struct {
 	parent *SM
+	next   int
 }
 
 func newDefaultDispatcher() *defaultDispatcher {
@@ -15,20 +20,36 @@ func (d *defaultDispatcher) withParent(sm *SM) smDispatcher {
 	return d
 }
 
-func (d *defaultDispatcher) dispatch(tb *nvidia.ThreadBlock) {
+// dispatch distributes the warps of the thread block to the SM units in a
+// round-robin fashion.
+func (d *defaultDispatcher) dispatch(
+	now sim.VTimeInSec,
+	tb *nvidia.ThreadBlock,
+) {
+	state := d.parent.threadBlocks[tb]
+	units := d.parent.smUnits
+
 	for _, warp := range tb.Warps {
-		for {
-			flag := false
-			for _, smUnit := range d.parent.smUnits {
-				if smUnit.IsFree() {
-					smUnit.Execute(warp)
-					flag = true
-					break
-				}
-			}
-			if flag {
+		for i := 0; i < len(units); i++ {
+			unit := units[(d.next+i)%len(units)]
+			if unit.IsFree() {
+				unit.Execute(now, tb, warp)
+				d.addUnit(state, unit)
+				d.next = (d.next + i + 1) % len(units)
 				break
 			}
 		}
 	}
 }
+
+func (d *defaultDispatcher) addUnit(
+	state *threadBlockState,
+	unit *smunit.SMUnit,
+) {
+	for _, u := range state.units {
+		if u == unit {
+			return
+		}
+	}
+	state.units = append(state.units, unit)
+}
@@ -1,10 +1,13 @@
 package sm
 
-import "github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+import (
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+)
 
 type smDispatcher interface {
 	withParent(sm *SM) smDispatcher
-	dispatch(tb *nvidia.ThreadBlock)
+	dispatch(now sim.VTimeInSec, tb *nvidia.ThreadBlock)
 }
 
 func (s *SM) buildDispatcher() {
@@ -1,24 +1,73 @@
 package smunit
 
 import (
-	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/alu"
+	"github.com/sarchlab/akita/v3/sim"
 	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
 )
 
+// WarpDoneHandler is called when all the instructions of a warp complete.
+type WarpDoneHandler func(now sim.VTimeInSec, tb *nvidia.ThreadBlock)
+
+// BarrierHandler is called when a warp arrives at a thread block barrier.
+type BarrierHandler func(now sim.VTimeInSec, tb *nvidia.ThreadBlock)
+
 type SMUnit struct {
-	meta         *smUnitMetaData
-	dispatcher   smUnitDispatcher
-	registerFile *RegisterFile
-	aluGroup     []*alu.ALUGroup
+	*sim.TickingComponent
+
+	meta       *smUnitMetaData
+	dispatcher smUnitDispatcher
+
+	toMem     sim.Port
+	lowModule sim.Port
+
+	warps         []*warpContext
+	inflight      []*inflightInst
+	memQueue      []*queuedReq
+	pendingMem    map[string]*memAccess
+	unitBusyUntil map[nvidia.FunctionalUnit]sim.VTimeInSec
+	wakeupTime    sim.VTimeInSec
+
+	warpDoneHandler WarpDoneHandler
+	barrierHandler  BarrierHandler
+
+	stats MemoryStats
+}
+
+// MemoryStats counts the memory transactions that an SM unit generates.
+type MemoryStats struct {
+	GlobalLoadSectors   uint64
+	GlobalStoreSectors  uint64
+	LocalLoadSectors    uint64
+	LocalStoreSectors   uint64
+	AtomicSectors       uint64
+	SharedAccesses      uint64
+	SharedBankConflicts uint64
+}
+
+// Add accumulates the counters of another MemoryStats.
+func (m *MemoryStats) Add(other MemoryStats) {
+	m.GlobalLoadSectors += other.GlobalLoadSectors
+	m.GlobalStoreSectors += other.GlobalStoreSectors
+	m.LocalLoadSectors += other.LocalLoadSectors
+	m.LocalStoreSectors += other.LocalStoreSectors
+	m.AtomicSectors += other.AtomicSectors
+	m.SharedAccesses += other.SharedAccesses
+	m.SharedBankConflicts += other.SharedBankConflicts
 }
 
 type smUnitMetaData struct {
+	name           string
+	engine         sim.Engine
+	freq           sim.Freq
 	smUnitStrategy string
 
-	l0CacheSize int32
+	maxWarps int32
 
-	registerFileSize int32
-	laneSize         int32
+	memQueueSize      int
+	numMemReqPerCycle int
+
+	latencies      map[nvidia.FunctionalUnit]int
+	issueIntervals map[nvidia.FunctionalUnit]int
 
 	alus []struct {
 		aluType string
@@ -29,38 +78,72 @@ type smUnitMetaData struct {
 func NewSMUnit() *SMUnit {
 	return &SMUnit{
 		meta: &smUnitMetaData{
+			name:           "SMUnit",
+			freq:           1 * sim.GHz,
 			smUnitStrategy: "default",
 
-			l0CacheSize: 0,
+			maxWarps: 16,
+
+			memQueueSize:      64,
+			numMemReqPerCycle: 4,
 
-			registerFileSize: 0,
-			laneSize:         0,
+			latencies:      defaultLatencies(),
+			issueIntervals: defaultIssueIntervals(),
 
 			alus: nil,
 		},
-		dispatcher:   nil,
-		registerFile: nil,
-		aluGroup:     nil,
+		dispatcher: nil,
 	}
 }
 
+func (s *SMUnit) WithName(name string) *SMUnit {
+	s.meta.name = name
+	return s
+}
+
+func (s *SMUnit) WithEngine(engine sim.Engine) *SMUnit {
+	s.meta.engine = engine
+	return s
+}
+
+func (s *SMUnit) WithFreq(freq sim.Freq) *SMUnit {
+	s.meta.freq = freq
+	return s
+}
+
 func (s *SMUnit) WithSMUnitStrategy(strategy string) *SMUnit {
 	s.meta.smUnitStrategy = strategy
 	return s
 }
 
-func (s *SMUnit) WithL0CacheSize(size int32) *SMUnit {
-	s.meta.l0CacheSize = size
+// WithMaxWarps sets the number of warps that can reside in the SM unit at the
+// same time.
+func (s *SMUnit) WithMaxWarps(num int32) *SMUnit {
+	s.meta.maxWarps = num
 	return s
 }
 
-func (s *SMUnit) WithRegisterFileSize(size int32) *SMUnit {
-	s.meta.registerFileSize = size
+// WithNumMemReqPerCycle sets the number of sector transactions that the SM
+// unit can send to the L1 cache in each cycle.
+func (s *SMUnit) WithNumMemReqPerCycle(num int) *SMUnit {
+	s.meta.numMemReqPerCycle = num
 	return s
 }
 
-func (s *SMUnit) WithLaneSize(size int32) *SMUnit {
-	s.meta.laneSize = size
+// WithLatency sets the number of cycles that the instructions executed by the
+// given functional unit take to produce their results.
+func (s *SMUnit) WithLatency(unit nvidia.FunctionalUnit, cycles int) *SMUnit {
+	s.meta.latencies[unit] = cycles
+	return s
+}
+
+// WithIssueInterval sets the number of cycles between two instructions that
+// are issued to the given functional unit.
+func (s *SMUnit) WithIssueInterval(
+	unit nvidia.FunctionalUnit,
+	cycles int,
+) *SMUnit {
+	s.meta.issueIntervals[unit] = cycles
 	return s
 }
 
@@ -72,22 +155,74 @@ func (s *SMUnit) WithALU(aluType string, num int32) *SMUnit {
 	return s
 }
 
+// WithWarpDoneHandler sets the function to call when a warp completes.
+func (s *SMUnit) WithWarpDoneHandler(h WarpDoneHandler) *SMUnit {
+	s.warpDoneHandler = h
+	return s
+}
+
+// WithBarrierHandler sets the function to call when a warp arrives at a
+// barrier.
+func (s *SMUnit) WithBarrierHandler(h BarrierHandler) *SMUnit {
+	s.barrierHandler = h
+	return s
+}
+
 func (s *SMUnit) Build() {
+	s.TickingComponent = sim.NewTickingComponent(
+		s.meta.name, s.meta.engine, s.meta.freq, s)
+	s.toMem = sim.NewLimitNumMsgPort(s, 64, s.meta.name+".ToMem")
+	s.AddPort("ToMem", s.toMem)
+	s.pendingMem = make(map[string]*memAccess)
+	s.unitBusyUntil = make(map[nvidia.FunctionalUnit]sim.VTimeInSec)
+
 	s.buildDispatcher()
-	s.buildRegisterFile(s.meta.registerFileSize, s.meta.laneSize)
-	s.aluGroup = make([]*alu.ALUGroup, len(s.meta.alus))
-	for i, a := range s.meta.alus {
-		s.aluGroup[i] = alu.NewALUGroup().
-			WithALUType(a.aluType).
-			WithALUNum(a.aluNum)
-		s.aluGroup[i].Build()
+	for _, a := range s.meta.alus {
+		s.applyALUWidth(a.aluType, a.aluNum)
 	}
 }
 
+// SetLowModule sets the port that the memory requests are sent to.
+func (s *SMUnit) SetLowModule(port sim.Port) {
+	s.lowModule = port
+}
+
+// ToMem returns the port that sends the memory requests.
+func (s *SMUnit) ToMem() sim.Port {
+	return s.toMem
+}
+
+// MemoryStats returns the memory transactions that the SM unit has generated.
+func (s *SMUnit) MemoryStats() MemoryStats {
+	return s.stats
+}
+
+// NumFreeWarpSlots returns the number of warps that the SM unit can accept.
+func (s *SMUnit) NumFreeWarpSlots() int {
+	return int(s.meta.maxWarps) - len(s.warps)
+}
+
 func (s *SMUnit) IsFree() bool {
-	return true
+	return s.NumFreeWarpSlots() > 0
 }
 
-func (s *SMUnit) Execute(warp *nvidia.Warp) {
-	s.dispatcher.dispatch(warp)
+// Execute starts running a warp of the given thread block.
+func (s *SMUnit) Execute(
+	now sim.VTimeInSec,
+	tb *nvidia.ThreadBlock,
+	warp *nvidia.Warp,
+) {
+	s.warps = append(s.warps, newWarpContext(tb, warp))
+	s.TickLater(now)
+}
+
+// ReleaseBarrier lets the warps of the thread block that wait at a barrier
+// continue.
+func (s *SMUnit) ReleaseBarrier(now sim.VTimeInSec, tb *nvidia.ThreadBlock) {
+	for _, w := range s.warps {
+		if w.tb == tb {
+			w.atBarrier = false
+		}
+	}
+	s.TickLater(now)
 }
@@ -0,0 +1,80 @@
+package smunit
+
+import (
+	"sort"
+
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+)
+
+const (
+	log2SectorSize = 5
+	sectorSize     = 1 << log2SectorSize
+	numSharedBanks = 32
+)
+
+// A sectorAccess is a memory transaction that covers one 32-byte sector.
+type sectorAccess struct {
+	address   uint64
+	dirtyMask []bool
+}
+
+// coalesce merges the accesses of the active threads into sector-sized
+// transactions. The transactions are sorted by address.
+func coalesce(inst *nvidia.Instruction) []*sectorAccess {
+	width := uint64(inst.MemWidth)
+	sectors := make(map[uint64]*sectorAccess)
+
+	for _, addr := range inst.MemAddresses {
+		for b := addr; b < addr+width; b++ {
+			base := b >> log2SectorSize << log2SectorSize
+			s, ok := sectors[base]
+			if !ok {
+				s = &sectorAccess{
+					address:   base,
+					dirtyMask: make([]bool, sectorSize),
+				}
+				sectors[base] = s
+			}
+			s.dirtyMask[b-base] = true
+		}
+	}
+
+	accesses := make([]*sectorAccess, 0, len(sectors))
+	for _, s := range sectors {
+		accesses = append(accesses, s)
+	}
+	sort.Slice(accesses, func(i, j int) bool {
+		return accesses[i].address < accesses[j].address
+	})
+
+	return accesses
+}
+
+// bankConflictDegree returns the number of cycles that the shared memory
+// needs to serve the instruction. Threads that access the same 4-byte word
+// do not conflict, while threads that access different words in the same bank
+// are served one after another.
+func bankConflictDegree(inst *nvidia.Instruction) int {
+	numWords := (uint64(inst.MemWidth) + 3) / 4
+	words := make([]map[uint64]bool, numSharedBanks)
+
+	for _, addr := range inst.MemAddresses {
+		for w := uint64(0); w < numWords; w++ {
+			word := addr>>2 + w
+			bank := word % numSharedBanks
+			if words[bank] == nil {
+				words[bank] = make(map[uint64]bool)
+			}
+			words[bank][word] = true
+		}
+	}
+
+	degree := 1
+	for _, w := range words {
+		if len(w) > degree {
+			degree = len(w)
+		}
+	}
+
+	return degree
+}
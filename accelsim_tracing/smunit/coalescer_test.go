@@ -0,0 +1,115 @@
+package smunit
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+)
+
+func makeMemInst(
+	opcode string,
+	width int32,
+	addrs ...uint64,
+) *nvidia.Instruction {
+	inst := makeInst(opcode, nil, nil)
+	inst.MemWidth = width
+	inst.MemAddresses = addrs
+	return inst
+}
+
+func consecutiveAddrs(base uint64, stride uint64, n int) []uint64 {
+	addrs := make([]uint64, n)
+	for i := range addrs {
+		addrs[i] = base + uint64(i)*stride
+	}
+	return addrs
+}
+
+var _ = Describe("Coalescer", func() {
+	It("should merge consecutive 4-byte accesses into 4 sectors", func() {
+		inst := makeMemInst("LDG", 4, consecutiveAddrs(0x1000, 4, 32)...)
+
+		sectors := coalesce(inst)
+
+		Expect(sectors).To(HaveLen(4))
+		for i, s := range sectors {
+			Expect(s.address).To(Equal(uint64(0x1000 + 32*i)))
+			Expect(s.dirtyMask).To(HaveEach(BeTrue()))
+		}
+	})
+
+	It("should merge the threads that access the same address", func() {
+		addrs := consecutiveAddrs(0x1000, 0, 32)
+		inst := makeMemInst("LDG", 4, addrs...)
+
+		sectors := coalesce(inst)
+
+		Expect(sectors).To(HaveLen(1))
+		Expect(sectors[0].address).To(Equal(uint64(0x1000)))
+		Expect(sectors[0].dirtyMask[:4]).To(HaveEach(BeTrue()))
+		Expect(sectors[0].dirtyMask[4:]).To(HaveEach(BeFalse()))
+	})
+
+	It("should use one sector per thread for scattered accesses", func() {
+		inst := makeMemInst("LDG", 4, consecutiveAddrs(0x1000, 128, 32)...)
+
+		Expect(coalesce(inst)).To(HaveLen(32))
+	})
+
+	It("should split an access that crosses a sector boundary", func() {
+		inst := makeMemInst("STG", 8, 0x101c)
+
+		sectors := coalesce(inst)
+
+		Expect(sectors).To(HaveLen(2))
+		Expect(sectors[0].address).To(Equal(uint64(0x1000)))
+		Expect(sectors[0].dirtyMask[28:]).To(HaveEach(BeTrue()))
+		Expect(sectors[0].dirtyMask[:28]).To(HaveEach(BeFalse()))
+		Expect(sectors[1].address).To(Equal(uint64(0x1020)))
+		Expect(sectors[1].dirtyMask[:4]).To(HaveEach(BeTrue()))
+		Expect(sectors[1].dirtyMask[4:]).To(HaveEach(BeFalse()))
+	})
+
+	It("should sort the sectors by address", func() {
+		inst := makeMemInst("LDG", 4, 0x3000, 0x1000, 0x2000)
+
+		sectors := coalesce(inst)
+
+		Expect(sectors).To(HaveLen(3))
+		Expect(sectors[0].address).To(Equal(uint64(0x1000)))
+		Expect(sectors[1].address).To(Equal(uint64(0x2000)))
+		Expect(sectors[2].address).To(Equal(uint64(0x3000)))
+	})
+})
+
+var _ = Describe("Bank conflicts", func() {
+	It("should not conflict when each thread uses its own bank", func() {
+		inst := makeMemInst("LDS", 4, consecutiveAddrs(0, 4, 32)...)
+
+		Expect(bankConflictDegree(inst)).To(Equal(1))
+	})
+
+	It("should not conflict when the threads read the same word", func() {
+		inst := makeMemInst("LDS", 4, consecutiveAddrs(0x40, 0, 32)...)
+
+		Expect(bankConflictDegree(inst)).To(Equal(1))
+	})
+
+	It("should serialize a stride of two words", func() {
+		inst := makeMemInst("LDS", 4, consecutiveAddrs(0, 8, 32)...)
+
+		Expect(bankConflictDegree(inst)).To(Equal(2))
+	})
+
+	It("should serialize all the threads that hit one bank", func() {
+		inst := makeMemInst("LDS", 4, consecutiveAddrs(0, 128, 32)...)
+
+		Expect(bankConflictDegree(inst)).To(Equal(32))
+	})
+
+	It("should count every word of wide accesses", func() {
+		inst := makeMemInst("LDS", 8, consecutiveAddrs(0, 8, 32)...)
+
+		Expect(bankConflictDegree(inst)).To(Equal(2))
+	})
+})
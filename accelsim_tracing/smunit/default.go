@@ -1,9 +1,12 @@
 package smunit
 
-import "github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+import "github.com/sarchlab/akita/v3/sim"
 
+// defaultDispatcher is a greedy-then-oldest scheduler. It keeps issuing from
+// the same warp until the warp stalls and then picks the oldest ready warp.
 type defaultDispatcher struct {
 	parent *SMUnit
+	last   *warpContext
 }
 
 func newDefaultDispatcher() *defaultDispatcher {
@@ -15,5 +18,27 @@ func (d *defaultDispatcher) withParent(sm *SMUnit) smUnitDispatcher {
 	return d
 }
 
-func (d *defaultDispatcher) dispatch(warp *nvidia.Warp) {
+func (d *defaultDispatcher) dispatch(now sim.VTimeInSec) bool {
+	if d.last != nil && d.isResident(d.last) &&
+		d.parent.canIssue(now, d.last) {
+		return d.parent.issue(now, d.last)
+	}
+
+	for _, w := range d.parent.warps {
+		if d.parent.canIssue(now, w) {
+			d.last = w
+			return d.parent.issue(now, w)
+		}
+	}
+
+	return false
+}
+
+func (d *defaultDispatcher) isResident(w *warpContext) bool {
+	for _, r := range d.parent.warps {
+		if r == w {
+			return true
+		}
+	}
+	return false
 }
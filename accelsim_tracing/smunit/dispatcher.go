@@ -1,16 +1,20 @@
 package smunit
 
-import "github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+import "github.com/sarchlab/akita/v3/sim"
 
+// A smUnitDispatcher is the warp scheduler of an SM unit. It decides which
+// warp issues an instruction in each cycle.
 type smUnitDispatcher interface {
 	withParent(sm *SMUnit) smUnitDispatcher
-	dispatch(tb *nvidia.Warp)
+	dispatch(now sim.VTimeInSec) bool
 }
 
 func (s *SMUnit) buildDispatcher() {
 	switch s.meta.smUnitStrategy {
-	case "default":
+	case "default", "gto":
 		s.dispatcher = newDefaultDispatcher().withParent(s)
+	case "lrr":
+		s.dispatcher = newLRRDispatcher().withParent(s)
 	default:
 		panic("Unknown dispatch strategy")
 	}
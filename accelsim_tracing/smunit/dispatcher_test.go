@@ -0,0 +1,151 @@
+package smunit
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+)
+
+func independentWarp() *nvidia.Warp {
+	return makeWarp(
+		makeInst("FADD", []string{"R1"}, []string{"R10"}),
+		makeInst("FADD", []string{"R2"}, []string{"R10"}),
+		makeInst("FADD", []string{"R3"}, []string{"R10"}),
+	)
+}
+
+func dependentWarp() *nvidia.Warp {
+	return makeWarp(
+		makeInst("FADD", []string{"R1"}, []string{"R10"}),
+		makeInst("FADD", []string{"R2"}, []string{"R1"}),
+	)
+}
+
+var _ = Describe("Dispatcher", func() {
+	var (
+		engine sim.Engine
+		s      *SMUnit
+		warps  []*warpContext
+	)
+
+	build := func(strategy string) {
+		engine = sim.NewSerialEngine()
+		s = NewSMUnit().
+			WithEngine(engine).
+			WithSMUnitStrategy(strategy).
+			WithIssueInterval(nvidia.UnitSP, 1)
+		s.Build()
+	}
+
+	addWarps := func(ws ...*nvidia.Warp) {
+		warps = nil
+		for _, w := range ws {
+			wc := newWarpContext(&nvidia.ThreadBlock{}, w)
+			warps = append(warps, wc)
+			s.warps = append(s.warps, wc)
+		}
+	}
+
+	// issueOrder runs the dispatcher for n cycles and returns the index of
+	// the warp that issues in each cycle, or -1 if no warp issues.
+	issueOrder := func(n int) []int {
+		order := make([]int, 0, n)
+		for cycle := 1; cycle <= n; cycle++ {
+			now := sim.VTimeInSec(cycle) * s.Freq.Period()
+			s.writeBack(now)
+
+			pcs := make([]int, len(warps))
+			for i, w := range warps {
+				pcs[i] = w.pc
+			}
+
+			s.dispatcher.dispatch(now)
+
+			issued := -1
+			for i, w := range warps {
+				if w.pc != pcs[i] {
+					issued = i
+				}
+			}
+			order = append(order, issued)
+		}
+		return order
+	}
+
+	Context("greedy-then-oldest", func() {
+		BeforeEach(func() {
+			build("gto")
+		})
+
+		It("should keep issuing from the same warp", func() {
+			addWarps(independentWarp(), independentWarp())
+
+			Expect(issueOrder(6)).To(Equal([]int{0, 0, 0, 1, 1, 1}))
+		})
+
+		It("should switch to the oldest ready warp on a stall", func() {
+			addWarps(dependentWarp(), independentWarp(), independentWarp())
+
+			Expect(issueOrder(5)).To(Equal([]int{0, 1, 1, 1, 0}))
+		})
+	})
+
+	Context("loose round-robin", func() {
+		BeforeEach(func() {
+			build("lrr")
+		})
+
+		It("should rotate among the ready warps", func() {
+			addWarps(independentWarp(), independentWarp())
+
+			Expect(issueOrder(6)).To(Equal([]int{0, 1, 0, 1, 0, 1}))
+		})
+
+		It("should skip the stalled warps", func() {
+			addWarps(dependentWarp(), independentWarp())
+
+			Expect(issueOrder(5)).To(Equal([]int{0, 1, 1, 1, 0}))
+		})
+	})
+
+	Context("when the SM unit waits for a functional unit", func() {
+		var doneTimes []sim.VTimeInSec
+
+		BeforeEach(func() {
+			build("gto")
+			s.WithLatency(nvidia.UnitSP, 1).
+				WithIssueInterval(nvidia.UnitSP, 10).
+				WithWarpDoneHandler(
+					func(now sim.VTimeInSec, _ *nvidia.ThreadBlock) {
+						doneTimes = append(doneTimes, now)
+					})
+			doneTimes = nil
+		})
+
+		It("should wake up when the unit becomes free", func() {
+			tb := &nvidia.ThreadBlock{}
+			for i := 0; i < 2; i++ {
+				s.Execute(0, tb, makeWarp(
+					makeInst("FADD", []string{"R1"}, []string{"R2"})))
+			}
+
+			Expect(engine.Run()).To(Succeed())
+
+			Expect(doneTimes).To(HaveLen(2))
+			Expect(doneTimes[0]).To(BeNumerically("~", 2e-9, 1e-12))
+			Expect(doneTimes[1]).To(BeNumerically("~", 12e-9, 1e-12))
+		})
+
+		It("should wake up when a dependency completes", func() {
+			s.WithLatency(nvidia.UnitSP, 4).
+				WithIssueInterval(nvidia.UnitSP, 1)
+			s.Execute(0, &nvidia.ThreadBlock{}, dependentWarp())
+
+			Expect(engine.Run()).To(Succeed())
+
+			Expect(doneTimes).To(HaveLen(1))
+			Expect(doneTimes[0]).To(BeNumerically("~", 9e-9, 1e-12))
+		})
+	})
+})
@@ -0,0 +1,75 @@
+package smunit
+
+import "github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+
+// defaultLatencies are the cycles that the instructions of each functional
+// unit class take to produce their results, roughly following the numbers
+// measured on Volta and Ampere GPUs.
+func defaultLatencies() map[nvidia.FunctionalUnit]int {
+	return map[nvidia.FunctionalUnit]int{
+		nvidia.UnitINT:     4,
+		nvidia.UnitSP:      4,
+		nvidia.UnitDP:      8,
+		nvidia.UnitHalf:    4,
+		nvidia.UnitSFU:     21,
+		nvidia.UnitTensor:  32,
+		nvidia.UnitLDST:    24,
+		nvidia.UnitTexture: 100,
+		nvidia.UnitBranch:  4,
+		nvidia.UnitBarrier: 1,
+		nvidia.UnitMisc:    4,
+	}
+}
+
+// defaultIssueIntervals are the cycles between two warp instructions that are
+// issued to the same functional unit. A unit with 16 lanes needs 2 cycles to
+// accept the 32 threads of a warp.
+func defaultIssueIntervals() map[nvidia.FunctionalUnit]int {
+	return map[nvidia.FunctionalUnit]int{
+		nvidia.UnitINT:     2,
+		nvidia.UnitSP:      2,
+		nvidia.UnitDP:      4,
+		nvidia.UnitHalf:    2,
+		nvidia.UnitSFU:     8,
+		nvidia.UnitTensor:  4,
+		nvidia.UnitLDST:    4,
+		nvidia.UnitTexture: 4,
+		nvidia.UnitBranch:  1,
+		nvidia.UnitBarrier: 1,
+		nvidia.UnitMisc:    1,
+	}
+}
+
+var aluTypeToUnit = map[string]nvidia.FunctionalUnit{
+	"int32": nvidia.UnitINT,
+	"fp32":  nvidia.UnitSP,
+	"fp64":  nvidia.UnitDP,
+	"fp16":  nvidia.UnitHalf,
+	"sfu":   nvidia.UnitSFU,
+}
+
+// applyALUWidth derives the issue interval of a functional unit from the
+// number of ALUs that the SM unit has for it.
+func (s *SMUnit) applyALUWidth(aluType string, aluNum int32) {
+	unit, ok := aluTypeToUnit[aluType]
+	if !ok || aluNum <= 0 {
+		return
+	}
+
+	interval := (32 + int(aluNum) - 1) / int(aluNum)
+	s.meta.issueIntervals[unit] = interval
+}
+
+func (s *SMUnit) latency(unit nvidia.FunctionalUnit) int {
+	if l, ok := s.meta.latencies[unit]; ok {
+		return l
+	}
+	return 1
+}
+
+func (s *SMUnit) issueInterval(unit nvidia.FunctionalUnit) int {
+	if i, ok := s.meta.issueIntervals[unit]; ok {
+		return i
+	}
+	return 1
+}
@@ -0,0 +1,33 @@
+package smunit
+
+import "github.com/sarchlab/akita/v3/sim"
+
+// lrrDispatcher is a loose round-robin scheduler. It starts searching for a
+// ready warp from the warp after the one that issued last.
+type lrrDispatcher struct {
+	parent *SMUnit
+	next   int
+}
+
+func newLRRDispatcher() *lrrDispatcher {
+	return &lrrDispatcher{}
+}
+
+func (d *lrrDispatcher) withParent(sm *SMUnit) smUnitDispatcher {
+	d.parent = sm
+	return d
+}
+
+func (d *lrrDispatcher) dispatch(now sim.VTimeInSec) bool {
+	warps := d.parent.warps
+	for i := 0; i < len(warps); i++ {
+		index := (d.next + i) % len(warps)
+		w := warps[index]
+		if d.parent.canIssue(now, w) {
+			d.next = index + 1
+			return d.parent.issue(now, w)
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,348 @@
+package smunit
+
+import (
+	"log"
+	"reflect"
+
+	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+)
+
+// Tick writes back the completed instructions and issues at most one new
+// instruction.
+func (s *SMUnit) Tick(now sim.VTimeInSec) bool {
+	madeProgress := false
+
+	madeProgress = s.writeBack(now) || madeProgress
+	madeProgress = s.parseFromMem(now) || madeProgress
+	madeProgress = s.sendToMem(now) || madeProgress
+	madeProgress = s.retireWarps(now) || madeProgress
+	madeProgress = s.dispatcher.dispatch(now) || madeProgress
+
+	if !madeProgress {
+		s.scheduleWakeup(now)
+	}
+
+	return madeProgress
+}
+
+// wakeupEvent ticks the SM unit again when a functional unit finishes.
+type wakeupEvent struct {
+	*sim.EventBase
+}
+
+// Handle processes the wakeup events. The tick events are handled by the
+// embedded TickingComponent.
+func (s *SMUnit) Handle(e sim.Event) error {
+	if _, ok := e.(*wakeupEvent); ok {
+		s.TickLater(e.Time())
+		return nil
+	}
+
+	return s.TickingComponent.Handle(e)
+}
+
+// scheduleWakeup makes the SM unit tick again when the earliest in-flight
+// instruction completes or the earliest busy functional unit becomes free.
+// Memory responses, free ports, new warps, and released barriers wake the SM
+// unit up by themselves.
+func (s *SMUnit) scheduleWakeup(now sim.VTimeInSec) {
+	next := sim.VTimeInSec(-1)
+	for _, i := range s.inflight {
+		if i.doneTime > now && (next < 0 || i.doneTime < next) {
+			next = i.doneTime
+		}
+	}
+	for _, t := range s.unitBusyUntil {
+		if t > now && (next < 0 || t < next) {
+			next = t
+		}
+	}
+
+	if next < 0 {
+		return
+	}
+
+	// The event fires on the cycle boundary before the wakeup, where its
+	// TickLater lands on the same tick as the TickLater calls of the other
+	// events of that cycle.
+	wakeup := s.Freq.ThisTick(next - s.Freq.Period())
+	if wakeup <= now {
+		s.TickLater(now)
+		return
+	}
+
+	if s.wakeupTime > now && s.wakeupTime <= wakeup {
+		return
+	}
+
+	s.wakeupTime = wakeup
+	s.Engine.Schedule(&wakeupEvent{EventBase: sim.NewEventBase(wakeup, s)})
+}
+
+func (s *SMUnit) writeBack(now sim.VTimeInSec) bool {
+	madeProgress := false
+
+	remaining := s.inflight[:0]
+	for _, i := range s.inflight {
+		if i.doneTime > now {
+			remaining = append(remaining, i)
+			continue
+		}
+
+		i.warp.releaseDestRegs(i.inst)
+		madeProgress = true
+	}
+	s.inflight = remaining
+
+	return madeProgress
+}
+
+func (s *SMUnit) parseFromMem(now sim.VTimeInSec) bool {
+	item := s.toMem.Peek()
+	if item == nil {
+		return false
+	}
+
+	var rspTo string
+	switch rsp := item.(type) {
+	case *mem.DataReadyRsp:
+		rspTo = rsp.RespondTo
+	case *mem.WriteDoneRsp:
+		rspTo = rsp.RespondTo
+	default:
+		log.Panicf("cannot handle message of type %s", reflect.TypeOf(item))
+	}
+
+	access, ok := s.pendingMem[rspTo]
+	if !ok {
+		log.Panicf("cannot find memory access %s", rspTo)
+	}
+	delete(s.pendingMem, rspTo)
+	s.toMem.Retrieve(now)
+
+	access.numPendingReqs--
+	if access.numPendingReqs == 0 {
+		access.warp.outstandingMem--
+		access.warp.releaseDestRegs(access.inst)
+	}
+
+	return true
+}
+
+func (s *SMUnit) retireWarps(now sim.VTimeInSec) bool {
+	var done []*warpContext
+	remaining := make([]*warpContext, 0, len(s.warps))
+	for _, w := range s.warps {
+		if w.isDone() {
+			done = append(done, w)
+		} else {
+			remaining = append(remaining, w)
+		}
+	}
+	s.warps = remaining
+
+	// The handlers may dispatch new warps to the SM unit, so they are called
+	// after the warp list is updated.
+	for _, w := range done {
+		if s.warpDoneHandler != nil {
+			s.warpDoneHandler(now, w.tb)
+		}
+	}
+
+	return len(done) > 0
+}
+
+// canIssue checks if the next instruction of the warp can be issued in the
+// current cycle.
+func (s *SMUnit) canIssue(now sim.VTimeInSec, w *warpContext) bool {
+	if w.atBarrier {
+		return false
+	}
+
+	inst := w.nextInst()
+	if inst == nil {
+		return false
+	}
+
+	if w.hasHazard(inst) {
+		return false
+	}
+
+	unit := inst.OpCode.FunctionalUnit()
+
+	return s.unitBusyUntil[unit] <= now
+}
+
+// issue sends the next instruction of the warp to its functional unit. It
+// returns false if the instruction cannot be issued.
+func (s *SMUnit) issue(now sim.VTimeInSec, w *warpContext) bool {
+	inst := w.nextInst()
+	op := inst.OpCode
+	unit := op.FunctionalUnit()
+	busyCycles := s.issueInterval(unit)
+
+	switch s.memoryPath(inst) {
+	case memoryPathCache:
+		if !s.issueToMem(w, inst) {
+			return false
+		}
+	case memoryPathShared:
+		degree := bankConflictDegree(inst)
+		s.stats.SharedAccesses++
+		s.stats.SharedBankConflicts += uint64(degree - 1)
+		s.execute(now, w, inst, s.latency(unit)+degree-1)
+		if degree > busyCycles {
+			busyCycles = degree
+		}
+	default:
+		s.execute(now, w, inst, s.latency(unit))
+	}
+
+	if op.OpcodeType() == nvidia.BAR && s.barrierHandler != nil {
+		w.atBarrier = true
+		defer s.barrierHandler(now, w.tb)
+	}
+
+	if op.OpcodeType() == nvidia.EXIT {
+		w.pc = len(w.warp.Insts)
+	} else {
+		w.pc++
+	}
+
+	s.unitBusyUntil[unit] = s.Freq.NCyclesLater(busyCycles, now)
+
+	return true
+}
+
+// execute sends the instruction to a functional unit that produces the result
+// after the given number of cycles.
+func (s *SMUnit) execute(
+	now sim.VTimeInSec,
+	w *warpContext,
+	inst *nvidia.Instruction,
+	latency int,
+) {
+	w.reserveDestRegs(inst)
+	s.inflight = append(s.inflight, &inflightInst{
+		warp:     w,
+		inst:     inst,
+		doneTime: s.Freq.NCyclesLater(latency, now),
+	})
+}
+
+type memoryPath int
+
+const (
+	memoryPathNone memoryPath = iota
+	memoryPathShared
+	memoryPathCache
+)
+
+// memoryPath decides how a memory instruction is modeled. Global and local
+// accesses go through the L1 cache, shared accesses are modeled with bank
+// conflicts, and constant accesses use a fixed latency.
+func (s *SMUnit) memoryPath(inst *nvidia.Instruction) memoryPath {
+	if inst.MemWidth == 0 || len(inst.MemAddresses) == 0 ||
+		!inst.OpCode.IsMemoryInst() {
+		return memoryPathNone
+	}
+
+	switch inst.MemorySpace {
+	case nvidia.MemoryGlobal, nvidia.MemoryGeneric, nvidia.MemoryLocal:
+		return memoryPathCache
+	case nvidia.MemoryShared:
+		return memoryPathShared
+	default:
+		return memoryPathNone
+	}
+}
+
+// issueToMem breaks the instruction into sector transactions and queues them
+// to be sent to the L1 cache.
+func (s *SMUnit) issueToMem(w *warpContext, inst *nvidia.Instruction) bool {
+	if len(s.memQueue) >= s.meta.memQueueSize {
+		return false
+	}
+
+	sectors := coalesce(inst)
+	access := &memAccess{
+		warp:           w,
+		inst:           inst,
+		numPendingReqs: len(sectors),
+	}
+	for _, sector := range sectors {
+		s.memQueue = append(s.memQueue, &queuedReq{
+			access: access,
+			sector: sector,
+		})
+	}
+	s.countSectors(inst, len(sectors))
+
+	w.reserveDestRegs(inst)
+	w.outstandingMem++
+
+	return true
+}
+
+func (s *SMUnit) countSectors(inst *nvidia.Instruction, n int) {
+	isStore := inst.OpCode.MemoryAccess() == nvidia.AccessStore
+
+	switch {
+	case inst.OpCode.MemoryAccess() == nvidia.AccessAtomic ||
+		inst.OpCode.MemoryAccess() == nvidia.AccessReduction:
+		s.stats.AtomicSectors += uint64(n)
+	case inst.MemorySpace == nvidia.MemoryLocal && isStore:
+		s.stats.LocalStoreSectors += uint64(n)
+	case inst.MemorySpace == nvidia.MemoryLocal:
+		s.stats.LocalLoadSectors += uint64(n)
+	case isStore:
+		s.stats.GlobalStoreSectors += uint64(n)
+	default:
+		s.stats.GlobalLoadSectors += uint64(n)
+	}
+}
+
+// sendToMem sends the queued sector transactions to the L1 cache.
+func (s *SMUnit) sendToMem(now sim.VTimeInSec) bool {
+	madeProgress := false
+
+	for i := 0; i < s.meta.numMemReqPerCycle && len(s.memQueue) > 0; i++ {
+		queued := s.memQueue[0]
+		req := s.createMemReq(now, queued)
+		if err := s.toMem.Send(req); err != nil {
+			return madeProgress
+		}
+
+		s.pendingMem[req.Meta().ID] = queued.access
+		s.memQueue = s.memQueue[1:]
+		madeProgress = true
+	}
+
+	return madeProgress
+}
+
+func (s *SMUnit) createMemReq(now sim.VTimeInSec, queued *queuedReq) sim.Msg {
+	inst := queued.access.inst
+	sector := queued.sector
+
+	if inst.OpCode.MemoryAccess() == nvidia.AccessStore {
+		return mem.WriteReqBuilder{}.
+			WithSendTime(now).
+			WithSrc(s.toMem).
+			WithDst(s.lowModule).
+			WithAddress(sector.address).
+			WithData(make([]byte, sectorSize)).
+			WithDirtyMask(sector.dirtyMask).
+			Build()
+	}
+
+	return mem.ReadReqBuilder{}.
+		WithSendTime(now).
+		WithSrc(s.toMem).
+		WithDst(s.lowModule).
+		WithAddress(sector.address).
+		WithByteSize(sectorSize).
+		Build()
+}
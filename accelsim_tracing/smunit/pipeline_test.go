@@ -0,0 +1,52 @@
+package smunit
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+)
+
+var _ = Describe("Pipeline", func() {
+	var (
+		engine   sim.Engine
+		s        *SMUnit
+		doneTime sim.VTimeInSec
+	)
+
+	BeforeEach(func() {
+		doneTime = -1
+		engine = sim.NewSerialEngine()
+		s = NewSMUnit().
+			WithEngine(engine).
+			WithLatency(nvidia.UnitSP, 4).
+			WithIssueInterval(nvidia.UnitSP, 1).
+			WithWarpDoneHandler(func(now sim.VTimeInSec, _ *nvidia.ThreadBlock) {
+				doneTime = now
+			})
+		s.Build()
+	})
+
+	It("should wake up on the cycle that the instruction completes", func() {
+		s.Execute(0, &nvidia.ThreadBlock{}, makeWarp(
+			makeInst("FADD", []string{"R1"}, []string{"R10"}),
+		))
+
+		Expect(engine.Run()).To(Succeed())
+
+		// Issued in cycle 1 and completes 4 cycles later.
+		Expect(s.Freq.Cycle(doneTime)).To(Equal(uint64(5)))
+	})
+
+	It("should wake up on a cycle boundary", func() {
+		s.inflight = append(s.inflight, &inflightInst{
+			warp:     newWarpContext(&nvidia.ThreadBlock{}, makeWarp()),
+			inst:     makeInst("FADD", []string{"R1"}, []string{"R10"}),
+			doneTime: 5 * s.Freq.Period(),
+		})
+
+		s.scheduleWakeup(2 * s.Freq.Period())
+
+		Expect(s.wakeupTime).To(Equal(s.Freq.ThisTick(4 * s.Freq.Period())))
+	})
+})
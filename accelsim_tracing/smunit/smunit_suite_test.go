@@ -0,0 +1,45 @@
+package smunit
+
+import (
+	"log"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+)
+
+func TestSMUnit(t *testing.T) {
+	log.SetOutput(GinkgoWriter)
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "SM Unit")
+}
+
+func makeInst(
+	opcode string,
+	dests []string,
+	srcs []string,
+) *nvidia.Instruction {
+	inst := &nvidia.Instruction{
+		OpCode: nvidia.NewOpcode(opcode),
+	}
+
+	for _, d := range dests {
+		inst.DestRegs = append(inst.DestRegs, nvidia.NewRegister(d))
+	}
+	inst.DestNum = int32(len(inst.DestRegs))
+
+	for _, s := range srcs {
+		inst.SrcRegs = append(inst.SrcRegs, nvidia.NewRegister(s))
+	}
+	inst.SrcNum = int32(len(inst.SrcRegs))
+
+	return inst
+}
+
+func makeWarp(insts ...*nvidia.Instruction) *nvidia.Warp {
+	return &nvidia.Warp{
+		InstNum: len(insts),
+		Insts:   insts,
+	}
+}
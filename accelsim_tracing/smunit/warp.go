@@ -0,0 +1,112 @@
+package smunit
+
+import (
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+)
+
+// uniformRegOffset separates the uniform registers from the vector registers
+// in the scoreboard.
+const uniformRegOffset = 1024
+
+type warpContext struct {
+	tb   *nvidia.ThreadBlock
+	warp *nvidia.Warp
+
+	pc        int
+	atBarrier bool
+
+	// pendingRegs is the scoreboard of the warp. It counts the in-flight
+	// instructions that write each register.
+	pendingRegs    map[int32]int
+	outstandingMem int
+}
+
+func newWarpContext(tb *nvidia.ThreadBlock, warp *nvidia.Warp) *warpContext {
+	return &warpContext{
+		tb:          tb,
+		warp:        warp,
+		pendingRegs: make(map[int32]int),
+	}
+}
+
+func (w *warpContext) nextInst() *nvidia.Instruction {
+	if w.pc >= len(w.warp.Insts) {
+		return nil
+	}
+	return w.warp.Insts[w.pc]
+}
+
+func (w *warpContext) isDone() bool {
+	return w.pc >= len(w.warp.Insts) &&
+		len(w.pendingRegs) == 0 &&
+		w.outstandingMem == 0
+}
+
+func scoreboardKey(reg *nvidia.Register) (int32, bool) {
+	if reg.IsZeroRegister() {
+		return 0, false
+	}
+
+	if reg.IsUniformRegister() {
+		return uniformRegOffset + reg.ID(), true
+	}
+
+	return reg.ID(), true
+}
+
+// hasHazard checks if the instruction reads or writes a register that an
+// in-flight instruction is going to write.
+func (w *warpContext) hasHazard(inst *nvidia.Instruction) bool {
+	for _, regs := range [][]*nvidia.Register{inst.SrcRegs, inst.DestRegs} {
+		for _, reg := range regs {
+			key, ok := scoreboardKey(reg)
+			if ok && w.pendingRegs[key] > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (w *warpContext) reserveDestRegs(inst *nvidia.Instruction) {
+	for _, reg := range inst.DestRegs {
+		if key, ok := scoreboardKey(reg); ok {
+			w.pendingRegs[key]++
+		}
+	}
+}
+
+func (w *warpContext) releaseDestRegs(inst *nvidia.Instruction) {
+	for _, reg := range inst.DestRegs {
+		key, ok := scoreboardKey(reg)
+		if !ok {
+			continue
+		}
+
+		w.pendingRegs[key]--
+		if w.pendingRegs[key] <= 0 {
+			delete(w.pendingRegs, key)
+		}
+	}
+}
+
+// inflightInst is an instruction that is being executed by a functional unit.
+type inflightInst struct {
+	warp     *warpContext
+	inst     *nvidia.Instruction
+	doneTime sim.VTimeInSec
+}
+
+// memAccess is an instruction that waits for the memory system.
+type memAccess struct {
+	warp           *warpContext
+	inst           *nvidia.Instruction
+	numPendingReqs int
+}
+
+// queuedReq is a sector transaction that waits to be sent to the L1 cache.
+type queuedReq struct {
+	access *memAccess
+	sector *sectorAccess
+}
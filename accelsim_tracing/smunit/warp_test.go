@@ -0,0 +1,73 @@
+package smunit
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+)
+
+var _ = Describe("Scoreboard", func() {
+	var (
+		w *warpContext
+	)
+
+	BeforeEach(func() {
+		w = newWarpContext(&nvidia.ThreadBlock{}, makeWarp())
+	})
+
+	It("should not report hazards when no register is pending", func() {
+		inst := makeInst("FADD", []string{"R1"}, []string{"R2", "R3"})
+
+		Expect(w.hasHazard(inst)).To(BeFalse())
+	})
+
+	It("should detect read-after-write hazards", func() {
+		w.reserveDestRegs(makeInst("FADD", []string{"R1"}, []string{"R2"}))
+
+		inst := makeInst("FADD", []string{"R4"}, []string{"R1", "R3"})
+
+		Expect(w.hasHazard(inst)).To(BeTrue())
+	})
+
+	It("should detect write-after-write hazards", func() {
+		w.reserveDestRegs(makeInst("FADD", []string{"R1"}, []string{"R2"}))
+
+		inst := makeInst("FADD", []string{"R1"}, []string{"R3"})
+
+		Expect(w.hasHazard(inst)).To(BeTrue())
+	})
+
+	It("should never track the zero register", func() {
+		w.reserveDestRegs(makeInst("FADD", []string{"RZ"}, []string{"R2"}))
+
+		inst := makeInst("FADD", []string{"R1"}, []string{"RZ"})
+
+		Expect(w.hasHazard(inst)).To(BeFalse())
+		Expect(w.pendingRegs).To(BeEmpty())
+	})
+
+	It("should track uniform registers apart from vector registers", func() {
+		w.reserveDestRegs(makeInst("UMOV", []string{"UR1"}, []string{"UR2"}))
+
+		Expect(w.hasHazard(
+			makeInst("FADD", []string{"R2"}, []string{"R1"}))).To(BeFalse())
+		Expect(w.hasHazard(
+			makeInst("FADD", []string{"R2"}, []string{"UR1"}))).To(BeTrue())
+	})
+
+	It("should clear the hazard after the last writer releases", func() {
+		writer := makeInst("FADD", []string{"R1"}, []string{"R2"})
+		reader := makeInst("FADD", []string{"R4"}, []string{"R1"})
+
+		w.reserveDestRegs(writer)
+		w.reserveDestRegs(writer)
+		w.releaseDestRegs(writer)
+
+		Expect(w.hasHazard(reader)).To(BeTrue())
+
+		w.releaseDestRegs(writer)
+
+		Expect(w.hasHazard(reader)).To(BeFalse())
+		Expect(w.pendingRegs).To(BeEmpty())
+	})
+})
@@ -36,6 +36,53 @@ func (inst *instruction) generateNVInst() *nvidia.Instruction {
 		MemAddress:        inst.MemAddress,
 		MemAddressSuffix1: inst.MemAddressSuffix1,
 		MemAddressSuffix2: inst.MemAddressSuffix2,
+		MemAddresses:      inst.MemAddresses,
+		MemorySpace:       inst.resolveMemorySpace(),
 	}
 	return nvinst
 }
+
+// windowSize is the size of the shared and the local memory windows in the
+// generic address space.
+const windowSize = 1 << 24
+
+// resolveMemorySpace finds the memory space that a generic access goes to by
+// checking the address against the shared and local memory windows that the
+// trace header records.
+func (inst *instruction) resolveMemorySpace() nvidia.MemorySpace {
+	if inst.OpCode == nil {
+		return nvidia.MemoryNone
+	}
+
+	space := inst.OpCode.MemorySpace()
+	if space != nvidia.MemoryGeneric || len(inst.MemAddresses) == 0 {
+		return space
+	}
+
+	header := inst.header()
+	if header == nil {
+		return nvidia.MemoryGlobal
+	}
+
+	addr := inst.MemAddresses[0]
+	switch {
+	case inWindow(addr, uint64(header.shmemBaseAddr)):
+		return nvidia.MemoryShared
+	case inWindow(addr, uint64(header.localMemBaseAddr)):
+		return nvidia.MemoryLocal
+	default:
+		return nvidia.MemoryGlobal
+	}
+}
+
+func inWindow(addr, base uint64) bool {
+	return base != 0 && addr >= base && addr < base+windowSize
+}
+
+func (inst *instruction) header() *traceHeader {
+	if inst.parent == nil || inst.parent.parent == nil ||
+		inst.parent.parent.parent == nil {
+		return nil
+	}
+	return inst.parent.parent.parent.traceHeader
+}
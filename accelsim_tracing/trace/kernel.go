@@ -3,6 +3,8 @@ package trace
 import (
 	"path"
 
+	"github.com/sarchlab/akita/v3/sim"
+
 	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/gpu"
 )
 
@@ -18,9 +20,29 @@ func (te *kernel) Type() string {
 	return "kernel"
 }
 
+// Execute launches the kernel on its stream. Like a CUDA kernel launch, it
+// does not wait for the kernel to complete. The kernel starts when the
+// kernels that are launched earlier on the same stream complete.
 func (te *kernel) Execute(gpu *gpu.GPU) error {
 	tg := NewTraceGroup().WithFilePath(path.Join(te.parent.traceDirPath, te.filePath))
 	tg.Build()
-	err := tg.Exec(gpu)
-	return err
+	te.traceGroup = tg
+
+	s := te.parent.stream(tg.traceHeader.cudaStreamID)
+	s.queue = append(s.queue, te)
+	if !s.busy {
+		s.startNextKernel(gpu)
+	}
+
+	return nil
+}
+
+// KernelRecord is the simulated execution time of a kernel.
+type KernelRecord struct {
+	Name      string
+	ID        int32
+	StreamID  int32
+	StartTime sim.VTimeInSec
+	EndTime   sim.VTimeInSec
+	Cycles    uint64
 }
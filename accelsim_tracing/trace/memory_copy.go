@@ -1,6 +1,9 @@
 package trace
 
-import "github.com/sarchlab/mgpusim/v3/accelsim_tracing/gpu"
+import (
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/gpu"
+)
 
 type memCopy struct { // trace execs interface
 	parent *Trace
@@ -19,6 +22,41 @@ func (te *memCopy) Type() string {
 	return "memcopy"
 }
 
+// Execute copies the data over the PCIe link. Like cudaMemcpy, the copy
+// starts after all the earlier work completes and blocks the work after it.
 func (te *memCopy) Execute(gpu *gpu.GPU) error {
+	err := gpu.Run()
+	if err != nil {
+		return err
+	}
+
+	start := gpu.Engine().CurrentTime()
+	end := start
+	gpu.Memcpy(te.h2d, te.startAddr, te.length, func(now sim.VTimeInSec) {
+		end = now
+	})
+
+	err = gpu.Run()
+	if err != nil {
+		return err
+	}
+
+	te.parent.memcpyRecords = append(te.parent.memcpyRecords, MemcpyRecord{
+		H2D:       te.h2d,
+		Address:   te.startAddr,
+		Length:    te.length,
+		StartTime: start,
+		EndTime:   end,
+	})
+
 	return nil
 }
+
+// MemcpyRecord is the simulated transfer time of a memory copy.
+type MemcpyRecord struct {
+	H2D       bool
+	Address   uint64
+	Length    uint64
+	StartTime sim.VTimeInSec
+	EndTime   sim.VTimeInSec
+}
@@ -0,0 +1,51 @@
+package trace
+
+import (
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/gpu"
+)
+
+// A stream runs its kernels one after another. Kernels on different streams
+// can run at the same time.
+type stream struct {
+	parent *Trace
+
+	id    int32
+	busy  bool
+	queue []*kernel
+}
+
+func (t *Trace) stream(id int32) *stream {
+	s, ok := t.streams[id]
+	if !ok {
+		s = &stream{parent: t, id: id}
+		t.streams[id] = s
+	}
+	return s
+}
+
+func (s *stream) startNextKernel(gpu *gpu.GPU) {
+	if len(s.queue) == 0 {
+		return
+	}
+
+	k := s.queue[0]
+	s.queue = s.queue[1:]
+	s.busy = true
+
+	tg := k.traceGroup
+	start := gpu.Engine().CurrentTime()
+	gpu.LaunchKernel(tg.generateNVThreadBlocks(), func(now sim.VTimeInSec) {
+		s.parent.kernelRecords = append(s.parent.kernelRecords, KernelRecord{
+			Name:      tg.traceHeader.kernelName,
+			ID:        tg.traceHeader.kernelID,
+			StreamID:  s.id,
+			StartTime: start,
+			EndTime:   now,
+			Cycles:    gpu.Freq().Cycle(now) - gpu.Freq().Cycle(start),
+		})
+
+		s.busy = false
+		s.startNextKernel(gpu)
+	})
+}
@@ -0,0 +1,5 @@
+MemcpyHtoD,0x00007f1000000000,4096
+kernel-1.traceg
+kernel-2.traceg
+kernel-3.traceg
+MemcpyDtoH,0x00007f1000000000,4096
@@ -10,14 +10,18 @@ import (
 )
 
 type Trace struct {
-	traceDirPath string
-	traceExecs   []traceExecs
+	traceDirPath  string
+	traceExecs    []traceExecs
+	streams       map[int32]*stream
+	kernelRecords []KernelRecord
+	memcpyRecords []MemcpyRecord
 }
 
 func NewTrace() *Trace {
 	return &Trace{
 		traceDirPath: "",
 		traceExecs:   nil,
+		streams:      make(map[int32]*stream),
 	}
 }
 
@@ -30,6 +34,8 @@ func (t *Trace) Build() {
 	t.parseKernelsList()
 }
 
+// Exec replays the kernel launches and the memory copies in the order of the
+// kernel list and simulates until all of them complete.
 func (t *Trace) Exec(gpu *gpu.GPU) error {
 	for _, tg := range t.traceExecs {
 		err := tg.Execute(gpu)
@@ -37,7 +43,18 @@ func (t *Trace) Exec(gpu *gpu.GPU) error {
 			return err
 		}
 	}
-	return nil
+	return gpu.Run()
+}
+
+// KernelRecords returns the execution time of the kernels that have run, in
+// the order that they complete.
+func (t *Trace) KernelRecords() []KernelRecord {
+	return t.kernelRecords
+}
+
+// MemcpyRecords returns the transfer time of the memory copies.
+func (t *Trace) MemcpyRecords() []MemcpyRecord {
+	return t.memcpyRecords
 }
 
 func (t *Trace) parseKernelsList() {
@@ -7,7 +7,7 @@ import (
 	"os"
 	"strings"
 
-	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/gpu"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
 )
 
 type traceGroup struct {
@@ -36,16 +36,18 @@ func (tg *traceGroup) Build() {
 	tg.parseTraceHeader()
 }
 
-func (tg *traceGroup) Exec(gpu *gpu.GPU) error {
+// generateNVThreadBlocks parses all the thread blocks of the kernel.
+func (tg *traceGroup) generateNVThreadBlocks() []*nvidia.ThreadBlock {
 	// [todo] threadblocks can be parallelized to save memory
 	tg.parseThreadBlocks()
+	tg.file.Close()
 
+	tbs := make([]*nvidia.ThreadBlock, 0, tg.threadBlockQueue.Len())
 	for it := tg.threadBlockQueue.Front(); it != nil; it = it.Next() {
-		gpu.RunThreadBlock(it.Value.(*threadBlock).generateNVThreadBlock())
+		tbs = append(tbs, it.Value.(*threadBlock).generateNVThreadBlock())
 	}
 
-	tg.file.Close()
-	return nil
+	return tbs
 }
 
 func (tg *traceGroup) buildFileScanner() {
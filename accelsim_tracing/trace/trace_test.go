@@ -0,0 +1,56 @@
+package trace
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/gpu"
+)
+
+var _ = Describe("Trace", func() {
+	var (
+		g  *gpu.GPU
+		tr *Trace
+	)
+
+	BeforeEach(func() {
+		g = gpu.NewGPU().
+			WithGPCNum(1).
+			WithSMNum(2).
+			WithSMUnitNum(1)
+		g.Build()
+
+		tr = NewTrace().WithTraceDirPath("testdata/streams")
+		tr.Build()
+	})
+
+	It("should overlap the kernels on different streams", func() {
+		Expect(tr.Exec(g)).To(Succeed())
+
+		records := make(map[int32]KernelRecord)
+		for _, r := range tr.KernelRecords() {
+			records[r.ID] = r
+		}
+		Expect(records).To(HaveLen(3))
+
+		k1, k2, k3 := records[1], records[2], records[3]
+		Expect(k1.StreamID).To(Equal(int32(0)))
+		Expect(k2.StreamID).To(Equal(int32(1)))
+		Expect(k2.StartTime).To(Equal(k1.StartTime))
+		Expect(k2.StartTime).To(BeNumerically("<", k1.EndTime))
+		Expect(k3.StartTime).To(Equal(k1.EndTime))
+	})
+
+	It("should run the memory copies after the earlier work", func() {
+		Expect(tr.Exec(g)).To(Succeed())
+
+		memcpys := tr.MemcpyRecords()
+		Expect(memcpys).To(HaveLen(2))
+		Expect(memcpys[0].H2D).To(BeTrue())
+		Expect(memcpys[1].H2D).To(BeFalse())
+
+		for _, k := range tr.KernelRecords() {
+			Expect(k.StartTime).To(BeNumerically(">=", memcpys[0].EndTime))
+			Expect(memcpys[1].StartTime).To(BeNumerically(">=", k.EndTime))
+		}
+	})
+})
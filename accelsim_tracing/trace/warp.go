@@ -3,6 +3,7 @@ package trace
 import (
 	"fmt"
 	"log"
+	"math/bits"
 	"strconv"
 	"strings"
 
@@ -37,6 +38,7 @@ type instruction struct {
 	MemAddress        int64
 	MemAddressSuffix1 int32
 	MemAddressSuffix2 []int32
+	MemAddresses      []uint64
 }
 
 func parseWarp(lines []string) *warp {
@@ -61,35 +63,80 @@ func parseWarp(lines []string) *warp {
 	return wp
 }
 
+// parseInst parses an instruction line in the format of
+// "PC mask destNum [destRegs] opcode srcNum [srcRegs] memWidth [memAddrs]".
 func parseInst(line string) instruction {
-	inst := &instruction{}
+	inst := &instruction{rawText: line}
 	elems := strings.Fields(line)
-	fmt.Sscanf(elems[0]+elems[1]+elems[2], "%x%x%d", &inst.PC, &inst.Mask, &inst.SrcNum)
-	for i := 0; i < int(inst.SrcNum); i++ {
-		inst.SrcRegs = append(inst.SrcRegs, nvidia.NewRegister(elems[3+i]))
-	}
-	fmt.Sscanf(elems[3+int(inst.SrcNum)], "%d", &inst.DestNum)
+	fmt.Sscanf(elems[0]+" "+elems[1]+" "+elems[2], "%x %x %d",
+		&inst.PC, &inst.Mask, &inst.DestNum)
 	for i := 0; i < int(inst.DestNum); i++ {
-		inst.DestRegs = append(inst.DestRegs, nvidia.NewRegister(elems[4+int(inst.SrcNum)+i]))
+		inst.DestRegs = append(inst.DestRegs, nvidia.NewRegister(elems[3+i]))
+	}
+	next := 3 + int(inst.DestNum)
+	inst.OpCode = nvidia.NewOpcode(elems[next])
+	fmt.Sscanf(elems[next+1], "%d", &inst.SrcNum)
+	for i := 0; i < int(inst.SrcNum); i++ {
+		inst.SrcRegs = append(inst.SrcRegs, nvidia.NewRegister(elems[next+2+i]))
 	}
-	inst.parseMemory(elems[4+int(inst.SrcNum)+int(inst.DestNum):])
+	inst.parseMemory(elems[next+2+int(inst.SrcNum):])
 	return *inst
 }
 
-// [todo]: understand memory format
+// parseMemory parses "memWidth [mode baseAddr suffix...]". Mode 0 lists the
+// addresses of all the active threads. Mode 1 lists a stride after the base
+// address and mode 2 lists the deltas between the addresses of the active
+// threads.
 func (inst *instruction) parseMemory(elems []string) {
 	fmt.Sscanf(elems[0], "%d", &inst.MemWidth)
 	if inst.MemWidth == 0 {
 		return
 	}
-	fmt.Sscanf(elems[1]+elems[2], "%d0x%x", &inst.AddressCompress, &inst.MemAddress)
+	fmt.Sscanf(elems[1], "%d", &inst.AddressCompress)
+	fmt.Sscanf(elems[2], "0x%x", &inst.MemAddress)
 	switch inst.AddressCompress {
+	case 0:
+		for _, s := range elems[2:] {
+			var addr uint64
+			fmt.Sscanf(s, "0x%x", &addr)
+			inst.MemAddresses = append(inst.MemAddresses, addr)
+		}
 	case 1:
-		fmt.Sscanf(elems[2], "%d", &inst.MemAddressSuffix1)
+		fmt.Sscanf(elems[3], "%d", &inst.MemAddressSuffix1)
+		inst.MemAddresses = inst.expandAddresses(func(i int) int64 {
+			return int64(inst.MemAddressSuffix1)
+		})
 	case 2:
-		for _, s := range elems[2:] {
+		deltas := make([]int64, 0, len(elems[3:]))
+		for _, s := range elems[3:] {
 			s32, _ := strconv.Atoi(s)
 			inst.MemAddressSuffix2 = append(inst.MemAddressSuffix2, int32(s32))
+			d, _ := strconv.ParseInt(s, 10, 64)
+			deltas = append(deltas, d)
+		}
+		inst.MemAddresses = inst.expandAddresses(func(i int) int64 {
+			if i-1 < len(deltas) {
+				return deltas[i-1]
+			}
+			return 0
+		})
+	default:
+		log.Panicf("Unknown address compression mode: %s", inst.rawText)
+	}
+}
+
+// expandAddresses generates the address of each active thread from the base
+// address. The i-th active thread accesses the address of the previous active
+// thread plus step(i).
+func (inst *instruction) expandAddresses(step func(i int) int64) []uint64 {
+	numActive := bits.OnesCount64(uint64(inst.Mask))
+	addrs := make([]uint64, numActive)
+	for i := range addrs {
+		if i == 0 {
+			addrs[i] = uint64(inst.MemAddress)
+			continue
 		}
+		addrs[i] = uint64(int64(addrs[i-1]) + step(i))
 	}
+	return addrs
 }
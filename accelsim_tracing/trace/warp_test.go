@@ -0,0 +1,153 @@
+package trace
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+)
+
+type parsedInst struct {
+	opType   nvidia.OpCodeType
+	varType  nvidia.VariableType
+	unit     nvidia.FunctionalUnit
+	space    nvidia.MemorySpace
+	access   nvidia.MemoryAccess
+	numDests int
+	numSrcs  int
+	addrs    []uint64
+}
+
+func strideAddrs(base uint64, stride uint64, n int) []uint64 {
+	addrs := make([]uint64, n)
+	for i := range addrs {
+		addrs[i] = base + uint64(i)*stride
+	}
+	return addrs
+}
+
+// The lines are in the format that the Accel-Sim tracer writes for the
+// Rodinia kernels compiled for Volta and Ampere, including the trailing spaces.
+var _ = DescribeTable("Parsing trace instructions",
+	func(line string, expected parsedInst) {
+		inst := parseInst(line)
+
+		Expect(inst.OpCode.OpcodeType()).To(Equal(expected.opType))
+		Expect(inst.OpCode.VariableType()).To(Equal(expected.varType))
+		Expect(inst.OpCode.FunctionalUnit()).To(Equal(expected.unit))
+		Expect(inst.OpCode.MemorySpace()).To(Equal(expected.space))
+		Expect(inst.OpCode.MemoryAccess()).To(Equal(expected.access))
+		Expect(inst.DestRegs).To(HaveLen(expected.numDests))
+		Expect(inst.SrcRegs).To(HaveLen(expected.numSrcs))
+		if expected.addrs == nil {
+			Expect(inst.MemAddresses).To(BeEmpty())
+		} else {
+			Expect(inst.MemAddresses).To(Equal(expected.addrs))
+		}
+	},
+	Entry("IMAD.MOV.U32",
+		"0000 ffffffff 1 R1 IMAD.MOV.U32 2 R255 R255 0 ",
+		parsedInst{nvidia.IMAD, nvidia.VariableINT32, nvidia.UnitINT,
+			nvidia.MemoryNone, nvidia.AccessNone, 1, 2, nil}),
+	Entry("S2R",
+		"0010 ffffffff 1 R0 S2R 0 0 ",
+		parsedInst{nvidia.S2R, nvidia.VariableINT32, nvidia.UnitMisc,
+			nvidia.MemoryNone, nvidia.AccessNone, 1, 0, nil}),
+	Entry("ULDC.64",
+		"0020 ffffffff 1 UR4 ULDC.64 0 0 ",
+		parsedInst{nvidia.ULDC, nvidia.VariableINT64, nvidia.UnitINT,
+			nvidia.MemoryNone, nvidia.AccessNone, 1, 0, nil}),
+	Entry("ISETP.GE.AND",
+		"0040 ffffffff 0 ISETP.GE.AND 2 R0 UR4 0 ",
+		parsedInst{nvidia.ISETP, nvidia.VariableINT32, nvidia.UnitINT,
+			nvidia.MemoryNone, nvidia.AccessNone, 0, 2, nil}),
+	Entry("IMAD.WIDE",
+		"0070 ffffffff 1 R2 IMAD.WIDE 2 R0 R255 0 ",
+		parsedInst{nvidia.IMAD, nvidia.VariableINT64, nvidia.UnitINT,
+			nvidia.MemoryNone, nvidia.AccessNone, 1, 2, nil}),
+	Entry("LDG.E.U8.SYS with a stride",
+		"0080 ffffffff 1 R4 LDG.E.U8.SYS 1 R2 1 1 0x7f8e4ba00000 1 ",
+		parsedInst{nvidia.LDG, nvidia.VariableINT32, nvidia.UnitLDST,
+			nvidia.MemoryGlobal, nvidia.AccessLoad, 1, 1,
+			strideAddrs(0x7f8e4ba00000, 1, 32)}),
+	Entry("LDG.E.SYS with deltas",
+		"0090 0000000f 1 R6 LDG.E.SYS 1 R4 4 2 0x7f8e4b400000 4 12 -8 ",
+		parsedInst{nvidia.LDG, nvidia.VariableDefault, nvidia.UnitLDST,
+			nvidia.MemoryGlobal, nvidia.AccessLoad, 1, 1,
+			[]uint64{0x7f8e4b400000, 0x7f8e4b400004,
+				0x7f8e4b400010, 0x7f8e4b400008}}),
+	Entry("STG.E.SYS with listed addresses",
+		"00a0 00000003 0 STG.E.SYS 2 R2 R7 4 0 "+
+			"0x7f8e4bc00000 0x7f8e4bc00100 ",
+		parsedInst{nvidia.STG, nvidia.VariableDefault, nvidia.UnitLDST,
+			nvidia.MemoryGlobal, nvidia.AccessStore, 0, 2,
+			[]uint64{0x7f8e4bc00000, 0x7f8e4bc00100}}),
+	Entry("RED.E.ADD.STRONG.GPU",
+		"00b0 00000001 0 RED.E.ADD.STRONG.GPU 2 R2 R5 4 1 0x7f8e4bd00000 0 ",
+		parsedInst{nvidia.RED, nvidia.VariableDefault, nvidia.UnitLDST,
+			nvidia.MemoryGlobal, nvidia.AccessReduction, 0, 2,
+			[]uint64{0x7f8e4bd00000}}),
+	Entry("ATOMS.ADD",
+		"00c0 00000001 1 R9 ATOMS.ADD 2 R255 R8 4 1 0x7f8e4c000040 0 ",
+		parsedInst{nvidia.ATOMS, nvidia.VariableDefault, nvidia.UnitLDST,
+			nvidia.MemoryShared, nvidia.AccessAtomic, 1, 2,
+			[]uint64{0x7f8e4c000040}}),
+	Entry("LDS.U.128",
+		"00d0 00000003 1 R12 LDS.U.128 1 R3 16 1 0x7f8e4c000000 16 ",
+		parsedInst{nvidia.LDS, nvidia.VariableDefault, nvidia.UnitLDST,
+			nvidia.MemoryShared, nvidia.AccessLoad, 1, 1,
+			[]uint64{0x7f8e4c000000, 0x7f8e4c000010}}),
+	Entry("LD.E.64 on generic addresses",
+		"00e0 00000001 1 R10 LD.E.64 1 R2 8 1 0x7f8e4b800000 0 ",
+		parsedInst{nvidia.LD, nvidia.VariableDefault, nvidia.UnitLDST,
+			nvidia.MemoryGeneric, nvidia.AccessLoad, 1, 1,
+			[]uint64{0x7f8e4b800000}}),
+	Entry("STL",
+		"00f0 00000001 0 STL 2 R1 R4 4 1 0xfffcd0 0 ",
+		parsedInst{nvidia.STL, nvidia.VariableDefault, nvidia.UnitLDST,
+			nvidia.MemoryLocal, nvidia.AccessStore, 0, 2,
+			[]uint64{0xfffcd0}}),
+	Entry("LDC",
+		"0100 ffffffff 1 R2 LDC 1 R0 0 ",
+		parsedInst{nvidia.LDC, nvidia.VariableDefault, nvidia.UnitLDST,
+			nvidia.MemoryConstant, nvidia.AccessLoad, 1, 1, nil}),
+	Entry("SHFL.BFLY",
+		"0110 ffffffff 1 R5 SHFL.BFLY 1 R4 0 ",
+		parsedInst{nvidia.SHFL, nvidia.VariableINT32, nvidia.UnitLDST,
+			nvidia.MemoryNone, nvidia.AccessNone, 1, 1, nil}),
+	Entry("FFMA",
+		"0120 ffffffff 1 R4 FFMA 3 R4 R5 R6 0 ",
+		parsedInst{nvidia.FFMA, nvidia.VariableFP32, nvidia.UnitSP,
+			nvidia.MemoryNone, nvidia.AccessNone, 1, 3, nil}),
+	Entry("DADD",
+		"0130 ffffffff 1 R6 DADD 2 R4 R8 0 ",
+		parsedInst{nvidia.DADD, nvidia.VariableFP64, nvidia.UnitDP,
+			nvidia.MemoryNone, nvidia.AccessNone, 1, 2, nil}),
+	Entry("HFMA2.MMA",
+		"0140 ffffffff 1 R3 HFMA2.MMA 2 R255 R2 0 ",
+		parsedInst{nvidia.HFMA2, nvidia.VariableFP16, nvidia.UnitHalf,
+			nvidia.MemoryNone, nvidia.AccessNone, 1, 2, nil}),
+	Entry("MUFU.RCP",
+		"0150 ffffffff 1 R8 MUFU.RCP 1 R7 0 ",
+		parsedInst{nvidia.MUFU, nvidia.VariableFP32, nvidia.UnitSFU,
+			nvidia.MemoryNone, nvidia.AccessNone, 1, 1, nil}),
+	Entry("I2F.F64",
+		"0160 ffffffff 1 R4 I2F.F64 1 R2 0 ",
+		parsedInst{nvidia.I2F, nvidia.VariableFP64, nvidia.UnitSFU,
+			nvidia.MemoryNone, nvidia.AccessNone, 1, 1, nil}),
+	Entry("BAR.SYNC",
+		"0170 ffffffff 0 BAR.SYNC 0 0 ",
+		parsedInst{nvidia.BAR, nvidia.VariableDefault, nvidia.UnitBarrier,
+			nvidia.MemoryNone, nvidia.AccessNone, 0, 0, nil}),
+	Entry("BSSY",
+		"0180 ffffffff 0 BSSY 0 0 ",
+		parsedInst{nvidia.BSSY, nvidia.VariableDefault, nvidia.UnitBranch,
+			nvidia.MemoryNone, nvidia.AccessNone, 0, 0, nil}),
+	Entry("BRA",
+		"0190 0000ffff 0 BRA 0 0 ",
+		parsedInst{nvidia.BRA, nvidia.VariableDefault, nvidia.UnitBranch,
+			nvidia.MemoryNone, nvidia.AccessNone, 0, 0, nil}),
+	Entry("EXIT",
+		"01a0 ffffffff 0 EXIT 0 0 ",
+		parsedInst{nvidia.EXIT, nvidia.VariableDefault, nvidia.UnitBranch,
+			nvidia.MemoryNone, nvidia.AccessNone, 0, 0, nil}),
+)
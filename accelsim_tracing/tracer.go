@@ -8,10 +8,12 @@ import (
 	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/benchmark"
 	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/gpu"
 	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+	"github.com/sarchlab/mgpusim/v3/samples/runner/metrics"
 )
 
 type inputArguments struct {
-	inputTraceDir string
+	inputTraceDir  string
+	metricFileName string
 	// deparse        bool
 	// outputTraceDir string
 }
@@ -24,6 +26,8 @@ func getInputArguments() *inputArguments {
 		flag.PrintDefaults()
 	}
 
+	flag.StringVar(&i.metricFileName, "metric-file-name", "metrics",
+		"Modify the name of the output csv file.")
 	flag.Parse()
 	if len(flag.Args()) < 1 {
 		flag.Usage()
@@ -45,9 +49,6 @@ func buildAmpereGPU() *gpu.GPU {
 		WithSMUnitStrategy("default").
 		WithL2CacheSize(4*1024*1024*nvidia.BYTE).
 		WithL1CacheSize(192*1024*nvidia.BYTE).
-		WithL0CacheSize(16*1024*nvidia.BYTE).
-		WithRegisterFileSize(256*1024*nvidia.BYTE).
-		WithLaneSize(4*nvidia.BYTE).
 		WithALU("int32", 16)
 	gpu.Build()
 	return gpu
@@ -57,6 +58,62 @@ func main() {
 	args := getInputArguments()
 	gpu := buildAmpereGPU()
 	benchmark := benchmark.NewBenchMark().WithTraceDirPath(args.inputTraceDir)
-	benchmark.Build()
-	benchmark.Exec(gpu)
+	err := benchmark.Build()
+	if err != nil {
+		log.Panic(err)
+	}
+
+	err = benchmark.Exec(gpu)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	c := &metrics.Collector{}
+	reportKernelTime(c, benchmark, gpu)
+	reportMemcpyTime(c, benchmark)
+	reportMemoryStats(c, gpu)
+	c.Dump(args.metricFileName)
+}
+
+func reportKernelTime(
+	c *metrics.Collector,
+	benchmark *benchmark.BenchMark,
+	gpu *gpu.GPU,
+) {
+	for _, k := range benchmark.KernelRecords() {
+		where := fmt.Sprintf("%s[%d]", k.Name, k.ID)
+		c.Collect(where, "stream_id", float64(k.StreamID))
+		c.Collect(where, "kernel_start", float64(k.StartTime))
+		c.Collect(where, "kernel_end", float64(k.EndTime))
+		c.Collect(where, "kernel_time", float64(k.EndTime-k.StartTime))
+		c.Collect(where, "kernel_cycles", float64(k.Cycles))
+	}
+	c.Collect("GPU", "total_time", float64(gpu.Engine().CurrentTime()))
+}
+
+func reportMemcpyTime(c *metrics.Collector, benchmark *benchmark.BenchMark) {
+	for i, m := range benchmark.MemcpyRecords() {
+		direction := "DtoH"
+		if m.H2D {
+			direction = "HtoD"
+		}
+
+		where := fmt.Sprintf("Memcpy%s[%d]", direction, i)
+		c.Collect(where, "memcpy_bytes", float64(m.Length))
+		c.Collect(where, "memcpy_start", float64(m.StartTime))
+		c.Collect(where, "memcpy_end", float64(m.EndTime))
+		c.Collect(where, "memcpy_time", float64(m.EndTime-m.StartTime))
+	}
+}
+
+func reportMemoryStats(c *metrics.Collector, gpu *gpu.GPU) {
+	stats := gpu.MemoryStats()
+	c.Collect("GPU", "global_load_sectors", float64(stats.GlobalLoadSectors))
+	c.Collect("GPU", "global_store_sectors", float64(stats.GlobalStoreSectors))
+	c.Collect("GPU", "local_load_sectors", float64(stats.LocalLoadSectors))
+	c.Collect("GPU", "local_store_sectors", float64(stats.LocalStoreSectors))
+	c.Collect("GPU", "atomic_sectors", float64(stats.AtomicSectors))
+	c.Collect("GPU", "shared_accesses", float64(stats.SharedAccesses))
+	c.Collect("GPU", "shared_bank_conflicts",
+		float64(stats.SharedBankConflicts))
 }
@@ -69,6 +69,11 @@ func (b *Benchmark) loadProgram() {
 	}
 }
 
+// Context returns the driver context that the benchmark runs in.
+func (b *Benchmark) Context() *driver.Context {
+	return b.context
+}
+
 // SelectGPU selects the GPUs to use.
 func (b *Benchmark) SelectGPU(gpuIDs []int) {
 	b.gpusToUse = gpuIDs
@@ -100,10 +105,10 @@ func (b *Benchmark) initMem() {
 	}
 
 	if b.useUnifiedMemory {
-		b.gInputData = b.driver.AllocateUnifiedMemory(b.context, uint64(b.Length*4))
+		b.gInputData = b.driver.MustAllocateUnifiedMemory(b.context, uint64(b.Length*4))
 	} else {
-		b.gInputData = b.driver.AllocateMemory(b.context, uint64(b.Length*4))
-		b.driver.Distribute(
+		b.gInputData = b.driver.MustAllocateMemory(b.context, uint64(b.Length*4))
+		b.driver.MustDistribute(
 			b.context,
 			b.gInputData, uint64(b.Length*4),
 			b.gpusToUse)
@@ -123,17 +123,17 @@ func (b *Benchmark) initMem() {
 	copy(b.hVerificationPathMatrix, b.hOutputPathMatrix)
 
 	if b.useUnifiedMemory {
-		b.dOutputPathMatrix = b.driver.AllocateUnifiedMemory(
+		b.dOutputPathMatrix = b.driver.MustAllocateUnifiedMemory(
 			b.context,
 			uint64(numNodes*numNodes*4))
-		b.dOutputPathDistanceMatrix = b.driver.AllocateUnifiedMemory(
+		b.dOutputPathDistanceMatrix = b.driver.MustAllocateUnifiedMemory(
 			b.context,
 			uint64(numNodes*numNodes*4))
 	} else {
-		b.dOutputPathMatrix = b.driver.AllocateMemory(
+		b.dOutputPathMatrix = b.driver.MustAllocateMemory(
 			b.context,
 			uint64(numNodes*numNodes*4))
-		b.dOutputPathDistanceMatrix = b.driver.AllocateMemory(
+		b.dOutputPathDistanceMatrix = b.driver.MustAllocateMemory(
 			b.context,
 			uint64(numNodes*numNodes*4))
 	}
@@ -112,22 +112,22 @@ func (m *GPUMatrixMultiplier) initMemory(
 	mA, mB, mC *Matrix,
 ) (driver.Ptr, driver.Ptr, driver.Ptr) {
 	if m.useUnifiedMemory {
-		gA := m.driver.AllocateUnifiedMemory(m.context, uint64(mA.Width*mA.Height*4))
-		gB := m.driver.AllocateUnifiedMemory(m.context, uint64(mB.Width*mB.Height*4))
-		gC := m.driver.AllocateUnifiedMemory(m.context, uint64(mC.Width*mC.Height*4))
+		gA := m.driver.MustAllocateUnifiedMemory(m.context, uint64(mA.Width*mA.Height*4))
+		gB := m.driver.MustAllocateUnifiedMemory(m.context, uint64(mB.Width*mB.Height*4))
+		gC := m.driver.MustAllocateUnifiedMemory(m.context, uint64(mC.Width*mC.Height*4))
 		m.driver.MemCopyH2D(m.context, gA, mA.Data)
 		m.driver.MemCopyH2D(m.context, gB, mB.Data)
 
 		return gA, gB, gC
 	}
-	gA := m.driver.AllocateMemory(m.context, uint64(mA.Width*mA.Height*4))
-	m.driver.Distribute(m.context, gA, uint64(mA.Width*mA.Height*4), m.gpus)
+	gA := m.driver.MustAllocateMemory(m.context, uint64(mA.Width*mA.Height*4))
+	m.driver.MustDistribute(m.context, gA, uint64(mA.Width*mA.Height*4), m.gpus)
 
-	gB := m.driver.AllocateMemory(m.context, uint64(mB.Width*mB.Height*4))
-	m.driver.Distribute(m.context, gB, uint64(mB.Width*mB.Height*4), m.gpus)
+	gB := m.driver.MustAllocateMemory(m.context, uint64(mB.Width*mB.Height*4))
+	m.driver.MustDistribute(m.context, gB, uint64(mB.Width*mB.Height*4), m.gpus)
 
-	gC := m.driver.AllocateMemory(m.context, uint64(mC.Width*mC.Height*4))
-	m.driver.Distribute(m.context, gC, uint64(mC.Width*mC.Height*4), m.gpus)
+	gC := m.driver.MustAllocateMemory(m.context, uint64(mC.Width*mC.Height*4))
+	m.driver.MustDistribute(m.context, gC, uint64(mC.Width*mC.Height*4), m.gpus)
 	m.driver.MemCopyH2D(m.context, gA, mA.Data)
 	m.driver.MemCopyH2D(m.context, gB, mB.Data)
 
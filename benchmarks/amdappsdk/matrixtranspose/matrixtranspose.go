@@ -102,17 +102,17 @@ func (b *Benchmark) initMem() {
 	}
 
 	if b.useUnifiedMemory {
-		b.dInputData = b.driver.AllocateUnifiedMemory(
+		b.dInputData = b.driver.MustAllocateUnifiedMemory(
 			b.context, uint64(numData*4))
-		b.dOutputData = b.driver.AllocateUnifiedMemory(
+		b.dOutputData = b.driver.MustAllocateUnifiedMemory(
 			b.context, uint64(numData*4))
 	} else {
-		b.dInputData = b.driver.AllocateMemory(
+		b.dInputData = b.driver.MustAllocateMemory(
 			b.context, uint64(numData*4))
-		b.dOutputData = b.driver.AllocateMemory(
+		b.dOutputData = b.driver.MustAllocateMemory(
 			b.context, uint64(numData*4))
-		b.driver.Distribute(b.context, b.dInputData, uint64(numData*4), b.gpus)
-		b.driver.Distribute(b.context, b.dOutputData, uint64(numData*4), b.gpus)
+		b.driver.MustDistribute(b.context, b.dInputData, uint64(numData*4), b.gpus)
+		b.driver.MustDistribute(b.context, b.dOutputData, uint64(numData*4), b.gpus)
 	}
 
 	b.driver.MemCopyH2D(b.context, b.dInputData, b.hInputData)
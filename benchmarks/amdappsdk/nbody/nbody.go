@@ -120,22 +120,22 @@ func (b *Benchmark) initMem() {
 	b.fill()
 
 	if b.useUnifiedMemory {
-		b.currPos = b.driver.AllocateUnifiedMemory(b.context,
+		b.currPos = b.driver.MustAllocateUnifiedMemory(b.context,
 			uint64(b.numBodies*4*4))
-		b.newPos = b.driver.AllocateUnifiedMemory(b.context,
+		b.newPos = b.driver.MustAllocateUnifiedMemory(b.context,
 			uint64(b.numBodies*4*4))
-		b.currVel = b.driver.AllocateUnifiedMemory(b.context,
+		b.currVel = b.driver.MustAllocateUnifiedMemory(b.context,
 			uint64(b.numBodies*4*4))
-		b.newVel = b.driver.AllocateUnifiedMemory(b.context,
+		b.newVel = b.driver.MustAllocateUnifiedMemory(b.context,
 			uint64(b.numBodies*4*4))
 	} else {
-		b.currPos = b.driver.AllocateMemory(b.context,
+		b.currPos = b.driver.MustAllocateMemory(b.context,
 			uint64(b.numBodies*4*4))
-		b.newPos = b.driver.AllocateMemory(b.context,
+		b.newPos = b.driver.MustAllocateMemory(b.context,
 			uint64(b.numBodies*4*4))
-		b.currVel = b.driver.AllocateMemory(b.context,
+		b.currVel = b.driver.MustAllocateMemory(b.context,
 			uint64(b.numBodies*4*4))
-		b.newVel = b.driver.AllocateMemory(b.context,
+		b.newVel = b.driver.MustAllocateMemory(b.context,
 			uint64(b.numBodies*4*4))
 	}
 	b.driver.MemCopyH2D(b.context, b.currPos, b.pos)
@@ -109,18 +109,18 @@ func (b *Benchmark) initMem() {
 	}
 
 	if b.useUnifiedMemory {
-		b.dInputData = b.driver.AllocateUnifiedMemory(b.context,
+		b.dInputData = b.driver.MustAllocateUnifiedMemory(b.context,
 			uint64(numInputData*4))
-		b.dOutputData = b.driver.AllocateUnifiedMemory(b.context,
+		b.dOutputData = b.driver.MustAllocateUnifiedMemory(b.context,
 			uint64(numOutputData*4))
 	} else {
-		b.dInputData = b.driver.AllocateMemory(b.context,
+		b.dInputData = b.driver.MustAllocateMemory(b.context,
 			uint64(numInputData*4))
-		b.driver.Distribute(b.context, b.dInputData,
+		b.driver.MustDistribute(b.context, b.dInputData,
 			uint64(numInputData*4), b.gpus)
-		b.dOutputData = b.driver.AllocateMemory(b.context,
+		b.dOutputData = b.driver.MustAllocateMemory(b.context,
 			uint64(numOutputData*4))
-		b.driver.Distribute(b.context, b.dOutputData,
+		b.driver.MustDistribute(b.context, b.dOutputData,
 			uint64(numOutputData*4), b.gpus)
 	}
 
@@ -128,11 +128,11 @@ func (b *Benchmark) initMem() {
 	for i, gpu := range b.gpus {
 		b.driver.SelectGPU(b.context, gpu)
 		if b.useUnifiedMemory {
-			b.dMasks[i] = b.driver.AllocateUnifiedMemory(
+			b.dMasks[i] = b.driver.MustAllocateUnifiedMemory(
 				b.context,
 				uint64(b.maskSize*b.maskSize*4))
 		} else {
-			b.dMasks[i] = b.driver.AllocateMemory(
+			b.dMasks[i] = b.driver.MustAllocateMemory(
 				b.context,
 				uint64(b.maskSize*b.maskSize*4))
 		}
@@ -194,7 +194,7 @@ func (o *GPUOperator) Create(size []int) tensor.Tensor {
 		size:   size,
 	}
 
-	t.ptr = o.driver.AllocateMemory(o.ctx, uint64(t.NumElement()*sizeOfFloat32))
+	t.ptr = o.driver.MustAllocateMemory(o.ctx, uint64(t.NumElement()*sizeOfFloat32))
 
 	return t
 }
@@ -414,19 +414,19 @@ func (o *GPUOperator) Transpose(t tensor.Tensor, order []int) tensor.Tensor {
 
 	output := o.Create(outSize).(*Tensor)
 
-	dOrder := o.driver.AllocateMemory(o.ctx, uint64(dim*sizeOfInt32))
+	dOrder := o.driver.MustAllocateMemory(o.ctx, uint64(dim*sizeOfInt32))
 	o.driver.MemCopyH2D(o.ctx, dOrder, hOrder)
 	defer o.driver.FreeMemory(o.ctx, dOrder)
-	dInSize := o.driver.AllocateMemory(o.ctx, uint64(dim*sizeOfInt32))
+	dInSize := o.driver.MustAllocateMemory(o.ctx, uint64(dim*sizeOfInt32))
 	o.driver.MemCopyH2D(o.ctx, dInSize, hInSize)
 	defer o.driver.FreeMemory(o.ctx, dInSize)
-	dOutSize := o.driver.AllocateMemory(o.ctx, uint64(dim*sizeOfInt32))
+	dOutSize := o.driver.MustAllocateMemory(o.ctx, uint64(dim*sizeOfInt32))
 	o.driver.MemCopyH2D(o.ctx, dOutSize, hOutSize)
 	defer o.driver.FreeMemory(o.ctx, dOutSize)
-	dInIndexBuf := o.driver.AllocateMemory(o.ctx,
+	dInIndexBuf := o.driver.MustAllocateMemory(o.ctx,
 		uint64(t.NumElement()*dim*sizeOfInt32))
 	defer o.driver.FreeMemory(o.ctx, dInIndexBuf)
-	dOutIndexBuf := o.driver.AllocateMemory(o.ctx,
+	dOutIndexBuf := o.driver.MustAllocateMemory(o.ctx,
 		uint64(t.NumElement()*dim*sizeOfInt32))
 	defer o.driver.FreeMemory(o.ctx, dOutIndexBuf)
 
@@ -499,16 +499,16 @@ func (o *GPUOperator) Rotate180(t tensor.Tensor) tensor.Tensor {
 
 	output := o.Create(outSize).(*Tensor)
 
-	dInSize := o.driver.AllocateMemory(o.ctx, uint64(dim*sizeOfInt32))
+	dInSize := o.driver.MustAllocateMemory(o.ctx, uint64(dim*sizeOfInt32))
 	o.driver.MemCopyH2D(o.ctx, dInSize, hInSize)
 	defer o.driver.FreeMemory(o.ctx, dInSize)
-	dOutSize := o.driver.AllocateMemory(o.ctx, uint64(dim*sizeOfInt32))
+	dOutSize := o.driver.MustAllocateMemory(o.ctx, uint64(dim*sizeOfInt32))
 	o.driver.MemCopyH2D(o.ctx, dOutSize, hOutSize)
 	defer o.driver.FreeMemory(o.ctx, dOutSize)
-	dInIndexBuf := o.driver.AllocateMemory(o.ctx,
+	dInIndexBuf := o.driver.MustAllocateMemory(o.ctx,
 		uint64(t.NumElement()*dim*sizeOfInt32))
 	defer o.driver.FreeMemory(o.ctx, dInIndexBuf)
-	dOutIndexBuf := o.driver.AllocateMemory(o.ctx,
+	dOutIndexBuf := o.driver.MustAllocateMemory(o.ctx,
 		uint64(t.NumElement()*dim*sizeOfInt32))
 	defer o.driver.FreeMemory(o.ctx, dOutIndexBuf)
 
@@ -568,19 +568,19 @@ func (o *GPUOperator) Dilate(t tensor.Tensor, dilate []int) tensor.Tensor {
 		hOutSize[i] = int32(outSize[i])
 	}
 
-	dInSize := o.driver.AllocateMemory(o.ctx, uint64(dim*sizeOfInt32))
+	dInSize := o.driver.MustAllocateMemory(o.ctx, uint64(dim*sizeOfInt32))
 	o.driver.MemCopyH2D(o.ctx, dInSize, hInSize)
 	defer o.driver.FreeMemory(o.ctx, dInSize)
-	dOutSize := o.driver.AllocateMemory(o.ctx, uint64(dim*sizeOfInt32))
+	dOutSize := o.driver.MustAllocateMemory(o.ctx, uint64(dim*sizeOfInt32))
 	o.driver.MemCopyH2D(o.ctx, dOutSize, hOutSize)
 	defer o.driver.FreeMemory(o.ctx, dOutSize)
-	dDilate := o.driver.AllocateMemory(o.ctx, uint64(2*sizeOfInt32))
+	dDilate := o.driver.MustAllocateMemory(o.ctx, uint64(2*sizeOfInt32))
 	o.driver.MemCopyH2D(o.ctx, dDilate, hDilate)
 	defer o.driver.FreeMemory(o.ctx, dDilate)
-	dInIndexBuf := o.driver.AllocateMemory(o.ctx,
+	dInIndexBuf := o.driver.MustAllocateMemory(o.ctx,
 		uint64(output.NumElement()*dim*sizeOfInt32))
 	defer o.driver.FreeMemory(o.ctx, dInIndexBuf)
-	dOutIndexBuf := o.driver.AllocateMemory(o.ctx,
+	dOutIndexBuf := o.driver.MustAllocateMemory(o.ctx,
 		uint64(output.NumElement()*dim*sizeOfInt32))
 	defer o.driver.FreeMemory(o.ctx, dOutIndexBuf)
 
@@ -683,19 +683,19 @@ func (o *GPUOperator) sumOneAxis(t tensor.Tensor, axis int) tensor.Tensor {
 	localSize := 64
 	globalSize := out.NumElement()
 
-	dInSize := o.driver.AllocateMemory(o.ctx, uint64(t.Dim()*4))
+	dInSize := o.driver.MustAllocateMemory(o.ctx, uint64(t.Dim()*4))
 	o.driver.MemCopyH2D(o.ctx, dInSize, hInSize)
 	defer o.driver.FreeMemory(o.ctx, dInSize)
 
-	dOutSize := o.driver.AllocateMemory(o.ctx, uint64(len(outSize)*4))
+	dOutSize := o.driver.MustAllocateMemory(o.ctx, uint64(len(outSize)*4))
 	o.driver.MemCopyH2D(o.ctx, dOutSize, hOutSize)
 	defer o.driver.FreeMemory(o.ctx, dOutSize)
 
-	dInIndexBuf := o.driver.AllocateMemory(o.ctx,
+	dInIndexBuf := o.driver.MustAllocateMemory(o.ctx,
 		uint64(globalSize*t.Dim()*4))
 	defer o.driver.FreeMemory(o.ctx, dInIndexBuf)
 
-	dOutIndexBuf := o.driver.AllocateMemory(o.ctx,
+	dOutIndexBuf := o.driver.MustAllocateMemory(o.ctx,
 		uint64(globalSize*out.Dim()*4))
 	defer o.driver.FreeMemory(o.ctx, dOutIndexBuf)
 
@@ -1318,7 +1318,7 @@ func (o *GPUOperator) CrossEntropyDerivative(
 	for i := 0; i < len(label); i++ {
 		hLabel[i] = int32(label[i])
 	}
-	dLabel := o.driver.AllocateMemory(o.ctx, uint64(len(label)*4))
+	dLabel := o.driver.MustAllocateMemory(o.ctx, uint64(len(label)*4))
 	defer o.driver.FreeMemory(o.ctx, dLabel)
 	o.driver.MemCopyH2D(o.ctx, dLabel, hLabel)
 
@@ -1360,7 +1360,7 @@ func (o *GPUOperator) SoftmaxCrossEntropyDerivative(
 	for i := 0; i < len(label); i++ {
 		hLabel[i] = int32(label[i])
 	}
-	dLabel := o.driver.AllocateMemory(o.ctx, uint64(len(label)*4))
+	dLabel := o.driver.MustAllocateMemory(o.ctx, uint64(len(label)*4))
 	defer o.driver.FreeMemory(o.ctx, dLabel)
 	o.driver.MemCopyH2D(o.ctx, dLabel, hLabel)
 
@@ -186,7 +186,7 @@ func (t DataParallelismMultiGPUTrainer) averageGradient() {
 
 		for i := 0; i < gpuNum; i++ {
 			datas[i] = gradients[i].Ptr()
-			bufs[i] = t.Driver.AllocateMemory(t.Contexts[i], uint64(bufSize*4))
+			bufs[i] = t.Driver.MustAllocateMemory(t.Contexts[i], uint64(bufSize*4))
 		}
 
 		comms := mccl.CommInitAllMultipleContexts(
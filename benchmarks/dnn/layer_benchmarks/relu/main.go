@@ -72,16 +72,16 @@ func (b *Benchmark) Run() {
 
 func (b *Benchmark) initMem() {
 	if b.useUnifiedMemory {
-		b.gInputData = b.driver.AllocateUnifiedMemory(b.context,
+		b.gInputData = b.driver.MustAllocateUnifiedMemory(b.context,
 			uint64(b.Length*4))
-		b.gOutputData = b.driver.AllocateUnifiedMemory(b.context,
+		b.gOutputData = b.driver.MustAllocateUnifiedMemory(b.context,
 			uint64(b.Length*4))
 	} else {
-		b.gInputData = b.driver.AllocateMemory(b.context, uint64(b.Length*4))
-		b.driver.Distribute(b.context, b.gInputData, uint64(b.Length*4), b.gpus)
+		b.gInputData = b.driver.MustAllocateMemory(b.context, uint64(b.Length*4))
+		b.driver.MustDistribute(b.context, b.gInputData, uint64(b.Length*4), b.gpus)
 
-		b.gOutputData = b.driver.AllocateMemory(b.context, uint64(b.Length*4))
-		b.driver.Distribute(b.context, b.gOutputData,
+		b.gOutputData = b.driver.MustAllocateMemory(b.context, uint64(b.Length*4))
+		b.driver.MustDistribute(b.context, b.gOutputData,
 			uint64(b.Length*4), b.gpus)
 	}
 
@@ -143,22 +143,22 @@ func (b *Benchmark) initMem() {
 	}
 
 	if b.useUnifiedMemory {
-		b.gInput = b.driver.AllocateUnifiedMemory(b.context, uint64(b.Length))
+		b.gInput = b.driver.MustAllocateUnifiedMemory(b.context, uint64(b.Length))
 	} else {
-		b.gInput = b.driver.AllocateMemory(b.context, uint64(b.Length))
-		b.driver.Distribute(b.context, b.gInput, uint64(b.Length), b.gpus)
+		b.gInput = b.driver.MustAllocateMemory(b.context, uint64(b.Length))
+		b.driver.MustDistribute(b.context, b.gInput, uint64(b.Length), b.gpus)
 	}
 
 	for i, gpu := range b.gpus {
 		b.driver.SelectGPU(b.context, gpu)
 		if b.useUnifiedMemory {
-			b.gExpandedKey[i] = b.driver.AllocateUnifiedMemory(
+			b.gExpandedKey[i] = b.driver.MustAllocateUnifiedMemory(
 				b.context, uint64(len(b.expandedKey)*4))
-			b.gS[i] = b.driver.AllocateUnifiedMemory(b.context, uint64(len(b.s)))
+			b.gS[i] = b.driver.MustAllocateUnifiedMemory(b.context, uint64(len(b.s)))
 		} else {
-			b.gExpandedKey[i] = b.driver.AllocateMemory(
+			b.gExpandedKey[i] = b.driver.MustAllocateMemory(
 				b.context, uint64(len(b.expandedKey)*4))
-			b.gS[i] = b.driver.AllocateMemory(b.context, uint64(len(b.s)))
+			b.gS[i] = b.driver.MustAllocateMemory(b.context, uint64(len(b.s)))
 		}
 
 		b.driver.MemCopyH2D(b.context, b.gExpandedKey[i], b.expandedKey)
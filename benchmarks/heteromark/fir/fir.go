@@ -62,6 +62,11 @@ func NewBenchmark(driver *driver.Driver) *Benchmark {
 	return b
 }
 
+// Context returns the driver context that the benchmark runs in.
+func (b *Benchmark) Context() *driver.Context {
+	return b.context
+}
+
 // SelectGPU select GPU
 func (b *Benchmark) SelectGPU(gpus []int) {
 	b.gpus = gpus
@@ -94,23 +99,23 @@ func (b *Benchmark) initMem() {
 
 	if b.useUnifiedMemory {
 		b.gFilterData = make([]driver.Ptr, len(b.gpus))
-		b.gHistoryData = b.driver.AllocateUnifiedMemory(
+		b.gHistoryData = b.driver.MustAllocateUnifiedMemory(
 			b.context, uint64(b.numTaps*4))
-		b.gInputData = b.driver.AllocateUnifiedMemory(
+		b.gInputData = b.driver.MustAllocateUnifiedMemory(
 			b.context, uint64(b.Length*4))
-		b.gOutputData = b.driver.AllocateUnifiedMemory(
+		b.gOutputData = b.driver.MustAllocateUnifiedMemory(
 			b.context, uint64(b.Length*4))
 	} else {
 		b.gFilterData = make([]driver.Ptr, len(b.gpus))
-		b.gHistoryData = b.driver.AllocateMemory(
+		b.gHistoryData = b.driver.MustAllocateMemory(
 			b.context, uint64(b.numTaps*4))
-		b.gInputData = b.driver.AllocateMemory(
+		b.gInputData = b.driver.MustAllocateMemory(
 			b.context, uint64(b.Length*4))
-		b.driver.Distribute(b.context,
+		b.driver.MustDistribute(b.context,
 			b.gInputData, uint64(b.Length*4), b.gpus)
-		b.gOutputData = b.driver.AllocateMemory(
+		b.gOutputData = b.driver.MustAllocateMemory(
 			b.context, uint64(b.Length*4))
-		b.driver.Distribute(b.context,
+		b.driver.MustDistribute(b.context,
 			b.gOutputData, uint64(b.Length*4), b.gpus)
 	}
 
@@ -119,10 +124,10 @@ func (b *Benchmark) initMem() {
 	for i, gpu := range b.gpus {
 		b.driver.SelectGPU(b.context, gpu)
 		if b.useUnifiedMemory {
-			b.gFilterData[i] = b.driver.AllocateUnifiedMemory(
+			b.gFilterData[i] = b.driver.MustAllocateUnifiedMemory(
 				b.context, uint64(b.numTaps*4))
 		} else {
-			b.gFilterData[i] = b.driver.AllocateMemory(
+			b.gFilterData[i] = b.driver.MustAllocateMemory(
 				b.context, uint64(b.numTaps*4))
 		}
 		b.driver.MemCopyH2D(b.context, b.gFilterData[i], b.filterData)
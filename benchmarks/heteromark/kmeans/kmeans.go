@@ -116,28 +116,28 @@ func (b *Benchmark) Run() {
 
 func (b *Benchmark) initMem() {
 	if b.useUnifiedMemory {
-		b.dFeatures = b.driver.AllocateUnifiedMemory(
+		b.dFeatures = b.driver.MustAllocateUnifiedMemory(
 			b.context,
 			uint64(b.NumPoints*b.NumFeatures*4))
-		b.dFeaturesSwap = b.driver.AllocateUnifiedMemory(
+		b.dFeaturesSwap = b.driver.MustAllocateUnifiedMemory(
 			b.context, uint64(b.NumPoints*b.NumFeatures*4))
-		b.dMembership = b.driver.AllocateUnifiedMemory(
+		b.dMembership = b.driver.MustAllocateUnifiedMemory(
 			b.context, uint64(b.NumPoints*4))
 	} else {
-		b.dFeatures = b.driver.AllocateMemory(
+		b.dFeatures = b.driver.MustAllocateMemory(
 			b.context,
 			uint64(b.NumPoints*b.NumFeatures*4))
-		b.driver.Distribute(b.context, b.dFeatures,
+		b.driver.MustDistribute(b.context, b.dFeatures,
 			uint64(b.NumPoints*b.NumFeatures*4), b.gpus)
 
-		b.dFeaturesSwap = b.driver.AllocateMemory(
+		b.dFeaturesSwap = b.driver.MustAllocateMemory(
 			b.context, uint64(b.NumPoints*b.NumFeatures*4))
-		b.driver.Distribute(b.context, b.dFeaturesSwap,
+		b.driver.MustDistribute(b.context, b.dFeaturesSwap,
 			uint64(b.NumPoints*b.NumFeatures*4), b.gpus)
 
-		b.dMembership = b.driver.AllocateMemory(
+		b.dMembership = b.driver.MustAllocateMemory(
 			b.context, uint64(b.NumPoints*4))
-		b.driver.Distribute(b.context, b.dMembership,
+		b.driver.MustDistribute(b.context, b.dMembership,
 			uint64(b.NumPoints*4), b.gpus)
 	}
 
@@ -145,10 +145,10 @@ func (b *Benchmark) initMem() {
 	for i, gpu := range b.gpus {
 		b.driver.SelectGPU(b.context, gpu)
 		if b.useUnifiedMemory {
-			b.dClusters[i] = b.driver.AllocateUnifiedMemory(
+			b.dClusters[i] = b.driver.MustAllocateUnifiedMemory(
 				b.context, uint64(b.NumClusters*b.NumFeatures*4))
 		} else {
-			b.dClusters[i] = b.driver.AllocateMemory(
+			b.dClusters[i] = b.driver.MustAllocateMemory(
 				b.context, uint64(b.NumClusters*b.NumFeatures*4))
 		}
 	}
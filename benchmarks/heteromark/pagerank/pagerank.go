@@ -110,26 +110,26 @@ func (b *Benchmark) initMem() {
 	}
 
 	if b.useUnifiedMemory {
-		b.dPageRank = b.driver.AllocateUnifiedMemory(
+		b.dPageRank = b.driver.MustAllocateUnifiedMemory(
 			b.context, uint64(b.NumNodes*4))
-		b.dPageRankTemp = b.driver.AllocateUnifiedMemory(
+		b.dPageRankTemp = b.driver.MustAllocateUnifiedMemory(
 			b.context, uint64(b.NumNodes*4))
-		b.dRowOffsets = b.driver.AllocateUnifiedMemory(
+		b.dRowOffsets = b.driver.MustAllocateUnifiedMemory(
 			b.context, uint64((b.NumNodes+1)*4))
-		b.dColumnNumbers = b.driver.AllocateUnifiedMemory(
+		b.dColumnNumbers = b.driver.MustAllocateUnifiedMemory(
 			b.context, uint64(b.NumConnections*4))
-		b.dValues = b.driver.AllocateUnifiedMemory(
+		b.dValues = b.driver.MustAllocateUnifiedMemory(
 			b.context, uint64(b.NumConnections*4))
 	} else {
-		b.dPageRank = b.driver.AllocateMemory(
+		b.dPageRank = b.driver.MustAllocateMemory(
 			b.context, uint64(b.NumNodes*4))
-		b.dPageRankTemp = b.driver.AllocateMemory(
+		b.dPageRankTemp = b.driver.MustAllocateMemory(
 			b.context, uint64(b.NumNodes*4))
-		b.dRowOffsets = b.driver.AllocateMemory(
+		b.dRowOffsets = b.driver.MustAllocateMemory(
 			b.context, uint64((b.NumNodes+1)*4))
-		b.dColumnNumbers = b.driver.AllocateMemory(
+		b.dColumnNumbers = b.driver.MustAllocateMemory(
 			b.context, uint64(b.NumConnections*4))
-		b.dValues = b.driver.AllocateMemory(
+		b.dValues = b.driver.MustAllocateMemory(
 			b.context, uint64(b.NumConnections*4))
 	}
 }
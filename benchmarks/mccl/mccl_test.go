@@ -39,7 +39,7 @@ var _ = Describe("MCCL", func() {
 
 		for i := 0; i < gpuNum; i++ {
 			gpuDriver.SelectGPU(context, i+1)
-			data := gpuDriver.AllocateMemory(context, uint64(dataSize*4))
+			data := gpuDriver.MustAllocateMemory(context, uint64(dataSize*4))
 			gpuIDs = append(gpuIDs, i+1)
 			datas[i] = data
 		}
@@ -76,9 +76,9 @@ var _ = Describe("MCCL", func() {
 				tmp[j] = float32(i + 1)
 			}
 			gpuDriver.SelectGPU(context, i+1)
-			data := gpuDriver.AllocateMemory(context, uint64(dataSize*4))
+			data := gpuDriver.MustAllocateMemory(context, uint64(dataSize*4))
 			gpuDriver.MemCopyH2D(context, data, tmp)
-			buf := gpuDriver.AllocateMemory(context, uint64(bufSize*4))
+			buf := gpuDriver.MustAllocateMemory(context, uint64(bufSize*4))
 			gpuIDs = append(gpuIDs, i+1)
 			datas[i] = data
 			bufs[i] = buf
@@ -111,9 +111,9 @@ var _ = Describe("MCCL", func() {
 				tmp[j] = float32(i + 1)
 			}
 			gpuDriver.SelectGPU(context, i+1)
-			data := gpuDriver.AllocateMemory(context, uint64(dataSize*4))
+			data := gpuDriver.MustAllocateMemory(context, uint64(dataSize*4))
 			gpuDriver.MemCopyH2D(context, data, tmp)
-			buf := gpuDriver.AllocateMemory(context, uint64(bufSize*4))
+			buf := gpuDriver.MustAllocateMemory(context, uint64(bufSize*4))
 			gpuIDs = append(gpuIDs, i+1)
 			datas[i] = data
 			bufs[i] = buf
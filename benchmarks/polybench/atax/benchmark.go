@@ -110,22 +110,22 @@ func (b *Benchmark) initMem() {
 	}
 
 	if b.useUnifiedMemory {
-		b.dA = b.driver.AllocateUnifiedMemory(b.context,
+		b.dA = b.driver.MustAllocateUnifiedMemory(b.context,
 			uint64(b.NY*b.NX*4))
-		b.dX = b.driver.AllocateUnifiedMemory(b.context,
+		b.dX = b.driver.MustAllocateUnifiedMemory(b.context,
 			uint64(b.NY*4))
-		b.dY = b.driver.AllocateUnifiedMemory(b.context,
+		b.dY = b.driver.MustAllocateUnifiedMemory(b.context,
 			uint64(b.NY*4))
-		b.dTmp = b.driver.AllocateUnifiedMemory(b.context,
+		b.dTmp = b.driver.MustAllocateUnifiedMemory(b.context,
 			uint64(b.NX*4))
 	} else {
-		b.dA = b.driver.AllocateMemory(b.context,
+		b.dA = b.driver.MustAllocateMemory(b.context,
 			uint64(b.NY*b.NX*4))
-		b.dX = b.driver.AllocateMemory(b.context,
+		b.dX = b.driver.MustAllocateMemory(b.context,
 			uint64(b.NY*4))
-		b.dY = b.driver.AllocateMemory(b.context,
+		b.dY = b.driver.MustAllocateMemory(b.context,
 			uint64(b.NY*4))
-		b.dTmp = b.driver.AllocateMemory(b.context,
+		b.dTmp = b.driver.MustAllocateMemory(b.context,
 			uint64(b.NX*4))
 	}
 }
@@ -116,26 +116,26 @@ func (b *Benchmark) initMem() {
 	}
 
 	if b.useUnifiedMemory {
-		b.dA = b.driver.AllocateUnifiedMemory(b.context,
+		b.dA = b.driver.MustAllocateUnifiedMemory(b.context,
 			uint64(b.NY*b.NX*4))
-		b.dR = b.driver.AllocateUnifiedMemory(b.context,
+		b.dR = b.driver.MustAllocateUnifiedMemory(b.context,
 			uint64(b.NX*4))
-		b.dS = b.driver.AllocateUnifiedMemory(b.context,
+		b.dS = b.driver.MustAllocateUnifiedMemory(b.context,
 			uint64(b.NY*4))
-		b.dP = b.driver.AllocateUnifiedMemory(b.context,
+		b.dP = b.driver.MustAllocateUnifiedMemory(b.context,
 			uint64(b.NY*4))
-		b.dQ = b.driver.AllocateUnifiedMemory(b.context,
+		b.dQ = b.driver.MustAllocateUnifiedMemory(b.context,
 			uint64(b.NX*4))
 	} else {
-		b.dA = b.driver.AllocateMemory(b.context,
+		b.dA = b.driver.MustAllocateMemory(b.context,
 			uint64(b.NY*b.NX*4))
-		b.dR = b.driver.AllocateMemory(b.context,
+		b.dR = b.driver.MustAllocateMemory(b.context,
 			uint64(b.NX*4))
-		b.dS = b.driver.AllocateMemory(b.context,
+		b.dS = b.driver.MustAllocateMemory(b.context,
 			uint64(b.NY*4))
-		b.dP = b.driver.AllocateMemory(b.context,
+		b.dP = b.driver.MustAllocateMemory(b.context,
 			uint64(b.NY*4))
-		b.dQ = b.driver.AllocateMemory(b.context,
+		b.dQ = b.driver.MustAllocateMemory(b.context,
 			uint64(b.NX*4))
 	}
 }
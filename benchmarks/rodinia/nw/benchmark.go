@@ -189,17 +189,17 @@ func (b *Benchmark) allocateGPUMem() {
 	b.dInputItemSets = b.allocate(uint64(b.col * b.row * 4))
 	b.dOutputItemSets = b.allocate(uint64(b.col * b.row * 4))
 	b.dReference = b.allocate(uint64(b.col * b.row * 4))
-	b.driver.Distribute(b.context, b.dInputItemSets, uint64(b.col*b.row*4), b.gpuIDs)
-	b.driver.Distribute(b.context, b.dOutputItemSets, uint64(b.col*b.row*4), b.gpuIDs)
-	b.driver.Distribute(b.context, b.dReference, uint64(b.col*b.row*4), b.gpuIDs)
+	b.driver.MustDistribute(b.context, b.dInputItemSets, uint64(b.col*b.row*4), b.gpuIDs)
+	b.driver.MustDistribute(b.context, b.dOutputItemSets, uint64(b.col*b.row*4), b.gpuIDs)
+	b.driver.MustDistribute(b.context, b.dReference, uint64(b.col*b.row*4), b.gpuIDs)
 }
 
 func (b *Benchmark) allocate(byteSize uint64) driver.Ptr {
 	if b.useUnifiedMemory {
-		return b.driver.AllocateUnifiedMemory(b.context, byteSize)
+		return b.driver.MustAllocateUnifiedMemory(b.context, byteSize)
 	}
 
-	return b.driver.AllocateMemory(b.context, byteSize)
+	return b.driver.MustAllocateMemory(b.context, byteSize)
 }
 
 func (b *Benchmark) exec() {
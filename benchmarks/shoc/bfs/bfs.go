@@ -119,21 +119,21 @@ func (b *Benchmark) initMem() {
 	b.hCost[b.sourceNode] = 0
 
 	if b.useUnifiedMemory {
-		b.dFrontier = b.driver.AllocateUnifiedMemory(b.context,
+		b.dFrontier = b.driver.MustAllocateUnifiedMemory(b.context,
 			uint64(b.NumNode*4))
-		b.dEdgeArray = b.driver.AllocateUnifiedMemory(b.context,
+		b.dEdgeArray = b.driver.MustAllocateUnifiedMemory(b.context,
 			uint64((b.NumNode+1)*4))
-		b.dEdgeArrayAux = b.driver.AllocateUnifiedMemory(b.context,
+		b.dEdgeArrayAux = b.driver.MustAllocateUnifiedMemory(b.context,
 			uint64(len(b.hEdgeList)*4))
-		b.dFlag = b.driver.AllocateUnifiedMemory(b.context, 4)
+		b.dFlag = b.driver.MustAllocateUnifiedMemory(b.context, 4)
 	} else {
-		b.dFrontier = b.driver.AllocateMemory(b.context,
+		b.dFrontier = b.driver.MustAllocateMemory(b.context,
 			uint64(b.NumNode*4))
-		b.dEdgeArray = b.driver.AllocateMemory(b.context,
+		b.dEdgeArray = b.driver.MustAllocateMemory(b.context,
 			uint64((b.NumNode+1)*4))
-		b.dEdgeArrayAux = b.driver.AllocateMemory(b.context,
+		b.dEdgeArrayAux = b.driver.MustAllocateMemory(b.context,
 			uint64(len(b.hEdgeList)*4))
-		b.dFlag = b.driver.AllocateMemory(b.context, 4)
+		b.dFlag = b.driver.MustAllocateMemory(b.context, 4)
 	}
 }
 
@@ -100,10 +100,10 @@ func (b *Benchmark) initMem() {
 	b.fill()
 
 	if b.useUnifiedMemory {
-		b.dSource = b.driver.AllocateUnifiedMemory(b.context,
+		b.dSource = b.driver.MustAllocateUnifiedMemory(b.context,
 			b.usedBytes)
 	} else {
-		b.dSource = b.driver.AllocateMemory(b.context,
+		b.dSource = b.driver.MustAllocateMemory(b.context,
 			b.usedBytes)
 	}
 	b.driver.MemCopyH2D(b.context, b.dSource, b.source)
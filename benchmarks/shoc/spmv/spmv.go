@@ -112,26 +112,26 @@ func (b *Benchmark) initMem() {
 	}
 
 	if b.useUnifiedMemory {
-		b.dValData = b.driver.AllocateUnifiedMemory(b.context,
+		b.dValData = b.driver.MustAllocateUnifiedMemory(b.context,
 			uint64(b.nItems*4))
-		b.dVecData = b.driver.AllocateUnifiedMemory(b.context,
+		b.dVecData = b.driver.MustAllocateUnifiedMemory(b.context,
 			uint64(b.Dim*4))
-		b.dColsData = b.driver.AllocateUnifiedMemory(b.context,
+		b.dColsData = b.driver.MustAllocateUnifiedMemory(b.context,
 			uint64(b.nItems*4))
-		b.dRowDData = b.driver.AllocateUnifiedMemory(b.context,
+		b.dRowDData = b.driver.MustAllocateUnifiedMemory(b.context,
 			uint64((b.Dim+1)*4))
-		b.dOutData = b.driver.AllocateUnifiedMemory(b.context,
+		b.dOutData = b.driver.MustAllocateUnifiedMemory(b.context,
 			uint64(b.Dim*4))
 	} else {
-		b.dValData = b.driver.AllocateMemory(b.context,
+		b.dValData = b.driver.MustAllocateMemory(b.context,
 			uint64(b.nItems*4))
-		b.dVecData = b.driver.AllocateMemory(b.context,
+		b.dVecData = b.driver.MustAllocateMemory(b.context,
 			uint64(b.Dim*4))
-		b.dColsData = b.driver.AllocateMemory(b.context,
+		b.dColsData = b.driver.MustAllocateMemory(b.context,
 			uint64(b.nItems*4))
-		b.dRowDData = b.driver.AllocateMemory(b.context,
+		b.dRowDData = b.driver.MustAllocateMemory(b.context,
 			uint64((b.Dim+1)*4))
-		b.dOutData = b.driver.AllocateMemory(b.context,
+		b.dOutData = b.driver.MustAllocateMemory(b.context,
 			uint64(b.Dim*4))
 	}
 }
@@ -136,14 +136,14 @@ func (b *Benchmark) initMem() {
 	}
 
 	if b.useUnifiedMemory {
-		b.dData1 = b.driver.AllocateUnifiedMemory(b.context,
+		b.dData1 = b.driver.MustAllocateUnifiedMemory(b.context,
 			uint64(b.paddedDataSize*4))
-		b.dData2 = b.driver.AllocateUnifiedMemory(b.context,
+		b.dData2 = b.driver.MustAllocateUnifiedMemory(b.context,
 			uint64(b.paddedDataSize*4))
 	} else {
-		b.dData1 = b.driver.AllocateMemory(b.context,
+		b.dData1 = b.driver.MustAllocateMemory(b.context,
 			uint64(b.paddedDataSize*4))
-		b.dData2 = b.driver.AllocateMemory(b.context,
+		b.dData2 = b.driver.MustAllocateMemory(b.context,
 			uint64(b.paddedDataSize*4))
 	}
 
@@ -1 +1,82 @@
 package bitops
+
+import "math"
+
+// Float16ToFloat32 converts an IEEE 754 half-precision number, given as its
+// bits, to a float32. The conversion is exact.
+func Float16ToFloat32(h uint16) float32 {
+	sign := uint32(h&0x8000) << 16
+	exp := uint32(h>>10) & 0x1f
+	mant := uint32(h & 0x3ff)
+
+	switch exp {
+	case 0:
+		if mant == 0 {
+			return math.Float32frombits(sign)
+		}
+
+		// Subnormal half-precision numbers are normal in float32.
+		e := uint32(127 - 15 + 1)
+		for mant&0x400 == 0 {
+			mant <<= 1
+			e--
+		}
+		mant &= 0x3ff
+
+		return math.Float32frombits(sign | e<<23 | mant<<13)
+	case 0x1f:
+		return math.Float32frombits(sign | 0x7f80_0000 | mant<<13)
+	}
+
+	return math.Float32frombits(sign | (exp+127-15)<<23 | mant<<13)
+}
+
+// Float32ToFloat16 converts a float32 to the bits of an IEEE 754
+// half-precision number, rounding to the nearest even. Numbers that are too
+// large become infinities and NaNs stay NaNs.
+func Float32ToFloat16(f float32) uint16 {
+	bits := math.Float32bits(f)
+	sign := uint16(bits>>16) & 0x8000
+	exp := int(bits>>23) & 0xff
+	mant := bits & 0x7f_ffff
+
+	if exp == 0xff {
+		if mant != 0 {
+			return sign | 0x7e00 | uint16(mant>>13)
+		}
+
+		return sign | 0x7c00
+	}
+
+	e := exp - 127 + 15
+	if e >= 0x1f {
+		return sign | 0x7c00
+	}
+
+	if e <= 0 {
+		if e < -10 {
+			return sign
+		}
+
+		mant |= 0x80_0000
+		shift := uint(14 - e)
+
+		return sign | roundToNearestEven(mant, shift)
+	}
+
+	// A carry out of the mantissa increments the exponent, which may turn
+	// the number into an infinity.
+	return sign | (uint16(e)<<10 + roundToNearestEven(mant, 13))
+}
+
+func roundToNearestEven(bits uint32, shift uint) uint16 {
+	result := bits >> shift
+	rem := bits & (1<<shift - 1)
+	halfway := uint32(1) << (shift - 1)
+
+	if rem > halfway || (rem == halfway && result&1 == 1) {
+		result++
+	}
+
+	return uint16(result)
+}
@@ -1,10 +1,63 @@
 package bitops_test
 
 import (
+	"math"
+
 	. "github.com/onsi/ginkgo/v2"
-	// . "github.com/onsi/gomega"
-	// . "github.com/sarchlab/mgpusim/v3/bitops"
+	. "github.com/onsi/gomega"
+
+	. "github.com/sarchlab/mgpusim/v3/bitops"
 )
 
 var _ = Describe("Conversion", func() {
+	It("should convert float16 to float32", func() {
+		table := []struct {
+			input  uint16
+			output float32
+		}{
+			{0x0000, 0},
+			{0x3c00, 1},
+			{0xc000, -2},
+			{0x3555, 0.333251953125},
+			{0x7bff, 65504},
+			{0x0001, float32(math.Pow(2, -24))},
+			{0x0400, float32(math.Pow(2, -14))},
+			{0x7c00, float32(math.Inf(1))},
+			{0xfc00, float32(math.Inf(-1))},
+		}
+
+		for _, entry := range table {
+			Expect(Float16ToFloat32(entry.input)).To(Equal(entry.output))
+		}
+
+		Expect(math.IsNaN(float64(Float16ToFloat32(0x7e00)))).To(BeTrue())
+	})
+
+	It("should convert float32 to float16", func() {
+		table := []struct {
+			input  float32
+			output uint16
+		}{
+			{0, 0x0000},
+			{1, 0x3c00},
+			{-2, 0xc000},
+			{1.0 / 3, 0x3555},
+			{65504, 0x7bff},
+			{65520, 0x7c00},
+			{1e10, 0x7c00},
+			{float32(math.Pow(2, -24)), 0x0001},
+			{float32(math.Pow(2, -26)), 0x0000},
+			{float32(math.Pow(2, -14)), 0x0400},
+			{1 + float32(math.Pow(2, -11)), 0x3c00},
+			{1 + 3*float32(math.Pow(2, -11)), 0x3c02},
+			{float32(math.Inf(-1)), 0xfc00},
+		}
+
+		for _, entry := range table {
+			Expect(Float32ToFloat16(entry.input)).To(Equal(entry.output))
+		}
+
+		Expect(Float32ToFloat16(float32(math.NaN())) & 0x7e00).
+			To(Equal(uint16(0x7e00)))
+	})
 })
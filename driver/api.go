@@ -106,12 +106,82 @@ func (d *Driver) CreateCommandQueue(c *Context) *CommandQueue {
 	q.Context = c
 
 	c.queueMutex.Lock()
+	q.Priority = c.queuePriority
+	q.CUMask = c.queueCUMask
 	c.queues = append(c.queues, q)
 	c.queueMutex.Unlock()
 
 	return q
 }
 
+// ReleaseCommandQueue waits for the commands in the queue to complete and
+// removes the queue from its context. The queue cannot be used after it is
+// released.
+func (d *Driver) ReleaseCommandQueue(q *CommandQueue) {
+	d.DrainCommandQueue(q)
+
+	c := q.Context
+	c.queueMutex.Lock()
+	defer c.queueMutex.Unlock()
+
+	for i, other := range c.queues {
+		if other == q {
+			c.queues = append(c.queues[:i], c.queues[i+1:]...)
+			return
+		}
+	}
+}
+
+// ReleaseContext waits for the commands in all the command queues of the
+// context to complete and removes the context and its queues from the driver.
+// The memory that the context allocates is not freed, so the caller should
+// free it first. The context cannot be used after it is released.
+func (d *Driver) ReleaseContext(c *Context) {
+	c.queueMutex.Lock()
+	queues := append([]*CommandQueue(nil), c.queues...)
+	c.queueMutex.Unlock()
+
+	for _, q := range queues {
+		d.ReleaseCommandQueue(q)
+	}
+
+	d.contextMutex.Lock()
+	defer d.contextMutex.Unlock()
+
+	for i, other := range d.contexts {
+		if other == c {
+			d.contexts = append(d.contexts[:i], d.contexts[i+1:]...)
+			return
+		}
+	}
+}
+
+// SetQueuePriority sets the priority of all the command queues of the
+// context, including the queues created later. The kernels from queues with
+// higher priorities get the CUs first.
+func (d *Driver) SetQueuePriority(c *Context, priority int) {
+	c.queueMutex.Lock()
+	defer c.queueMutex.Unlock()
+
+	c.queuePriority = priority
+	for _, q := range c.queues {
+		q.Priority = priority
+	}
+}
+
+// SetQueueCUMask limits the CUs that the kernels from the command queues of
+// the context can run on, including the queues created later. Bit i of word
+// i/32 of the mask enables the i-th CU. An empty mask enables all the CUs.
+func (d *Driver) SetQueueCUMask(c *Context, mask []uint32) {
+	c.queueMutex.Lock()
+	defer c.queueMutex.Unlock()
+
+	c.queueCUMask = mask
+	for _, q := range c.queues {
+		q.CUMask = mask
+	}
+}
+
 // DrainCommandQueue will return when there is no command to execute
 func (d *Driver) DrainCommandQueue(q *CommandQueue) {
 	listener := q.Subscribe()
@@ -127,14 +197,22 @@ func (d *Driver) DrainCommandQueue(q *CommandQueue) {
 	}
 }
 
+// ErrOutOfMemory is returned when the GPU does not have enough free memory
+// to serve an allocation, similar to hipErrorOutOfMemory.
+var ErrOutOfMemory = internal.ErrOutOfMemory
+
 // AllocateMemory allocates a chunk of memory of size byteSize in storage.
 // It returns the pointer pointing to the newly allocated memory in the GPU
-// memory space.
+// memory space. If the GPU does not have enough free memory, it returns
+// ErrOutOfMemory and nothing is allocated.
 func (d *Driver) AllocateMemory(
 	ctx *Context,
 	byteSize uint64,
-) Ptr {
-	ptr := d.memAllocator.Allocate(ctx.pid, byteSize, ctx.currentGPUID)
+) (Ptr, error) {
+	ptr, err := d.memAllocator.Allocate(ctx.pid, byteSize, ctx.currentGPUID)
+	if err != nil {
+		return 0, err
+	}
 
 	ctx.buffers = append(ctx.buffers, &buffer{
 		vAddr:   Ptr(ptr),
@@ -143,16 +221,45 @@ func (d *Driver) AllocateMemory(
 		l2Dirty: false,
 	})
 
+	if d.sanitizer != nil {
+		d.sanitizer.allocate(ctx.pid, ptr, byteSize)
+	}
+
 	// log.Printf("Allocate %d\n", ptr)
-	return Ptr(ptr)
+	return Ptr(ptr), nil
 }
 
-// AllocateUnifiedMemory allocates a unified memory. Allocation is done on CPU
-func (d *Driver) AllocateUnifiedMemory(
+// MustAllocateMemory is the same as AllocateMemory, except that it panics if
+// the memory cannot be allocated.
+func (d *Driver) MustAllocateMemory(
 	ctx *Context,
 	byteSize uint64,
 ) Ptr {
-	ptr := Ptr(d.memAllocator.AllocateUnified(ctx.pid, byteSize))
+	ptr, err := d.AllocateMemory(ctx, byteSize)
+	if err != nil {
+		panic(err)
+	}
+
+	return ptr
+}
+
+// AllocateUnifiedMemory allocates a unified memory. Allocation is done on CPU.
+// If the GPUs do not have enough free memory, it returns ErrOutOfMemory and
+// nothing is allocated.
+func (d *Driver) AllocateUnifiedMemory(
+	ctx *Context,
+	byteSize uint64,
+) (Ptr, error) {
+	addr, err := d.memAllocator.AllocateUnified(ctx.pid, byteSize)
+	if err != nil {
+		return 0, err
+	}
+
+	ptr := Ptr(addr)
+
+	if d.sanitizer != nil {
+		d.sanitizer.allocate(ctx.pid, uint64(ptr), byteSize)
+	}
 
 	ctx.buffers = append(ctx.buffers, &buffer{
 		vAddr:   ptr,
@@ -161,29 +268,73 @@ func (d *Driver) AllocateUnifiedMemory(
 		l2Dirty: false,
 	})
 
+	return ptr, nil
+}
+
+// MustAllocateUnifiedMemory is the same as AllocateUnifiedMemory, except that
+// it panics if the memory cannot be allocated.
+func (d *Driver) MustAllocateUnifiedMemory(
+	ctx *Context,
+	byteSize uint64,
+) Ptr {
+	ptr, err := d.AllocateUnifiedMemory(ctx, byteSize)
+	if err != nil {
+		panic(err)
+	}
+
 	return ptr
 }
 
 // Remap keeps the virtual address unchanged and moves the physical address to
-// another GPU
-func (d *Driver) Remap(ctx *Context, addr, size uint64, deviceID int) {
-	d.memAllocator.Remap(ctx.pid, addr, size, deviceID)
+// another GPU. If the GPU does not have enough free memory, it returns
+// ErrOutOfMemory and the pages stay where they are.
+func (d *Driver) Remap(ctx *Context, addr, size uint64, deviceID int) error {
+	return d.memAllocator.Remap(ctx.pid, addr, size, deviceID)
 }
 
 // Distribute rearranges a consecutive virtual memory space and re-allocate the
 // memory on designated GPUs. This function returns the number of bytes
-// allocated to each GPU.
+// allocated to each GPU. If a GPU does not have enough free memory, it returns
+// ErrOutOfMemory.
 func (d *Driver) Distribute(
 	ctx *Context,
 	addr Ptr,
 	byteSize uint64,
 	gpuIDs []int,
-) []uint64 {
+) ([]uint64, error) {
 	if len(gpuIDs) == 1 {
-		return []uint64{byteSize}
+		return []uint64{byteSize}, nil
+	}
+
+	bytes, err := d.distributor.Distribute(ctx, uint64(addr), byteSize, gpuIDs)
+	if err != nil {
+		return nil, err
 	}
 
-	return d.distributor.Distribute(ctx, uint64(addr), byteSize, gpuIDs)
+	ctx.recordDistribution(&distribution{
+		addr:           addr,
+		byteSize:       byteSize,
+		gpuIDs:         gpuIDs,
+		bytesOnEachGPU: bytes,
+	})
+
+	return bytes, nil
+}
+
+// MustDistribute is the same as Distribute, except that it panics if the
+// memory cannot be distributed.
+func (d *Driver) MustDistribute(
+	ctx *Context,
+	addr Ptr,
+	byteSize uint64,
+	gpuIDs []int,
+) []uint64 {
+	bytes, err := d.Distribute(ctx, addr, byteSize, gpuIDs)
+	if err != nil {
+		panic(err)
+	}
+
+	return bytes
 }
 
 func unique(in []int) []int {
@@ -205,7 +356,11 @@ func unique(in []int) []int {
 // provided is invalid.
 func (d *Driver) FreeMemory(ctx *Context, ptr Ptr) error {
 	// log.Printf("Free %d\n", ptr)
-	d.memAllocator.Free(uint64(ptr))
+	d.memAllocator.Free(ctx.pid, uint64(ptr))
+
+	if d.sanitizer != nil {
+		d.sanitizer.free(ctx.pid, uint64(ptr))
+	}
 
 	for i, buffer := range ctx.buffers {
 		if buffer.vAddr == ptr {
@@ -213,6 +368,8 @@ func (d *Driver) FreeMemory(ctx *Context, ptr Ptr) error {
 		}
 	}
 
+	ctx.removeDistribution(ptr)
+
 	return nil
 }
 
@@ -270,6 +427,34 @@ func (d *Driver) EnqueueMemCopyD2D(
 	d.EnqueueLaunchKernel(queue, co, gridSize, wgSize, &kernelArgs)
 }
 
+// EnqueueMemSet registers a command in the queue that sets num bytes starting
+// from dst to value.
+func (d *Driver) EnqueueMemSet(
+	queue *CommandQueue,
+	dst Ptr,
+	value byte,
+	num int,
+) {
+	data := make([]byte, num)
+	for i := range data {
+		data[i] = value
+	}
+
+	d.EnqueueMemCopyH2D(queue, dst, data)
+}
+
+// EnqueueEvent registers an EventCommand in the queue. The event completes
+// when all the commands enqueued before it have completed.
+func (d *Driver) EnqueueEvent(queue *CommandQueue) *EventCommand {
+	cmd := &EventCommand{
+		ID: sim.GetIDGenerator().Generate(),
+	}
+
+	d.Enqueue(queue, cmd)
+
+	return cmd
+}
+
 // MemCopyH2D copies a memory from the host to a GPU device.
 func (d *Driver) MemCopyH2D(ctx *Context, dst Ptr, src interface{}) {
 	queue := d.CreateCommandQueue(ctx)
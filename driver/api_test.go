@@ -66,10 +66,61 @@ var _ = ginkgo.Describe("Driver async API execution", func() {
 		Expect(q.commands).To(HaveLen(0))
 	})
 
+	ginkgo.It("should complete events after the commands before them", func() {
+		context := driver.Init()
+		q := driver.CreateCommandQueue(context)
+		enqueueNoopCommand(driver, q)
+		event := driver.EnqueueEvent(q)
+		Expect(event.IsCompleted()).To(BeFalse())
+
+		driver.DrainCommandQueue(q)
+
+		Expect(q.commands).To(HaveLen(0))
+		Expect(event.IsCompleted()).To(BeTrue())
+	})
+
+	ginkgo.It("should release queues", func() {
+		context := driver.Init()
+		q1 := driver.CreateCommandQueue(context)
+		q2 := driver.CreateCommandQueue(context)
+		enqueueNoopCommand(driver, q1)
+
+		driver.ReleaseCommandQueue(q1)
+
+		Expect(q1.commands).To(HaveLen(0))
+		Expect(context.queues).To(Equal([]*CommandQueue{q2}))
+	})
+
+	ginkgo.It("should release contexts", func() {
+		context := driver.Init()
+		q := driver.CreateCommandQueue(context)
+		enqueueNoopCommand(driver, q)
+
+		driver.ReleaseContext(context)
+
+		Expect(q.commands).To(HaveLen(0))
+		Expect(context.queues).To(BeEmpty())
+		Expect(driver.contexts).NotTo(ContainElement(context))
+	})
+
+	ginkgo.It("should apply the queue settings of the context", func() {
+		context := driver.Init()
+		q1 := driver.CreateCommandQueue(context)
+
+		driver.SetQueuePriority(context, 1)
+		driver.SetQueueCUMask(context, []uint32{0x3})
+		q2 := driver.CreateCommandQueue(context)
+
+		Expect(q1.Priority).To(Equal(1))
+		Expect(q1.CUMask).To(Equal([]uint32{0x3}))
+		Expect(q2.Priority).To(Equal(1))
+		Expect(q2.CUMask).To(Equal([]uint32{0x3}))
+	})
+
 	ginkgo.It("should allocate memory", func() {
 		context := driver.Init()
 
-		ptr := driver.AllocateMemory(context, 1*mem.MB)
+		ptr := driver.MustAllocateMemory(context, 1*mem.MB)
 
 		Expect(context.buffers).To(HaveLen(1))
 		Expect(context.buffers[0].size).To(Equal(1 * mem.MB))
@@ -78,10 +129,31 @@ var _ = ginkgo.Describe("Driver async API execution", func() {
 		Expect(context.buffers[0].l2Dirty).To(BeFalse())
 	})
 
+	ginkgo.It("should return an error if the GPU runs out of memory", func() {
+		context := driver.Init()
+
+		_, err := driver.AllocateMemory(context, 2*mem.GB)
+
+		Expect(err).To(MatchError(ErrOutOfMemory))
+		Expect(context.buffers).To(BeEmpty())
+	})
+
+	ginkgo.It("should reuse the memory that is freed", func() {
+		context := driver.Init()
+
+		ptr, err := driver.AllocateMemory(context, 1*mem.GB)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(driver.FreeMemory(context, ptr)).To(Succeed())
+
+		_, err = driver.AllocateMemory(context, 1*mem.GB)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
 	ginkgo.It("should allocate unified memory", func() {
 		context := driver.Init()
 
-		ptr := driver.AllocateUnifiedMemory(context, 1*mem.MB)
+		ptr, err := driver.AllocateUnifiedMemory(context, 1*mem.MB)
+		Expect(err).NotTo(HaveOccurred())
 
 		Expect(context.buffers).To(HaveLen(1))
 		Expect(context.buffers[0].size).To(Equal(1 * mem.MB))
@@ -90,10 +162,19 @@ var _ = ginkgo.Describe("Driver async API execution", func() {
 		Expect(context.buffers[0].l2Dirty).To(BeFalse())
 	})
 
+	ginkgo.It("should return an error if unified memory runs out", func() {
+		context := driver.Init()
+
+		_, err := driver.AllocateUnifiedMemory(context, 2*mem.GB)
+
+		Expect(err).To(MatchError(ErrOutOfMemory))
+		Expect(context.buffers).To(BeEmpty())
+	})
+
 	// ginkgo.Measure("Memory allocation", func(b ginkgo.Benchmarker) {
 	// 	context := driver.Init()
 	// 	b.Time("runtime", func() {
-	// 		driver.AllocateMemory(context, 400*mem.MB)
+	// 		driver.MustAllocateMemory(context, 400*mem.MB)
 	// 	})
 	// }, 10)
 })
@@ -17,13 +17,22 @@ type Builder struct {
 	useMagicMemoryCopy  bool
 	middlewareD2HCycles int
 	middlewareH2DCycles int
+
+	continueOnMemoryFault bool
+	useSanitizer          bool
+	useContextSaveArea    bool
+
+	wgDistributionPolicy    WGDistributionPolicy
+	wgDistributionChunkSize int
 }
 
 // MakeBuilder creates a driver builder with some default configuration
 // parameters.
 func MakeBuilder() Builder {
 	return Builder{
-		freq: 1 * sim.GHz,
+		freq:                    1 * sim.GHz,
+		wgDistributionPolicy:    WGDistributionStatic,
+		wgDistributionChunkSize: defaultWGDistributionChunkSize,
 	}
 }
 
@@ -74,6 +83,48 @@ func (b Builder) WithH2DCycles(h2dCycles int) Builder {
 	return b
 }
 
+// WithContinueOnMemoryFault lets the simulation keep running after a kernel
+// accesses an unmapped address. The faults are still reported.
+func (b Builder) WithContinueOnMemoryFault() Builder {
+	b.continueOnMemoryFault = true
+	return b
+}
+
+// WithSanitizer makes the driver track the boundaries of the allocations and
+// the freed ranges, so that the GPUs can check the addresses that the kernels
+// access with CheckAccess.
+func (b Builder) WithSanitizer() Builder {
+	b.useSanitizer = true
+	return b
+}
+
+// WithContextSaveArea makes the driver allocate a context save area for each
+// kernel, so that the GPUs can preempt the running work-groups of the kernel
+// by saving their contexts.
+func (b Builder) WithContextSaveArea() Builder {
+	b.useContextSaveArea = true
+	return b
+}
+
+// WithWGDistributionPolicy sets how the work-groups of the kernels launched on
+// unified GPUs are split among the GPUs.
+func (b Builder) WithWGDistributionPolicy(policy WGDistributionPolicy) Builder {
+	mustBeKnownWGDistributionPolicy(policy)
+	b.wgDistributionPolicy = policy
+	return b
+}
+
+// WithWGDistributionChunkSize sets the number of consecutive work-groups that
+// the interleaved and the work-stealing policies hand out at a time.
+func (b Builder) WithWGDistributionChunkSize(n int) Builder {
+	if n <= 0 {
+		panic("work-group distribution chunk size must be positive")
+	}
+
+	b.wgDistributionChunkSize = n
+	return b
+}
+
 // Build creates a driver.
 func (b Builder) Build(name string) *Driver {
 	driver := new(Driver)
@@ -91,6 +142,14 @@ func (b Builder) Build(name string) *Driver {
 
 	driver.pageTable = b.pageTable
 	driver.globalStorage = b.globalStorage
+	driver.continueOnMemoryFault = b.continueOnMemoryFault
+	driver.useContextSaveArea = b.useContextSaveArea
+	driver.wgDistributionPolicy = b.wgDistributionPolicy
+	driver.wgDistributionChunkSize = b.wgDistributionChunkSize
+
+	if b.useSanitizer {
+		driver.sanitizer = newSanitizer()
+	}
 
 	if b.useMagicMemoryCopy {
 		globalStorageMemoryCopyMiddleware := &globalStorageMemoryCopyMiddleware{
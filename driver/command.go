@@ -1,6 +1,8 @@
 package driver
 
 import (
+	"sync"
+
 	"github.com/sarchlab/akita/v3/sim"
 	"github.com/sarchlab/mgpusim/v3/insts"
 	"github.com/sarchlab/mgpusim/v3/kernels"
@@ -86,6 +88,11 @@ type LaunchKernelCommand struct {
 	Packet     *kernels.HsaKernelDispatchPacket
 	DPacket    Ptr
 	Reqs       []sim.Msg
+
+	// DContextSaveArea is where the GPU saves the contexts of the preempted
+	// work-groups. It is 0 if the kernel cannot be preempted with context
+	// switches.
+	DContextSaveArea Ptr
 }
 
 // GetID returns the ID of the command
@@ -163,6 +170,59 @@ func (c *NoopCommand) RemoveReq(req sim.Msg) {
 	// no action
 }
 
+// An EventCommand is a command that records the time when all the commands
+// enqueued before it in the same queue have completed.
+type EventCommand struct {
+	ID string
+
+	mutex     sync.Mutex
+	completed bool
+	time      sim.VTimeInSec
+}
+
+// GetID returns the ID of the command
+func (c *EventCommand) GetID() string {
+	return c.ID
+}
+
+// GetReqs returns the request associated with the command
+func (c *EventCommand) GetReqs() []sim.Msg {
+	return nil
+}
+
+// AddReq adds a request to the request list associated with the command
+func (c *EventCommand) AddReq(req sim.Msg) {
+	// No action
+}
+
+// RemoveReq removes a request from the request list associated with the
+// command.
+func (c *EventCommand) RemoveReq(req sim.Msg) {
+	// no action
+}
+
+// IsCompleted returns true if the event has been reached.
+func (c *EventCommand) IsCompleted() bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.completed
+}
+
+// Time returns the simulation time when the event was reached. The time is
+// only valid after the event has completed.
+func (c *EventCommand) Time() sim.VTimeInSec {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.time
+}
+
+func (c *EventCommand) complete(now sim.VTimeInSec) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.completed = true
+	c.time = now
+}
+
 func removeMsgFromMsgList(msg sim.Msg, msgs []sim.Msg) []sim.Msg {
 	for i, m := range msgs {
 		if m == msg {
@@ -184,6 +244,8 @@ type LaunchUnifiedMultiGPUKernelCommand struct {
 	PacketArray  []*kernels.HsaKernelDispatchPacket
 	DPacketArray []Ptr
 	Reqs         []sim.Msg
+
+	DContextSaveAreaArray []Ptr
 }
 
 // GetID returns the ID of the command
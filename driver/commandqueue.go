@@ -14,6 +14,16 @@ type CommandQueue struct {
 	PID       vm.PID
 	Context   *Context
 
+	// Priority decides which kernel gets the CUs first when the kernels from
+	// several queues run on the same GPU. A higher value means a higher
+	// priority.
+	Priority int
+
+	// CUMask limits the CUs that the kernels from the queue can run on, in
+	// the same format as HSA CU masks. Bit i of word i/32 enables the i-th
+	// CU of the GPU. An empty mask enables all the CUs.
+	CUMask []uint32
+
 	commandsMutex sync.Mutex
 	commands      []Command
 
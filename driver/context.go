@@ -24,10 +24,64 @@ type Context struct {
 	prevPageVAddr uint64
 	l2Dirty       bool
 
-	queueMutex sync.Mutex
-	queues     []*CommandQueue
+	queueMutex    sync.Mutex
+	queues        []*CommandQueue
+	queuePriority int
+	queueCUMask   []uint32
 
-	buffers []*buffer
+	buffers       []*buffer
+	distributions []*distribution
+}
+
+// distribution records how Distribute placed a buffer on the GPUs. Each GPU
+// holds a contiguous part of the buffer, in the order of the GPU IDs.
+type distribution struct {
+	addr           Ptr
+	byteSize       uint64
+	gpuIDs         []int
+	bytesOnEachGPU []uint64
+}
+
+// gpuIDAt returns the ID of the GPU that holds the given address.
+func (d *distribution) gpuIDAt(addr Ptr) (int, bool) {
+	if addr < d.addr || uint64(addr-d.addr) >= d.byteSize {
+		return 0, false
+	}
+
+	offset := uint64(addr - d.addr)
+	for i, bytes := range d.bytesOnEachGPU {
+		if offset < bytes {
+			return d.gpuIDs[i], true
+		}
+
+		offset -= bytes
+	}
+
+	return 0, false
+}
+
+func (c *Context) recordDistribution(dist *distribution) {
+	c.removeDistribution(dist.addr)
+	c.distributions = append(c.distributions, dist)
+}
+
+func (c *Context) removeDistribution(addr Ptr) {
+	for i, d := range c.distributions {
+		if d.addr == addr {
+			c.distributions = append(c.distributions[:i], c.distributions[i+1:]...)
+			return
+		}
+	}
+}
+
+func (c *Context) distributionOf(addr Ptr) *distribution {
+	for _, d := range c.distributions {
+		if _, ok := d.gpuIDAt(addr); ok {
+			return d
+		}
+	}
+
+	return nil
 }
 
 func (c *Context) markAllBuffersDirty() {
@@ -43,9 +97,12 @@ func (c *Context) markAllBuffersClean() {
 }
 
 func (c *Context) removeFreedBuffers() {
-	for i, b := range c.buffers {
-		if b.freed {
-			c.buffers = append(c.buffers[:i], c.buffers[i+1:]...)
+	buffers := c.buffers[:0]
+	for _, b := range c.buffers {
+		if !b.freed {
+			buffers = append(buffers, b)
 		}
 	}
+
+	c.buffers = buffers
 }
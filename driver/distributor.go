@@ -10,7 +10,7 @@ type distributor interface {
 		ctx *Context,
 		addr, byteSize uint64,
 		gpuIDs []int,
-	) (byteAllocatedOnEachGPU []uint64)
+	) (byteAllocatedOnEachGPU []uint64, err error)
 }
 
 type distributorImpl struct {
@@ -28,7 +28,7 @@ func (d *distributorImpl) Distribute(
 	ctx *Context,
 	addr, byteSize uint64,
 	gpuIDs []int,
-) (byteAllocatedOnEachGPU []uint64) {
+) (byteAllocatedOnEachGPU []uint64, err error) {
 	pageSize := uint64(1 << d.pageSizeAsPowerOf2)
 	if addr%pageSize != 0 {
 		panic("Address much align with pages")
@@ -50,25 +50,33 @@ func (d *distributorImpl) Distribute(
 	var i uint64
 	var lastAllocatedGPU uint64
 	for i = 0; i < numGPUsToUse; i++ {
-		d.memAllocator.Remap(
+		err = d.memAllocator.Remap(
 			ctx.pid,
 			addr+i*numPagesPerGPU*pageSize,
 			numPagesPerGPU*pageSize,
 			gpuIDs[i],
 		)
+		if err != nil {
+			return nil, err
+		}
+
 		byteAllocatedOnEachGPU[i] += numPagesPerGPU * pageSize
 		lastAllocatedGPU = i
 	}
 
 	for i := uint64(0); i < remainingPages; i++ {
-		d.memAllocator.Remap(
+		err = d.memAllocator.Remap(
 			ctx.pid,
 			addr+(numPagesPerGPU*numGPUsToUse+i)*pageSize,
 			pageSize,
 			gpuIDs[lastAllocatedGPU],
 		)
+		if err != nil {
+			return nil, err
+		}
+
 		byteAllocatedOnEachGPU[lastAllocatedGPU] += pageSize
 	}
 
-	return byteAllocatedOnEachGPU
+	return byteAllocatedOnEachGPU, nil
 }
@@ -30,8 +30,9 @@ var _ = ginkgo.Describe("Distributor", func() {
 	ginkgo.It("should distribute memory less than a page", func() {
 		memAllocator.EXPECT().
 			Remap(vm.PID(1), uint64(0x100000000), uint64(4096), 1)
-		bytes := dist.Distribute(ctx, 0x100000000, 1024, []int{1, 2, 3})
+		bytes, err := dist.Distribute(ctx, 0x100000000, 1024, []int{1, 2, 3})
 
+		Expect(err).NotTo(HaveOccurred())
 		Expect(bytes).To(Equal([]uint64{4096, 0, 0}))
 	})
 
@@ -47,8 +48,22 @@ var _ = ginkgo.Describe("Distributor", func() {
 		memAllocator.EXPECT().
 			Remap(vm.PID(1), uint64(0x100004000), uint64(0x1000), 3)
 
-		bytes := dist.Distribute(ctx, 0x100000000, 0x4020, []int{1, 2, 3})
+		bytes, err := dist.Distribute(ctx, 0x100000000, 0x4020, []int{1, 2, 3})
 
+		Expect(err).NotTo(HaveOccurred())
 		Expect(bytes).To(Equal([]uint64{4096, 4096, 12288}))
 	})
+
+	ginkgo.It("should return an error if a GPU runs out of memory", func() {
+		memAllocator.EXPECT().
+			Remap(vm.PID(1), uint64(0x100000000), uint64(0x1000), 1)
+		memAllocator.EXPECT().
+			Remap(vm.PID(1), uint64(0x100001000), uint64(0x1000), 2).
+			Return(ErrOutOfMemory)
+
+		bytes, err := dist.Distribute(ctx, 0x100000000, 0x3000, []int{1, 2, 3})
+
+		Expect(err).To(MatchError(ErrOutOfMemory))
+		Expect(bytes).To(BeNil())
+	})
 })
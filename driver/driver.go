@@ -59,6 +59,15 @@ type Driver struct {
 	isCurrentlyMigratingOnePage     bool
 
 	RemotePMCPorts []sim.Port
+
+	continueOnMemoryFault bool
+	memoryFaultMutex      sync.Mutex
+	memoryFaults          []error
+	sanitizer             *sanitizer
+	useContextSaveArea    bool
+
+	wgDistributionPolicy    WGDistributionPolicy
+	wgDistributionChunkSize int
 }
 
 // Run starts a new threads that handles all commands in the command queues
@@ -280,6 +289,9 @@ func (d *Driver) processOneCommand(
 	case *NoopCommand:
 		d.logCmdStart(cmd, now)
 		return d.processNoopCommand(now, cmd, cmdQueue)
+	case *EventCommand:
+		d.logCmdStart(cmd, now)
+		return d.processEventCommand(now, cmd, cmdQueue)
 	case *LaunchUnifiedMultiGPUKernelCommand:
 		d.logCmdStart(cmd, now)
 		return d.processUnifiedMultiGPULaunchKernelCommand(now, cmd, cmdQueue)
@@ -329,6 +341,17 @@ func (d *Driver) processNoopCommand(
 	return true
 }
 
+func (d *Driver) processEventCommand(
+	now sim.VTimeInSec,
+	cmd *EventCommand,
+	queue *CommandQueue,
+) bool {
+	cmd.complete(now)
+	queue.Dequeue()
+	d.logCmdComplete(cmd, now)
+	return true
+}
+
 func (d *Driver) logTaskToGPUInitiate(
 	now sim.VTimeInSec,
 	cmd Command,
@@ -353,9 +376,16 @@ func (d *Driver) processLaunchKernelCommand(
 		d.gpuPort, d.GPUs[queue.GPUID-1])
 	req.PID = queue.Context.pid
 	req.HsaCo = cmd.CodeObject
+	req.Priority = queue.Priority
+	req.CUMask = queue.CUMask
 
 	req.Packet = cmd.Packet
 	req.PacketAddress = uint64(cmd.DPacket)
+	req.ContextSaveArea = uint64(cmd.DContextSaveArea)
+	if req.ContextSaveArea != 0 {
+		req.NumContextSaveSlots = d.numContextSaveSlots(
+			queue.GPUID, cmd.CodeObject, cmd.Packet)
+	}
 
 	queue.IsRunning = true
 	cmd.Reqs = append(cmd.Reqs, req)
@@ -375,39 +405,26 @@ func (d *Driver) processUnifiedMultiGPULaunchKernelCommand(
 	cmd *LaunchUnifiedMultiGPUKernelCommand,
 	queue *CommandQueue,
 ) bool {
-	wgDist := d.distributeWGToGPUs(queue, cmd)
+	wgDistributor := d.newWGDistributor(queue, cmd)
 
 	dev := d.devices[queue.GPUID]
 	for i, gpuID := range dev.UnifiedGPUIDs {
-		if wgDist[i+1]-wgDist[i] == 0 {
+		req := protocol.NewLaunchKernelReq(now, d.gpuPort, d.GPUs[gpuID-1])
+		if !wgDistributor.assign(req, i) {
 			continue
 		}
 
-		req := protocol.NewLaunchKernelReq(now, d.gpuPort, d.GPUs[gpuID-1])
 		req.PID = queue.Context.pid
 		req.HsaCo = cmd.CodeObject
+		req.Priority = queue.Priority
+		req.CUMask = queue.CUMask
 		req.Packet = cmd.PacketArray[i]
 		req.PacketAddress = uint64(cmd.DPacketArray[i])
-
-		currentGPUIndex := i
-		req.WGFilter = func(
-			pkt *kernels.HsaKernelDispatchPacket,
-			wg *kernels.WorkGroup,
-		) bool {
-			numWGX := (pkt.GridSizeX-1)/uint32(pkt.WorkgroupSizeX) + 1
-			numWGY := (pkt.GridSizeY-1)/uint32(pkt.WorkgroupSizeY) + 1
-
-			flattenedID :=
-				wg.IDZ*int(numWGX)*int(numWGY) +
-					wg.IDY*int(numWGX) +
-					wg.IDX
-
-			if flattenedID >= wgDist[currentGPUIndex] &&
-				flattenedID < wgDist[currentGPUIndex+1] {
-				return true
-			}
-
-			return false
+		if cmd.DContextSaveAreaArray != nil &&
+			cmd.DContextSaveAreaArray[i] != 0 {
+			req.ContextSaveArea = uint64(cmd.DContextSaveAreaArray[i])
+			req.NumContextSaveSlots = d.numContextSaveSlots(
+				gpuID, cmd.CodeObject, cmd.PacketArray[i])
 		}
 
 		queue.IsRunning = true
@@ -424,38 +441,106 @@ func (d *Driver) processUnifiedMultiGPULaunchKernelCommand(
 	return true
 }
 
-func (d *Driver) distributeWGToGPUs(
+func (d *Driver) newWGDistributor(
 	queue *CommandQueue,
 	cmd *LaunchUnifiedMultiGPUKernelCommand,
-) []int {
-	dev := d.devices[queue.GPUID]
-	actualGPUs := dev.UnifiedGPUIDs
-	wgAllocated := 0
-	wgDist := make([]int, len(actualGPUs)+1)
+) wgDistributor {
+	gpuIDs := d.devices[queue.GPUID].UnifiedGPUIDs
+	numWGX, numWGY, numWGZ := numWGInPacket(cmd.PacketArray[0])
+	numWG := numWGX * numWGY * numWGZ
+
+	switch d.wgDistributionPolicy {
+	case WGDistributionInterleaved:
+		owners := interleavedWGOwners(numWG, len(gpuIDs), d.wgDistributionChunkSize)
+		return newOwnerWGDistributor(owners, len(gpuIDs))
+	case WGDistributionWorkStealing:
+		return &poolWGDistributor{
+			pool: kernels.NewWGPool(numWG, d.wgDistributionChunkSize),
+		}
+	case WGDistributionPageOwnership:
+		owners := pageWGOwners(
+			d.staticWGOwners(gpuIDs, numWG),
+			len(gpuIDs),
+			d.pageOwnershipVotes(queue.Context, gpuIDs, cmd.KernelArgs, numWG),
+		)
+		return newOwnerWGDistributor(owners, len(gpuIDs))
+	default:
+		owners := d.staticWGOwners(gpuIDs, numWG)
+		return newOwnerWGDistributor(owners, len(gpuIDs))
+	}
+}
+
+func (d *Driver) staticWGOwners(gpuIDs []int, numWG int) []int {
+	cuCounts := make([]int, len(gpuIDs))
+	for i, gpuID := range gpuIDs {
+		cuCounts[i] = d.devices[gpuID].Properties.CUCount
+	}
+
+	return staticWGOwners(numWG, cuCounts)
+}
+
+// pageOwnershipVotes finds the distributed buffers that the kernel arguments
+// point to. A work-group votes for the GPUs that hold its part of each of
+// these buffers.
+func (d *Driver) pageOwnershipVotes(
+	ctx *Context,
+	gpuIDs []int,
+	kernelArgs interface{},
+	numWG int,
+) func(wgID int, count []int) {
+	gpuIndex := make(map[int]int)
+	for i, gpuID := range gpuIDs {
+		gpuIndex[gpuID] = i
+	}
+
+	var dists []*distribution
+	for _, ptr := range pointerKernelArgs(kernelArgs) {
+		if dist := ctx.distributionOf(ptr); dist != nil {
+			dists = append(dists, dist)
+		}
+	}
+
+	return func(wgID int, count []int) {
+		for _, dist := range dists {
+			offset := (2*uint64(wgID) + 1) * dist.byteSize / (2 * uint64(numWG))
+
+			gpuID, ok := dist.gpuIDAt(dist.addr + Ptr(offset))
+			if !ok {
+				continue
+			}
+
+			if i, ok := gpuIndex[gpuID]; ok {
+				count[i]++
+			}
+		}
+	}
+}
 
-	totalCUCount := 0
-	for _, devID := range actualGPUs {
-		totalCUCount += d.devices[devID].Properties.CUCount
+// pointerKernelArgs returns the GPU pointers among the fields of the kernel
+// argument struct. Serialized arguments do not tell which fields are
+// pointers, so no pointer is returned for them.
+func pointerKernelArgs(kernelArgs interface{}) []Ptr {
+	if kernelArgs == nil {
+		return nil
 	}
 
-	numWGX := (cmd.PacketArray[0].GridSizeX-1)/uint32(cmd.PacketArray[0].WorkgroupSizeX) + 1
-	numWGY := (cmd.PacketArray[0].GridSizeY-1)/uint32(cmd.PacketArray[0].WorkgroupSizeY) + 1
-	numWGZ := (cmd.PacketArray[0].GridSizeZ-1)/uint32(cmd.PacketArray[0].WorkgroupSizeZ) + 1
-	totalWGCount := int(numWGX * numWGY * numWGZ)
-	wgPerCU := (totalWGCount-1)/totalCUCount + 1
+	v := reflect.ValueOf(kernelArgs)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
 
-	for i, devID := range actualGPUs {
-		cuCount := d.devices[devID].Properties.CUCount
-		wgToAllocate := cuCount * wgPerCU
-		wgDist[i+1] = wgAllocated + wgToAllocate
-		wgAllocated += wgToAllocate
+	if v.Kind() != reflect.Struct {
+		return nil
 	}
 
-	if wgAllocated < totalWGCount {
-		panic("not all wg allocated")
+	var ptrs []Ptr
+	for i := 0; i < v.NumField(); i++ {
+		if ptr, ok := v.Field(i).Interface().(Ptr); ok {
+			ptrs = append(ptrs, ptr)
+		}
 	}
 
-	return wgDist
+	return ptrs
 }
 
 func (d *Driver) processLaunchKernelReturn(
@@ -471,12 +556,36 @@ func (d *Driver) processLaunchKernelReturn(
 		cmdQueue.IsRunning = false
 		cmdQueue.Dequeue()
 
+		d.freeContextSaveArea(cmdQueue.Context, cmd)
 		d.logCmdComplete(cmd, now)
 	}
 
 	return true
 }
 
+// freeContextSaveArea releases the context save areas of a completed kernel.
+// No work-group can be preempted once all the work-groups have completed.
+func (d *Driver) freeContextSaveArea(ctx *Context, cmd Command) {
+	var saveAreas []Ptr
+	switch cmd := cmd.(type) {
+	case *LaunchKernelCommand:
+		saveAreas = []Ptr{cmd.DContextSaveArea}
+	case *LaunchUnifiedMultiGPUKernelCommand:
+		saveAreas = cmd.DContextSaveAreaArray
+	}
+
+	for _, saveArea := range saveAreas {
+		if saveArea == 0 {
+			continue
+		}
+
+		err := d.FreeMemory(ctx, saveArea)
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
 func (d *Driver) findCommandByReq(req sim.Msg) (Command, *CommandQueue) {
 	d.contextMutex.Lock()
 	defer d.contextMutex.Unlock()
@@ -697,8 +806,12 @@ func (d *Driver) preparePageForMigration(
 	}
 	oldPAddr := page.PAddr
 
-	newPage := d.memAllocator.AllocatePageWithGivenVAddr(
+	newPage, err := d.memAllocator.AllocatePageWithGivenVAddr(
 		context.pid, int(gpuID+1), vAddr, true)
+	if err != nil {
+		panic(err)
+	}
+
 	newPage.DeviceID = gpuID + 1
 
 	newPage.IsMigrating = true
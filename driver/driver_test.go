@@ -7,6 +7,8 @@ import (
 	"github.com/sarchlab/akita/v3/mem/mem"
 	"github.com/sarchlab/akita/v3/mem/vm"
 	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/insts"
+	"github.com/sarchlab/mgpusim/v3/kernels"
 	"github.com/sarchlab/mgpusim/v3/protocol"
 )
 
@@ -353,6 +355,59 @@ var _ = ginkgo.Describe("Driver", func() {
 			Expect(req.PID).To(Equal(vm.PID(1)))
 			Expect(driver.requestsToSend).To(HaveLen(1))
 		})
+
+		ginkgo.It("should carry the priority and CU mask of the queue", func() {
+			driver.SetQueuePriority(context, 2)
+			driver.SetQueueCUMask(context, []uint32{0xf0})
+
+			cmd := &LaunchKernelCommand{
+				GridSize: [3]uint32{256, 1, 1},
+				WGSize:   [3]uint16{64, 1, 1},
+			}
+			cmdQueue.Enqueue(cmd)
+			cmdQueue.IsRunning = false
+
+			toGPUs.EXPECT().Peek().Return(nil).AnyTimes()
+			toMMU.EXPECT().Retrieve(sim.VTimeInSec(11)).Return(nil)
+			engine.EXPECT().Schedule(
+				gomock.AssignableToTypeOf(sim.TickEvent{}))
+
+			driver.Handle(sim.MakeTickEvent(11, nil))
+
+			req := cmd.Reqs[0].(*protocol.LaunchKernelReq)
+			Expect(req.Priority).To(Equal(2))
+			Expect(req.CUMask).To(Equal([]uint32{0xf0}))
+		})
+
+		ginkgo.It("should carry the context save area of the kernel", func() {
+			cmd := &LaunchKernelCommand{
+				CodeObject: insts.NewHsaCo(),
+				Packet: &kernels.HsaKernelDispatchPacket{
+					GridSizeX:      256,
+					GridSizeY:      1,
+					GridSizeZ:      1,
+					WorkgroupSizeX: 64,
+					WorkgroupSizeY: 1,
+					WorkgroupSizeZ: 1,
+				},
+				GridSize:         [3]uint32{256, 1, 1},
+				WGSize:           [3]uint16{64, 1, 1},
+				DContextSaveArea: 0x100000,
+			}
+			cmdQueue.Enqueue(cmd)
+			cmdQueue.IsRunning = false
+
+			toGPUs.EXPECT().Peek().Return(nil).AnyTimes()
+			toMMU.EXPECT().Retrieve(sim.VTimeInSec(11)).Return(nil)
+			engine.EXPECT().Schedule(
+				gomock.AssignableToTypeOf(sim.TickEvent{}))
+
+			driver.Handle(sim.MakeTickEvent(11, nil))
+
+			req := cmd.Reqs[0].(*protocol.LaunchKernelReq)
+			Expect(req.ContextSaveArea).To(Equal(uint64(0x100000)))
+			Expect(req.NumContextSaveSlots).To(Equal(4))
+		})
 	})
 
 	ginkgo.It("should process LaunchKernel return", func() {
@@ -379,6 +434,134 @@ var _ = ginkgo.Describe("Driver", func() {
 		Expect(cmdQueue.commands).To(HaveLen(0))
 	})
 
+	ginkgo.Context("process LaunchUnifiedMultiGPUKernelCommand", func() {
+		var (
+			cmd *LaunchUnifiedMultiGPUKernelCommand
+		)
+
+		ginkgo.BeforeEach(func() {
+			cmdQueue.GPUID = driver.CreateUnifiedGPU(context, []int{1, 2})
+
+			cmd = &LaunchUnifiedMultiGPUKernelCommand{}
+			for i := 0; i < 2; i++ {
+				cmd.PacketArray = append(cmd.PacketArray,
+					&kernels.HsaKernelDispatchPacket{
+						GridSizeX:      512,
+						GridSizeY:      1,
+						GridSizeZ:      1,
+						WorkgroupSizeX: 64,
+						WorkgroupSizeY: 1,
+						WorkgroupSizeZ: 1,
+					})
+				cmd.DPacketArray = append(cmd.DPacketArray, Ptr(0x1000*i))
+			}
+			cmdQueue.Enqueue(cmd)
+		})
+
+		wgIDsOf := func(req *protocol.LaunchKernelReq) []int {
+			var wgIDs []int
+			for i := 0; i < 8; i++ {
+				wg := &kernels.WorkGroup{IDX: i}
+				if req.WGFilter(req.Packet, wg) {
+					wgIDs = append(wgIDs, i)
+				}
+			}
+
+			return wgIDs
+		}
+
+		ginkgo.It("should split the work-groups statically", func() {
+			driver.processUnifiedMultiGPULaunchKernelCommand(10, cmd, cmdQueue)
+
+			Expect(cmdQueue.IsRunning).To(BeTrue())
+			Expect(cmd.Reqs).To(HaveLen(2))
+			req0 := cmd.Reqs[0].(*protocol.LaunchKernelReq)
+			req1 := cmd.Reqs[1].(*protocol.LaunchKernelReq)
+			Expect(req0.Dst).To(Equal(driver.GPUs[0]))
+			Expect(req1.Dst).To(Equal(driver.GPUs[1]))
+			Expect(wgIDsOf(req0)).To(Equal([]int{0, 1, 2, 3}))
+			Expect(wgIDsOf(req1)).To(Equal([]int{4, 5, 6, 7}))
+		})
+
+		ginkgo.It("should let the GPUs share a pool of work-groups", func() {
+			driver.wgDistributionPolicy = WGDistributionWorkStealing
+			driver.wgDistributionChunkSize = 3
+
+			driver.processUnifiedMultiGPULaunchKernelCommand(10, cmd, cmdQueue)
+
+			Expect(cmd.Reqs).To(HaveLen(2))
+			req0 := cmd.Reqs[0].(*protocol.LaunchKernelReq)
+			req1 := cmd.Reqs[1].(*protocol.LaunchKernelReq)
+			Expect(req0.Dst).To(Equal(driver.GPUs[0]))
+			Expect(req1.Dst).To(Equal(driver.GPUs[1]))
+			Expect(req0.WGFilter).To(BeNil())
+			Expect(req0.WGPool).NotTo(BeNil())
+			Expect(req1.WGPool).To(BeIdenticalTo(req0.WGPool))
+
+			var chunks []kernels.WGRange
+			for {
+				wgs, ok := req0.WGPool.Claim()
+				if !ok {
+					break
+				}
+				chunks = append(chunks, wgs)
+			}
+			Expect(chunks).To(Equal([]kernels.WGRange{
+				{Start: 0, End: 3}, {Start: 3, End: 6}, {Start: 6, End: 8},
+			}))
+
+			for _, req := range []*protocol.LaunchKernelReq{req0, req1} {
+				rsp := protocol.NewLaunchKernelRsp(11, nil, nil, req.ID)
+				driver.processLaunchKernelReturn(11, rsp)
+			}
+
+			Expect(cmdQueue.IsRunning).To(BeFalse())
+			Expect(cmdQueue.commands).To(HaveLen(0))
+		})
+
+		ginkgo.It("should place work-groups on the GPUs that own the pages", func() {
+			memAllocator.EXPECT().Remap(gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any()).AnyTimes()
+			dist := newDistributorImpl(memAllocator)
+			dist.pageSizeAsPowerOf2 = log2PageSize
+			driver.distributor = dist
+			driver.wgDistributionPolicy = WGDistributionPageOwnership
+			driver.Distribute(context, Ptr(0x100000000), 0x4000, []int{2, 1})
+			cmd.KernelArgs = &struct {
+				Input Ptr
+				N     int32
+			}{Input: 0x100000000, N: 8}
+
+			driver.processUnifiedMultiGPULaunchKernelCommand(10, cmd, cmdQueue)
+
+			Expect(cmd.Reqs).To(HaveLen(2))
+			req0 := cmd.Reqs[0].(*protocol.LaunchKernelReq)
+			req1 := cmd.Reqs[1].(*protocol.LaunchKernelReq)
+			Expect(req0.Dst).To(Equal(driver.GPUs[0]))
+			Expect(wgIDsOf(req0)).To(Equal([]int{4, 5, 6, 7}))
+			Expect(wgIDsOf(req1)).To(Equal([]int{0, 1, 2, 3}))
+		})
+	})
+
+	ginkgo.It("should free the context save area when the kernel completes",
+		func() {
+			req := protocol.NewLaunchKernelReq(7, toGPUs, nil)
+			cmd := &LaunchKernelCommand{
+				Reqs:             []sim.Msg{req},
+				DContextSaveArea: 0x100000,
+			}
+			cmdQueue.Enqueue(cmd)
+			cmdQueue.IsRunning = true
+			rsp := protocol.NewLaunchKernelRsp(9, nil, nil, req.ID)
+
+			memAllocator.EXPECT().Free(vm.PID(1), uint64(0x100000))
+
+			driver.processLaunchKernelReturn(11, rsp)
+
+			Expect(cmdQueue.IsRunning).To(BeFalse())
+			Expect(cmdQueue.commands).To(HaveLen(0))
+		})
+
 	ginkgo.It("should handle page migration req from MMU ", func() {
 		req := vm.NewPageMigrationReqToDriver(10, nil, driver.mmuPort)
 		toMMU.EXPECT().Retrieve(sim.VTimeInSec(10)).Return(req)
@@ -476,7 +659,7 @@ var _ = ginkgo.Describe("Driver", func() {
 		})
 		memAllocator.EXPECT().
 			AllocatePageWithGivenVAddr(vm.PID(0), 2, uint64(0x100), true).
-			Return(*page2)
+			Return(*page2, nil)
 
 		toGPUs.EXPECT().Peek().Return(req)
 		toGPUs.EXPECT().Retrieve(sim.VTimeInSec(10)).Return(req)
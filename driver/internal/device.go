@@ -1,5 +1,11 @@
 package internal
 
+import "errors"
+
+// ErrOutOfMemory is returned when a device does not have enough free pages to
+// serve an allocation.
+var ErrOutOfMemory = errors.New("hipErrorOutOfMemory: out of device memory")
+
 // DeviceType marks the type of a device.
 type DeviceType int
 
@@ -45,35 +51,73 @@ func (d *Device) SetTotalMemSize(size uint64) {
 	d.MemState.setStorageSize(size)
 }
 
-func (d *Device) allocatePage() (pAddr uint64) {
+// allocatePage allocates a single page. It returns ErrOutOfMemory if the
+// device does not have a free page.
+func (d *Device) allocatePage() (pAddr uint64, err error) {
 	if d.Type == DeviceTypeUnifiedGPU {
 		return d.allocateUnifiedGPUPage()
 	}
 
-	d.mustHaveSpaceLeft()
+	if d.MemState.noAvailablePAddrs() {
+		return 0, ErrOutOfMemory
+	}
+
 	pAddr = d.MemState.popNextAvailablePAddrs()
 
-	return pAddr
+	return pAddr, nil
 }
 
-func (d *Device) allocateMultiplePages(numPages int) (pAddrs []uint64) {
+// allocateMultiplePages allocates numPages pages on one GPU. It returns
+// ErrOutOfMemory if the GPU cannot serve all the pages, in which case nothing
+// is allocated.
+func (d *Device) allocateMultiplePages(numPages int) ([]uint64, error) {
 	if d.Type == DeviceTypeUnifiedGPU {
 		return d.allocateMultipleUnifiedGPUPages(numPages)
 	}
 
-	d.mustHaveSpaceLeft()
-	pAddrs = d.MemState.allocateMultiplePages(numPages)
+	return d.MemState.allocateMultiplePages(numPages)
+}
+
+// allocatePages allocates the pages of an allocation. The pages of a unified
+// GPU are interleaved across the GPUs. It returns ErrOutOfMemory if the
+// device cannot serve all the pages, in which case nothing is allocated.
+func (d *Device) allocatePages(numPages int) ([]uint64, error) {
+	if d.Type != DeviceTypeUnifiedGPU {
+		return d.allocateMultiplePages(numPages)
+	}
+
+	if d.numAvailablePages() < uint64(numPages) {
+		return nil, ErrOutOfMemory
+	}
+
+	pAddrs := make([]uint64, 0, numPages)
+	for i := 0; i < numPages; i++ {
+		pAddr, err := d.allocateUnifiedGPUPage()
+		if err != nil {
+			return nil, err
+		}
+
+		pAddrs = append(pAddrs, pAddr)
+	}
 
-	return pAddrs
+	return pAddrs, nil
 }
 
-func (d *Device) mustHaveSpaceLeft() {
-	if d.MemState.noAvailablePAddrs() {
-		panic("out of memory")
+// numAvailablePages returns the number of pages that can still be allocated
+// on the device.
+func (d *Device) numAvailablePages() uint64 {
+	if d.Type == DeviceTypeUnifiedGPU {
+		n := uint64(0)
+		for _, gpu := range d.ActualGPUs {
+			n += gpu.MemState.numAvailablePages()
+		}
+		return n
 	}
+
+	return d.MemState.numAvailablePages()
 }
 
-func (d *Device) allocateUnifiedGPUPage() (pAddr uint64) {
+func (d *Device) allocateUnifiedGPUPage() (pAddr uint64, err error) {
 	var devSelected *Device
 
 	devSelected = nil
@@ -89,17 +133,27 @@ func (d *Device) allocateUnifiedGPUPage() (pAddr uint64) {
 	}
 
 	if devSelected == nil {
-		panic("out of memory")
+		return 0, ErrOutOfMemory
+	}
+
+	pAddr, err = devSelected.allocatePage()
+	if err != nil {
+		return 0, err
 	}
 
-	pAddr = devSelected.allocatePage()
 	d.nextActualGPUIndex = (d.nextActualGPUIndex + 1) % len(d.ActualGPUs)
-	return pAddr
+	return pAddr, nil
 }
 
-func (d *Device) allocateMultipleUnifiedGPUPages(numPages int) (pAddrs []uint64) {
+func (d *Device) allocateMultipleUnifiedGPUPages(
+	numPages int,
+) ([]uint64, error) {
 	dev := d.ActualGPUs[d.nextActualGPUIndex]
-	pAddrs = dev.allocateMultiplePages(numPages)
+	pAddrs, err := dev.allocateMultiplePages(numPages)
+	if err != nil {
+		return nil, err
+	}
+
 	d.nextActualGPUIndex = (d.nextActualGPUIndex + 1) % len(d.ActualGPUs)
-	return pAddrs
+	return pAddrs, nil
 }
@@ -71,7 +71,10 @@ func (bms *deviceBuddyMemoryState) addSinglePAddr(addr uint64) {
 }
 
 func (bms *deviceBuddyMemoryState) popNextAvailablePAddrs() uint64 {
-	addrs := bms.allocateMultiplePages(1)
+	addrs, err := bms.allocateMultiplePages(1)
+	if err != nil {
+		panic(err)
+	}
 	return  addrs[0]
 }
 
@@ -84,20 +87,36 @@ func (bms *deviceBuddyMemoryState) noAvailablePAddrs() bool {
 	return true
 }
 
+func (bms *deviceBuddyMemoryState) numAvailablePages() uint64 {
+	n := uint64(0)
+	for level := range bms.freeList {
+		pagesPerBlock := bms.sizeOfLevel(level) >> bms.log2PageSize
+		n += uint64(bms.freeList[level].Len()) * pagesPerBlock
+	}
+	return n
+}
+
+// allocateMultiplePages allocates numPages contiguous pages from the smallest
+// free block that can hold them. It returns ErrOutOfMemory if no free block is
+// large enough, even if the free blocks hold enough pages in total.
 func (bms *deviceBuddyMemoryState) allocateMultiplePages(
 	numPages int,
-) (pAddrs []uint64) {
+) (pAddrs []uint64, err error) {
 	freeListLen := len(bms.freeList) - 1
 
+	log2PageSize := int(bms.log2PageSize)
+	pageSize := uint64(1) << bms.log2PageSize
+
 	var order int
-	for order = 12; (1 << order) < (numPages * 4096); order++ {}
-	level := freeListLen - (order - 12)
+	for order = log2PageSize; (1 << order) < (numPages << log2PageSize); order++ {
+	}
+	level := freeListLen - (order - log2PageSize)
 
 	i := level
 
 	for {
 		if i < 0 {
-			panic("not enough memory available")
+			return nil, ErrOutOfMemory
 		}
 		if bms.freeList[i].Len() != 0 {
 			break
@@ -107,7 +126,9 @@ func (bms *deviceBuddyMemoryState) allocateMultiplePages(
 	e := bms.freeList[i].Front()
 	block := bms.freeList[i].Remove(e).(uint64)
 
-	if i == level && i > 0 {
+	// The block is no longer free, whether it is split or not, so the state of
+	// the block and its buddy changes.
+	if i > 0 {
 		bms.updateMergeListBitField(bms.indexOfBlock(block, i-1))
 	}
 
@@ -127,10 +148,10 @@ func (bms *deviceBuddyMemoryState) allocateMultiplePages(
 	for j := 0; j < numPages; j++ {
 		pAddrs = append(pAddrs, block)
 		bms.blockTracking[block] = bTracker
-		block += 4096
+		block += pageSize
 	}
 
-	return pAddrs
+	return pAddrs, nil
 }
 
 func (bms *deviceBuddyMemoryState) buddyOf(addr uint64, level int) (buddy uint64) {
@@ -115,7 +115,9 @@ var _ = Describe("Implementation of buddy allocation DeviceMemoryState", func()
 	})
 
 	It("should allocate multiple PAddrs", func() {
-		addrs := buddyDMS.allocateMultiplePages(3)
+		addrs, err := buddyDMS.allocateMultiplePages(3)
+
+		Expect(err).NotTo(HaveOccurred())
 
 		Expect(addrs).To(HaveLen(3))
 		Expect(addrs[0]).To(Equal(uint64(0x1_0000_1000)))
@@ -134,13 +136,33 @@ var _ = Describe("Implementation of buddy allocation DeviceMemoryState", func()
 	})
 
 	It("should allocate the whole space", func() {
-		addrs := buddyDMS.allocateMultiplePages(1048555)
+		addrs, err := buddyDMS.allocateMultiplePages(1048555)
+		Expect(err).NotTo(HaveOccurred())
 		Expect(addrs).To(HaveLen(1048555))
 
 		ok := buddyDMS.noAvailablePAddrs()
 		Expect(ok).To(BeTrue())
 	})
 
+	It("should run out of memory if the free pages are fragmented", func() {
+		bDMS := newDeviceBuddyMemoryState(12)
+		bDMS.setStorageSize(0x10000)
+		bDMS.setInitialAddress(0x1_0000_0000)
+
+		var addrs []uint64
+		for i := 0; i < 16; i++ {
+			addrs = append(addrs, bDMS.popNextAvailablePAddrs())
+		}
+		for i := 0; i < 16; i += 2 {
+			bDMS.addSinglePAddr(addrs[i])
+		}
+
+		_, err := bDMS.allocateMultiplePages(2)
+
+		Expect(err).To(MatchError(ErrOutOfMemory))
+		Expect(bDMS.numAvailablePages()).To(Equal(uint64(8)))
+	})
+
 	It("should find the proper buddy of a block", func() {
 		bDMS := buddyDMS.(*deviceBuddyMemoryState)
 		block := uint64(0x1_0000_1000)
@@ -227,7 +249,7 @@ var _ = Describe("Implementation of buddy allocation DeviceMemoryState", func()
 		level := bDMS.levelOfBlock(addr)
 		Expect(level).To(Equal(listLen - 1))
 
-		addrs := buddyDMS.allocateMultiplePages(2)
+		addrs, _ := buddyDMS.allocateMultiplePages(2)
 		level = bDMS.levelOfBlock(addrs[0])
 		Expect(level).To(Equal(listLen - 2))
 		level = bDMS.levelOfBlock(addrs[1])
@@ -304,6 +326,28 @@ var _ = Describe("Implementation of buddy allocation DeviceMemoryState", func()
 		}
 	})
 
+	It("should return the pages to the free list", func() {
+		bDMS := newDeviceBuddyMemoryState(12)
+		bDMS.setStorageSize(0x10_0000)
+		bDMS.setInitialAddress(0x1_0000_0000)
+
+		Expect(bDMS.numAvailablePages()).To(Equal(uint64(256)))
+
+		addrs, _ := bDMS.allocateMultiplePages(3)
+		addr := bDMS.popNextAvailablePAddrs()
+
+		// The 3 pages are served with a 4-page block.
+		Expect(bDMS.numAvailablePages()).To(Equal(uint64(251)))
+
+		for _, a := range addrs {
+			bDMS.addSinglePAddr(a)
+		}
+		bDMS.addSinglePAddr(addr)
+
+		Expect(bDMS.numAvailablePages()).To(Equal(uint64(256)))
+		Expect(bDMS.(*deviceBuddyMemoryState).freeList[0].Len()).To(Equal(1))
+	})
+
 	It("should have no available PAddrs", func() {
 		bDMS := buddyDMS.(*deviceBuddyMemoryState)
 		bDMS.freeList[0].Init()
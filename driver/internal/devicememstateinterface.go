@@ -9,7 +9,8 @@ type DeviceMemoryState interface {
 	addSinglePAddr(addr uint64)
 	popNextAvailablePAddrs() uint64
 	noAvailablePAddrs() bool
-	allocateMultiplePages(numPages int) []uint64
+	numAvailablePages() uint64
+	allocateMultiplePages(numPages int) ([]uint64, error)
 }
 
 // NewDeviceMemoryState creates a new device memory state based on allocator type.
@@ -74,12 +75,20 @@ func (dms *deviceMemoryStateImpl) noAvailablePAddrs() bool {
 	return len(dms.availablePAddrs) == 0
 }
 
+func (dms *deviceMemoryStateImpl) numAvailablePages() uint64 {
+	return uint64(len(dms.availablePAddrs))
+}
+
 func (dms *deviceMemoryStateImpl) allocateMultiplePages(
 	numPages int,
-) (pAddrs []uint64) {
+) (pAddrs []uint64, err error) {
+	if len(dms.availablePAddrs) < numPages {
+		return nil, ErrOutOfMemory
+	}
+
 	for i := 0; i < numPages; i++ {
 		pAddr := dms.popNextAvailablePAddrs()
 		pAddrs = append(pAddrs, pAddr)
 	}
-	return pAddrs
+	return pAddrs, nil
 }
\ No newline at end of file
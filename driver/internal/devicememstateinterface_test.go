@@ -49,7 +49,7 @@ var _ = Describe("Implementation of regular DeviceMemoryState", func() {
 		regularDMS.addSinglePAddr(0x0_0000_3000)
 		regularDMS.addSinglePAddr(0x0_0000_4000)
 
-		addrs := regularDMS.allocateMultiplePages(3)
+		addrs, _ := regularDMS.allocateMultiplePages(3)
 
 		Expect(addrs).To(HaveLen(3))
 		Expect(addrs[0]).To(Equal(uint64(0x0_0000_1000)))
@@ -11,17 +11,17 @@ import (
 type MemoryAllocator interface {
 	RegisterDevice(device *Device)
 	GetDeviceIDByPAddr(pAddr uint64) int
-	Allocate(pid vm.PID, byteSize uint64, deviceID int) uint64
-	AllocateUnified(pid vm.PID, byteSize uint64) uint64
-	Free(vAddr uint64)
-	Remap(pid vm.PID, pageVAddr, byteSize uint64, deviceID int)
-	RemovePage(vAddr uint64)
+	Allocate(pid vm.PID, byteSize uint64, deviceID int) (uint64, error)
+	AllocateUnified(pid vm.PID, byteSize uint64) (uint64, error)
+	Free(pid vm.PID, vAddr uint64)
+	Remap(pid vm.PID, pageVAddr, byteSize uint64, deviceID int) error
+	RemovePage(pid vm.PID, vAddr uint64)
 	AllocatePageWithGivenVAddr(
 		pid vm.PID,
 		deviceID int,
 		vAddr uint64,
 		unified bool,
-	) vm.Page
+	) (vm.Page, error)
 }
 
 // NewMemoryAllocator creates a new memory allocator.
@@ -34,12 +34,21 @@ func NewMemoryAllocator(
 		totalStorageByteSize: 1 << log2PageSize, // Starting with a page to avoid 0 address.
 		log2PageSize:         log2PageSize,
 		processMemoryStates:  make(map[vm.PID]*processMemoryState),
-		vAddrToPageMapping:   make(map[uint64]vm.Page),
+		vAddrToPageMapping:   make(map[pageKey]vm.Page),
+		allocationNumPages:   make(map[pageKey]int),
 		devices:              make(map[int]*Device),
 	}
 	return a
 }
 
+// A pageKey identifies a page by its virtual address. Each process has its own
+// virtual address space, so the same address can map to different pages in
+// different processes.
+type pageKey struct {
+	pid   vm.PID
+	vAddr uint64
+}
+
 type processMemoryState struct {
 	pid       vm.PID
 	nextVAddr uint64
@@ -51,7 +60,8 @@ type memoryAllocatorImpl struct {
 	sync.Mutex
 	pageTable            vm.PageTable
 	log2PageSize         uint64
-	vAddrToPageMapping   map[uint64]vm.Page
+	vAddrToPageMapping   map[pageKey]vm.Page
+	allocationNumPages   map[pageKey]int
 	processMemoryStates  map[vm.PID]*processMemoryState
 	devices              map[int]*Device
 	totalStorageByteSize uint64
@@ -99,7 +109,7 @@ func (a *memoryAllocatorImpl) Allocate(
 	pid vm.PID,
 	byteSize uint64,
 	deviceID int,
-) uint64 {
+) (uint64, error) {
 	if byteSize == 0 {
 		panic("Allocating 0 bytes.")
 	}
@@ -109,13 +119,14 @@ func (a *memoryAllocatorImpl) Allocate(
 
 	pageSize := uint64(1 << a.log2PageSize)
 	numPages := (byteSize-1)/pageSize + 1
+
 	return a.allocatePages(int(numPages), pid, deviceID, false)
 }
 
 func (a *memoryAllocatorImpl) AllocateUnified(
 	pid vm.PID,
 	byteSize uint64,
-) uint64 {
+) (uint64, error) {
 	if byteSize == 0 {
 		panic("Allocating 0 bytes.")
 	}
@@ -125,6 +136,7 @@ func (a *memoryAllocatorImpl) AllocateUnified(
 
 	pageSize := uint64(1 << a.log2PageSize)
 	numPages := (byteSize-1)/pageSize + 1
+
 	return a.allocatePages(int(numPages), pid, 1, true)
 }
 
@@ -133,7 +145,13 @@ func (a *memoryAllocatorImpl) allocatePages(
 	pid vm.PID,
 	deviceID int,
 	unified bool,
-) (firstPageVAddr uint64) {
+) (firstPageVAddr uint64, err error) {
+	device := a.devices[deviceID]
+	pAddrs, err := device.allocatePages(numPages)
+	if err != nil {
+		return 0, err
+	}
+
 	pState, found := a.processMemoryStates[pid]
 	if !found {
 		a.processMemoryStates[pid] = &processMemoryState{
@@ -142,13 +160,11 @@ func (a *memoryAllocatorImpl) allocatePages(
 		}
 		pState = a.processMemoryStates[pid]
 	}
-	device := a.devices[deviceID]
 
 	pageSize := uint64(1 << a.log2PageSize)
 	nextVAddr := pState.nextVAddr
 
-	for i := 0; i < numPages; i++ {
-		pAddr := device.allocatePage()
+	for i, pAddr := range pAddrs {
 		vAddr := nextVAddr + uint64(i)*pageSize
 
 		page := vm.Page{
@@ -164,19 +180,23 @@ func (a *memoryAllocatorImpl) allocatePages(
 		// fmt.Printf("page.addr is %x piage Device ID is %d \n", page.PAddr, page.DeviceID)
 		// debug.PrintStack()
 		a.pageTable.Insert(page)
-		a.vAddrToPageMapping[page.VAddr] = page
+		a.vAddrToPageMapping[pageKey{page.PID, page.VAddr}] = page
 	}
 
 	pState.nextVAddr += pageSize * uint64(numPages)
+	a.allocationNumPages[pageKey{pid, nextVAddr}] = numPages
 
-	return nextVAddr
+	return nextVAddr, nil
 }
 
+// Remap moves the pages that cover the given virtual address range to the
+// given device. It returns ErrOutOfMemory if the device cannot hold all the
+// pages, in which case the pages are left where they are.
 func (a *memoryAllocatorImpl) Remap(
 	pid vm.PID,
 	pageVAddr, byteSize uint64,
 	deviceID int,
-) {
+) error {
 	a.Lock()
 	defer a.Unlock()
 
@@ -188,18 +208,37 @@ func (a *memoryAllocatorImpl) Remap(
 		addr += pageSize
 	}
 
-	a.allocateMultiplePagesWithGivenVAddrs(pid, deviceID, vAddrs, false)
+	oldPages := make([]vm.Page, 0, len(vAddrs))
+	for _, vAddr := range vAddrs {
+		if page, found := a.vAddrToPageMapping[pageKey{pid, vAddr}]; found {
+			oldPages = append(oldPages, page)
+		}
+	}
+
+	_, err := a.allocateMultiplePagesWithGivenVAddrs(
+		pid, deviceID, vAddrs, false)
+	if err != nil {
+		return err
+	}
+
+	for _, page := range oldPages {
+		oldDeviceID := a.deviceIDByPAddr(page.PAddr)
+		a.devices[oldDeviceID].MemState.addSinglePAddr(page.PAddr)
+	}
+
+	return nil
 }
 
-func (a *memoryAllocatorImpl) RemovePage(vAddr uint64) {
+func (a *memoryAllocatorImpl) RemovePage(pid vm.PID, vAddr uint64) {
 	a.Lock()
 	defer a.Unlock()
 
-	a.removePage(vAddr)
+	a.removePage(pid, vAddr)
 }
 
-func (a *memoryAllocatorImpl) removePage(vAddr uint64) {
-	page, ok := a.vAddrToPageMapping[vAddr]
+func (a *memoryAllocatorImpl) removePage(pid vm.PID, vAddr uint64) {
+	key := pageKey{pid, vAddr}
+	page, ok := a.vAddrToPageMapping[key]
 
 	if !ok {
 		panic("page not found")
@@ -209,6 +248,7 @@ func (a *memoryAllocatorImpl) removePage(vAddr uint64) {
 	dState := a.devices[deviceID].MemState
 	dState.addSinglePAddr(page.PAddr)
 
+	delete(a.vAddrToPageMapping, key)
 	a.pageTable.Remove(page.PID, page.VAddr)
 }
 
@@ -217,7 +257,7 @@ func (a *memoryAllocatorImpl) AllocatePageWithGivenVAddr(
 	deviceID int,
 	vAddr uint64,
 	isUnified bool,
-) vm.Page {
+) (vm.Page, error) {
 	a.Lock()
 	defer a.Unlock()
 
@@ -229,11 +269,14 @@ func (a *memoryAllocatorImpl) allocatePageWithGivenVAddr(
 	deviceID int,
 	vAddr uint64,
 	isUnified bool,
-) vm.Page {
+) (vm.Page, error) {
 	pageSize := uint64(1 << a.log2PageSize)
 
 	device := a.devices[deviceID]
-	pAddr := device.allocatePage()
+	pAddr, err := device.allocatePage()
+	if err != nil {
+		return vm.Page{}, err
+	}
 
 	page := vm.Page{
 		PID:      pid,
@@ -244,10 +287,10 @@ func (a *memoryAllocatorImpl) allocatePageWithGivenVAddr(
 		DeviceID: uint64(deviceID),
 		Unified:  isUnified,
 	}
-	a.vAddrToPageMapping[page.VAddr] = page
+	a.vAddrToPageMapping[pageKey{page.PID, page.VAddr}] = page
 	a.pageTable.Update(page)
 
-	return page
+	return page, nil
 }
 
 func (a *memoryAllocatorImpl) allocateMultiplePagesWithGivenVAddrs(
@@ -255,11 +298,14 @@ func (a *memoryAllocatorImpl) allocateMultiplePagesWithGivenVAddrs(
 	deviceID int,
 	vAddrs []uint64,
 	isUnified bool,
-) (pages []vm.Page) {
+) (pages []vm.Page, err error) {
 	pageSize := uint64(1 << a.log2PageSize)
 
 	device := a.devices[deviceID]
-	pAddrs := device.allocateMultiplePages(len(vAddrs))
+	pAddrs, err := device.allocateMultiplePages(len(vAddrs))
+	if err != nil {
+		return nil, err
+	}
 
 	for i, vAddr := range vAddrs {
 		page := vm.Page{
@@ -271,17 +317,36 @@ func (a *memoryAllocatorImpl) allocateMultiplePagesWithGivenVAddrs(
 			DeviceID: uint64(deviceID),
 			Unified:  isUnified,
 		}
-		a.vAddrToPageMapping[page.VAddr] = page
+		a.vAddrToPageMapping[pageKey{page.PID, page.VAddr}] = page
 		a.pageTable.Update(page)
 		pages = append(pages, page)
 	}
 
-	return pages
+	return pages, nil
 }
 
-func (a *memoryAllocatorImpl) Free(ptr uint64) {
+// Free releases all the pages of the allocation that starts at ptr in the
+// address space of the process.
+func (a *memoryAllocatorImpl) Free(pid vm.PID, ptr uint64) {
 	a.Lock()
 	defer a.Unlock()
 
-	a.removePage(ptr)
+	numPages, ok := a.allocationNumPages[pageKey{pid, ptr}]
+	if !ok {
+		a.removePage(pid, ptr)
+		return
+	}
+	delete(a.allocationNumPages, pageKey{pid, ptr})
+
+	pageSize := uint64(1 << a.log2PageSize)
+	for i := 0; i < numPages; i++ {
+		vAddr := ptr + uint64(i)*pageSize
+
+		// The page may have been removed with RemovePage.
+		if _, found := a.vAddrToPageMapping[pageKey{pid, vAddr}]; !found {
+			continue
+		}
+
+		a.removePage(pid, vAddr)
+	}
 }
@@ -39,7 +39,8 @@ var _ = Describe("MemoryAllocatorImpl", func() {
 				Valid:    true,
 			})
 
-		ptr := allocator.Allocate(1, 8, 1)
+		ptr, err := allocator.Allocate(1, 8, 1)
+		Expect(err).NotTo(HaveOccurred())
 		Expect(ptr).To(Equal(uint64(4096)))
 	})
 
@@ -55,10 +56,18 @@ var _ = Describe("MemoryAllocatorImpl", func() {
 				Unified:  true,
 			})
 
-		ptr := allocator.AllocateUnified(1, 8)
+		ptr, err := allocator.AllocateUnified(1, 8)
+		Expect(err).NotTo(HaveOccurred())
 		Expect(ptr).To(Equal(uint64(4096)))
 	})
 
+	It("should return an error if unified memory runs out", func() {
+		ptr, err := allocator.AllocateUnified(1, 0x1_0000_1000)
+
+		Expect(err).To(MatchError(ErrOutOfMemory))
+		Expect(ptr).To(Equal(uint64(0)))
+	})
+
 	It("should allocate memory larger than a page", func() {
 		for i := uint64(0); i < 3; i++ {
 			pageTable.EXPECT().Insert(
@@ -72,10 +81,101 @@ var _ = Describe("MemoryAllocatorImpl", func() {
 				})
 		}
 
-		ptr := allocator.Allocate(1, 8196, 1)
+		ptr, err := allocator.Allocate(1, 8196, 1)
+		Expect(err).NotTo(HaveOccurred())
 		Expect(ptr).To(Equal(uint64(4096)))
 	})
 
+	It("should return an error if the device runs out of memory", func() {
+		ptr, err := allocator.Allocate(1, 0x1_0000_1000, 1)
+
+		Expect(err).To(MatchError(ErrOutOfMemory))
+		Expect(ptr).To(Equal(uint64(0)))
+		Expect(allocator.devices[1].numAvailablePages()).
+			To(Equal(uint64(0x10_0000)))
+	})
+
+	It("should return an error if the free buddy blocks are too small",
+		func() {
+			allocator = NewMemoryAllocator(pageTable, 12).(*memoryAllocatorImpl)
+			device := &Device{
+				ID:       1,
+				Type:     DeviceTypeGPU,
+				MemState: newDeviceBuddyMemoryState(12),
+			}
+			device.SetTotalMemSize(0x10000)
+			allocator.RegisterDevice(device)
+
+			pageTable.EXPECT().Insert(gomock.Any()).AnyTimes()
+			pageTable.EXPECT().Remove(gomock.Any(), gomock.Any()).AnyTimes()
+
+			var ptrs []uint64
+			for i := 0; i < 16; i++ {
+				ptr, err := allocator.Allocate(1, 0x1000, 1)
+				Expect(err).NotTo(HaveOccurred())
+				ptrs = append(ptrs, ptr)
+			}
+			for i := 0; i < 16; i += 2 {
+				allocator.Free(1, ptrs[i])
+			}
+
+			ptr, err := allocator.Allocate(1, 0x2000, 1)
+
+			Expect(err).To(MatchError(ErrOutOfMemory))
+			Expect(ptr).To(Equal(uint64(0)))
+			Expect(device.numAvailablePages()).To(Equal(uint64(8)))
+
+			_, err = allocator.Allocate(1, 0x1000, 1)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+	It("should free all the pages of an allocation", func() {
+		pageTable.EXPECT().Insert(gomock.Any()).Times(3)
+		ptr, err := allocator.Allocate(1, 8196, 1)
+		Expect(err).NotTo(HaveOccurred())
+
+		for i := uint64(0); i < 3; i++ {
+			pageTable.EXPECT().Remove(vm.PID(1), ptr+0x1000*i)
+		}
+		allocator.Free(1, ptr)
+
+		Expect(allocator.vAddrToPageMapping).To(BeEmpty())
+		Expect(allocator.devices[1].numAvailablePages()).
+			To(Equal(uint64(0x10_0000)))
+	})
+
+	It("should keep the pages of different processes apart", func() {
+		pageTable.EXPECT().Insert(gomock.Any()).Times(2)
+		ptr1, err := allocator.Allocate(1, 4096, 1)
+		Expect(err).NotTo(HaveOccurred())
+		ptr2, err := allocator.Allocate(2, 4096, 1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ptr2).To(Equal(ptr1))
+
+		pageTable.EXPECT().Remove(vm.PID(1), ptr1)
+		allocator.Free(1, ptr1)
+
+		Expect(allocator.vAddrToPageMapping).To(HaveLen(1))
+		Expect(allocator.vAddrToPageMapping).
+			To(HaveKey(pageKey{pid: 2, vAddr: ptr2}))
+	})
+
+	It("should allocate again after freeing all the memory", func() {
+		pageTable.EXPECT().Insert(gomock.Any()).AnyTimes()
+		pageTable.EXPECT().Remove(gomock.Any(), gomock.Any()).AnyTimes()
+
+		ptr, err := allocator.Allocate(1, 0x1_0000_0000, 1)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = allocator.Allocate(1, 0x1000, 1)
+		Expect(err).To(MatchError(ErrOutOfMemory))
+
+		allocator.Free(1, ptr)
+
+		_, err = allocator.Allocate(1, 0x1000, 1)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
 	It("should remap page to another device", func() {
 		page := vm.Page{
 			PID:      1,
@@ -86,13 +186,55 @@ var _ = Describe("MemoryAllocatorImpl", func() {
 			Valid:    true,
 		}
 		pageTable.EXPECT().Insert(page)
-		ptr := allocator.Allocate(1, 4000, 1)
+		ptr, _ := allocator.Allocate(1, 4000, 1)
 
 		updatedPage := page
 		updatedPage.PAddr = 0x2_0000_1000
 		updatedPage.DeviceID = 2
 		pageTable.EXPECT().Update(updatedPage)
-		allocator.Remap(1, ptr, 4000, 2)
+		err := allocator.Remap(1, ptr, 4000, 2)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Context("when the devices are small", func() {
+		BeforeEach(func() {
+			allocator = NewMemoryAllocator(pageTable, 12).(*memoryAllocatorImpl)
+			for i := 1; i <= 2; i++ {
+				gpu := &Device{
+					ID:       i,
+					Type:     DeviceTypeGPU,
+					MemState: NewDeviceMemoryState(12),
+				}
+				gpu.SetTotalMemSize(0x2000)
+				allocator.RegisterDevice(gpu)
+			}
+
+			pageTable.EXPECT().Insert(gomock.Any()).AnyTimes()
+		})
+
+		It("should keep the pages if the remap runs out of memory", func() {
+			ptr, err := allocator.Allocate(1, 0x2000, 1)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = allocator.Allocate(1, 0x1000, 2)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = allocator.Remap(1, ptr, 0x2000, 2)
+
+			Expect(err).To(MatchError(ErrOutOfMemory))
+			Expect(allocator.devices[1].numAvailablePages()).
+				To(Equal(uint64(0)))
+			Expect(allocator.devices[2].numAvailablePages()).
+				To(Equal(uint64(1)))
+		})
+
+		It("should return an error if a page cannot be allocated", func() {
+			_, err := allocator.Allocate(1, 0x2000, 2)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = allocator.AllocatePageWithGivenVAddr(1, 2, 0x1000, true)
+
+			Expect(err).To(MatchError(ErrOutOfMemory))
+		})
 	})
 })
 
@@ -32,19 +32,61 @@ func (d *Driver) EnqueueLaunchKernel(
 		d.EnqueueMemCopyH2D(queue, dKernArgData, newKernelArgs)
 		d.EnqueueMemCopyH2D(queue, dPacket, packet)
 
-		d.enqueueLaunchKernelCommand(queue, co, packet, dPacket)
+		dSaveArea := d.allocateContextSaveArea(queue.Context, co, packet)
+
+		d.enqueueLaunchKernelCommand(queue, co, packet, dPacket, dSaveArea)
+	}
+}
+
+// allocateContextSaveArea allocates the memory that holds the contexts of the
+// preempted work-groups of the kernel. It returns 0 if the driver does not
+// support context switching. If the GPU does not have enough memory left, it
+// also returns 0 and the kernel can only be preempted at the work-group
+// boundaries.
+func (d *Driver) allocateContextSaveArea(
+	ctx *Context,
+	co *insts.HsaCo,
+	packet *kernels.HsaKernelDispatchPacket,
+) Ptr {
+	if !d.useContextSaveArea {
+		return 0
+	}
+
+	numCU := d.devices[ctx.currentGPUID].Properties.CUCount
+	byteSize := kernels.ContextSaveAreaByteSize(co, packet, numCU)
+	if byteSize == 0 {
+		return 0
 	}
+
+	ptr, err := d.AllocateMemory(ctx, byteSize)
+	if err != nil {
+		return 0
+	}
+
+	return ptr
+}
+
+// numContextSaveSlots returns the number of work-group contexts that the
+// context save area of the kernel holds on the given GPU.
+func (d *Driver) numContextSaveSlots(
+	gpuID int,
+	co *insts.HsaCo,
+	packet *kernels.HsaKernelDispatchPacket,
+) int {
+	numCU := d.devices[gpuID].Properties.CUCount
+
+	return kernels.NumContextSaveSlots(co, packet, numCU)
 }
 
 func (d *Driver) allocateGPUMemory(
 	ctx *Context,
 	co *insts.HsaCo,
 ) (dCoData, dKernArgData, dPacket Ptr) {
-	dCoData = d.AllocateMemory(ctx, uint64(len(co.Data)))
-	dKernArgData = d.AllocateMemory(ctx, co.KernargSegmentByteSize)
+	dCoData = d.MustAllocateMemory(ctx, uint64(len(co.Data)))
+	dKernArgData = d.MustAllocateMemory(ctx, co.KernargSegmentByteSize)
 
 	packet := kernels.HsaKernelDispatchPacket{}
-	dPacket = d.AllocateMemory(ctx, uint64(binary.Size(packet)))
+	dPacket = d.MustAllocateMemory(ctx, uint64(binary.Size(packet)))
 
 	return dCoData, dKernArgData, dPacket
 }
@@ -54,24 +96,26 @@ func (d *Driver) prepareLocalMemory(
 	kernelArgs interface{},
 	packet *kernels.HsaKernelDispatchPacket,
 ) (newKernelArgs interface{}) {
+	ldsSize := co.WGGroupSegmentByteSize
+
+	// From server, the arguments are already serialized.
+	if rawArgs, ok := kernelArgs.([]byte); ok {
+		packet.GroupSegmentSize = ldsSize
+		return rawArgs
+	}
+
 	newKernelArgs = reflect.New(reflect.TypeOf(kernelArgs).Elem()).Interface()
 	reflect.ValueOf(newKernelArgs).Elem().
 		Set(reflect.ValueOf(kernelArgs).Elem())
 
-	ldsSize := co.WGGroupSegmentByteSize
+	kernArgStruct := reflect.ValueOf(newKernelArgs).Elem()
+	for i := 0; i < kernArgStruct.NumField(); i++ {
+		arg := kernArgStruct.Field(i).Interface()
 
-	if reflect.TypeOf(newKernelArgs).Kind() == reflect.Slice {
-		// From server, do nothing
-	} else {
-		kernArgStruct := reflect.ValueOf(newKernelArgs).Elem()
-		for i := 0; i < kernArgStruct.NumField(); i++ {
-			arg := kernArgStruct.Field(i).Interface()
-
-			switch ldsPtr := arg.(type) {
-			case LocalPtr:
-				kernArgStruct.Field(i).SetUint(uint64(ldsSize))
-				ldsSize += uint32(ldsPtr)
-			}
+		switch ldsPtr := arg.(type) {
+		case LocalPtr:
+			kernArgStruct.Field(i).SetUint(uint64(ldsSize))
+			ldsSize += uint32(ldsPtr)
 		}
 	}
 
@@ -117,12 +161,14 @@ func (d *Driver) enqueueLaunchKernelCommand(
 	co *insts.HsaCo,
 	packet *kernels.HsaKernelDispatchPacket,
 	dPacket Ptr,
+	dSaveArea Ptr,
 ) {
 	cmd := &LaunchKernelCommand{
-		ID:         sim.GetIDGenerator().Generate(),
-		CodeObject: co,
-		DPacket:    dPacket,
-		Packet:     packet,
+		ID:               sim.GetIDGenerator().Generate(),
+		CodeObject:       co,
+		DPacket:          dPacket,
+		Packet:           packet,
+		DContextSaveArea: dSaveArea,
 	}
 	d.Enqueue(queue, cmd)
 }
@@ -130,14 +176,18 @@ func (d *Driver) enqueueLaunchKernelCommand(
 func (d *Driver) enqueueLaunchUnifiedKernelCommand(
 	queue *CommandQueue,
 	co *insts.HsaCo,
+	kernelArgs interface{},
 	packet []*kernels.HsaKernelDispatchPacket,
 	dPacket []Ptr,
+	dSaveArea []Ptr,
 ) {
 	cmd := &LaunchUnifiedMultiGPUKernelCommand{
-		ID:           sim.GetIDGenerator().Generate(),
-		CodeObject:   co,
-		DPacketArray: dPacket,
-		PacketArray:  packet,
+		ID:                    sim.GetIDGenerator().Generate(),
+		CodeObject:            co,
+		KernelArgs:            kernelArgs,
+		DPacketArray:          dPacket,
+		PacketArray:           packet,
+		DContextSaveAreaArray: dSaveArea,
 	}
 	d.Enqueue(queue, cmd)
 }
@@ -155,6 +205,7 @@ func (d *Driver) enqueueLaunchUnifiedKernel(
 	dKernArgDataArray := make([]Ptr, len(dev.UnifiedGPUIDs)+1)
 	dPacketArray := make([]Ptr, len(dev.UnifiedGPUIDs)+1)
 	packetArray := make([]*kernels.HsaKernelDispatchPacket, len(dev.UnifiedGPUIDs)+1)
+	dSaveAreaArray := make([]Ptr, len(dev.UnifiedGPUIDs)+1)
 	// fmt.Printf("# of GPUs : %v \n", len(dev.UnifiedGPUIDs))
 
 	for i, gpuID := range dev.UnifiedGPUIDs {
@@ -173,9 +224,12 @@ func (d *Driver) enqueueLaunchUnifiedKernel(
 		dKernArgDataArray[i] = dKernArgData
 		dPacketArray[i] = dPacket
 		packetArray[i] = packet
+		dSaveAreaArray[i] = d.allocateContextSaveArea(
+			queue.Context, co, packet)
 		// fmt.Printf("packetArray: %v \n", packetArray[i])
 	}
 
 	queue.Context.currentGPUID = initGPUID
-	d.enqueueLaunchUnifiedKernelCommand(queue, co, packetArray, dPacketArray)
+	d.enqueueLaunchUnifiedKernelCommand(
+		queue, co, kernelArgs, packetArray, dPacketArray, dSaveAreaArray)
 }
@@ -0,0 +1,53 @@
+package driver
+
+import (
+	"github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/mgpusim/v3/insts"
+	"github.com/sarchlab/mgpusim/v3/kernels"
+)
+
+type localMemoryTestArgs struct {
+	Input  Ptr
+	Shared LocalPtr
+}
+
+var _ = ginkgo.Describe("Kernel argument preparation", func() {
+	var (
+		driver *Driver
+		co     *insts.HsaCo
+		packet *kernels.HsaKernelDispatchPacket
+	)
+
+	ginkgo.BeforeEach(func() {
+		driver = &Driver{}
+		co = insts.NewHsaCo()
+		co.WGGroupSegmentByteSize = 256
+		packet = &kernels.HsaKernelDispatchPacket{}
+	})
+
+	ginkgo.It("should allocate local memory for LocalPtr arguments", func() {
+		args := &localMemoryTestArgs{Input: 0x1000, Shared: 1024}
+
+		newArgs := driver.prepareLocalMemory(co, args, packet)
+
+		Expect(newArgs.(*localMemoryTestArgs).Shared).To(Equal(LocalPtr(256)))
+		Expect(args.Shared).To(Equal(LocalPtr(1024)))
+		Expect(packet.GroupSegmentSize).To(Equal(uint32(1280)))
+	})
+
+	ginkgo.It("should not allocate a context save area by default", func() {
+		ptr := driver.allocateContextSaveArea(nil, co, packet)
+
+		Expect(ptr).To(Equal(Ptr(0)))
+	})
+
+	ginkgo.It("should keep serialized arguments", func() {
+		args := []byte{1, 2, 3, 4}
+
+		newArgs := driver.prepareLocalMemory(co, args, packet)
+
+		Expect(newArgs).To(Equal(args))
+		Expect(packet.GroupSegmentSize).To(Equal(uint32(256)))
+	})
+})
@@ -28,8 +28,8 @@ var _ = ginkgo.Describe("Test Memory Copy D2D", func() {
 	})
 
 	ginkgo.It("should copy d2d", func() {
-		ptr1 := gpuDriver.AllocateMemory(context, uint64(48))
-		ptr2 := gpuDriver.AllocateMemory(context, uint64(48))
+		ptr1 := gpuDriver.MustAllocateMemory(context, uint64(48))
+		ptr2 := gpuDriver.MustAllocateMemory(context, uint64(48))
 		hInput := make([]float32, 48)
 		hOutput := make([]float32, 48)
 		for i := 0; i < 48; i++ {
@@ -44,8 +44,8 @@ var _ = ginkgo.Describe("Test Memory Copy D2D", func() {
 	})
 
 	ginkgo.It("should copy d2d", func() {
-		ptr1 := gpuDriver.AllocateMemory(context, uint64(49))
-		ptr2 := gpuDriver.AllocateMemory(context, uint64(49))
+		ptr1 := gpuDriver.MustAllocateMemory(context, uint64(49))
+		ptr2 := gpuDriver.MustAllocateMemory(context, uint64(49))
 		hInput := make([]float32, 49)
 		hOutput := make([]float32, 49)
 		for i := 0; i < 49; i++ {
@@ -0,0 +1,35 @@
+package driver
+
+import (
+	"log"
+
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/tebeka/atexit"
+)
+
+// HandleMemoryFault is called by the GPUs when a kernel accesses an address
+// that is not mapped. Like the GPU page fault handling of a real driver, it
+// prints the fault and terminates the program, unless the driver is built to
+// continue after memory faults.
+func (d *Driver) HandleMemoryFault(now sim.VTimeInSec, fault error) {
+	d.memoryFaultMutex.Lock()
+	d.memoryFaults = append(d.memoryFaults, fault)
+	d.memoryFaultMutex.Unlock()
+
+	log.Printf("%.10f: %v", now, fault)
+
+	if !d.continueOnMemoryFault {
+		atexit.Exit(1)
+	}
+}
+
+// MemoryFaults returns the memory faults that the GPUs have reported.
+func (d *Driver) MemoryFaults() []error {
+	d.memoryFaultMutex.Lock()
+	defer d.memoryFaultMutex.Unlock()
+
+	faults := make([]error, len(d.memoryFaults))
+	copy(faults, d.memoryFaults)
+
+	return faults
+}
@@ -0,0 +1,22 @@
+package driver
+
+import (
+	"errors"
+
+	"github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = ginkgo.Describe("Memory Fault", func() {
+	ginkgo.It("should record memory faults and continue", func() {
+		d := MakeBuilder().
+			WithLog2PageSize(12).
+			WithContinueOnMemoryFault().
+			Build("Driver")
+		fault := errors.New("memory access fault")
+
+		d.HandleMemoryFault(1, fault)
+
+		Expect(d.MemoryFaults()).To(Equal([]error{fault}))
+	})
+})
@@ -35,11 +35,12 @@ func (m *MockMemoryAllocator) EXPECT() *MockMemoryAllocatorMockRecorder {
 }
 
 // Allocate mocks base method.
-func (m *MockMemoryAllocator) Allocate(arg0 vm.PID, arg1 uint64, arg2 int) uint64 {
+func (m *MockMemoryAllocator) Allocate(arg0 vm.PID, arg1 uint64, arg2 int) (uint64, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Allocate", arg0, arg1, arg2)
 	ret0, _ := ret[0].(uint64)
-	return ret0
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Allocate indicates an expected call of Allocate.
@@ -49,11 +50,12 @@ func (mr *MockMemoryAllocatorMockRecorder) Allocate(arg0, arg1, arg2 interface{}
 }
 
 // AllocatePageWithGivenVAddr mocks base method.
-func (m *MockMemoryAllocator) AllocatePageWithGivenVAddr(arg0 vm.PID, arg1 int, arg2 uint64, arg3 bool) vm.Page {
+func (m *MockMemoryAllocator) AllocatePageWithGivenVAddr(arg0 vm.PID, arg1 int, arg2 uint64, arg3 bool) (vm.Page, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "AllocatePageWithGivenVAddr", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(vm.Page)
-	return ret0
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // AllocatePageWithGivenVAddr indicates an expected call of AllocatePageWithGivenVAddr.
@@ -63,11 +65,12 @@ func (mr *MockMemoryAllocatorMockRecorder) AllocatePageWithGivenVAddr(arg0, arg1
 }
 
 // AllocateUnified mocks base method.
-func (m *MockMemoryAllocator) AllocateUnified(arg0 vm.PID, arg1 uint64) uint64 {
+func (m *MockMemoryAllocator) AllocateUnified(arg0 vm.PID, arg1 uint64) (uint64, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "AllocateUnified", arg0, arg1)
 	ret0, _ := ret[0].(uint64)
-	return ret0
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // AllocateUnified indicates an expected call of AllocateUnified.
@@ -77,15 +80,15 @@ func (mr *MockMemoryAllocatorMockRecorder) AllocateUnified(arg0, arg1 interface{
 }
 
 // Free mocks base method.
-func (m *MockMemoryAllocator) Free(arg0 uint64) {
+func (m *MockMemoryAllocator) Free(arg0 vm.PID, arg1 uint64) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Free", arg0)
+	m.ctrl.Call(m, "Free", arg0, arg1)
 }
 
 // Free indicates an expected call of Free.
-func (mr *MockMemoryAllocatorMockRecorder) Free(arg0 interface{}) *gomock.Call {
+func (mr *MockMemoryAllocatorMockRecorder) Free(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Free", reflect.TypeOf((*MockMemoryAllocator)(nil).Free), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Free", reflect.TypeOf((*MockMemoryAllocator)(nil).Free), arg0, arg1)
 }
 
 // GetDeviceIDByPAddr mocks base method.
@@ -115,9 +118,11 @@ func (mr *MockMemoryAllocatorMockRecorder) RegisterDevice(arg0 interface{}) *gom
 }
 
 // Remap mocks base method.
-func (m *MockMemoryAllocator) Remap(arg0 vm.PID, arg1, arg2 uint64, arg3 int) {
+func (m *MockMemoryAllocator) Remap(arg0 vm.PID, arg1, arg2 uint64, arg3 int) error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Remap", arg0, arg1, arg2, arg3)
+	ret := m.ctrl.Call(m, "Remap", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // Remap indicates an expected call of Remap.
@@ -127,13 +132,13 @@ func (mr *MockMemoryAllocatorMockRecorder) Remap(arg0, arg1, arg2, arg3 interfac
 }
 
 // RemovePage mocks base method.
-func (m *MockMemoryAllocator) RemovePage(arg0 uint64) {
+func (m *MockMemoryAllocator) RemovePage(arg0 vm.PID, arg1 uint64) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "RemovePage", arg0)
+	m.ctrl.Call(m, "RemovePage", arg0, arg1)
 }
 
 // RemovePage indicates an expected call of RemovePage.
-func (mr *MockMemoryAllocatorMockRecorder) RemovePage(arg0 interface{}) *gomock.Call {
+func (mr *MockMemoryAllocatorMockRecorder) RemovePage(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePage", reflect.TypeOf((*MockMemoryAllocator)(nil).RemovePage), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePage", reflect.TypeOf((*MockMemoryAllocator)(nil).RemovePage), arg0, arg1)
 }
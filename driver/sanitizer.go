@@ -0,0 +1,125 @@
+package driver
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/sarchlab/akita/v3/mem/vm"
+)
+
+// ErrOutOfBounds is reported by the sanitizer when a kernel accesses memory
+// that is not part of any allocation.
+var ErrOutOfBounds = errors.New("out-of-bounds access")
+
+// ErrUseAfterFree is reported by the sanitizer when a kernel accesses memory
+// that has been freed.
+var ErrUseAfterFree = errors.New("use after free")
+
+// An allocation is a range of virtual addresses returned by the driver.
+type allocation struct {
+	start, size uint64
+	freed       bool
+}
+
+func (a allocation) end() uint64 {
+	return a.start + a.size
+}
+
+// A sanitizer tracks the boundaries of the allocations and the ranges that
+// have been freed, so that the GPUs can check the addresses that the kernels
+// access. The allocations of each process are sorted by the start address and
+// do not overlap, as freed ranges are dropped once they are reallocated.
+type sanitizer struct {
+	mutex       sync.RWMutex
+	allocations map[vm.PID][]allocation
+}
+
+func newSanitizer() *sanitizer {
+	return &sanitizer{
+		allocations: make(map[vm.PID][]allocation),
+	}
+}
+
+func (s *sanitizer) allocate(pid vm.PID, ptr, size uint64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	newAlloc := allocation{start: ptr, size: size}
+	allocs := s.allocations[pid]
+
+	kept := make([]allocation, 0, len(allocs)+1)
+	for _, a := range allocs {
+		if a.start < newAlloc.end() && newAlloc.start < a.end() {
+			continue
+		}
+
+		kept = append(kept, a)
+	}
+
+	i := sort.Search(len(kept), func(i int) bool {
+		return kept[i].start > ptr
+	})
+	kept = append(kept, allocation{})
+	copy(kept[i+1:], kept[i:])
+	kept[i] = newAlloc
+
+	s.allocations[pid] = kept
+}
+
+func (s *sanitizer) free(pid vm.PID, ptr uint64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	allocs := s.allocations[pid]
+	for i := range allocs {
+		if allocs[i].start == ptr {
+			allocs[i].freed = true
+		}
+	}
+}
+
+// check returns an error if the byteSize bytes starting at vAddr are not
+// within one allocation that is not freed.
+func (s *sanitizer) check(pid vm.PID, vAddr, byteSize uint64) error {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	allocs := s.allocations[pid]
+	i := sort.Search(len(allocs), func(i int) bool {
+		return allocs[i].start > vAddr
+	}) - 1
+
+	if i < 0 {
+		return fmt.Errorf("%w: %d bytes at 0x%x are not allocated",
+			ErrOutOfBounds, byteSize, vAddr)
+	}
+
+	a := allocs[i]
+	if a.freed && vAddr < a.end() {
+		return fmt.Errorf(
+			"%w: %d bytes at 0x%x are in the freed %d-byte allocation at 0x%x",
+			ErrUseAfterFree, byteSize, vAddr, a.size, a.start)
+	}
+
+	if vAddr+byteSize > a.end() {
+		return fmt.Errorf(
+			"%w: %d bytes at 0x%x end %d bytes after the %d-byte allocation at 0x%x",
+			ErrOutOfBounds, byteSize, vAddr, vAddr+byteSize-a.end(),
+			a.size, a.start)
+	}
+
+	return nil
+}
+
+// CheckAccess returns an error if a kernel of the process is not allowed to
+// access the byteSize bytes starting at vAddr. It always returns nil if the
+// driver is not built with the sanitizer.
+func (d *Driver) CheckAccess(pid vm.PID, vAddr, byteSize uint64) error {
+	if d.sanitizer == nil {
+		return nil
+	}
+
+	return d.sanitizer.check(pid, vAddr, byteSize)
+}
@@ -0,0 +1,48 @@
+package driver
+
+import (
+	"github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/akita/v3/mem/vm"
+)
+
+var _ = ginkgo.Describe("Sanitizer", func() {
+	var s *sanitizer
+
+	ginkgo.BeforeEach(func() {
+		s = newSanitizer()
+		s.allocate(1, 0x1000, 256)
+		s.allocate(1, 0x2000, 64)
+	})
+
+	ginkgo.It("should allow accesses within an allocation", func() {
+		Expect(s.check(1, 0x1000, 4)).To(Succeed())
+		Expect(s.check(1, 0x10fc, 4)).To(Succeed())
+		Expect(s.check(1, 0x2000, 64)).To(Succeed())
+	})
+
+	ginkgo.It("should report accesses past the end of an allocation", func() {
+		Expect(s.check(1, 0x10fe, 4)).To(MatchError(ErrOutOfBounds))
+		Expect(s.check(1, 0x1100, 4)).To(MatchError(ErrOutOfBounds))
+		Expect(s.check(1, 0x800, 4)).To(MatchError(ErrOutOfBounds))
+	})
+
+	ginkgo.It("should keep the allocations of processes apart", func() {
+		Expect(s.check(vm.PID(2), 0x1000, 4)).To(MatchError(ErrOutOfBounds))
+	})
+
+	ginkgo.It("should report accesses to freed memory", func() {
+		s.free(1, 0x1000)
+
+		Expect(s.check(1, 0x1010, 4)).To(MatchError(ErrUseAfterFree))
+		Expect(s.check(1, 0x2000, 4)).To(Succeed())
+	})
+
+	ginkgo.It("should forget freed ranges that are allocated again", func() {
+		s.free(1, 0x1000)
+		s.allocate(1, 0x1000, 128)
+
+		Expect(s.check(1, 0x1000, 4)).To(Succeed())
+		Expect(s.check(1, 0x1080, 4)).To(MatchError(ErrOutOfBounds))
+	})
+})
@@ -0,0 +1,190 @@
+package driver
+
+import (
+	"log"
+
+	"github.com/sarchlab/mgpusim/v3/kernels"
+	"github.com/sarchlab/mgpusim/v3/protocol"
+)
+
+// WGDistributionPolicy decides how the driver splits the work-groups of a
+// kernel launched on a unified GPU among the GPUs that form the unified GPU.
+type WGDistributionPolicy string
+
+// All the supported work-group distribution policies.
+const (
+	// WGDistributionStatic gives each GPU a contiguous range of the
+	// flattened work-group IDs, in proportion to its CU count.
+	WGDistributionStatic WGDistributionPolicy = "static"
+
+	// WGDistributionInterleaved deals chunks of consecutive work-groups to
+	// the GPUs in turn.
+	WGDistributionInterleaved WGDistributionPolicy = "interleaved"
+
+	// WGDistributionWorkStealing keeps the work-groups in a pool that all
+	// the GPUs share. The dispatchers of a GPU take a chunk of work-groups
+	// at a time and take another chunk when they have dispatched one, so
+	// that the GPUs that run ahead take over the work of the others.
+	WGDistributionWorkStealing WGDistributionPolicy = "work-stealing"
+
+	// WGDistributionPageOwnership runs each work-group on the GPU that owns
+	// most of the pages that the work-group touches. It assumes that the
+	// work-groups walk the buffers that Distribute placed on the GPUs in
+	// order, so that the n-th work-group touches the n-th part of each
+	// buffer. Kernels without such buffers fall back to the static policy.
+	WGDistributionPageOwnership WGDistributionPolicy = "page-ownership"
+)
+
+const defaultWGDistributionChunkSize = 16
+
+func mustBeKnownWGDistributionPolicy(policy WGDistributionPolicy) {
+	switch policy {
+	case WGDistributionStatic,
+		WGDistributionInterleaved,
+		WGDistributionWorkStealing,
+		WGDistributionPageOwnership:
+	default:
+		log.Panicf("unknown work-group distribution policy %s", policy)
+	}
+}
+
+// A wgDistributor decides which work-groups of a kernel each GPU of a
+// unified GPU runs.
+type wgDistributor interface {
+	// assign sets the work-groups that the GPU with the given index in the
+	// unified GPU runs on the request that launches the kernel on the GPU.
+	// It returns false if the GPU has nothing to run.
+	assign(req *protocol.LaunchKernelReq, gpuIndex int) bool
+}
+
+// ownerWGDistributor decides the GPU of every work-group up front.
+type ownerWGDistributor struct {
+	owners []int
+	numWGs []int
+}
+
+func newOwnerWGDistributor(owners []int, numGPU int) *ownerWGDistributor {
+	d := &ownerWGDistributor{
+		owners: owners,
+		numWGs: make([]int, numGPU),
+	}
+
+	for _, owner := range owners {
+		d.numWGs[owner]++
+	}
+
+	return d
+}
+
+func (d *ownerWGDistributor) assign(
+	req *protocol.LaunchKernelReq,
+	gpuIndex int,
+) bool {
+	if d.numWGs[gpuIndex] == 0 {
+		return false
+	}
+
+	req.WGFilter = func(
+		pkt *kernels.HsaKernelDispatchPacket,
+		wg *kernels.WorkGroup,
+	) bool {
+		return d.owners[flattenedWGID(pkt, wg)] == gpuIndex
+	}
+
+	return true
+}
+
+// poolWGDistributor lets all the GPUs take their work-groups from a shared
+// pool.
+type poolWGDistributor struct {
+	pool *kernels.WGPool
+}
+
+func (d *poolWGDistributor) assign(
+	req *protocol.LaunchKernelReq,
+	_ int,
+) bool {
+	req.WGPool = d.pool
+
+	return true
+}
+
+// staticWGOwners assigns contiguous ranges of work-groups to the GPUs. Every
+// GPU gets the same number of work-groups per CU, except that the last GPUs
+// may get fewer as the work-groups run out.
+func staticWGOwners(numWG int, cuCounts []int) []int {
+	totalCUCount := 0
+	for _, cuCount := range cuCounts {
+		totalCUCount += cuCount
+	}
+
+	wgPerCU := (numWG-1)/totalCUCount + 1
+	owners := make([]int, numWG)
+	wgID := 0
+	for i, cuCount := range cuCounts {
+		for n := 0; n < cuCount*wgPerCU && wgID < numWG; n++ {
+			owners[wgID] = i
+			wgID++
+		}
+	}
+
+	return owners
+}
+
+// interleavedWGOwners deals chunks of consecutive work-groups to the GPUs in
+// a round-robin fashion.
+func interleavedWGOwners(numWG, numGPU, chunkSize int) []int {
+	owners := make([]int, numWG)
+	for wgID := range owners {
+		owners[wgID] = (wgID / chunkSize) % numGPU
+	}
+
+	return owners
+}
+
+// pageWGOwners assigns each work-group to the GPU that owns most of its
+// pages. The votes function counts, for a work-group, the pages that each GPU
+// owns. Work-groups without any vote keep the owners given as the fallback.
+func pageWGOwners(
+	fallback []int,
+	numGPU int,
+	votes func(wgID int, count []int),
+) []int {
+	owners := make([]int, len(fallback))
+	count := make([]int, numGPU)
+	for wgID := range owners {
+		for i := range count {
+			count[i] = 0
+		}
+
+		votes(wgID, count)
+
+		owners[wgID] = fallback[wgID]
+		maxCount := 0
+		for i, c := range count {
+			if c > maxCount {
+				owners[wgID] = i
+				maxCount = c
+			}
+		}
+	}
+
+	return owners
+}
+
+func numWGInPacket(pkt *kernels.HsaKernelDispatchPacket) (x, y, z int) {
+	x = int((pkt.GridSizeX-1)/uint32(pkt.WorkgroupSizeX) + 1)
+	y = int((pkt.GridSizeY-1)/uint32(pkt.WorkgroupSizeY) + 1)
+	z = int((pkt.GridSizeZ-1)/uint32(pkt.WorkgroupSizeZ) + 1)
+
+	return x, y, z
+}
+
+func flattenedWGID(
+	pkt *kernels.HsaKernelDispatchPacket,
+	wg *kernels.WorkGroup,
+) int {
+	numWGX, numWGY, _ := numWGInPacket(pkt)
+
+	return wg.IDZ*numWGX*numWGY + wg.IDY*numWGX + wg.IDX
+}
@@ -0,0 +1,100 @@
+package driver
+
+import (
+	"github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/mgpusim/v3/kernels"
+	"github.com/sarchlab/mgpusim/v3/protocol"
+)
+
+var _ = ginkgo.Describe("Work-group distribution", func() {
+	ginkgo.It("should split the work-groups in proportion to the CU count", func() {
+		owners := staticWGOwners(10, []int{2, 3})
+
+		Expect(owners).To(Equal([]int{0, 0, 0, 0, 1, 1, 1, 1, 1, 1}))
+	})
+
+	ginkgo.It("should leave the last GPU idle if work-groups run out", func() {
+		owners := staticWGOwners(3, []int{2, 2})
+
+		Expect(owners).To(Equal([]int{0, 0, 1}))
+	})
+
+	ginkgo.It("should interleave chunks of work-groups", func() {
+		owners := interleavedWGOwners(7, 2, 2)
+
+		Expect(owners).To(Equal([]int{0, 0, 1, 1, 0, 0, 1}))
+	})
+
+	ginkgo.It("should place work-groups on the GPU with most votes", func() {
+		owners := pageWGOwners([]int{0, 0, 1, 1}, 2,
+			func(wgID int, count []int) {
+				switch wgID {
+				case 0:
+					count[1] += 2
+					count[0]++
+				case 2:
+					count[0]++
+				}
+			})
+
+		Expect(owners).To(Equal([]int{1, 0, 0, 1}))
+	})
+
+	ginkgo.It("should run each work-group on its owner", func() {
+		d := newOwnerWGDistributor([]int{0, 1, 0}, 3)
+		pkt := &kernels.HsaKernelDispatchPacket{
+			GridSizeX:      192,
+			GridSizeY:      1,
+			GridSizeZ:      1,
+			WorkgroupSizeX: 64,
+			WorkgroupSizeY: 1,
+			WorkgroupSizeZ: 1,
+		}
+
+		req := protocol.NewLaunchKernelReq(0, nil, nil)
+		Expect(d.assign(req, 0)).To(BeTrue())
+		Expect(req.WGFilter(pkt, &kernels.WorkGroup{IDX: 0})).To(BeTrue())
+		Expect(req.WGFilter(pkt, &kernels.WorkGroup{IDX: 1})).To(BeFalse())
+		Expect(req.WGFilter(pkt, &kernels.WorkGroup{IDX: 2})).To(BeTrue())
+
+		req = protocol.NewLaunchKernelReq(0, nil, nil)
+		Expect(d.assign(req, 2)).To(BeFalse())
+	})
+
+	ginkgo.It("should let all the GPUs share the pool", func() {
+		pool := kernels.NewWGPool(5, 2)
+		d := &poolWGDistributor{pool: pool}
+
+		req0 := protocol.NewLaunchKernelReq(0, nil, nil)
+		req1 := protocol.NewLaunchKernelReq(0, nil, nil)
+		Expect(d.assign(req0, 0)).To(BeTrue())
+		Expect(d.assign(req1, 1)).To(BeTrue())
+		Expect(req0.WGPool).To(BeIdenticalTo(pool))
+		Expect(req1.WGPool).To(BeIdenticalTo(pool))
+		Expect(req0.WGFilter).To(BeNil())
+	})
+
+	ginkgo.It("should find the GPU that holds an address of a distribution", func() {
+		dist := &distribution{
+			addr:           0x1000,
+			byteSize:       0x2800,
+			gpuIDs:         []int{1, 2},
+			bytesOnEachGPU: []uint64{0x1000, 0x2000},
+		}
+
+		gpuID, ok := dist.gpuIDAt(0x1800)
+		Expect(ok).To(BeTrue())
+		Expect(gpuID).To(Equal(1))
+
+		gpuID, ok = dist.gpuIDAt(0x2000)
+		Expect(ok).To(BeTrue())
+		Expect(gpuID).To(Equal(2))
+
+		_, ok = dist.gpuIDAt(0x3800)
+		Expect(ok).To(BeFalse())
+
+		_, ok = dist.gpuIDAt(0x800)
+		Expect(ok).To(BeFalse())
+	})
+})
@@ -3,7 +3,6 @@ package emu
 import (
 	"bytes"
 	"fmt"
-	"log"
 
 	"encoding/binary"
 
@@ -22,6 +21,10 @@ type ALU interface {
 type ALUImpl struct {
 	storageAccessor *storageAccessor
 	lds             []byte
+	addressChecker  AddressChecker
+	faults          []laneFault
+	recordAccesses  bool
+	accesses        []memAccess
 }
 
 // NewALU creates a new ALU with a storage as a dependency.
@@ -65,10 +68,16 @@ func (u *ALUImpl) Run(state InstEmuState) {
 		u.runVOP3A(state)
 	case insts.VOP3b:
 		u.runVOP3B(state)
+	case insts.VOP3P:
+		u.runVOP3P(state)
 	case insts.VOPC:
 		u.runVOPC(state)
 	case insts.FLAT:
 		u.runFlat(state)
+	case insts.MUBUF:
+		u.runMUBUF(state)
+	case insts.MTBUF:
+		u.runMTBUF(state)
 	case insts.SOPP:
 		u.runSOPP(state)
 	case insts.SOPK:
@@ -76,7 +85,7 @@ func (u *ALUImpl) Run(state InstEmuState) {
 	case insts.DS:
 		u.runDS(state)
 	default:
-		log.Panicf("Inst format %s is not supported", inst.Format.FormatName)
+		panicUnimplemented(inst)
 	}
 }
 
@@ -92,7 +101,7 @@ func (u *ALUImpl) runSMEM(state InstEmuState) {
 	case 3:
 		u.runSLOADDWORDX8(state)
 	default:
-		log.Panicf("Opcode %d for SMEM format is not implemented", inst.Opcode)
+		panicUnimplemented(inst)
 	}
 }
 
@@ -100,7 +109,7 @@ func (u *ALUImpl) runSLOADDWORD(state InstEmuState) {
 	sp := state.Scratchpad().AsSMEM()
 	pid := state.PID()
 
-	buf := u.storageAccessor.Read(pid, sp.Base+sp.Offset, 4)
+	buf := u.readMemory(pid, ScalarLane, sp.Base+sp.Offset, 4)
 
 	sp.DST[0] = insts.BytesToUint32(buf)
 }
@@ -110,7 +119,7 @@ func (u *ALUImpl) runSLOADDWORDX2(state InstEmuState) {
 	spRaw := state.Scratchpad()
 	pid := state.PID()
 
-	buf := u.storageAccessor.Read(pid, sp.Base+sp.Offset, 8)
+	buf := u.readMemory(pid, ScalarLane, sp.Base+sp.Offset, 8)
 	copy(spRaw[32:40], buf)
 }
 
@@ -119,7 +128,7 @@ func (u *ALUImpl) runSLOADDWORDX4(state InstEmuState) {
 	spRaw := state.Scratchpad()
 	pid := state.PID()
 
-	buf := u.storageAccessor.Read(pid, sp.Base+sp.Offset, 16)
+	buf := u.readMemory(pid, ScalarLane, sp.Base+sp.Offset, 16)
 	copy(spRaw[32:48], buf)
 }
 
@@ -128,7 +137,7 @@ func (u *ALUImpl) runSLOADDWORDX8(state InstEmuState) {
 	spRaw := state.Scratchpad()
 	pid := state.PID()
 
-	buf := u.storageAccessor.Read(pid, sp.Base+sp.Offset, 32)
+	buf := u.readMemory(pid, ScalarLane, sp.Base+sp.Offset, 32)
 	copy(spRaw[32:64], buf)
 }
 
@@ -154,8 +163,16 @@ func (u *ALUImpl) runSOPP(state InstEmuState) {
 		u.runSCBRANCHEXECNZ(state)
 	case 12: // S_WAITCNT
 	// Do nothing
+	case 14, 15: // S_SLEEP, S_SETPRIO
+	// Do nothing
+	case 16: // S_SENDMSG
+	// Do nothing
+	case 19, 20, 21, 22: // S_ICACHE_INV, S_INC/DECPERFLEVEL, S_TTRACEDATA
+	// Do nothing
+	case 23, 24, 25, 26: // S_CBRANCH_CDBG*
+	// Never taken, as there is no debugger attached
 	default:
-		log.Panicf("Opcode %d for SOPP format is not implemented", inst.Opcode)
+		panicUnimplemented(inst)
 	}
 }
 
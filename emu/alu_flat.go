@@ -10,15 +10,28 @@ import (
 //nolint:funlen
 func (u *ALUImpl) runFlat(state InstEmuState) {
 	inst := state.Inst()
+
+	// The scratch segment needs the private memory of the work-items, which
+	// is not modeled. The kernel loader rejects the kernels that use it.
+	if inst.Seg == insts.FlatSegmentScratch {
+		panicUnimplemented(inst)
+	}
+
 	switch inst.Opcode {
 	case 16:
 		u.runFlatLoadUByte(state)
+	case 17:
+		u.runFlatLoadSByte(state)
 	case 18:
 		u.runFlatLoadUShort(state)
+	case 19:
+		u.runFlatLoadSShort(state)
 	case 20:
 		u.runFlatLoadDWord(state)
 	case 21:
 		u.runFlatLoadDWordX2(state)
+	case 22:
+		u.runFlatLoadDWordX3(state)
 	case 23:
 		u.runFlatLoadDWordX4(state)
 	case 28:
@@ -29,8 +42,15 @@ func (u *ALUImpl) runFlat(state InstEmuState) {
 		u.runFlatStoreDWordX3(state)
 	case 31:
 		u.runFlatStoreDWordX4(state)
+	case 24, 25, 26, 27:
+		u.runFlatStoreSubDWord(state)
+	case 32, 33, 34, 35, 36, 37:
+		u.runFlatLoadD16(state)
+	case 64, 65, 66, 67, 68, 69, 70, 71, 72, 73, 74, 75, 76,
+		96, 97, 98, 99, 100, 101, 102, 103, 104, 105, 106, 107, 108:
+		u.runFlatAtomic(state)
 	default:
-		log.Panicf("Opcode %d for FLAT format is not implemented", inst.Opcode)
+		panicUnimplemented(inst)
 	}
 }
 
@@ -42,10 +62,8 @@ func (u *ALUImpl) runFlatLoadUByte(state InstEmuState) {
 			continue
 		}
 
-		buf := u.storageAccessor.Read(pid, sp.ADDR[i], uint64(4))
-		buf[1] = 0
-		buf[2] = 0
-		buf[3] = 0
+		buf := make([]byte, 4)
+		copy(buf, u.readMemory(pid, int(i), sp.ADDR[i], 1))
 
 		sp.DST[i*4] = insts.BytesToUint32(buf)
 	}
@@ -59,15 +77,41 @@ func (u *ALUImpl) runFlatLoadUShort(state InstEmuState) {
 			continue
 		}
 
-		buf := u.storageAccessor.Read(pid, sp.ADDR[i], uint64(4))
-
-		buf[2] = 0
-		buf[3] = 0
+		buf := make([]byte, 4)
+		copy(buf, u.readMemory(pid, int(i), sp.ADDR[i], 2))
 
 		sp.DST[i*4] = insts.BytesToUint32(buf)
 	}
 }
 
+func (u *ALUImpl) runFlatLoadSByte(state InstEmuState) {
+	sp := state.Scratchpad().AsFlat()
+	pid := state.PID()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		buf := u.readMemory(pid, int(i), sp.ADDR[i], 1)
+
+		sp.DST[i*4] = uint32(int32(int8(buf[0])))
+	}
+}
+
+func (u *ALUImpl) runFlatLoadSShort(state InstEmuState) {
+	sp := state.Scratchpad().AsFlat()
+	pid := state.PID()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		buf := u.readMemory(pid, int(i), sp.ADDR[i], 2)
+
+		sp.DST[i*4] = uint32(int32(int16(insts.BytesToUint16(buf))))
+	}
+}
+
 func (u *ALUImpl) runFlatLoadDWord(state InstEmuState) {
 	sp := state.Scratchpad().AsFlat()
 	pid := state.PID()
@@ -76,7 +120,7 @@ func (u *ALUImpl) runFlatLoadDWord(state InstEmuState) {
 			continue
 		}
 
-		buf := u.storageAccessor.Read(pid, sp.ADDR[i], uint64(4))
+		buf := u.readMemory(pid, int(i), sp.ADDR[i], uint64(4))
 		sp.DST[i*4] = insts.BytesToUint32(buf)
 	}
 }
@@ -89,10 +133,26 @@ func (u *ALUImpl) runFlatLoadDWordX2(state InstEmuState) {
 			continue
 		}
 
-		buf := u.storageAccessor.Read(pid, sp.ADDR[i], uint64(8))
+		buf := u.readMemory(pid, int(i), sp.ADDR[i], uint64(8))
+
+		sp.DST[i*4] = insts.BytesToUint32(buf[0:4])
+		sp.DST[i*4+1] = insts.BytesToUint32(buf[4:8])
+	}
+}
+
+func (u *ALUImpl) runFlatLoadDWordX3(state InstEmuState) {
+	sp := state.Scratchpad().AsFlat()
+	pid := state.PID()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		buf := u.readMemory(pid, int(i), sp.ADDR[i], uint64(12))
 
 		sp.DST[i*4] = insts.BytesToUint32(buf[0:4])
 		sp.DST[i*4+1] = insts.BytesToUint32(buf[4:8])
+		sp.DST[i*4+2] = insts.BytesToUint32(buf[8:12])
 	}
 }
 
@@ -104,7 +164,7 @@ func (u *ALUImpl) runFlatLoadDWordX4(state InstEmuState) {
 			continue
 		}
 
-		buf := u.storageAccessor.Read(pid, sp.ADDR[i], uint64(16))
+		buf := u.readMemory(pid, int(i), sp.ADDR[i], uint64(16))
 
 		sp.DST[i*4] = insts.BytesToUint32(buf[0:4])
 		sp.DST[i*4+1] = insts.BytesToUint32(buf[4:8])
@@ -113,6 +173,41 @@ func (u *ALUImpl) runFlatLoadDWordX4(state InstEmuState) {
 	}
 }
 
+// runFlatLoadD16 runs the GFX9 d16 loads, which read a byte or a short into
+// one half of the destination register and keep the other half.
+func (u *ALUImpl) runFlatLoadD16(state InstEmuState) {
+	inst := state.Inst()
+	sp := state.Scratchpad().AsFlat()
+	pid := state.PID()
+	byteSize := VMemLaneByteSize(inst)
+
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		buf := u.readMemory(pid, int(i), sp.ADDR[i], byteSize)
+		sp.DST[i*4] = FlatD16LoadMerge(inst, sp.DST[i*4], buf)
+	}
+}
+
+// runFlatStoreSubDWord runs the byte and short stores, including the GFX9
+// d16_hi stores that take the data from the upper half of the register.
+func (u *ALUImpl) runFlatStoreSubDWord(state InstEmuState) {
+	inst := state.Inst()
+	sp := state.Scratchpad().AsFlat()
+	pid := state.PID()
+
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		u.writeMemory(
+			pid, int(i), sp.ADDR[i], FlatStoreBytes(inst, sp.DATA[i*4]))
+	}
+}
+
 func (u *ALUImpl) runFlatStoreDWord(state InstEmuState) {
 	sp := state.Scratchpad().AsFlat()
 	pid := state.PID()
@@ -122,8 +217,8 @@ func (u *ALUImpl) runFlatStoreDWord(state InstEmuState) {
 			continue
 		}
 
-		u.storageAccessor.Write(
-			pid, sp.ADDR[i], insts.Uint32ToBytes(sp.DATA[i*4]))
+		u.writeMemory(
+			pid, int(i), sp.ADDR[i], insts.Uint32ToBytes(sp.DATA[i*4]))
 	}
 }
 
@@ -139,7 +234,7 @@ func (u *ALUImpl) runFlatStoreDWordX2(state InstEmuState) {
 		copy(buf[0:4], insts.Uint32ToBytes(sp.DATA[i*4]))
 		copy(buf[4:8], insts.Uint32ToBytes(sp.DATA[(i*4)+1]))
 
-		u.storageAccessor.Write(pid, sp.ADDR[i], buf)
+		u.writeMemory(pid, int(i), sp.ADDR[i], buf)
 	}
 }
 
@@ -156,7 +251,7 @@ func (u *ALUImpl) runFlatStoreDWordX3(state InstEmuState) {
 		copy(buf[4:8], insts.Uint32ToBytes(sp.DATA[(i*4)+1]))
 		copy(buf[8:12], insts.Uint32ToBytes(sp.DATA[(i*4)+2]))
 
-		u.storageAccessor.Write(pid, sp.ADDR[i], buf)
+		u.writeMemory(pid, int(i), sp.ADDR[i], buf)
 	}
 }
 
@@ -174,6 +269,90 @@ func (u *ALUImpl) runFlatStoreDWordX4(state InstEmuState) {
 		copy(buf[8:12], insts.Uint32ToBytes(sp.DATA[(i*4)+2]))
 		copy(buf[12:16], insts.Uint32ToBytes(sp.DATA[(i*4)+3]))
 
-		u.storageAccessor.Write(pid, sp.ADDR[i], buf)
+		u.writeMemory(pid, int(i), sp.ADDR[i], buf)
 	}
 }
+
+func (u *ALUImpl) runFlatAtomic(state InstEmuState) {
+	sp := state.Scratchpad().AsFlat()
+	pid := state.PID()
+	info, _ := VMemAtomicInfo(state.Inst())
+
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		src, cmp := VMemAtomicOperands(info, sp.DATA[i*4:i*4+4])
+
+		if info.Is64Bit {
+			buf := u.readMemory(pid, int(i), sp.ADDR[i], 8)
+			old := insts.BytesToUint64(buf)
+			value := ApplyAtomic64(info.Op, old, src, cmp)
+			u.writeMemory(pid, int(i), sp.ADDR[i], insts.Uint64ToBytes(value))
+
+			sp.DST[i*4] = uint32(old)
+			sp.DST[i*4+1] = uint32(old >> 32)
+
+			continue
+		}
+
+		buf := u.readMemory(pid, int(i), sp.ADDR[i], 4)
+		old := insts.BytesToUint32(buf)
+		value := ApplyAtomic32(info.Op, old, uint32(src), uint32(cmp))
+		u.writeMemory(pid, int(i), sp.ADDR[i], insts.Uint32ToBytes(value))
+
+		sp.DST[i*4] = old
+	}
+}
+
+// IsFlatD16Load checks if a FLAT instruction is a GFX9 d16 load, which writes
+// only one half of the destination register.
+func IsFlatD16Load(inst *insts.Inst) bool {
+	return inst.FormatType == insts.FLAT &&
+		inst.Opcode >= 32 && inst.Opcode <= 37
+}
+
+// FlatD16LoadMerge returns the destination register of a lane of a GFX9 d16
+// load, given the value that the register holds before the load and the
+// bytes read from the memory. The _hi loads write bits 16 to 31 and the other
+// loads write bits 0 to 15. Bytes are sign- or zero-extended to 16 bits.
+func FlatD16LoadMerge(inst *insts.Inst, old uint32, data []byte) uint32 {
+	var value uint16
+	switch inst.Opcode {
+	case 32, 33: // flat_load_ubyte_d16
+		value = uint16(data[0])
+	case 34, 35: // flat_load_sbyte_d16
+		value = uint16(int16(int8(data[0])))
+	case 36, 37: // flat_load_short_d16
+		value = insts.BytesToUint16(data[0:2])
+	}
+
+	if inst.Opcode%2 == 1 {
+		return old&0x0000ffff | uint32(value)<<16
+	}
+
+	return old&0xffff0000 | uint32(value)
+}
+
+// FlatStoreBytes returns the bytes that a lane of a FLAT byte or short store
+// writes, given the data register of the lane. The GFX9 d16_hi stores take
+// the data from bits 16 to 31.
+func FlatStoreBytes(inst *insts.Inst, data uint32) []byte {
+	buf := insts.Uint32ToBytes(data)
+
+	switch inst.Opcode {
+	case 24: // flat_store_byte
+		return buf[0:1]
+	case 25: // flat_store_byte_d16_hi
+		return buf[2:3]
+	case 26: // flat_store_short
+		return buf[0:2]
+	case 27: // flat_store_short_d16_hi
+		return buf[2:4]
+	}
+
+	log.Panicf("FLAT opcode %d is not a byte or short store", inst.Opcode)
+
+	panic("never")
+}
@@ -1,6 +1,8 @@
 package emu
 
 import (
+	"errors"
+
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -153,6 +155,59 @@ var _ = Describe("ALU", func() {
 		}
 	})
 
+	It("should run FLAT_LOAD_SBYTE", func() {
+		pageTable.EXPECT().Find(vm.PID(1), uint64(0x100)).
+			Return(vm.Page{PAddr: uint64(0)}, true)
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.FLAT
+		state.inst.Opcode = 17
+
+		layout := state.Scratchpad().AsFlat()
+		layout.ADDR[0] = 0x100
+		layout.EXEC = 0x1
+		storage.Write(0x100, []byte{0xf0, 0xab, 0xcd, 0xef})
+
+		alu.Run(state)
+
+		Expect(layout.DST[0]).To(Equal(uint32(0xfffffff0)))
+	})
+
+	It("should run FLAT_LOAD_SSHORT", func() {
+		pageTable.EXPECT().Find(vm.PID(1), uint64(0x100)).
+			Return(vm.Page{PAddr: uint64(0)}, true)
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.FLAT
+		state.inst.Opcode = 19
+
+		layout := state.Scratchpad().AsFlat()
+		layout.ADDR[0] = 0x100
+		layout.EXEC = 0x1
+		storage.Write(0x100, []byte{0xf0, 0xab, 0xcd, 0xef})
+
+		alu.Run(state)
+
+		Expect(layout.DST[0]).To(Equal(uint32(0xffffabf0)))
+	})
+
+	It("should run FLAT_LOAD_DWORDX3", func() {
+		pageTable.EXPECT().Find(vm.PID(1), uint64(0x100)).
+			Return(vm.Page{PAddr: uint64(0)}, true)
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.FLAT
+		state.inst.Opcode = 22
+
+		layout := state.Scratchpad().AsFlat()
+		layout.ADDR[0] = 0x100
+		layout.EXEC = 0x1
+		for j := 0; j < 4; j++ {
+			storage.Write(uint64(0x100+j*4), insts.Uint32ToBytes(uint32(j+1)))
+		}
+
+		alu.Run(state)
+
+		Expect(layout.DST[0:4]).To(Equal([]uint32{1, 2, 3, 0}))
+	})
+
 	It("should run FLAT_LOAD_DWORDX4", func() {
 		for i := 0; i < 64; i++ {
 			pageTable.EXPECT().
@@ -307,4 +362,336 @@ var _ = Describe("ALU", func() {
 			Expect(insts.BytesToUint32(buf[12:16])).To(Equal(uint32(i)))
 		}
 	})
+
+	It("should run FLAT_LOAD_UBYTE_D16", func() {
+		pageTable.EXPECT().Find(vm.PID(1), uint64(0x100)).
+			Return(vm.Page{PAddr: uint64(0)}, true)
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.FLAT
+		state.inst.Opcode = 32
+
+		layout := state.Scratchpad().AsFlat()
+		layout.ADDR[0] = 0x100
+		layout.DST[0] = 0x12340080
+		layout.EXEC = 0x1
+		storage.Write(0x100, []byte{0xf0, 0xab, 0xcd, 0xef})
+
+		alu.Run(state)
+
+		Expect(layout.DST[0]).To(Equal(uint32(0x123400f0)))
+	})
+
+	It("should run FLAT_LOAD_UBYTE_D16_HI", func() {
+		pageTable.EXPECT().Find(vm.PID(1), uint64(0x100)).
+			Return(vm.Page{PAddr: uint64(0)}, true)
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.FLAT
+		state.inst.Opcode = 33
+
+		layout := state.Scratchpad().AsFlat()
+		layout.ADDR[0] = 0x100
+		layout.DST[0] = 0x12340080
+		layout.EXEC = 0x1
+		storage.Write(0x100, []byte{0xf0, 0xab, 0xcd, 0xef})
+
+		alu.Run(state)
+
+		Expect(layout.DST[0]).To(Equal(uint32(0x00f00080)))
+	})
+
+	It("should run FLAT_LOAD_SBYTE_D16", func() {
+		pageTable.EXPECT().Find(vm.PID(1), uint64(0x100)).
+			Return(vm.Page{PAddr: uint64(0)}, true)
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.FLAT
+		state.inst.Opcode = 34
+
+		layout := state.Scratchpad().AsFlat()
+		layout.ADDR[0] = 0x100
+		layout.DST[0] = 0x12340080
+		layout.EXEC = 0x1
+		storage.Write(0x100, []byte{0xf0, 0xab, 0xcd, 0xef})
+
+		alu.Run(state)
+
+		Expect(layout.DST[0]).To(Equal(uint32(0x1234fff0)))
+	})
+
+	It("should run FLAT_LOAD_SBYTE_D16_HI", func() {
+		pageTable.EXPECT().Find(vm.PID(1), uint64(0x100)).
+			Return(vm.Page{PAddr: uint64(0)}, true)
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.FLAT
+		state.inst.Opcode = 35
+
+		layout := state.Scratchpad().AsFlat()
+		layout.ADDR[0] = 0x100
+		layout.DST[0] = 0x12340080
+		layout.EXEC = 0x1
+		storage.Write(0x100, []byte{0xf0, 0xab, 0xcd, 0xef})
+
+		alu.Run(state)
+
+		Expect(layout.DST[0]).To(Equal(uint32(0xfff00080)))
+	})
+
+	It("should run FLAT_LOAD_SHORT_D16", func() {
+		pageTable.EXPECT().Find(vm.PID(1), uint64(0x100)).
+			Return(vm.Page{PAddr: uint64(0)}, true)
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.FLAT
+		state.inst.Opcode = 36
+
+		layout := state.Scratchpad().AsFlat()
+		layout.ADDR[0] = 0x100
+		layout.DST[0] = 0x12340080
+		layout.EXEC = 0x1
+		storage.Write(0x100, []byte{0xf0, 0xab, 0xcd, 0xef})
+
+		alu.Run(state)
+
+		Expect(layout.DST[0]).To(Equal(uint32(0x1234abf0)))
+	})
+
+	It("should run FLAT_LOAD_SHORT_D16_HI", func() {
+		pageTable.EXPECT().Find(vm.PID(1), uint64(0x100)).
+			Return(vm.Page{PAddr: uint64(0)}, true)
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.FLAT
+		state.inst.Opcode = 37
+
+		layout := state.Scratchpad().AsFlat()
+		layout.ADDR[0] = 0x100
+		layout.DST[0] = 0x12340080
+		layout.EXEC = 0x1
+		storage.Write(0x100, []byte{0xf0, 0xab, 0xcd, 0xef})
+
+		alu.Run(state)
+
+		Expect(layout.DST[0]).To(Equal(uint32(0xabf00080)))
+	})
+
+	It("should run FLAT_STORE_BYTE", func() {
+		pageTable.EXPECT().Find(vm.PID(1), uint64(0x100)).
+			Return(vm.Page{PAddr: uint64(0)}, true)
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.FLAT
+		state.inst.Opcode = 24
+
+		layout := state.Scratchpad().AsFlat()
+		layout.ADDR[0] = 0x100
+		layout.DATA[0] = 0x11335577
+		layout.EXEC = 0x1
+		storage.Write(0x100, []byte{0xaa, 0xbb, 0xcc, 0xdd})
+
+		alu.Run(state)
+
+		buf, err := storage.Read(0x100, 4)
+		Expect(err).To(BeNil())
+		Expect(buf).To(Equal([]byte{0x77, 0xbb, 0xcc, 0xdd}))
+	})
+
+	It("should run FLAT_STORE_SHORT", func() {
+		pageTable.EXPECT().Find(vm.PID(1), uint64(0x100)).
+			Return(vm.Page{PAddr: uint64(0)}, true)
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.FLAT
+		state.inst.Opcode = 26
+
+		layout := state.Scratchpad().AsFlat()
+		layout.ADDR[0] = 0x100
+		layout.DATA[0] = 0x11335577
+		layout.EXEC = 0x1
+		storage.Write(0x100, []byte{0xaa, 0xbb, 0xcc, 0xdd})
+
+		alu.Run(state)
+
+		buf, err := storage.Read(0x100, 4)
+		Expect(err).To(BeNil())
+		Expect(buf).To(Equal([]byte{0x77, 0x55, 0xcc, 0xdd}))
+	})
+
+	It("should run FLAT_STORE_BYTE_D16_HI", func() {
+		pageTable.EXPECT().Find(vm.PID(1), uint64(0x100)).
+			Return(vm.Page{PAddr: uint64(0)}, true)
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.FLAT
+		state.inst.Opcode = 25
+
+		layout := state.Scratchpad().AsFlat()
+		layout.ADDR[0] = 0x100
+		layout.DATA[0] = 0x11335577
+		layout.EXEC = 0x1
+		storage.Write(0x100, []byte{0xaa, 0xbb, 0xcc, 0xdd})
+
+		alu.Run(state)
+
+		buf, err := storage.Read(0x100, 4)
+		Expect(err).To(BeNil())
+		Expect(buf).To(Equal([]byte{0x33, 0xbb, 0xcc, 0xdd}))
+	})
+
+	It("should run FLAT_STORE_SHORT_D16_HI", func() {
+		pageTable.EXPECT().Find(vm.PID(1), uint64(0x100)).
+			Return(vm.Page{PAddr: uint64(0)}, true)
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.FLAT
+		state.inst.Opcode = 27
+
+		layout := state.Scratchpad().AsFlat()
+		layout.ADDR[0] = 0x100
+		layout.DATA[0] = 0x11335577
+		layout.EXEC = 0x1
+		storage.Write(0x100, []byte{0xaa, 0xbb, 0xcc, 0xdd})
+
+		alu.Run(state)
+
+		buf, err := storage.Read(0x100, 4)
+		Expect(err).To(BeNil())
+		Expect(buf).To(Equal([]byte{0x33, 0x11, 0xcc, 0xdd}))
+	})
+
+	It("should run FLAT_ATOMIC_ADD", func() {
+		pageTable.EXPECT().
+			Find(vm.PID(1), uint64(0x100)).
+			Return(vm.Page{PAddr: uint64(0)}, true).
+			Times(128)
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.FLAT
+		state.inst.Opcode = 66
+
+		layout := state.Scratchpad().AsFlat()
+		for i := 0; i < 64; i++ {
+			layout.ADDR[i] = 0x100
+			layout.DATA[i*4] = uint32(i)
+		}
+		layout.EXEC = 0xffffffffffffffff
+		storage.Write(0x100, insts.Uint32ToBytes(10))
+
+		alu.Run(state)
+
+		buf, _ := storage.Read(0x100, 4)
+		Expect(insts.BytesToUint32(buf)).To(Equal(uint32(10 + 63*64/2)))
+		for i := 0; i < 64; i++ {
+			Expect(layout.DST[i*4]).To(Equal(uint32(10 + i*(i-1)/2)))
+		}
+	})
+
+	It("should run FLAT_ATOMIC_CMPSWAP_X2", func() {
+		pageTable.EXPECT().
+			Find(vm.PID(1), gomock.Any()).
+			Return(vm.Page{PAddr: uint64(0)}, true).
+			AnyTimes()
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.FLAT
+		state.inst.Opcode = 97
+
+		layout := state.Scratchpad().AsFlat()
+		layout.EXEC = 0x3
+		for i := 0; i < 2; i++ {
+			layout.ADDR[i] = uint64(0x200 + i*8)
+			layout.DATA[i*4] = 0x1
+			layout.DATA[i*4+1] = 0x2
+			layout.DATA[i*4+2] = 0x3
+			layout.DATA[i*4+3] = 0x4
+		}
+		storage.Write(0x200, insts.Uint64ToBytes(0x0000000400000003))
+		storage.Write(0x208, insts.Uint64ToBytes(0x0000000400000005))
+
+		alu.Run(state)
+
+		buf, _ := storage.Read(0x200, 16)
+		Expect(insts.BytesToUint64(buf[0:8])).
+			To(Equal(uint64(0x0000000200000001)))
+		Expect(insts.BytesToUint64(buf[8:16])).
+			To(Equal(uint64(0x0000000400000005)))
+		Expect(layout.DST[0]).To(Equal(uint32(3)))
+		Expect(layout.DST[1]).To(Equal(uint32(4)))
+		Expect(layout.DST[4]).To(Equal(uint32(5)))
+		Expect(layout.DST[5]).To(Equal(uint32(4)))
+	})
+
+	It("should record the faults of the lanes that load unmapped addresses", func() {
+		pageTable.EXPECT().Find(vm.PID(1), uint64(0)).
+			Return(vm.Page{PAddr: uint64(0)}, true)
+		pageTable.EXPECT().Find(vm.PID(1), uint64(0x10000)).
+			Return(vm.Page{}, false)
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.FLAT
+		state.inst.Opcode = 20
+
+		layout := state.Scratchpad().AsFlat()
+		layout.ADDR[0] = 0
+		layout.ADDR[1] = 0x10000
+		layout.DST[4] = 0xffffffff
+		layout.EXEC = 0x3
+		storage.Write(0, insts.Uint32ToBytes(42))
+
+		alu.Run(state)
+
+		Expect(layout.DST[0]).To(Equal(uint32(42)))
+		Expect(layout.DST[4]).To(Equal(uint32(0)))
+		Expect(alu.takeFaults()).To(Equal([]laneFault{
+			{lane: 1, vAddr: 0x10000, err: ErrPageNotFound},
+		}))
+		Expect(alu.takeFaults()).To(BeEmpty())
+	})
+
+	It("should record the faults of the lanes that store to unmapped addresses", func() {
+		pageTable.EXPECT().Find(vm.PID(1), uint64(0x10000)).
+			Return(vm.Page{}, false)
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.FLAT
+		state.inst.Opcode = 28
+
+		layout := state.Scratchpad().AsFlat()
+		layout.ADDR[2] = 0x10000
+		layout.EXEC = 0x4
+
+		alu.Run(state)
+
+		Expect(alu.takeFaults()).To(Equal([]laneFault{
+			{lane: 2, vAddr: 0x10000, isWrite: true, err: ErrPageNotFound},
+		}))
+	})
+
+	It("should record the accesses that the address checker rejects", func() {
+		pageTable.EXPECT().Find(vm.PID(1), gomock.Any()).
+			Return(vm.Page{PAddr: uint64(0)}, true).AnyTimes()
+		alu.SetAddressChecker(&rangeChecker{start: 0, end: 0x100})
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.FLAT
+		state.inst.Opcode = 28
+
+		layout := state.Scratchpad().AsFlat()
+		layout.ADDR[0] = 0xfc
+		layout.ADDR[1] = 0xfe
+		layout.DATA[4] = 7
+		layout.EXEC = 0x3
+
+		alu.Run(state)
+
+		faults := alu.takeFaults()
+		Expect(faults).To(HaveLen(1))
+		Expect(faults[0].lane).To(Equal(1))
+		Expect(faults[0].isWrite).To(BeTrue())
+		buf, _ := storage.Read(0xfe, 4)
+		Expect(insts.BytesToUint32(buf)).To(Equal(uint32(7)))
+	})
 })
+
+// rangeChecker accepts the accesses within [start, end).
+type rangeChecker struct {
+	start, end uint64
+}
+
+func (c *rangeChecker) CheckAccess(
+	pid vm.PID,
+	vAddr, byteSize uint64,
+) error {
+	if vAddr < c.start || vAddr+byteSize > c.end {
+		return errors.New("out of range")
+	}
+
+	return nil
+}
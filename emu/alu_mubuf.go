@@ -0,0 +1,325 @@
+package emu
+
+import (
+	"log"
+	"math"
+
+	"github.com/sarchlab/mgpusim/v3/insts"
+)
+
+// Buffer data formats that have 32-bit components.
+const (
+	bufDataFormat32          = 4
+	bufDataFormat32x32       = 11
+	bufDataFormat32x32x32    = 13
+	bufDataFormat32x32x32x32 = 14
+)
+
+const bufNumFormatFloat = 7
+
+//nolint:gocyclo
+func (u *ALUImpl) runMUBUF(state InstEmuState) {
+	inst := state.Inst()
+	sp := state.Scratchpad().AsMUBUF()
+
+	if inst.LDS {
+		log.Panicf("MUBUF loads into LDS are not supported")
+	}
+
+	CalculateBufferAddresses(inst, sp)
+
+	switch inst.Opcode {
+	case 0, 1, 2, 3:
+		u.runBufferLoadFormat(state)
+	case 4, 5, 6, 7:
+		u.runBufferStore(state, BufferAccessDWords(inst, sp.SRSRC))
+	case 16:
+		u.runBufferLoadSubDWord(state, 1, false)
+	case 17:
+		u.runBufferLoadSubDWord(state, 1, true)
+	case 18:
+		u.runBufferLoadSubDWord(state, 2, false)
+	case 19:
+		u.runBufferLoadSubDWord(state, 2, true)
+	case 20, 21, 22, 23:
+		u.runBufferLoad(state, int(inst.Opcode)-19)
+	case 24:
+		u.runBufferStoreSubDWord(state, 1)
+	case 26:
+		u.runBufferStoreSubDWord(state, 2)
+	case 28, 29, 30, 31:
+		u.runBufferStore(state, int(inst.Opcode)-27)
+	case 62, 63: // BUFFER_WBINVL1, BUFFER_WBINVL1_VOL
+		// The emulator does not have caches.
+	default:
+		panicUnimplemented(inst)
+	}
+}
+
+func (u *ALUImpl) runMTBUF(state InstEmuState) {
+	inst := state.Inst()
+	sp := state.Scratchpad().AsMUBUF()
+
+	CalculateBufferAddresses(inst, sp)
+
+	switch inst.Opcode {
+	case 0, 1, 2, 3:
+		u.runBufferLoadFormat(state)
+	case 4, 5, 6, 7:
+		u.runBufferStore(state, BufferAccessDWords(inst, sp.SRSRC))
+	default:
+		panicUnimplemented(inst)
+	}
+}
+
+func (u *ALUImpl) runBufferLoad(state InstEmuState, numDWords int) {
+	sp := state.Scratchpad().AsMUBUF()
+	pid := state.PID()
+	mask := sp.EXEC & sp.InRange
+
+	zeroOutOfRangeLanes(sp, numDWords)
+
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(mask, i) {
+			continue
+		}
+
+		buf := u.readMemory(pid, int(i), sp.ADDR[i], uint64(4*numDWords))
+		for j := 0; j < numDWords; j++ {
+			sp.DST[int(i)*4+j] = insts.BytesToUint32(buf[j*4 : j*4+4])
+		}
+	}
+}
+
+func (u *ALUImpl) runBufferLoadSubDWord(
+	state InstEmuState,
+	byteSize int,
+	signed bool,
+) {
+	sp := state.Scratchpad().AsMUBUF()
+	pid := state.PID()
+	mask := sp.EXEC & sp.InRange
+
+	zeroOutOfRangeLanes(sp, 1)
+
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(mask, i) {
+			continue
+		}
+
+		buf := u.readMemory(pid, int(i), sp.ADDR[i], uint64(byteSize))
+
+		var value uint32
+		switch {
+		case byteSize == 1 && signed:
+			value = uint32(int32(int8(buf[0])))
+		case byteSize == 1:
+			value = uint32(buf[0])
+		case signed:
+			value = uint32(int32(int16(insts.BytesToUint16(buf))))
+		default:
+			value = uint32(insts.BytesToUint16(buf))
+		}
+
+		sp.DST[i*4] = value
+	}
+}
+
+func (u *ALUImpl) runBufferStore(state InstEmuState, numDWords int) {
+	sp := state.Scratchpad().AsMUBUF()
+	pid := state.PID()
+	mask := sp.EXEC & sp.InRange
+
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(mask, i) {
+			continue
+		}
+
+		buf := make([]byte, 4*numDWords)
+		for j := 0; j < numDWords; j++ {
+			copy(buf[j*4:j*4+4], insts.Uint32ToBytes(sp.DATA[int(i)*4+j]))
+		}
+
+		u.writeMemory(pid, int(i), sp.ADDR[i], buf)
+	}
+}
+
+func (u *ALUImpl) runBufferStoreSubDWord(state InstEmuState, byteSize int) {
+	sp := state.Scratchpad().AsMUBUF()
+	pid := state.PID()
+	mask := sp.EXEC & sp.InRange
+
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(mask, i) {
+			continue
+		}
+
+		buf := insts.Uint32ToBytes(sp.DATA[i*4])
+		u.writeMemory(pid, int(i), sp.ADDR[i], buf[:byteSize])
+	}
+}
+
+// runBufferLoadFormat loads the components that the data format has and
+// fills the other components with BufferLoadFill.
+func (u *ALUImpl) runBufferLoadFormat(state InstEmuState) {
+	inst := state.Inst()
+	sp := state.Scratchpad().AsMUBUF()
+	pid := state.PID()
+	mask := sp.EXEC & sp.InRange
+	numToRead := BufferAccessDWords(inst, sp.SRSRC)
+	fill := BufferLoadFill(inst, sp.SRSRC)
+
+	zeroOutOfRangeLanes(sp, numToRead+len(fill))
+
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(mask, i) {
+			continue
+		}
+
+		buf := u.readMemory(pid, int(i), sp.ADDR[i], uint64(4*numToRead))
+		for j := 0; j < numToRead; j++ {
+			sp.DST[int(i)*4+j] = insts.BytesToUint32(buf[j*4 : j*4+4])
+		}
+
+		copy(sp.DST[int(i)*4+numToRead:], fill)
+	}
+}
+
+// zeroOutOfRangeLanes writes 0 to the destination registers of the active
+// lanes whose accesses fall outside of the buffer.
+func zeroOutOfRangeLanes(sp *MUBUFLayout, numDWords int) {
+	mask := sp.EXEC &^ sp.InRange
+
+	for i := 0; i < 64; i++ {
+		if !laneMasked(mask, uint(i)) {
+			continue
+		}
+
+		for j := 0; j < numDWords; j++ {
+			sp.DST[i*4+j] = 0
+		}
+	}
+}
+
+// IsBufferLoad checks if a MUBUF or MTBUF instruction reads from the memory.
+func IsBufferLoad(inst *insts.Inst) bool {
+	switch inst.Opcode {
+	case 0, 1, 2, 3, 8, 9, 10, 11:
+		return true
+	case 16, 17, 18, 19, 20, 21, 22, 23:
+		return inst.FormatType == insts.MUBUF
+	}
+
+	return false
+}
+
+// BufferAccessDWords returns the number of dwords that each lane of a MUBUF or
+// MTBUF instruction reads from or writes to the memory. Sub-dword accesses
+// count as one dword. Format accesses only touch the components that the data
+// format has. The buffer resource descriptor is only used by MUBUF format
+// accesses, which take the data format from it.
+func BufferAccessDWords(inst *insts.Inst, srsrc [4]uint32) int {
+	if isBufferFormatInst(inst) {
+		numComponents := int(inst.Opcode%4) + 1
+		dataFormat, _ := bufferFormat(inst, srsrc)
+		formatComponents := bufferDataFormatComponents(dataFormat)
+		if formatComponents < numComponents {
+			return formatComponents
+		}
+
+		return numComponents
+	}
+
+	switch inst.Opcode {
+	case 16, 17, 18, 19, 20, 24, 25, 26, 27, 28:
+		return 1
+	case 21, 29:
+		return 2
+	case 22, 30:
+		return 3
+	case 23, 31:
+		return 4
+	}
+
+	log.Panicf("buffer opcode %d is not supported", inst.Opcode)
+
+	panic("never")
+}
+
+// BufferLoadFill returns the values of the destination registers that a
+// buffer format load does not read from the memory, in register order.
+// Components that the data format does not have are filled with 0, except for
+// W which is 1.
+func BufferLoadFill(inst *insts.Inst, srsrc [4]uint32) []uint32 {
+	if !isBufferFormatInst(inst) || !IsBufferLoad(inst) {
+		return nil
+	}
+
+	numComponents := int(inst.Opcode%4) + 1
+	numToRead := BufferAccessDWords(inst, srsrc)
+	fill := make([]uint32, numComponents-numToRead)
+
+	if numComponents == 4 && numToRead < 4 {
+		_, numFormat := bufferFormat(inst, srsrc)
+		fill[len(fill)-1] = 1
+		if numFormat == bufNumFormatFloat {
+			fill[len(fill)-1] = math.Float32bits(1.0)
+		}
+	}
+
+	return fill
+}
+
+func isBufferFormatInst(inst *insts.Inst) bool {
+	return (inst.FormatType == insts.MUBUF || inst.FormatType == insts.MTBUF) &&
+		inst.Opcode <= 7
+}
+
+// bufferFormat returns the data format and the number format of a buffer
+// format access. MTBUF instructions carry the formats in the instruction,
+// while MUBUF instructions use the formats of the buffer resource.
+func bufferFormat(inst *insts.Inst, srsrc [4]uint32) (dataFormat, numFormat int) {
+	if inst.FormatType == insts.MTBUF {
+		return inst.DataFormat, inst.NumFormat
+	}
+
+	resource := NewBufferResource(srsrc)
+
+	return int(resource.DataFormat), int(resource.NumFormat)
+}
+
+// bufferAccessByteSize returns the number of bytes that each lane of a MUBUF
+// or MTBUF instruction touches in the buffer.
+func bufferAccessByteSize(inst *insts.Inst, srsrc [4]uint32) uint32 {
+	if isBufferFormatInst(inst) {
+		return uint32(4 * BufferAccessDWords(inst, srsrc))
+	}
+
+	switch inst.Opcode {
+	case 16, 17, 24, 25:
+		return 1
+	case 18, 19, 26, 27:
+		return 2
+	case 20, 21, 22, 23, 28, 29, 30, 31:
+		return uint32(4 * BufferAccessDWords(inst, srsrc))
+	default:
+		return 4
+	}
+}
+
+func bufferDataFormatComponents(dataFormat int) int {
+	switch dataFormat {
+	case bufDataFormat32:
+		return 1
+	case bufDataFormat32x32:
+		return 2
+	case bufDataFormat32x32x32:
+		return 3
+	case bufDataFormat32x32x32x32:
+		return 4
+	default:
+		log.Panicf("buffer data format %d is not supported", dataFormat)
+	}
+
+	panic("never")
+}
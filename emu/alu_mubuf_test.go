@@ -0,0 +1,212 @@
+package emu
+
+import (
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/mem/vm"
+	"github.com/sarchlab/mgpusim/v3/insts"
+)
+
+var _ = Describe("ALU", func() {
+
+	var (
+		mockCtrl  *gomock.Controller
+		pageTable *MockPageTable
+
+		alu       *ALUImpl
+		state     *mockInstState
+		storage   *mem.Storage
+		sAccessor *storageAccessor
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		pageTable = NewMockPageTable(mockCtrl)
+		pageTable.EXPECT().
+			Find(vm.PID(1), gomock.Any()).
+			Return(vm.Page{PAddr: 0}, true).
+			AnyTimes()
+
+		storage = mem.NewStorage(1 * mem.GB)
+		sAccessor = newStorageAccessor(storage, pageTable, 12, nil)
+		alu = NewALU(sAccessor)
+
+		state = new(mockInstState)
+		state.scratchpad = make([]byte, 4096)
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	It("should run BUFFER_LOAD_DWORD", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.MUBUF
+		state.inst.Opcode = 20
+		state.inst.OffEn = true
+		state.inst.Offset0 = 4
+
+		layout := state.Scratchpad().AsMUBUF()
+		layout.EXEC = 0xffffffffffffffff
+		layout.SRSRC = [4]uint32{0x1000, 0, 60 * 4, 0}
+		for i := 0; i < 64; i++ {
+			layout.VADDR[i*2] = uint32(i * 4)
+			storage.Write(uint64(0x1004+i*4), insts.Uint32ToBytes(uint32(i)))
+		}
+
+		alu.Run(state)
+
+		for i := 0; i < 59; i++ {
+			Expect(layout.DST[i*4]).To(Equal(uint32(i)))
+		}
+		for i := 59; i < 64; i++ {
+			Expect(layout.DST[i*4]).To(Equal(uint32(0)))
+		}
+	})
+
+	It("should run BUFFER_LOAD_SSHORT", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.MUBUF
+		state.inst.Opcode = 19
+		state.inst.IdxEn = true
+
+		layout := state.Scratchpad().AsMUBUF()
+		layout.EXEC = 0x1
+		layout.SRSRC = [4]uint32{0x1000, 8 << 16, 16, 0}
+		layout.VADDR[0] = 2
+		storage.Write(0x1010, insts.Uint16ToBytes(0xfffe))
+
+		alu.Run(state)
+
+		Expect(layout.DST[0]).To(Equal(uint32(0xfffffffe)))
+	})
+
+	It("should run BUFFER_LOAD_FORMAT_XYZW", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.MUBUF
+		state.inst.Opcode = 3
+
+		layout := state.Scratchpad().AsMUBUF()
+		layout.EXEC = 0x1
+		layout.SRSRC = [4]uint32{0x1000, 0, 64, 4<<15 | 7<<12}
+		storage.Write(0x1000, insts.Uint32ToBytes(42))
+
+		alu.Run(state)
+
+		Expect(layout.DST[0]).To(Equal(uint32(42)))
+		Expect(layout.DST[1]).To(Equal(uint32(0)))
+		Expect(layout.DST[2]).To(Equal(uint32(0)))
+		Expect(layout.DST[3]).To(Equal(uint32(0x3f800000)))
+	})
+
+	It("should zero the out-of-range lanes of a dirty scratchpad", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.MUBUF
+		state.inst.Opcode = 21
+		state.inst.OffEn = true
+
+		layout := state.Scratchpad().AsMUBUF()
+		layout.EXEC = 0x3
+		layout.SRSRC = [4]uint32{0x1000, 0, 8, 0}
+		layout.VADDR[0] = 0
+		layout.VADDR[2] = 8
+		for i := range layout.DST {
+			layout.DST[i] = 0xdeadbeef
+		}
+		storage.Write(0x1000, insts.Uint32ToBytes(1))
+		storage.Write(0x1004, insts.Uint32ToBytes(2))
+
+		alu.Run(state)
+
+		Expect(layout.DST[0:2]).To(Equal([]uint32{1, 2}))
+		Expect(layout.DST[4:6]).To(Equal([]uint32{0, 0}))
+	})
+
+	It("should fill the missing format components of a dirty scratchpad",
+		func() {
+			state.inst = insts.NewInst()
+			state.inst.FormatType = insts.MTBUF
+			state.inst.Opcode = 3
+			state.inst.DataFormat = 11
+			state.inst.NumFormat = 4
+
+			layout := state.Scratchpad().AsMUBUF()
+			layout.EXEC = 0x1
+			layout.SRSRC = [4]uint32{0x4000, 0, 64, 0}
+			for i := range layout.DST {
+				layout.DST[i] = 0xdeadbeef
+			}
+			storage.Write(0x4000, insts.Uint32ToBytes(5))
+			storage.Write(0x4004, insts.Uint32ToBytes(6))
+
+			alu.Run(state)
+
+			Expect(layout.DST[0:4]).To(Equal([]uint32{5, 6, 0, 1}))
+		})
+
+	It("should run BUFFER_STORE_DWORDX2", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.MUBUF
+		state.inst.Opcode = 29
+		state.inst.OffEn = true
+
+		layout := state.Scratchpad().AsMUBUF()
+		layout.EXEC = 0x3
+		layout.SRSRC = [4]uint32{0x2000, 0, 16, 0}
+		layout.SOFFSET = 0x10
+		for i := 0; i < 2; i++ {
+			layout.VADDR[i*2] = uint32(i * 8)
+			layout.DATA[i*4] = uint32(i + 1)
+			layout.DATA[i*4+1] = uint32(i + 2)
+		}
+
+		alu.Run(state)
+
+		buf, _ := storage.Read(0x2010, 16)
+		Expect(insts.BytesToUint32(buf[0:4])).To(Equal(uint32(1)))
+		Expect(insts.BytesToUint32(buf[4:8])).To(Equal(uint32(2)))
+		Expect(insts.BytesToUint32(buf[8:12])).To(Equal(uint32(2)))
+		Expect(insts.BytesToUint32(buf[12:16])).To(Equal(uint32(3)))
+	})
+
+	It("should drop out-of-range stores", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.MUBUF
+		state.inst.Opcode = 24
+		state.inst.OffEn = true
+
+		layout := state.Scratchpad().AsMUBUF()
+		layout.EXEC = 0x3
+		layout.SRSRC = [4]uint32{0x3000, 0, 1, 0}
+		layout.VADDR[0] = 0
+		layout.VADDR[2] = 1
+		layout.DATA[0] = 0xab
+		layout.DATA[4] = 0xcd
+
+		alu.Run(state)
+
+		buf, _ := storage.Read(0x3000, 2)
+		Expect(buf).To(Equal([]byte{0xab, 0}))
+	})
+
+	It("should run TBUFFER_LOAD_FORMAT_XY", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.MTBUF
+		state.inst.Opcode = 1
+		state.inst.DataFormat = 11
+		state.inst.NumFormat = 4
+
+		layout := state.Scratchpad().AsMUBUF()
+		layout.EXEC = 0x1
+		layout.SRSRC = [4]uint32{0x4000, 0, 64, 0}
+		storage.Write(0x4000, insts.Uint32ToBytes(5))
+		storage.Write(0x4004, insts.Uint32ToBytes(6))
+
+		alu.Run(state)
+
+		Expect(layout.DST[0]).To(Equal(uint32(5)))
+		Expect(layout.DST[1]).To(Equal(uint32(6)))
+	})
+})
@@ -1,7 +1,7 @@
 package emu
 
 import (
-	"log"
+	"github.com/sarchlab/mgpusim/v3/insts"
 )
 
 func (u *ALUImpl) runDS(state InstEmuState) {
@@ -22,7 +22,57 @@ func (u *ALUImpl) runDS(state InstEmuState) {
 	case 119:
 		u.runDSREAD2B64(state)
 	default:
-		log.Panicf("Opcode %d for DS format is not implemented", inst.Opcode)
+		if atomic, isAtomic := DSAtomicInfo(inst); isAtomic {
+			u.runDSAtomic(state, atomic)
+			return
+		}
+
+		panicUnimplemented(inst)
+	}
+}
+
+func (u *ALUImpl) runDSAtomic(state InstEmuState, atomic AtomicInfo) {
+	inst := state.Inst()
+	layout := state.Scratchpad().AsDS()
+	lds := u.LDS()
+
+	i := uint(0)
+	for i = 0; i < 64; i++ {
+		if !laneMasked(layout.EXEC, i) {
+			continue
+		}
+
+		addr := layout.ADDR[i] + inst.Offset0
+
+		data0 := uint64(layout.DATA[i*4])
+		data1 := uint64(layout.DATA1[i*4])
+		if atomic.Is64Bit {
+			data0 |= uint64(layout.DATA[i*4+1]) << 32
+			data1 |= uint64(layout.DATA1[i*4+1]) << 32
+		}
+
+		// With two data operands, DATA0 is the compare value or the mask.
+		src, cmp := data0, uint64(0)
+		if inst.Data1 != nil {
+			src, cmp = data1, data0
+		}
+
+		if atomic.Is64Bit {
+			old := insts.BytesToUint64(lds[addr : addr+8])
+			value := ApplyAtomic64(atomic.Op, old, src, cmp)
+			copy(lds[addr:addr+8], insts.Uint64ToBytes(value))
+
+			layout.DST[i*4] = uint32(old)
+			layout.DST[i*4+1] = uint32(old >> 32)
+
+			continue
+		}
+
+		old := insts.BytesToUint32(lds[addr : addr+4])
+		value := ApplyAtomic32(atomic.Op, old, uint32(src), uint32(cmp))
+		copy(lds[addr:addr+4], insts.Uint32ToBytes(value))
+
+		layout.DST[i*4] = old
 	}
 }
 
@@ -42,6 +92,7 @@ func (u *ALUImpl) runDSWRITEB32(state InstEmuState) {
 		data0offset := uint(8 + 64*4)
 
 		copy(lds[addr0:addr0+4], sp[data0offset+i*16:data0offset+i*16+4])
+		u.recordAccess(LDSSpace, int(i), uint64(addr0), 4, true)
 	}
 }
 
@@ -64,6 +115,8 @@ func (u *ALUImpl) runDSWRITE2B32(state InstEmuState) {
 
 		copy(lds[addr0:addr0+4], sp[data0offset+i*16:data0offset+i*16+4])
 		copy(lds[addr1:addr1+4], sp[data1offset+i*16:data1offset+i*16+4])
+		u.recordAccess(LDSSpace, int(i), uint64(addr0), 4, true)
+		u.recordAccess(LDSSpace, int(i), uint64(addr1), 4, true)
 	}
 }
 
@@ -83,6 +136,7 @@ func (u *ALUImpl) runDSREADB32(state InstEmuState) {
 		// addr0 := layout.ADDR[i]
 		dstOffset := uint(8 + 64*4 + 256*4*2)
 		copy(sp[dstOffset+i*16:dstOffset+i*16+4], lds[addr0:addr0+4])
+		u.recordAccess(LDSSpace, int(i), uint64(addr0), 4, false)
 	}
 }
 
@@ -101,9 +155,11 @@ func (u *ALUImpl) runDSREAD2B32(state InstEmuState) {
 		addr0 := layout.ADDR[i] + inst.Offset0*4
 		dstOffset := uint(8 + 64*4 + 256*4*2)
 		copy(sp[dstOffset+i*16:dstOffset+i*16+4], lds[addr0:addr0+4])
+		u.recordAccess(LDSSpace, int(i), uint64(addr0), 4, false)
 
 		addr1 := layout.ADDR[i] + inst.Offset1*4
 		copy(sp[dstOffset+i*16+4:dstOffset+i*16+8], lds[addr1:addr1+4])
+		u.recordAccess(LDSSpace, int(i), uint64(addr1), 4, false)
 	}
 }
 
@@ -122,10 +178,12 @@ func (u *ALUImpl) runDSWRITE2B64(state InstEmuState) {
 		addr0 := layout.ADDR[i] + inst.Offset0*8
 		data0Offset := uint(8 + 64*4)
 		copy(lds[addr0:addr0+8], sp[data0Offset+i*16:data0Offset+i*16+8])
+		u.recordAccess(LDSSpace, int(i), uint64(addr0), 8, true)
 
 		addr1 := layout.ADDR[i] + inst.Offset1*8
 		data1Offset := uint(8 + 64*4 + 256*4)
 		copy(lds[addr1:addr1+8], sp[data1Offset+i*16:data1Offset+i*16+8])
+		u.recordAccess(LDSSpace, int(i), uint64(addr1), 8, true)
 	}
 }
 
@@ -143,6 +201,7 @@ func (u *ALUImpl) runDSREADB64(state InstEmuState) {
 		addr := layout.ADDR[i]
 		dstOffset := uint(8 + 64*4 + 256*4*2)
 		copy(sp[dstOffset+i*16:dstOffset+i*16+8], lds[addr:addr+8])
+		u.recordAccess(LDSSpace, int(i), uint64(addr), 8, false)
 	}
 }
 
@@ -161,8 +220,10 @@ func (u *ALUImpl) runDSREAD2B64(state InstEmuState) {
 		addr0 := layout.ADDR[i] + inst.Offset0*8
 		dstOffset := uint(8 + 64*4 + 256*4*2)
 		copy(sp[dstOffset+i*16:dstOffset+i*16+8], lds[addr0:addr0+8])
+		u.recordAccess(LDSSpace, int(i), uint64(addr0), 8, false)
 
 		addr1 := layout.ADDR[i] + inst.Offset1*8
 		copy(sp[dstOffset+i*16+8:dstOffset+i*16+16], lds[addr1:addr1+8])
+		u.recordAccess(LDSSpace, int(i), uint64(addr1), 8, false)
 	}
 }
@@ -158,4 +158,72 @@ var _ = Describe("ALU", func() {
 		Expect(sp.DST[2]).To(Equal(uint32(156)))
 	})
 
+	It("should run DS_ADD_U32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.DS
+		state.inst.Opcode = 0
+		state.inst.Offset0 = 4
+
+		sp := state.scratchpad.AsDS()
+		sp.EXEC = 0xf
+		for i := 0; i < 4; i++ {
+			sp.ADDR[i] = 96
+			sp.DATA[i*4] = uint32(i + 1)
+		}
+
+		lds := alu.LDS()
+		copy(lds[100:], insts.Uint32ToBytes(10))
+
+		alu.Run(state)
+
+		Expect(insts.BytesToUint32(lds[100:])).To(Equal(uint32(20)))
+	})
+
+	It("should run DS_CMPST_RTN_B32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.DS
+		state.inst.Opcode = 48
+		state.inst.Data1 = insts.NewVRegOperand(2, 2, 1)
+
+		sp := state.scratchpad.AsDS()
+		sp.EXEC = 0x3
+		sp.ADDR[0] = 100
+		sp.ADDR[1] = 104
+		sp.DATA[0] = 1
+		sp.DATA1[0] = 7
+		sp.DATA[4] = 1
+		sp.DATA1[4] = 8
+
+		lds := alu.LDS()
+		copy(lds[100:], insts.Uint32ToBytes(1))
+		copy(lds[104:], insts.Uint32ToBytes(2))
+
+		alu.Run(state)
+
+		Expect(insts.BytesToUint32(lds[100:])).To(Equal(uint32(7)))
+		Expect(insts.BytesToUint32(lds[104:])).To(Equal(uint32(2)))
+		Expect(sp.DST[0]).To(Equal(uint32(1)))
+		Expect(sp.DST[4]).To(Equal(uint32(2)))
+	})
+
+	It("should run DS_MAX_RTN_I64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.DS
+		state.inst.Opcode = 102
+
+		sp := state.scratchpad.AsDS()
+		sp.EXEC = 0x1
+		sp.ADDR[0] = 96
+		sp.DATA[0] = 0xffffffff
+		sp.DATA[1] = 0xffffffff
+
+		lds := alu.LDS()
+		copy(lds[96:], insts.Uint64ToBytes(5))
+
+		alu.Run(state)
+
+		Expect(insts.BytesToUint64(lds[96:])).To(Equal(uint64(5)))
+		Expect(sp.DST[0]).To(Equal(uint32(5)))
+		Expect(sp.DST[1]).To(Equal(uint32(0)))
+	})
 })
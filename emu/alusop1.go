@@ -1,21 +1,71 @@
 package emu
 
-import "log"
+import "math/bits"
 
-//nolint:gocyclo
+//nolint:gocyclo,funlen
 func (u *ALUImpl) runSOP1(state InstEmuState) {
 	inst := state.Inst()
 	switch inst.Opcode {
-	case 0:
+	case 0, 42, 44: // s_mov_b32, s_movrels_b32, s_movreld_b32
 		u.runSMOVB32(state)
-	case 1:
+	case 1, 43, 45: // s_mov_b64, s_movrels_b64, s_movreld_b64
 		u.runSMOVB64(state)
+	case 2, 3:
+		u.runSCMOV(state)
 	case 4:
 		u.runSNOTU32(state)
+	case 5:
+		u.runSNOTB64(state)
+	case 6:
+		u.runSWQMB32(state)
+	case 7:
+		u.runSWQMB64(state)
 	case 8:
 		u.runSBREVB32(state)
+	case 9:
+		u.runSBREVB64(state)
+	case 10:
+		u.runSBCNT0I32B32(state)
+	case 11:
+		u.runSBCNT0I32B64(state)
+	case 12:
+		u.runSBCNT1I32B32(state)
+	case 13:
+		u.runSBCNT1I32B64(state)
+	case 14:
+		u.runSFF0I32B32(state)
+	case 15:
+		u.runSFF0I32B64(state)
+	case 16:
+		u.runSFF1I32B32(state)
+	case 17:
+		u.runSFF1I32B64(state)
+	case 18:
+		u.runSFLBITI32B32(state)
+	case 19:
+		u.runSFLBITI32B64(state)
+	case 20:
+		u.runSFLBITI32(state)
+	case 21:
+		u.runSFLBITI32I64(state)
+	case 22:
+		u.runSSEXTI32I8(state)
+	case 23:
+		u.runSSEXTI32I16(state)
+	case 24:
+		u.runSBITSET0B32(state)
+	case 25:
+		u.runSBITSET0B64(state)
+	case 26:
+		u.runSBITSET1B32(state)
+	case 27:
+		u.runSBITSET1B64(state)
 	case 28:
 		u.runSGETPCB64(state)
+	case 29:
+		u.runSSETPCB64(state)
+	case 30:
+		u.runSSWAPPCB64(state)
 	case 32:
 		u.runSANDSAVEEXECB64(state)
 	case 33:
@@ -32,8 +82,24 @@ func (u *ALUImpl) runSOP1(state InstEmuState) {
 		u.runSNORSAVEEXECB64(state)
 	case 39:
 		u.runSNXORSAVEEXECB64(state)
+	case 40:
+		u.runSQUADMASKB32(state)
+	case 41:
+		u.runSQUADMASKB64(state)
+	case 48:
+		u.runSABSI32(state)
+	case 51:
+		u.runSANDN1SAVEEXECB64(state)
+	case 52:
+		u.runSORN1SAVEEXECB64(state)
+	case 53:
+		u.runSANDN1WREXECB64(state)
+	case 54:
+		u.runSANDN2WREXECB64(state)
+	case 55:
+		u.runSBITREPLICATEB64B32(state)
 	default:
-		log.Panicf("Opcode %d for SOP1 format is not implemented", inst.Opcode)
+		panicUnimplemented(inst)
 	}
 }
 
@@ -68,9 +134,21 @@ func (u *ALUImpl) runSBREVB32(state InstEmuState) {
 	sp.DST = uint64(dst)
 }
 
+// The PC in the scratchpad is the address of the next instruction.
 func (u *ALUImpl) runSGETPCB64(state InstEmuState) {
 	sp := state.Scratchpad().AsSOP1()
-	sp.DST = sp.PC + 4
+	sp.DST = sp.PC
+}
+
+func (u *ALUImpl) runSSETPCB64(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP1()
+	sp.PC = sp.SRC0
+}
+
+func (u *ALUImpl) runSSWAPPCB64(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP1()
+	sp.DST = sp.PC
+	sp.PC = sp.SRC0
 }
 
 func (u *ALUImpl) runSANDSAVEEXECB64(state InstEmuState) {
@@ -160,3 +238,314 @@ func (u *ALUImpl) runSNXORSAVEEXECB64(state InstEmuState) {
 		sp.SCC = 0
 	}
 }
+
+// runSCMOV runs both s_cmov_b32 and s_cmov_b64, which keep the original
+// destination when SCC is not set.
+func (u *ALUImpl) runSCMOV(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP1()
+	if sp.SCC == 1 {
+		sp.DST = sp.SRC0
+	}
+}
+
+func (u *ALUImpl) runSNOTB64(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP1()
+	sp.DST = ^sp.SRC0
+	if sp.DST != 0 {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+// wholeQuadMode sets all the bits of each group of 4 bits if any bit in the
+// group is set.
+func wholeQuadMode(src uint64, numBits int) uint64 {
+	dst := uint64(0)
+	for i := 0; i < numBits; i += 4 {
+		if (src>>i)&0xf != 0 {
+			dst |= 0xf << i
+		}
+	}
+
+	return dst
+}
+
+func (u *ALUImpl) runSWQMB32(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP1()
+	sp.DST = wholeQuadMode(sp.SRC0, 32)
+	if sp.DST != 0 {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+func (u *ALUImpl) runSWQMB64(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP1()
+	sp.DST = wholeQuadMode(sp.SRC0, 64)
+	if sp.DST != 0 {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+func (u *ALUImpl) runSBREVB64(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP1()
+	sp.DST = bits.Reverse64(sp.SRC0)
+}
+
+func (u *ALUImpl) runSBCNT0I32B32(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP1()
+	sp.DST = uint64(32 - bits.OnesCount32(uint32(sp.SRC0)))
+	if sp.DST != 0 {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+func (u *ALUImpl) runSBCNT0I32B64(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP1()
+	sp.DST = uint64(64 - bits.OnesCount64(sp.SRC0))
+	if sp.DST != 0 {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+func (u *ALUImpl) runSBCNT1I32B32(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP1()
+	sp.DST = uint64(bits.OnesCount32(uint32(sp.SRC0)))
+	if sp.DST != 0 {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+func (u *ALUImpl) runSBCNT1I32B64(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP1()
+	sp.DST = uint64(bits.OnesCount64(sp.SRC0))
+	if sp.DST != 0 {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+// findFirstOne returns the index of the lowest set bit among the lowest
+// numBits bits of src, or -1 if there is no set bit.
+func findFirstOne(src uint64, numBits int) int32 {
+	index := bits.TrailingZeros64(src)
+	if index >= numBits {
+		return -1
+	}
+
+	return int32(index)
+}
+
+// findLastOne returns the index of the highest set bit among the lowest
+// numBits bits of src, counting from the most significant bit, or -1 if
+// there is no set bit.
+func findLastOne(src uint64, numBits int) int32 {
+	if numBits < 64 {
+		src &= (1 << numBits) - 1
+	}
+
+	if src == 0 {
+		return -1
+	}
+
+	return int32(bits.LeadingZeros64(src) - (64 - numBits))
+}
+
+func (u *ALUImpl) runSFF0I32B32(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP1()
+	sp.DST = uint64(uint32(findFirstOne(^sp.SRC0, 32)))
+}
+
+func (u *ALUImpl) runSFF0I32B64(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP1()
+	sp.DST = uint64(uint32(findFirstOne(^sp.SRC0, 64)))
+}
+
+func (u *ALUImpl) runSFF1I32B32(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP1()
+	sp.DST = uint64(uint32(findFirstOne(sp.SRC0, 32)))
+}
+
+func (u *ALUImpl) runSFF1I32B64(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP1()
+	sp.DST = uint64(uint32(findFirstOne(sp.SRC0, 64)))
+}
+
+func (u *ALUImpl) runSFLBITI32B32(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP1()
+	sp.DST = uint64(uint32(findLastOne(sp.SRC0, 32)))
+}
+
+func (u *ALUImpl) runSFLBITI32B64(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP1()
+	sp.DST = uint64(uint32(findLastOne(sp.SRC0, 64)))
+}
+
+// runSFLBITI32 finds the first bit that differs from the sign bit, counting
+// from the most significant bit.
+func (u *ALUImpl) runSFLBITI32(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP1()
+	src := uint32(sp.SRC0)
+	if asInt32(src) < 0 {
+		src = ^src
+	}
+
+	sp.DST = uint64(uint32(findLastOne(uint64(src), 32)))
+}
+
+func (u *ALUImpl) runSFLBITI32I64(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP1()
+	src := sp.SRC0
+	if asInt64(src) < 0 {
+		src = ^src
+	}
+
+	sp.DST = uint64(uint32(findLastOne(src, 64)))
+}
+
+func (u *ALUImpl) runSSEXTI32I8(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP1()
+	sp.DST = uint64(int32ToBits(int32(int8(sp.SRC0))))
+}
+
+func (u *ALUImpl) runSSEXTI32I16(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP1()
+	sp.DST = uint64(int32ToBits(int32(int16(sp.SRC0))))
+}
+
+func (u *ALUImpl) runSBITSET0B32(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP1()
+	sp.DST &^= 1 << (sp.SRC0 & 0x1f)
+}
+
+func (u *ALUImpl) runSBITSET0B64(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP1()
+	sp.DST &^= 1 << (sp.SRC0 & 0x3f)
+}
+
+func (u *ALUImpl) runSBITSET1B32(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP1()
+	sp.DST |= 1 << (sp.SRC0 & 0x1f)
+}
+
+func (u *ALUImpl) runSBITSET1B64(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP1()
+	sp.DST |= 1 << (sp.SRC0 & 0x3f)
+}
+
+// quadMask sets bit i of the result if any bit in the i-th group of 4 bits
+// of src is set.
+func quadMask(src uint64, numBits int) uint64 {
+	dst := uint64(0)
+	for i := 0; i < numBits; i += 4 {
+		if (src>>i)&0xf != 0 {
+			dst |= 1 << (i / 4)
+		}
+	}
+
+	return dst
+}
+
+func (u *ALUImpl) runSQUADMASKB32(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP1()
+	sp.DST = quadMask(sp.SRC0, 32)
+	if sp.DST != 0 {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+func (u *ALUImpl) runSQUADMASKB64(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP1()
+	sp.DST = quadMask(sp.SRC0, 64)
+	if sp.DST != 0 {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+func (u *ALUImpl) runSABSI32(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP1()
+	src := asInt32(uint32(sp.SRC0))
+	if src < 0 {
+		src = -src
+	}
+
+	sp.DST = uint64(int32ToBits(src))
+	if sp.DST != 0 {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+func (u *ALUImpl) runSANDN1SAVEEXECB64(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP1()
+	sp.DST = sp.EXEC
+	sp.EXEC = (^sp.SRC0) & sp.EXEC
+	if sp.EXEC != 0 {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+func (u *ALUImpl) runSORN1SAVEEXECB64(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP1()
+	sp.DST = sp.EXEC
+	sp.EXEC = (^sp.SRC0) | sp.EXEC
+	if sp.EXEC != 0 {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+// The wrexec instructions write the new EXEC, rather than the old one, into
+// the destination.
+func (u *ALUImpl) runSANDN1WREXECB64(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP1()
+	sp.EXEC = (^sp.SRC0) & sp.EXEC
+	sp.DST = sp.EXEC
+	if sp.EXEC != 0 {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+func (u *ALUImpl) runSANDN2WREXECB64(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP1()
+	sp.EXEC = sp.SRC0 & (^sp.EXEC)
+	sp.DST = sp.EXEC
+	if sp.EXEC != 0 {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+func (u *ALUImpl) runSBITREPLICATEB64B32(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP1()
+	src := uint32(sp.SRC0)
+	dst := uint64(0)
+	for i := 0; i < 32; i++ {
+		bit := uint64((src >> i) & 1)
+		dst |= bit << (2 * i)
+		dst |= bit << (2*i + 1)
+	}
+	sp.DST = dst
+}
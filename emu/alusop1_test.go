@@ -83,7 +83,7 @@ var _ = Describe("ALU", func() {
 
 		alu.Run(state)
 
-		Expect(sp.DST).To(Equal(uint64(0xffffffff00000004)))
+		Expect(sp.DST).To(Equal(uint64(0xffffffff00000000)))
 	})
 
 	It("should run s_and_saveexec_b64", func() {
@@ -214,4 +214,392 @@ var _ = Describe("ALU", func() {
 		Expect(sp.SCC).To(Equal(byte(0x1)))
 	})
 
+	It("should run s_cmov_b32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOP1
+		state.inst.Opcode = 2
+
+		sp := state.Scratchpad().AsSOP1()
+		sp.SRC0 = 5
+		sp.DST = 3
+		sp.SCC = 1
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(5)))
+	})
+
+	It("should run s_cmov_b32 when SCC is not set", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOP1
+		state.inst.Opcode = 2
+
+		sp := state.Scratchpad().AsSOP1()
+		sp.SRC0 = 5
+		sp.DST = 3
+		sp.SCC = 0
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(3)))
+	})
+
+	It("should run s_not_b64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOP1
+		state.inst.Opcode = 5
+
+		sp := state.Scratchpad().AsSOP1()
+		sp.SRC0 = 0xffffffffffffffff
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(0)))
+		Expect(sp.SCC).To(Equal(byte(0)))
+	})
+
+	It("should run s_wqm_b64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOP1
+		state.inst.Opcode = 7
+
+		sp := state.Scratchpad().AsSOP1()
+		sp.SRC0 = 0x0000000000000121
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(0xfff)))
+		Expect(sp.SCC).To(Equal(byte(1)))
+	})
+
+	It("should run s_brev_b64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOP1
+		state.inst.Opcode = 9
+
+		sp := state.Scratchpad().AsSOP1()
+		sp.SRC0 = 0x1
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(0x8000000000000000)))
+	})
+
+	It("should run s_bcnt0_i32_b32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOP1
+		state.inst.Opcode = 10
+
+		sp := state.Scratchpad().AsSOP1()
+		sp.SRC0 = 0xffffffff
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(0)))
+		Expect(sp.SCC).To(Equal(byte(0)))
+	})
+
+	It("should run s_bcnt1_i32_b64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOP1
+		state.inst.Opcode = 13
+
+		sp := state.Scratchpad().AsSOP1()
+		sp.SRC0 = 0xf0000000f0f0
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(12)))
+		Expect(sp.SCC).To(Equal(byte(1)))
+	})
+
+	It("should run s_ff0_i32_b64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOP1
+		state.inst.Opcode = 15
+
+		sp := state.Scratchpad().AsSOP1()
+		sp.SRC0 = 0xff
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(8)))
+	})
+
+	It("should run s_ff1_i32_b32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOP1
+		state.inst.Opcode = 16
+
+		sp := state.Scratchpad().AsSOP1()
+		sp.SRC0 = 0x100
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(8)))
+	})
+
+	It("should run s_ff1_i32_b32 when no bit is set", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOP1
+		state.inst.Opcode = 16
+
+		sp := state.Scratchpad().AsSOP1()
+		sp.SRC0 = 0xffffffff00000000
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(0xffffffff)))
+	})
+
+	It("should run s_flbit_i32_b32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOP1
+		state.inst.Opcode = 18
+
+		sp := state.Scratchpad().AsSOP1()
+		sp.SRC0 = 0x00010000
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(15)))
+	})
+
+	It("should run s_flbit_i32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOP1
+		state.inst.Opcode = 20
+
+		sp := state.Scratchpad().AsSOP1()
+		sp.SRC0 = 0xffff0000
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(16)))
+	})
+
+	It("should run s_sext_i32_i8", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOP1
+		state.inst.Opcode = 22
+
+		sp := state.Scratchpad().AsSOP1()
+		sp.SRC0 = 0x80
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(0xffffff80)))
+	})
+
+	It("should run s_bitset0_b64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOP1
+		state.inst.Opcode = 25
+
+		sp := state.Scratchpad().AsSOP1()
+		sp.SRC0 = 63
+		sp.DST = 0xffffffffffffffff
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(0x7fffffffffffffff)))
+	})
+
+	It("should run s_bitset1_b32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOP1
+		state.inst.Opcode = 26
+
+		sp := state.Scratchpad().AsSOP1()
+		sp.SRC0 = 4
+		sp.DST = 0x1
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(0x11)))
+	})
+
+	It("should run s_quadmask_b32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOP1
+		state.inst.Opcode = 40
+
+		sp := state.Scratchpad().AsSOP1()
+		sp.SRC0 = 0x00f00010
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(0x22)))
+		Expect(sp.SCC).To(Equal(byte(1)))
+	})
+
+	It("should run s_abs_i32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOP1
+		state.inst.Opcode = 48
+
+		sp := state.Scratchpad().AsSOP1()
+		sp.SRC0 = 0xfffffffb
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(5)))
+		Expect(sp.SCC).To(Equal(byte(1)))
+	})
+
+	It("should run s_setpc_b64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOP1
+		state.inst.Opcode = 29
+
+		sp := state.Scratchpad().AsSOP1()
+		sp.SRC0 = 0x1000
+		sp.PC = 0x24
+
+		alu.Run(state)
+
+		Expect(sp.PC).To(Equal(uint64(0x1000)))
+	})
+
+	It("should run s_swappc_b64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOP1
+		state.inst.Opcode = 30
+
+		sp := state.Scratchpad().AsSOP1()
+		sp.SRC0 = 0x1000
+		sp.PC = 0x24
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(0x24)))
+		Expect(sp.PC).To(Equal(uint64(0x1000)))
+	})
+
+	It("should run s_andn1_saveexec_b64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOP1
+		state.inst.Opcode = 51
+
+		sp := state.Scratchpad().AsSOP1()
+		sp.EXEC = 0xff
+		sp.SRC0 = 0x0f
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(0xff)))
+		Expect(sp.EXEC).To(Equal(uint64(0xf0)))
+		Expect(sp.SCC).To(Equal(byte(1)))
+	})
+
+	It("should run s_orn1_saveexec_b64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOP1
+		state.inst.Opcode = 52
+
+		sp := state.Scratchpad().AsSOP1()
+		sp.EXEC = 0x0f
+		sp.SRC0 = 0xffffffffffffff00
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(0x0f)))
+		Expect(sp.EXEC).To(Equal(uint64(0xff)))
+	})
+
+	It("should run s_andn1_wrexec_b64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOP1
+		state.inst.Opcode = 53
+
+		sp := state.Scratchpad().AsSOP1()
+		sp.EXEC = 0xff
+		sp.SRC0 = 0x0f
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(0xf0)))
+		Expect(sp.EXEC).To(Equal(uint64(0xf0)))
+	})
+
+	It("should run s_andn2_wrexec_b64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOP1
+		state.inst.Opcode = 54
+
+		sp := state.Scratchpad().AsSOP1()
+		sp.EXEC = 0xff
+		sp.SRC0 = 0xff
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(0)))
+		Expect(sp.EXEC).To(Equal(uint64(0)))
+		Expect(sp.SCC).To(Equal(byte(0)))
+	})
+
+	It("should run s_bitreplicate_b64_b32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOP1
+		state.inst.Opcode = 55
+
+		sp := state.Scratchpad().AsSOP1()
+		sp.SRC0 = 0x80000005
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(0xc000000000000033)))
+	})
+
+	It("should run s_movrels_b64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOP1
+		state.inst.Opcode = 43
+
+		sp := state.Scratchpad().AsSOP1()
+		sp.SRC0 = 0xffffffff00000001
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(0xffffffff00000001)))
+	})
+
+	It("should run s_movreld_b64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOP1
+		state.inst.Opcode = 45
+
+		sp := state.Scratchpad().AsSOP1()
+		sp.SRC0 = 0xffffffff00000001
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(0xffffffff00000001)))
+	})
+
+	It("should run s_movrels_b32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOP1
+		state.inst.Opcode = 42
+
+		sp := state.Scratchpad().AsSOP1()
+		sp.SRC0 = 517
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(517)))
+	})
+
+	It("should run s_movreld_b32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOP1
+		state.inst.Opcode = 44
+
+		sp := state.Scratchpad().AsSOP1()
+		sp.SRC0 = 517
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(517)))
+	})
 })
@@ -1,7 +1,6 @@
 package emu
 
 import (
-	"log"
 	"math"
 
 	"github.com/sarchlab/mgpusim/v3/bitops"
@@ -32,18 +31,38 @@ func (u *ALUImpl) runSOP2(state InstEmuState) {
 		u.runSMAXI32(state)
 	case 9:
 		u.runSMAXU32(state)
-	case 10:
+	case 10, 11:
 		u.runSCSELECTB32(state)
 	case 12:
 		u.runSANDB32(state)
 	case 13:
 		u.runSANDB64(state)
+	case 14:
+		u.runSORB32(state)
 	case 15:
 		u.runSORB64(state)
 	case 16, 17:
 		u.runSXORB64(state)
+	case 18:
+		u.runSANDN2B32(state)
 	case 19:
 		u.runSANDN2B64(state)
+	case 20:
+		u.runSORN2B32(state)
+	case 21:
+		u.runSORN2B64(state)
+	case 22:
+		u.runSNANDB32(state)
+	case 23:
+		u.runSNANDB64(state)
+	case 24:
+		u.runSNORB32(state)
+	case 25:
+		u.runSNORB64(state)
+	case 26:
+		u.runSXNORB32(state)
+	case 27:
+		u.runSXNORB64(state)
 	case 28:
 		u.runSLSHLB32(state)
 	case 29:
@@ -54,14 +73,38 @@ func (u *ALUImpl) runSOP2(state InstEmuState) {
 		u.runSLSHRB64(state)
 	case 32:
 		u.runSASHRI32(state)
+	case 33:
+		u.runSASHRI64(state)
 	case 34:
 		u.runSBFMB32(state)
+	case 35:
+		u.runSBFMB64(state)
 	case 36:
 		u.runSMULI32(state)
+	case 37:
+		u.runSBFEU32(state)
 	case 38:
 		u.runSBFEI32(state)
+	case 39:
+		u.runSBFEU64(state)
+	case 40:
+		u.runSBFEI64(state)
+	case 42:
+		u.runSABSDIFFI32(state)
+	case 44:
+		u.runSMULHIU32(state)
+	case 45:
+		u.runSMULHII32(state)
+	case 46, 47, 48, 49:
+		u.runSLSHLADDU32(state, uint(inst.Opcode-45))
+	case 50:
+		u.runSPACKLLB32B16(state)
+	case 51:
+		u.runSPACKLHB32B16(state)
+	case 52:
+		u.runSPACKHHB32B16(state)
 	default:
-		log.Panicf("Opcode %d for SOP2 format is not implemented", inst.Opcode)
+		panicUnimplemented(inst)
 	}
 }
 
@@ -380,3 +423,260 @@ func (u *ALUImpl) runSBFEI32(state InstEmuState) {
 		sp.SCC = 0
 	}
 }
+
+func (u *ALUImpl) runSORB32(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP2()
+
+	sp.DST = uint64(uint32(sp.SRC0 | sp.SRC1))
+	if sp.DST != 0 {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+func (u *ALUImpl) runSANDN2B32(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP2()
+
+	sp.DST = uint64(uint32(sp.SRC0 &^ sp.SRC1))
+	if sp.DST != 0 {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+func (u *ALUImpl) runSORN2B32(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP2()
+
+	sp.DST = uint64(uint32(sp.SRC0 | ^sp.SRC1))
+	if sp.DST != 0 {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+func (u *ALUImpl) runSORN2B64(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP2()
+
+	sp.DST = sp.SRC0 | ^sp.SRC1
+	if sp.DST != 0 {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+func (u *ALUImpl) runSNANDB32(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP2()
+
+	sp.DST = uint64(^uint32(sp.SRC0 & sp.SRC1))
+	if sp.DST != 0 {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+func (u *ALUImpl) runSNANDB64(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP2()
+
+	sp.DST = ^(sp.SRC0 & sp.SRC1)
+	if sp.DST != 0 {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+func (u *ALUImpl) runSNORB32(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP2()
+
+	sp.DST = uint64(^uint32(sp.SRC0 | sp.SRC1))
+	if sp.DST != 0 {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+func (u *ALUImpl) runSNORB64(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP2()
+
+	sp.DST = ^(sp.SRC0 | sp.SRC1)
+	if sp.DST != 0 {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+func (u *ALUImpl) runSXNORB32(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP2()
+
+	sp.DST = uint64(^uint32(sp.SRC0 ^ sp.SRC1))
+	if sp.DST != 0 {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+func (u *ALUImpl) runSXNORB64(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP2()
+
+	sp.DST = ^(sp.SRC0 ^ sp.SRC1)
+	if sp.DST != 0 {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+func (u *ALUImpl) runSASHRI64(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP2()
+
+	src0 := asInt64(sp.SRC0)
+	dst := src0 >> (sp.SRC1 & 0x3f)
+
+	sp.DST = int64ToBits(dst)
+
+	if sp.DST != 0 {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+func (u *ALUImpl) runSBFMB64(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP2()
+
+	sp.DST = ((1 << (sp.SRC0 & 0x3f)) - 1) << (sp.SRC1 & 0x3f)
+}
+
+func (u *ALUImpl) runSBFEU32(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP2()
+
+	src0 := uint32(sp.SRC0)
+	src1 := uint32(sp.SRC1)
+	offset := bitops.ExtractBitsFromU32(src1, 0, 4)
+	width := bitops.ExtractBitsFromU32(src1, 16, 22)
+	dst := uint32((uint64(src0) >> offset) & ((1 << width) - 1))
+
+	sp.DST = uint64(dst)
+
+	if dst != 0 {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+func (u *ALUImpl) runSBFEU64(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP2()
+
+	src1 := uint32(sp.SRC1)
+	offset := bitops.ExtractBitsFromU32(src1, 0, 5)
+	width := bitops.ExtractBitsFromU32(src1, 16, 22)
+	dst := sp.SRC0 >> offset
+	if width < 64 {
+		dst &= (1 << width) - 1
+	}
+
+	sp.DST = dst
+
+	if dst != 0 {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+func (u *ALUImpl) runSBFEI64(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP2()
+
+	src1 := uint32(sp.SRC1)
+	offset := bitops.ExtractBitsFromU32(src1, 0, 5)
+	width := bitops.ExtractBitsFromU32(src1, 16, 22)
+
+	dst := uint64(0)
+	if width > 0 {
+		dst = sp.SRC0 >> offset
+		if width < 64 {
+			dst = bitops.SignExt(dst&((1<<width)-1), int(width-1))
+		}
+	}
+
+	sp.DST = dst
+
+	if dst != 0 {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+func (u *ALUImpl) runSABSDIFFI32(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP2()
+
+	src0 := int64(asInt32(uint32(sp.SRC0)))
+	src1 := int64(asInt32(uint32(sp.SRC1)))
+	dst := src0 - src1
+	if dst < 0 {
+		dst = -dst
+	}
+
+	// The difference of the most negative and the most positive numbers
+	// wraps around, as the hardware does.
+	sp.DST = uint64(uint32(dst))
+
+	if sp.DST != 0 {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+func (u *ALUImpl) runSMULHIU32(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP2()
+	src0 := uint64(uint32(sp.SRC0))
+	src1 := uint64(uint32(sp.SRC1))
+	sp.DST = (src0 * src1) >> 32
+}
+
+func (u *ALUImpl) runSMULHII32(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP2()
+	src0 := int64(asInt32(uint32(sp.SRC0)))
+	src1 := int64(asInt32(uint32(sp.SRC1)))
+	sp.DST = uint64(uint32((src0 * src1) >> 32))
+}
+
+// runSLSHLADDU32 runs s_lshl1_add_u32 to s_lshl4_add_u32, which shift SRC0
+// left by shift bits before adding SRC1. SCC is the carry out.
+func (u *ALUImpl) runSLSHLADDU32(state InstEmuState, shift uint) {
+	sp := state.Scratchpad().AsSOP2()
+	dst := (uint64(uint32(sp.SRC0)) << shift) + uint64(uint32(sp.SRC1))
+	sp.DST = uint64(uint32(dst))
+
+	if dst > math.MaxUint32 {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+func (u *ALUImpl) runSPACKLLB32B16(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP2()
+	sp.DST = uint64(uint16(sp.SRC0)) | uint64(uint16(sp.SRC1))<<16
+}
+
+func (u *ALUImpl) runSPACKLHB32B16(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP2()
+	sp.DST = uint64(uint16(sp.SRC0)) | uint64(uint16(sp.SRC1>>16))<<16
+}
+
+func (u *ALUImpl) runSPACKHHB32B16(state InstEmuState) {
+	sp := state.Scratchpad().AsSOP2()
+	sp.DST = uint64(uint16(sp.SRC0>>16)) | uint64(uint16(sp.SRC1>>16))<<16
+}
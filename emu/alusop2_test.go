@@ -539,4 +539,252 @@ var _ = Describe("ALU", func() {
 		Expect(sp.SCC).To(Equal(byte(1)))
 	})
 
+	It("should run s_cselect_b32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOP2
+		state.inst.Opcode = 10
+
+		sp := state.Scratchpad().AsSOP2()
+		sp.SRC0 = 1
+		sp.SRC1 = 2
+		sp.SCC = 0
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(2)))
+	})
+
+	It("should run s_or_b32 with a negative inline constant", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOP2
+		state.inst.Opcode = 14
+
+		sp := state.Scratchpad().AsSOP2()
+		sp.SRC0 = 0xffffffffffffff00
+		sp.SRC1 = 0x0f
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(0xffffff0f)))
+		Expect(sp.SCC).To(Equal(byte(1)))
+	})
+
+	It("should run s_andn2_b32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOP2
+		state.inst.Opcode = 18
+
+		sp := state.Scratchpad().AsSOP2()
+		sp.SRC0 = 0xff
+		sp.SRC1 = 0xffffffff0000000f
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(0xf0)))
+		Expect(sp.SCC).To(Equal(byte(1)))
+	})
+
+	It("should run s_nor_b32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOP2
+		state.inst.Opcode = 24
+
+		sp := state.Scratchpad().AsSOP2()
+		sp.SRC0 = 0xffff0000
+		sp.SRC1 = 0x0000fff0
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(0xf)))
+		Expect(sp.SCC).To(Equal(byte(1)))
+	})
+
+	It("should run s_xnor_b64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOP2
+		state.inst.Opcode = 27
+
+		sp := state.Scratchpad().AsSOP2()
+		sp.SRC0 = 0xff00
+		sp.SRC1 = 0x0ff0
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(0xffffffffffff0f0f)))
+		Expect(sp.SCC).To(Equal(byte(1)))
+	})
+
+	It("should run s_ashr_i64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOP2
+		state.inst.Opcode = 33
+
+		sp := state.Scratchpad().AsSOP2()
+		sp.SRC0 = 0x8000000000000000
+		sp.SRC1 = 4
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(0xf800000000000000)))
+		Expect(sp.SCC).To(Equal(byte(1)))
+	})
+
+	It("should run s_bfm_b64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOP2
+		state.inst.Opcode = 35
+
+		sp := state.Scratchpad().AsSOP2()
+		sp.SRC0 = 4
+		sp.SRC1 = 8
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(0xf00)))
+	})
+
+	It("should run s_bfe_u32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOP2
+		state.inst.Opcode = 37
+
+		sp := state.Scratchpad().AsSOP2()
+		sp.SRC0 = 0x12345678
+		sp.SRC1 = 0x00080008
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(0x56)))
+		Expect(sp.SCC).To(Equal(byte(1)))
+	})
+
+	It("should run s_bfe_i64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOP2
+		state.inst.Opcode = 40
+
+		sp := state.Scratchpad().AsSOP2()
+		sp.SRC0 = 0x8000
+		sp.SRC1 = 0x0004000c
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(0xfffffffffffffff8)))
+		Expect(sp.SCC).To(Equal(byte(1)))
+	})
+
+	It("should run s_absdiff_i32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOP2
+		state.inst.Opcode = 42
+
+		sp := state.Scratchpad().AsSOP2()
+		sp.SRC0 = 3
+		sp.SRC1 = 0xfffffffe
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(5)))
+		Expect(sp.SCC).To(Equal(byte(1)))
+	})
+
+	It("should run s_mul_hi_u32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOP2
+		state.inst.Opcode = 44
+
+		sp := state.Scratchpad().AsSOP2()
+		sp.SRC0 = 0xffffffff
+		sp.SRC1 = 2
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(1)))
+	})
+
+	It("should run s_mul_hi_i32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOP2
+		state.inst.Opcode = 45
+
+		sp := state.Scratchpad().AsSOP2()
+		sp.SRC0 = 0xffffffff
+		sp.SRC1 = 2
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(0xffffffff)))
+	})
+
+	It("should run s_lshl2_add_u32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOP2
+		state.inst.Opcode = 47
+
+		sp := state.Scratchpad().AsSOP2()
+		sp.SRC0 = 3
+		sp.SRC1 = 1
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(13)))
+		Expect(sp.SCC).To(Equal(byte(0)))
+	})
+
+	It("should run s_lshl1_add_u32 with carry", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOP2
+		state.inst.Opcode = 46
+
+		sp := state.Scratchpad().AsSOP2()
+		sp.SRC0 = 0x80000001
+		sp.SRC1 = 1
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(3)))
+		Expect(sp.SCC).To(Equal(byte(1)))
+	})
+
+	It("should run s_pack_ll_b32_b16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOP2
+		state.inst.Opcode = 50
+
+		sp := state.Scratchpad().AsSOP2()
+		sp.SRC0 = 0x1234abcd
+		sp.SRC1 = 0x5678ef01
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(0xef01abcd)))
+	})
+
+	It("should run s_pack_lh_b32_b16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOP2
+		state.inst.Opcode = 51
+
+		sp := state.Scratchpad().AsSOP2()
+		sp.SRC0 = 0x1234abcd
+		sp.SRC1 = 0x5678ef01
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(0x5678abcd)))
+	})
+
+	It("should run s_pack_hh_b32_b16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOP2
+		state.inst.Opcode = 52
+
+		sp := state.Scratchpad().AsSOP2()
+		sp.SRC0 = 0x1234abcd
+		sp.SRC1 = 0x5678ef01
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(0x56781234)))
+	})
 })
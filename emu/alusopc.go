@@ -1,9 +1,5 @@
 package emu
 
-import (
-	"log"
-)
-
 //nolint:gocyclo,funlen
 func (u *ALUImpl) runSOPC(state InstEmuState) {
 	inst := state.Inst()
@@ -26,10 +22,26 @@ func (u *ALUImpl) runSOPC(state InstEmuState) {
 		u.runSCMPLGU32(state)
 	case 8:
 		u.runSCMPGTU32(state)
+	case 9:
+		u.runSCMPGEU32(state)
 	case 10:
 		u.runSCMPLTU32(state)
+	case 11:
+		u.runSCMPLEU32(state)
+	case 12:
+		u.runSBITCMP0B32(state)
+	case 13:
+		u.runSBITCMP1B32(state)
+	case 14:
+		u.runSBITCMP0B64(state)
+	case 15:
+		u.runSBITCMP1B64(state)
+	case 18:
+		u.runSCMPEQU64(state)
+	case 19:
+		u.runSCMPNEU64(state)
 	default:
-		log.Panicf("Opcode %d for SOPC format is not implemented", inst.Opcode)
+		panicUnimplemented(inst)
 	}
 }
 
@@ -112,3 +124,75 @@ func (u *ALUImpl) runSCMPLTU32(state InstEmuState) {
 		sp.SCC = 0
 	}
 }
+
+func (u *ALUImpl) runSCMPGEU32(state InstEmuState) {
+	sp := state.Scratchpad().AsSOPC()
+	if uint32(sp.SRC0) >= uint32(sp.SRC1) {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+func (u *ALUImpl) runSCMPLEU32(state InstEmuState) {
+	sp := state.Scratchpad().AsSOPC()
+	if uint32(sp.SRC0) <= uint32(sp.SRC1) {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+func (u *ALUImpl) runSBITCMP0B32(state InstEmuState) {
+	sp := state.Scratchpad().AsSOPC()
+	if uint32(sp.SRC0)&(1<<(sp.SRC1&0x1f)) == 0 {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+func (u *ALUImpl) runSBITCMP1B32(state InstEmuState) {
+	sp := state.Scratchpad().AsSOPC()
+	if uint32(sp.SRC0)&(1<<(sp.SRC1&0x1f)) != 0 {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+func (u *ALUImpl) runSBITCMP0B64(state InstEmuState) {
+	sp := state.Scratchpad().AsSOPC()
+	if sp.SRC0&(1<<(sp.SRC1&0x3f)) == 0 {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+func (u *ALUImpl) runSBITCMP1B64(state InstEmuState) {
+	sp := state.Scratchpad().AsSOPC()
+	if sp.SRC0&(1<<(sp.SRC1&0x3f)) != 0 {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+func (u *ALUImpl) runSCMPEQU64(state InstEmuState) {
+	sp := state.Scratchpad().AsSOPC()
+	if sp.SRC0 == sp.SRC1 {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+func (u *ALUImpl) runSCMPNEU64(state InstEmuState) {
+	sp := state.Scratchpad().AsSOPC()
+	if sp.SRC0 != sp.SRC1 {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
@@ -257,4 +257,74 @@ var _ = Describe("ALU", func() {
 
 		Expect(layout.SCC).To(Equal(byte(1)))
 	})
+
+	It("should run s_cmp_ge_u32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOPC
+		state.inst.Opcode = 9
+
+		sp := state.Scratchpad().AsSOPC()
+		sp.SRC0 = 0xffffffff
+		sp.SRC1 = 1
+
+		alu.Run(state)
+
+		Expect(sp.SCC).To(Equal(byte(1)))
+	})
+
+	It("should run s_cmp_le_u32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOPC
+		state.inst.Opcode = 11
+
+		sp := state.Scratchpad().AsSOPC()
+		sp.SRC0 = 2
+		sp.SRC1 = 1
+
+		alu.Run(state)
+
+		Expect(sp.SCC).To(Equal(byte(0)))
+	})
+
+	It("should run s_bitcmp1_b32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOPC
+		state.inst.Opcode = 13
+
+		sp := state.Scratchpad().AsSOPC()
+		sp.SRC0 = 0x10
+		sp.SRC1 = 4
+
+		alu.Run(state)
+
+		Expect(sp.SCC).To(Equal(byte(1)))
+	})
+
+	It("should run s_bitcmp0_b64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOPC
+		state.inst.Opcode = 14
+
+		sp := state.Scratchpad().AsSOPC()
+		sp.SRC0 = 1 << 40
+		sp.SRC1 = 40
+
+		alu.Run(state)
+
+		Expect(sp.SCC).To(Equal(byte(0)))
+	})
+
+	It("should run s_cmp_ne_u64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOPC
+		state.inst.Opcode = 19
+
+		sp := state.Scratchpad().AsSOPC()
+		sp.SRC0 = 1 << 32
+		sp.SRC1 = 0
+
+		alu.Run(state)
+
+		Expect(sp.SCC).To(Equal(byte(1)))
+	})
 })
@@ -1,20 +1,51 @@
 package emu
 
-import (
-	"log"
-)
-
+//nolint:gocyclo
 func (u *ALUImpl) runSOPK(state InstEmuState) {
 	inst := state.Inst()
 	switch inst.Opcode {
 	case 0:
 		u.runSMOVKI32(state)
+	case 1:
+		u.runSCMOVKI32(state)
+	case 2:
+		u.runSCMPKEQI32(state)
 	case 3:
 		u.runSCMPKLGI32(state)
+	case 4:
+		u.runSCMPKGTI32(state)
+	case 5:
+		u.runSCMPKGEI32(state)
+	case 6:
+		u.runSCMPKLTI32(state)
+	case 7:
+		u.runSCMPKLEI32(state)
+	case 8:
+		u.runSCMPKEQU32(state)
+	case 9:
+		u.runSCMPKLGU32(state)
+	case 10:
+		u.runSCMPKGTU32(state)
+	case 11:
+		u.runSCMPKGEU32(state)
+	case 12:
+		u.runSCMPKLTU32(state)
+	case 13:
+		u.runSCMPKLEU32(state)
+	case 14:
+		u.runSADDKI32(state)
 	case 15:
 		u.runSMULKI32(state)
+	case 17:
+		u.runSGETREGB32(state)
+	case 18:
+		u.runSSETREGB32(state, uint32(state.Scratchpad().AsSOPK().DST))
+	case 20:
+		u.runSSETREGB32(state, uint32(state.Scratchpad().AsSOPK().SRC0))
+	case 21:
+		u.runSCALLB64(state)
 	default:
-		log.Panicf("Opcode %d for SOPK format is not implemented", inst.Opcode)
+		panicUnimplemented(inst)
 	}
 }
 
@@ -24,10 +55,156 @@ func (u *ALUImpl) runSMOVKI32(state InstEmuState) {
 	sp.DST = uint64(imm)
 }
 
-func (u *ALUImpl) runSCMPKLGI32(state InstEmuState) {
+func (u *ALUImpl) runSCMOVKI32(state InstEmuState) {
 	sp := state.Scratchpad().AsSOPK()
 	imm := asInt16(uint16(sp.IMM & 0xffff))
-	if asInt16(uint16(sp.DST)) != imm {
+	if sp.SCC == 1 {
+		sp.DST = uint64(int32ToBits(int32(imm)))
+	}
+}
+
+// sopkSignedOperands returns the 32-bit signed register operand and the
+// sign-extended immediate of a SOPK instruction.
+func sopkSignedOperands(sp *SOPKLayout) (int32, int32) {
+	return asInt32(uint32(sp.DST)), int32(asInt16(uint16(sp.IMM & 0xffff)))
+}
+
+// sopkUnsignedOperands returns the 32-bit unsigned register operand and the
+// zero-extended immediate of a SOPK instruction.
+func sopkUnsignedOperands(sp *SOPKLayout) (uint32, uint32) {
+	return uint32(sp.DST), uint32(sp.IMM & 0xffff)
+}
+
+func (u *ALUImpl) runSCMPKEQI32(state InstEmuState) {
+	sp := state.Scratchpad().AsSOPK()
+	src, imm := sopkSignedOperands(sp)
+	if src == imm {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+func (u *ALUImpl) runSCMPKLGI32(state InstEmuState) {
+	sp := state.Scratchpad().AsSOPK()
+	src, imm := sopkSignedOperands(sp)
+	if src != imm {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+func (u *ALUImpl) runSCMPKGTI32(state InstEmuState) {
+	sp := state.Scratchpad().AsSOPK()
+	src, imm := sopkSignedOperands(sp)
+	if src > imm {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+func (u *ALUImpl) runSCMPKGEI32(state InstEmuState) {
+	sp := state.Scratchpad().AsSOPK()
+	src, imm := sopkSignedOperands(sp)
+	if src >= imm {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+func (u *ALUImpl) runSCMPKLTI32(state InstEmuState) {
+	sp := state.Scratchpad().AsSOPK()
+	src, imm := sopkSignedOperands(sp)
+	if src < imm {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+func (u *ALUImpl) runSCMPKLEI32(state InstEmuState) {
+	sp := state.Scratchpad().AsSOPK()
+	src, imm := sopkSignedOperands(sp)
+	if src <= imm {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+func (u *ALUImpl) runSCMPKEQU32(state InstEmuState) {
+	sp := state.Scratchpad().AsSOPK()
+	src, imm := sopkUnsignedOperands(sp)
+	if src == imm {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+func (u *ALUImpl) runSCMPKLGU32(state InstEmuState) {
+	sp := state.Scratchpad().AsSOPK()
+	src, imm := sopkUnsignedOperands(sp)
+	if src != imm {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+func (u *ALUImpl) runSCMPKGTU32(state InstEmuState) {
+	sp := state.Scratchpad().AsSOPK()
+	src, imm := sopkUnsignedOperands(sp)
+	if src > imm {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+func (u *ALUImpl) runSCMPKGEU32(state InstEmuState) {
+	sp := state.Scratchpad().AsSOPK()
+	src, imm := sopkUnsignedOperands(sp)
+	if src >= imm {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+func (u *ALUImpl) runSCMPKLTU32(state InstEmuState) {
+	sp := state.Scratchpad().AsSOPK()
+	src, imm := sopkUnsignedOperands(sp)
+	if src < imm {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+func (u *ALUImpl) runSCMPKLEU32(state InstEmuState) {
+	sp := state.Scratchpad().AsSOPK()
+	src, imm := sopkUnsignedOperands(sp)
+	if src <= imm {
+		sp.SCC = 1
+	} else {
+		sp.SCC = 0
+	}
+}
+
+func (u *ALUImpl) runSADDKI32(state InstEmuState) {
+	sp := state.Scratchpad().AsSOPK()
+	src, imm := sopkSignedOperands(sp)
+	dst := src + imm
+
+	sp.DST = uint64(int32ToBits(dst))
+
+	// Signed overflow happens when both operands have the same sign and the
+	// result has the other sign.
+	if (src >= 0) == (imm >= 0) && (dst >= 0) != (src >= 0) {
 		sp.SCC = 1
 	} else {
 		sp.SCC = 0
@@ -41,3 +218,50 @@ func (u *ALUImpl) runSMULKI32(state InstEmuState) {
 
 	sp.DST = int64ToBits(int64(int32(imm) * dst))
 }
+
+// runSCALLB64 saves the address of the next instruction in the destination
+// and jumps by the signed immediate, counted in words.
+func (u *ALUImpl) runSCALLB64(state InstEmuState) {
+	sp := state.Scratchpad().AsSOPK()
+	imm := asInt16(uint16(sp.IMM & 0xffff))
+
+	sp.DST = sp.PC
+	sp.PC = uint64(int64(sp.PC) + int64(imm)*4)
+}
+
+// hwRegMode is the ID of the MODE hardware register. The emulator only keeps
+// the MODE register. The other hardware registers read as 0 and ignore the
+// writes.
+const hwRegMode = 1
+
+// hwRegField returns the hardware register, the offset and the mask of the
+// bits that the SIMM16 of s_getreg_b32 and s_setreg_b32 selects.
+func hwRegField(simm16 uint64) (id, offset, mask uint32) {
+	id = uint32(simm16 & 0x3f)
+	offset = uint32(simm16>>6) & 0x1f
+	size := uint32(simm16>>11)&0x1f + 1
+	mask = uint32((uint64(1)<<size - 1) << offset)
+
+	return id, offset, mask
+}
+
+func (u *ALUImpl) runSGETREGB32(state InstEmuState) {
+	sp := state.Scratchpad().AsSOPK()
+	id, offset, mask := hwRegField(sp.IMM)
+
+	var value uint32
+	if id == hwRegMode {
+		value = sp.MODE
+	}
+
+	sp.DST = uint64((value & mask) >> offset)
+}
+
+func (u *ALUImpl) runSSETREGB32(state InstEmuState, value uint32) {
+	sp := state.Scratchpad().AsSOPK()
+	id, offset, mask := hwRegField(sp.IMM)
+
+	if id == hwRegMode {
+		sp.MODE = sp.MODE&^mask | (value<<offset)&mask
+	}
+}
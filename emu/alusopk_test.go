@@ -75,4 +75,133 @@ var _ = Describe("ALU", func() {
 		Expect(asInt64(sp.DST)).To(Equal(int64(-20000)))
 	})
 
+	It("should run s_cmovk_i32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOPK
+		state.inst.Opcode = 1
+
+		sp := state.Scratchpad().AsSOPK()
+		sp.IMM = 0xfffe
+		sp.SCC = 1
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(0xfffffffe)))
+	})
+
+	It("should run s_cmpk_gt_i32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOPK
+		state.inst.Opcode = 4
+
+		sp := state.Scratchpad().AsSOPK()
+		sp.DST = 0
+		sp.IMM = 0xffff
+
+		alu.Run(state)
+
+		Expect(sp.SCC).To(Equal(byte(1)))
+	})
+
+	It("should run s_cmpk_lt_u32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOPK
+		state.inst.Opcode = 12
+
+		sp := state.Scratchpad().AsSOPK()
+		sp.DST = 0xffffffff
+		sp.IMM = 0xffff
+
+		alu.Run(state)
+
+		Expect(sp.SCC).To(Equal(byte(0)))
+	})
+
+	It("should run s_addk_i32 with overflow", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOPK
+		state.inst.Opcode = 14
+
+		sp := state.Scratchpad().AsSOPK()
+		sp.DST = 0x7fffffff
+		sp.IMM = 1
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(0x80000000)))
+		Expect(sp.SCC).To(Equal(byte(1)))
+	})
+
+	It("should run s_call_b64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOPK
+		state.inst.Opcode = 21
+
+		sp := state.Scratchpad().AsSOPK()
+		sp.PC = 0x104
+		sp.IMM = 0xfffe
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(0x104)))
+		Expect(sp.PC).To(Equal(uint64(0xfc)))
+	})
+
+	It("should run s_getreg_b32 on the MODE register", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOPK
+		state.inst.Opcode = 17
+
+		sp := state.Scratchpad().AsSOPK()
+		sp.IMM = 1 | 4<<6 | (4-1)<<11 // hwreg(HW_REG_MODE, 4, 4)
+		sp.MODE = 0x2f0
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(0xf)))
+	})
+
+	It("should read the hardware registers that are not kept as 0", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOPK
+		state.inst.Opcode = 17
+
+		sp := state.Scratchpad().AsSOPK()
+		sp.IMM = 4 | (32-1)<<11 // hwreg(HW_REG_HW_ID)
+		sp.DST = 517
+
+		alu.Run(state)
+
+		Expect(sp.DST).To(Equal(uint64(0)))
+	})
+
+	It("should run s_setreg_b32 on the MODE register", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOPK
+		state.inst.Opcode = 18
+
+		sp := state.Scratchpad().AsSOPK()
+		sp.IMM = 1 | 4<<6 | (2-1)<<11 // hwreg(HW_REG_MODE, 4, 2)
+		sp.DST = 0xfffffffd
+		sp.MODE = 0x2f0
+
+		alu.Run(state)
+
+		Expect(sp.MODE).To(Equal(uint32(0x2d0)))
+	})
+
+	It("should run s_setreg_imm32_b32 on the whole MODE register", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.SOPK
+		state.inst.Opcode = 20
+
+		sp := state.Scratchpad().AsSOPK()
+		sp.IMM = 1 | (32-1)<<11 // hwreg(HW_REG_MODE)
+		sp.SRC0 = 0x3c0
+		sp.MODE = 0x2f0
+
+		alu.Run(state)
+
+		Expect(sp.MODE).To(Equal(uint32(0x3c0)))
+	})
 })
@@ -1,19 +1,32 @@
 package emu
 
 import (
-	"fmt"
-	"log"
 	"math"
+	"math/bits"
+
+	"github.com/sarchlab/mgpusim/v3/bitops"
+	"github.com/sarchlab/mgpusim/v3/insts"
 )
 
-//nolint:gocyclo,funlen
 func (u *ALUImpl) runVOP1(state InstEmuState) {
-	inst := state.Inst()
-	switch inst.Opcode {
-	case 1:
+	u.runVOP1Op(state, state.Inst().Opcode)
+}
+
+// runVOP1Op runs a VOP1 opcode. The VOP3a instructions that are promoted
+// from VOP1 also run here, as the VOP1 scratchpad layout is a prefix of the
+// VOP3a one.
+//
+//nolint:gocyclo,funlen
+func (u *ALUImpl) runVOP1Op(state InstEmuState, opcode insts.Opcode) {
+	switch opcode {
+	case 0, 53: // v_nop, v_clrexcp
+	// Do nothing
+	case 1, 54, 55, 56: // v_mov_b32 and v_movrel
 		u.runVMOVB32(state)
 	case 2:
 		u.runVREADFIRSTLANEB32(state)
+	case 3:
+		u.runVCVTI32F64(state)
 	case 4:
 		u.runVCVTF64I32(state)
 	case 5:
@@ -26,16 +39,44 @@ func (u *ALUImpl) runVOP1(state InstEmuState) {
 		u.runVCVTI32F32(state)
 	case 10:
 		u.runVCVTF16F32(state)
+	case 11:
+		u.runVCVTF32F16(state)
+	case 12:
+		u.runVCVTRPII32F32(state)
+	case 13:
+		u.runVCVTFLRI32F32(state)
+	case 14:
+		u.runVCVTOFFF32I4(state)
 	case 15:
 		u.runVCVTF32F64(state)
 	case 16:
 		u.runVCVTF64F32(state)
 	case 17:
 		u.runVCVTF32UBYTE0(state)
+	case 18, 19, 20:
+		u.runVCVTF32UBYTE(state, uint(opcode-17))
+	case 21:
+		u.runVCVTU32F64(state)
+	case 22:
+		u.runVCVTF64U32(state)
+	case 23:
+		u.runVOP1F64(state, math.Trunc)
+	case 24:
+		u.runVOP1F64(state, math.Ceil)
+	case 25:
+		u.runVOP1F64(state, math.RoundToEven)
+	case 26:
+		u.runVOP1F64(state, math.Floor)
+	case 27:
+		u.runVOP1F32(state, fract)
 	case 28:
 		u.runTRUNKF32(state)
+	case 29:
+		u.runVOP1F32(state, math.Ceil)
 	case 30:
 		u.runRNDNEF32(state)
+	case 31:
+		u.runVOP1F32(state, math.Floor)
 	case 32:
 		u.runEXPF32(state)
 	case 33:
@@ -46,17 +87,88 @@ func (u *ALUImpl) runVOP1(state InstEmuState) {
 		u.runVRSQF32(state)
 	case 37:
 		u.runVRCPF64(state)
+	case 38:
+		u.runVOP1F64(state, rsq)
 	case 39:
 		u.runVSQRTF32(state)
+	case 40:
+		u.runVOP1F64(state, math.Sqrt)
+	case 41:
+		u.runVOP1F32(state, sinRevolutions)
+	case 42:
+		u.runVOP1F32(state, cosRevolutions)
 	case 43:
 		u.runVNOTB32(state)
 	case 44:
 		u.runBFREVB32(state)
-	case 76:
-		u.runLogLegacyF32(state)
+	case 45:
+		u.runVFFBHU32(state)
+	case 46:
+		u.runVFFBLB32(state)
+	case 47:
+		u.runVFFBHI32(state)
+	case 48:
+		u.runVFREXPEXPI32F64(state)
+	case 49:
+		u.runVOP1F64(state, frexpMant)
+	case 50:
+		u.runVOP1F64(state, fract)
+	case 51:
+		u.runVFREXPEXPI32F32(state)
+	case 52:
+		u.runVOP1F32(state, frexpMant)
+	default:
+		u.runVOP1F16Op(state, opcode)
+	}
+}
 
+// runVOP1F16Op runs the VOP1 opcodes that work on 16-bit numbers.
+//
+//nolint:gocyclo
+func (u *ALUImpl) runVOP1F16Op(state InstEmuState, opcode insts.Opcode) {
+	switch opcode {
+	case 57:
+		u.runVCVTF16U16(state)
+	case 58:
+		u.runVCVTF16I16(state)
+	case 59:
+		u.runVCVTU16F16(state)
+	case 60:
+		u.runVCVTI16F16(state)
+	case 61:
+		u.runVOP1F16(state, func(x float64) float64 { return 1 / x })
+	case 62:
+		u.runVOP1F16(state, math.Sqrt)
+	case 63:
+		u.runVOP1F16(state, rsq)
+	case 64:
+		u.runVOP1F16(state, math.Log2)
+	case 65:
+		u.runVOP1F16(state, math.Exp2)
+	case 66:
+		u.runVOP1F16(state, frexpMant)
+	case 67:
+		u.runVFREXPEXPI16F16(state)
+	case 68:
+		u.runVOP1F16(state, math.Floor)
+	case 69:
+		u.runVOP1F16(state, math.Ceil)
+	case 70:
+		u.runVOP1F16(state, math.Trunc)
+	case 71:
+		u.runVOP1F16(state, math.RoundToEven)
+	case 72:
+		u.runVOP1F16(state, fract)
+	case 73:
+		u.runVOP1F16(state, sinRevolutions)
+	case 74:
+		u.runVOP1F16(state, cosRevolutions)
+	case 75:
+		u.runEXPF32(state)
+	case 76:
+		u.runLOGF32(state)
 	default:
-		log.Panicf("Opcode %d for VOP1 format is not implemented", inst.Opcode)
+		panicUnimplemented(state.Inst())
 	}
 }
 
@@ -374,41 +486,303 @@ func (u *ALUImpl) runVCVTF16F32(state InstEmuState) {
 			continue
 		}
 
-		sign := uint16((uint32(sp.SRC0[i]) >> 31) & 0x1)
-		exp := (uint32(sp.SRC0[i]) >> 23) & 0xff
-		exp16 := int16(exp) - 127 + 15
-		frac := uint16(i>>13) & 0x3ff
-		if exp == 0 {
-			exp16 = 0
-		} else if exp == 0xff {
-			exp16 = 0x1f
-		} else {
-			if exp16 > 0x1e {
-				exp16 = 0x1f
-				frac = 0
-			} else if exp16 < 0x01 {
-				exp16 = 0
-				frac = 0
-			}
+		src := math.Float32frombits(uint32(sp.SRC0[i]))
+		sp.DST[i] = uint64(bitops.Float32ToFloat16(src))
+	}
+}
+
+// runVOP1F32 applies op to SRC0 of the active lanes as 32-bit floats.
+func (u *ALUImpl) runVOP1F32(state InstEmuState, op func(float64) float64) {
+	sp := state.Scratchpad().AsVOP1()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		src := math.Float32frombits(uint32(sp.SRC0[i]))
+		dst := float32(op(float64(src)))
+		sp.DST[i] = uint64(math.Float32bits(dst))
+	}
+}
+
+// runVOP1F64 applies op to SRC0 of the active lanes as 64-bit floats.
+func (u *ALUImpl) runVOP1F64(state InstEmuState, op func(float64) float64) {
+	sp := state.Scratchpad().AsVOP1()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
 		}
-		f16 := (sign << 15) | uint16(exp16<<10) | frac
-		sp.DST[i] = uint64(f16)
+
+		src := math.Float64frombits(sp.SRC0[i])
+		sp.DST[i] = math.Float64bits(op(src))
 	}
 }
 
-func (u *ALUImpl) runLogLegacyF32(state InstEmuState) {
+// runVOP1F16 applies op to the lower 16 bits of SRC0 of the active lanes as
+// half-precision floats.
+func (u *ALUImpl) runVOP1F16(state InstEmuState, op func(float64) float64) {
 	sp := state.Scratchpad().AsVOP1()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
 
-	fmt.Printf("Print value inst:\n")
+		src := bitops.Float16ToFloat32(uint16(sp.SRC0[i]))
+		dst := float32(op(float64(src)))
+		sp.DST[i] = uint64(bitops.Float32ToFloat16(dst))
+	}
+}
 
-	var i uint
-	for i = 0; i < 64; i++ {
+func (u *ALUImpl) runVCVTI32F64(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP1()
+	for i := uint(0); i < 64; i++ {
 		if !laneMasked(sp.EXEC, i) {
 			continue
 		}
 
-		fmt.Printf("Print value %d\n", sp.SRC0[i])
+		src := math.Float64frombits(sp.SRC0[i])
+		sp.DST[i] = uint64(int32ToBits(clampToInt32(src)))
+	}
+}
 
-		sp.DST[i] = sp.SRC0[i]
+func (u *ALUImpl) runVCVTF32F16(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP1()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		dst := bitops.Float16ToFloat32(uint16(sp.SRC0[i]))
+		sp.DST[i] = uint64(math.Float32bits(dst))
+	}
+}
+
+func (u *ALUImpl) runVCVTRPII32F32(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP1()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		src := math.Float32frombits(uint32(sp.SRC0[i]))
+		dst := clampToInt32(math.Floor(float64(src) + 0.5))
+		sp.DST[i] = uint64(int32ToBits(dst))
+	}
+}
+
+func (u *ALUImpl) runVCVTFLRI32F32(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP1()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		src := math.Float32frombits(uint32(sp.SRC0[i]))
+		dst := clampToInt32(math.Floor(float64(src)))
+		sp.DST[i] = uint64(int32ToBits(dst))
+	}
+}
+
+// runVCVTOFFF32I4 converts a signed 4-bit integer to a float in sixteenths.
+func (u *ALUImpl) runVCVTOFFF32I4(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP1()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		src := asInt64(bitops.SignExt(sp.SRC0[i]&0xf, 3))
+		dst := float32(src) / 16
+		sp.DST[i] = uint64(math.Float32bits(dst))
+	}
+}
+
+func (u *ALUImpl) runVCVTF32UBYTE(state InstEmuState, byteIndex uint) {
+	sp := state.Scratchpad().AsVOP1()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		src := (uint32(sp.SRC0[i]) >> (8 * byteIndex)) & 0xff
+		sp.DST[i] = uint64(math.Float32bits(float32(src)))
+	}
+}
+
+func (u *ALUImpl) runVCVTU32F64(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP1()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		src := math.Float64frombits(sp.SRC0[i])
+		sp.DST[i] = uint64(clampToUint32(src))
+	}
+}
+
+func (u *ALUImpl) runVCVTF64U32(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP1()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		sp.DST[i] = math.Float64bits(float64(uint32(sp.SRC0[i])))
+	}
+}
+
+// runVFFBHU32 finds the first set bit from the most significant bit.
+func (u *ALUImpl) runVFFBHU32(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP1()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		src := uint32(sp.SRC0[i])
+		dst := uint32(0xffffffff)
+		if src != 0 {
+			dst = uint32(bits.LeadingZeros32(src))
+		}
+
+		sp.DST[i] = uint64(dst)
+	}
+}
+
+// runVFFBLB32 finds the first set bit from the least significant bit.
+func (u *ALUImpl) runVFFBLB32(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP1()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		src := uint32(sp.SRC0[i])
+		dst := uint32(0xffffffff)
+		if src != 0 {
+			dst = uint32(bits.TrailingZeros32(src))
+		}
+
+		sp.DST[i] = uint64(dst)
+	}
+}
+
+// runVFFBHI32 finds the first bit that differs from the sign bit, counting
+// from the most significant bit.
+func (u *ALUImpl) runVFFBHI32(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP1()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		src := uint32(sp.SRC0[i])
+		if asInt32(src) < 0 {
+			src = ^src
+		}
+
+		dst := uint32(0xffffffff)
+		if src != 0 {
+			dst = uint32(bits.LeadingZeros32(src))
+		}
+
+		sp.DST[i] = uint64(dst)
+	}
+}
+
+func (u *ALUImpl) runVFREXPEXPI32F64(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP1()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		src := math.Float64frombits(sp.SRC0[i])
+		sp.DST[i] = uint64(int32ToBits(int32(frexpExp(src))))
+	}
+}
+
+func (u *ALUImpl) runVFREXPEXPI32F32(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP1()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		src := math.Float32frombits(uint32(sp.SRC0[i]))
+		sp.DST[i] = uint64(int32ToBits(int32(frexpExp(float64(src)))))
+	}
+}
+
+func (u *ALUImpl) runVFREXPEXPI16F16(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP1()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		src := bitops.Float16ToFloat32(uint16(sp.SRC0[i]))
+		sp.DST[i] = uint64(int16ToBits(int16(frexpExp(float64(src)))))
+	}
+}
+
+func (u *ALUImpl) runVCVTF16U16(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP1()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		src := float32(uint16(sp.SRC0[i]))
+		sp.DST[i] = uint64(bitops.Float32ToFloat16(src))
+	}
+}
+
+func (u *ALUImpl) runVCVTF16I16(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP1()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		src := float32(asInt16(uint16(sp.SRC0[i])))
+		sp.DST[i] = uint64(bitops.Float32ToFloat16(src))
+	}
+}
+
+func (u *ALUImpl) runVCVTU16F16(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP1()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		src := float64(bitops.Float16ToFloat32(uint16(sp.SRC0[i])))
+		dst := uint16(math.MaxUint16)
+		if math.IsNaN(src) || src < math.MaxUint16 {
+			dst = uint16(clampToUint32(src))
+		}
+
+		sp.DST[i] = uint64(dst)
+	}
+}
+
+func (u *ALUImpl) runVCVTI16F16(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP1()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		src := float64(bitops.Float16ToFloat32(uint16(sp.SRC0[i])))
+		dst := clampToInt32(src)
+		if dst > math.MaxInt16 {
+			dst = math.MaxInt16
+		} else if dst < math.MinInt16 {
+			dst = math.MinInt16
+		}
+
+		sp.DST[i] = uint64(int16ToBits(int16(dst)))
 	}
 }
@@ -429,4 +429,751 @@ var _ = Describe("ALU", func() {
 		Expect(uint32(sp.DST[0])).To(Equal(uint32(0xffff0000)))
 	})
 
+	It("should run v_cvt_f32_f16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 11
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x3e00
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(1.5)))))
+	})
+
+	It("should run v_cvt_rpi_i32_f32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 12
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x3
+		sp.SRC0[0] = uint64(math.Float32bits(1.5))
+		sp.SRC0[1] = uint64(math.Float32bits(-1.5))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(2)))
+		Expect(sp.DST[1]).To(Equal(uint64(0xffffffff)))
+	})
+
+	It("should run v_cvt_f32_ubyte2", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 19
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x00ab0000
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(171.0)))))
+	})
+
+	It("should run v_floor_f64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 26
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = math.Float64bits(-1.5)
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(math.Float64bits(-2.0))))
+	})
+
+	It("should run v_fract_f32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 27
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(-1.25))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(0.75)))))
+	})
+
+	It("should run v_sin_f32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 41
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(0.25))
+
+		alu.Run(state)
+
+		Expect(math.Float32frombits(uint32(sp.DST[0]))).To(BeNumerically("~", 1.0, 1e-6))
+	})
+
+	It("should run v_ffbl_b32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 46
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x3
+		sp.SRC0[0] = 0x10
+		sp.SRC0[1] = 0
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(4)))
+		Expect(sp.DST[1]).To(Equal(uint64(0xffffffff)))
+	})
+
+	It("should run v_frexp_exp_i32_f32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 51
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(8.0))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(4)))
+	})
+
+	It("should run v_cvt_f16_u16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 57
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 3
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x4200)))
+	})
+
+	It("should run v_rcp_f16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 61
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x4000
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x3800)))
+	})
+
+	It("should run v_movreld_b32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 54
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.SRC0[0] = 517
+		sp.SRC0[1] = 518
+		sp.EXEC = 0x1
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(517)))
+		Expect(sp.DST[1]).To(Equal(uint64(0)))
+	})
+
+	It("should run v_movrels_b32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 55
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.SRC0[0] = 517
+		sp.EXEC = 0x1
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(517)))
+	})
+
+	It("should run v_movrelsd_b32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 56
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.SRC0[0] = 517
+		sp.EXEC = 0x1
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(517)))
+	})
+
+	It("should run V_CVT_I32_F64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 3
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = math.Float64bits(-2.75)
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xfffffffe)))
+	})
+
+	It("should run V_CVT_FLR_I32_F32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 13
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(-2.5))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xfffffffd)))
+	})
+
+	It("should run V_CVT_OFF_F32_I4", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 14
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xf
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(-0.0625)))))
+	})
+
+	It("should run V_CVT_F32_UBYTE1", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 18
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x04030201
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(2.0)))))
+	})
+
+	It("should run V_CVT_F32_UBYTE3", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 20
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x04030201
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(4.0)))))
+	})
+
+	It("should run V_CVT_U32_F64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 21
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = math.Float64bits(7.9)
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(7)))
+	})
+
+	It("should run V_CVT_F64_U32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 22
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xffffffff
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(math.Float64bits(4294967295.0))))
+	})
+
+	It("should run V_TRUNC_F64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 23
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = math.Float64bits(-2.5)
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(math.Float64bits(-2.0))))
+	})
+
+	It("should run V_CEIL_F64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 24
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = math.Float64bits(2.25)
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(math.Float64bits(3.0))))
+	})
+
+	It("should run V_RNDNE_F64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 25
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = math.Float64bits(2.5)
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(math.Float64bits(2.0))))
+	})
+
+	It("should run V_CEIL_F32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 29
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(2.25))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(3.0)))))
+	})
+
+	It("should run V_FLOOR_F32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 31
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(-2.5))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(-3.0)))))
+	})
+
+	It("should run V_RSQ_F64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 38
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = math.Float64bits(16.0)
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(math.Float64bits(0.25))))
+	})
+
+	It("should run V_SQRT_F64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 40
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = math.Float64bits(2.25)
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(math.Float64bits(1.5))))
+	})
+
+	It("should run V_COS_F32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 42
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(0.5))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(-1.0)))))
+	})
+
+	It("should run V_NOT_B32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 43
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x0f0f0f0f
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xf0f0f0f0)))
+	})
+
+	It("should run V_FFBH_U32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 45
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x00010000
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(15)))
+	})
+
+	It("should run V_FFBH_I32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 47
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xfffe0000
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(15)))
+	})
+
+	It("should run V_FREXP_EXP_I32_F64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 48
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = math.Float64bits(8.0)
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(4)))
+	})
+
+	It("should run V_FREXP_MANT_F64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 49
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = math.Float64bits(8.0)
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(math.Float64bits(0.5))))
+	})
+
+	It("should run V_FRACT_F64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 50
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = math.Float64bits(-1.25)
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(math.Float64bits(0.75))))
+	})
+
+	It("should run V_FREXP_MANT_F32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 52
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(6.0))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(0.75)))))
+	})
+
+	It("should run V_CVT_F16_I16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 58
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xfffe
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xc000)))
+	})
+
+	It("should run V_CVT_U16_F16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 59
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x4700
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(7)))
+	})
+
+	It("should run V_CVT_I16_F16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 60
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xc700
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xfff9)))
+	})
+
+	It("should run V_SQRT_F16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 62
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x4400
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x4000)))
+	})
+
+	It("should run V_RSQ_F16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 63
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x4400
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x3800)))
+	})
+
+	It("should run V_LOG_F16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 64
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x4800
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x4200)))
+	})
+
+	It("should run V_EXP_F16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 65
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x4200
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x4800)))
+	})
+
+	It("should run V_FREXP_MANT_F16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 66
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x4600
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x3a00)))
+	})
+
+	It("should run V_FREXP_EXP_I16_F16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 67
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x4600
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(3)))
+	})
+
+	It("should run V_FLOOR_F16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 68
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xc100
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xc200)))
+	})
+
+	It("should run V_CEIL_F16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 69
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x4080
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x4200)))
+	})
+
+	It("should run V_TRUNC_F16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 70
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xc100
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xc000)))
+	})
+
+	It("should run V_RNDNE_F16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 71
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x4100
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x4000)))
+	})
+
+	It("should run V_FRACT_F16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 72
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xbd00
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x3a00)))
+	})
+
+	It("should run V_SIN_F16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 73
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x3400
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x3c00)))
+	})
+
+	It("should run V_COS_F16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 74
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x3800
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xbc00)))
+	})
+
+	It("should run V_EXP_LEGACY_F32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 75
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(3.0))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(8.0)))))
+	})
+
+	It("should run V_LOG_LEGACY_F32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP1
+		state.inst.Opcode = 76
+
+		sp := state.Scratchpad().AsVOP1()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(8.0))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(3.0)))))
+	})
 })
@@ -1,16 +1,32 @@
 package emu
 
 import (
-	"log"
 	"math"
 
 	"github.com/sarchlab/mgpusim/v3/bitops"
+	"github.com/sarchlab/mgpusim/v3/insts"
 )
 
-//nolint:gocyclo,funlen
+// vop2SDWAOpcodes are the VOP2 opcodes that run with the sub-dword addressing
+// (SDWA) extension, which are v_and_b32, v_or_b32, v_xor_b32 and v_add_u32.
+var vop2SDWAOpcodes = map[insts.Opcode]bool{19: true, 20: true, 21: true, 25: true}
+
 func (u *ALUImpl) runVOP2(state InstEmuState) {
 	inst := state.Inst()
-	switch inst.Opcode {
+	if inst.IsSdwa && !vop2SDWAOpcodes[inst.Opcode] {
+		panicUnimplementedSDWA(inst)
+	}
+
+	u.runVOP2Op(state, inst.Opcode)
+}
+
+// runVOP2Op runs a VOP2 opcode. The VOP3a instructions that are promoted
+// from VOP2 also run here, with SRC0 and SRC1 at the same place in the
+// scratchpad.
+//
+//nolint:gocyclo,funlen
+func (u *ALUImpl) runVOP2Op(state InstEmuState, opcode insts.Opcode) {
+	switch opcode {
 	case 0:
 		u.runVCNDMASKB32(state)
 	case 1:
@@ -20,13 +36,17 @@ func (u *ALUImpl) runVOP2(state InstEmuState) {
 	case 3:
 		u.runVSUBREVF32(state)
 	case 4:
-		u.runVMULF32(state)
+		u.runVMULLEGACYF32(state)
 	case 5:
 		u.runVMULF32(state)
 	case 6:
 		u.runVMULI32I24(state)
+	case 7:
+		u.runVMULHII32I24(state)
 	case 8:
 		u.runVMULU32U24(state)
+	case 9:
+		u.runVMULHIU32U24(state)
 	case 10:
 		u.runVMINF32(state)
 	case 11:
@@ -53,6 +73,8 @@ func (u *ALUImpl) runVOP2(state InstEmuState) {
 		u.runVXORB32(state)
 	case 22:
 		u.runVMACF32(state)
+	case 23:
+		u.runVMADMKF32(state)
 	case 24:
 		u.runVMADAKF32(state)
 	case 25:
@@ -68,8 +90,71 @@ func (u *ALUImpl) runVOP2(state InstEmuState) {
 	case 30:
 		u.runVSUBBREVU32(state)
 	default:
-		log.Panicf("Opcode %d for VOP2 format (%s) is not implemented",
-			inst.Opcode, inst.String(nil))
+		u.runVOP2F16Op(state, opcode)
+	}
+}
+
+// runVOP2F16Op runs the VOP2 opcodes that work on 16-bit numbers.
+//
+//nolint:gocyclo,funlen
+func (u *ALUImpl) runVOP2F16Op(state InstEmuState, opcode insts.Opcode) {
+	switch opcode {
+	case 31:
+		u.runVOP2F16(state, func(a, b float64) float64 { return a + b })
+	case 32:
+		u.runVOP2F16(state, func(a, b float64) float64 { return a - b })
+	case 33:
+		u.runVOP2F16(state, func(a, b float64) float64 { return b - a })
+	case 34:
+		u.runVOP2F16(state, func(a, b float64) float64 { return a * b })
+	case 35:
+		u.runVMACF16(state)
+	case 36:
+		u.runVMADMKF16(state)
+	case 37:
+		u.runVMADAKF16(state)
+	case 38:
+		u.runVOP2U16(state, func(a, b uint16) uint16 { return a + b })
+	case 39:
+		u.runVOP2U16(state, func(a, b uint16) uint16 { return a - b })
+	case 40:
+		u.runVOP2U16(state, func(a, b uint16) uint16 { return b - a })
+	case 41:
+		u.runVOP2U16(state, func(a, b uint16) uint16 { return a * b })
+	case 42:
+		u.runVOP2U16(state, func(a, b uint16) uint16 { return b << (a & 0xf) })
+	case 43:
+		u.runVOP2U16(state, func(a, b uint16) uint16 { return b >> (a & 0xf) })
+	case 44:
+		u.runVOP2U16(state, func(a, b uint16) uint16 {
+			return int16ToBits(asInt16(b) >> (a & 0xf))
+		})
+	case 45:
+		u.runVOP2F16(state, maxNum)
+	case 46:
+		u.runVOP2F16(state, minNum)
+	case 47:
+		u.runVOP2U16(state, func(a, b uint16) uint16 { return max(a, b) })
+	case 48:
+		u.runVOP2U16(state, func(a, b uint16) uint16 {
+			return int16ToBits(max(asInt16(a), asInt16(b)))
+		})
+	case 49:
+		u.runVOP2U16(state, func(a, b uint16) uint16 { return min(a, b) })
+	case 50:
+		u.runVOP2U16(state, func(a, b uint16) uint16 {
+			return int16ToBits(min(asInt16(a), asInt16(b)))
+		})
+	case 51:
+		u.runVLDEXPF16(state)
+	case 52:
+		u.runVOP2U32(state, func(a, b uint32) uint32 { return a + b })
+	case 53:
+		u.runVOP2U32(state, func(a, b uint32) uint32 { return a - b })
+	case 54:
+		u.runVOP2U32(state, func(a, b uint32) uint32 { return b - a })
+	default:
+		panicUnimplemented(state.Inst())
 	}
 }
 
@@ -90,7 +175,7 @@ func (u *ALUImpl) runVCNDMASKB32(state InstEmuState) {
 			}
 		}
 	} else {
-		log.Panicf("SDWA for VOP2 instruction opcode %d not implemented \n", inst.Opcode)
+		panicUnimplementedSDWA(inst)
 	}
 }
 
@@ -110,7 +195,7 @@ func (u *ALUImpl) runVADDF32(state InstEmuState) {
 			sp.DST[i] = uint64(math.Float32bits(dst))
 		}
 	} else {
-		log.Panicf("SDWA for VOP2 instruction opcode  %d not implemented \n", inst.Opcode)
+		panicUnimplementedSDWA(inst)
 	}
 }
 
@@ -130,7 +215,7 @@ func (u *ALUImpl) runVSUBF32(state InstEmuState) {
 			sp.DST[i] = uint64(math.Float32bits(dst))
 		}
 	} else {
-		log.Panicf("SDWA for VOP2 instruction opcode  %d not implemented \n", inst.Opcode)
+		panicUnimplementedSDWA(inst)
 	}
 }
 
@@ -150,7 +235,7 @@ func (u *ALUImpl) runVSUBREVF32(state InstEmuState) {
 			sp.DST[i] = uint64(math.Float32bits(dst))
 		}
 	} else {
-		log.Panicf("SDWA for VOP2 instruction opcode  %d not implemented \n", inst.Opcode)
+		panicUnimplementedSDWA(inst)
 	}
 }
 
@@ -170,7 +255,28 @@ func (u *ALUImpl) runVMULF32(state InstEmuState) {
 			sp.DST[i] = uint64(math.Float32bits(dst))
 		}
 	} else {
-		log.Panicf("SDWA for VOP2 instruction opcode %d not implemented \n", inst.Opcode)
+		panicUnimplementedSDWA(inst)
+	}
+}
+
+// runVMULLEGACYF32 gives 0 if any operand is 0, even if the other operand
+// is an infinity or NaN.
+func (u *ALUImpl) runVMULLEGACYF32(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP2()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		src0 := math.Float32frombits(uint32(sp.SRC0[i]))
+		src1 := math.Float32frombits(uint32(sp.SRC1[i]))
+
+		dst := float32(0)
+		if src0 != 0 && src1 != 0 {
+			dst = src0 * src1
+		}
+
+		sp.DST[i] = uint64(math.Float32bits(dst))
 	}
 }
 
@@ -190,10 +296,10 @@ func (u *ALUImpl) runVMULI32I24(state InstEmuState) {
 				bitops.ExtractBitsFromU64(sp.SRC1[i], 0, 23), 23))
 
 			dst := src0 * src1
-			sp.DST[i] = uint64(dst)
+			sp.DST[i] = uint64(uint32(dst))
 		}
 	} else {
-		log.Panicf("SDWA for VOP2 instruction opcode %d not implemented \n", inst.Opcode)
+		panicUnimplementedSDWA(inst)
 	}
 }
 
@@ -232,7 +338,7 @@ func (u *ALUImpl) runVMINF32(state InstEmuState) {
 			sp.DST[i] = uint64(math.Float32bits(dst))
 		}
 	} else {
-		log.Panicf("SDWA for VOP2 instruction opcode %d not implemented \n", inst.Opcode)
+		panicUnimplementedSDWA(inst)
 	}
 }
 
@@ -256,7 +362,7 @@ func (u *ALUImpl) runVMAXF32(state InstEmuState) {
 			sp.DST[i] = uint64(math.Float32bits(dst))
 		}
 	} else {
-		log.Panicf("SDWA for VOP2 instruction opcode %d not implemented \n", inst.Opcode)
+		panicUnimplementedSDWA(inst)
 	}
 }
 
@@ -277,7 +383,7 @@ func (u *ALUImpl) runVMINI32(state InstEmuState) {
 			dst = src1
 		}
 
-		sp.DST[i] = uint64(dst)
+		sp.DST[i] = uint64(uint32(dst))
 	}
 }
 
@@ -298,7 +404,7 @@ func (u *ALUImpl) runVMAXI32(state InstEmuState) {
 			dst = src1
 		}
 
-		sp.DST[i] = uint64(dst)
+		sp.DST[i] = uint64(uint32(dst))
 	}
 }
 
@@ -359,7 +465,7 @@ func (u *ALUImpl) runVLSHRREVB32(state InstEmuState) {
 			sp.DST[i] = dst
 		}
 	} else {
-		log.Panicf("SDWA for VOP2 instruction opcode %d not implemented \n", inst.Opcode)
+		panicUnimplementedSDWA(inst)
 	}
 }
 
@@ -375,10 +481,10 @@ func (u *ALUImpl) runVASHRREVI32(state InstEmuState) {
 			src0 := uint32(sp.SRC0[i])
 			src1 := int32(sp.SRC1[i])
 			dst := src1 >> (src0 & 0x1f)
-			sp.DST[i] = uint64(dst)
+			sp.DST[i] = uint64(uint32(dst))
 		}
 	} else {
-		log.Panicf("SDWA for VOP2 instruction opcode  %d not implemented \n", inst.Opcode)
+		panicUnimplementedSDWA(inst)
 	}
 }
 
@@ -397,7 +503,7 @@ func (u *ALUImpl) runVLSHLREVB32(state InstEmuState) {
 			sp.DST[i] = uint64(dst)
 		}
 	} else {
-		log.Panicf("SDWA for VOP2 instruction opcode  %d not implemented \n", inst.Opcode)
+		panicUnimplementedSDWA(inst)
 	}
 }
 
@@ -509,7 +615,7 @@ func (u *ALUImpl) runVMACF32(state InstEmuState) {
 			sp.DST[i] = uint64(float32ToBits(dst))
 		}
 	} else {
-		log.Panicf("SDWA for VOP2 instruction opcode  %d not implemented \n", inst.Opcode)
+		panicUnimplementedSDWA(inst)
 	}
 }
 
@@ -534,7 +640,7 @@ func (u *ALUImpl) runVMADAKF32(state InstEmuState) {
 			sp.DST[i] = uint64(float32ToBits(dst))
 		}
 	} else {
-		log.Panicf("SDWA for VOP2 instruction opcode  %d not implemented \n", inst.Opcode)
+		panicUnimplementedSDWA(inst)
 	}
 }
 
@@ -622,7 +728,7 @@ func (u *ALUImpl) runVSUBI32(state InstEmuState) {
 			sp.DST[i] = uint64(src0 - src1)
 		}
 	} else {
-		log.Panicf("SDWA for VOP2 instruction opcode  %d not implemented \n", inst.Opcode)
+		panicUnimplementedSDWA(inst)
 	}
 }
 
@@ -647,7 +753,7 @@ func (u *ALUImpl) runVSUBREVI32(state InstEmuState) {
 			sp.DST[i] = uint64(src1 - src0)
 		}
 	} else {
-		log.Panicf("SDWA for VOP2 instruction opcode  %d not implemented \n", inst.Opcode)
+		panicUnimplementedSDWA(inst)
 	}
 }
 
@@ -673,7 +779,7 @@ func (u *ALUImpl) runVADDCU32(state InstEmuState) {
 		}
 		sp.VCC = newVCC
 	} else {
-		log.Panicf("SDWA for VOP2 instruction opcode  %d not implemented \n", inst.Opcode)
+		panicUnimplementedSDWA(inst)
 	}
 }
 
@@ -698,7 +804,7 @@ func (u *ALUImpl) runVSUBBU32(state InstEmuState) {
 		}
 		sp.VCC = newVCC
 	} else {
-		log.Panicf("SDWA for VOP2 instruction opcode  %d not implemented \n", inst.Opcode)
+		panicUnimplementedSDWA(inst)
 	}
 }
 
@@ -724,6 +830,152 @@ func (u *ALUImpl) runVSUBBREVU32(state InstEmuState) {
 		}
 		sp.VCC = newVCC
 	} else {
-		log.Panicf("SDWA for VOP2 instruction opcode  %d not implemented \n", inst.Opcode)
+		panicUnimplementedSDWA(inst)
+	}
+}
+
+func (u *ALUImpl) runVMULHII32I24(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP2()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		src0 := asInt64(bitops.SignExt(sp.SRC0[i]&0xffffff, 23))
+		src1 := asInt64(bitops.SignExt(sp.SRC1[i]&0xffffff, 23))
+		dst := (src0 * src1) >> 32
+
+		sp.DST[i] = uint64(uint32(dst))
+	}
+}
+
+func (u *ALUImpl) runVMULHIU32U24(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP2()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		src0 := sp.SRC0[i] & 0xffffff
+		src1 := sp.SRC1[i] & 0xffffff
+
+		sp.DST[i] = (src0 * src1) >> 32
+	}
+}
+
+func (u *ALUImpl) runVMADMKF32(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP2()
+	k := asFloat32(uint32(sp.LiteralConstant))
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		src0 := asFloat32(uint32(sp.SRC0[i]))
+		src1 := asFloat32(uint32(sp.SRC1[i]))
+		dst := src0*k + src1
+		sp.DST[i] = uint64(float32ToBits(dst))
+	}
+}
+
+// runVOP2F16 applies op to the lower 16 bits of SRC0 and SRC1 of the active
+// lanes as half-precision floats.
+func (u *ALUImpl) runVOP2F16(state InstEmuState, op func(a, b float64) float64) {
+	sp := state.Scratchpad().AsVOP2()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		src0 := bitops.Float16ToFloat32(uint16(sp.SRC0[i]))
+		src1 := bitops.Float16ToFloat32(uint16(sp.SRC1[i]))
+		dst := float32(op(float64(src0), float64(src1)))
+		sp.DST[i] = uint64(bitops.Float32ToFloat16(dst))
+	}
+}
+
+// runVOP2U16 applies op to the lower 16 bits of SRC0 and SRC1 of the active
+// lanes.
+func (u *ALUImpl) runVOP2U16(state InstEmuState, op func(a, b uint16) uint16) {
+	sp := state.Scratchpad().AsVOP2()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		sp.DST[i] = uint64(op(uint16(sp.SRC0[i]), uint16(sp.SRC1[i])))
+	}
+}
+
+// runVOP2U32 applies op to the lower 32 bits of SRC0 and SRC1 of the active
+// lanes. Unlike the GCN3 adds, the GFX9 ones that run here do not write a
+// carry.
+func (u *ALUImpl) runVOP2U32(state InstEmuState, op func(a, b uint32) uint32) {
+	sp := state.Scratchpad().AsVOP2()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		sp.DST[i] = uint64(op(uint32(sp.SRC0[i]), uint32(sp.SRC1[i])))
+	}
+}
+
+func (u *ALUImpl) runVMACF16(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP2()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		src0 := bitops.Float16ToFloat32(uint16(sp.SRC0[i]))
+		src1 := bitops.Float16ToFloat32(uint16(sp.SRC1[i]))
+		dst := bitops.Float16ToFloat32(uint16(sp.DST[i]))
+		dst += src0 * src1
+		sp.DST[i] = uint64(bitops.Float32ToFloat16(dst))
+	}
+}
+
+func (u *ALUImpl) runVMADMKF16(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP2()
+	k := bitops.Float16ToFloat32(uint16(sp.LiteralConstant))
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		src0 := bitops.Float16ToFloat32(uint16(sp.SRC0[i]))
+		src1 := bitops.Float16ToFloat32(uint16(sp.SRC1[i]))
+		dst := src0*k + src1
+		sp.DST[i] = uint64(bitops.Float32ToFloat16(dst))
+	}
+}
+
+func (u *ALUImpl) runVMADAKF16(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP2()
+	k := bitops.Float16ToFloat32(uint16(sp.LiteralConstant))
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		src0 := bitops.Float16ToFloat32(uint16(sp.SRC0[i]))
+		src1 := bitops.Float16ToFloat32(uint16(sp.SRC1[i]))
+		dst := src0*src1 + k
+		sp.DST[i] = uint64(bitops.Float32ToFloat16(dst))
+	}
+}
+
+func (u *ALUImpl) runVLDEXPF16(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP2()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		src0 := bitops.Float16ToFloat32(uint16(sp.SRC0[i]))
+		exp := int(asInt16(uint16(sp.SRC1[i])))
+		dst := float32(math.Ldexp(float64(src0), exp))
+		sp.DST[i] = uint64(bitops.Float32ToFloat16(dst))
 	}
 }
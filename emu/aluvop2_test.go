@@ -367,6 +367,19 @@ var _ = Describe("ALU", func() {
 		Expect(uint32(sp.DST[0])).To(Equal(uint32(0x009a0000)))
 	})
 
+	It("should panic on SDWA for the opcodes without SDWA", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP2
+		state.inst.Opcode = 2
+		state.inst.IsSdwa = true
+
+		sp := state.Scratchpad().AsVOP2()
+		sp.EXEC = 1
+
+		Expect(func() { alu.Run(state) }).To(
+			PanicWith(BeAssignableToTypeOf(unimplementedInstError{})))
+	})
+
 	It("should run V_XOR_B32", func() {
 		state.inst = insts.NewInst()
 		state.inst.FormatType = insts.VOP2
@@ -651,4 +664,427 @@ var _ = Describe("ALU", func() {
 		Expect(sp.VCC).To(Equal(uint64(1)))
 	})
 
+	It("should run v_mul_hi_u32_u24", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP2
+		state.inst.Opcode = 9
+
+		sp := state.Scratchpad().AsVOP2()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xffffff
+		sp.SRC1[0] = 0xffffff
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xffff)))
+	})
+
+	It("should run v_madmk_f32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP2
+		state.inst.Opcode = 23
+
+		sp := state.Scratchpad().AsVOP2()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(2.0))
+		sp.SRC1[0] = uint64(math.Float32bits(1.0))
+		sp.LiteralConstant = uint64(math.Float32bits(3.0))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(7.0)))))
+	})
+
+	It("should run v_add_f16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP2
+		state.inst.Opcode = 31
+
+		sp := state.Scratchpad().AsVOP2()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x3c00
+		sp.SRC1[0] = 0x4000
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x4200)))
+	})
+
+	It("should run v_madak_f16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP2
+		state.inst.Opcode = 37
+
+		sp := state.Scratchpad().AsVOP2()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x4000
+		sp.SRC1[0] = 0x3e00
+		sp.LiteralConstant = 0x3c00
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x4400)))
+	})
+
+	It("should run v_sub_u16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP2
+		state.inst.Opcode = 39
+
+		sp := state.Scratchpad().AsVOP2()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 1
+		sp.SRC1[0] = 2
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xffff)))
+	})
+
+	It("should run v_ashrrev_i16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP2
+		state.inst.Opcode = 44
+
+		sp := state.Scratchpad().AsVOP2()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 4
+		sp.SRC1[0] = 0x8000
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xf800)))
+	})
+
+	It("should run v_max_i16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP2
+		state.inst.Opcode = 48
+
+		sp := state.Scratchpad().AsVOP2()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xffff
+		sp.SRC1[0] = 1
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(1)))
+	})
+
+	It("should run v_ldexp_f16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP2
+		state.inst.Opcode = 51
+
+		sp := state.Scratchpad().AsVOP2()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x3e00
+		sp.SRC1[0] = 2
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x4600)))
+	})
+
+	It("should run v_add_u32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP2
+		state.inst.Opcode = 52
+
+		sp := state.Scratchpad().AsVOP2()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xffffffff
+		sp.SRC1[0] = 2
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(1)))
+		Expect(sp.VCC).To(Equal(uint64(0)))
+	})
+
+	It("should run v_subrev_u32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP2
+		state.inst.Opcode = 54
+
+		sp := state.Scratchpad().AsVOP2()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 1
+		sp.SRC1[0] = 3
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(2)))
+	})
+
+	It("should run V_MUL_LEGACY_F32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP2
+		state.inst.Opcode = 4
+
+		sp := state.Scratchpad().AsVOP2()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(0))
+		sp.SRC1[0] = uint64(math.Float32bits(float32(math.Inf(1))))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(0)))))
+	})
+
+	It("should run V_MUL_HI_I32_I24", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP2
+		state.inst.Opcode = 7
+
+		sp := state.Scratchpad().AsVOP2()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x7fffff
+		sp.SRC1[0] = 0x7fffff
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x3fff)))
+	})
+
+	It("should run V_MIN_I32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP2
+		state.inst.Opcode = 12
+
+		sp := state.Scratchpad().AsVOP2()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xffffffff
+		sp.SRC1[0] = 1
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xffffffff)))
+	})
+
+	It("should run V_MAX_I32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP2
+		state.inst.Opcode = 13
+
+		sp := state.Scratchpad().AsVOP2()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xffffffff
+		sp.SRC1[0] = 1
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(1)))
+	})
+
+	It("should run V_SUB_F16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP2
+		state.inst.Opcode = 32
+
+		sp := state.Scratchpad().AsVOP2()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x4200
+		sp.SRC1[0] = 0x3c00
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x4000)))
+	})
+
+	It("should run V_SUBREV_F16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP2
+		state.inst.Opcode = 33
+
+		sp := state.Scratchpad().AsVOP2()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x3c00
+		sp.SRC1[0] = 0x4200
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x4000)))
+	})
+
+	It("should run V_MUL_F16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP2
+		state.inst.Opcode = 34
+
+		sp := state.Scratchpad().AsVOP2()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x4000
+		sp.SRC1[0] = 0x4200
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x4600)))
+	})
+
+	It("should run V_MAC_F16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP2
+		state.inst.Opcode = 35
+
+		sp := state.Scratchpad().AsVOP2()
+		sp.EXEC = 0x1
+		sp.DST[0] = 0x3c00
+		sp.SRC0[0] = 0x4000
+		sp.SRC1[0] = 0x4200
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x4700)))
+	})
+
+	It("should run V_ADD_U16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP2
+		state.inst.Opcode = 38
+
+		sp := state.Scratchpad().AsVOP2()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xffff
+		sp.SRC1[0] = 2
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(1)))
+	})
+
+	It("should run V_SUBREV_U16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP2
+		state.inst.Opcode = 40
+
+		sp := state.Scratchpad().AsVOP2()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 5
+		sp.SRC1[0] = 7
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(2)))
+	})
+
+	It("should run V_MUL_LO_U16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP2
+		state.inst.Opcode = 41
+
+		sp := state.Scratchpad().AsVOP2()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x100
+		sp.SRC1[0] = 0x101
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x100)))
+	})
+
+	It("should run V_LSHLREV_B16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP2
+		state.inst.Opcode = 42
+
+		sp := state.Scratchpad().AsVOP2()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 4
+		sp.SRC1[0] = 0x0f01
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xf010)))
+	})
+
+	It("should run V_LSHRREV_B16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP2
+		state.inst.Opcode = 43
+
+		sp := state.Scratchpad().AsVOP2()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 4
+		sp.SRC1[0] = 0x8000
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x0800)))
+	})
+
+	It("should run V_MAX_F16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP2
+		state.inst.Opcode = 45
+
+		sp := state.Scratchpad().AsVOP2()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x3c00
+		sp.SRC1[0] = 0xc000
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x3c00)))
+	})
+
+	It("should run V_MIN_F16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP2
+		state.inst.Opcode = 46
+
+		sp := state.Scratchpad().AsVOP2()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x3c00
+		sp.SRC1[0] = 0xc000
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xc000)))
+	})
+
+	It("should run V_MAX_U16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP2
+		state.inst.Opcode = 47
+
+		sp := state.Scratchpad().AsVOP2()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xffff
+		sp.SRC1[0] = 1
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xffff)))
+	})
+
+	It("should run V_MIN_U16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP2
+		state.inst.Opcode = 49
+
+		sp := state.Scratchpad().AsVOP2()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xffff
+		sp.SRC1[0] = 1
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(1)))
+	})
+
+	It("should run V_MIN_I16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP2
+		state.inst.Opcode = 50
+
+		sp := state.Scratchpad().AsVOP2()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xffff
+		sp.SRC1[0] = 1
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xffff)))
+	})
 })
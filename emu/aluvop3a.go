@@ -3,16 +3,23 @@ package emu
 import (
 	"log"
 	"math"
+	"math/bits"
 	"sort"
 	"strings"
 
 	"github.com/sarchlab/mgpusim/v3/bitops"
+	"github.com/sarchlab/mgpusim/v3/insts"
 )
 
 //nolint:gocyclo,funlen
 func (u *ALUImpl) runVOP3A(state InstEmuState) {
 	inst := state.Inst()
 
+	var origDst [64]uint64
+	if inst.OpSel&vop3OpSelDst != 0 {
+		origDst = state.Scratchpad().AsVOP3A().DST
+	}
+
 	u.vop3aPreprocess(state)
 
 	switch inst.Opcode {
@@ -91,14 +98,300 @@ func (u *ALUImpl) runVOP3A(state InstEmuState) {
 	case 657:
 		u.runVASHRREVI64(state)
 	default:
-		log.Panicf("Opcode %d for VOP3a format is not implemented", inst.Opcode)
+		u.runVOP3AByEncoding(state, inst.Opcode)
 	}
 	u.vop3aPostprocess(state)
+
+	if inst.OpSel&vop3OpSelDst != 0 {
+		u.vop3aWriteDstHi(state, &origDst)
+	}
+}
+
+// runVOP3AByEncoding runs the opcodes that the switch of runVOP3A does not
+// list. The VOP3a opcodes that are promoted from VOPC, VOP2, and VOP1 behave
+// the same as their 32-bit encoded versions.
+func (u *ALUImpl) runVOP3AByEncoding(state InstEmuState, opcode insts.Opcode) {
+	switch {
+	case opcode < 256:
+		u.runVCmpVOP3a(state, opcode)
+	case opcode < 320:
+		u.runVOP2Op(state, opcode-256)
+	case opcode < 448:
+		u.runVOP1Op(state, opcode-320)
+	default:
+		u.runVOP3ANativeOp(state, opcode)
+	}
+}
+
+// runVCmpVOP3a writes the result of the compare to the SGPRs that DST[0]
+// stands for, rather than to VCC.
+func (u *ALUImpl) runVCmpVOP3a(state InstEmuState, opcode insts.Opcode) {
+	cmp, writesExec := vCmp(opcode)
+	if cmp == nil {
+		panicUnimplemented(state.Inst())
+	}
+
+	sp := state.Scratchpad().AsVOP3A()
+	sp.DST[0] = compareLanes(sp.EXEC, &sp.SRC0, &sp.SRC1, cmp)
+	if writesExec {
+		sp.EXEC = sp.DST[0]
+	}
+}
+
+//nolint:gocyclo,funlen
+func (u *ALUImpl) runVOP3ANativeOp(state InstEmuState, opcode insts.Opcode) {
+	switch opcode {
+	case 448:
+		u.runVMADLEGACYF32(state)
+	case 452, 453, 454, 455:
+		u.runVCUBE(state, opcode)
+	case 456:
+		u.runVBFEU32(state)
+	case 457:
+		u.runVBFEI32(state)
+	case 458:
+		u.runVBFIB32(state)
+	case 459:
+		u.runVFMAF32(state)
+	case 461:
+		u.runVLERPU8(state)
+	case 462:
+		u.runVALIGNBITB32(state)
+	case 463:
+		u.runVALIGNBYTEB32(state)
+	case 473:
+		u.runVSADU8(state)
+	case 474:
+		u.runVSADHIU8(state)
+	case 475:
+		u.runVSADU16(state)
+	case 476:
+		u.runVSADU32(state)
+	case 477, 496:
+		u.runVCVTPKU8F32(state)
+	case 478:
+		u.runVDIVFIXUPF32(state)
+	case 482:
+		u.runVDIVFMASF32(state)
+	case 484:
+		u.runVMSADU8(state)
+	case 485:
+		u.runVQSADPKU16U8(state, false)
+	case 486:
+		u.runVQSADPKU16U8(state, true)
+	case 489:
+		u.runVMADI64I32(state)
+	case 490, 494:
+		u.runVMADF16(state)
+	case 491:
+		u.runVMADU16(state)
+	case 492:
+		u.runVMADI16(state)
+	case 493:
+		u.runVPERMB32(state)
+	case 495:
+		u.runVDIVFIXUPF16(state)
+	default:
+		u.runVOP3ANative64Op(state, opcode)
+	}
+}
+
+// runVOP3ANative64Op runs the native VOP3a opcodes from 640 on, which are
+// mostly 64-bit and lane operations.
+//
+//nolint:gocyclo
+func (u *ALUImpl) runVOP3ANative64Op(state InstEmuState, opcode insts.Opcode) {
+	switch opcode {
+	case 642:
+		u.runVMINF64(state)
+	case 643:
+		u.runVMAXF64(state)
+	case 644:
+		u.runVLDEXPF64(state)
+	case 647:
+		u.runVMULHII32(state)
+	case 648:
+		u.runVLDEXPF32(state)
+	case 649:
+		u.runVREADLANEB32(state)
+	case 650:
+		u.runVWRITELANEB32(state)
+	case 651:
+		u.runVBCNTU32B32(state)
+	case 652:
+		u.runVMBCNTLOU32B32(state)
+	case 653:
+		u.runVMBCNTHIU32B32(state)
+	case 656:
+		u.runVLSHRREVB64(state)
+	case 658:
+		u.runVTRIGPREOPF64(state)
+	case 659:
+		u.runVBFMB32(state)
+	case 660:
+		u.runVCVTPKNORMI16F32(state)
+	case 661:
+		u.runVCVTPKNORMU16F32(state)
+	case 662:
+		u.runVCVTPKRTZF16F32(state)
+	case 663:
+		u.runVCVTPKU16U32(state)
+	case 664:
+		u.runVCVTPKI16I32(state)
+	default:
+		u.runVOP3AGFX9Op(state, opcode)
+	}
+}
+
+// runVOP3AGFX9Op runs the VOP3a opcodes that GFX9 adds.
+//
+//nolint:gocyclo,funlen
+func (u *ALUImpl) runVOP3AGFX9Op(state InstEmuState, opcode insts.Opcode) {
+	switch opcode {
+	case 497:
+		u.runVOP3AU32(state, func(a, b, c uint32) uint32 {
+			return uint32(uint16(a))*uint32(uint16(b)) + c
+		})
+	case 498:
+		u.runVOP3AU32(state, func(a, b, c uint32) uint32 {
+			return uint32(int32(asInt16(uint16(a)))*int32(asInt16(uint16(b))) +
+				asInt32(c))
+		})
+	case 499:
+		u.runVOP3AU32(state, func(a, b, c uint32) uint32 { return (a ^ b) + c })
+	case 500:
+		u.runVOP3AF16(state, min3Float)
+	case 501:
+		u.runVOP3AU16(state, func(a, b, c uint16) uint16 {
+			return int16ToBits(min(asInt16(a), asInt16(b), asInt16(c)))
+		})
+	case 502:
+		u.runVOP3AU16(state, func(a, b, c uint16) uint16 { return min(a, b, c) })
+	case 503:
+		u.runVOP3AF16(state, max3Float)
+	case 504:
+		u.runVOP3AU16(state, func(a, b, c uint16) uint16 {
+			return int16ToBits(max(asInt16(a), asInt16(b), asInt16(c)))
+		})
+	case 505:
+		u.runVOP3AU16(state, func(a, b, c uint16) uint16 { return max(a, b, c) })
+	case 506:
+		u.runVOP3AF16(state, med3Float)
+	case 507:
+		u.runVOP3AU16(state, func(a, b, c uint16) uint16 {
+			return int16ToBits(median3(asInt16(a), asInt16(b), asInt16(c)))
+		})
+	case 508:
+		u.runVOP3AU16(state, median3[uint16])
+	case 509:
+		u.runVOP3AU32(state, func(a, b, c uint32) uint32 { return (a << (b & 0x1f)) + c })
+	case 510:
+		u.runVOP3AU32(state, func(a, b, c uint32) uint32 { return (a + b) << (c & 0x1f) })
+	case 511:
+		u.runVOP3AU32(state, func(a, b, c uint32) uint32 { return a + b + c })
+	case 512:
+		u.runVOP3AU32(state, func(a, b, c uint32) uint32 { return (a << (b & 0x1f)) | c })
+	case 513:
+		u.runVOP3AU32(state, func(a, b, c uint32) uint32 { return (a & b) | c })
+	case 514:
+		u.runVOP3AU32(state, func(a, b, c uint32) uint32 { return a | b | c })
+	case 515, 518:
+		u.runVMADF16(state)
+	case 516:
+		u.runVMADU16(state)
+	case 517:
+		u.runVMADI16(state)
+	case 519:
+		u.runVDIVFIXUPF16(state)
+	case 668:
+		u.runVOP3AU32(state, func(a, b, _ uint32) uint32 { return a + b })
+	case 669:
+		u.runVOP3AU32(state, func(a, b, _ uint32) uint32 { return a - b })
+	case 670:
+		u.runVOP3AU16(state, func(a, b, _ uint16) uint16 { return a + b })
+	case 671:
+		u.runVOP3AU16(state, func(a, b, _ uint16) uint16 { return a - b })
+	case 672:
+		u.runVOP3AU32(state, func(a, b, _ uint32) uint32 {
+			return uint32(packHalves(uint16(a), uint16(b)))
+		})
+	default:
+		panicUnimplemented(state.Inst())
+	}
+}
+
+// runVOP3AU32 applies op to the lower 32 bits of the sources of the active
+// lanes.
+func (u *ALUImpl) runVOP3AU32(state InstEmuState, op func(a, b, c uint32) uint32) {
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		sp.DST[i] = uint64(op(uint32(sp.SRC0[i]), uint32(sp.SRC1[i]), uint32(sp.SRC2[i])))
+	}
+}
+
+// runVOP3AU16 applies op to the lower 16 bits of the sources of the active
+// lanes.
+func (u *ALUImpl) runVOP3AU16(state InstEmuState, op func(a, b, c uint16) uint16) {
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		sp.DST[i] = uint64(op(uint16(sp.SRC0[i]), uint16(sp.SRC1[i]), uint16(sp.SRC2[i])))
+	}
+}
+
+// runVOP3AF16 applies op to the half-precision sources of the active lanes.
+func (u *ALUImpl) runVOP3AF16(state InstEmuState, op func(a, b, c float64) float64) {
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		dst := op(f16ToFloat(sp.SRC0[i]), f16ToFloat(sp.SRC1[i]), f16ToFloat(sp.SRC2[i]))
+		sp.DST[i] = uint64(bitops.Float32ToFloat16(float32(dst)))
+	}
+}
+
+func min3Float(a, b, c float64) float64 {
+	return minNum(minNum(a, b), c)
+}
+
+func max3Float(a, b, c float64) float64 {
+	return maxNum(maxNum(a, b), c)
 }
 
+// med3Float returns the median of three numbers. A NaN is treated as the
+// smallest number, so that the median is taken from the others.
+func med3Float(a, b, c float64) float64 {
+	if math.IsNaN(a) || math.IsNaN(b) || math.IsNaN(c) {
+		return min3Float(a, b, c)
+	}
+
+	return median3(a, b, c)
+}
+
+func median3[T int16 | uint16 | float64](a, b, c T) T {
+	return max(min(a, b), min(max(a, b), c))
+}
+
+// The op_sel bit that GFX9 VOP3a instructions use to write the high half of
+// the destination. The lower bits select the high halves of the sources.
+const vop3OpSelDst = 0x8
+
 func (u *ALUImpl) vop3aPreprocess(state InstEmuState) {
 	inst := state.Inst()
 
+	if inst.OpSel&0x7 != 0 {
+		u.vop3aPreProcessOpSel(state)
+	}
+
 	if inst.Abs != 0 {
 		u.vop3aPreProcessAbs(state)
 	}
@@ -110,35 +403,78 @@ func (u *ALUImpl) vop3aPreprocess(state InstEmuState) {
 
 func (u *ALUImpl) vop3aPreProcessAbs(state InstEmuState) {
 	inst := state.Inst()
-	sp := state.Scratchpad().AsVOP3A()
 
-	if strings.Contains(inst.InstName, "F32") ||
+	if strings.Contains(inst.InstName, "F64") ||
+		strings.Contains(inst.InstName, "f64") {
+		u.vop3aPreProcessSignBit(state, inst.Abs, 1<<63, clearBits)
+	} else if strings.Contains(inst.InstName, "F32") ||
 		strings.Contains(inst.InstName, "f32") {
-		if inst.Abs&0x1 != 0 {
-			for i := 0; i < 64; i++ {
-				src0 := math.Float32frombits(uint32(sp.SRC0[i]))
-				src0 = float32(math.Abs(float64(src0)))
-				sp.SRC0[i] = uint64(math.Float32bits(src0))
-			}
+		u.vop3aPreProcessF32Abs(state)
+	} else if strings.Contains(inst.InstName, "F16") ||
+		strings.Contains(inst.InstName, "f16") {
+		u.vop3aPreProcessSignBit(state, inst.Abs, 1<<15, clearBits)
+	} else {
+		log.Printf("Absolute operation for %s is not implemented.", inst.InstName)
+	}
+}
+
+func (u *ALUImpl) vop3aPreProcessF32Abs(state InstEmuState) {
+	inst := state.Inst()
+	sp := state.Scratchpad().AsVOP3A()
+
+	if inst.Abs&0x1 != 0 {
+		for i := 0; i < 64; i++ {
+			src0 := math.Float32frombits(uint32(sp.SRC0[i]))
+			src0 = float32(math.Abs(float64(src0)))
+			sp.SRC0[i] = uint64(math.Float32bits(src0))
 		}
+	}
 
-		if inst.Abs&0x2 != 0 {
-			for i := 0; i < 64; i++ {
-				src1 := math.Float32frombits(uint32(sp.SRC1[i]))
-				src1 = float32(math.Abs(float64(src1)))
-				sp.SRC1[i] = uint64(math.Float32bits(src1))
-			}
+	if inst.Abs&0x2 != 0 {
+		for i := 0; i < 64; i++ {
+			src1 := math.Float32frombits(uint32(sp.SRC1[i]))
+			src1 = float32(math.Abs(float64(src1)))
+			sp.SRC1[i] = uint64(math.Float32bits(src1))
 		}
+	}
 
-		if inst.Abs&0x4 != 0 {
-			for i := 0; i < 64; i++ {
-				src2 := math.Float32frombits(uint32(sp.SRC2[i]))
-				src2 = float32(math.Abs(float64(src2)))
-				sp.SRC2[i] = uint64(math.Float32bits(src2))
-			}
+	if inst.Abs&0x4 != 0 {
+		for i := 0; i < 64; i++ {
+			src2 := math.Float32frombits(uint32(sp.SRC2[i]))
+			src2 = float32(math.Abs(float64(src2)))
+			sp.SRC2[i] = uint64(math.Float32bits(src2))
+		}
+	}
+}
+
+func clearBits(x, mask uint64) uint64 {
+	return x &^ mask
+}
+
+func flipBits(x, mask uint64) uint64 {
+	return x ^ mask
+}
+
+// vop3aPreProcessSignBit applies op to the sign bit of the sources that the
+// abs or neg modifier selects. Clearing the sign bit takes the absolute
+// value and flipping it negates.
+func (u *ALUImpl) vop3aPreProcessSignBit(
+	state InstEmuState,
+	modifier int,
+	signBit uint64,
+	op func(x, mask uint64) uint64,
+) {
+	sp := state.Scratchpad().AsVOP3A()
+	srcs := []*[64]uint64{&sp.SRC0, &sp.SRC1, &sp.SRC2}
+
+	for n, src := range srcs {
+		if modifier&(1<<n) == 0 {
+			continue
+		}
+
+		for i := 0; i < 64; i++ {
+			src[i] = op(src[i], signBit)
 		}
-	} else {
-		log.Printf("Absolute operation for %s is not implemented.", inst.InstName)
 	}
 }
 
@@ -151,6 +487,9 @@ func (u *ALUImpl) vop3aPreProcessNeg(state InstEmuState) {
 	} else if strings.Contains(inst.InstName, "F32") ||
 		strings.Contains(inst.InstName, "f32") {
 		u.vop3aPreProcessF32Neg(state)
+	} else if strings.Contains(inst.InstName, "F16") ||
+		strings.Contains(inst.InstName, "f16") {
+		u.vop3aPreProcessSignBit(state, inst.Neg, 1<<15, flipBits)
 	} else if strings.Contains(inst.InstName, "B32") ||
 		strings.Contains(inst.InstName, "b32") {
 		u.vop3aPreProcessB32Neg(state)
@@ -244,6 +583,37 @@ func (u *ALUImpl) vop3aPreProcessB32Neg(state InstEmuState) {
 	}
 }
 
+// vop3aPreProcessOpSel moves the high halves of the sources that op_sel
+// selects to the low halves, where the 16-bit instructions read them.
+func (u *ALUImpl) vop3aPreProcessOpSel(state InstEmuState) {
+	inst := state.Inst()
+	sp := state.Scratchpad().AsVOP3A()
+	srcs := []*[64]uint64{&sp.SRC0, &sp.SRC1, &sp.SRC2}
+
+	for n, src := range srcs {
+		if inst.OpSel&(1<<n) == 0 {
+			continue
+		}
+
+		for i := 0; i < 64; i++ {
+			src[i] = (src[i] >> 16) & 0xffff
+		}
+	}
+}
+
+// vop3aWriteDstHi moves the 16-bit results to the high halves of the
+// destination, keeping the low halves of the original destination.
+func (u *ALUImpl) vop3aWriteDstHi(state InstEmuState, origDst *[64]uint64) {
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		sp.DST[i] = (origDst[i] & 0xffff) | (sp.DST[i]&0xffff)<<16
+	}
+}
+
 func (u *ALUImpl) vop3aPostprocess(state InstEmuState) {
 	inst := state.Inst()
 
@@ -650,7 +1020,7 @@ func (u *ALUImpl) runVADDF64(state InstEmuState) {
 			sp.DST[i] = math.Float64bits(dst)
 		}
 	} else {
-		log.Panicf("SDWA for VOP3A instruction opcode  %d not implemented \n", inst.Opcode)
+		panicUnimplementedSDWA(inst)
 	}
 }
 
@@ -671,7 +1041,7 @@ func (u *ALUImpl) runVFMAF64(state InstEmuState) {
 			sp.DST[i] = math.Float64bits(dst)
 		}
 	} else {
-		log.Panicf("SDWA for VOP3A instruction opcode  %d not implemented \n", inst.Opcode)
+		panicUnimplementedSDWA(inst)
 	}
 }
 
@@ -700,7 +1070,7 @@ func (u *ALUImpl) runVMIN3F32(state InstEmuState) {
 			sp.DST[i] = uint64(math.Float32bits(dst))
 		}
 	} else {
-		log.Panicf("SDWA for VOP3A instruction opcode  %d not implemented \n", inst.Opcode)
+		panicUnimplementedSDWA(inst)
 	}
 }
 
@@ -729,7 +1099,7 @@ func (u *ALUImpl) runVMIN3I32(state InstEmuState) {
 			sp.DST[i] = uint64(int32ToBits(dst))
 		}
 	} else {
-		log.Panicf("SDWA for VOP3A instruction opcode  %d not implemented \n", inst.Opcode)
+		panicUnimplementedSDWA(inst)
 	}
 }
 
@@ -758,7 +1128,7 @@ func (u *ALUImpl) runVMIN3U32(state InstEmuState) {
 			sp.DST[i] = uint64(dst)
 		}
 	} else {
-		log.Panicf("SDWA for VOP3A instruction opcode  %d not implemented \n", inst.Opcode)
+		panicUnimplementedSDWA(inst)
 	}
 }
 
@@ -787,7 +1157,7 @@ func (u *ALUImpl) runVMAX3F32(state InstEmuState) {
 			sp.DST[i] = uint64(math.Float32bits(dst))
 		}
 	} else {
-		log.Panicf("SDWA for VOP3A instruction opcode  %d not implemented \n", inst.Opcode)
+		panicUnimplementedSDWA(inst)
 	}
 }
 
@@ -816,7 +1186,7 @@ func (u *ALUImpl) runVMAX3I32(state InstEmuState) {
 			sp.DST[i] = uint64(int32ToBits(dst))
 		}
 	} else {
-		log.Panicf("SDWA for VOP3A instruction opcode  %d not implemented \n", inst.Opcode)
+		panicUnimplementedSDWA(inst)
 	}
 }
 
@@ -845,7 +1215,7 @@ func (u *ALUImpl) runVMAX3U32(state InstEmuState) {
 			sp.DST[i] = uint64(dst)
 		}
 	} else {
-		log.Panicf("SDWA for VOP3A instruction opcode  %d not implemented \n", inst.Opcode)
+		panicUnimplementedSDWA(inst)
 	}
 }
 
@@ -869,7 +1239,7 @@ func (u *ALUImpl) runVMED3F32(state InstEmuState) {
 			sp.DST[i] = uint64(math.Float32bits(float32(list[1])))
 		}
 	} else {
-		log.Panicf("SDWA for VOP3A instruction opcode  %d not implemented \n", inst.Opcode)
+		panicUnimplementedSDWA(inst)
 	}
 }
 
@@ -894,7 +1264,7 @@ func (u *ALUImpl) runVMED3I32(state InstEmuState) {
 			sp.DST[i] = uint64(int32ToBits(dst))
 		}
 	} else {
-		log.Panicf("SDWA for VOP3A instruction opcode  %d not implemented \n", inst.Opcode)
+		panicUnimplementedSDWA(inst)
 	}
 }
 
@@ -916,7 +1286,7 @@ func (u *ALUImpl) runVMED3U32(state InstEmuState) {
 			sp.DST[i] = uint64(dst)
 		}
 	} else {
-		log.Panicf("SDWA for VOP3A instruction opcode  %d not implemented \n", inst.Opcode)
+		panicUnimplementedSDWA(inst)
 	}
 }
 
@@ -950,7 +1320,7 @@ func (u *ALUImpl) runVMULF64(state InstEmuState) {
 			sp.DST[i] = math.Float64bits(dst)
 		}
 	} else {
-		log.Panicf("SDWA for VOP3A instruction opcode  %d not implemented \n", inst.Opcode)
+		panicUnimplementedSDWA(inst)
 	}
 }
 
@@ -980,7 +1350,7 @@ func (u *ALUImpl) runVDIVFMASF64(state InstEmuState) {
 			sp.DST[i] = math.Float64bits(dst)
 		}
 	} else {
-		log.Panicf("SDWA for VOP3A instruction opcode  %d not implemented \n", inst.Opcode)
+		panicUnimplementedSDWA(inst)
 	}
 }
 
@@ -989,7 +1359,7 @@ func (u *ALUImpl) runVDIVFIXUPF64(state InstEmuState) {
 	inst := state.Inst()
 
 	if inst.IsSdwa {
-		log.Panicf("SDWA for VOP3A instruction opcode %d not implemented \n", inst.Opcode)
+		panicUnimplementedSDWA(inst)
 	}
 
 	var i uint
@@ -1072,3 +1442,793 @@ func (u *ALUImpl) isDIVFIXUPF64Overflow(
 	return int64(exponentSrc2-exponentSrc1) < -1075 ||
 		exponentSrc1 == 2047
 }
+
+func (u *ALUImpl) runVMADLEGACYF32(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		src0 := math.Float32frombits(uint32(sp.SRC0[i]))
+		src1 := math.Float32frombits(uint32(sp.SRC1[i]))
+		src2 := math.Float32frombits(uint32(sp.SRC2[i]))
+
+		// The legacy multiplication gives 0 if any operand is 0, even if the
+		// other operand is an infinity or NaN.
+		product := float32(0)
+		if src0 != 0 && src1 != 0 {
+			product = src0 * src1
+		}
+
+		sp.DST[i] = uint64(math.Float32bits(product + src2))
+	}
+}
+
+// cubeMap returns the face ID, the s and the t coordinates, and twice the
+// major axis coordinate of the cubemap lookup of a direction.
+func cubeMap(x, y, z float32) (id, sc, tc, ma float32) {
+	ax := float32(math.Abs(float64(x)))
+	ay := float32(math.Abs(float64(y)))
+	az := float32(math.Abs(float64(z)))
+
+	switch {
+	case az >= ax && az >= ay:
+		if z < 0 {
+			return 5, -x, -y, 2 * z
+		}
+		return 4, x, -y, 2 * z
+	case ay >= ax:
+		if y < 0 {
+			return 3, x, -z, 2 * y
+		}
+		return 2, x, z, 2 * y
+	default:
+		if x < 0 {
+			return 1, z, -y, 2 * x
+		}
+		return 0, -z, -y, 2 * x
+	}
+}
+
+// runVCUBE runs v_cubeid_f32, v_cubesc_f32, v_cubetc_f32, and
+// v_cubema_f32, which differ in the output of the cubemap lookup.
+func (u *ALUImpl) runVCUBE(state InstEmuState, opcode insts.Opcode) {
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		x := math.Float32frombits(uint32(sp.SRC0[i]))
+		y := math.Float32frombits(uint32(sp.SRC1[i]))
+		z := math.Float32frombits(uint32(sp.SRC2[i]))
+
+		id, sc, tc, ma := cubeMap(x, y, z)
+		dst := [4]float32{id, sc, tc, ma}[opcode-452]
+		sp.DST[i] = uint64(math.Float32bits(dst))
+	}
+}
+
+func (u *ALUImpl) runVBFEU32(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		src0 := uint32(sp.SRC0[i])
+		offset := sp.SRC1[i] & 0x1f
+		width := sp.SRC2[i] & 0x1f
+
+		sp.DST[i] = uint64((src0 >> offset) & (1<<width - 1))
+	}
+}
+
+func (u *ALUImpl) runVBFEI32(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		src0 := uint64(uint32(sp.SRC0[i]))
+		offset := sp.SRC1[i] & 0x1f
+		width := sp.SRC2[i] & 0x1f
+
+		dst := uint64(0)
+		if width > 0 {
+			field := (src0 >> offset) & (1<<width - 1)
+			dst = bitops.SignExt(field, int(width-1))
+		}
+
+		sp.DST[i] = uint64(uint32(dst))
+	}
+}
+
+func (u *ALUImpl) runVBFIB32(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		mask := uint32(sp.SRC0[i])
+		dst := mask&uint32(sp.SRC1[i]) | ^mask&uint32(sp.SRC2[i])
+		sp.DST[i] = uint64(dst)
+	}
+}
+
+func (u *ALUImpl) runVFMAF32(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		src0 := float64(math.Float32frombits(uint32(sp.SRC0[i])))
+		src1 := float64(math.Float32frombits(uint32(sp.SRC1[i])))
+		src2 := float64(math.Float32frombits(uint32(sp.SRC2[i])))
+
+		dst := float32(math.FMA(src0, src1, src2))
+		sp.DST[i] = uint64(math.Float32bits(dst))
+	}
+}
+
+// runVLERPU8 averages the bytes of SRC0 and SRC1, rounding up if the lowest
+// bit of the byte of SRC2 is set.
+func (u *ALUImpl) runVLERPU8(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		dst := uint32(0)
+		for b := 0; b < 32; b += 8 {
+			a := (uint32(sp.SRC0[i]) >> b) & 0xff
+			c := (uint32(sp.SRC1[i]) >> b) & 0xff
+			r := (uint32(sp.SRC2[i]) >> b) & 1
+			dst |= ((a + c + r) >> 1) << b
+		}
+
+		sp.DST[i] = uint64(dst)
+	}
+}
+
+func (u *ALUImpl) runVALIGNBITB32(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		src := uint64(uint32(sp.SRC0[i]))<<32 | uint64(uint32(sp.SRC1[i]))
+		sp.DST[i] = uint64(uint32(src >> (sp.SRC2[i] & 0x1f)))
+	}
+}
+
+func (u *ALUImpl) runVALIGNBYTEB32(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		src := uint64(uint32(sp.SRC0[i]))<<32 | uint64(uint32(sp.SRC1[i]))
+		sp.DST[i] = uint64(uint32(src >> (8 * (sp.SRC2[i] & 0x3))))
+	}
+}
+
+func absDiff(a, b uint32) uint32 {
+	if a > b {
+		return a - b
+	}
+
+	return b - a
+}
+
+// sumOfAbsDiff sums the absolute differences of the fields of a and b. The
+// fields are width bits wide. Fields of b that are 0 are skipped if
+// skipZero is set.
+func sumOfAbsDiff(a, b uint32, width int, skipZero bool) uint32 {
+	mask := uint32(1)<<width - 1
+	sum := uint32(0)
+	for shift := 0; shift < 32; shift += width {
+		fieldA := (a >> shift) & mask
+		fieldB := (b >> shift) & mask
+		if skipZero && fieldB == 0 {
+			continue
+		}
+
+		sum += absDiff(fieldA, fieldB)
+	}
+
+	return sum
+}
+
+func (u *ALUImpl) runVSADU8(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		sad := sumOfAbsDiff(uint32(sp.SRC0[i]), uint32(sp.SRC1[i]), 8, false)
+		sp.DST[i] = uint64(sad + uint32(sp.SRC2[i]))
+	}
+}
+
+func (u *ALUImpl) runVSADHIU8(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		sad := sumOfAbsDiff(uint32(sp.SRC0[i]), uint32(sp.SRC1[i]), 8, false)
+		sp.DST[i] = uint64(sad<<16 + uint32(sp.SRC2[i]))
+	}
+}
+
+func (u *ALUImpl) runVSADU16(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		sad := sumOfAbsDiff(uint32(sp.SRC0[i]), uint32(sp.SRC1[i]), 16, false)
+		sp.DST[i] = uint64(sad + uint32(sp.SRC2[i]))
+	}
+}
+
+func (u *ALUImpl) runVSADU32(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		sad := absDiff(uint32(sp.SRC0[i]), uint32(sp.SRC1[i]))
+		sp.DST[i] = uint64(sad + uint32(sp.SRC2[i]))
+	}
+}
+
+func (u *ALUImpl) runVMSADU8(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		sad := sumOfAbsDiff(uint32(sp.SRC0[i]), uint32(sp.SRC1[i]), 8, true)
+		sp.DST[i] = uint64(sad + uint32(sp.SRC2[i]))
+	}
+}
+
+// runVQSADPKU16U8 slides the 4-byte SRC1 over the 8 bytes of SRC0 and adds
+// the SAD at each of the first four byte offsets to the matching 16-bit
+// accumulator of SRC2. The masked form skips the zero bytes of SRC1.
+func (u *ALUImpl) runVQSADPKU16U8(state InstEmuState, masked bool) {
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		dst := uint64(0)
+		for j := 0; j < 4; j++ {
+			window := uint32(sp.SRC0[i] >> (8 * j))
+			acc := uint32(sp.SRC2[i]>>(16*j)) & 0xffff
+			sad := sumOfAbsDiff(window, uint32(sp.SRC1[i]), 8, masked) + acc
+			dst |= uint64(sad&0xffff) << (16 * j)
+		}
+		sp.DST[i] = dst
+	}
+}
+
+// runVCVTPKU8F32 converts SRC0 to a byte and places it in the byte of SRC2
+// that SRC1 selects. v_cvt_pkaccum_u8_f32 passes the destination in as
+// SRC2, so it runs the same way.
+func (u *ALUImpl) runVCVTPKU8F32(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		src0 := float64(math.Float32frombits(uint32(sp.SRC0[i])))
+		value := uint32(min(clampToUint32(math.RoundToEven(src0)), 0xff))
+		shift := 8 * (sp.SRC1[i] & 0x3)
+
+		dst := uint32(sp.SRC2[i])&^(0xff<<shift) | value<<shift
+		sp.DST[i] = uint64(dst)
+	}
+}
+
+// divFixup handles the special cases of the division of the numerator by
+// the denominator, given the quotient that the division steps produce.
+func divFixup(quotient, denominator, numerator float64) float64 {
+	negative := math.Signbit(denominator) != math.Signbit(numerator)
+	sign := 1.0
+	if negative {
+		sign = -1.0
+	}
+
+	switch {
+	case math.IsNaN(numerator):
+		return numerator
+	case math.IsNaN(denominator):
+		return denominator
+	case denominator == 0 && numerator == 0,
+		math.IsInf(denominator, 0) && math.IsInf(numerator, 0):
+		return math.NaN()
+	case denominator == 0 || math.IsInf(numerator, 0):
+		return math.Inf(int(sign))
+	case math.IsInf(denominator, 0) || numerator == 0:
+		return math.Copysign(0, sign)
+	}
+
+	return math.Copysign(quotient, sign)
+}
+
+func (u *ALUImpl) runVDIVFIXUPF32(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		dst := divFixup(
+			float64(math.Float32frombits(uint32(sp.SRC0[i]))),
+			float64(math.Float32frombits(uint32(sp.SRC1[i]))),
+			float64(math.Float32frombits(uint32(sp.SRC2[i]))))
+		sp.DST[i] = uint64(math.Float32bits(float32(dst)))
+	}
+}
+
+func (u *ALUImpl) runVDIVFIXUPF16(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		dst := divFixup(
+			f16ToFloat(sp.SRC0[i]), f16ToFloat(sp.SRC1[i]), f16ToFloat(sp.SRC2[i]))
+		sp.DST[i] = uint64(bitops.Float32ToFloat16(float32(dst)))
+	}
+}
+
+// runVDIVFMASF32 undoes the scaling of v_div_scale_f32 for the lanes that
+// have VCC set. The numerator is scaled up when it is tiny and the
+// denominator when the numerator is huge.
+func (u *ALUImpl) runVDIVFMASF32(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		src0 := float64(math.Float32frombits(uint32(sp.SRC0[i])))
+		src1 := float64(math.Float32frombits(uint32(sp.SRC1[i])))
+		src2 := float64(math.Float32frombits(uint32(sp.SRC2[i])))
+
+		dst := math.FMA(src0, src1, src2)
+		if laneMasked(sp.VCC, i) {
+			if (uint32(sp.SRC2[i])>>23)&0xff >= 127 {
+				dst = math.Ldexp(dst, 64)
+			} else {
+				dst = math.Ldexp(dst, -64)
+			}
+		}
+
+		sp.DST[i] = uint64(math.Float32bits(float32(dst)))
+	}
+}
+
+func (u *ALUImpl) runVMADI64I32(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		src0 := int64(asInt32(uint32(sp.SRC0[i])))
+		src1 := int64(asInt32(uint32(sp.SRC1[i])))
+		src2 := asInt64(sp.SRC2[i])
+
+		sp.DST[i] = int64ToBits(src0*src1 + src2)
+	}
+}
+
+// runVMADF16 runs both v_mad_f16 and v_fma_f16. The product of two
+// half-precision floats is exact in single precision, so both round once.
+func (u *ALUImpl) runVMADF16(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		dst := math.FMA(
+			f16ToFloat(sp.SRC0[i]), f16ToFloat(sp.SRC1[i]), f16ToFloat(sp.SRC2[i]))
+		sp.DST[i] = uint64(bitops.Float32ToFloat16(float32(dst)))
+	}
+}
+
+func (u *ALUImpl) runVMADU16(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		dst := uint16(sp.SRC0[i])*uint16(sp.SRC1[i]) + uint16(sp.SRC2[i])
+		sp.DST[i] = uint64(dst)
+	}
+}
+
+func (u *ALUImpl) runVMADI16(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		src0 := asInt16(uint16(sp.SRC0[i]))
+		src1 := asInt16(uint16(sp.SRC1[i]))
+		src2 := asInt16(uint16(sp.SRC2[i]))
+
+		sp.DST[i] = uint64(int16ToBits(src0*src1 + src2))
+	}
+}
+
+// permuteByte returns the byte of the 8 bytes of {SRC0, SRC1} that the
+// selector selects. Selectors 8 to 11 replicate the sign bit of every other
+// byte, 12 gives 0, and the larger ones give 0xff.
+func permuteByte(src uint64, sel uint32) uint32 {
+	switch {
+	case sel >= 13:
+		return 0xff
+	case sel == 12:
+		return 0
+	case sel >= 8:
+		signBit := (src >> (16*(sel-8) + 15)) & 1
+		return uint32(signBit) * 0xff
+	}
+
+	return uint32(src>>(8*sel)) & 0xff
+}
+
+func (u *ALUImpl) runVPERMB32(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		src := uint64(uint32(sp.SRC0[i]))<<32 | uint64(uint32(sp.SRC1[i]))
+		sel := uint32(sp.SRC2[i])
+
+		dst := uint32(0)
+		for b := 0; b < 32; b += 8 {
+			dst |= permuteByte(src, (sel>>b)&0xff) << b
+		}
+
+		sp.DST[i] = uint64(dst)
+	}
+}
+
+func (u *ALUImpl) runVMINF64(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		src0 := math.Float64frombits(sp.SRC0[i])
+		src1 := math.Float64frombits(sp.SRC1[i])
+		sp.DST[i] = math.Float64bits(minNum(src0, src1))
+	}
+}
+
+func (u *ALUImpl) runVMAXF64(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		src0 := math.Float64frombits(sp.SRC0[i])
+		src1 := math.Float64frombits(sp.SRC1[i])
+		sp.DST[i] = math.Float64bits(maxNum(src0, src1))
+	}
+}
+
+func (u *ALUImpl) runVLDEXPF64(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		src0 := math.Float64frombits(sp.SRC0[i])
+		exp := int(asInt32(uint32(sp.SRC1[i])))
+		sp.DST[i] = math.Float64bits(math.Ldexp(src0, exp))
+	}
+}
+
+// twoOverPi holds the leading fraction bits of 2/pi, most significant first.
+var twoOverPi = [...]uint64{
+	0xa2f9836e4e441529, 0xfc2757d1f534ddc0, 0xdb6295993c439041,
+	0xfe5163abdebbc561, 0xb7246e3a424dd2e0, 0x06492eea09d1921c,
+	0xfe1deb1cb129a73e, 0xe88235f52ebb4484, 0xe99c7026b45f7e41,
+	0x3991d639835339f4, 0x9c845f8bbdf9283b, 0x1ff897ffde05980f,
+	0xef2f118b5a0a6d1f, 0x6d367ecf27cb09b7, 0x4f463f669e5fea2d,
+	0x7527bac7ebe5f17b, 0x3d0739f78a5292ea, 0x6bfb5fb11f8d5d08,
+	0x56033046fc7b6bab,
+}
+
+// twoOverPiSegment returns the 53 fraction bits of 2/pi that start shift
+// bits after the binary point.
+func twoOverPiSegment(shift uint) uint64 {
+	idx := shift / 64
+	if idx+1 >= uint(len(twoOverPi)) {
+		return 0
+	}
+
+	bits := twoOverPi[idx]
+	if offset := shift % 64; offset != 0 {
+		bits = bits<<offset | twoOverPi[idx+1]>>(64-offset)
+	}
+
+	return bits >> 11
+}
+
+// runVTRIGPREOPF64 returns the segment of 2/pi that SRC1 selects, skipping
+// the bits that are too small to matter for the exponent of SRC0.
+func (u *ALUImpl) runVTRIGPREOPF64(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		exp := int((sp.SRC0[i] >> 52) & 0x7ff)
+		shift := int(sp.SRC1[i]&0x1f) * 53
+		if exp > 1077 {
+			shift += exp - 1077
+		}
+
+		scale := -53 - shift
+		if exp >= 1968 {
+			scale += 128
+		}
+
+		segment := float64(twoOverPiSegment(uint(shift)))
+		sp.DST[i] = math.Float64bits(math.Ldexp(segment, scale))
+	}
+}
+
+func (u *ALUImpl) runVLDEXPF32(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		src0 := float64(math.Float32frombits(uint32(sp.SRC0[i])))
+		exp := int(asInt32(uint32(sp.SRC1[i])))
+		dst := float32(math.Ldexp(src0, exp))
+		sp.DST[i] = uint64(math.Float32bits(dst))
+	}
+}
+
+func (u *ALUImpl) runVMULHII32(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		src0 := int64(asInt32(uint32(sp.SRC0[i])))
+		src1 := int64(asInt32(uint32(sp.SRC1[i])))
+		sp.DST[i] = uint64(uint32((src0 * src1) >> 32))
+	}
+}
+
+// runVREADLANEB32 copies SRC0 of the lane that SRC1 selects to the scalar
+// destination. Every lane carries the value, as the scalar destination is
+// written once per active lane.
+func (u *ALUImpl) runVREADLANEB32(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP3A()
+	lane := sp.SRC1[0] & 0x3f
+	for i := 0; i < 64; i++ {
+		sp.DST[i] = uint64(uint32(sp.SRC0[lane]))
+	}
+}
+
+// runVWRITELANEB32 writes the scalar SRC0 to the lane that SRC1 selects. The
+// other lanes keep the original value of the destination.
+func (u *ALUImpl) runVWRITELANEB32(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP3A()
+	lane := sp.SRC1[0] & 0x3f
+	sp.DST[lane] = uint64(uint32(sp.SRC0[0]))
+}
+
+func (u *ALUImpl) runVBCNTU32B32(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		count := uint32(bits.OnesCount32(uint32(sp.SRC0[i])))
+		sp.DST[i] = uint64(count + uint32(sp.SRC1[i]))
+	}
+}
+
+// runVMBCNTLOU32B32 counts the bits of SRC0 that stand for the lanes lower
+// than the current lane among lanes 0 to 31. Together with
+// v_mbcnt_hi_u32_b32, it gives the index of a lane among the active lanes.
+func (u *ALUImpl) runVMBCNTLOU32B32(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		lowerLanes := uint32(uint64(1)<<i - 1)
+		count := uint32(bits.OnesCount32(uint32(sp.SRC0[i]) & lowerLanes))
+		sp.DST[i] = uint64(count + uint32(sp.SRC1[i]))
+	}
+}
+
+func (u *ALUImpl) runVMBCNTHIU32B32(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		lowerLanes := uint32((uint64(1)<<i - 1) >> 32)
+		count := uint32(bits.OnesCount32(uint32(sp.SRC0[i]) & lowerLanes))
+		sp.DST[i] = uint64(count + uint32(sp.SRC1[i]))
+	}
+}
+
+func (u *ALUImpl) runVLSHRREVB64(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		sp.DST[i] = sp.SRC1[i] >> (sp.SRC0[i] & 0x3f)
+	}
+}
+
+func (u *ALUImpl) runVBFMB32(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		width := sp.SRC0[i] & 0x1f
+		offset := sp.SRC1[i] & 0x1f
+		sp.DST[i] = uint64(uint32((1<<width - 1) << offset))
+	}
+}
+
+// packHalves places lo in the lower 16 bits and hi in the upper 16 bits.
+func packHalves(lo, hi uint16) uint64 {
+	return uint64(hi)<<16 | uint64(lo)
+}
+
+func normI16(x float32) uint16 {
+	clamped := math.Max(-1, math.Min(1, float64(x)))
+	if math.IsNaN(float64(x)) {
+		clamped = 0
+	}
+
+	return int16ToBits(int16(math.RoundToEven(clamped * math.MaxInt16)))
+}
+
+func normU16(x float32) uint16 {
+	clamped := math.Max(0, math.Min(1, float64(x)))
+	if math.IsNaN(float64(x)) {
+		clamped = 0
+	}
+
+	return uint16(math.RoundToEven(clamped * math.MaxUint16))
+}
+
+func (u *ALUImpl) runVCVTPKNORMI16F32(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		src0 := math.Float32frombits(uint32(sp.SRC0[i]))
+		src1 := math.Float32frombits(uint32(sp.SRC1[i]))
+		sp.DST[i] = packHalves(normI16(src0), normI16(src1))
+	}
+}
+
+func (u *ALUImpl) runVCVTPKNORMU16F32(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		src0 := math.Float32frombits(uint32(sp.SRC0[i]))
+		src1 := math.Float32frombits(uint32(sp.SRC1[i]))
+		sp.DST[i] = packHalves(normU16(src0), normU16(src1))
+	}
+}
+
+// float32ToFloat16RTZ converts a float to half precision, rounding toward
+// zero.
+func float32ToFloat16RTZ(x float32) uint16 {
+	h := bitops.Float32ToFloat16(x)
+	if math.IsNaN(float64(x)) || math.IsInf(float64(x), 0) {
+		return h
+	}
+
+	// Halves are sign-magnitude, so decrementing the bits moves toward zero.
+	rounded := bitops.Float16ToFloat32(h)
+	if math.Abs(float64(rounded)) > math.Abs(float64(x)) {
+		h--
+	}
+
+	return h
+}
+
+func (u *ALUImpl) runVCVTPKRTZF16F32(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		src0 := math.Float32frombits(uint32(sp.SRC0[i]))
+		src1 := math.Float32frombits(uint32(sp.SRC1[i]))
+		sp.DST[i] = packHalves(
+			float32ToFloat16RTZ(src0), float32ToFloat16RTZ(src1))
+	}
+}
+
+func (u *ALUImpl) runVCVTPKU16U32(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		lo := uint16(min(uint32(sp.SRC0[i]), math.MaxUint16))
+		hi := uint16(min(uint32(sp.SRC1[i]), math.MaxUint16))
+		sp.DST[i] = packHalves(lo, hi)
+	}
+}
+
+func clampToInt16(x int32) uint16 {
+	return int16ToBits(int16(max(min(x, math.MaxInt16), math.MinInt16)))
+}
+
+func (u *ALUImpl) runVCVTPKI16I32(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		lo := clampToInt16(asInt32(uint32(sp.SRC0[i])))
+		hi := clampToInt16(asInt32(uint32(sp.SRC1[i])))
+		sp.DST[i] = packHalves(lo, hi)
+	}
+}
@@ -701,4 +701,5044 @@ var _ = Describe("ALU", func() {
 		// inf / inf
 		Expect(sp.DST[0]).To(Equal(math.Float64bits(0xFFF8000000000000)))
 	})
+
+	It("should run v_cmpx_lt_f32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 81
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x3
+		sp.SRC0[0] = uint64(math.Float32bits(1.0))
+		sp.SRC1[0] = uint64(math.Float32bits(2.0))
+		sp.SRC0[1] = uint64(math.Float32bits(2.0))
+		sp.SRC1[1] = uint64(math.Float32bits(1.0))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x1)))
+		Expect(sp.EXEC).To(Equal(uint64(0x1)))
+	})
+
+	It("should run v_mac_f16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 291
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x4000
+		sp.SRC1[0] = 0x3e00
+		sp.DST[0] = 0x3c00
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x4400)))
+	})
+
+	It("should run v_cvt_f64_f32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 336
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(1.5))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(math.Float64bits(1.5))))
+	})
+
+	It("should run v_movreld_b32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 374
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 517
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(517)))
+	})
+
+	It("should run v_movrels_b32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 375
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 517
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(517)))
+	})
+
+	It("should run v_movrelsd_b32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 376
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 517
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(517)))
+	})
+
+	It("should run v_cubeid_f32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 452
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x7
+		sp.SRC0[0] = uint64(math.Float32bits(1.0))
+		sp.SRC1[0] = uint64(math.Float32bits(0.5))
+		sp.SRC2[0] = uint64(math.Float32bits(-2.0))
+		sp.SRC0[1] = uint64(math.Float32bits(1.0))
+		sp.SRC1[1] = uint64(math.Float32bits(-3.0))
+		sp.SRC2[1] = uint64(math.Float32bits(0.5))
+		sp.SRC0[2] = uint64(math.Float32bits(-4.0))
+		sp.SRC1[2] = uint64(math.Float32bits(1.0))
+		sp.SRC2[2] = uint64(math.Float32bits(0.5))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(5.0)))))
+		Expect(sp.DST[1]).To(Equal(uint64(uint64(math.Float32bits(3.0)))))
+		Expect(sp.DST[2]).To(Equal(uint64(uint64(math.Float32bits(1.0)))))
+	})
+
+	It("should run v_bfi_b32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 458
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x0000ffff
+		sp.SRC1[0] = 0x12345678
+		sp.SRC2[0] = 0xabcdef01
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xabcd5678)))
+	})
+
+	It("should run v_lerp_u8", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 461
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x00ff0102
+		sp.SRC1[0] = 0x00ff0203
+		sp.SRC2[0] = 0x00000100
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x00ff0202)))
+	})
+
+	It("should run v_alignbit_b32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 462
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x12345678
+		sp.SRC1[0] = 0x9abcdef0
+		sp.SRC2[0] = 8
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x789abcde)))
+	})
+
+	It("should run v_sad_u8", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 473
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x01020304
+		sp.SRC1[0] = 0x04030201
+		sp.SRC2[0] = 10
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(18)))
+	})
+
+	It("should run v_cvt_pk_u8_f32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 477
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(300.0))
+		sp.SRC1[0] = 2
+		sp.SRC2[0] = 0x11223344
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x11ff3344)))
+	})
+
+	It("should run v_qsad_pk_u16_u8", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 485
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x0807060504030201
+		sp.SRC1[0] = 0x04030201
+		sp.SRC2[0] = 0x0004000300020001
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x0010000b00060001)))
+	})
+
+	It("should run v_mqsad_pk_u16_u8", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 486
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x0807060504030201
+		sp.SRC1[0] = 0x04030001
+		sp.SRC2[0] = 0x0004000300020001
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x000d000900050001)))
+	})
+
+	It("should run v_cvt_pkaccum_u8_f32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 496
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(7.0))
+		sp.SRC1[0] = 1
+		sp.SRC2[0] = 0x11223344
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x11220744)))
+	})
+
+	It("should run v_div_fixup_f32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 478
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x3
+		sp.SRC0[0] = uint64(math.Float32bits(2.0))
+		sp.SRC1[0] = uint64(math.Float32bits(-2.0))
+		sp.SRC2[0] = uint64(math.Float32bits(4.0))
+		sp.SRC0[1] = uint64(math.Float32bits(2.0))
+		sp.SRC1[1] = uint64(math.Float32bits(0.0))
+		sp.SRC2[1] = uint64(math.Float32bits(4.0))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(-2.0)))))
+		Expect(sp.DST[1]).To(Equal(uint64(uint64(math.Float32bits(float32(math.Inf(1)))))))
+	})
+
+	It("should run v_div_fmas_f32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 482
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x3
+		sp.VCC = 0x2
+		sp.SRC0[0] = uint64(math.Float32bits(2.0))
+		sp.SRC1[0] = uint64(math.Float32bits(3.0))
+		sp.SRC2[0] = uint64(math.Float32bits(1.0))
+		sp.SRC0[1] = uint64(math.Float32bits(2.0))
+		sp.SRC1[1] = uint64(math.Float32bits(3.0))
+		sp.SRC2[1] = uint64(math.Float32bits(1.0))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(7.0)))))
+		Expect(sp.DST[1]).To(Equal(uint64(uint64(math.Float32bits(float32(math.Ldexp(7, 64)))))))
+	})
+
+	It("should run v_mad_i64_i32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 489
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xffffffff
+		sp.SRC1[0] = 0x80000000
+		sp.SRC2[0] = 1
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x80000001)))
+	})
+
+	It("should run v_perm_b32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 493
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x8899aabb
+		sp.SRC1[0] = 0x11223344
+		sp.SRC2[0] = 0x0c0b0704
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x00ff88bb)))
+	})
+
+	It("should run v_mul_hi_i32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 647
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xffffffff
+		sp.SRC1[0] = 2
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xffffffff)))
+	})
+
+	It("should run v_readlane_b32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 649
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.SRC0[5] = 42
+		sp.SRC1[0] = 5
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(42)))
+	})
+
+	It("should run v_writelane_b32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 650
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.SRC0[0] = 42
+		sp.SRC1[0] = 5
+		sp.DST[4] = 7
+
+		alu.Run(state)
+
+		Expect(sp.DST[5]).To(Equal(uint64(42)))
+		Expect(sp.DST[4]).To(Equal(uint64(7)))
+	})
+
+	It("should run v_mbcnt_lo_u32_b32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 652
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0xffffffffffffffff
+		sp.SRC0[4] = 0xff
+		sp.SRC0[40] = 0xff
+		sp.SRC1[4] = 1
+
+		alu.Run(state)
+
+		Expect(sp.DST[4]).To(Equal(uint64(5)))
+		Expect(sp.DST[40]).To(Equal(uint64(8)))
+	})
+
+	It("should run v_mbcnt_hi_u32_b32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 653
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0xffffffffffffffff
+		sp.SRC0[4] = 0xff
+		sp.SRC0[40] = 0xff
+
+		alu.Run(state)
+
+		Expect(sp.DST[4]).To(Equal(uint64(0)))
+		Expect(sp.DST[40]).To(Equal(uint64(8)))
+	})
+
+	It("should run v_trig_preop_f64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 658
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x7
+		sp.SRC0[0] = math.Float64bits(1.0)
+		sp.SRC1[0] = 0
+		sp.SRC0[1] = math.Float64bits(1.0)
+		sp.SRC1[1] = 1
+		sp.SRC0[2] = math.Float64bits(math.Ldexp(1, 55))
+		sp.SRC1[2] = 0
+
+		alu.Run(state)
+
+		hi := uint64(0xa2f9836e4e441529)
+		lo := uint64(0xfc2757d1f534ddc0)
+		Expect(math.Float64frombits(sp.DST[0])).To(
+			Equal(math.Ldexp(float64(hi>>11), -53)))
+		Expect(math.Float64frombits(sp.DST[0])).To(
+			BeNumerically("~", 2/math.Pi, 1e-15))
+		Expect(math.Float64frombits(sp.DST[1])).To(
+			Equal(math.Ldexp(float64((hi<<53|lo>>11)>>11), -106)))
+		Expect(math.Float64frombits(sp.DST[2])).To(
+			Equal(math.Ldexp(float64((hi<<1|lo>>63)>>11), -54)))
+	})
+
+	It("should run v_mad_legacy_f32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 448
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x3
+		sp.SRC0[0] = uint64(math.Float32bits(0))
+		sp.SRC1[0] = uint64(math.Float32bits(float32(math.Inf(1))))
+		sp.SRC2[0] = uint64(math.Float32bits(1.5))
+		sp.SRC0[1] = uint64(math.Float32bits(2.0))
+		sp.SRC1[1] = uint64(math.Float32bits(3.0))
+		sp.SRC2[1] = uint64(math.Float32bits(1.0))
+
+		alu.Run(state)
+
+		Expect(math.Float32frombits(uint32(sp.DST[0]))).To(Equal(float32(1.5)))
+		Expect(math.Float32frombits(uint32(sp.DST[1]))).To(Equal(float32(7.0)))
+	})
+
+	It("should run v_cubesc_f32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 453
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(1.0))
+		sp.SRC1[0] = uint64(math.Float32bits(0.5))
+		sp.SRC2[0] = uint64(math.Float32bits(-2.0))
+
+		alu.Run(state)
+
+		Expect(math.Float32frombits(uint32(sp.DST[0]))).To(Equal(float32(-1.0)))
+	})
+
+	It("should run v_cubetc_f32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 454
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(1.0))
+		sp.SRC1[0] = uint64(math.Float32bits(0.5))
+		sp.SRC2[0] = uint64(math.Float32bits(-2.0))
+
+		alu.Run(state)
+
+		Expect(math.Float32frombits(uint32(sp.DST[0]))).To(Equal(float32(-0.5)))
+	})
+
+	It("should run v_cubema_f32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 455
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(1.0))
+		sp.SRC1[0] = uint64(math.Float32bits(0.5))
+		sp.SRC2[0] = uint64(math.Float32bits(-2.0))
+
+		alu.Run(state)
+
+		Expect(math.Float32frombits(uint32(sp.DST[0]))).To(Equal(float32(-4.0)))
+	})
+
+	It("should run v_bfe_u32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 456
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x12345678
+		sp.SRC1[0] = 8
+		sp.SRC2[0] = 12
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x456)))
+	})
+
+	It("should run v_bfe_i32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 457
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x3
+		sp.SRC0[0] = 0x00000f00
+		sp.SRC1[0] = 8
+		sp.SRC2[0] = 4
+		sp.SRC0[1] = 0x00000f00
+		sp.SRC1[1] = 8
+		sp.SRC2[1] = 0
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xffffffff)))
+		Expect(sp.DST[1]).To(Equal(uint64(0)))
+	})
+
+	It("should run v_fma_f32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 459
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(2.0))
+		sp.SRC1[0] = uint64(math.Float32bits(3.0))
+		sp.SRC2[0] = uint64(math.Float32bits(1.0))
+
+		alu.Run(state)
+
+		Expect(math.Float32frombits(uint32(sp.DST[0]))).To(Equal(float32(7.0)))
+	})
+
+	It("should run v_alignbyte_b32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 463
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x11223344
+		sp.SRC1[0] = 0x55667788
+		sp.SRC2[0] = 1
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x44556677)))
+	})
+
+	It("should run v_sad_hi_u8", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 474
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x01020304
+		sp.SRC1[0] = 0x04030201
+		sp.SRC2[0] = 10
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x8000a)))
+	})
+
+	It("should run v_sad_u16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 475
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x00050001
+		sp.SRC1[0] = 0x00010003
+		sp.SRC2[0] = 1
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(7)))
+	})
+
+	It("should run v_sad_u32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 476
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 3
+		sp.SRC1[0] = 10
+		sp.SRC2[0] = 5
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(12)))
+	})
+
+	It("should run v_msad_u8", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 484
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x01020304
+		sp.SRC1[0] = 0x04000201
+		sp.SRC2[0] = 0
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(7)))
+	})
+
+	It("should run v_mad_f16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 490
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x4000
+		sp.SRC1[0] = 0x4200
+		sp.SRC2[0] = 0x3c00
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x4700)))
+	})
+
+	It("should run v_mad_u16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 491
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x3
+		sp.SRC0[0] = 3
+		sp.SRC1[0] = 4
+		sp.SRC2[0] = 5
+		sp.SRC0[1] = 0x100
+		sp.SRC1[1] = 0x100
+		sp.SRC2[1] = 5
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(17)))
+		Expect(sp.DST[1]).To(Equal(uint64(5)))
+	})
+
+	It("should run v_mad_i16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 492
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xfffe
+		sp.SRC1[0] = 3
+		sp.SRC2[0] = 1
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xfffb)))
+	})
+
+	It("should run v_fma_f16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 494
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x3800
+		sp.SRC1[0] = 0x4400
+		sp.SRC2[0] = 0xbc00
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x3c00)))
+	})
+
+	It("should run v_div_fixup_f16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 495
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x3
+		sp.SRC0[0] = 0x4000
+		sp.SRC1[0] = 0
+		sp.SRC2[0] = 0x3c00
+		sp.SRC0[1] = 0x3800
+		sp.SRC1[1] = 0xc000
+		sp.SRC2[1] = 0x3c00
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x7c00)))
+		Expect(sp.DST[1]).To(Equal(uint64(0xb800)))
+	})
+
+	It("should run v_min_f64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 642
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x3
+		sp.SRC0[0] = math.Float64bits(1.5)
+		sp.SRC1[0] = math.Float64bits(-2.0)
+		sp.SRC0[1] = math.Float64bits(math.NaN())
+		sp.SRC1[1] = math.Float64bits(3.0)
+
+		alu.Run(state)
+
+		Expect(math.Float64frombits(sp.DST[0])).To(Equal(-2.0))
+		Expect(math.Float64frombits(sp.DST[1])).To(Equal(3.0))
+	})
+
+	It("should run v_max_f64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 643
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x3
+		sp.SRC0[0] = math.Float64bits(1.5)
+		sp.SRC1[0] = math.Float64bits(-2.0)
+		sp.SRC0[1] = math.Float64bits(math.NaN())
+		sp.SRC1[1] = math.Float64bits(3.0)
+
+		alu.Run(state)
+
+		Expect(math.Float64frombits(sp.DST[0])).To(Equal(1.5))
+		Expect(math.Float64frombits(sp.DST[1])).To(Equal(3.0))
+	})
+
+	It("should run v_ldexp_f64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 644
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x3
+		sp.SRC0[0] = math.Float64bits(1.5)
+		sp.SRC1[0] = 3
+		sp.SRC0[1] = math.Float64bits(1.0)
+		sp.SRC1[1] = 0xffffffff
+
+		alu.Run(state)
+
+		Expect(math.Float64frombits(sp.DST[0])).To(Equal(12.0))
+		Expect(math.Float64frombits(sp.DST[1])).To(Equal(0.5))
+	})
+
+	It("should run v_ldexp_f32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 648
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(1.5))
+		sp.SRC1[0] = 2
+
+		alu.Run(state)
+
+		Expect(math.Float32frombits(uint32(sp.DST[0]))).To(Equal(float32(6.0)))
+	})
+
+	It("should run v_bcnt_u32_b32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 651
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xf0f0
+		sp.SRC1[0] = 3
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(11)))
+	})
+
+	It("should run v_lshrrev_b64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 656
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 4
+		sp.SRC1[0] = 0x8000000000000000
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x0800000000000000)))
+	})
+
+	It("should run v_bfm_b32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 659
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 4
+		sp.SRC1[0] = 8
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xf00)))
+	})
+
+	It("should run v_cvt_pknorm_u16_f32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 661
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(0.5))
+		sp.SRC1[0] = uint64(math.Float32bits(2.0))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xffff8000)))
+	})
+
+	It("should run v_cvt_pk_u16_u32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 663
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x12345
+		sp.SRC1[0] = 7
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x0007ffff)))
+	})
+
+	It("should run v_cvt_pk_i16_i32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 664
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xfffe7960
+		sp.SRC1[0] = 5
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x00058000)))
+	})
+
+	It("should run v_cvt_pknorm_i16_f32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 660
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(-2.0))
+		sp.SRC1[0] = uint64(math.Float32bits(0.5))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x40008001)))
+	})
+
+	It("should run v_cvt_pkrtz_f16_f32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 662
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(1.0009))
+		sp.SRC1[0] = uint64(math.Float32bits(-2.0))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xc0003c00)))
+	})
+
+	It("should run v_mad_u32_u16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 497
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x10002
+		sp.SRC1[0] = 3
+		sp.SRC2[0] = 0x10000
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x10006)))
+	})
+
+	It("should run v_max3_f16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 503
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x3c00
+		sp.SRC1[0] = 0x4000
+		sp.SRC2[0] = 0xbc00
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x4000)))
+	})
+
+	It("should run v_med3_i16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 507
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xffff
+		sp.SRC1[0] = 5
+		sp.SRC2[0] = 2
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(2)))
+	})
+
+	It("should run v_lshl_add_u32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 509
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 1
+		sp.SRC1[0] = 4
+		sp.SRC2[0] = 3
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(19)))
+	})
+
+	It("should run v_add3_u32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 511
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xffffffff
+		sp.SRC1[0] = 2
+		sp.SRC2[0] = 3
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(4)))
+	})
+
+	It("should run v_and_or_b32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 513
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xf0
+		sp.SRC1[0] = 0x3c
+		sp.SRC2[0] = 1
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x31)))
+	})
+
+	It("should run v_pack_b32_f16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 672
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x3c00
+		sp.SRC1[0] = 0x4000
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x40003c00)))
+	})
+
+	It("should run v_add_u16 with op_sel", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 294
+		state.inst.OpSel = 0x9 // The high half of SRC0 and DST
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.DST[0] = 0xaaaa1111
+		sp.SRC0[0] = 0x00050000
+		sp.SRC1[0] = 3
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x00081111)))
+	})
+
+	It("should run v_cmp_class_f32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 16
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, classLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1101)))
+	})
+
+	It("should run v_cmpx_class_f32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 17
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, classLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1101)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1101)))
+	})
+
+	It("should run v_cmp_class_f64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 18
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, classLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1101)))
+	})
+
+	It("should run v_cmpx_class_f64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 19
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, classLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1101)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1101)))
+	})
+
+	It("should run v_cmp_class_f16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 20
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, classLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1101)))
+	})
+
+	It("should run v_cmpx_class_f16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 21
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, classLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1101)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1101)))
+	})
+
+	It("should run v_cmp_f_f16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 32
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0000)))
+	})
+
+	It("should run v_cmp_lt_f16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 33
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0001)))
+	})
+
+	It("should run v_cmp_eq_f16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 34
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0010)))
+	})
+
+	It("should run v_cmp_le_f16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 35
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0011)))
+	})
+
+	It("should run v_cmp_gt_f16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 36
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0100)))
+	})
+
+	It("should run v_cmp_lg_f16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 37
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0101)))
+	})
+
+	It("should run v_cmp_ge_f16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 38
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0110)))
+	})
+
+	It("should run v_cmp_o_f16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 39
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0111)))
+	})
+
+	It("should run v_cmp_u_f16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 40
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1000)))
+	})
+
+	It("should run v_cmp_nge_f16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 41
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1001)))
+	})
+
+	It("should run v_cmp_nlg_f16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 42
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1010)))
+	})
+
+	It("should run v_cmp_ngt_f16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 43
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1011)))
+	})
+
+	It("should run v_cmp_nle_f16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 44
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1100)))
+	})
+
+	It("should run v_cmp_neq_f16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 45
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1101)))
+	})
+
+	It("should run v_cmp_nlt_f16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 46
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1110)))
+	})
+
+	It("should run v_cmp_tru_f16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 47
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1111)))
+	})
+
+	It("should run v_cmpx_f_f16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 48
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0000)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0000)))
+	})
+
+	It("should run v_cmpx_lt_f16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 49
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0001)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0001)))
+	})
+
+	It("should run v_cmpx_eq_f16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 50
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0010)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0010)))
+	})
+
+	It("should run v_cmpx_le_f16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 51
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0011)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0011)))
+	})
+
+	It("should run v_cmpx_gt_f16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 52
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0100)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0100)))
+	})
+
+	It("should run v_cmpx_lg_f16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 53
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0101)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0101)))
+	})
+
+	It("should run v_cmpx_ge_f16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 54
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0110)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0110)))
+	})
+
+	It("should run v_cmpx_o_f16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 55
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0111)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0111)))
+	})
+
+	It("should run v_cmpx_u_f16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 56
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1000)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1000)))
+	})
+
+	It("should run v_cmpx_nge_f16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 57
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1001)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1001)))
+	})
+
+	It("should run v_cmpx_nlg_f16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 58
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1010)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1010)))
+	})
+
+	It("should run v_cmpx_ngt_f16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 59
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1011)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1011)))
+	})
+
+	It("should run v_cmpx_nle_f16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 60
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1100)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1100)))
+	})
+
+	It("should run v_cmpx_neq_f16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 61
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1101)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1101)))
+	})
+
+	It("should run v_cmpx_nlt_f16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 62
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1110)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1110)))
+	})
+
+	It("should run v_cmpx_tru_f16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 63
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1111)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1111)))
+	})
+
+	It("should run v_cmp_f_f32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 64
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0000)))
+	})
+
+	It("should run v_cmp_eq_f32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 66
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0010)))
+	})
+
+	It("should run v_cmp_le_f32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 67
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0011)))
+	})
+
+	It("should run v_cmp_lg_f32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 69
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0101)))
+	})
+
+	It("should run v_cmp_ge_f32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 70
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0110)))
+	})
+
+	It("should run v_cmp_o_f32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 71
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0111)))
+	})
+
+	It("should run v_cmp_u_f32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 72
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1000)))
+	})
+
+	It("should run v_cmp_nge_f32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 73
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1001)))
+	})
+
+	It("should run v_cmp_nlg_f32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 74
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1010)))
+	})
+
+	It("should run v_cmp_ngt_f32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 75
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1011)))
+	})
+
+	It("should run v_cmp_nle_f32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 76
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1100)))
+	})
+
+	It("should run v_cmp_neq_f32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 77
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1101)))
+	})
+
+	It("should run v_cmp_tru_f32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 79
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1111)))
+	})
+
+	It("should run v_cmpx_f_f32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 80
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0000)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0000)))
+	})
+
+	It("should run v_cmpx_eq_f32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 82
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0010)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0010)))
+	})
+
+	It("should run v_cmpx_le_f32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 83
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0011)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0011)))
+	})
+
+	It("should run v_cmpx_gt_f32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 84
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0100)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0100)))
+	})
+
+	It("should run v_cmpx_lg_f32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 85
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0101)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0101)))
+	})
+
+	It("should run v_cmpx_ge_f32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 86
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0110)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0110)))
+	})
+
+	It("should run v_cmpx_o_f32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 87
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0111)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0111)))
+	})
+
+	It("should run v_cmpx_u_f32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 88
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1000)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1000)))
+	})
+
+	It("should run v_cmpx_nge_f32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 89
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1001)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1001)))
+	})
+
+	It("should run v_cmpx_nlg_f32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 90
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1010)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1010)))
+	})
+
+	It("should run v_cmpx_ngt_f32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 91
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1011)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1011)))
+	})
+
+	It("should run v_cmpx_nle_f32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 92
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1100)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1100)))
+	})
+
+	It("should run v_cmpx_neq_f32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 93
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1101)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1101)))
+	})
+
+	It("should run v_cmpx_nlt_f32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 94
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1110)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1110)))
+	})
+
+	It("should run v_cmpx_tru_f32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 95
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1111)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1111)))
+	})
+
+	It("should run v_cmp_f_f64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 96
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0000)))
+	})
+
+	It("should run v_cmp_lt_f64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 97
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0001)))
+	})
+
+	It("should run v_cmp_eq_f64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 98
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0010)))
+	})
+
+	It("should run v_cmp_le_f64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 99
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0011)))
+	})
+
+	It("should run v_cmp_gt_f64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 100
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0100)))
+	})
+
+	It("should run v_cmp_lg_f64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 101
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0101)))
+	})
+
+	It("should run v_cmp_ge_f64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 102
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0110)))
+	})
+
+	It("should run v_cmp_o_f64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 103
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0111)))
+	})
+
+	It("should run v_cmp_u_f64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 104
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1000)))
+	})
+
+	It("should run v_cmp_nge_f64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 105
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1001)))
+	})
+
+	It("should run v_cmp_nlg_f64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 106
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1010)))
+	})
+
+	It("should run v_cmp_ngt_f64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 107
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1011)))
+	})
+
+	It("should run v_cmp_nle_f64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 108
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1100)))
+	})
+
+	It("should run v_cmp_neq_f64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 109
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1101)))
+	})
+
+	It("should run v_cmp_nlt_f64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 110
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1110)))
+	})
+
+	It("should run v_cmp_tru_f64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 111
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1111)))
+	})
+
+	It("should run v_cmpx_f_f64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 112
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0000)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0000)))
+	})
+
+	It("should run v_cmpx_lt_f64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 113
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0001)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0001)))
+	})
+
+	It("should run v_cmpx_eq_f64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 114
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0010)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0010)))
+	})
+
+	It("should run v_cmpx_le_f64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 115
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0011)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0011)))
+	})
+
+	It("should run v_cmpx_gt_f64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 116
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0100)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0100)))
+	})
+
+	It("should run v_cmpx_lg_f64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 117
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0101)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0101)))
+	})
+
+	It("should run v_cmpx_ge_f64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 118
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0110)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0110)))
+	})
+
+	It("should run v_cmpx_o_f64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 119
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0111)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0111)))
+	})
+
+	It("should run v_cmpx_u_f64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 120
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1000)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1000)))
+	})
+
+	It("should run v_cmpx_nge_f64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 121
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1001)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1001)))
+	})
+
+	It("should run v_cmpx_nlg_f64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 122
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1010)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1010)))
+	})
+
+	It("should run v_cmpx_ngt_f64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 123
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1011)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1011)))
+	})
+
+	It("should run v_cmpx_nle_f64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 124
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1100)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1100)))
+	})
+
+	It("should run v_cmpx_neq_f64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 125
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1101)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1101)))
+	})
+
+	It("should run v_cmpx_nlt_f64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 126
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1110)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1110)))
+	})
+
+	It("should run v_cmpx_tru_f64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 127
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1111)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1111)))
+	})
+
+	It("should run v_cmp_f_i16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 160
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0000)))
+	})
+
+	It("should run v_cmp_lt_i16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 161
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1001)))
+	})
+
+	It("should run v_cmp_eq_i16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 162
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0010)))
+	})
+
+	It("should run v_cmp_le_i16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 163
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1011)))
+	})
+
+	It("should run v_cmp_gt_i16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 164
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0100)))
+	})
+
+	It("should run v_cmp_lg_i16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 165
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1101)))
+	})
+
+	It("should run v_cmp_ge_i16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 166
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0110)))
+	})
+
+	It("should run v_cmp_tru_i16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 167
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1111)))
+	})
+
+	It("should run v_cmp_f_u16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 168
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0000)))
+	})
+
+	It("should run v_cmp_lt_u16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 169
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0001)))
+	})
+
+	It("should run v_cmp_eq_u16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 170
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0010)))
+	})
+
+	It("should run v_cmp_le_u16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 171
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0011)))
+	})
+
+	It("should run v_cmp_gt_u16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 172
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1100)))
+	})
+
+	It("should run v_cmp_lg_u16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 173
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1101)))
+	})
+
+	It("should run v_cmp_ge_u16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 174
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1110)))
+	})
+
+	It("should run v_cmp_tru_u16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 175
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1111)))
+	})
+
+	It("should run v_cmpx_f_i16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 176
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0000)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0000)))
+	})
+
+	It("should run v_cmpx_lt_i16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 177
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1001)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1001)))
+	})
+
+	It("should run v_cmpx_eq_i16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 178
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0010)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0010)))
+	})
+
+	It("should run v_cmpx_le_i16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 179
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1011)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1011)))
+	})
+
+	It("should run v_cmpx_gt_i16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 180
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0100)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0100)))
+	})
+
+	It("should run v_cmpx_lg_i16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 181
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1101)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1101)))
+	})
+
+	It("should run v_cmpx_ge_i16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 182
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0110)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0110)))
+	})
+
+	It("should run v_cmpx_tru_i16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 183
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1111)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1111)))
+	})
+
+	It("should run v_cmpx_f_u16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 184
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0000)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0000)))
+	})
+
+	It("should run v_cmpx_lt_u16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 185
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0001)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0001)))
+	})
+
+	It("should run v_cmpx_eq_u16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 186
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0010)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0010)))
+	})
+
+	It("should run v_cmpx_le_u16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 187
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0011)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0011)))
+	})
+
+	It("should run v_cmpx_gt_u16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 188
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1100)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1100)))
+	})
+
+	It("should run v_cmpx_lg_u16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 189
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1101)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1101)))
+	})
+
+	It("should run v_cmpx_ge_u16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 190
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1110)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1110)))
+	})
+
+	It("should run v_cmpx_tru_u16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 191
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1111)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1111)))
+	})
+
+	It("should run v_cmp_f_i32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 192
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0000)))
+	})
+
+	It("should run v_cmp_eq_i32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 194
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0010)))
+	})
+
+	It("should run v_cmp_lg_i32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 197
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1101)))
+	})
+
+	It("should run v_cmp_tru_i32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 199
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1111)))
+	})
+
+	It("should run v_cmp_f_u32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 200
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0000)))
+	})
+
+	It("should run v_cmp_tru_u32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 207
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1111)))
+	})
+
+	It("should run v_cmpx_f_i32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 208
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0000)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0000)))
+	})
+
+	It("should run v_cmpx_lt_i32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 209
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1001)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1001)))
+	})
+
+	It("should run v_cmpx_eq_i32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 210
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0010)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0010)))
+	})
+
+	It("should run v_cmpx_le_i32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 211
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1011)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1011)))
+	})
+
+	It("should run v_cmpx_gt_i32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 212
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0100)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0100)))
+	})
+
+	It("should run v_cmpx_lg_i32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 213
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1101)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1101)))
+	})
+
+	It("should run v_cmpx_ge_i32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 214
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0110)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0110)))
+	})
+
+	It("should run v_cmpx_tru_i32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 215
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1111)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1111)))
+	})
+
+	It("should run v_cmpx_f_u32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 216
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0000)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0000)))
+	})
+
+	It("should run v_cmpx_lt_u32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 217
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0001)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0001)))
+	})
+
+	It("should run v_cmpx_eq_u32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 218
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0010)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0010)))
+	})
+
+	It("should run v_cmpx_le_u32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 219
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0011)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0011)))
+	})
+
+	It("should run v_cmpx_gt_u32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 220
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1100)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1100)))
+	})
+
+	It("should run v_cmpx_lg_u32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 221
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1101)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1101)))
+	})
+
+	It("should run v_cmpx_ge_u32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 222
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1110)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1110)))
+	})
+
+	It("should run v_cmpx_tru_u32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 223
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["32"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1111)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1111)))
+	})
+
+	It("should run v_cmp_f_i64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 224
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0000)))
+	})
+
+	It("should run v_cmp_lt_i64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 225
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1001)))
+	})
+
+	It("should run v_cmp_eq_i64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 226
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0010)))
+	})
+
+	It("should run v_cmp_le_i64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 227
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1011)))
+	})
+
+	It("should run v_cmp_gt_i64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 228
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0100)))
+	})
+
+	It("should run v_cmp_lg_i64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 229
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1101)))
+	})
+
+	It("should run v_cmp_ge_i64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 230
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0110)))
+	})
+
+	It("should run v_cmp_tru_i64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 231
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1111)))
+	})
+
+	It("should run v_cmp_f_u64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 232
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0000)))
+	})
+
+	It("should run v_cmp_eq_u64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 234
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0010)))
+	})
+
+	It("should run v_cmp_le_u64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 235
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0011)))
+	})
+
+	It("should run v_cmp_gt_u64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 236
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1100)))
+	})
+
+	It("should run v_cmp_lg_u64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 237
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1101)))
+	})
+
+	It("should run v_cmp_ge_u64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 238
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1110)))
+	})
+
+	It("should run v_cmp_tru_u64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 239
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1111)))
+	})
+
+	It("should run v_cmpx_f_i64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 240
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0000)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0000)))
+	})
+
+	It("should run v_cmpx_lt_i64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 241
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1001)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1001)))
+	})
+
+	It("should run v_cmpx_eq_i64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 242
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0010)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0010)))
+	})
+
+	It("should run v_cmpx_le_i64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 243
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1011)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1011)))
+	})
+
+	It("should run v_cmpx_gt_i64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 244
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0100)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0100)))
+	})
+
+	It("should run v_cmpx_lg_i64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 245
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1101)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1101)))
+	})
+
+	It("should run v_cmpx_ge_i64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 246
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0110)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0110)))
+	})
+
+	It("should run v_cmpx_tru_i64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 247
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1111)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1111)))
+	})
+
+	It("should run v_cmpx_f_u64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 248
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0000)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0000)))
+	})
+
+	It("should run v_cmpx_lt_u64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 249
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0001)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0001)))
+	})
+
+	It("should run v_cmpx_eq_u64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 250
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0010)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0010)))
+	})
+
+	It("should run v_cmpx_le_u64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 251
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b0011)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0011)))
+	})
+
+	It("should run v_cmpx_gt_u64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 252
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1100)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1100)))
+	})
+
+	It("should run v_cmpx_lg_u64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 253
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1101)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1101)))
+	})
+
+	It("should run v_cmpx_ge_u64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 254
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1110)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1110)))
+	})
+
+	It("should run v_cmpx_tru_u64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 255
+
+		sp := state.Scratchpad().AsVOP3A()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0b1111)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1111)))
+	})
+
+	It("should run V_ADD_F32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 257
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(1.5))
+		sp.SRC1[0] = uint64(math.Float32bits(2.0))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(3.5)))))
+	})
+
+	It("should run V_SUBREV_F32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 259
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(1.5))
+		sp.SRC1[0] = uint64(math.Float32bits(4.0))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(2.5)))))
+	})
+
+	It("should run V_MUL_LEGACY_F32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 260
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(0))
+		sp.SRC1[0] = uint64(math.Float32bits(float32(math.Inf(1))))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(0)))))
+	})
+
+	It("should run V_MUL_F32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 261
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(1.5))
+		sp.SRC1[0] = uint64(math.Float32bits(2.0))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(3.0)))))
+	})
+
+	It("should run V_MUL_I32_I24 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 262
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xfffffe
+		sp.SRC1[0] = 3
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xfffffffa)))
+	})
+
+	It("should run V_MUL_HI_I32_I24 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 263
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x7fffff
+		sp.SRC1[0] = 0x7fffff
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x3fff)))
+	})
+
+	It("should run V_MUL_U32_U24 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 264
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x1000003
+		sp.SRC1[0] = 5
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(15)))
+	})
+
+	It("should run V_MUL_HI_U32_U24 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 265
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xffffff
+		sp.SRC1[0] = 0xffffff
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xffff)))
+	})
+
+	It("should run V_MIN_F32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 266
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(1.5))
+		sp.SRC1[0] = uint64(math.Float32bits(-2.0))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(-2.0)))))
+	})
+
+	It("should run V_MAX_F32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 267
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(1.5))
+		sp.SRC1[0] = uint64(math.Float32bits(-2.0))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(1.5)))))
+	})
+
+	It("should run V_MIN_I32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 268
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xffffffff
+		sp.SRC1[0] = 1
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xffffffff)))
+	})
+
+	It("should run V_MAX_I32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 269
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xffffffff
+		sp.SRC1[0] = 1
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(1)))
+	})
+
+	It("should run V_MIN_U32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 270
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xffffffff
+		sp.SRC1[0] = 1
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(1)))
+	})
+
+	It("should run V_MAX_U32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 271
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xffffffff
+		sp.SRC1[0] = 1
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xffffffff)))
+	})
+
+	It("should run V_LSHRREV_B32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 272
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 4
+		sp.SRC1[0] = 0x80
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x8)))
+	})
+
+	It("should run V_ASHRREV_I32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 273
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 4
+		sp.SRC1[0] = 0x80000000
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xf8000000)))
+	})
+
+	It("should run V_LSHLREV_B32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 274
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 4
+		sp.SRC1[0] = 1
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x10)))
+	})
+
+	It("should run V_AND_B32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 275
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xff00ff00
+		sp.SRC1[0] = 0x0ff00ff0
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x0f000f00)))
+	})
+
+	It("should run V_OR_B32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 276
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xff00ff00
+		sp.SRC1[0] = 0x0ff00ff0
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xfff0fff0)))
+	})
+
+	It("should run V_XOR_B32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 277
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xff00ff00
+		sp.SRC1[0] = 0x0ff00ff0
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xf0f0f0f0)))
+	})
+
+	It("should run V_MAC_F32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 278
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.DST[0] = uint64(math.Float32bits(1.0))
+		sp.SRC0[0] = uint64(math.Float32bits(2.0))
+		sp.SRC1[0] = uint64(math.Float32bits(3.0))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(7.0)))))
+	})
+
+	It("should run V_ADD_F16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 287
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x3c00
+		sp.SRC1[0] = 0x4000
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x4200)))
+	})
+
+	It("should run V_SUB_F16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 288
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x4200
+		sp.SRC1[0] = 0x3c00
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x4000)))
+	})
+
+	It("should run V_SUBREV_F16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 289
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x3c00
+		sp.SRC1[0] = 0x4200
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x4000)))
+	})
+
+	It("should run V_MUL_F16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 290
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x4000
+		sp.SRC1[0] = 0x4200
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x4600)))
+	})
+
+	It("should run V_SUB_U16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 295
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 5
+		sp.SRC1[0] = 7
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xfffe)))
+	})
+
+	It("should run V_SUBREV_U16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 296
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 5
+		sp.SRC1[0] = 7
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(2)))
+	})
+
+	It("should run V_MUL_LO_U16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 297
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x100
+		sp.SRC1[0] = 0x101
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x100)))
+	})
+
+	It("should run V_LSHLREV_B16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 298
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 4
+		sp.SRC1[0] = 0x0f01
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xf010)))
+	})
+
+	It("should run V_LSHRREV_B16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 299
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 4
+		sp.SRC1[0] = 0x8000
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x0800)))
+	})
+
+	It("should run V_ASHRREV_I16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 300
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 4
+		sp.SRC1[0] = 0x8000
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xf800)))
+	})
+
+	It("should run V_MAX_F16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 301
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x3c00
+		sp.SRC1[0] = 0xc000
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x3c00)))
+	})
+
+	It("should run V_MIN_F16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 302
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x3c00
+		sp.SRC1[0] = 0xc000
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xc000)))
+	})
+
+	It("should run V_MAX_U16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 303
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xffff
+		sp.SRC1[0] = 1
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xffff)))
+	})
+
+	It("should run V_MAX_I16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 304
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xffff
+		sp.SRC1[0] = 1
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(1)))
+	})
+
+	It("should run V_MIN_U16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 305
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xffff
+		sp.SRC1[0] = 1
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(1)))
+	})
+
+	It("should run V_MIN_I16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 306
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xffff
+		sp.SRC1[0] = 1
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xffff)))
+	})
+
+	It("should run V_LDEXP_F16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 307
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x3c00
+		sp.SRC1[0] = 3
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x4800)))
+	})
+
+	It("should run V_MOV_B32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 321
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x12345678
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x12345678)))
+	})
+
+	It("should run V_CVT_I32_F64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 323
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = math.Float64bits(-2.75)
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xfffffffe)))
+	})
+
+	It("should run V_CVT_F64_I32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 324
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xfffffffd
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(math.Float64bits(-3.0))))
+	})
+
+	It("should run V_CVT_F32_I32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 325
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xfffffffd
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(-3.0)))))
+	})
+
+	It("should run V_CVT_F32_U32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 326
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xffffffff
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(4294967296.0)))))
+	})
+
+	It("should run V_CVT_U32_F32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 327
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(3.75))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(3)))
+	})
+
+	It("should run V_CVT_I32_F32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 328
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(-3.75))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xfffffffd)))
+	})
+
+	It("should run V_CVT_F16_F32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 330
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(1.5))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x3e00)))
+	})
+
+	It("should run V_CVT_F32_F16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 331
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x3e00
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(1.5)))))
+	})
+
+	It("should run V_CVT_RPI_I32_F32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 332
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(-2.5))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xfffffffe)))
+	})
+
+	It("should run V_CVT_FLR_I32_F32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 333
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(-2.5))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xfffffffd)))
+	})
+
+	It("should run V_CVT_OFF_F32_I4 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 334
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xf
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(-0.0625)))))
+	})
+
+	It("should run V_CVT_F32_F64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 335
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = math.Float64bits(1.5)
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(1.5)))))
+	})
+
+	It("should run V_CVT_F32_UBYTE0 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 337
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x04030201
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(1.0)))))
+	})
+
+	It("should run V_CVT_F32_UBYTE1 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 338
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x04030201
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(2.0)))))
+	})
+
+	It("should run V_CVT_F32_UBYTE2 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 339
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x04030201
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(3.0)))))
+	})
+
+	It("should run V_CVT_F32_UBYTE3 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 340
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x04030201
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(4.0)))))
+	})
+
+	It("should run V_CVT_U32_F64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 341
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = math.Float64bits(7.9)
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(7)))
+	})
+
+	It("should run V_CVT_F64_U32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 342
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xffffffff
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(math.Float64bits(4294967295.0))))
+	})
+
+	It("should run V_TRUNC_F64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 343
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = math.Float64bits(-2.5)
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(math.Float64bits(-2.0))))
+	})
+
+	It("should run V_CEIL_F64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 344
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = math.Float64bits(2.25)
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(math.Float64bits(3.0))))
+	})
+
+	It("should run V_RNDNE_F64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 345
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = math.Float64bits(2.5)
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(math.Float64bits(2.0))))
+	})
+
+	It("should run V_FLOOR_F64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 346
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = math.Float64bits(-2.5)
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(math.Float64bits(-3.0))))
+	})
+
+	It("should run V_FRACT_F32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 347
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(-1.25))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(0.75)))))
+	})
+
+	It("should run V_TRUNC_F32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 348
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(-2.5))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(-2.0)))))
+	})
+
+	It("should run V_CEIL_F32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 349
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(2.25))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(3.0)))))
+	})
+
+	It("should run V_RNDNE_F32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 350
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(3.5))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(4.0)))))
+	})
+
+	It("should run V_FLOOR_F32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 351
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(-2.5))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(-3.0)))))
+	})
+
+	It("should run V_EXP_F32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 352
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(3.0))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(8.0)))))
+	})
+
+	It("should run V_LOG_F32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 353
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(8.0))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(3.0)))))
+	})
+
+	It("should run V_RCP_F32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 354
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(4.0))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(0.25)))))
+	})
+
+	It("should run V_RCP_IFLAG_F32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 355
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(4.0))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(0.25)))))
+	})
+
+	It("should run V_RSQ_F32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 356
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(4.0))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(0.5)))))
+	})
+
+	It("should run V_RCP_F64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 357
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = math.Float64bits(4.0)
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(math.Float64bits(0.25))))
+	})
+
+	It("should run V_RSQ_F64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 358
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = math.Float64bits(16.0)
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(math.Float64bits(0.25))))
+	})
+
+	It("should run V_SQRT_F32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 359
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(16.0))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(4.0)))))
+	})
+
+	It("should run V_SQRT_F64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 360
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = math.Float64bits(2.25)
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(math.Float64bits(1.5))))
+	})
+
+	It("should run V_SIN_F32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 361
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(0.25))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(1.0)))))
+	})
+
+	It("should run V_COS_F32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 362
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(0.5))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(-1.0)))))
+	})
+
+	It("should run V_NOT_B32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 363
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x0f0f0f0f
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xf0f0f0f0)))
+	})
+
+	It("should run V_BFREV_B32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 364
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x1
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x80000000)))
+	})
+
+	It("should run V_FFBH_U32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 365
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x00010000
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(15)))
+	})
+
+	It("should run V_FFBL_B32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 366
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x00010000
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(16)))
+	})
+
+	It("should run V_FFBH_I32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 367
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xfffe0000
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(15)))
+	})
+
+	It("should run V_FREXP_EXP_I32_F64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 368
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = math.Float64bits(8.0)
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(4)))
+	})
+
+	It("should run V_FREXP_MANT_F64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 369
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = math.Float64bits(8.0)
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(math.Float64bits(0.5))))
+	})
+
+	It("should run V_FRACT_F64 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 370
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = math.Float64bits(-1.25)
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(math.Float64bits(0.75))))
+	})
+
+	It("should run V_FREXP_EXP_I32_F32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 371
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(0.75))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0)))
+	})
+
+	It("should run V_FREXP_MANT_F32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 372
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(6.0))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(0.75)))))
+	})
+
+	It("should run V_CVT_F16_U16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 377
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 3
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x4200)))
+	})
+
+	It("should run V_CVT_F16_I16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 378
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xfffe
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xc000)))
+	})
+
+	It("should run V_CVT_U16_F16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 379
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x4700
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(7)))
+	})
+
+	It("should run V_CVT_I16_F16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 380
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xc700
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xfff9)))
+	})
+
+	It("should run V_RCP_F16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 381
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x4400
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x3400)))
+	})
+
+	It("should run V_SQRT_F16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 382
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x4400
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x4000)))
+	})
+
+	It("should run V_RSQ_F16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 383
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x4400
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x3800)))
+	})
+
+	It("should run V_LOG_F16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 384
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x4800
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x4200)))
+	})
+
+	It("should run V_EXP_F16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 385
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x4200
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x4800)))
+	})
+
+	It("should run V_FREXP_MANT_F16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 386
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x4600
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x3a00)))
+	})
+
+	It("should run V_FREXP_EXP_I16_F16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 387
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x4600
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(3)))
+	})
+
+	It("should run V_FLOOR_F16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 388
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xc100
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xc200)))
+	})
+
+	It("should run V_CEIL_F16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 389
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x4080
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x4200)))
+	})
+
+	It("should run V_TRUNC_F16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 390
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xc100
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xc000)))
+	})
+
+	It("should run V_RNDNE_F16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 391
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x4100
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x4000)))
+	})
+
+	It("should run V_FRACT_F16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 392
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0xbd00
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x3a00)))
+	})
+
+	It("should run V_SIN_F16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 393
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x3400
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x3c00)))
+	})
+
+	It("should run V_COS_F16 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 394
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x3800
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xbc00)))
+	})
+
+	It("should run V_EXP_LEGACY_F32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 395
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(3.0))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(8.0)))))
+	})
+
+	It("should run V_LOG_LEGACY_F32 VOP3a", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3a
+		state.inst.Opcode = 396
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = uint64(math.Float32bits(8.0))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(3.0)))))
+	})
 })
@@ -1,7 +1,6 @@
 package emu
 
 import (
-	"log"
 	"math"
 )
 
@@ -23,10 +22,12 @@ func (u *ALUImpl) runVOP3B(state InstEmuState) {
 		u.runVSUBBU32VOP3b(state)
 	case 286:
 		u.runVSUBBREVU32VOP3b(state)
+	case 480:
+		u.runVDIVSCALEF32(state)
 	case 481:
 		u.runVDIVSCALEF64(state)
 	default:
-		log.Panicf("Opcode %d for VOP3b format is not implemented", inst.Opcode)
+		panicUnimplemented(inst)
 	}
 
 	u.vop3aPostprocess(state)
@@ -208,3 +209,59 @@ func (u *ALUImpl) runVDIVSCALEF64(state InstEmuState) {
 		}
 	}
 }
+
+func isDenormF32(x float32) bool {
+	bits := math.Float32bits(x)
+	return bits&0x7f800000 == 0 && bits&0x7fffff != 0
+}
+
+func exponentF32(x float32) int {
+	return int(math.Float32bits(x)>>23) & 0xff
+}
+
+// runVDIVSCALEF32 scales the numerator or the denominator of a division by
+// 2^64 or 2^-64 so that the division steps do not overflow or underflow. SDST
+// records the lanes where v_div_fmas_f32 needs to undo the scaling.
+func (u *ALUImpl) runVDIVSCALEF32(state InstEmuState) {
+	sp := state.Scratchpad().AsVOP3B()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		src0 := math.Float32frombits(uint32(sp.SRC0[i]))
+		den := math.Float32frombits(uint32(sp.SRC1[i]))
+		num := math.Float32frombits(uint32(sp.SRC2[i]))
+
+		scaleUp := float32(math.Ldexp(float64(src0), 64))
+		dst := src0
+
+		switch {
+		case num == 0 || den == 0:
+			dst = float32(math.NaN())
+		case exponentF32(num)-exponentF32(den) >= 96:
+			sp.SDST |= 1 << i
+			if src0 == den {
+				dst = scaleUp
+			}
+		case isDenormF32(den):
+			dst = scaleUp
+		case isDenormF32(1/den) && isDenormF32(num/den):
+			sp.SDST |= 1 << i
+			if src0 == den {
+				dst = scaleUp
+			}
+		case isDenormF32(1 / den):
+			dst = float32(math.Ldexp(float64(src0), -64))
+		case isDenormF32(num / den):
+			sp.SDST |= 1 << i
+			if src0 == num {
+				dst = scaleUp
+			}
+		case exponentF32(num) <= 23:
+			dst = scaleUp
+		}
+
+		sp.DST[i] = uint64(math.Float32bits(dst))
+	}
+}
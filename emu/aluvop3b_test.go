@@ -159,4 +159,24 @@ var _ = Describe("ALU", func() {
 		Expect(sp.DST[0]).To(Equal(math.Float64bits(math.Pow(2.0, 128))))
 	})
 
+	It("should run V_DIV_SCALE_F32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3b
+		state.inst.Opcode = 480
+
+		sp := state.Scratchpad().AsVOP3B()
+		sp.EXEC = 0x3
+		sp.SRC0[0] = uint64(math.Float32bits(2.0))
+		sp.SRC1[0] = uint64(math.Float32bits(2.0))
+		sp.SRC2[0] = uint64(math.Float32bits(4.0))
+		sp.SRC0[1] = uint64(math.Float32bits(1e-35))
+		sp.SRC1[1] = uint64(math.Float32bits(2.0))
+		sp.SRC2[1] = uint64(math.Float32bits(1e-35))
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(uint64(math.Float32bits(2.0)))))
+		Expect(sp.DST[1]).To(Equal(uint64(uint64(math.Float32bits(float32(math.Ldexp(float64(float32(1e-35)), 64)))))))
+		Expect(sp.SDST).To(Equal(uint64(0)))
+	})
 })
@@ -0,0 +1,248 @@
+package emu
+
+import (
+	"math"
+
+	"github.com/sarchlab/mgpusim/v3/bitops"
+)
+
+// runVOP3P runs the packed math instructions that GFX9 adds. They work on
+// the two 16-bit halves of the registers at the same time. The op_sel bits
+// pick the source halves of the low result and the op_sel_hi bits pick the
+// ones of the high result.
+//
+//nolint:gocyclo,funlen
+func (u *ALUImpl) runVOP3P(state InstEmuState) {
+	inst := state.Inst()
+	switch inst.Opcode {
+	case 0:
+		u.runVOP3PU16(state, func(a, b, c uint16) uint16 {
+			return int16ToBits(asInt16(a)*asInt16(b) + asInt16(c))
+		})
+	case 1:
+		u.runVOP3PU16(state, func(a, b, _ uint16) uint16 { return a * b })
+	case 2, 10:
+		u.runVOP3PU16(state, func(a, b, _ uint16) uint16 { return a + b })
+	case 3, 11:
+		u.runVOP3PU16(state, func(a, b, _ uint16) uint16 { return a - b })
+	case 4:
+		u.runVOP3PU16(state, func(a, b, _ uint16) uint16 { return b << (a & 0xf) })
+	case 5:
+		u.runVOP3PU16(state, func(a, b, _ uint16) uint16 { return b >> (a & 0xf) })
+	case 6:
+		u.runVOP3PU16(state, func(a, b, _ uint16) uint16 {
+			return int16ToBits(asInt16(b) >> (a & 0xf))
+		})
+	case 7:
+		u.runVOP3PU16(state, func(a, b, _ uint16) uint16 {
+			return int16ToBits(max(asInt16(a), asInt16(b)))
+		})
+	case 8:
+		u.runVOP3PU16(state, func(a, b, _ uint16) uint16 {
+			return int16ToBits(min(asInt16(a), asInt16(b)))
+		})
+	case 9:
+		u.runVOP3PU16(state, func(a, b, c uint16) uint16 { return a*b + c })
+	case 12:
+		u.runVOP3PU16(state, func(a, b, _ uint16) uint16 { return max(a, b) })
+	case 13:
+		u.runVOP3PU16(state, func(a, b, _ uint16) uint16 { return min(a, b) })
+	case 14:
+		u.runVOP3PF16(state, math.FMA)
+	case 15:
+		u.runVOP3PF16(state, func(a, b, _ float64) float64 { return a + b })
+	case 16:
+		u.runVOP3PF16(state, func(a, b, _ float64) float64 { return a * b })
+	case 17:
+		u.runVOP3PF16(state, func(a, b, _ float64) float64 { return minNum(a, b) })
+	case 18:
+		u.runVOP3PF16(state, func(a, b, _ float64) float64 { return maxNum(a, b) })
+	case 32, 33, 34:
+		u.runVMADMIX(state)
+	case 35:
+		u.runVDOT2F32F16(state)
+	case 36:
+		u.runVDOTInt(state, 16, true)
+	case 37:
+		u.runVDOTInt(state, 16, false)
+	case 38:
+		u.runVDOTInt(state, 8, true)
+	case 39:
+		u.runVDOTInt(state, 8, false)
+	case 40:
+		u.runVDOTInt(state, 4, true)
+	case 41:
+		u.runVDOTInt(state, 4, false)
+	default:
+		panicUnimplemented(inst)
+	}
+}
+
+// vop3pSrcHalves returns the halves of the n-th source that the low and the
+// high results use.
+func vop3pSrcHalves(opSel, opSelHi int, n int, src uint64) (lo, hi uint16) {
+	lo = uint16(src)
+	if opSel&(1<<n) != 0 {
+		lo = uint16(src >> 16)
+	}
+
+	hi = uint16(src)
+	if opSelHi&(1<<n) != 0 {
+		hi = uint16(src >> 16)
+	}
+
+	return lo, hi
+}
+
+// runVOP3PU16 applies op to the 16-bit halves of the sources of the active
+// lanes.
+func (u *ALUImpl) runVOP3PU16(state InstEmuState, op func(a, b, c uint16) uint16) {
+	inst := state.Inst()
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		var lo, hi [3]uint16
+		for n, src := range []uint64{sp.SRC0[i], sp.SRC1[i], sp.SRC2[i]} {
+			lo[n], hi[n] = vop3pSrcHalves(inst.OpSel, inst.OpSelHi, n, src)
+		}
+
+		sp.DST[i] = packHalves(op(lo[0], lo[1], lo[2]), op(hi[0], hi[1], hi[2]))
+	}
+}
+
+// runVOP3PF16 applies op to the half-precision halves of the sources of the
+// active lanes. The neg_lo and neg_hi modifiers negate the halves.
+func (u *ALUImpl) runVOP3PF16(state InstEmuState, op func(a, b, c float64) float64) {
+	inst := state.Inst()
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		var lo, hi [3]float64
+		for n, src := range []uint64{sp.SRC0[i], sp.SRC1[i], sp.SRC2[i]} {
+			loBits, hiBits := vop3pSrcHalves(inst.OpSel, inst.OpSelHi, n, src)
+			lo[n] = negateIf(f16ToFloat(uint64(loBits)), inst.Neg&(1<<n) != 0)
+			hi[n] = negateIf(f16ToFloat(uint64(hiBits)), inst.NegHi&(1<<n) != 0)
+		}
+
+		sp.DST[i] = packHalves(
+			bitops.Float32ToFloat16(float32(op(lo[0], lo[1], lo[2]))),
+			bitops.Float32ToFloat16(float32(op(hi[0], hi[1], hi[2]))),
+		)
+	}
+}
+
+func negateIf(x float64, neg bool) float64 {
+	if neg {
+		return -x
+	}
+
+	return x
+}
+
+// mixSource returns the n-th source of the v_mad_mix instructions. A source
+// with its op_sel_hi bit clear is a 32-bit float. Otherwise, it is the
+// half-precision half that the op_sel bit picks. The neg_hi bits of these
+// instructions take the absolute values.
+func mixSource(opSel, opSelHi, neg, abs int, n int, src uint64) float64 {
+	var x float64
+	switch {
+	case opSelHi&(1<<n) == 0:
+		x = f32ToFloat(src)
+	case opSel&(1<<n) != 0:
+		x = f16ToFloat(src >> 16)
+	default:
+		x = f16ToFloat(src)
+	}
+
+	if abs&(1<<n) != 0 {
+		x = math.Abs(x)
+	}
+
+	return negateIf(x, neg&(1<<n) != 0)
+}
+
+// runVMADMIX runs v_mad_mix_f32, which writes a 32-bit float, and
+// v_mad_mixlo_f16 and v_mad_mixhi_f16, which write a half of the
+// destination and keep the other.
+func (u *ALUImpl) runVMADMIX(state InstEmuState) {
+	inst := state.Inst()
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		var src [3]float64
+		for n, bits := range []uint64{sp.SRC0[i], sp.SRC1[i], sp.SRC2[i]} {
+			src[n] = mixSource(inst.OpSel, inst.OpSelHi, inst.Neg, inst.NegHi, n, bits)
+		}
+
+		dst := float32(src[0]*src[1] + src[2])
+		switch inst.Opcode {
+		case 32:
+			sp.DST[i] = uint64(math.Float32bits(dst))
+		case 33:
+			sp.DST[i] = sp.DST[i]&0xffff0000 | uint64(bitops.Float32ToFloat16(dst))
+		case 34:
+			sp.DST[i] = sp.DST[i]&0xffff | uint64(bitops.Float32ToFloat16(dst))<<16
+		}
+	}
+}
+
+// runVDOT2F32F16 adds the dot product of the half-precision halves of SRC0
+// and SRC1 to the 32-bit float SRC2.
+func (u *ALUImpl) runVDOT2F32F16(state InstEmuState) {
+	inst := state.Inst()
+	sp := state.Scratchpad().AsVOP3A()
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		a0, a1 := vop3pSrcHalves(inst.OpSel, inst.OpSelHi, 0, sp.SRC0[i])
+		b0, b1 := vop3pSrcHalves(inst.OpSel, inst.OpSelHi, 1, sp.SRC1[i])
+		lo := negateIf(f16ToFloat(uint64(a0)), inst.Neg&0x1 != 0) *
+			negateIf(f16ToFloat(uint64(b0)), inst.Neg&0x2 != 0)
+		hi := negateIf(f16ToFloat(uint64(a1)), inst.NegHi&0x1 != 0) *
+			negateIf(f16ToFloat(uint64(b1)), inst.NegHi&0x2 != 0)
+		dst := negateIf(f32ToFloat(sp.SRC2[i]), inst.Neg&0x4 != 0) + lo + hi
+
+		sp.DST[i] = uint64(math.Float32bits(float32(dst)))
+	}
+}
+
+// runVDOTInt adds the dot product of SRC0 and SRC1, split into elements of
+// the given number of bits, to the 32-bit integer SRC2.
+func (u *ALUImpl) runVDOTInt(state InstEmuState, elemBits int, signed bool) {
+	sp := state.Scratchpad().AsVOP3A()
+	mask := uint64(1)<<elemBits - 1
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(sp.EXEC, i) {
+			continue
+		}
+
+		dst := int64(uint32(sp.SRC2[i]))
+		if signed {
+			dst = int64(asInt32(uint32(sp.SRC2[i])))
+		}
+
+		for e := 0; e < 32/elemBits; e++ {
+			a := (sp.SRC0[i] >> (e * elemBits)) & mask
+			b := (sp.SRC1[i] >> (e * elemBits)) & mask
+			if signed {
+				a = bitops.SignExt(a, elemBits-1)
+				b = bitops.SignExt(b, elemBits-1)
+			}
+
+			dst += asInt64(a) * asInt64(b)
+		}
+
+		sp.DST[i] = uint64(uint32(dst))
+	}
+}
@@ -0,0 +1,191 @@
+package emu
+
+import (
+	"math"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/mgpusim/v3/insts"
+)
+
+var _ = Describe("ALU", func() {
+
+	var (
+		alu   *ALUImpl
+		state *mockInstState
+	)
+
+	BeforeEach(func() {
+		alu = NewALU(nil)
+
+		state = new(mockInstState)
+		state.scratchpad = make([]byte, 4096)
+	})
+
+	It("should run v_pk_add_u16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3P
+		state.inst.Opcode = 10
+		state.inst.OpSelHi = 0x7
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x00020001
+		sp.SRC1[0] = 0x00040003
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x00060004)))
+	})
+
+	It("should run v_pk_ashrrev_i16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3P
+		state.inst.Opcode = 6
+		state.inst.OpSelHi = 0x3
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x00010001
+		sp.SRC1[0] = 0x8000fff0
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xc000fff8)))
+	})
+
+	It("should run v_pk_fma_f16 with the low halves", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3P
+		state.inst.Opcode = 14
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x4000
+		sp.SRC1[0] = 0x4200
+		sp.SRC2[0] = 0x3c00
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x47004700)))
+	})
+
+	It("should run v_pk_add_f16 with op_sel", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3P
+		state.inst.Opcode = 15
+		state.inst.OpSel = 0x1
+		state.inst.OpSelHi = 0x3
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x40003c00
+		sp.SRC1[0] = 0x3c003c00
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x42004200)))
+	})
+
+	It("should run v_pk_mul_f16 with neg_hi", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3P
+		state.inst.Opcode = 16
+		state.inst.OpSelHi = 0x3
+		state.inst.NegHi = 0x1
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x40004000
+		sp.SRC1[0] = 0x40004000
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0xc4004400)))
+	})
+
+	It("should run v_mad_mix_f32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3P
+		state.inst.Opcode = 32
+		state.inst.OpSel = 0x2
+		state.inst.OpSelHi = 0x3
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x4000
+		sp.SRC1[0] = 0x42000000
+		sp.SRC2[0] = uint64(math.Float32bits(1.0))
+
+		alu.Run(state)
+
+		Expect(math.Float32frombits(uint32(sp.DST[0]))).To(Equal(float32(7.0)))
+	})
+
+	It("should run v_mad_mixhi_f16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3P
+		state.inst.Opcode = 34
+		state.inst.OpSelHi = 0x7
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.DST[0] = 0x1234
+		sp.SRC0[0] = 0x3c00
+		sp.SRC1[0] = 0x4000
+		sp.SRC2[0] = 0x3c00
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(0x42001234)))
+	})
+
+	It("should run v_dot2_f32_f16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3P
+		state.inst.Opcode = 35
+		state.inst.OpSelHi = 0x3
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x40003c00
+		sp.SRC1[0] = 0x42004000
+		sp.SRC2[0] = uint64(math.Float32bits(1.0))
+
+		alu.Run(state)
+
+		Expect(math.Float32frombits(uint32(sp.DST[0]))).To(Equal(float32(9.0)))
+	})
+
+	It("should run v_dot4_i32_i8", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3P
+		state.inst.Opcode = 38
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x01ff0203
+		sp.SRC1[0] = 0x02020202
+		sp.SRC2[0] = 0xfffffffe
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(8)))
+	})
+
+	It("should run v_dot8_u32_u4", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOP3P
+		state.inst.Opcode = 41
+
+		sp := state.Scratchpad().AsVOP3A()
+		sp.EXEC = 0x1
+		sp.SRC0[0] = 0x11111111
+		sp.SRC1[0] = 0x22222222
+		sp.SRC2[0] = 1
+
+		alu.Run(state)
+
+		Expect(sp.DST[0]).To(Equal(uint64(17)))
+	})
+})
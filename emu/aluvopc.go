@@ -1,8 +1,10 @@
 package emu
 
 import (
-	"log"
 	"math"
+
+	"github.com/sarchlab/mgpusim/v3/bitops"
+	"github.com/sarchlab/mgpusim/v3/insts"
 )
 
 //nolint:gocyclo,funlen
@@ -72,7 +74,212 @@ func (u *ALUImpl) runVOPC(state InstEmuState) {
 	case 0xEF:
 		u.runVCmpTruU64(state)
 	default:
-		log.Panicf("Opcode 0x%02X for VOPC format is not implemented", inst.Opcode)
+		u.runVCmp(state)
+	}
+}
+
+// runVCmp runs the VOPC opcodes that do not have a dedicated implementation.
+func (u *ALUImpl) runVCmp(state InstEmuState) {
+	cmp, writesExec := vCmp(state.Inst().Opcode)
+	if cmp == nil {
+		panicUnimplemented(state.Inst())
+	}
+
+	sp := state.Scratchpad().AsVOPC()
+	sp.VCC = compareLanes(sp.EXEC, &sp.SRC0, &sp.SRC1, cmp)
+	if writesExec {
+		sp.EXEC = sp.VCC
+	}
+}
+
+// compareLanes returns a mask that has the bits of the active lanes that
+// satisfy the comparison set.
+func compareLanes(
+	exec uint64,
+	src0, src1 *[64]uint64,
+	cmp func(src0, src1 uint64) bool,
+) uint64 {
+	mask := uint64(0)
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(exec, i) {
+			continue
+		}
+
+		if cmp(src0[i], src1[i]) {
+			mask |= 1 << i
+		}
+	}
+
+	return mask
+}
+
+// floatCmps are the float comparisons, in the order of the opcodes of each
+// float type.
+var floatCmps = [16]func(a, b float64) bool{
+	func(a, b float64) bool { return false },
+	func(a, b float64) bool { return a < b },
+	func(a, b float64) bool { return a == b },
+	func(a, b float64) bool { return a <= b },
+	func(a, b float64) bool { return a > b },
+	func(a, b float64) bool { return a < b || a > b },
+	func(a, b float64) bool { return a >= b },
+	func(a, b float64) bool { return !math.IsNaN(a) && !math.IsNaN(b) },
+	func(a, b float64) bool { return math.IsNaN(a) || math.IsNaN(b) },
+	func(a, b float64) bool { return !(a >= b) },
+	func(a, b float64) bool { return !(a < b || a > b) },
+	func(a, b float64) bool { return !(a > b) },
+	func(a, b float64) bool { return !(a <= b) },
+	func(a, b float64) bool { return !(a == b) },
+	func(a, b float64) bool { return !(a < b) },
+	func(a, b float64) bool { return true },
+}
+
+// intCmps are the integer comparisons, in the order of the opcodes of each
+// integer type. They take the sign of a - b.
+var intCmps = [8]func(sign int) bool{
+	func(sign int) bool { return false },
+	func(sign int) bool { return sign < 0 },
+	func(sign int) bool { return sign == 0 },
+	func(sign int) bool { return sign <= 0 },
+	func(sign int) bool { return sign > 0 },
+	func(sign int) bool { return sign != 0 },
+	func(sign int) bool { return sign >= 0 },
+	func(sign int) bool { return true },
+}
+
+// vCmp returns the comparison of a VOPC opcode, which the promoted VOP3a
+// compare instructions share, and if the instruction is a v_cmpx that also
+// writes EXEC. The comparison is nil if the opcode is unknown.
+func vCmp(opcode insts.Opcode) (cmp func(src0, src1 uint64) bool, writesExec bool) {
+	switch {
+	case opcode >= 0x10 && opcode <= 0x15:
+		formats := [3]floatBits{float32Bits, float64Bits, float16Bits}
+		return classCmp(formats[(opcode-0x10)/2]), opcode&1 == 1
+	case opcode >= 0x20 && opcode < 0x80:
+		toFloat := [3]func(uint64) float64{f16ToFloat, f32ToFloat, f64ToFloat}
+		return floatCmp(toFloat[(opcode-0x20)/0x20], floatCmps[opcode&0xf]),
+			opcode&0x10 != 0
+	case opcode >= 0xA0 && opcode < 0x100:
+		width := [3]int{16, 32, 64}[(opcode-0xA0)/0x20]
+		signed := opcode&0x8 == 0
+		return intCmp(width, signed, intCmps[opcode&0x7]), opcode&0x10 != 0
+	}
+
+	return nil, false
+}
+
+func f16ToFloat(bits uint64) float64 {
+	return float64(bitops.Float16ToFloat32(uint16(bits)))
+}
+
+func f32ToFloat(bits uint64) float64 {
+	return float64(math.Float32frombits(uint32(bits)))
+}
+
+func f64ToFloat(bits uint64) float64 {
+	return math.Float64frombits(bits)
+}
+
+func floatCmp(
+	toFloat func(uint64) float64,
+	op func(a, b float64) bool,
+) func(src0, src1 uint64) bool {
+	return func(src0, src1 uint64) bool {
+		return op(toFloat(src0), toFloat(src1))
+	}
+}
+
+func intCmp(
+	width int,
+	signed bool,
+	op func(sign int) bool,
+) func(src0, src1 uint64) bool {
+	return func(src0, src1 uint64) bool {
+		if width < 64 {
+			src0 &= 1<<width - 1
+			src1 &= 1<<width - 1
+		}
+
+		if signed {
+			a := asInt64(bitops.SignExt(src0, width-1))
+			b := asInt64(bitops.SignExt(src1, width-1))
+			return op(cmpSign(a, b))
+		}
+
+		return op(cmpSign(src0, src1))
+	}
+}
+
+func cmpSign[T int64 | uint64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+
+	return 0
+}
+
+// floatBits describes the bit fields of a float type.
+type floatBits struct {
+	expBits, mantBits uint
+}
+
+var (
+	float16Bits = floatBits{expBits: 5, mantBits: 10}
+	float32Bits = floatBits{expBits: 8, mantBits: 23}
+	float64Bits = floatBits{expBits: 11, mantBits: 52}
+)
+
+// The classes of floats that the v_cmp_class instructions test, as the bit
+// indices of the class mask in SRC1.
+const (
+	classSignalingNaN = iota
+	classQuietNaN
+	classNegInf
+	classNegNormal
+	classNegDenorm
+	classNegZero
+	classPosZero
+	classPosDenorm
+	classPosNormal
+	classPosInf
+)
+
+func (f floatBits) class(bits uint64) int {
+	mant := bits & (1<<f.mantBits - 1)
+	exp := (bits >> f.mantBits) & (1<<f.expBits - 1)
+	negative := (bits>>(f.expBits+f.mantBits))&1 == 1
+	maxExp := uint64(1)<<f.expBits - 1
+
+	pick := func(neg, pos int) int {
+		if negative {
+			return neg
+		}
+		return pos
+	}
+
+	switch {
+	case exp == maxExp && mant != 0:
+		if mant>>(f.mantBits-1) == 1 {
+			return classQuietNaN
+		}
+		return classSignalingNaN
+	case exp == maxExp:
+		return pick(classNegInf, classPosInf)
+	case exp == 0 && mant == 0:
+		return pick(classNegZero, classPosZero)
+	case exp == 0:
+		return pick(classNegDenorm, classPosDenorm)
+	}
+
+	return pick(classNegNormal, classPosNormal)
+}
+
+func classCmp(f floatBits) func(src0, src1 uint64) bool {
+	return func(src0, src1 uint64) bool {
+		return src1&(1<<f.class(src0)) != 0
 	}
 }
 
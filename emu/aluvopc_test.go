@@ -8,6 +8,47 @@ import (
 	"github.com/sarchlab/mgpusim/v3/insts"
 )
 
+// cmpLanes holds SRC0 and SRC1 for four lanes. In the first three lanes SRC0
+// is less than, equal to and greater than SRC1. In the last lane the floats
+// are unordered. The integers compare -1 with 2 there, which is less than as
+// signed numbers and greater than as unsigned ones.
+var cmpLanes = map[string][4][2]uint64{
+	"f16": {{0x3c00, 0x4000}, {0x4000, 0x4000}, {0x4200, 0x4000}, {0x7e00, 0x4000}},
+	"f32": {
+		{0x3f800000, 0x40000000}, {0x40000000, 0x40000000},
+		{0x40400000, 0x40000000}, {0x7fc00000, 0x40000000},
+	},
+	"f64": {
+		{0x3ff0000000000000, 0x4000000000000000},
+		{0x4000000000000000, 0x4000000000000000},
+		{0x4008000000000000, 0x4000000000000000},
+		{0x7ff8000000000000, 0x4000000000000000},
+	},
+	"16": {{1, 2}, {2, 2}, {3, 2}, {0xffff, 2}},
+	"32": {{1, 2}, {2, 2}, {3, 2}, {0xffffffff, 2}},
+	"64": {{1, 2}, {2, 2}, {3, 2}, {0xffffffffffffffff, 2}},
+}
+
+// classLanes holds, for four lanes, a value to classify and the class mask
+// to check it against. The lanes hold -inf, +0, a quiet NaN and 1.0. Only
+// the +0 lane is checked against the wrong class, which is -0.
+var classLanes = map[string][4][2]uint64{
+	"f16": {{0xfc00, 1 << 2}, {0, 1 << 5}, {0x7e00, 1 << 1}, {0x3c00, 1 << 8}},
+	"f32": {{0xff800000, 1 << 2}, {0, 1 << 5}, {0x7fc00000, 1 << 1}, {0x3f800000, 1 << 8}},
+	"f64": {
+		{0xfff0000000000000, 1 << 2}, {0, 1 << 5},
+		{0x7ff8000000000000, 1 << 1}, {0x3ff0000000000000, 1 << 8},
+	},
+}
+
+func setCmpLanes(exec *uint64, src0, src1 *[64]uint64, lanes [4][2]uint64) {
+	*exec = 0xf
+	for i, lane := range lanes {
+		src0[i] = lane[0]
+		src1[i] = lane[1]
+	}
+}
+
 var _ = Describe("ALU", func() {
 
 	var (
@@ -613,4 +654,2297 @@ var _ = Describe("ALU", func() {
 		Expect(sp.VCC).To(Equal(uint64(0x7)))
 	})
 
+	It("should run v_cmp_class_f32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x10
+
+		sp := state.Scratchpad().AsVOPC()
+		sp.EXEC = 0x7
+		sp.SRC0[0] = uint64(math.Float32bits(float32(math.Inf(1))))
+		sp.SRC1[0] = 1 << 9
+		sp.SRC0[1] = 0x80000000
+		sp.SRC1[1] = 1 << 6
+		sp.SRC0[2] = 0x7fc00000
+		sp.SRC1[2] = 1 << 1
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0x5)))
+	})
+
+	It("should run v_cmpx_lt_f32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x51
+
+		sp := state.Scratchpad().AsVOPC()
+		sp.EXEC = 0x7
+		sp.SRC0[0] = uint64(math.Float32bits(1.0))
+		sp.SRC1[0] = uint64(math.Float32bits(2.0))
+		sp.SRC0[1] = uint64(math.Float32bits(2.0))
+		sp.SRC1[1] = uint64(math.Float32bits(1.0))
+		sp.SRC0[2] = uint64(math.Float32bits(-1.0))
+		sp.SRC1[2] = uint64(math.Float32bits(0.0))
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0x5)))
+		Expect(sp.EXEC).To(Equal(uint64(0x5)))
+	})
+
+	It("should run v_cmp_gt_f64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x64
+
+		sp := state.Scratchpad().AsVOPC()
+		sp.EXEC = 0x3
+		sp.SRC0[0] = math.Float64bits(2.5)
+		sp.SRC1[0] = math.Float64bits(-1.0)
+		sp.SRC0[1] = math.Float64bits(math.NaN())
+		sp.SRC1[1] = math.Float64bits(-1.0)
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0x1)))
+	})
+
+	It("should run v_cmp_lt_i16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xa1
+
+		sp := state.Scratchpad().AsVOPC()
+		sp.EXEC = 0x3
+		sp.SRC0[0] = 0xffff
+		sp.SRC1[0] = 1
+		sp.SRC0[1] = 1
+		sp.SRC1[1] = 0xffff
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0x1)))
+	})
+
+	It("should run v_cmpx_lg_u64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xfd
+
+		sp := state.Scratchpad().AsVOPC()
+		sp.EXEC = 0x3
+		sp.SRC0[0] = 1 << 32
+		sp.SRC1[0] = 0
+		sp.SRC0[1] = 7
+		sp.SRC1[1] = 7
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0x1)))
+		Expect(sp.EXEC).To(Equal(uint64(0x1)))
+	})
+
+	It("should run v_cmpx_class_f32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x11
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, classLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1101)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1101)))
+	})
+
+	It("should run v_cmp_class_f64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x12
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, classLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1101)))
+	})
+
+	It("should run v_cmpx_class_f64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x13
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, classLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1101)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1101)))
+	})
+
+	It("should run v_cmp_class_f16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x14
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, classLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1101)))
+	})
+
+	It("should run v_cmpx_class_f16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x15
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, classLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1101)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1101)))
+	})
+
+	It("should run v_cmp_f_f16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x20
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0000)))
+	})
+
+	It("should run v_cmp_lt_f16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x21
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0001)))
+	})
+
+	It("should run v_cmp_eq_f16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x22
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0010)))
+	})
+
+	It("should run v_cmp_le_f16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x23
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0011)))
+	})
+
+	It("should run v_cmp_gt_f16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x24
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0100)))
+	})
+
+	It("should run v_cmp_lg_f16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x25
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0101)))
+	})
+
+	It("should run v_cmp_ge_f16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x26
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0110)))
+	})
+
+	It("should run v_cmp_o_f16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x27
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0111)))
+	})
+
+	It("should run v_cmp_u_f16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x28
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1000)))
+	})
+
+	It("should run v_cmp_nge_f16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x29
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1001)))
+	})
+
+	It("should run v_cmp_nlg_f16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x2a
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1010)))
+	})
+
+	It("should run v_cmp_ngt_f16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x2b
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1011)))
+	})
+
+	It("should run v_cmp_nle_f16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x2c
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1100)))
+	})
+
+	It("should run v_cmp_neq_f16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x2d
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1101)))
+	})
+
+	It("should run v_cmp_nlt_f16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x2e
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1110)))
+	})
+
+	It("should run v_cmp_tru_f16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x2f
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1111)))
+	})
+
+	It("should run v_cmpx_f_f16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x30
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0000)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0000)))
+	})
+
+	It("should run v_cmpx_lt_f16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x31
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0001)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0001)))
+	})
+
+	It("should run v_cmpx_eq_f16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x32
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0010)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0010)))
+	})
+
+	It("should run v_cmpx_le_f16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x33
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0011)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0011)))
+	})
+
+	It("should run v_cmpx_gt_f16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x34
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0100)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0100)))
+	})
+
+	It("should run v_cmpx_lg_f16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x35
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0101)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0101)))
+	})
+
+	It("should run v_cmpx_ge_f16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x36
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0110)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0110)))
+	})
+
+	It("should run v_cmpx_o_f16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x37
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0111)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0111)))
+	})
+
+	It("should run v_cmpx_u_f16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x38
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1000)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1000)))
+	})
+
+	It("should run v_cmpx_nge_f16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x39
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1001)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1001)))
+	})
+
+	It("should run v_cmpx_nlg_f16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x3a
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1010)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1010)))
+	})
+
+	It("should run v_cmpx_ngt_f16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x3b
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1011)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1011)))
+	})
+
+	It("should run v_cmpx_nle_f16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x3c
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1100)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1100)))
+	})
+
+	It("should run v_cmpx_neq_f16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x3d
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1101)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1101)))
+	})
+
+	It("should run v_cmpx_nlt_f16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x3e
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1110)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1110)))
+	})
+
+	It("should run v_cmpx_tru_f16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x3f
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1111)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1111)))
+	})
+
+	It("should run v_cmp_f_f32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x40
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0000)))
+	})
+
+	It("should run v_cmp_o_f32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x47
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0111)))
+	})
+
+	It("should run v_cmp_u_f32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x48
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1000)))
+	})
+
+	It("should run v_cmp_tru_f32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x4f
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1111)))
+	})
+
+	It("should run v_cmpx_f_f32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x50
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0000)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0000)))
+	})
+
+	It("should run v_cmpx_eq_f32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x52
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0010)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0010)))
+	})
+
+	It("should run v_cmpx_le_f32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x53
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0011)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0011)))
+	})
+
+	It("should run v_cmpx_gt_f32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x54
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0100)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0100)))
+	})
+
+	It("should run v_cmpx_lg_f32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x55
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0101)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0101)))
+	})
+
+	It("should run v_cmpx_ge_f32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x56
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0110)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0110)))
+	})
+
+	It("should run v_cmpx_o_f32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x57
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0111)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0111)))
+	})
+
+	It("should run v_cmpx_u_f32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x58
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1000)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1000)))
+	})
+
+	It("should run v_cmpx_nge_f32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x59
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1001)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1001)))
+	})
+
+	It("should run v_cmpx_nlg_f32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x5a
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1010)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1010)))
+	})
+
+	It("should run v_cmpx_ngt_f32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x5b
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1011)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1011)))
+	})
+
+	It("should run v_cmpx_nle_f32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x5c
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1100)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1100)))
+	})
+
+	It("should run v_cmpx_neq_f32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x5d
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1101)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1101)))
+	})
+
+	It("should run v_cmpx_nlt_f32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x5e
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1110)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1110)))
+	})
+
+	It("should run v_cmpx_tru_f32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x5f
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f32"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1111)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1111)))
+	})
+
+	It("should run v_cmp_f_f64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x60
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0000)))
+	})
+
+	It("should run v_cmp_lt_f64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x61
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0001)))
+	})
+
+	It("should run v_cmp_eq_f64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x62
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0010)))
+	})
+
+	It("should run v_cmp_le_f64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x63
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0011)))
+	})
+
+	It("should run v_cmp_lg_f64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x65
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0101)))
+	})
+
+	It("should run v_cmp_ge_f64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x66
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0110)))
+	})
+
+	It("should run v_cmp_o_f64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x67
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0111)))
+	})
+
+	It("should run v_cmp_u_f64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x68
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1000)))
+	})
+
+	It("should run v_cmp_nge_f64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x69
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1001)))
+	})
+
+	It("should run v_cmp_nlg_f64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x6a
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1010)))
+	})
+
+	It("should run v_cmp_ngt_f64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x6b
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1011)))
+	})
+
+	It("should run v_cmp_nle_f64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x6c
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1100)))
+	})
+
+	It("should run v_cmp_neq_f64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x6d
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1101)))
+	})
+
+	It("should run v_cmp_nlt_f64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x6e
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1110)))
+	})
+
+	It("should run v_cmp_tru_f64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x6f
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1111)))
+	})
+
+	It("should run v_cmpx_f_f64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x70
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0000)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0000)))
+	})
+
+	It("should run v_cmpx_lt_f64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x71
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0001)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0001)))
+	})
+
+	It("should run v_cmpx_eq_f64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x72
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0010)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0010)))
+	})
+
+	It("should run v_cmpx_le_f64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x73
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0011)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0011)))
+	})
+
+	It("should run v_cmpx_gt_f64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x74
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0100)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0100)))
+	})
+
+	It("should run v_cmpx_lg_f64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x75
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0101)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0101)))
+	})
+
+	It("should run v_cmpx_ge_f64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x76
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0110)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0110)))
+	})
+
+	It("should run v_cmpx_o_f64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x77
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0111)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0111)))
+	})
+
+	It("should run v_cmpx_u_f64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x78
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1000)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1000)))
+	})
+
+	It("should run v_cmpx_nge_f64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x79
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1001)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1001)))
+	})
+
+	It("should run v_cmpx_nlg_f64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x7a
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1010)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1010)))
+	})
+
+	It("should run v_cmpx_ngt_f64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x7b
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1011)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1011)))
+	})
+
+	It("should run v_cmpx_nle_f64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x7c
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1100)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1100)))
+	})
+
+	It("should run v_cmpx_neq_f64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x7d
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1101)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1101)))
+	})
+
+	It("should run v_cmpx_nlt_f64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x7e
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1110)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1110)))
+	})
+
+	It("should run v_cmpx_tru_f64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0x7f
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["f64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1111)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1111)))
+	})
+
+	It("should run v_cmp_f_i16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xa0
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0000)))
+	})
+
+	It("should run v_cmp_eq_i16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xa2
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0010)))
+	})
+
+	It("should run v_cmp_le_i16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xa3
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1011)))
+	})
+
+	It("should run v_cmp_gt_i16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xa4
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0100)))
+	})
+
+	It("should run v_cmp_lg_i16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xa5
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1101)))
+	})
+
+	It("should run v_cmp_ge_i16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xa6
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0110)))
+	})
+
+	It("should run v_cmp_tru_i16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xa7
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1111)))
+	})
+
+	It("should run v_cmp_f_u16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xa8
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0000)))
+	})
+
+	It("should run v_cmp_lt_u16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xa9
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0001)))
+	})
+
+	It("should run v_cmp_eq_u16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xaa
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0010)))
+	})
+
+	It("should run v_cmp_le_u16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xab
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0011)))
+	})
+
+	It("should run v_cmp_gt_u16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xac
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1100)))
+	})
+
+	It("should run v_cmp_lg_u16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xad
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1101)))
+	})
+
+	It("should run v_cmp_ge_u16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xae
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1110)))
+	})
+
+	It("should run v_cmp_tru_u16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xaf
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1111)))
+	})
+
+	It("should run v_cmpx_f_i16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xb0
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0000)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0000)))
+	})
+
+	It("should run v_cmpx_lt_i16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xb1
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1001)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1001)))
+	})
+
+	It("should run v_cmpx_eq_i16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xb2
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0010)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0010)))
+	})
+
+	It("should run v_cmpx_le_i16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xb3
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1011)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1011)))
+	})
+
+	It("should run v_cmpx_gt_i16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xb4
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0100)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0100)))
+	})
+
+	It("should run v_cmpx_lg_i16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xb5
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1101)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1101)))
+	})
+
+	It("should run v_cmpx_ge_i16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xb6
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0110)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0110)))
+	})
+
+	It("should run v_cmpx_tru_i16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xb7
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1111)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1111)))
+	})
+
+	It("should run v_cmpx_f_u16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xb8
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0000)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0000)))
+	})
+
+	It("should run v_cmpx_lt_u16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xb9
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0001)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0001)))
+	})
+
+	It("should run v_cmpx_eq_u16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xba
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0010)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0010)))
+	})
+
+	It("should run v_cmpx_le_u16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xbb
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0011)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0011)))
+	})
+
+	It("should run v_cmpx_gt_u16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xbc
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1100)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1100)))
+	})
+
+	It("should run v_cmpx_lg_u16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xbd
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1101)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1101)))
+	})
+
+	It("should run v_cmpx_ge_u16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xbe
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1110)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1110)))
+	})
+
+	It("should run v_cmpx_tru_u16", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xbf
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["16"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1111)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1111)))
+	})
+
+	It("should run v_cmp_f_i32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xc0
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["32"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0000)))
+	})
+
+	It("should run v_cmp_eq_i32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xc2
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["32"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0010)))
+	})
+
+	It("should run v_cmp_tru_i32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xc7
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["32"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1111)))
+	})
+
+	It("should run v_cmp_f_u32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xc8
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["32"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0000)))
+	})
+
+	It("should run v_cmp_tru_u32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xcf
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["32"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1111)))
+	})
+
+	It("should run v_cmpx_f_i32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xd0
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["32"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0000)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0000)))
+	})
+
+	It("should run v_cmpx_lt_i32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xd1
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["32"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1001)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1001)))
+	})
+
+	It("should run v_cmpx_eq_i32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xd2
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["32"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0010)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0010)))
+	})
+
+	It("should run v_cmpx_le_i32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xd3
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["32"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1011)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1011)))
+	})
+
+	It("should run v_cmpx_gt_i32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xd4
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["32"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0100)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0100)))
+	})
+
+	It("should run v_cmpx_lg_i32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xd5
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["32"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1101)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1101)))
+	})
+
+	It("should run v_cmpx_ge_i32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xd6
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["32"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0110)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0110)))
+	})
+
+	It("should run v_cmpx_tru_i32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xd7
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["32"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1111)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1111)))
+	})
+
+	It("should run v_cmpx_f_u32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xd8
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["32"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0000)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0000)))
+	})
+
+	It("should run v_cmpx_lt_u32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xd9
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["32"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0001)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0001)))
+	})
+
+	It("should run v_cmpx_eq_u32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xda
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["32"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0010)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0010)))
+	})
+
+	It("should run v_cmpx_le_u32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xdb
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["32"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0011)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0011)))
+	})
+
+	It("should run v_cmpx_gt_u32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xdc
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["32"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1100)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1100)))
+	})
+
+	It("should run v_cmpx_lg_u32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xdd
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["32"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1101)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1101)))
+	})
+
+	It("should run v_cmpx_ge_u32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xde
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["32"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1110)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1110)))
+	})
+
+	It("should run v_cmpx_tru_u32", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xdf
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["32"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1111)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1111)))
+	})
+
+	It("should run v_cmp_f_i64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xe0
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0000)))
+	})
+
+	It("should run v_cmp_lt_i64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xe1
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1001)))
+	})
+
+	It("should run v_cmp_eq_i64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xe2
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0010)))
+	})
+
+	It("should run v_cmp_le_i64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xe3
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1011)))
+	})
+
+	It("should run v_cmp_gt_i64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xe4
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0100)))
+	})
+
+	It("should run v_cmp_lg_i64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xe5
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1101)))
+	})
+
+	It("should run v_cmp_ge_i64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xe6
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0110)))
+	})
+
+	It("should run v_cmp_tru_i64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xe7
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1111)))
+	})
+
+	It("should run v_cmpx_f_i64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xf0
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0000)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0000)))
+	})
+
+	It("should run v_cmpx_lt_i64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xf1
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1001)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1001)))
+	})
+
+	It("should run v_cmpx_eq_i64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xf2
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0010)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0010)))
+	})
+
+	It("should run v_cmpx_le_i64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xf3
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1011)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1011)))
+	})
+
+	It("should run v_cmpx_gt_i64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xf4
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0100)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0100)))
+	})
+
+	It("should run v_cmpx_lg_i64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xf5
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1101)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1101)))
+	})
+
+	It("should run v_cmpx_ge_i64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xf6
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0110)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0110)))
+	})
+
+	It("should run v_cmpx_tru_i64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xf7
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1111)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1111)))
+	})
+
+	It("should run v_cmpx_f_u64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xf8
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0000)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0000)))
+	})
+
+	It("should run v_cmpx_lt_u64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xf9
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0001)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0001)))
+	})
+
+	It("should run v_cmpx_eq_u64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xfa
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0010)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0010)))
+	})
+
+	It("should run v_cmpx_le_u64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xfb
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b0011)))
+		Expect(sp.EXEC).To(Equal(uint64(0b0011)))
+	})
+
+	It("should run v_cmpx_gt_u64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xfc
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1100)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1100)))
+	})
+
+	It("should run v_cmpx_ge_u64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xfe
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1110)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1110)))
+	})
+
+	It("should run v_cmpx_tru_u64", func() {
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.VOPC
+		state.inst.Opcode = 0xff
+
+		sp := state.Scratchpad().AsVOPC()
+		setCmpLanes(&sp.EXEC, &sp.SRC0, &sp.SRC1, cmpLanes["64"])
+
+		alu.Run(state)
+
+		Expect(sp.VCC).To(Equal(uint64(0b1111)))
+		Expect(sp.EXEC).To(Equal(uint64(0b1111)))
+	})
 })
@@ -0,0 +1,281 @@
+package emu
+
+import (
+	"log"
+	"math"
+
+	"github.com/sarchlab/mgpusim/v3/insts"
+)
+
+// AtomicOp is the read-modify-write operation that an atomic instruction
+// applies to a memory location.
+type AtomicOp int
+
+// A list of all the supported atomic operations.
+const (
+	AtomicSwap AtomicOp = iota
+	AtomicCmpSwap
+	AtomicAdd
+	AtomicSub
+	AtomicRSub
+	AtomicSMin
+	AtomicUMin
+	AtomicSMax
+	AtomicUMax
+	AtomicAnd
+	AtomicOr
+	AtomicXor
+	AtomicInc
+	AtomicDec
+	AtomicMskOr
+	AtomicFCmpSwap
+	AtomicFMin
+	AtomicFMax
+	AtomicFAdd
+)
+
+// AtomicInfo describes the atomic operation performed by an instruction.
+type AtomicInfo struct {
+	Op AtomicOp
+
+	// Is64Bit is true if the instruction operates on 64-bit values.
+	Is64Bit bool
+
+	// ReturnPreOp is true if the instruction writes the value in memory
+	// before the operation into the destination VGPRs.
+	ReturnPreOp bool
+}
+
+var vMemAtomicOps = [13]AtomicOp{
+	AtomicSwap, AtomicCmpSwap, AtomicAdd, AtomicSub,
+	AtomicSMin, AtomicUMin, AtomicSMax, AtomicUMax,
+	AtomicAnd, AtomicOr, AtomicXor, AtomicInc, AtomicDec,
+}
+
+var dsAtomicOps = map[insts.Opcode]AtomicOp{
+	0: AtomicAdd, 1: AtomicSub, 2: AtomicRSub, 3: AtomicInc, 4: AtomicDec,
+	5: AtomicSMin, 6: AtomicSMax, 7: AtomicUMin, 8: AtomicUMax,
+	9: AtomicAnd, 10: AtomicOr, 11: AtomicXor, 12: AtomicMskOr,
+	13: AtomicSwap, // Only valid for the WRXCHG (rtn) variants.
+	16: AtomicCmpSwap, 17: AtomicFCmpSwap, 18: AtomicFMin, 19: AtomicFMax,
+	21: AtomicFAdd,
+}
+
+// VMemAtomicInfo returns the atomic operation of a FLAT or MUBUF instruction.
+// The second return value is false if the instruction is not an atomic.
+func VMemAtomicInfo(inst *insts.Inst) (AtomicInfo, bool) {
+	info := AtomicInfo{ReturnPreOp: inst.GlobalLevelCoherent}
+
+	switch {
+	case inst.Opcode >= 64 && inst.Opcode <= 76:
+		info.Op = vMemAtomicOps[inst.Opcode-64]
+	case inst.Opcode >= 96 && inst.Opcode <= 108:
+		info.Op = vMemAtomicOps[inst.Opcode-96]
+		info.Is64Bit = true
+	default:
+		return info, false
+	}
+
+	return info, true
+}
+
+// DSAtomicInfo returns the atomic operation of a DS instruction. The second
+// return value is false if the instruction is not an atomic.
+func DSAtomicInfo(inst *insts.Inst) (AtomicInfo, bool) {
+	info := AtomicInfo{}
+	opcode := inst.Opcode
+
+	if opcode >= 64 {
+		info.Is64Bit = true
+		opcode -= 64
+	}
+
+	if opcode >= 32 {
+		info.ReturnPreOp = true
+		opcode -= 32
+	} else if opcode == 13 {
+		return info, false // DS_WRITE_B32 and DS_WRITE_B64
+	}
+
+	op, found := dsAtomicOps[opcode]
+	if !found {
+		return info, false
+	}
+
+	info.Op = op
+
+	return info, true
+}
+
+// VMemAtomicOperands returns the source and the compare values of a FLAT or
+// MUBUF atomic from the 4 data dwords of a lane. The compare value follows
+// the source value in the data VGPRs.
+func VMemAtomicOperands(info AtomicInfo, data []uint32) (src, cmp uint64) {
+	if !info.Is64Bit {
+		return uint64(data[0]), uint64(data[1])
+	}
+
+	src = uint64(data[0]) | uint64(data[1])<<32
+	cmp = uint64(data[2]) | uint64(data[3])<<32
+
+	return src, cmp
+}
+
+// ApplyAtomic32 returns the new value of a 32-bit memory location that holds
+// old after applying the operation. For compare-and-swap operations, cmp is
+// the value to compare against. For mask-or operations, cmp is the mask.
+//
+//nolint:gocyclo
+func ApplyAtomic32(op AtomicOp, old, src, cmp uint32) uint32 {
+	switch op {
+	case AtomicSwap:
+		return src
+	case AtomicCmpSwap:
+		if old == cmp {
+			return src
+		}
+		return old
+	case AtomicAdd:
+		return old + src
+	case AtomicSub:
+		return old - src
+	case AtomicRSub:
+		return src - old
+	case AtomicSMin:
+		if int32(src) < int32(old) {
+			return src
+		}
+		return old
+	case AtomicUMin:
+		if src < old {
+			return src
+		}
+		return old
+	case AtomicSMax:
+		if int32(src) > int32(old) {
+			return src
+		}
+		return old
+	case AtomicUMax:
+		if src > old {
+			return src
+		}
+		return old
+	case AtomicAnd:
+		return old & src
+	case AtomicOr:
+		return old | src
+	case AtomicXor:
+		return old ^ src
+	case AtomicInc:
+		if old >= src {
+			return 0
+		}
+		return old + 1
+	case AtomicDec:
+		if old == 0 || old > src {
+			return src
+		}
+		return old - 1
+	case AtomicMskOr:
+		return (old &^ cmp) | src
+	case AtomicFCmpSwap, AtomicFMin, AtomicFMax, AtomicFAdd:
+		return math.Float32bits(applyAtomicFloat32(op,
+			math.Float32frombits(old),
+			math.Float32frombits(src),
+			math.Float32frombits(cmp)))
+	}
+
+	log.Panicf("atomic operation %d is not supported", op)
+	return 0
+}
+
+// ApplyAtomic64 is the 64-bit version of ApplyAtomic32.
+//
+//nolint:gocyclo
+func ApplyAtomic64(op AtomicOp, old, src, cmp uint64) uint64 {
+	switch op {
+	case AtomicSwap:
+		return src
+	case AtomicCmpSwap:
+		if old == cmp {
+			return src
+		}
+		return old
+	case AtomicAdd:
+		return old + src
+	case AtomicSub:
+		return old - src
+	case AtomicRSub:
+		return src - old
+	case AtomicSMin:
+		if int64(src) < int64(old) {
+			return src
+		}
+		return old
+	case AtomicUMin:
+		if src < old {
+			return src
+		}
+		return old
+	case AtomicSMax:
+		if int64(src) > int64(old) {
+			return src
+		}
+		return old
+	case AtomicUMax:
+		if src > old {
+			return src
+		}
+		return old
+	case AtomicAnd:
+		return old & src
+	case AtomicOr:
+		return old | src
+	case AtomicXor:
+		return old ^ src
+	case AtomicInc:
+		if old >= src {
+			return 0
+		}
+		return old + 1
+	case AtomicDec:
+		if old == 0 || old > src {
+			return src
+		}
+		return old - 1
+	case AtomicMskOr:
+		return (old &^ cmp) | src
+	case AtomicFCmpSwap, AtomicFMin, AtomicFMax, AtomicFAdd:
+		return math.Float64bits(applyAtomicFloat64(op,
+			math.Float64frombits(old),
+			math.Float64frombits(src),
+			math.Float64frombits(cmp)))
+	}
+
+	log.Panicf("atomic operation %d is not supported", op)
+	return 0
+}
+
+func applyAtomicFloat32(op AtomicOp, old, src, cmp float32) float32 {
+	return float32(applyAtomicFloat64(op,
+		float64(old), float64(src), float64(cmp)))
+}
+
+func applyAtomicFloat64(op AtomicOp, old, src, cmp float64) float64 {
+	switch op {
+	case AtomicFCmpSwap:
+		if old == cmp {
+			return src
+		}
+		return old
+	case AtomicFMin:
+		return math.Min(old, src)
+	case AtomicFMax:
+		return math.Max(old, src)
+	case AtomicFAdd:
+		return old + src
+	}
+
+	panic("never")
+}
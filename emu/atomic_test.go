@@ -0,0 +1,97 @@
+package emu
+
+import (
+	"math"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/mgpusim/v3/insts"
+)
+
+var _ = Describe("Atomic", func() {
+	It("should find the atomic operation of FLAT instructions", func() {
+		inst := insts.NewInst()
+		inst.FormatType = insts.FLAT
+		inst.Opcode = 97
+		inst.GlobalLevelCoherent = true
+
+		info, isAtomic := VMemAtomicInfo(inst)
+
+		Expect(isAtomic).To(BeTrue())
+		Expect(info).To(Equal(AtomicInfo{
+			Op:          AtomicCmpSwap,
+			Is64Bit:     true,
+			ReturnPreOp: true,
+		}))
+	})
+
+	It("should not treat FLAT loads as atomics", func() {
+		inst := insts.NewInst()
+		inst.FormatType = insts.FLAT
+		inst.Opcode = 20
+
+		_, isAtomic := VMemAtomicInfo(inst)
+
+		Expect(isAtomic).To(BeFalse())
+	})
+
+	It("should find the atomic operation of DS instructions", func() {
+		inst := insts.NewInst()
+		inst.FormatType = insts.DS
+
+		inst.Opcode = 38
+		info, isAtomic := DSAtomicInfo(inst)
+		Expect(isAtomic).To(BeTrue())
+		Expect(info).To(Equal(AtomicInfo{
+			Op:          AtomicSMax,
+			ReturnPreOp: true,
+		}))
+
+		inst.Opcode = 109
+		info, isAtomic = DSAtomicInfo(inst)
+		Expect(isAtomic).To(BeTrue())
+		Expect(info).To(Equal(AtomicInfo{
+			Op:          AtomicSwap,
+			Is64Bit:     true,
+			ReturnPreOp: true,
+		}))
+
+		inst.Opcode = 13
+		_, isAtomic = DSAtomicInfo(inst)
+		Expect(isAtomic).To(BeFalse())
+
+		inst.Opcode = 54
+		_, isAtomic = DSAtomicInfo(inst)
+		Expect(isAtomic).To(BeFalse())
+	})
+
+	It("should apply 32-bit atomic operations", func() {
+		Expect(ApplyAtomic32(AtomicAdd, 3, 4, 0)).To(Equal(uint32(7)))
+		Expect(ApplyAtomic32(AtomicRSub, 3, 4, 0)).To(Equal(uint32(1)))
+		Expect(ApplyAtomic32(AtomicSMin, 3, 0xffffffff, 0)).
+			To(Equal(uint32(0xffffffff)))
+		Expect(ApplyAtomic32(AtomicUMin, 3, 0xffffffff, 0)).To(Equal(uint32(3)))
+		Expect(ApplyAtomic32(AtomicCmpSwap, 3, 5, 3)).To(Equal(uint32(5)))
+		Expect(ApplyAtomic32(AtomicCmpSwap, 3, 5, 4)).To(Equal(uint32(3)))
+		Expect(ApplyAtomic32(AtomicInc, 3, 3, 0)).To(Equal(uint32(0)))
+		Expect(ApplyAtomic32(AtomicInc, 2, 3, 0)).To(Equal(uint32(3)))
+		Expect(ApplyAtomic32(AtomicDec, 0, 3, 0)).To(Equal(uint32(3)))
+		Expect(ApplyAtomic32(AtomicDec, 5, 3, 0)).To(Equal(uint32(3)))
+		Expect(ApplyAtomic32(AtomicDec, 2, 3, 0)).To(Equal(uint32(1)))
+		Expect(ApplyAtomic32(AtomicMskOr, 0xff, 0x100, 0x0f)).
+			To(Equal(uint32(0x1f0)))
+		Expect(ApplyAtomic32(AtomicFAdd,
+			math.Float32bits(1.5), math.Float32bits(2.25), 0)).
+			To(Equal(math.Float32bits(3.75)))
+	})
+
+	It("should apply 64-bit atomic operations", func() {
+		Expect(ApplyAtomic64(AtomicAdd, 0xffffffff, 1, 0)).
+			To(Equal(uint64(0x100000000)))
+		Expect(ApplyAtomic64(AtomicSMax, 1, 0xffffffffffffffff, 0)).
+			To(Equal(uint64(1)))
+		Expect(ApplyAtomic64(AtomicFMin,
+			math.Float64bits(1.5), math.Float64bits(-2), 0)).
+			To(Equal(math.Float64bits(-2)))
+	})
+})
@@ -0,0 +1,121 @@
+package emu
+
+import "github.com/sarchlab/mgpusim/v3/insts"
+
+// bufferSwizzleElementSize is the element size used by swizzled buffer
+// addressing. GCN3 buffer descriptors do not carry an element size, so dword
+// elements are assumed.
+const bufferSwizzleElementSize = 4
+
+// BufferResource is the 128-bit buffer resource descriptor (V#) that MUBUF
+// and MTBUF instructions read from 4 consecutive SGPRs.
+type BufferResource struct {
+	BaseAddress   uint64
+	Stride        uint32
+	CacheSwizzle  bool
+	SwizzleEnable bool
+	NumRecords    uint32
+	DstSelX       uint8
+	DstSelY       uint8
+	DstSelZ       uint8
+	DstSelW       uint8
+	NumFormat     uint8
+	DataFormat    uint8
+	IndexStride   uint32
+	AddTIDEnable  bool
+}
+
+// NewBufferResource decodes a buffer resource descriptor from the 4 dwords
+// that hold it.
+func NewBufferResource(words [4]uint32) BufferResource {
+	r := BufferResource{}
+
+	r.BaseAddress = uint64(words[0]) | uint64(words[1]&0xffff)<<32
+	r.Stride = (words[1] >> 16) & 0x3fff
+	r.CacheSwizzle = (words[1]>>30)&1 == 1
+	r.SwizzleEnable = (words[1]>>31)&1 == 1
+	r.NumRecords = words[2]
+	r.DstSelX = uint8(words[3] & 0x7)
+	r.DstSelY = uint8((words[3] >> 3) & 0x7)
+	r.DstSelZ = uint8((words[3] >> 6) & 0x7)
+	r.DstSelW = uint8((words[3] >> 9) & 0x7)
+	r.NumFormat = uint8((words[3] >> 12) & 0x7)
+	r.DataFormat = uint8((words[3] >> 15) & 0xf)
+	r.IndexStride = 8 << ((words[3] >> 21) & 0x3)
+	r.AddTIDEnable = (words[3]>>23)&1 == 1
+
+	return r
+}
+
+// Address returns the address that an access with the given index and offset
+// touches. The offset includes both the VGPR offset and the instruction
+// offset, but not the SGPR offset. The second return value is false if any
+// of the size bytes that the access touches falls outside of the buffer.
+func (r BufferResource) Address(
+	index, offset, sOffset, size uint32,
+) (uint64, bool) {
+	var bufferOffset uint64
+
+	if r.SwizzleEnable {
+		indexMSB := uint64(index / r.IndexStride)
+		indexLSB := uint64(index % r.IndexStride)
+		offsetMSB := uint64(offset / bufferSwizzleElementSize)
+		offsetLSB := uint64(offset % bufferSwizzleElementSize)
+
+		bufferOffset = (indexMSB*uint64(r.Stride)+
+			offsetMSB*bufferSwizzleElementSize)*uint64(r.IndexStride) +
+			indexLSB*bufferSwizzleElementSize + offsetLSB
+	} else {
+		bufferOffset = uint64(index)*uint64(r.Stride) + uint64(offset)
+	}
+
+	addr := r.BaseAddress + uint64(sOffset) + bufferOffset
+
+	return addr, r.isInRange(index, offset, size)
+}
+
+func (r BufferResource) isInRange(index, offset, size uint32) bool {
+	if r.Stride == 0 {
+		return uint64(offset)+uint64(size) <= uint64(r.NumRecords)
+	}
+
+	return index < r.NumRecords
+}
+
+// CalculateBufferAddresses fills the ADDR field and the InRange mask of a
+// MUBUF scratchpad using the buffer resource descriptor, the VGPR index and
+// offset, and the SGPR offset in the scratchpad.
+func CalculateBufferAddresses(inst *insts.Inst, sp *MUBUFLayout) {
+	resource := NewBufferResource(sp.SRSRC)
+	size := bufferAccessByteSize(inst, sp.SRSRC)
+	sp.InRange = 0
+
+	for i := 0; i < 64; i++ {
+		if !laneMasked(sp.EXEC, uint(i)) {
+			continue
+		}
+
+		index := uint32(0)
+		offset := inst.Offset0
+		vaddr := sp.VADDR[i*2 : i*2+2]
+
+		if inst.IdxEn {
+			index = vaddr[0]
+			vaddr = vaddr[1:]
+		}
+
+		if inst.OffEn {
+			offset += vaddr[0]
+		}
+
+		if resource.AddTIDEnable {
+			index += uint32(i)
+		}
+
+		addr, inRange := resource.Address(index, offset, sp.SOFFSET, size)
+		sp.ADDR[i] = addr
+		if inRange {
+			sp.InRange |= 1 << uint(i)
+		}
+	}
+}
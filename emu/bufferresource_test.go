@@ -0,0 +1,133 @@
+package emu
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/mgpusim/v3/insts"
+)
+
+var _ = Describe("Buffer Resource", func() {
+	It("should decode the descriptor", func() {
+		r := NewBufferResource([4]uint32{
+			0x89abcdef,
+			0xc0100123,
+			0x400,
+			0x00a27fac,
+		})
+
+		Expect(r.BaseAddress).To(Equal(uint64(0x012389abcdef)))
+		Expect(r.Stride).To(Equal(uint32(16)))
+		Expect(r.CacheSwizzle).To(BeTrue())
+		Expect(r.SwizzleEnable).To(BeTrue())
+		Expect(r.NumRecords).To(Equal(uint32(0x400)))
+		Expect(r.DstSelX).To(Equal(uint8(4)))
+		Expect(r.DstSelY).To(Equal(uint8(5)))
+		Expect(r.DstSelZ).To(Equal(uint8(6)))
+		Expect(r.DstSelW).To(Equal(uint8(7)))
+		Expect(r.NumFormat).To(Equal(uint8(7)))
+		Expect(r.DataFormat).To(Equal(uint8(4)))
+		Expect(r.IndexStride).To(Equal(uint32(16)))
+		Expect(r.AddTIDEnable).To(BeTrue())
+	})
+
+	It("should calculate raw buffer addresses", func() {
+		r := BufferResource{BaseAddress: 0x1000, NumRecords: 64}
+
+		addr, inRange := r.Address(0, 16, 4, 4)
+
+		Expect(addr).To(Equal(uint64(0x1014)))
+		Expect(inRange).To(BeTrue())
+	})
+
+	It("should detect out-of-range raw buffer accesses", func() {
+		r := BufferResource{BaseAddress: 0x1000, NumRecords: 64}
+
+		_, inRange := r.Address(0, 64, 0, 4)
+
+		Expect(inRange).To(BeFalse())
+	})
+
+	It("should detect raw buffer accesses that cross the buffer end", func() {
+		r := BufferResource{BaseAddress: 0x1000, NumRecords: 64}
+
+		_, inRange := r.Address(0, 62, 0, 4)
+
+		Expect(inRange).To(BeFalse())
+	})
+
+	It("should calculate structured buffer addresses", func() {
+		r := BufferResource{BaseAddress: 0x1000, Stride: 12, NumRecords: 8}
+
+		addr, inRange := r.Address(3, 4, 0, 4)
+
+		Expect(addr).To(Equal(uint64(0x1000 + 3*12 + 4)))
+		Expect(inRange).To(BeTrue())
+	})
+
+	It("should detect out-of-range structured buffer accesses", func() {
+		r := BufferResource{BaseAddress: 0x1000, Stride: 12, NumRecords: 8}
+
+		_, inRange := r.Address(8, 0, 0, 4)
+
+		Expect(inRange).To(BeFalse())
+	})
+
+	It("should calculate swizzled buffer addresses", func() {
+		r := BufferResource{
+			BaseAddress:   0x1000,
+			Stride:        16,
+			SwizzleEnable: true,
+			NumRecords:    64,
+			IndexStride:   8,
+		}
+
+		addr, inRange := r.Address(10, 6, 0, 1)
+
+		// index_msb = 1, index_lsb = 2, offset_msb = 1, offset_lsb = 2
+		// (1*16 + 1*4) * 8 + 2*4 + 2 = 170
+		Expect(addr).To(Equal(uint64(0x1000 + 170)))
+		Expect(inRange).To(BeTrue())
+	})
+
+	It("should calculate the addresses of all the lanes", func() {
+		inst := insts.NewInst()
+		inst.FormatType = insts.MUBUF
+		inst.Opcode = 20
+		inst.IdxEn = true
+		inst.OffEn = true
+		inst.Offset0 = 8
+
+		sp := new(MUBUFLayout)
+		sp.EXEC = 0x7
+		sp.SRSRC = [4]uint32{0x1000, 16 << 16, 2, 0}
+		sp.SOFFSET = 0x100
+		for i := 0; i < 4; i++ {
+			sp.VADDR[i*2] = uint32(i)
+			sp.VADDR[i*2+1] = 4
+		}
+
+		CalculateBufferAddresses(inst, sp)
+
+		Expect(sp.ADDR[0]).To(Equal(uint64(0x1000 + 0x100 + 12)))
+		Expect(sp.ADDR[1]).To(Equal(uint64(0x1000 + 0x100 + 16 + 12)))
+		Expect(sp.ADDR[3]).To(Equal(uint64(0)))
+		Expect(sp.InRange).To(Equal(uint64(0x3)))
+	})
+
+	It("should add the thread ID to the index", func() {
+		inst := insts.NewInst()
+		inst.FormatType = insts.MUBUF
+		inst.Opcode = 20
+
+		sp := new(MUBUFLayout)
+		sp.EXEC = 0xffffffffffffffff
+		sp.SRSRC = [4]uint32{0x1000, 4 << 16, 64, 1 << 23}
+
+		CalculateBufferAddresses(inst, sp)
+
+		for i := 0; i < 64; i++ {
+			Expect(sp.ADDR[i]).To(Equal(uint64(0x1000 + i*4)))
+		}
+		Expect(sp.InRange).To(Equal(uint64(0xffffffffffffffff)))
+	})
+})
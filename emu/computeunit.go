@@ -39,6 +39,16 @@ type ComputeUnit struct {
 
 	ToDispatcher sim.Port
 
+	// MemoryFaultHandler is notified of the accesses to unmapped addresses.
+	// If it is nil, a memory fault aborts the simulation.
+	MemoryFaultHandler MemoryFaultHandler
+
+	// RaceHandler is notified of the data races between the wavefronts of a
+	// work-group if race detection is enabled. If it is nil, the races are
+	// logged.
+	RaceHandler  RaceHandler
+	raceDetector *raceDetector
+
 	finishedMapWGReqs []string
 }
 
@@ -145,6 +155,10 @@ func (cu *ComputeUnit) runWG(
 			cu.runWfUntilBarrier(wf)
 		}
 		cu.resolveBarrier(wg)
+
+		if cu.raceDetector != nil {
+			cu.raceDetector.barrier()
+		}
 	}
 
 	evt := NewWGCompleteEvent(cu.Freq.NextTick(now), cu, req)
@@ -186,6 +200,7 @@ func (cu *ComputeUnit) initWfRegs(wf *Wavefront) {
 
 	wf.PC = pkt.KernelObject + co.KernelCodeEntryByteOffset
 	wf.Exec = wf.InitExecMask
+	wf.MODE = InitialMode(co)
 
 	SGPRPtr := 0
 	if co.EnableSgprPrivateSegmentBuffer() {
@@ -312,11 +327,19 @@ func (cu *ComputeUnit) isAllWfCompleted(wg *kernels.WorkGroup) bool {
 
 func (cu *ComputeUnit) runWfUntilBarrier(wf *Wavefront) error {
 	for {
-		instBuf := cu.storageAccessor.Read(wf.pid, wf.PC, 8)
+		instBuf, err := cu.storageAccessor.Read(wf.pid, wf.PC, 8)
+		if err != nil {
+			// The wavefront cannot continue without instructions.
+			cu.reportMemoryFault(wf, wf.PC, nil,
+				laneFault{lane: ScalarLane, vAddr: wf.PC, err: err})
+			wf.Completed = true
+			break
+		}
 
-		inst, _ := cu.decoder.Decode(instBuf)
+		inst, _ := DecodeForCodeObject(cu.decoder, wf.CodeObject, instBuf)
 		wf.inst = inst
 
+		pc := wf.PC
 		wf.PC += uint64(inst.ByteSize)
 
 		if inst.FormatType == insts.SOPP && inst.Opcode == 10 { // S_ENDPGM
@@ -333,11 +356,109 @@ func (cu *ComputeUnit) runWfUntilBarrier(wf *Wavefront) error {
 
 		cu.executeInst(wf)
 		cu.logInst(wf, inst)
+
+		if alu, ok := cu.alu.(*ALUImpl); ok {
+			for _, f := range alu.takeFaults() {
+				cu.reportMemoryFault(wf, pc, inst, f)
+			}
+
+			cu.detectRaces(wf, pc, inst, alu.takeAccesses())
+		}
 	}
 
 	return nil
 }
 
+func (cu *ComputeUnit) reportMemoryFault(
+	wf *Wavefront,
+	pc uint64,
+	inst *insts.Inst,
+	f laneFault,
+) {
+	fault := NewMemoryFault(cu.Name(), wf.Wavefront, pc, inst)
+	fault.Lane = f.lane
+	fault.VAddr = f.vAddr
+	fault.IsWrite = f.isWrite
+	fault.Err = f.err
+
+	if cu.MemoryFaultHandler == nil {
+		log.Panic(fault)
+	}
+
+	cu.MemoryFaultHandler.HandleMemoryFault(cu.Engine.CurrentTime(), fault)
+}
+
+// SetAddressChecker makes the compute unit check the address of every memory
+// access that lanes make.
+func (cu *ComputeUnit) SetAddressChecker(c AddressChecker) {
+	if alu, ok := cu.alu.(*ALUImpl); ok {
+		alu.SetAddressChecker(c)
+	}
+}
+
+// EnableRaceDetection makes the compute unit look for the data races between
+// the wavefronts of each work-group, in both the LDS and the global memory.
+// Two accesses race if they are made by different wavefronts with no barrier
+// in between and at least one of them is a write. Atomic accesses do not race.
+func (cu *ComputeUnit) EnableRaceDetection() {
+	alu, ok := cu.alu.(*ALUImpl)
+	if !ok {
+		log.Panic("race detection requires the default ALU")
+	}
+
+	alu.recordAccesses = true
+	cu.raceDetector = newRaceDetector()
+}
+
+func (cu *ComputeUnit) detectRaces(
+	wf *Wavefront,
+	pc uint64,
+	inst *insts.Inst,
+	accesses []memAccess,
+) {
+	if cu.raceDetector == nil || len(accesses) == 0 || isVMemAtomic(inst) {
+		return
+	}
+
+	wfID := -1
+	for i, w := range wf.WG.Wavefronts {
+		if w == wf.Wavefront {
+			wfID = i
+		}
+	}
+
+	for _, a := range accesses {
+		for _, race := range cu.raceDetector.access(wfID, pc, inst, a) {
+			cu.reportRace(wf, race)
+		}
+	}
+}
+
+func isVMemAtomic(inst *insts.Inst) bool {
+	if inst.FormatType != insts.FLAT && inst.FormatType != insts.MUBUF {
+		return false
+	}
+
+	_, ok := VMemAtomicInfo(inst)
+	return ok
+}
+
+func (cu *ComputeUnit) reportRace(wf *Wavefront, race *DataRace) {
+	race.CU = cu.Name()
+	race.Kernel = "<unknown>"
+	if wf.CodeObject != nil && wf.CodeObject.Symbol != nil {
+		race.Kernel = wf.CodeObject.Symbol.Name
+	}
+	race.WorkGroupID = [3]int{wf.WG.IDX, wf.WG.IDY, wf.WG.IDZ}
+
+	if cu.RaceHandler == nil {
+		log.Print(race)
+		return
+	}
+
+	cu.RaceHandler.HandleDataRace(cu.Engine.CurrentTime(), race)
+}
+
 func (cu *ComputeUnit) logInst(wf *Wavefront, inst *insts.Inst) {
 	ctx := sim.HookCtx{
 		Domain: cu,
@@ -0,0 +1,158 @@
+package emu
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sarchlab/akita/v3/mem/vm"
+	"github.com/sarchlab/mgpusim/v3/insts"
+)
+
+// unimplementedInstError is the panic value of the ALU when it runs an
+// instruction that the emulator does not support.
+type unimplementedInstError struct {
+	format string
+	opcode insts.Opcode
+	name   string
+	sdwa   bool
+}
+
+func (e unimplementedInstError) Error() string {
+	if e.sdwa {
+		return fmt.Sprintf("SDWA for opcode %d (%s) for %s format is not implemented",
+			e.opcode, e.name, e.format)
+	}
+
+	return fmt.Sprintf("Opcode %d (%s) for %s format is not implemented",
+		e.opcode, e.name, e.format)
+}
+
+func panicUnimplemented(inst *insts.Inst) {
+	panic(unimplementedInstError{
+		format: strings.ToUpper(inst.Format.FormatName),
+		opcode: inst.Opcode,
+		name:   inst.InstName,
+	})
+}
+
+// panicUnimplementedSDWA is called when the ALU knows the instruction, but
+// not with the sub-dword addressing (SDWA) extension.
+func panicUnimplementedSDWA(inst *insts.Inst) {
+	panic(unimplementedInstError{
+		format: strings.ToUpper(inst.Format.FormatName),
+		opcode: inst.Opcode,
+		name:   inst.InstName,
+		sdwa:   true,
+	})
+}
+
+// knownGaps are the instructions that the emulator leaves out on purpose,
+// with the reason.
+var knownGaps = map[string]string{
+	"s_cbranch_g_fork":   "fork and join keep a branch stack in SGPRs, which compilers do not emit",
+	"s_cbranch_i_fork":   "fork and join keep a branch stack in SGPRs, which compilers do not emit",
+	"s_cbranch_join":     "fork and join keep a branch stack in SGPRs, which compilers do not emit",
+	"s_rfe_b64":          "trap handlers are not simulated",
+	"s_rfe_restore_b64":  "trap handlers are not simulated",
+	"s_trap":             "trap handlers are not simulated",
+	"s_set_gpr_idx_on":   "the VGPR index mode is not simulated, compilers use the movrel instructions",
+	"s_set_gpr_idx_off":  "the VGPR index mode is not simulated, compilers use the movrel instructions",
+	"s_set_gpr_idx_idx":  "the VGPR index mode is not simulated, compilers use the movrel instructions",
+	"s_set_gpr_idx_mode": "the VGPR index mode is not simulated, compilers use the movrel instructions",
+	"v_mqsad_u32_u8":     "the 128-bit result does not fit the 64-bit lanes of the VOP3a scratchpad",
+}
+
+// KnownGap returns why the emulator leaves out the instructions of the given
+// type on purpose. It returns an empty string if there is no known reason.
+func KnownGap(t *insts.InstType) string {
+	if strings.HasPrefix(t.InstName, "v_interp_") {
+		return "graphics only, the attributes to interpolate are not in LDS"
+	}
+
+	return knownGaps[t.InstName]
+}
+
+// isHandledByComputeUnit tells if the compute unit executes the instruction
+// by itself, without the ALU.
+func isHandledByComputeUnit(format insts.FormatType, opcode insts.Opcode) bool {
+	// S_ENDPGM and S_BARRIER
+	return format == insts.SOPP && (opcode == 1 || opcode == 10)
+}
+
+type probeState struct {
+	inst       *insts.Inst
+	scratchpad Scratchpad
+}
+
+func (s *probeState) PID() vm.PID {
+	return 1
+}
+
+func (s *probeState) Inst() *insts.Inst {
+	return s.inst
+}
+
+func (s *probeState) Scratchpad() Scratchpad {
+	return s.scratchpad
+}
+
+// IsEmulated tells if the emulator can execute the instructions of the given
+// type. It runs the instruction on an ALU that has no memory, so it only
+// tells if the ALU knows the instruction, rather than if the instruction
+// gives correct results.
+func IsEmulated(t *insts.InstType) bool {
+	if isHandledByComputeUnit(t.Format.FormatType, t.Opcode) {
+		return true
+	}
+
+	inst := insts.NewInst()
+	inst.InstType = t
+	inst.Format = t.Format
+
+	// The scratch instructions share the opcodes of the FLAT ones, and only
+	// the segment tells them apart.
+	if t.Format.FormatType == insts.FLAT && strings.HasPrefix(t.InstName, "scratch_") {
+		inst.Seg = insts.FlatSegmentScratch
+	}
+
+	return probe(inst)
+}
+
+// IsSDWAEmulated tells if the emulator can execute the instructions of the
+// given type with the sub-dword addressing (SDWA) extension. Only the VOP2
+// instructions can have SDWA.
+func IsSDWAEmulated(t *insts.InstType) bool {
+	if t.Format.FormatType != insts.VOP2 {
+		return false
+	}
+
+	inst := insts.NewInst()
+	inst.InstType = t
+	inst.Format = t.Format
+	inst.IsSdwa = true
+	inst.Src0Sel = insts.SDWASelectDWord
+	inst.Src1Sel = insts.SDWASelectDWord
+	inst.DstSel = insts.SDWASelectDWord
+
+	return probe(inst)
+}
+
+func probe(inst *insts.Inst) (emulated bool) {
+	state := &probeState{
+		inst:       inst,
+		scratchpad: make([]byte, 4096),
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			// Panicking for other reasons, such as not having a memory,
+			// means that the ALU has found the instruction.
+			_, unimplemented := r.(unimplementedInstError)
+			emulated = !unimplemented
+		}
+	}()
+
+	NewALU(nil).Run(state)
+
+	return true
+}
@@ -0,0 +1,44 @@
+package emu
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/mgpusim/v3/insts"
+)
+
+var _ = Describe("ISA coverage", func() {
+	var disasm *insts.Disassembler
+
+	BeforeEach(func() {
+		disasm = insts.NewDisassembler()
+	})
+
+	instType := func(format insts.FormatType, name string) *insts.InstType {
+		for _, t := range disasm.InstTypes(format) {
+			if t.InstName == name {
+				return t
+			}
+		}
+
+		Fail("cannot find " + name)
+
+		return nil
+	}
+
+	It("should tell if an instruction is emulated", func() {
+		Expect(IsEmulated(instType(insts.VOP2, "v_and_b32_e32"))).To(BeTrue())
+		Expect(IsEmulated(instType(insts.SOP2, "s_cbranch_g_fork"))).To(BeFalse())
+	})
+
+	It("should tell if a VOP2 instruction is emulated with SDWA", func() {
+		Expect(IsSDWAEmulated(instType(insts.VOP2, "v_and_b32_e32"))).To(BeTrue())
+		Expect(IsSDWAEmulated(instType(insts.VOP2, "v_sub_f32_e32"))).To(BeFalse())
+		Expect(IsSDWAEmulated(instType(insts.VOP1, "v_not_b32_e32"))).To(BeFalse())
+	})
+
+	It("should give the reason of the known gaps", func() {
+		Expect(KnownGap(instType(insts.SOP1, "s_cbranch_join"))).NotTo(BeEmpty())
+		Expect(KnownGap(instType(insts.VOP3a, "v_interp_p1_f32"))).NotTo(BeEmpty())
+		Expect(KnownGap(instType(insts.VOP2, "v_and_b32_e32"))).To(BeEmpty())
+	})
+})
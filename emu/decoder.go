@@ -7,3 +7,26 @@ import "github.com/sarchlab/mgpusim/v3/insts"
 type Decoder interface {
 	Decode(buf []byte) (*insts.Inst, error)
 }
+
+// ArchDecoder is a Decoder that can also decode the instructions of a given
+// architecture.
+type ArchDecoder interface {
+	Decoder
+	DecodeArch(arch insts.Arch, buf []byte) (*insts.Inst, error)
+}
+
+// DecodeForCodeObject decodes the instruction bytes with the architecture
+// that the code object targets. Decoders that do not know about
+// architectures decode the bytes as they always do.
+func DecodeForCodeObject(
+	d Decoder,
+	co *insts.HsaCo,
+	buf []byte,
+) (*insts.Inst, error) {
+	archDecoder, ok := d.(ArchDecoder)
+	if !ok || co == nil {
+		return d.Decode(buf)
+	}
+
+	return archDecoder.DecodeArch(co.Arch, buf)
+}
@@ -1,7 +1,10 @@
 package emu
 
 import (
+	"log"
+
 	"github.com/sarchlab/akita/v3/mem/vm"
+	"github.com/sarchlab/mgpusim/v3/bitops"
 	"github.com/sarchlab/mgpusim/v3/insts"
 )
 
@@ -12,3 +15,199 @@ type InstEmuState interface {
 	Inst() *insts.Inst
 	Scratchpad() Scratchpad
 }
+
+// ReadsDst tells if a scalar, a VOP3a, or a VOP3P instruction uses the
+// original value of its destination, which the scratchpad preparers then have
+// to load into the DST field of the scratchpad. VOP2 instructions always have
+// their destination loaded.
+func ReadsDst(inst *insts.Inst) bool {
+	switch inst.FormatType {
+	case insts.SOP1:
+		switch inst.Opcode {
+		case 2, 3, // s_cmov
+			24, 25, 26, 27, // s_bitset
+			29: // s_setpc_b64, which writes its unused destination back
+			return true
+		}
+	case insts.VOP3a:
+		switch inst.Opcode {
+		case 278, 291, // v_mac_f32, v_mac_f16
+			650: // v_writelane_b32
+			return true
+		}
+
+		// Writing the high half of the destination keeps the low half.
+		return inst.OpSel&vop3OpSelDst != 0
+	case insts.VOP3P:
+		switch inst.Opcode {
+		case 33, 34: // v_mad_mixlo_f16, v_mad_mixhi_f16
+			return true
+		}
+	}
+
+	return false
+}
+
+// VOP3PInlineConstant returns the register value that an inline constant
+// source of a VOP3P instruction stands for. The packed sources see the
+// constant in both halves, as a half-precision number if it is a float. It
+// returns false for the other operands and for the 32-bit sources of the
+// mix and the dot instructions, which read constants as the VOP3a
+// instructions do.
+func VOP3PInlineConstant(
+	inst *insts.Inst,
+	n int,
+	operand *insts.Operand,
+) (uint64, bool) {
+	if vop3pSrcIs32Bit(inst, n) {
+		return 0, false
+	}
+
+	var half uint16
+	switch operand.OperandType {
+	case insts.IntOperand:
+		half = uint16(operand.IntValue)
+	case insts.FloatOperand:
+		half = bitops.Float32ToFloat16(float32(operand.FloatValue))
+	default:
+		return 0, false
+	}
+
+	return uint64(half)<<16 | uint64(half), true
+}
+
+func vop3pSrcIs32Bit(inst *insts.Inst, n int) bool {
+	switch {
+	case inst.Opcode >= 32 && inst.Opcode <= 34: // v_mad_mix
+		return inst.OpSelHi&(1<<n) == 0
+	case inst.Opcode >= 38: // v_dot4 and v_dot8
+		return true
+	case inst.Opcode >= 35: // v_dot2
+		return n == 2
+	}
+
+	return false
+}
+
+// FlatAddress returns the address that a lane of a FLAT instruction
+// accesses, given the VGPR address of the lane and the SGPR address. GFX9
+// global instructions can take a 64-bit SGPR base with 32-bit VGPR offsets,
+// and add the signed offset of the instruction.
+func FlatAddress(inst *insts.Inst, vAddr, sAddr uint64) uint64 {
+	addr := vAddr
+	if inst.SAddr != nil {
+		addr = sAddr + uint64(uint32(vAddr))
+	}
+
+	if inst.Offset != nil {
+		addr = uint64(int64(addr) + inst.Offset.IntValue)
+	}
+
+	return addr
+}
+
+// InitialMode returns the MODE register that the wavefronts of a kernel start
+// with. It holds the float mode in bits 0 to 7, the DX10 clamp bit at bit 8,
+// and the IEEE mode bit at bit 9.
+func InitialMode(co *insts.HsaCo) uint32 {
+	mode := co.FloatMode()
+
+	if co.EnableDX10Clamp() {
+		mode |= 1 << 8
+	}
+
+	if co.EnableIEEEMode() {
+		mode |= 1 << 9
+	}
+
+	return mode
+}
+
+// IsMovRel tells if an instruction is an M0-relative move, which accesses the
+// register that is M0 registers after the one that it encodes. The s_movrels
+// and v_movrels instructions read the source that way, the s_movreld and
+// v_movreld instructions write the destination that way, and
+// v_movrelsd_b32 does both.
+func IsMovRel(inst *insts.Inst) bool {
+	relSrc, relDst := movRelOperands(inst)
+	return relSrc || relDst
+}
+
+// MovRelOperands returns the SRC0 and the DST operands that an instruction
+// accesses. The registers of the M0-relative moves are moved by m0.
+func MovRelOperands(
+	inst *insts.Inst,
+	m0 uint32,
+) (src0, dst *insts.Operand) {
+	src0, dst = inst.Src0, inst.Dst
+	relSrc, relDst := movRelOperands(inst)
+
+	if relSrc {
+		src0 = offsetRegOperand(src0, m0)
+	}
+
+	if relDst {
+		dst = offsetRegOperand(dst, m0)
+	}
+
+	return src0, dst
+}
+
+func movRelOperands(inst *insts.Inst) (relSrc, relDst bool) {
+	switch inst.FormatType {
+	case insts.SOP1:
+		switch inst.Opcode {
+		case 42, 43: // s_movrels
+			return true, false
+		case 44, 45: // s_movreld
+			return false, true
+		}
+	case insts.VOP1:
+		return vMovRelOperands(inst.Opcode)
+	case insts.VOP3a:
+		if inst.Opcode >= 320 && inst.Opcode < 448 {
+			return vMovRelOperands(inst.Opcode - 320)
+		}
+	}
+
+	return false, false
+}
+
+func vMovRelOperands(opcode insts.Opcode) (relSrc, relDst bool) {
+	switch opcode {
+	case 54: // v_movreld_b32
+		return false, true
+	case 55: // v_movrels_b32
+		return true, false
+	case 56: // v_movrelsd_b32
+		return true, true
+	}
+
+	return false, false
+}
+
+func offsetRegOperand(o *insts.Operand, m0 uint32) *insts.Operand {
+	if o.OperandType != insts.RegOperand || o.Register.RegIndex() < 0 {
+		log.Panicf("cannot offset operand %s by M0", o.String())
+	}
+
+	numRegs := 102
+	if o.Register.IsVReg() {
+		numRegs = 256
+	}
+
+	index := o.Register.RegIndex() + int(m0)
+	if index+max(o.RegCount, 1) > numRegs {
+		log.Panicf("register %s + M0 (%d) is out of range",
+			o.Register.Name, m0)
+	}
+
+	offset := *o
+	if o.Register.IsVReg() {
+		offset.Register = insts.VReg(index)
+	} else {
+		offset.Register = insts.SReg(index)
+	}
+
+	return &offset
+}
@@ -0,0 +1,269 @@
+// Command isacoverage lists, for each instruction format, the opcodes that
+// the disassembler can decode, the opcodes that the emulator can execute and
+// the opcodes that the unit tests of the emulator cover. For VOP2, it also
+// lists the opcodes that the emulator can execute with SDWA, and for the
+// opcodes that the emulator leaves out on purpose, it gives the reason.
+//
+// An opcode counts as unit-tested if an ALU test file (alu*_test.go) of the
+// emu package sets the FormatType and then the Opcode of an instruction to
+// it, which is how the ALU tests set up the instructions to run.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/sarchlab/mgpusim/v3/emu"
+	"github.com/sarchlab/mgpusim/v3/insts"
+)
+
+var formatFlag = flag.String("format", "",
+	"Only list the opcodes of the given format, for example, vop1.")
+var gapsFlag = flag.Bool("gaps", false,
+	"Only list the opcodes that are not emulated or not unit-tested, "+
+		"or, for VOP2, not emulated with SDWA.")
+var summaryFlag = flag.Bool("summary", false,
+	"Only print the number of opcodes of each format.")
+var archFlag = flag.String("arch", "gcn3",
+	"The instruction set to list the opcodes of, gcn3 or gfx9.")
+var emuDirFlag = flag.String("emu-dir", "",
+	"The directory of the emu package, where the unit tests are. By "+
+		"default, the directory is found with the go tool.")
+
+type opcodeKey struct {
+	format insts.FormatType
+	opcode insts.Opcode
+}
+
+type opcodeStatus struct {
+	instType *insts.InstType
+	emulated bool
+	sdwa     bool
+	tested   bool
+}
+
+func main() {
+	flag.Parse()
+
+	tested, err := findTestedOpcodes(emuDir())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	defer w.Flush()
+
+	// Probing the emulator makes it log about the instructions that it
+	// cannot run without a memory.
+	log.SetOutput(io.Discard)
+
+	disasm := insts.NewDisassemblerForArch(arch())
+	for _, format := range formats() {
+		var statuses []opcodeStatus
+		for _, t := range disasm.InstTypes(format.FormatType) {
+			statuses = append(statuses, opcodeStatus{
+				instType: t,
+				emulated: emu.IsEmulated(t),
+				sdwa:     emu.IsSDWAEmulated(t),
+				tested:   tested[opcodeKey{format.FormatType, t.Opcode}],
+			})
+		}
+
+		printFormat(w, format, statuses)
+	}
+}
+
+func arch() insts.Arch {
+	for _, a := range []insts.Arch{insts.GCN3, insts.GFX9} {
+		if strings.EqualFold(a.String(), *archFlag) {
+			return a
+		}
+	}
+
+	log.Fatalf("unknown architecture %s", *archFlag)
+
+	return insts.GCN3
+}
+
+func emuDir() string {
+	if *emuDirFlag != "" {
+		return *emuDirFlag
+	}
+
+	pkg, err := build.Import(
+		"github.com/sarchlab/mgpusim/v3/emu", ".", build.FindOnly)
+	if err != nil {
+		log.Fatalf("cannot find the emu package, use -emu-dir: %v", err)
+	}
+
+	return pkg.Dir
+}
+
+// formats returns the formats to list, in the order of the format types.
+func formats() []*insts.Format {
+	var list []*insts.Format
+	for _, f := range insts.FormatTable {
+		if *formatFlag != "" && !strings.EqualFold(f.FormatName, *formatFlag) {
+			continue
+		}
+
+		list = append(list, f)
+	}
+
+	if len(list) == 0 {
+		log.Fatalf("unknown format %s", *formatFlag)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].FormatType < list[j].FormatType
+	})
+
+	return list
+}
+
+func printFormat(
+	w *tabwriter.Writer,
+	format *insts.Format,
+	statuses []opcodeStatus,
+) {
+	hasSDWA := format.FormatType == insts.VOP2
+
+	numEmulated, numSDWA, numTested := 0, 0, 0
+	for _, s := range statuses {
+		if s.emulated {
+			numEmulated++
+		}
+
+		if s.sdwa {
+			numSDWA++
+		}
+
+		if s.tested {
+			numTested++
+		}
+	}
+
+	fmt.Fprintf(w, "%s\t%d decodable\t%d emulated\t",
+		strings.ToUpper(format.FormatName), len(statuses), numEmulated)
+	if hasSDWA {
+		fmt.Fprintf(w, "%d with SDWA\t", numSDWA)
+	}
+	fmt.Fprintf(w, "%d unit-tested\t\n", numTested)
+
+	if *summaryFlag {
+		return
+	}
+
+	for _, s := range statuses {
+		if *gapsFlag && s.emulated && s.tested && (s.sdwa || !hasSDWA) {
+			continue
+		}
+
+		fmt.Fprintf(w, "  %d\t%s\t%s\t",
+			s.instType.Opcode, s.instType.InstName,
+			mark(s.emulated, "emulated"))
+		if hasSDWA {
+			fmt.Fprintf(w, "%s\t", mark(s.sdwa, "SDWA"))
+		}
+		fmt.Fprintf(w, "%s\t", mark(s.tested, "unit-tested"))
+
+		if reason := emu.KnownGap(s.instType); reason != "" && !s.emulated {
+			fmt.Fprintf(w, "%s", reason)
+		}
+
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintln(w)
+}
+
+func mark(b bool, s string) string {
+	if b {
+		return s
+	}
+
+	return "-"
+}
+
+// findTestedOpcodes scans the ALU test files in the given directory for
+// assignments to the FormatType and the Opcode fields of instructions. Each
+// opcode is paired with the format that is assigned last before it.
+func findTestedOpcodes(dir string) (map[opcodeKey]bool, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "alu*_test.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no test files in %s", dir)
+	}
+
+	tested := make(map[opcodeKey]bool)
+	fset := token.NewFileSet()
+	for _, file := range files {
+		f, err := parser.ParseFile(fset, file, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		scanTestFile(f, tested)
+	}
+
+	return tested, nil
+}
+
+func scanTestFile(f *ast.File, tested map[opcodeKey]bool) {
+	formatsByName := make(map[string]insts.FormatType)
+	for _, format := range insts.FormatTable {
+		formatsByName[strings.ToUpper(format.FormatName)] = format.FormatType
+	}
+
+	format := insts.FormatType(-1)
+	ast.Inspect(f, func(n ast.Node) bool {
+		assign, ok := n.(*ast.AssignStmt)
+		if !ok || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+			return true
+		}
+
+		lhs, ok := assign.Lhs[0].(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+
+		switch lhs.Sel.Name {
+		case "FormatType":
+			rhs, ok := assign.Rhs[0].(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+
+			if t, found := formatsByName[strings.ToUpper(rhs.Sel.Name)]; found {
+				format = t
+			}
+		case "Opcode":
+			lit, ok := assign.Rhs[0].(*ast.BasicLit)
+			if !ok || lit.Kind != token.INT || format < 0 {
+				return true
+			}
+
+			opcode, err := strconv.ParseUint(lit.Value, 0, 16)
+			if err == nil {
+				tested[opcodeKey{format, insts.Opcode(opcode)}] = true
+			}
+		}
+
+		return true
+	})
+}
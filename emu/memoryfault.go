@@ -0,0 +1,218 @@
+package emu
+
+import (
+	"fmt"
+
+	"github.com/sarchlab/akita/v3/mem/vm"
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/insts"
+	"github.com/sarchlab/mgpusim/v3/kernels"
+)
+
+// ScalarLane is the lane that is reported for accesses that are not made by
+// a single lane, such as scalar memory loads and instruction fetches.
+const ScalarLane = -1
+
+// A MemoryFault describes an invalid memory access made by a wavefront. Err
+// tells why the access is invalid, for example, ErrPageNotFound.
+type MemoryFault struct {
+	CU          string
+	Kernel      string
+	WorkGroupID [3]int
+	WavefrontID int
+	PC          uint64
+	Inst        string
+	Lane        int
+	VAddr       uint64
+	IsWrite     bool
+	Err         error
+}
+
+// NewMemoryFault creates a MemoryFault that is caused by the instruction at
+// the given PC of a wavefront. The caller fills in the access.
+func NewMemoryFault(
+	cu string,
+	wf *kernels.Wavefront,
+	pc uint64,
+	inst *insts.Inst,
+) *MemoryFault {
+	f := &MemoryFault{
+		CU:          cu,
+		Kernel:      "<unknown>",
+		WavefrontID: -1,
+		PC:          pc,
+	}
+
+	if wf.CodeObject != nil && wf.CodeObject.Symbol != nil {
+		f.Kernel = wf.CodeObject.Symbol.Name
+	}
+
+	if wf.WG != nil {
+		f.WorkGroupID = [3]int{wf.WG.IDX, wf.WG.IDY, wf.WG.IDZ}
+		for i, w := range wf.WG.Wavefronts {
+			if w == wf {
+				f.WavefrontID = i
+			}
+		}
+	}
+
+	if inst != nil {
+		f.Inst = inst.String(nil)
+	}
+
+	return f
+}
+
+// Error returns the fault report.
+func (f *MemoryFault) Error() string {
+	access := "read"
+	if f.IsWrite {
+		access = "write"
+	}
+
+	lane := "scalar unit"
+	if f.Lane != ScalarLane {
+		lane = fmt.Sprintf("lane %d", f.Lane)
+	}
+
+	return fmt.Sprintf(
+		"memory access fault on %s: %s of address 0x%x by %s, "+
+			"kernel %s, work-group (%d, %d, %d), wavefront %d, "+
+			"PC 0x%x, instruction %q. Reason: %v",
+		f.CU, access, f.VAddr, lane,
+		f.Kernel, f.WorkGroupID[0], f.WorkGroupID[1], f.WorkGroupID[2],
+		f.WavefrontID, f.PC, f.Inst, f.Err)
+}
+
+// Unwrap returns the reason of the fault.
+func (f *MemoryFault) Unwrap() error {
+	return f.Err
+}
+
+// A MemoryFaultHandler is notified when a wavefront makes an invalid memory
+// access. The faulting lane reads zeros from unmapped addresses and its
+// writes to them are dropped, so the kernel can keep running if the handler
+// returns.
+type MemoryFaultHandler interface {
+	HandleMemoryFault(now sim.VTimeInSec, fault error)
+}
+
+// An AddressChecker checks the addresses that the lanes access beyond the
+// page table, for example, against the boundaries of the allocations.
+type AddressChecker interface {
+	CheckAccess(pid vm.PID, vAddr, byteSize uint64) error
+}
+
+// VMemLaneByteSize returns the number of bytes that each lane accesses when
+// executing a FLAT, MUBUF or MTBUF load, store or atomic instruction. For the
+// format instructions, it assumes 32-bit components.
+func VMemLaneByteSize(inst *insts.Inst) uint64 {
+	if info, ok := VMemAtomicInfo(inst); ok {
+		if info.Is64Bit {
+			return 8
+		}
+		return 4
+	}
+
+	op := uint64(inst.Opcode)
+	switch {
+	case inst.FormatType != insts.FLAT && op <= 7:
+		return 4 * (op%4 + 1)
+	case op == 16 || op == 17 || op == 24 || op == 25:
+		return 1
+	case op == 18 || op == 19 || op == 26 || op == 27:
+		return 2
+	case op >= 20 && op <= 23:
+		return 4 * (op - 19)
+	case op >= 28 && op <= 31:
+		return 4 * (op - 27)
+	case op >= 32 && op <= 35: // The GFX9 d16 byte loads
+		return 1
+	case op == 36 || op == 37: // The GFX9 d16 short loads
+		return 2
+	}
+
+	return 0
+}
+
+// laneFault is a fault recorded by the ALU, which does not know which
+// wavefront it is executing.
+type laneFault struct {
+	lane    int
+	vAddr   uint64
+	isWrite bool
+	err     error
+}
+
+// SetAddressChecker makes the ALU check the address of every memory access
+// that lanes make.
+func (u *ALUImpl) SetAddressChecker(c AddressChecker) {
+	u.addressChecker = c
+}
+
+// readMemory reads the global memory on behalf of a lane. If the access is not
+// valid, it records the fault. The bytes at unmapped addresses read as zeros.
+// The address checker knows more about the access than the page table, for
+// example, that the page is unmapped because the buffer is freed, so its
+// error takes precedence.
+func (u *ALUImpl) readMemory(
+	pid vm.PID,
+	lane int,
+	vAddr, byteSize uint64,
+) []byte {
+	u.recordAccess(GlobalSpace, lane, vAddr, byteSize, false)
+
+	data, err := u.storageAccessor.Read(pid, vAddr, byteSize)
+	if checkErr := u.checkAddress(pid, lane, vAddr, byteSize); checkErr != nil {
+		err = checkErr
+	}
+
+	if err != nil {
+		u.faults = append(u.faults,
+			laneFault{lane: lane, vAddr: vAddr, err: err})
+	}
+
+	return data
+}
+
+// writeMemory writes the global memory on behalf of a lane. If the access is
+// not valid, it records the fault. Like on the hardware, writes to mapped
+// addresses are performed even if the address checker rejects them.
+func (u *ALUImpl) writeMemory(
+	pid vm.PID,
+	lane int,
+	vAddr uint64,
+	data []byte,
+) {
+	u.recordAccess(GlobalSpace, lane, vAddr, uint64(len(data)), true)
+
+	err := u.storageAccessor.Write(pid, vAddr, data)
+	checkErr := u.checkAddress(pid, lane, vAddr, uint64(len(data)))
+	if checkErr != nil {
+		err = checkErr
+	}
+
+	if err != nil {
+		u.faults = append(u.faults,
+			laneFault{lane: lane, vAddr: vAddr, isWrite: true, err: err})
+	}
+}
+
+func (u *ALUImpl) checkAddress(
+	pid vm.PID,
+	lane int,
+	vAddr, byteSize uint64,
+) error {
+	if u.addressChecker == nil || lane == ScalarLane {
+		return nil
+	}
+
+	return u.addressChecker.CheckAccess(pid, vAddr, byteSize)
+}
+
+// takeFaults returns the faults recorded since the last call.
+func (u *ALUImpl) takeFaults() []laneFault {
+	faults := u.faults
+	u.faults = nil
+	return faults
+}
@@ -0,0 +1,280 @@
+package emu
+
+import (
+	"fmt"
+
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/insts"
+)
+
+// The kinds of hazards that the race detector reports. The emulator runs the
+// wavefronts of a work-group one after another, so which of the two accesses
+// comes first only reflects that order. On the hardware, they can happen in
+// either order.
+const (
+	ReadAfterWrite  = "read-after-write"
+	WriteAfterRead  = "write-after-read"
+	WriteAfterWrite = "write-after-write"
+)
+
+// The memory spaces in which the race detector looks for hazards.
+const (
+	LDSSpace    = "LDS"
+	GlobalSpace = "global"
+)
+
+// A RaceAccess is one of the two accesses that form a data race.
+type RaceAccess struct {
+	WavefrontID int
+	PC          uint64
+	Inst        string
+	Lane        int
+	IsWrite     bool
+}
+
+func (a RaceAccess) String() string {
+	access := "read"
+	if a.IsWrite {
+		access = "write"
+	}
+
+	lane := "scalar unit"
+	if a.Lane != ScalarLane {
+		lane = fmt.Sprintf("lane %d", a.Lane)
+	}
+
+	return fmt.Sprintf("%s by wavefront %d, %s, PC 0x%x, instruction %q",
+		access, a.WavefrontID, lane, a.PC, a.Inst)
+}
+
+// A DataRace describes two accesses to the same address, made by different
+// wavefronts of a work-group with no barrier in between, at least one of
+// which is a write.
+type DataRace struct {
+	CU          string
+	Kernel      string
+	WorkGroupID [3]int
+	Hazard      string
+	Space       string
+	Addr        uint64
+	First       RaceAccess
+	Second      RaceAccess
+}
+
+// Error returns the race report.
+func (r *DataRace) Error() string {
+	return fmt.Sprintf(
+		"%s data race on %s: %s memory address 0x%x, "+
+			"kernel %s, work-group (%d, %d, %d). First %s. Then %s",
+		r.Hazard, r.CU, r.Space, r.Addr,
+		r.Kernel, r.WorkGroupID[0], r.WorkGroupID[1], r.WorkGroupID[2],
+		r.First, r.Second)
+}
+
+// A RaceHandler is notified of the data races that the race detector finds.
+type RaceHandler interface {
+	HandleDataRace(now sim.VTimeInSec, race *DataRace)
+}
+
+// memAccess is a memory access recorded by the ALU for the race detector.
+type memAccess struct {
+	space    string
+	lane     int
+	addr     uint64
+	byteSize uint64
+	isWrite  bool
+}
+
+// shadowAccess is an access that the race detector remembers for a byte.
+type shadowAccess struct {
+	wf   int
+	pc   uint64
+	inst *insts.Inst
+	lane int
+}
+
+func (a shadowAccess) raceAccess(isWrite bool) RaceAccess {
+	ra := RaceAccess{
+		WavefrontID: a.wf,
+		PC:          a.pc,
+		Lane:        a.lane,
+		IsWrite:     isWrite,
+	}
+
+	if a.inst != nil {
+		ra.Inst = a.inst.String(nil)
+	}
+
+	return ra
+}
+
+// shadowByte holds the last writer and the readers from each wavefront of a
+// byte since the last barrier.
+type shadowByte struct {
+	writer    shadowAccess
+	hasWriter bool
+	readers   []shadowAccess
+}
+
+type shadowKey struct {
+	space string
+	addr  uint64
+}
+
+// raceKey identifies the code that races, so that a race in a loop or in
+// many work-groups is only reported once.
+type raceKey struct {
+	hazard   string
+	space    string
+	firstPC  uint64
+	secondPC uint64
+}
+
+// A raceDetector keeps the shadow memory of the work-group that the compute
+// unit is running. The shadow memory is cleared at every barrier.
+type raceDetector struct {
+	shadow   map[shadowKey]*shadowByte
+	reported map[raceKey]bool
+}
+
+func newRaceDetector() *raceDetector {
+	return &raceDetector{
+		shadow:   make(map[shadowKey]*shadowByte),
+		reported: make(map[raceKey]bool),
+	}
+}
+
+func (d *raceDetector) barrier() {
+	d.shadow = make(map[shadowKey]*shadowByte)
+}
+
+// access records that a wavefront makes an access and returns the races that
+// the access forms with the earlier accesses from other wavefronts.
+func (d *raceDetector) access(
+	wf int,
+	pc uint64,
+	inst *insts.Inst,
+	a memAccess,
+) []*DataRace {
+	var races []*DataRace
+
+	curr := shadowAccess{wf: wf, pc: pc, inst: inst, lane: a.lane}
+	for addr := a.addr; addr < a.addr+a.byteSize; addr++ {
+		key := shadowKey{space: a.space, addr: addr}
+		b, ok := d.shadow[key]
+		if !ok {
+			b = &shadowByte{}
+			d.shadow[key] = b
+		}
+
+		if a.isWrite {
+			races = d.write(races, b, curr, a.space, addr)
+		} else {
+			races = d.read(races, b, curr, a.space, addr)
+		}
+	}
+
+	return races
+}
+
+func (d *raceDetector) write(
+	races []*DataRace,
+	b *shadowByte,
+	curr shadowAccess,
+	space string,
+	addr uint64,
+) []*DataRace {
+	if b.hasWriter && b.writer.wf != curr.wf {
+		races = d.report(races, WriteAfterWrite, space, addr,
+			b.writer.raceAccess(true), curr.raceAccess(true))
+	}
+
+	for _, r := range b.readers {
+		if r.wf != curr.wf {
+			races = d.report(races, WriteAfterRead, space, addr,
+				r.raceAccess(false), curr.raceAccess(true))
+		}
+	}
+
+	b.writer = curr
+	b.hasWriter = true
+
+	return races
+}
+
+func (d *raceDetector) read(
+	races []*DataRace,
+	b *shadowByte,
+	curr shadowAccess,
+	space string,
+	addr uint64,
+) []*DataRace {
+	if b.hasWriter && b.writer.wf != curr.wf {
+		races = d.report(races, ReadAfterWrite, space, addr,
+			b.writer.raceAccess(true), curr.raceAccess(false))
+	}
+
+	for _, r := range b.readers {
+		if r.wf == curr.wf {
+			return races
+		}
+	}
+
+	b.readers = append(b.readers, curr)
+
+	return races
+}
+
+func (d *raceDetector) report(
+	races []*DataRace,
+	hazard, space string,
+	addr uint64,
+	first, second RaceAccess,
+) []*DataRace {
+	key := raceKey{
+		hazard:   hazard,
+		space:    space,
+		firstPC:  first.PC,
+		secondPC: second.PC,
+	}
+	if d.reported[key] {
+		return races
+	}
+	d.reported[key] = true
+
+	return append(races, &DataRace{
+		Hazard: hazard,
+		Space:  space,
+		Addr:   addr,
+		First:  first,
+		Second: second,
+	})
+}
+
+// recordAccess remembers a memory access for the race detector if it is
+// enabled.
+func (u *ALUImpl) recordAccess(
+	space string,
+	lane int,
+	addr, byteSize uint64,
+	isWrite bool,
+) {
+	if !u.recordAccesses {
+		return
+	}
+
+	u.accesses = append(u.accesses, memAccess{
+		space:    space,
+		lane:     lane,
+		addr:     addr,
+		byteSize: byteSize,
+		isWrite:  isWrite,
+	})
+}
+
+// takeAccesses returns the accesses recorded since the last call.
+func (u *ALUImpl) takeAccesses() []memAccess {
+	accesses := u.accesses
+	u.accesses = nil
+	return accesses
+}
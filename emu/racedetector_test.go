@@ -0,0 +1,119 @@
+package emu
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/mgpusim/v3/insts"
+)
+
+var _ = Describe("Race Detector", func() {
+	var (
+		d *raceDetector
+	)
+
+	BeforeEach(func() {
+		d = newRaceDetector()
+	})
+
+	write := func(addr uint64) memAccess {
+		return memAccess{
+			space: LDSSpace, lane: 3, addr: addr, byteSize: 4, isWrite: true}
+	}
+
+	read := func(addr uint64) memAccess {
+		return memAccess{space: LDSSpace, lane: 5, addr: addr, byteSize: 4}
+	}
+
+	It("should not report accesses from the same wavefront", func() {
+		Expect(d.access(0, 0x100, nil, write(64))).To(BeEmpty())
+		Expect(d.access(0, 0x108, nil, read(64))).To(BeEmpty())
+		Expect(d.access(0, 0x110, nil, write(64))).To(BeEmpty())
+	})
+
+	It("should report read-after-write", func() {
+		Expect(d.access(0, 0x100, nil, write(64))).To(BeEmpty())
+
+		races := d.access(1, 0x108, nil, read(66))
+
+		Expect(races).To(HaveLen(1))
+		Expect(races[0].Hazard).To(Equal(ReadAfterWrite))
+		Expect(races[0].Space).To(Equal(LDSSpace))
+		Expect(races[0].Addr).To(Equal(uint64(66)))
+		Expect(races[0].First).To(Equal(RaceAccess{
+			WavefrontID: 0, PC: 0x100, Lane: 3, IsWrite: true}))
+		Expect(races[0].Second).To(Equal(RaceAccess{
+			WavefrontID: 1, PC: 0x108, Lane: 5}))
+	})
+
+	It("should report write-after-write", func() {
+		inst := insts.NewInst()
+		inst.FormatType = insts.SOPP
+		inst.Opcode = 10
+		inst.InstName = "s_barrier"
+
+		d.access(0, 0x100, nil, write(64))
+		races := d.access(2, 0x200, inst, write(64))
+
+		Expect(races).To(HaveLen(1))
+		Expect(races[0].Hazard).To(Equal(WriteAfterWrite))
+		Expect(races[0].Second.WavefrontID).To(Equal(2))
+		Expect(races[0].Second.Inst).To(Equal("s_barrier"))
+	})
+
+	It("should report write-after-read", func() {
+		d.access(0, 0x100, nil, read(64))
+		races := d.access(1, 0x200, nil, write(64))
+
+		Expect(races).To(HaveLen(1))
+		Expect(races[0].Hazard).To(Equal(WriteAfterRead))
+	})
+
+	It("should report the same pair of instructions only once", func() {
+		d.access(0, 0x100, nil, write(64))
+		d.access(0, 0x100, nil, write(128))
+
+		Expect(d.access(1, 0x108, nil, read(64))).To(HaveLen(1))
+		Expect(d.access(1, 0x108, nil, read(128))).To(BeEmpty())
+	})
+
+	It("should not report accesses separated by a barrier", func() {
+		d.access(0, 0x100, nil, write(64))
+		d.barrier()
+
+		Expect(d.access(1, 0x108, nil, read(64))).To(BeEmpty())
+	})
+
+	It("should not mix the LDS and the global memory", func() {
+		d.access(0, 0x100, nil, write(64))
+
+		global := read(64)
+		global.space = GlobalSpace
+		Expect(d.access(1, 0x108, nil, global)).To(BeEmpty())
+	})
+
+	It("should record the accesses of the ALU if enabled", func() {
+		alu := NewALU(nil)
+		alu.lds = make([]byte, 4096)
+		alu.recordAccesses = true
+
+		state := new(mockInstState)
+		state.scratchpad = make([]byte, 4096)
+		state.inst = insts.NewInst()
+		state.inst.FormatType = insts.DS
+		state.inst.Opcode = 14
+		state.inst.Offset0 = 0
+		state.inst.Offset1 = 4
+
+		sp := state.scratchpad.AsDS()
+		sp.EXEC = 0x2
+		sp.ADDR[1] = 100
+
+		alu.Run(state)
+
+		Expect(alu.takeAccesses()).To(Equal([]memAccess{
+			{space: LDSSpace, lane: 1, addr: 100, byteSize: 4, isWrite: true},
+			{space: LDSSpace, lane: 1, addr: 116, byteSize: 4, isWrite: true},
+		}))
+		Expect(alu.takeAccesses()).To(BeEmpty())
+	})
+})
@@ -72,6 +72,12 @@ func (sp Scratchpad) AsFlat() *FlatLayout {
 	return (*FlatLayout)(unsafe.Pointer(&sp[0]))
 }
 
+// AsMUBUF returns the ScratchPad as a struct representing the MUBUF and MTBUF
+// scratchpad layout
+func (sp Scratchpad) AsMUBUF() *MUBUFLayout {
+	return (*MUBUFLayout)(unsafe.Pointer(&sp[0]))
+}
+
 // AsSMEM returns the ScratchPad as a struct representing the SMEM scratchpad
 // layout
 func (sp Scratchpad) AsSMEM() *SMEMLayout {
@@ -127,9 +133,12 @@ type SOPPLayout struct {
 
 //SOPKLayout represents the scratchpad layout for SOPK instructions
 type SOPKLayout struct {
-	DST uint64
-	IMM uint64
-	SCC byte
+	DST  uint64
+	IMM  uint64
+	SCC  byte
+	PC   uint64
+	SRC0 uint64 // The literal constant of s_setreg_imm32_b32
+	MODE uint32
 }
 
 // VOP1Layout represents the scratchpad layout for VOP1 instructions
@@ -187,6 +196,20 @@ type FlatLayout struct {
 	DST  [256]uint32
 }
 
+// MUBUFLayout represents the scratchpad layout for MUBUF and MTBUF
+// instructions. ADDR and InRange are derived from VADDR, SRSRC, and SOFFSET by
+// CalculateBufferAddresses.
+type MUBUFLayout struct {
+	EXEC    uint64
+	ADDR    [64]uint64
+	DATA    [256]uint32
+	DST     [256]uint32
+	VADDR   [128]uint32 // Up to 2 VGPRs (index, offset) per lane
+	SRSRC   [4]uint32
+	SOFFSET uint32
+	InRange uint64
+}
+
 // DSLayout represents the scratchpad layout for DS instructions
 type DSLayout struct {
 	EXEC  uint64
@@ -53,10 +53,14 @@ func (p *ScratchpadPreparerImpl) Prepare(
 		p.prepareVOP3a(instEmuState, wf)
 	case insts.VOP3b:
 		p.prepareVOP3b(instEmuState, wf)
+	case insts.VOP3P:
+		p.prepareVOP3P(instEmuState, wf)
 	case insts.VOPC:
 		p.prepareVOPC(instEmuState, wf)
 	case insts.FLAT:
 		p.prepareFlat(instEmuState, wf)
+	case insts.MUBUF, insts.MTBUF:
+		p.prepareMUBUF(instEmuState, wf)
 	case insts.SMEM:
 		p.prepareSMEM(instEmuState, wf)
 	case insts.SOPP:
@@ -78,7 +82,11 @@ func (p *ScratchpadPreparerImpl) prepareSOP1(
 	scratchPad := instEmuState.Scratchpad()
 	layout := scratchPad.AsSOP1()
 	layout.PC = wf.PC
-	p.readOperand(inst.Src0, wf, 0, scratchPad[0:8])
+	src0, _ := MovRelOperands(inst, wf.M0)
+	p.readOperand(src0, wf, 0, scratchPad[0:8])
+	if ReadsDst(inst) {
+		p.readOperand(inst.Dst, wf, 0, scratchPad[8:16])
+	}
 	copy(scratchPad[24:25], wf.ReadReg(insts.Regs[insts.SCC], 1, 0))
 	copy(scratchPad[16:24], wf.ReadReg(insts.Regs[insts.EXEC], 1, 0))
 }
@@ -108,9 +116,10 @@ func (p *ScratchpadPreparerImpl) prepareVOP1(
 	copy(sp[0:8], wf.ReadReg(insts.Regs[insts.EXEC], 1, 0))
 	copy(sp[520:528], wf.ReadReg(insts.Regs[insts.VCC], 1, 0))
 
+	src0, _ := MovRelOperands(inst, wf.M0)
 	offset := 528
 	for i := 0; i < 64; i++ {
-		p.readOperand(inst.Src0, wf, i, sp[offset:offset+8])
+		p.readOperand(src0, wf, i, sp[offset:offset+8])
 		offset += 8
 	}
 }
@@ -151,11 +160,18 @@ func (p *ScratchpadPreparerImpl) prepareVOP3a(
 	copy(sp[0:8], wf.ReadReg(insts.Regs[insts.EXEC], 1, 0))
 	copy(sp[520:528], wf.ReadReg(insts.Regs[insts.VCC], 1, 0))
 
+	readsDst := ReadsDst(inst)
+	src0, _ := MovRelOperands(inst, wf.M0)
+	dstOffset := 8
 	src0Offset := 528
 	src1Offset := 1040
 	src2Offset := 1552
 	for i := 0; i < 64; i++ {
-		p.readOperand(inst.Src0, wf, i, sp[src0Offset:src0Offset+8])
+		if readsDst {
+			p.readOperand(inst.Dst, wf, i, sp[dstOffset:dstOffset+8])
+			dstOffset += 8
+		}
+		p.readOperand(src0, wf, i, sp[src0Offset:src0Offset+8])
 		src0Offset += 8
 		p.readOperand(inst.Src1, wf, i, sp[src1Offset:src1Offset+8])
 		src1Offset += 8
@@ -191,6 +207,40 @@ func (p *ScratchpadPreparerImpl) prepareVOP3b(
 	}
 }
 
+// prepareVOP3P uses the VOP3a layout, as the packed instructions have the
+// same operands.
+func (p *ScratchpadPreparerImpl) prepareVOP3P(
+	instEmuState InstEmuState,
+	wf *Wavefront,
+) {
+	inst := instEmuState.Inst()
+	sp := instEmuState.Scratchpad()
+
+	copy(sp[0:8], wf.ReadReg(insts.Regs[insts.EXEC], 1, 0))
+
+	readsDst := ReadsDst(inst)
+	srcs := []*insts.Operand{inst.Src0, inst.Src1, inst.Src2}
+	for i := 0; i < 64; i++ {
+		if readsDst {
+			p.readOperand(inst.Dst, wf, i, sp[8+i*8:16+i*8])
+		}
+
+		for n, src := range srcs {
+			if src == nil {
+				continue
+			}
+
+			offset := 528 + n*512 + i*8
+			if value, ok := VOP3PInlineConstant(inst, n, src); ok {
+				copy(sp[offset:offset+8], insts.Uint64ToBytes(value))
+				continue
+			}
+
+			p.readOperand(src, wf, i, sp[offset:offset+8])
+		}
+	}
+}
+
 func (p *ScratchpadPreparerImpl) prepareVOPC(
 	instEmuState InstEmuState,
 	wf *Wavefront,
@@ -219,10 +269,46 @@ func (p *ScratchpadPreparerImpl) prepareFlat(
 
 	copy(sp[0:8], wf.ReadReg(insts.Regs[insts.EXEC], 1, 0))
 
+	var sAddr uint64
+	if inst.SAddr != nil {
+		sAddr = insts.BytesToUint64(wf.ReadReg(inst.SAddr.Register, 2, 0))
+	}
+
+	layout := sp.AsFlat()
+	for i := 0; i < 64; i++ {
+		var vAddr uint64
+		if inst.Addr != nil {
+			p.readOperand(inst.Addr, wf, i, sp[8+i*8:8+i*8+8])
+			vAddr = layout.ADDR[i]
+		}
+
+		layout.ADDR[i] = FlatAddress(inst, vAddr, sAddr)
+		p.readOperand(inst.Data, wf, i, sp[520+i*16:520+i*16+16])
+
+		// The d16 loads keep half of the destination register.
+		if IsFlatD16Load(inst) {
+			p.readOperand(inst.Dst, wf, i, sp[1544+i*16:1544+i*16+4])
+		}
+	}
+}
+
+func (p *ScratchpadPreparerImpl) prepareMUBUF(
+	instEmuState InstEmuState, wf *Wavefront,
+) {
+	inst := instEmuState.Inst()
+	sp := instEmuState.Scratchpad()
+
+	copy(sp[0:8], wf.ReadReg(insts.Regs[insts.EXEC], 1, 0))
+
 	for i := 0; i < 64; i++ {
-		p.readOperand(inst.Addr, wf, i, sp[8+i*8:8+i*8+8])
+		if inst.IdxEn || inst.OffEn {
+			p.readOperand(inst.Addr, wf, i, sp[2568+i*8:2568+i*8+8])
+		}
 		p.readOperand(inst.Data, wf, i, sp[520+i*16:520+i*16+16])
 	}
+
+	p.readOperand(inst.Base, wf, 0, sp[3080:3096])
+	p.readOperand(inst.Offset, wf, 0, sp[3096:3100])
 }
 
 func (p *ScratchpadPreparerImpl) prepareSMEM(
@@ -238,6 +324,13 @@ func (p *ScratchpadPreparerImpl) prepareSMEM(
 
 	p.readOperand(inst.Offset, wf, 0, scratchpad[16:24])
 	p.readOperand(inst.Base, wf, 0, scratchpad[24:32])
+
+	// GFX9 can add an SGPR to the immediate offset.
+	if inst.SOffset != nil {
+		layout := scratchpad.AsSMEM()
+		soffset := wf.ReadReg(inst.SOffset.Register, 1, 0)
+		layout.Offset += uint64(insts.BytesToUint32(soffset))
+	}
 }
 
 func (p *ScratchpadPreparerImpl) prepareSOPP(
@@ -263,8 +356,13 @@ func (p *ScratchpadPreparerImpl) prepareSOPK(
 	scratchPad := instEmuState.Scratchpad()
 	layout := scratchPad.AsSOPK()
 	layout.SCC = wf.SCC
+	layout.PC = wf.PC
+	layout.MODE = wf.MODE
 	p.readOperand(inst.Dst, wf, 0, scratchPad[0:8])
 	p.readOperand(inst.SImm16, wf, 0, scratchPad[8:16])
+	if inst.Src0 != nil {
+		p.readOperand(inst.Src0, wf, 0, scratchPad[32:40])
+	}
 }
 
 func (p *ScratchpadPreparerImpl) prepareSOPC(
@@ -329,10 +427,14 @@ func (p *ScratchpadPreparerImpl) Commit(
 		p.commitVOP3a(instEmuState, wf)
 	case insts.VOP3b:
 		p.commitVOP3b(instEmuState, wf)
+	case insts.VOP3P:
+		p.commitVOP3P(instEmuState, wf)
 	case insts.VOPC:
 		p.commitVOPC(instEmuState, wf)
 	case insts.FLAT:
 		p.commitFlat(instEmuState, wf)
+	case insts.MUBUF, insts.MTBUF:
+		p.commitMUBUF(instEmuState, wf)
 	case insts.SMEM:
 		p.commitSMEM(instEmuState, wf)
 	case insts.SOPP:
@@ -355,7 +457,8 @@ func (p *ScratchpadPreparerImpl) commitSOP1(
 	inst := instEmuState.Inst()
 	scratchpad := instEmuState.Scratchpad()
 
-	p.writeOperand(inst.Dst, wf, 0, scratchpad[8:16])
+	_, dst := MovRelOperands(inst, wf.M0)
+	p.writeOperand(dst, wf, 0, scratchpad[8:16])
 	wf.WriteReg(insts.Regs[insts.EXEC], 1, 0, scratchpad[16:24])
 	wf.WriteReg(insts.Regs[insts.SCC], 1, 0, scratchpad[24:25])
 	wf.PC = scratchpad.AsSOP1().PC
@@ -381,12 +484,13 @@ func (p *ScratchpadPreparerImpl) commitVOP1(
 
 	wf.WriteReg(insts.Regs[insts.VCC], 1, 0, scratchpad[520:528])
 
+	_, dst := MovRelOperands(inst, wf.M0)
 	for i := 63; i >= 0; i-- {
 		if !laneMasked(exec, uint(i)) {
 			continue
 		}
 		offset := 8 + i*8
-		p.writeOperand(inst.Dst, wf, i, scratchpad[offset:offset+8])
+		p.writeOperand(dst, wf, i, scratchpad[offset:offset+8])
 	}
 }
 
@@ -426,13 +530,14 @@ func (p *ScratchpadPreparerImpl) commitVOP3a(
 
 	exec := sp.AsVOP3A().EXEC
 
+	_, dst := MovRelOperands(inst, wf.M0)
 	for i := 63; i >= 0; i-- {
 		if !laneMasked(exec, uint(i)) {
 			continue
 		}
 
 		offset := 8 + i*8
-		p.writeOperand(inst.Dst, wf, i, sp[offset:offset+8])
+		p.writeOperand(dst, wf, i, sp[offset:offset+8])
 	}
 }
 
@@ -444,6 +549,9 @@ func (p *ScratchpadPreparerImpl) commitVOP3aCmp(
 	sp := instEmuState.Scratchpad()
 
 	p.writeOperand(inst.Dst, wf, 0, sp[8:16])
+
+	// The v_cmpx instructions also write EXEC.
+	wf.WriteReg(insts.Regs[insts.EXEC], 1, 0, sp[0:8])
 }
 
 func (p *ScratchpadPreparerImpl) commitVOP3b(
@@ -467,6 +575,24 @@ func (p *ScratchpadPreparerImpl) commitVOP3b(
 	p.writeOperand(inst.SDst, wf, 0, insts.Uint64ToBytes(layout.SDST))
 }
 
+func (p *ScratchpadPreparerImpl) commitVOP3P(
+	instEmuState InstEmuState,
+	wf *Wavefront,
+) {
+	inst := instEmuState.Inst()
+	sp := instEmuState.Scratchpad()
+	exec := sp.AsVOP3A().EXEC
+
+	for i := 0; i < 64; i++ {
+		if !laneMasked(exec, uint(i)) {
+			continue
+		}
+
+		offset := 8 + i*8
+		p.writeOperand(inst.Dst, wf, i, sp[offset:offset+8])
+	}
+}
+
 func (p *ScratchpadPreparerImpl) commitVOPC(
 	instEmuState InstEmuState,
 	wf *Wavefront,
@@ -484,6 +610,10 @@ func (p *ScratchpadPreparerImpl) commitFlat(
 	scratchpad := instEmuState.Scratchpad()
 	exec := scratchpad.AsFlat().EXEC
 
+	if atomic, isAtomic := VMemAtomicInfo(inst); isAtomic && !atomic.ReturnPreOp {
+		return
+	}
+
 	if inst.Opcode < 24 || inst.Opcode > 31 { // Skip store instructions
 		for i := 0; i < 64; i++ {
 			if !laneMasked(exec, uint(i)) {
@@ -495,6 +625,27 @@ func (p *ScratchpadPreparerImpl) commitFlat(
 	}
 }
 
+func (p *ScratchpadPreparerImpl) commitMUBUF(
+	instEmuState InstEmuState,
+	wf *Wavefront,
+) {
+	inst := instEmuState.Inst()
+	scratchpad := instEmuState.Scratchpad()
+	exec := scratchpad.AsMUBUF().EXEC
+
+	if !IsBufferLoad(inst) {
+		return
+	}
+
+	for i := 0; i < 64; i++ {
+		if !laneMasked(exec, uint(i)) {
+			continue
+		}
+
+		p.writeOperand(inst.Dst, wf, i, scratchpad[1544+i*16:1544+i*16+16])
+	}
+}
+
 func (p *ScratchpadPreparerImpl) commitSMEM(
 	instEmuState InstEmuState,
 	wf *Wavefront,
@@ -531,6 +682,8 @@ func (p *ScratchpadPreparerImpl) commitSOPK(
 	scratchpad := instEmuState.Scratchpad()
 	p.writeOperand(inst.Dst, wf, 0, scratchpad[0:8])
 	wf.SCC = scratchpad.AsSOPK().SCC
+	wf.PC = scratchpad.AsSOPK().PC
+	wf.MODE = scratchpad.AsSOPK().MODE
 }
 
 func (p *ScratchpadPreparerImpl) commitDS(
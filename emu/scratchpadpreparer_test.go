@@ -41,6 +41,21 @@ var _ = Describe("ScratchpadPreparer", func() {
 		Expect(sp.PC).To(Equal(uint64(10)))
 	})
 
+	It("should prepare for s_movrels with the source moved by M0", func() {
+		inst := insts.NewInst()
+		inst.FormatType = insts.SOP1
+		inst.Opcode = 42
+		inst.Src0 = insts.NewSRegOperand(4, 4, 1)
+		wf.inst = inst
+
+		wf.WriteReg(insts.SReg(7), 1, 0, insts.Uint32ToBytes(517))
+		wf.M0 = 3
+
+		sp.Prepare(wf, wf)
+
+		Expect(wf.Scratchpad().AsSOP1().SRC0).To(Equal(uint64(517)))
+	})
+
 	It("should prepare for SOP2", func() {
 		inst := insts.NewInst()
 		inst.FormatType = insts.SOP2
@@ -223,6 +238,52 @@ var _ = Describe("ScratchpadPreparer", func() {
 		Expect(layout.EXEC).To(Equal(uint64(0xff)))
 	})
 
+	It("should prepare for global instructions with an SGPR base", func() {
+		inst := insts.NewInst()
+		inst.FormatType = insts.FLAT
+		inst.Seg = insts.FlatSegmentGlobal
+		inst.Addr = insts.NewVRegOperand(0, 0, 1)
+		inst.SAddr = insts.NewSRegOperand(2, 2, 2)
+		inst.Offset = insts.NewIntOperand(0, -8)
+		inst.Data = insts.NewVRegOperand(2, 2, 1)
+		wf.inst = inst
+
+		wf.WriteReg(insts.SReg(2), 2, 0, insts.Uint64ToBytes(0x100000000))
+		for i := 0; i < 64; i++ {
+			wf.WriteReg(insts.VReg(0), 1, i, insts.Uint32ToBytes(uint32(i*4)))
+		}
+		wf.Exec = 0xff
+
+		sp.Prepare(wf, wf)
+
+		layout := wf.Scratchpad().AsFlat()
+		for i := 0; i < 64; i++ {
+			Expect(layout.ADDR[i]).To(Equal(uint64(0x100000000 + i*4 - 8)))
+		}
+	})
+
+	It("should read the destination of the d16 loads", func() {
+		inst := insts.NewInst()
+		inst.FormatType = insts.FLAT
+		inst.Opcode = 36 // flat_load_short_d16
+		inst.Addr = insts.NewVRegOperand(0, 0, 2)
+		inst.Data = insts.NewVRegOperand(2, 2, 0)
+		inst.Dst = insts.NewVRegOperand(3, 3, 0)
+		wf.inst = inst
+
+		for i := 0; i < 64; i++ {
+			wf.WriteReg(insts.VReg(3), 1, i, insts.Uint32ToBytes(uint32(i)))
+		}
+		wf.Exec = 0xff
+
+		sp.Prepare(wf, wf)
+
+		layout := wf.Scratchpad().AsFlat()
+		for i := 0; i < 64; i++ {
+			Expect(layout.DST[i*4]).To(Equal(uint32(i)))
+		}
+	})
+
 	It("should prepare for SMEM", func() {
 		inst := insts.NewInst()
 		inst.FormatType = insts.SMEM
@@ -250,6 +311,26 @@ var _ = Describe("ScratchpadPreparer", func() {
 
 	})
 
+	It("should prepare for SMEM with an SGPR offset", func() {
+		inst := insts.NewInst()
+		inst.FormatType = insts.SMEM
+		inst.Opcode = 0
+		inst.Data = insts.NewSRegOperand(0, 0, 1)
+		inst.Offset = insts.NewIntOperand(0, 4)
+		inst.SOffset = insts.NewSRegOperand(6, 6, 1)
+		inst.Base = insts.NewSRegOperand(4, 4, 2)
+		wf.inst = inst
+
+		wf.WriteReg(insts.SReg(4), 2, 0, insts.Uint64ToBytes(1024))
+		wf.WriteReg(insts.SReg(6), 1, 0, insts.Uint32ToBytes(16))
+
+		sp.Prepare(wf, wf)
+
+		layout := wf.Scratchpad().AsSMEM()
+		Expect(layout.Offset).To(Equal(uint64(20)))
+		Expect(layout.Base).To(Equal(uint64(1024)))
+	})
+
 	It("should prepare for SOPP", func() {
 		inst := insts.NewInst()
 		inst.FormatType = insts.SOPP
@@ -290,6 +371,7 @@ var _ = Describe("ScratchpadPreparer", func() {
 		inst.SImm16 = insts.NewIntOperand(1, 1)
 		wf.inst = inst
 		wf.SCC = 1
+		wf.PC = 160
 		wf.WriteReg(insts.SReg(0), 1, 0, insts.Uint32ToBytes(100))
 
 		sp.Prepare(wf, wf)
@@ -298,6 +380,52 @@ var _ = Describe("ScratchpadPreparer", func() {
 		Expect(layout.DST).To(Equal(uint64(100)))
 		Expect(layout.IMM).To(Equal(uint64(1)))
 		Expect(layout.SCC).To(Equal(byte(1)))
+		Expect(layout.PC).To(Equal(uint64(160)))
+	})
+
+	It("should prepare for s_setreg_imm32_b32", func() {
+		inst := insts.NewInst()
+		inst.FormatType = insts.SOPK
+		inst.Opcode = 20
+		inst.Dst = insts.NewSRegOperand(0, 0, 1)
+		inst.SImm16 = insts.NewIntOperand(0x801, 0x801)
+		inst.Src0 = &insts.Operand{
+			OperandType:     insts.LiteralConstant,
+			LiteralConstant: 3,
+		}
+		wf.inst = inst
+		wf.MODE = 0x2f0
+
+		sp.Prepare(wf, wf)
+
+		layout := wf.Scratchpad().AsSOPK()
+		Expect(layout.SRC0).To(Equal(uint64(3)))
+		Expect(layout.MODE).To(Equal(uint32(0x2f0)))
+	})
+
+	It("should prepare for VOP3P", func() {
+		inst := insts.NewInst()
+		inst.FormatType = insts.VOP3P
+		inst.Opcode = 14
+		inst.Src0 = insts.NewVRegOperand(0, 0, 1)
+		inst.Src1 = insts.NewFloatOperand(242, 1.0)
+		inst.Src2 = insts.NewIntOperand(193, -1)
+		wf.inst = inst
+
+		for i := 0; i < 64; i++ {
+			wf.WriteReg(insts.VReg(0), 1, i, insts.Uint32ToBytes(uint32(i)))
+		}
+		wf.Exec = 0xff
+
+		sp.Prepare(wf, wf)
+
+		layout := wf.Scratchpad().AsVOP3A()
+		for i := 0; i < 64; i++ {
+			Expect(layout.SRC0[i]).To(Equal(uint64(i)))
+			Expect(layout.SRC1[i]).To(Equal(uint64(0x3c003c00)))
+			Expect(layout.SRC2[i]).To(Equal(uint64(0xffffffff)))
+		}
+		Expect(layout.EXEC).To(Equal(uint64(0xff)))
 	})
 
 	It("should prepare for DS", func() {
@@ -352,6 +480,23 @@ var _ = Describe("ScratchpadPreparer", func() {
 		Expect(wf.PC).To(Equal(uint64(20)))
 	})
 
+	It("should commit for s_movreld with the destination moved by M0", func() {
+		inst := insts.NewInst()
+		inst.FormatType = insts.SOP1
+		inst.Opcode = 44
+		inst.Dst = insts.NewSRegOperand(4, 4, 1)
+		wf.inst = inst
+		wf.M0 = 3
+
+		layout := wf.Scratchpad().AsSOP1()
+		layout.DST = 517
+
+		sp.Commit(wf, wf)
+
+		Expect(wf.SRegValue(4)).To(Equal(uint32(0)))
+		Expect(wf.SRegValue(7)).To(Equal(uint32(517)))
+	})
+
 	It("should commit for SOP2", func() {
 		inst := insts.NewInst()
 		inst.FormatType = insts.SOP2
@@ -389,6 +534,29 @@ var _ = Describe("ScratchpadPreparer", func() {
 		Expect(wf.VCC).To(Equal(uint64(0xffff0000ffff0000)))
 	})
 
+	It("should move both registers of v_movrelsd_b32 by M0", func() {
+		inst := insts.NewInst()
+		inst.FormatType = insts.VOP1
+		inst.Opcode = 56
+		inst.Src0 = insts.NewVRegOperand(0, 0, 1)
+		inst.Dst = insts.NewVRegOperand(10, 10, 1)
+		wf.inst = inst
+		wf.M0 = 2
+		wf.Exec = 0x1
+
+		wf.WriteReg(insts.VReg(2), 1, 0, insts.Uint32ToBytes(517))
+
+		sp.Prepare(wf, wf)
+		layout := wf.Scratchpad().AsVOP1()
+		Expect(layout.SRC0[0]).To(Equal(uint64(517)))
+
+		layout.DST[0] = layout.SRC0[0]
+		sp.Commit(wf, wf)
+
+		Expect(wf.VRegValue(0, 10)).To(Equal(uint32(0)))
+		Expect(wf.VRegValue(0, 12)).To(Equal(uint32(517)))
+	})
+
 	It("should commit for VOP2", func() {
 		inst := insts.NewInst()
 		inst.FormatType = insts.VOP2
@@ -543,6 +711,25 @@ var _ = Describe("ScratchpadPreparer", func() {
 		}
 	})
 
+	It("should commit for FLAT atomic only if GLC is set", func() {
+		inst := insts.NewInst()
+		inst.FormatType = insts.FLAT
+		inst.Dst = insts.NewVRegOperand(3, 3, 1)
+		inst.Opcode = 66 // Atomic add
+		wf.inst = inst
+
+		layout := wf.Scratchpad().AsFlat()
+		layout.EXEC = 0x1
+		layout.DST[0] = 42
+
+		sp.Commit(wf, wf)
+		Expect(wf.VRegValue(0, 3)).To(Equal(uint32(0)))
+
+		inst.GlobalLevelCoherent = true
+		sp.Commit(wf, wf)
+		Expect(wf.VRegValue(0, 3)).To(Equal(uint32(42)))
+	})
+
 	It("should commit for SMEM", func() {
 		inst := insts.NewInst()
 		inst.FormatType = insts.SMEM
@@ -599,11 +786,13 @@ var _ = Describe("ScratchpadPreparer", func() {
 		layout := wf.Scratchpad().AsSOPK()
 		layout.SCC = 1
 		layout.DST = 517
+		layout.MODE = 0x3c0
 
 		sp.Commit(wf, wf)
 
 		Expect(wf.SCC).To(Equal(byte(1)))
 		Expect(wf.SRegValue(0)).To(Equal(uint32(517)))
+		Expect(wf.MODE).To(Equal(uint32(0x3c0)))
 	})
 
 	It("should commit for DS", func() {
@@ -1,12 +1,17 @@
 package emu
 
 import (
+	"errors"
 	"log"
 
 	"github.com/sarchlab/akita/v3/mem/mem"
 	"github.com/sarchlab/akita/v3/mem/vm"
 )
 
+// ErrPageNotFound is returned when an accessed address is not mapped in the
+// page table of the process.
+var ErrPageNotFound = errors.New("page not found in page table")
+
 type storageAccessor struct {
 	storage       *mem.Storage
 	addrConverter mem.AddressConverter
@@ -14,7 +19,13 @@ type storageAccessor struct {
 	log2PageSize  uint64
 }
 
-func (a *storageAccessor) Read(pid vm.PID, vAddr, byteSize uint64) []byte {
+// Read returns the data at the virtual address. If part of the address range
+// is not mapped, the bytes of that part are zero and ErrPageNotFound is
+// returned.
+func (a *storageAccessor) Read(
+	pid vm.PID,
+	vAddr, byteSize uint64,
+) ([]byte, error) {
 	data := make([]byte, byteSize)
 	sizeLeft := byteSize
 	offset := uint64(0)
@@ -30,7 +41,7 @@ func (a *storageAccessor) Read(pid vm.PID, vAddr, byteSize uint64) []byte {
 
 		page, found := a.pageTable.Find(pid, currVAddr)
 		if !found {
-			panic("page not found in page table")
+			return data, ErrPageNotFound
 		}
 		pAddr := page.PAddr + (currVAddr - page.VAddr)
 
@@ -50,10 +61,12 @@ func (a *storageAccessor) Read(pid vm.PID, vAddr, byteSize uint64) []byte {
 		sizeLeft -= sizeToRead
 	}
 
-	return data
+	return data, nil
 }
 
-func (a *storageAccessor) Write(pid vm.PID, vAddr uint64, data []byte) {
+// Write stores the data at the virtual address. It stops at the first page
+// that is not mapped and returns ErrPageNotFound.
+func (a *storageAccessor) Write(pid vm.PID, vAddr uint64, data []byte) error {
 	sizeLeft := uint64(len(data))
 	offset := uint64(0)
 
@@ -66,9 +79,9 @@ func (a *storageAccessor) Write(pid vm.PID, vAddr uint64, data []byte) {
 			sizeToWrite = sizeLeft
 		}
 
-		page, found := a.pageTable.Find(pid, vAddr)
+		page, found := a.pageTable.Find(pid, currVAddr)
 		if !found {
-			panic("page not found in page table")
+			return ErrPageNotFound
 		}
 		pAddr := page.PAddr + (currVAddr - page.VAddr)
 
@@ -85,6 +98,8 @@ func (a *storageAccessor) Write(pid vm.PID, vAddr uint64, data []byte) {
 		offset += sizeToWrite
 		sizeLeft -= sizeToWrite
 	}
+
+	return nil
 }
 
 // NewStorageAccessor creates a storageAccessor, injecting dependencies
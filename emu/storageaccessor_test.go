@@ -0,0 +1,54 @@
+package emu
+
+import (
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/mem/vm"
+)
+
+var _ = Describe("Storage Accessor", func() {
+	var (
+		mockCtrl  *gomock.Controller
+		pageTable *MockPageTable
+		storage   *mem.Storage
+		sAccessor *storageAccessor
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		pageTable = NewMockPageTable(mockCtrl)
+		storage = mem.NewStorage(1 * mem.MB)
+		sAccessor = newStorageAccessor(storage, pageTable, 12, nil)
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	It("should write data that crosses pages", func() {
+		pageTable.EXPECT().Find(vm.PID(1), uint64(0x1ffe)).
+			Return(vm.Page{VAddr: 0x1000, PAddr: 0x5000}, true)
+		pageTable.EXPECT().Find(vm.PID(1), uint64(0x2000)).
+			Return(vm.Page{VAddr: 0x2000, PAddr: 0x8000}, true)
+
+		err := sAccessor.Write(vm.PID(1), 0x1ffe, []byte{1, 2, 3, 4})
+
+		Expect(err).To(BeNil())
+		data, _ := storage.Read(0x5ffe, 2)
+		Expect(data).To(Equal([]byte{1, 2}))
+		data, _ = storage.Read(0x8000, 2)
+		Expect(data).To(Equal([]byte{3, 4}))
+	})
+
+	It("should return zeros and an error when reading an unmapped page", func() {
+		pageTable.EXPECT().Find(vm.PID(1), uint64(0x3000)).
+			Return(vm.Page{}, false)
+
+		data, err := sAccessor.Read(vm.PID(1), 0x3000, 4)
+
+		Expect(err).To(MatchError(ErrPageNotFound))
+		Expect(data).To(Equal([]byte{0, 0, 0, 0}))
+	})
+})
@@ -1,6 +1,9 @@
 package emu
 
-import "unsafe"
+import (
+	"math"
+	"unsafe"
+)
 
 func asInt16(bits uint16) int16 {
 	return *((*int16)((unsafe.Pointer(&bits))))
@@ -41,3 +44,92 @@ func float32ToBits(num float32) uint32 {
 func float64ToBits(num float64) uint64 {
 	return *((*uint64)((unsafe.Pointer(&num))))
 }
+
+// clampToInt32 converts a float to an int32, rounding toward zero. Floats out
+// of range saturate and NaN becomes 0.
+func clampToInt32(x float64) int32 {
+	switch {
+	case math.IsNaN(x):
+		return 0
+	case x >= math.MaxInt32:
+		return math.MaxInt32
+	case x <= math.MinInt32:
+		return math.MinInt32
+	}
+
+	return int32(x)
+}
+
+// clampToUint32 converts a float to a uint32, rounding toward zero. Floats
+// out of range saturate and NaN becomes 0.
+func clampToUint32(x float64) uint32 {
+	switch {
+	case math.IsNaN(x), x <= 0:
+		return 0
+	case x >= math.MaxUint32:
+		return math.MaxUint32
+	}
+
+	return uint32(x)
+}
+
+func fract(x float64) float64 {
+	return x - math.Floor(x)
+}
+
+func rsq(x float64) float64 {
+	return 1 / math.Sqrt(x)
+}
+
+// sinRevolutions and cosRevolutions take the angle in revolutions rather than
+// radians, as the hardware does.
+func sinRevolutions(x float64) float64 {
+	return math.Sin(x * 2 * math.Pi)
+}
+
+func cosRevolutions(x float64) float64 {
+	return math.Cos(x * 2 * math.Pi)
+}
+
+// frexpMant returns the mantissa in [0.5, 1) of x. Infinities and NaN are
+// returned unchanged.
+func frexpMant(x float64) float64 {
+	frac, _ := math.Frexp(x)
+	return frac
+}
+
+// frexpExp returns the exponent of x, such that x = frexpMant(x) * 2^exp.
+// Infinities and NaN have an exponent of 0.
+func frexpExp(x float64) int {
+	if math.IsInf(x, 0) || math.IsNaN(x) {
+		return 0
+	}
+
+	_, exp := math.Frexp(x)
+
+	return exp
+}
+
+// minNum and maxNum return the other operand if one of the operands is NaN,
+// as the IEEE 754 minNum and maxNum operations do.
+func minNum(a, b float64) float64 {
+	switch {
+	case math.IsNaN(a):
+		return b
+	case math.IsNaN(b):
+		return a
+	}
+
+	return math.Min(a, b)
+}
+
+func maxNum(a, b float64) float64 {
+	switch {
+	case math.IsNaN(a):
+		return b
+	case math.IsNaN(b):
+		return a
+	}
+
+	return math.Max(a, b)
+}
@@ -24,6 +24,7 @@ type Wavefront struct {
 	SCC      byte
 	VCC      uint64
 	M0       uint32
+	MODE     uint32
 	SRegFile []byte
 	VRegFile []byte
 	LDS      []byte
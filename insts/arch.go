@@ -0,0 +1,106 @@
+package insts
+
+import (
+	"debug/elf"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// Arch is an instruction set architecture that code objects can target.
+type Arch int
+
+// All the supported architectures
+const (
+	// GCN3 is the architecture of the gfx8 GPUs, such as the gfx803 R9 Nano.
+	GCN3 Arch = iota
+
+	// GFX9 is the architecture of the Vega GPUs, such as gfx900 and gfx906.
+	GFX9
+)
+
+func (a Arch) String() string {
+	switch a {
+	case GCN3:
+		return "gcn3"
+	case GFX9:
+		return "gfx9"
+	default:
+		return "unknown"
+	}
+}
+
+// The machine numbers that AMDGPU ELF files record in the lowest byte of the
+// e_flags field (EF_AMDGPU_MACH).
+const (
+	efAMDGPUMachMask   = 0xff
+	efAMDGPUMachGFX801 = 0x28
+	efAMDGPUMachGFX802 = 0x29
+	efAMDGPUMachGFX803 = 0x2a
+	efAMDGPUMachGFX810 = 0x2b
+	efAMDGPUMachGFX900 = 0x2c
+	efAMDGPUMachGFX902 = 0x2d
+	efAMDGPUMachGFX904 = 0x2e
+	efAMDGPUMachGFX906 = 0x2f
+	efAMDGPUMachGFX909 = 0x31
+	efAMDGPUMachGFX90C = 0x32
+)
+
+// ArchFromELFFlags returns the architecture that the e_flags field of an
+// AMDGPU ELF file names. It returns false if the flags do not name a
+// supported GPU, which is also the case for the code objects of version 2,
+// which leave the machine out of the flags.
+func ArchFromELFFlags(flags uint32) (Arch, bool) {
+	switch flags & efAMDGPUMachMask {
+	case efAMDGPUMachGFX801, efAMDGPUMachGFX802,
+		efAMDGPUMachGFX803, efAMDGPUMachGFX810:
+		return GCN3, true
+	case efAMDGPUMachGFX900, efAMDGPUMachGFX902, efAMDGPUMachGFX904,
+		efAMDGPUMachGFX906, efAMDGPUMachGFX909, efAMDGPUMachGFX90C:
+		return GFX9, true
+	}
+
+	return GCN3, false
+}
+
+// archFromMachineVersion returns the architecture that the machine version
+// in the header of a kernel code object names.
+func archFromMachineVersion(major uint16) Arch {
+	if major == 9 {
+		return GFX9
+	}
+
+	return GCN3
+}
+
+// ReadELFFlags reads the e_flags field from the header of an ELF file. The
+// debug/elf package parses the header but does not keep the flags.
+func ReadELFFlags(r io.ReaderAt) (uint32, error) {
+	ident := make([]byte, elf.EI_NIDENT)
+	if _, err := r.ReadAt(ident, 0); err != nil {
+		return 0, err
+	}
+
+	if string(ident[:4]) != elf.ELFMAG {
+		return 0, errors.New("not an ELF file")
+	}
+
+	var byteOrder binary.ByteOrder = binary.LittleEndian
+	if elf.Data(ident[elf.EI_DATA]) == elf.ELFDATA2MSB {
+		byteOrder = binary.BigEndian
+	}
+
+	// The flags follow the entry point and the two table offsets, which are
+	// 4 bytes each in 32-bit files and 8 bytes each in 64-bit files.
+	offset := int64(48)
+	if elf.Class(ident[elf.EI_CLASS]) == elf.ELFCLASS32 {
+		offset = 36
+	}
+
+	flags := make([]byte, 4)
+	if _, err := r.ReadAt(flags, offset); err != nil {
+		return 0, err
+	}
+
+	return byteOrder.Uint32(flags), nil
+}
@@ -0,0 +1,47 @@
+package insts_test
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/mgpusim/v3/insts"
+)
+
+var _ = Describe("Arch", func() {
+	It("should tell the architecture from the ELF flags", func() {
+		arch, ok := insts.ArchFromELFFlags(0x2a) // gfx803
+		Expect(ok).To(BeTrue())
+		Expect(arch).To(Equal(insts.GCN3))
+
+		arch, ok = insts.ArchFromELFFlags(0x12f) // gfx906 with xnack
+		Expect(ok).To(BeTrue())
+		Expect(arch).To(Equal(insts.GFX9))
+	})
+
+	It("should not tell the architecture of version 2 code objects", func() {
+		_, ok := insts.ArchFromELFFlags(0x2)
+
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should read the flags of a 64-bit ELF file", func() {
+		header := make([]byte, 64)
+		copy(header, "\x7fELF")
+		header[4] = 2 // ELFCLASS64
+		header[5] = 1 // ELFDATA2LSB
+		binary.LittleEndian.PutUint32(header[48:], 0x2c)
+
+		flags, err := insts.ReadELFFlags(bytes.NewReader(header))
+
+		Expect(err).To(BeNil())
+		Expect(flags).To(Equal(uint32(0x2c)))
+	})
+
+	It("should not read the flags of other files", func() {
+		_, err := insts.ReadELFFlags(bytes.NewReader(make([]byte, 64)))
+
+		Expect(err).NotTo(BeNil())
+	})
+})
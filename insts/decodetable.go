@@ -46,8 +46,8 @@ func (d *Disassembler) initializeDecodeTable() {
 	d.addInstType(&InstType{"s_bfe_i32", 38, FormatTable[SOP2], 0, ExeUnitScalar, 32, 32, 32, 0, 0})
 	d.addInstType(&InstType{"s_bfe_u64", 39, FormatTable[SOP2], 0, ExeUnitScalar, 32, 32, 32, 0, 0})
 	d.addInstType(&InstType{"s_bfe_i64", 40, FormatTable[SOP2], 0, ExeUnitScalar, 32, 32, 32, 0, 0})
-	d.addInstType(&InstType{"s_cbrahcn_g_fork", 41, FormatTable[SOP2], 0, ExeUnitScalar, 32, 32, 32, 0, 0})
-	d.addInstType(&InstType{"s_absdiss_i32", 42, FormatTable[SOP2], 0, ExeUnitScalar, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"s_cbranch_g_fork", 41, FormatTable[SOP2], 0, ExeUnitScalar, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"s_absdiff_i32", 42, FormatTable[SOP2], 0, ExeUnitScalar, 32, 32, 32, 0, 0})
 	d.addInstType(&InstType{"s_rfe_restore_b64", 43, FormatTable[SOP2], 0, ExeUnitScalar, 32, 32, 32, 0, 0})
 
 	// VOP2 instructions
@@ -108,7 +108,7 @@ func (d *Disassembler) initializeDecodeTable() {
 	d.addInstType(&InstType{"v_nop", 0, FormatTable[VOP1], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
 	d.addInstType(&InstType{"v_mov_b32_e32", 1, FormatTable[VOP1], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
 	d.addInstType(&InstType{"v_readfirstlane_b32", 2, FormatTable[VOP1], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
-	d.addInstType(&InstType{"v_cvt_i32_f64", 3, FormatTable[VOP1], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"v_cvt_i32_f64", 3, FormatTable[VOP1], 0, ExeUnitVALU, 32, 64, 32, 0, 0})
 	d.addInstType(&InstType{"v_cvt_f64_i32_e32", 4, FormatTable[VOP1], 0, ExeUnitVALU, 64, 32, 32, 0, 0})
 	d.addInstType(&InstType{"v_cvt_f32_i32", 5, FormatTable[VOP1], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
 	d.addInstType(&InstType{"v_cvt_f32_u32_e32", 6, FormatTable[VOP1], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
@@ -125,12 +125,12 @@ func (d *Disassembler) initializeDecodeTable() {
 	d.addInstType(&InstType{"v_cvt_f32_ubyte1", 18, FormatTable[VOP1], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
 	d.addInstType(&InstType{"v_cvt_f32_ubyte2", 19, FormatTable[VOP1], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
 	d.addInstType(&InstType{"v_cvt_f32_ubyte3", 20, FormatTable[VOP1], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
-	d.addInstType(&InstType{"v_cvt_u32_f64", 21, FormatTable[VOP1], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
-	d.addInstType(&InstType{"v_cvt_f64_u32", 22, FormatTable[VOP1], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
-	d.addInstType(&InstType{"v_trunc_f64", 23, FormatTable[VOP1], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
-	d.addInstType(&InstType{"v_ceil_f64", 24, FormatTable[VOP1], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
-	d.addInstType(&InstType{"v_rndne_f64", 25, FormatTable[VOP1], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
-	d.addInstType(&InstType{"v_floor_f64", 26, FormatTable[VOP1], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"v_cvt_u32_f64", 21, FormatTable[VOP1], 0, ExeUnitVALU, 32, 64, 32, 0, 0})
+	d.addInstType(&InstType{"v_cvt_f64_u32", 22, FormatTable[VOP1], 0, ExeUnitVALU, 64, 32, 32, 0, 0})
+	d.addInstType(&InstType{"v_trunc_f64", 23, FormatTable[VOP1], 0, ExeUnitVALU, 64, 64, 32, 0, 0})
+	d.addInstType(&InstType{"v_ceil_f64", 24, FormatTable[VOP1], 0, ExeUnitVALU, 64, 64, 32, 0, 0})
+	d.addInstType(&InstType{"v_rndne_f64", 25, FormatTable[VOP1], 0, ExeUnitVALU, 64, 64, 32, 0, 0})
+	d.addInstType(&InstType{"v_floor_f64", 26, FormatTable[VOP1], 0, ExeUnitVALU, 64, 64, 32, 0, 0})
 	d.addInstType(&InstType{"v_fract_f32", 27, FormatTable[VOP1], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
 	d.addInstType(&InstType{"v_trunc_f32_e32", 28, FormatTable[VOP1], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
 	d.addInstType(&InstType{"v_ceil_f32", 29, FormatTable[VOP1], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
@@ -142,9 +142,9 @@ func (d *Disassembler) initializeDecodeTable() {
 	d.addInstType(&InstType{"v_rcp_iflag_f32_e32", 35, FormatTable[VOP1], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
 	d.addInstType(&InstType{"v_rsq_f32_e32", 36, FormatTable[VOP1], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
 	d.addInstType(&InstType{"v_rcp_f64_e32", 37, FormatTable[VOP1], 0, ExeUnitVALU, 64, 64, 32, 0, 0})
-	d.addInstType(&InstType{"v_rsq_f64", 38, FormatTable[VOP1], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"v_rsq_f64", 38, FormatTable[VOP1], 0, ExeUnitVALU, 64, 64, 32, 0, 0})
 	d.addInstType(&InstType{"v_sqrt_f32_e32", 39, FormatTable[VOP1], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
-	d.addInstType(&InstType{"v_sqrt_f64", 40, FormatTable[VOP1], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"v_sqrt_f64", 40, FormatTable[VOP1], 0, ExeUnitVALU, 64, 64, 32, 0, 0})
 	d.addInstType(&InstType{"v_sin_f32", 41, FormatTable[VOP1], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
 	d.addInstType(&InstType{"v_cos_f32", 42, FormatTable[VOP1], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
 	d.addInstType(&InstType{"v_not_b32_e32", 43, FormatTable[VOP1], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
@@ -152,9 +152,9 @@ func (d *Disassembler) initializeDecodeTable() {
 	d.addInstType(&InstType{"v_ffbh_u32_e32", 45, FormatTable[VOP1], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
 	d.addInstType(&InstType{"v_ffbl_b32", 46, FormatTable[VOP1], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
 	d.addInstType(&InstType{"v_ffbh_i32", 47, FormatTable[VOP1], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
-	d.addInstType(&InstType{"v_frexp_exp_i32_f64", 48, FormatTable[VOP1], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
-	d.addInstType(&InstType{"v_frexp_mant_f64", 49, FormatTable[VOP1], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
-	d.addInstType(&InstType{"v_fract_f64", 50, FormatTable[VOP1], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"v_frexp_exp_i32_f64", 48, FormatTable[VOP1], 0, ExeUnitVALU, 32, 64, 32, 0, 0})
+	d.addInstType(&InstType{"v_frexp_mant_f64", 49, FormatTable[VOP1], 0, ExeUnitVALU, 64, 64, 32, 0, 0})
+	d.addInstType(&InstType{"v_fract_f64", 50, FormatTable[VOP1], 0, ExeUnitVALU, 64, 64, 32, 0, 0})
 	d.addInstType(&InstType{"v_frexp_exp_i32_f32", 51, FormatTable[VOP1], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
 	d.addInstType(&InstType{"v_frexp_mant_f32", 52, FormatTable[VOP1], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
 	d.addInstType(&InstType{"v_clrexcp", 53, FormatTable[VOP1], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
@@ -197,32 +197,110 @@ func (d *Disassembler) initializeDecodeTable() {
 	d.addInstType(&InstType{"flat_store_dwordx2", 29, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
 	d.addInstType(&InstType{"flat_store_dwordx3", 30, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
 	d.addInstType(&InstType{"flat_store_dwordx4", 31, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
-	d.addInstType(&InstType{"flat_atomic_swap", 48, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
-	d.addInstType(&InstType{"flat_atomic_cmpswap", 49, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
-	d.addInstType(&InstType{"flat_atomic_add", 50, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
-	d.addInstType(&InstType{"flat_atomic_sub", 51, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
-	d.addInstType(&InstType{"flat_atomic_smin", 53, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
-	d.addInstType(&InstType{"flat_atomic_umin", 54, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
-	d.addInstType(&InstType{"flat_atomic_smax", 55, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
-	d.addInstType(&InstType{"flag_atomic_umax", 56, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
-	d.addInstType(&InstType{"flat_atomic_and", 57, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
-	d.addInstType(&InstType{"flat_atomic_or", 58, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
-	d.addInstType(&InstType{"flat_atomic_xor", 59, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
-	d.addInstType(&InstType{"flat_atomic_inc", 60, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
-	d.addInstType(&InstType{"flat_atomic_dec", 61, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
-	d.addInstType(&InstType{"flat_atomic_swap_x2", 80, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
-	d.addInstType(&InstType{"flat_atomic_cmpswap_x2", 81, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
-	d.addInstType(&InstType{"flat_atomic_add_x2", 82, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
-	d.addInstType(&InstType{"flat_atomic_sub_x2", 83, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
-	d.addInstType(&InstType{"flat_atomic_smin_x2", 85, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
-	d.addInstType(&InstType{"flat_atomic_umin_x2", 86, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
-	d.addInstType(&InstType{"flat_atomic_smax_x2", 87, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
-	d.addInstType(&InstType{"flat_atomic_umax_x2", 88, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
-	d.addInstType(&InstType{"flat_atomic_and_x2", 89, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
-	d.addInstType(&InstType{"flat_atomic_or_x2", 90, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
-	d.addInstType(&InstType{"flat_atomic_xor_x2", 91, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
-	d.addInstType(&InstType{"flat_atomic_inc_x2", 92, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
-	d.addInstType(&InstType{"flat_atomic_dec_x2", 93, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"flat_atomic_swap", 64, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"flat_atomic_cmpswap", 65, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"flat_atomic_add", 66, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"flat_atomic_sub", 67, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"flat_atomic_smin", 68, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"flat_atomic_umin", 69, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"flat_atomic_smax", 70, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"flat_atomic_umax", 71, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"flat_atomic_and", 72, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"flat_atomic_or", 73, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"flat_atomic_xor", 74, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"flat_atomic_inc", 75, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"flat_atomic_dec", 76, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"flat_atomic_swap_x2", 96, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"flat_atomic_cmpswap_x2", 97, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"flat_atomic_add_x2", 98, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"flat_atomic_sub_x2", 99, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"flat_atomic_smin_x2", 100, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"flat_atomic_umin_x2", 101, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"flat_atomic_smax_x2", 102, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"flat_atomic_umax_x2", 103, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"flat_atomic_and_x2", 104, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"flat_atomic_or_x2", 105, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"flat_atomic_xor_x2", 106, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"flat_atomic_inc_x2", 107, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"flat_atomic_dec_x2", 108, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	// MUBUF instructions
+	d.addInstType(&InstType{"buffer_load_format_x", 0, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_load_format_xy", 1, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_load_format_xyz", 2, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_load_format_xyzw", 3, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_store_format_x", 4, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_store_format_xy", 5, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_store_format_xyz", 6, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_store_format_xyzw", 7, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_load_format_d16_x", 8, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_load_format_d16_xy", 9, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_load_format_d16_xyz", 10, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_load_format_d16_xyzw", 11, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_store_format_d16_x", 12, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_store_format_d16_xy", 13, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_store_format_d16_xyz", 14, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_store_format_d16_xyzw", 15, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_load_ubyte", 16, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_load_sbyte", 17, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_load_ushort", 18, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_load_sshort", 19, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_load_dword", 20, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_load_dwordx2", 21, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_load_dwordx3", 22, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_load_dwordx4", 23, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_store_byte", 24, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_store_short", 26, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_store_dword", 28, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_store_dwordx2", 29, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_store_dwordx3", 30, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_store_dwordx4", 31, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_store_lds_dword", 61, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_wbinvl1", 62, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_wbinvl1_vol", 63, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_atomic_swap", 64, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_atomic_cmpswap", 65, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_atomic_add", 66, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_atomic_sub", 67, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_atomic_smin", 68, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_atomic_umin", 69, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_atomic_smax", 70, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_atomic_umax", 71, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_atomic_and", 72, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_atomic_or", 73, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_atomic_xor", 74, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_atomic_inc", 75, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_atomic_dec", 76, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_atomic_swap_x2", 96, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_atomic_cmpswap_x2", 97, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_atomic_add_x2", 98, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_atomic_sub_x2", 99, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_atomic_smin_x2", 100, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_atomic_umin_x2", 101, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_atomic_smax_x2", 102, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_atomic_umax_x2", 103, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_atomic_and_x2", 104, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_atomic_or_x2", 105, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_atomic_xor_x2", 106, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_atomic_inc_x2", 107, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"buffer_atomic_dec_x2", 108, FormatTable[MUBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+
+	// MTBUF instructions
+	d.addInstType(&InstType{"tbuffer_load_format_x", 0, FormatTable[MTBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"tbuffer_load_format_xy", 1, FormatTable[MTBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"tbuffer_load_format_xyz", 2, FormatTable[MTBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"tbuffer_load_format_xyzw", 3, FormatTable[MTBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"tbuffer_store_format_x", 4, FormatTable[MTBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"tbuffer_store_format_xy", 5, FormatTable[MTBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"tbuffer_store_format_xyz", 6, FormatTable[MTBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"tbuffer_store_format_xyzw", 7, FormatTable[MTBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"tbuffer_load_format_d16_x", 8, FormatTable[MTBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"tbuffer_load_format_d16_xy", 9, FormatTable[MTBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"tbuffer_load_format_d16_xyz", 10, FormatTable[MTBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"tbuffer_load_format_d16_xyzw", 11, FormatTable[MTBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"tbuffer_store_format_d16_x", 12, FormatTable[MTBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"tbuffer_store_format_d16_xy", 13, FormatTable[MTBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"tbuffer_store_format_d16_xyz", 14, FormatTable[MTBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"tbuffer_store_format_d16_xyzw", 15, FormatTable[MTBUF], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
 
 	// SMEM instructions
 	d.addInstType(&InstType{"s_load_dword", 0, FormatTable[SMEM], 0, ExeUnitScalar, 32, 32, 32, 0, 0})
@@ -781,7 +859,8 @@ func (d *Disassembler) initializeDecodeTable() {
 	for _, instType := range d.decodeTables[VOP1].insts {
 		d.addInstType(&InstType{instType.InstName,
 			instType.Opcode + Opcode(320),
-			FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
+			FormatTable[VOP3a], 0, ExeUnitVALU,
+			instType.DSTWidth, instType.SRC0Width, 32, 0, 0})
 	}
 	d.addInstType(&InstType{"v_mad_legacy_f32", 448, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
 	d.addInstType(&InstType{"v_mad_f32", 449, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
@@ -820,8 +899,8 @@ func (d *Disassembler) initializeDecodeTable() {
 	d.addInstType(&InstType{"v_div_fmas_f32", 482, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
 	d.addInstType(&InstType{"v_div_fmas_f64", 483, FormatTable[VOP3a], 0, ExeUnitVALU, 64, 64, 64, 64, 0})
 	d.addInstType(&InstType{"v_msad_u8", 484, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
-	d.addInstType(&InstType{"v_qsad_pk_u16_u8", 485, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
-	d.addInstType(&InstType{"v_mqsad_pk_u16_u8", 486, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
+	d.addInstType(&InstType{"v_qsad_pk_u16_u8", 485, FormatTable[VOP3a], 0, ExeUnitVALU, 64, 64, 32, 64, 0})
+	d.addInstType(&InstType{"v_mqsad_pk_u16_u8", 486, FormatTable[VOP3a], 0, ExeUnitVALU, 64, 64, 32, 64, 0})
 	d.addInstType(&InstType{"v_mqsad_u32_u8", 487, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
 	d.addInstType(&InstType{"v_mad_u64_u32", 488, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
 	d.addInstType(&InstType{"v_mad_i64_i32", 489, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
@@ -830,7 +909,7 @@ func (d *Disassembler) initializeDecodeTable() {
 	d.addInstType(&InstType{"v_mad_i16", 492, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
 	d.addInstType(&InstType{"v_perm_b32", 493, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
 	d.addInstType(&InstType{"v_fma_f16", 494, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
-	d.addInstType(&InstType{"v_div_fixup_16", 495, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
+	d.addInstType(&InstType{"v_div_fixup_f16", 495, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
 	d.addInstType(&InstType{"v_cvt_pkaccum_u8_f32", 496, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
 	d.addInstType(&InstType{"v_interp_p1_f32", 624, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
 	d.addInstType(&InstType{"v_interp_p2_f32", 625, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
@@ -840,9 +919,9 @@ func (d *Disassembler) initializeDecodeTable() {
 	d.addInstType(&InstType{"v_interp_p2_f16", 630, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
 	d.addInstType(&InstType{"v_add_f64", 640, FormatTable[VOP3a], 0, ExeUnitVALU, 64, 64, 64, 0, 0})
 	d.addInstType(&InstType{"v_mul_f64", 641, FormatTable[VOP3a], 0, ExeUnitVALU, 64, 64, 64, 0, 0})
-	d.addInstType(&InstType{"v_min_f64_e64", 642, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
-	d.addInstType(&InstType{"v_max_f64_e64", 643, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
-	d.addInstType(&InstType{"v_ldexp_f64", 644, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
+	d.addInstType(&InstType{"v_min_f64_e64", 642, FormatTable[VOP3a], 0, ExeUnitVALU, 64, 64, 64, 0, 0})
+	d.addInstType(&InstType{"v_max_f64_e64", 643, FormatTable[VOP3a], 0, ExeUnitVALU, 64, 64, 64, 0, 0})
+	d.addInstType(&InstType{"v_ldexp_f64", 644, FormatTable[VOP3a], 0, ExeUnitVALU, 64, 64, 32, 0, 0})
 	d.addInstType(&InstType{"v_mul_lo_u32", 645, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
 	d.addInstType(&InstType{"v_mul_hi_u32", 646, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
 	d.addInstType(&InstType{"v_mul_hi_i32", 647, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
@@ -855,7 +934,7 @@ func (d *Disassembler) initializeDecodeTable() {
 	d.addInstType(&InstType{"v_lshlrev_b64", 655, FormatTable[VOP3a], 0, ExeUnitVALU, 64, 64, 64, 0, 0})
 	d.addInstType(&InstType{"v_lshrrev_b64", 656, FormatTable[VOP3a], 0, ExeUnitVALU, 64, 64, 64, 0, 0})
 	d.addInstType(&InstType{"v_ashrrev_i64", 657, FormatTable[VOP3a], 0, ExeUnitVALU, 64, 64, 64, 0, 0})
-	d.addInstType(&InstType{"v_trig_preop_f64", 658, FormatTable[VOP3a], 0, ExeUnitVALU, 64, 64, 64, 0, 0})
+	d.addInstType(&InstType{"v_trig_preop_f64", 658, FormatTable[VOP3a], 0, ExeUnitVALU, 64, 64, 32, 0, 0})
 	d.addInstType(&InstType{"v_bfm_b32", 659, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
 	d.addInstType(&InstType{"v_cvt_pknorm_i16_f32", 660, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
 	d.addInstType(&InstType{"v_cvt_pknorm_u16_f32", 661, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
@@ -893,8 +972,8 @@ func (d *Disassembler) initializeDecodeTable() {
 	d.addInstType(&InstType{"s_bitset1_b32", 26, FormatTable[SOP1], 0, ExeUnitScalar, 32, 32, 0, 0, 0})
 	d.addInstType(&InstType{"s_bitset1_b64", 27, FormatTable[SOP1], 0, ExeUnitScalar, 64, 64, 0, 0, 0})
 	d.addInstType(&InstType{"s_getpc_b64", 28, FormatTable[SOP1], 0, ExeUnitScalar, 64, 64, 0, 0, 0})
-	d.addInstType(&InstType{"s_setpc_b64", 29, FormatTable[SOP1], 0, ExeUnitScalar, 64, 64, 0, 0, 0})
-	d.addInstType(&InstType{"s_swappc_b64", 30, FormatTable[SOP1], 0, ExeUnitScalar, 64, 64, 0, 0, 0})
+	d.addInstType(&InstType{"s_setpc_b64", 29, FormatTable[SOP1], 0, ExeUnitBranch, 64, 64, 0, 0, 0})
+	d.addInstType(&InstType{"s_swappc_b64", 30, FormatTable[SOP1], 0, ExeUnitBranch, 64, 64, 0, 0, 0})
 	d.addInstType(&InstType{"s_rfe_b64", 31, FormatTable[SOP1], 0, ExeUnitScalar, 64, 64, 0, 0, 0})
 	d.addInstType(&InstType{"s_and_saveexec_b64", 32, FormatTable[SOP1], 0, ExeUnitScalar, 64, 64, 0, 0, 0})
 	d.addInstType(&InstType{"s_or_saveexec_b64", 33, FormatTable[SOP1], 0, ExeUnitScalar, 64, 64, 0, 0, 0})
@@ -914,50 +993,50 @@ func (d *Disassembler) initializeDecodeTable() {
 	d.addInstType(&InstType{"s_abs_i32", 48, FormatTable[SOP1], 0, ExeUnitScalar, 32, 32, 0, 0, 0})
 	d.addInstType(&InstType{"s_set_gpr_idx_idx", 49, FormatTable[SOP1], 0, ExeUnitScalar, 32, 32, 0, 0, 0})
 
-	d.addInstType(&InstType{"ds_add_u32", 0, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_sub_u32", 1, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_rsub_u32", 2, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_inc_u32", 3, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_dec_u32", 4, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_min_i32", 5, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_max_i32", 6, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_min_u32", 7, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_max_u32", 8, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_and_b32", 9, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_or_b32", 10, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_xor_b32", 11, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_mskor_b32", 12, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
+	d.addInstType(&InstType{"ds_add_u32", 0, FormatTable[DS], 0, ExeUnitLDS, 0, 32, 0, 0, 0})
+	d.addInstType(&InstType{"ds_sub_u32", 1, FormatTable[DS], 0, ExeUnitLDS, 0, 32, 0, 0, 0})
+	d.addInstType(&InstType{"ds_rsub_u32", 2, FormatTable[DS], 0, ExeUnitLDS, 0, 32, 0, 0, 0})
+	d.addInstType(&InstType{"ds_inc_u32", 3, FormatTable[DS], 0, ExeUnitLDS, 0, 32, 0, 0, 0})
+	d.addInstType(&InstType{"ds_dec_u32", 4, FormatTable[DS], 0, ExeUnitLDS, 0, 32, 0, 0, 0})
+	d.addInstType(&InstType{"ds_min_i32", 5, FormatTable[DS], 0, ExeUnitLDS, 0, 32, 0, 0, 0})
+	d.addInstType(&InstType{"ds_max_i32", 6, FormatTable[DS], 0, ExeUnitLDS, 0, 32, 0, 0, 0})
+	d.addInstType(&InstType{"ds_min_u32", 7, FormatTable[DS], 0, ExeUnitLDS, 0, 32, 0, 0, 0})
+	d.addInstType(&InstType{"ds_max_u32", 8, FormatTable[DS], 0, ExeUnitLDS, 0, 32, 0, 0, 0})
+	d.addInstType(&InstType{"ds_and_b32", 9, FormatTable[DS], 0, ExeUnitLDS, 0, 32, 0, 0, 0})
+	d.addInstType(&InstType{"ds_or_b32", 10, FormatTable[DS], 0, ExeUnitLDS, 0, 32, 0, 0, 0})
+	d.addInstType(&InstType{"ds_xor_b32", 11, FormatTable[DS], 0, ExeUnitLDS, 0, 32, 0, 0, 0})
+	d.addInstType(&InstType{"ds_mskor_b32", 12, FormatTable[DS], 0, ExeUnitLDS, 0, 32, 32, 0, 0})
 	d.addInstType(&InstType{"ds_write_b32", 13, FormatTable[DS], 0, ExeUnitLDS, 0, 32, 0, 0, 0})
 	d.addInstType(&InstType{"ds_write2_b32", 14, FormatTable[DS], 0, ExeUnitLDS, 0, 32, 32, 0, 0})
 	d.addInstType(&InstType{"ds_write2st64_b32", 15, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_cmpst_b32", 16, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_cmpst_f32", 17, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_min_f32", 18, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_max_f32", 19, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
+	d.addInstType(&InstType{"ds_cmpst_b32", 16, FormatTable[DS], 0, ExeUnitLDS, 0, 32, 32, 0, 0})
+	d.addInstType(&InstType{"ds_cmpst_f32", 17, FormatTable[DS], 0, ExeUnitLDS, 0, 32, 32, 0, 0})
+	d.addInstType(&InstType{"ds_min_f32", 18, FormatTable[DS], 0, ExeUnitLDS, 0, 32, 0, 0, 0})
+	d.addInstType(&InstType{"ds_max_f32", 19, FormatTable[DS], 0, ExeUnitLDS, 0, 32, 0, 0, 0})
 	d.addInstType(&InstType{"ds_nop ", 20, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_add_f32", 21, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
+	d.addInstType(&InstType{"ds_add_f32", 21, FormatTable[DS], 0, ExeUnitLDS, 0, 32, 0, 0, 0})
 	d.addInstType(&InstType{"ds_write_b8", 30, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
 	d.addInstType(&InstType{"ds_write_b16", 31, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_add_rtn_u32", 32, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_sub_rtn_u32", 33, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_rsub_rtn_u32", 34, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_inc_rtn_u32", 35, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_dec_rtn_u32", 36, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_min_rtn_i32", 37, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_max_rtn_i32", 38, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_min_rtn_u32", 39, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_max_rtn_u32", 40, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_and_rtn_b32", 41, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_or_rtn_b32", 42, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_xor_rtn_b32", 43, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_mskor_rtn_b32", 44, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_wrxchg_rtn_b32", 45, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
+	d.addInstType(&InstType{"ds_add_rtn_u32", 32, FormatTable[DS], 0, ExeUnitLDS, 32, 32, 0, 0, 0})
+	d.addInstType(&InstType{"ds_sub_rtn_u32", 33, FormatTable[DS], 0, ExeUnitLDS, 32, 32, 0, 0, 0})
+	d.addInstType(&InstType{"ds_rsub_rtn_u32", 34, FormatTable[DS], 0, ExeUnitLDS, 32, 32, 0, 0, 0})
+	d.addInstType(&InstType{"ds_inc_rtn_u32", 35, FormatTable[DS], 0, ExeUnitLDS, 32, 32, 0, 0, 0})
+	d.addInstType(&InstType{"ds_dec_rtn_u32", 36, FormatTable[DS], 0, ExeUnitLDS, 32, 32, 0, 0, 0})
+	d.addInstType(&InstType{"ds_min_rtn_i32", 37, FormatTable[DS], 0, ExeUnitLDS, 32, 32, 0, 0, 0})
+	d.addInstType(&InstType{"ds_max_rtn_i32", 38, FormatTable[DS], 0, ExeUnitLDS, 32, 32, 0, 0, 0})
+	d.addInstType(&InstType{"ds_min_rtn_u32", 39, FormatTable[DS], 0, ExeUnitLDS, 32, 32, 0, 0, 0})
+	d.addInstType(&InstType{"ds_max_rtn_u32", 40, FormatTable[DS], 0, ExeUnitLDS, 32, 32, 0, 0, 0})
+	d.addInstType(&InstType{"ds_and_rtn_b32", 41, FormatTable[DS], 0, ExeUnitLDS, 32, 32, 0, 0, 0})
+	d.addInstType(&InstType{"ds_or_rtn_b32", 42, FormatTable[DS], 0, ExeUnitLDS, 32, 32, 0, 0, 0})
+	d.addInstType(&InstType{"ds_xor_rtn_b32", 43, FormatTable[DS], 0, ExeUnitLDS, 32, 32, 0, 0, 0})
+	d.addInstType(&InstType{"ds_mskor_rtn_b32", 44, FormatTable[DS], 0, ExeUnitLDS, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"ds_wrxchg_rtn_b32", 45, FormatTable[DS], 0, ExeUnitLDS, 32, 32, 0, 0, 0})
 	d.addInstType(&InstType{"ds_wrxchg2_rtn_b32", 46, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
 	d.addInstType(&InstType{"ds_wrxchg2st64_rtn_b32", 47, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_cmpst_rtn_b32", 48, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_cmpst_rtn_f32", 49, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_min_rtn_f32", 50, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_max_rtn_f32", 51, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
+	d.addInstType(&InstType{"ds_cmpst_rtn_b32", 48, FormatTable[DS], 0, ExeUnitLDS, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"ds_cmpst_rtn_f32", 49, FormatTable[DS], 0, ExeUnitLDS, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"ds_min_rtn_f32", 50, FormatTable[DS], 0, ExeUnitLDS, 32, 32, 0, 0, 0})
+	d.addInstType(&InstType{"ds_max_rtn_f32", 51, FormatTable[DS], 0, ExeUnitLDS, 32, 32, 0, 0, 0})
 	d.addInstType(&InstType{"ds_wrap_rtn_b32", 52, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
 	d.addInstType(&InstType{"ds_read_b32", 54, FormatTable[DS], 0, ExeUnitLDS, 32, 0, 0, 0, 0})
 	d.addInstType(&InstType{"ds_read2_b32", 55, FormatTable[DS], 0, ExeUnitLDS, 64, 0, 0, 0, 0})
@@ -969,46 +1048,46 @@ func (d *Disassembler) initializeDecodeTable() {
 	d.addInstType(&InstType{"ds_swizzle_b32", 61, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
 	d.addInstType(&InstType{"ds_permute_b32", 62, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
 	d.addInstType(&InstType{"ds_bpermute_b32", 63, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_add_u64", 64, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_sub_u64", 65, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_rsub_u64", 66, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_inc_u64", 67, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_dec_u64", 68, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_min_i64", 69, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_max_i64", 70, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_min_u64", 71, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_max_u64", 72, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_and_b64", 73, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_or_b64", 74, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_xor_b64", 75, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_mskor_b64", 76, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
+	d.addInstType(&InstType{"ds_add_u64", 64, FormatTable[DS], 0, ExeUnitLDS, 0, 64, 0, 0, 0})
+	d.addInstType(&InstType{"ds_sub_u64", 65, FormatTable[DS], 0, ExeUnitLDS, 0, 64, 0, 0, 0})
+	d.addInstType(&InstType{"ds_rsub_u64", 66, FormatTable[DS], 0, ExeUnitLDS, 0, 64, 0, 0, 0})
+	d.addInstType(&InstType{"ds_inc_u64", 67, FormatTable[DS], 0, ExeUnitLDS, 0, 64, 0, 0, 0})
+	d.addInstType(&InstType{"ds_dec_u64", 68, FormatTable[DS], 0, ExeUnitLDS, 0, 64, 0, 0, 0})
+	d.addInstType(&InstType{"ds_min_i64", 69, FormatTable[DS], 0, ExeUnitLDS, 0, 64, 0, 0, 0})
+	d.addInstType(&InstType{"ds_max_i64", 70, FormatTable[DS], 0, ExeUnitLDS, 0, 64, 0, 0, 0})
+	d.addInstType(&InstType{"ds_min_u64", 71, FormatTable[DS], 0, ExeUnitLDS, 0, 64, 0, 0, 0})
+	d.addInstType(&InstType{"ds_max_u64", 72, FormatTable[DS], 0, ExeUnitLDS, 0, 64, 0, 0, 0})
+	d.addInstType(&InstType{"ds_and_b64", 73, FormatTable[DS], 0, ExeUnitLDS, 0, 64, 0, 0, 0})
+	d.addInstType(&InstType{"ds_or_b64", 74, FormatTable[DS], 0, ExeUnitLDS, 0, 64, 0, 0, 0})
+	d.addInstType(&InstType{"ds_xor_b64", 75, FormatTable[DS], 0, ExeUnitLDS, 0, 64, 0, 0, 0})
+	d.addInstType(&InstType{"ds_mskor_b64", 76, FormatTable[DS], 0, ExeUnitLDS, 0, 64, 64, 0, 0})
 	d.addInstType(&InstType{"ds_write_b64", 77, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
 	d.addInstType(&InstType{"ds_write2_b64", 78, FormatTable[DS], 0, ExeUnitLDS, 0, 64, 64, 0, 0})
 	d.addInstType(&InstType{"ds_write2st64_b64", 79, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_cmpst_b64", 80, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_cmpst_f64", 81, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_min_f64", 82, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_max_f64", 83, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_add_rtn_u64", 96, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_sub_rtn_u64", 97, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_rsub_rtn_u64", 98, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_inc_rtn_u64", 99, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_dec_rtn_u64", 100, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_min_rtn_i64", 101, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_max_rtn_i64", 102, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_min_rtn_u64", 103, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_max_rtn_u64", 104, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_and_rtn_b64", 105, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_or_rtn_b64", 106, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_xor_rtn_b64", 107, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_mskor_rtn_b64", 108, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_wrxchg_rtn_b64", 109, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
+	d.addInstType(&InstType{"ds_cmpst_b64", 80, FormatTable[DS], 0, ExeUnitLDS, 0, 64, 64, 0, 0})
+	d.addInstType(&InstType{"ds_cmpst_f64", 81, FormatTable[DS], 0, ExeUnitLDS, 0, 64, 64, 0, 0})
+	d.addInstType(&InstType{"ds_min_f64", 82, FormatTable[DS], 0, ExeUnitLDS, 0, 64, 0, 0, 0})
+	d.addInstType(&InstType{"ds_max_f64", 83, FormatTable[DS], 0, ExeUnitLDS, 0, 64, 0, 0, 0})
+	d.addInstType(&InstType{"ds_add_rtn_u64", 96, FormatTable[DS], 0, ExeUnitLDS, 64, 64, 0, 0, 0})
+	d.addInstType(&InstType{"ds_sub_rtn_u64", 97, FormatTable[DS], 0, ExeUnitLDS, 64, 64, 0, 0, 0})
+	d.addInstType(&InstType{"ds_rsub_rtn_u64", 98, FormatTable[DS], 0, ExeUnitLDS, 64, 64, 0, 0, 0})
+	d.addInstType(&InstType{"ds_inc_rtn_u64", 99, FormatTable[DS], 0, ExeUnitLDS, 64, 64, 0, 0, 0})
+	d.addInstType(&InstType{"ds_dec_rtn_u64", 100, FormatTable[DS], 0, ExeUnitLDS, 64, 64, 0, 0, 0})
+	d.addInstType(&InstType{"ds_min_rtn_i64", 101, FormatTable[DS], 0, ExeUnitLDS, 64, 64, 0, 0, 0})
+	d.addInstType(&InstType{"ds_max_rtn_i64", 102, FormatTable[DS], 0, ExeUnitLDS, 64, 64, 0, 0, 0})
+	d.addInstType(&InstType{"ds_min_rtn_u64", 103, FormatTable[DS], 0, ExeUnitLDS, 64, 64, 0, 0, 0})
+	d.addInstType(&InstType{"ds_max_rtn_u64", 104, FormatTable[DS], 0, ExeUnitLDS, 64, 64, 0, 0, 0})
+	d.addInstType(&InstType{"ds_and_rtn_b64", 105, FormatTable[DS], 0, ExeUnitLDS, 64, 64, 0, 0, 0})
+	d.addInstType(&InstType{"ds_or_rtn_b64", 106, FormatTable[DS], 0, ExeUnitLDS, 64, 64, 0, 0, 0})
+	d.addInstType(&InstType{"ds_xor_rtn_b64", 107, FormatTable[DS], 0, ExeUnitLDS, 64, 64, 0, 0, 0})
+	d.addInstType(&InstType{"ds_mskor_rtn_b64", 108, FormatTable[DS], 0, ExeUnitLDS, 64, 64, 64, 0, 0})
+	d.addInstType(&InstType{"ds_wrxchg_rtn_b64", 109, FormatTable[DS], 0, ExeUnitLDS, 64, 64, 0, 0, 0})
 	d.addInstType(&InstType{"ds_wrxchg2_rtn_b64", 110, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
 	d.addInstType(&InstType{"ds_wrxchg2st64_rtn_b64", 111, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_cmpst_rtn_b64", 112, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_cmpst_rtn_f64", 113, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_min_rtn_f64", 114, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
-	d.addInstType(&InstType{"ds_max_rtn_f64", 115, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
+	d.addInstType(&InstType{"ds_cmpst_rtn_b64", 112, FormatTable[DS], 0, ExeUnitLDS, 64, 64, 64, 0, 0})
+	d.addInstType(&InstType{"ds_cmpst_rtn_f64", 113, FormatTable[DS], 0, ExeUnitLDS, 64, 64, 64, 0, 0})
+	d.addInstType(&InstType{"ds_min_rtn_f64", 114, FormatTable[DS], 0, ExeUnitLDS, 64, 64, 0, 0, 0})
+	d.addInstType(&InstType{"ds_max_rtn_f64", 115, FormatTable[DS], 0, ExeUnitLDS, 64, 64, 0, 0, 0})
 	d.addInstType(&InstType{"ds_read_b64", 118, FormatTable[DS], 0, ExeUnitLDS, 64, 0, 0, 0, 0})
 	d.addInstType(&InstType{"ds_read2_b64", 119, FormatTable[DS], 0, ExeUnitLDS, 128, 0, 0, 0, 0})
 	d.addInstType(&InstType{"ds_read2st64_b64", 120, FormatTable[DS], 0, ExeUnitLDS, 0, 0, 0, 0, 0})
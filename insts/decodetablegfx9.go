@@ -0,0 +1,173 @@
+package insts
+
+import "strings"
+
+// initializeGFX9DecodeTable changes the GCN3 decode table into the GFX9 one.
+// GFX9 keeps almost all the GCN3 opcodes, renames a few of them, and adds the
+// VOP3P format and the global and scratch segments of the FLAT format.
+//
+//nolint:funlen
+func (d *Disassembler) initializeGFX9DecodeTable() {
+	// SOP2 instructions
+	d.addInstType(&InstType{"s_mul_hi_u32", 44, FormatTable[SOP2], 0, ExeUnitScalar, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"s_mul_hi_i32", 45, FormatTable[SOP2], 0, ExeUnitScalar, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"s_lshl1_add_u32", 46, FormatTable[SOP2], 0, ExeUnitScalar, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"s_lshl2_add_u32", 47, FormatTable[SOP2], 0, ExeUnitScalar, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"s_lshl3_add_u32", 48, FormatTable[SOP2], 0, ExeUnitScalar, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"s_lshl4_add_u32", 49, FormatTable[SOP2], 0, ExeUnitScalar, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"s_pack_ll_b32_b16", 50, FormatTable[SOP2], 0, ExeUnitScalar, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"s_pack_lh_b32_b16", 51, FormatTable[SOP2], 0, ExeUnitScalar, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"s_pack_hh_b32_b16", 52, FormatTable[SOP2], 0, ExeUnitScalar, 32, 32, 32, 0, 0})
+
+	// SOP1 instructions
+	d.addInstType(&InstType{"s_andn1_saveexec_b64", 51, FormatTable[SOP1], 0, ExeUnitScalar, 64, 64, 0, 0, 0})
+	d.addInstType(&InstType{"s_orn1_saveexec_b64", 52, FormatTable[SOP1], 0, ExeUnitScalar, 64, 64, 0, 0, 0})
+	d.addInstType(&InstType{"s_andn1_wrexec_b64", 53, FormatTable[SOP1], 0, ExeUnitScalar, 64, 64, 0, 0, 0})
+	d.addInstType(&InstType{"s_andn2_wrexec_b64", 54, FormatTable[SOP1], 0, ExeUnitScalar, 64, 64, 0, 0, 0})
+	d.addInstType(&InstType{"s_bitreplicate_b64_b32", 55, FormatTable[SOP1], 0, ExeUnitScalar, 64, 32, 0, 0, 0})
+
+	// SOPK instructions
+	d.addInstType(&InstType{"s_call_b64", 21, FormatTable[SOPK], 0, ExeUnitBranch, 64, 0, 0, 0, 0})
+
+	// SMEM instructions
+	d.addInstType(&InstType{"s_scratch_load_dword", 5, FormatTable[SMEM], 0, ExeUnitScalar, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"s_scratch_load_dwordx2", 6, FormatTable[SMEM], 0, ExeUnitScalar, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"s_scratch_load_dwordx4", 7, FormatTable[SMEM], 0, ExeUnitScalar, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"s_scratch_store_dword", 21, FormatTable[SMEM], 0, ExeUnitScalar, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"s_scratch_store_dwordx2", 22, FormatTable[SMEM], 0, ExeUnitScalar, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"s_scratch_store_dwordx4", 23, FormatTable[SMEM], 0, ExeUnitScalar, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"s_dcache_discard", 40, FormatTable[SMEM], 0, ExeUnitScalar, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"s_dcache_discard_x2", 41, FormatTable[SMEM], 0, ExeUnitScalar, 32, 32, 32, 0, 0})
+
+	// VOP2 instructions, the ones that write a carry are renamed
+	d.addInstType(&InstType{"v_add_co_u32_e32", 25, FormatTable[VOP2], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"v_sub_co_u32_e32", 26, FormatTable[VOP2], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"v_subrev_co_u32_e32", 27, FormatTable[VOP2], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"v_addc_co_u32_e32", 28, FormatTable[VOP2], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"v_subb_co_u32_e32", 29, FormatTable[VOP2], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"v_subbrev_co_u32", 30, FormatTable[VOP2], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"v_add_u32_e32", 52, FormatTable[VOP2], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"v_sub_u32_e32", 53, FormatTable[VOP2], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"v_subrev_u32_e32", 54, FormatTable[VOP2], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
+
+	// VOP3b instructions promoted from VOP2
+	d.addInstType(&InstType{"v_add_co_u32_e64", 25 + 256, FormatTable[VOP3b], 0, ExeUnitVALU, 32, 32, 32, 64, 64})
+	d.addInstType(&InstType{"v_sub_co_u32_e64", 26 + 256, FormatTable[VOP3b], 0, ExeUnitVALU, 32, 32, 32, 0, 64})
+	d.addInstType(&InstType{"v_subrev_co_u32_e64", 27 + 256, FormatTable[VOP3b], 0, ExeUnitVALU, 32, 32, 0, 0, 64})
+	d.addInstType(&InstType{"v_addc_co_u32_e64", 28 + 256, FormatTable[VOP3b], 0, ExeUnitVALU, 32, 32, 32, 64, 64})
+	d.addInstType(&InstType{"v_subb_co_u32_e64", 29 + 256, FormatTable[VOP3b], 0, ExeUnitVALU, 32, 32, 32, 0, 64})
+	d.addInstType(&InstType{"v_subbrev_co_u32_e64", 30 + 256, FormatTable[VOP3b], 0, ExeUnitVALU, 32, 32, 32, 64, 64})
+
+	// VOP3a instructions promoted from VOP2
+	d.addInstType(&InstType{"v_add_u32_e64", 52 + 256, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"v_sub_u32_e64", 53 + 256, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"v_subrev_u32_e64", 54 + 256, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
+
+	// VOP3a instructions, the GCN3 16-bit multiply-adds become the legacy ones
+	d.addInstType(&InstType{"v_mad_legacy_f16", 490, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
+	d.addInstType(&InstType{"v_mad_legacy_u16", 491, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
+	d.addInstType(&InstType{"v_mad_legacy_i16", 492, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
+	d.addInstType(&InstType{"v_fma_legacy_f16", 494, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
+	d.addInstType(&InstType{"v_div_fixup_legacy_f16", 495, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
+	d.addInstType(&InstType{"v_mad_u32_u16", 497, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
+	d.addInstType(&InstType{"v_mad_i32_i16", 498, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
+	d.addInstType(&InstType{"v_xad_u32", 499, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
+	d.addInstType(&InstType{"v_min3_f16", 500, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
+	d.addInstType(&InstType{"v_min3_i16", 501, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
+	d.addInstType(&InstType{"v_min3_u16", 502, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
+	d.addInstType(&InstType{"v_max3_f16", 503, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
+	d.addInstType(&InstType{"v_max3_i16", 504, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
+	d.addInstType(&InstType{"v_max3_u16", 505, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
+	d.addInstType(&InstType{"v_med3_f16", 506, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
+	d.addInstType(&InstType{"v_med3_i16", 507, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
+	d.addInstType(&InstType{"v_med3_u16", 508, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
+	d.addInstType(&InstType{"v_lshl_add_u32", 509, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
+	d.addInstType(&InstType{"v_add_lshl_u32", 510, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
+	d.addInstType(&InstType{"v_add3_u32", 511, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
+	d.addInstType(&InstType{"v_lshl_or_b32", 512, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
+	d.addInstType(&InstType{"v_and_or_b32", 513, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
+	d.addInstType(&InstType{"v_or3_b32", 514, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
+	d.addInstType(&InstType{"v_mad_f16", 515, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
+	d.addInstType(&InstType{"v_mad_u16", 516, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
+	d.addInstType(&InstType{"v_mad_i16", 517, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
+	d.addInstType(&InstType{"v_fma_f16", 518, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
+	d.addInstType(&InstType{"v_div_fixup_f16", 519, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
+	d.addInstType(&InstType{"v_add_i32", 668, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"v_sub_i32", 669, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"v_add_i16", 670, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"v_sub_i16", 671, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"v_pack_b32_f16", 672, FormatTable[VOP3a], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
+
+	// VOP3P instructions
+	d.addInstType(&InstType{"v_pk_mad_i16", 0, FormatTable[VOP3P], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
+	d.addInstType(&InstType{"v_pk_mul_lo_u16", 1, FormatTable[VOP3P], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"v_pk_add_i16", 2, FormatTable[VOP3P], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"v_pk_sub_i16", 3, FormatTable[VOP3P], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"v_pk_lshlrev_b16", 4, FormatTable[VOP3P], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"v_pk_lshrrev_b16", 5, FormatTable[VOP3P], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"v_pk_ashrrev_i16", 6, FormatTable[VOP3P], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"v_pk_max_i16", 7, FormatTable[VOP3P], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"v_pk_min_i16", 8, FormatTable[VOP3P], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"v_pk_mad_u16", 9, FormatTable[VOP3P], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
+	d.addInstType(&InstType{"v_pk_add_u16", 10, FormatTable[VOP3P], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"v_pk_sub_u16", 11, FormatTable[VOP3P], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"v_pk_max_u16", 12, FormatTable[VOP3P], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"v_pk_min_u16", 13, FormatTable[VOP3P], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"v_pk_fma_f16", 14, FormatTable[VOP3P], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
+	d.addInstType(&InstType{"v_pk_add_f16", 15, FormatTable[VOP3P], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"v_pk_mul_f16", 16, FormatTable[VOP3P], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"v_pk_min_f16", 17, FormatTable[VOP3P], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"v_pk_max_f16", 18, FormatTable[VOP3P], 0, ExeUnitVALU, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"v_mad_mix_f32", 32, FormatTable[VOP3P], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
+	d.addInstType(&InstType{"v_mad_mixlo_f16", 33, FormatTable[VOP3P], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
+	d.addInstType(&InstType{"v_mad_mixhi_f16", 34, FormatTable[VOP3P], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
+	d.addInstType(&InstType{"v_dot2_f32_f16", 35, FormatTable[VOP3P], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
+	d.addInstType(&InstType{"v_dot2_i32_i16", 36, FormatTable[VOP3P], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
+	d.addInstType(&InstType{"v_dot2_u32_u16", 37, FormatTable[VOP3P], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
+	d.addInstType(&InstType{"v_dot4_i32_i8", 38, FormatTable[VOP3P], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
+	d.addInstType(&InstType{"v_dot4_u32_u8", 39, FormatTable[VOP3P], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
+	d.addInstType(&InstType{"v_dot8_i32_i4", 40, FormatTable[VOP3P], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
+	d.addInstType(&InstType{"v_dot8_u32_u4", 41, FormatTable[VOP3P], 0, ExeUnitVALU, 32, 32, 32, 32, 0})
+
+	// FLAT instructions
+	d.addInstType(&InstType{"flat_store_byte_d16_hi", 25, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"flat_store_short_d16_hi", 27, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"flat_load_ubyte_d16", 32, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"flat_load_ubyte_d16_hi", 33, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"flat_load_sbyte_d16", 34, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"flat_load_sbyte_d16_hi", 35, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"flat_load_short_d16", 36, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+	d.addInstType(&InstType{"flat_load_short_d16_hi", 37, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
+
+	d.initializeFlatSegmentTables()
+}
+
+// initializeFlatSegmentTables derives the instructions of the global and the
+// scratch segments from the FLAT instructions, which share the opcodes. The
+// scratch segment has no atomic instructions.
+func (d *Disassembler) initializeFlatSegmentTables() {
+	d.flatSegmentTables = map[FlatSegment]*decodeTable{
+		FlatSegmentGlobal:  newDecodeTable(),
+		FlatSegmentScratch: newDecodeTable(),
+	}
+
+	prefixes := map[FlatSegment]string{
+		FlatSegmentGlobal:  "global_",
+		FlatSegmentScratch: "scratch_",
+	}
+
+	for _, flatType := range d.InstTypes(FLAT) {
+		for _, seg := range []FlatSegment{FlatSegmentGlobal, FlatSegmentScratch} {
+			if seg == FlatSegmentScratch && flatType.Opcode >= 64 {
+				continue
+			}
+
+			t := *flatType
+			t.InstName = strings.Replace(t.InstName, "flat_", prefixes[seg], 1)
+			t.ID = d.nextInstID
+			d.nextInstID++
+
+			d.flatSegmentTables[seg].insts[t.Opcode] = &t
+		}
+	}
+}
@@ -42,11 +42,20 @@ func newDecodeTable() *decodeTable {
 
 // Disassembler is the unit that can decode .hsaco file
 type Disassembler struct {
+	arch       Arch
 	formatList []*Format
 
 	// Maps from the format to table
 	decodeTables map[FormatType]*decodeTable
 	nextInstID   int
+
+	// The tables of the FLAT instructions that access the global and the
+	// scratch segments, which only GFX9 has.
+	flatSegmentTables map[FlatSegment]*decodeTable
+
+	// The disassemblers of all the architectures, which DecodeArch picks
+	// from.
+	archDisassemblers map[Arch]*Disassembler
 }
 
 func (d *Disassembler) addInstType(info *InstType) {
@@ -58,18 +67,68 @@ func (d *Disassembler) addInstType(info *InstType) {
 	d.nextInstID++
 }
 
-// NewDisassembler creates a new disassembler
+// NewDisassembler creates a new disassembler that decodes GCN3 instructions.
 func NewDisassembler() *Disassembler {
+	return NewDisassemblerForArch(GCN3)
+}
+
+// NewDisassemblerForArch creates a new disassembler that decodes the
+// instructions of the given architecture with Decode. The disassembler can
+// still decode the instructions of the other architectures with DecodeArch.
+func NewDisassemblerForArch(arch Arch) *Disassembler {
+	disassemblers := map[Arch]*Disassembler{
+		GCN3: newArchDisassembler(GCN3),
+		GFX9: newArchDisassembler(GFX9),
+	}
+
+	for _, d := range disassemblers {
+		d.archDisassemblers = disassemblers
+	}
+
+	return disassemblers[arch]
+}
+
+func newArchDisassembler(arch Arch) *Disassembler {
 	d := new(Disassembler)
 
+	d.arch = arch
 	d.nextInstID = 0
 
 	d.initFormatList()
 	d.initializeDecodeTable()
 
+	if arch == GFX9 {
+		d.initializeGFX9DecodeTable()
+	}
+
 	return d
 }
 
+// Arch returns the architecture that Decode decodes the instructions of.
+func (d *Disassembler) Arch() Arch {
+	return d.arch
+}
+
+// InstTypes returns all the instruction types that the disassembler can
+// decode in the given format, sorted by opcode.
+func (d *Disassembler) InstTypes(format FormatType) []*InstType {
+	table := d.decodeTables[format]
+	if table == nil {
+		return nil
+	}
+
+	types := make([]*InstType, 0, len(table.insts))
+	for _, t := range table.insts {
+		types = append(types, t)
+	}
+
+	sort.Slice(types, func(i, j int) bool {
+		return types[i].Opcode < types[j].Opcode
+	})
+
+	return types
+}
+
 func (d *Disassembler) matchFormat(firstFourBytes uint32) (*Format, error) {
 	for _, f := range d.formatList {
 		if f.FormatType == VOP3b { // Skip VOP3b this time.
@@ -107,6 +166,23 @@ func (d *Disassembler) lookUp(
 		format.FormatName, opcode)
 }
 
+func (d *Disassembler) lookUpFlat(
+	seg FlatSegment,
+	opcode Opcode,
+) (*InstType, error) {
+	if seg == FlatSegmentFlat {
+		return d.lookUp(FormatTable[FLAT], opcode)
+	}
+
+	table := d.flatSegmentTables[seg]
+	if table != nil && table.insts[opcode] != nil {
+		return table.insts[opcode], nil
+	}
+
+	return nil, fmt.Errorf("FLAT instruction of segment %d, opcode %d "+
+		"not found", seg, opcode)
+}
+
 func (d *Disassembler) decodeSOP2(inst *Inst, buf []byte) error {
 	bytes := binary.LittleEndian.Uint32(buf)
 
@@ -194,6 +270,9 @@ func (d *Disassembler) decodeVOP2(inst *Inst, buf []byte) error {
 		sdwaBytes := binary.LittleEndian.Uint32(buf[4:8])
 		src0Bits := int(extractBits(sdwaBytes, 0, 7))
 		inst.Src0 = NewVRegOperand(src0Bits, src0Bits, 0)
+		if d.arch == GFX9 && extractBits(sdwaBytes, 23, 23) != 0 {
+			inst.Src0, _ = getOperand(uint16(src0Bits))
+		}
 
 		dstSel := int(extractBits(sdwaBytes, 8, 10))
 		dstUnused := int(extractBits(sdwaBytes, 11, 12))
@@ -323,12 +402,16 @@ func (d *Disassembler) decodeVOP2(inst *Inst, buf []byte) error {
 
 	bits := int(extractBits(bytes, 9, 16))
 	inst.Src1 = NewVRegOperand(bits, bits, 0)
+	if inst.IsSdwa && d.arch == GFX9 &&
+		extractBits(binary.LittleEndian.Uint32(buf[4:8]), 31, 31) != 0 {
+		inst.Src1, _ = getOperand(uint16(bits))
+	}
 
 	bits = int(extractBits(bytes, 17, 24))
 	inst.Dst = NewVRegOperand(bits, bits, 0)
 
 	switch inst.Opcode {
-	case 24, 37: // v_madak
+	case 23, 24, 36, 37: // v_madmk and v_madak
 		inst.Imm = true
 		inst.ByteSize += 4
 		inst.Src2 = &Operand{0, LiteralConstant, nil, 0, 0, 0, 0}
@@ -354,7 +437,8 @@ func (d *Disassembler) decodeFLAT(inst *Inst, buf []byte) error {
 		inst.GlobalLevelCoherent = true
 	}
 
-	if extractBits(bytesHi, 23, 23) != 0 {
+	// GFX9 uses the bit as the non-volatile hint.
+	if d.arch == GCN3 && extractBits(bytesHi, 23, 23) != 0 {
 		inst.TextureFailEnable = true
 	}
 
@@ -366,8 +450,13 @@ func (d *Disassembler) decodeFLAT(inst *Inst, buf []byte) error {
 	inst.Data = NewVRegOperand(bits, bits, 0)
 
 	switch inst.Opcode {
-	case 21, 29, 80, 81, 82, 83, 84, 85, 86, 87, 88, 89, 90, 91, 92, 93:
+	case 65: // flat_atomic_cmpswap
 		inst.Data.RegCount = 2
+	case 21, 29, 96, 98, 99, 100, 101, 102, 103, 104, 105, 106, 107, 108:
+		inst.Data.RegCount = 2
+		inst.Dst.RegCount = 2
+	case 97: // flat_atomic_cmpswap_x2
+		inst.Data.RegCount = 4
 		inst.Dst.RegCount = 2
 	case 22, 30:
 		inst.Data.RegCount = 3
@@ -376,9 +465,136 @@ func (d *Disassembler) decodeFLAT(inst *Inst, buf []byte) error {
 		inst.Data.RegCount = 4
 		inst.Dst.RegCount = 4
 	}
+
+	if d.arch == GFX9 {
+		d.decodeFLATGFX9(inst, bytesLo, bytesHi)
+	}
+
+	return nil
+}
+
+// decodeFLATGFX9 decodes the segment, the offset, and the scalar address,
+// which GFX9 adds to the FLAT encoding.
+func (d *Disassembler) decodeFLATGFX9(inst *Inst, bytesLo, bytesHi uint32) {
+	inst.Seg = FlatSegment(extractBits(bytesLo, 14, 15))
+	inst.LDS = extractBits(bytesLo, 13, 13) != 0
+
+	// The offset is unsigned and has 12 bits for the flat segment, and is
+	// signed and has 13 bits for the global and the scratch segments.
+	offset := int64(extractBits(bytesLo, 0, 12))
+	if inst.Seg == FlatSegmentFlat {
+		offset &= 0xfff
+	} else if offset&0x1000 != 0 {
+		offset -= 0x2000
+	}
+	inst.Offset = NewIntOperand(0, offset)
+
+	saddr := extractBits(bytesHi, 16, 22)
+	if inst.Seg == FlatSegmentFlat || saddr == 0x7f { // 0x7f means off
+		return
+	}
+
+	inst.SAddr, _ = getOperand(uint16(saddr))
+	switch inst.Seg {
+	case FlatSegmentGlobal:
+		// A 64-bit base in SGPRs plus a 32-bit offset in a VGPR.
+		inst.SAddr.RegCount = 2
+		inst.Addr.RegCount = 1
+	case FlatSegmentScratch:
+		// A 32-bit offset in an SGPR, without VGPR.
+		inst.SAddr.RegCount = 1
+		inst.Addr = nil
+	}
+}
+
+func (d *Disassembler) decodeMUBUF(inst *Inst, buf []byte) error {
+	bytesLo := binary.LittleEndian.Uint32(buf)
+	bytesHi := binary.LittleEndian.Uint32(buf[4:])
+
+	inst.Offset0 = extractBits(bytesLo, 0, 11)
+	inst.OffEn = extractBits(bytesLo, 12, 12) != 0
+	inst.IdxEn = extractBits(bytesLo, 13, 13) != 0
+	inst.GlobalLevelCoherent = extractBits(bytesLo, 14, 14) != 0
+	inst.LDS = extractBits(bytesLo, 16, 16) != 0
+	inst.SystemLevelCoherent = extractBits(bytesLo, 17, 17) != 0
+	inst.TextureFailEnable = extractBits(bytesHi, 23, 23) != 0
+
+	d.decodeBufferOperands(inst, bytesHi)
+
+	switch inst.Opcode {
+	case 1, 5, 10, 11, 14, 15, 21, 29:
+		inst.Data.RegCount = 2
+		inst.Dst.RegCount = 2
+	case 2, 6, 22, 30:
+		inst.Data.RegCount = 3
+		inst.Dst.RegCount = 3
+	case 3, 7, 23, 31:
+		inst.Data.RegCount = 4
+		inst.Dst.RegCount = 4
+	case 65: // buffer_atomic_cmpswap
+		inst.Data.RegCount = 2
+		inst.Dst.RegCount = 1
+	case 96, 98, 99, 100, 101, 102, 103, 104, 105, 106, 107, 108:
+		inst.Data.RegCount = 2
+		inst.Dst.RegCount = 2
+	case 97: // buffer_atomic_cmpswap_x2
+		inst.Data.RegCount = 4
+		inst.Dst.RegCount = 2
+	}
+
+	return nil
+}
+
+func (d *Disassembler) decodeMTBUF(inst *Inst, buf []byte) error {
+	bytesLo := binary.LittleEndian.Uint32(buf)
+	bytesHi := binary.LittleEndian.Uint32(buf[4:])
+
+	inst.Offset0 = extractBits(bytesLo, 0, 11)
+	inst.OffEn = extractBits(bytesLo, 12, 12) != 0
+	inst.IdxEn = extractBits(bytesLo, 13, 13) != 0
+	inst.GlobalLevelCoherent = extractBits(bytesLo, 14, 14) != 0
+	inst.DataFormat = int(extractBits(bytesLo, 19, 22))
+	inst.NumFormat = int(extractBits(bytesLo, 23, 25))
+	inst.SystemLevelCoherent = extractBits(bytesHi, 22, 22) != 0
+	inst.TextureFailEnable = extractBits(bytesHi, 23, 23) != 0
+
+	d.decodeBufferOperands(inst, bytesHi)
+
+	switch inst.Opcode {
+	case 1, 5, 10, 11, 14, 15:
+		inst.Data.RegCount = 2
+		inst.Dst.RegCount = 2
+	case 2, 6:
+		inst.Data.RegCount = 3
+		inst.Dst.RegCount = 3
+	case 3, 7:
+		inst.Data.RegCount = 4
+		inst.Dst.RegCount = 4
+	}
+
 	return nil
 }
 
+// decodeBufferOperands decodes the second dword that MUBUF and MTBUF
+// instructions share.
+func (d *Disassembler) decodeBufferOperands(inst *Inst, bytesHi uint32) {
+	addrRegCount := 1
+	if inst.IdxEn && inst.OffEn {
+		addrRegCount = 2
+	}
+	bits := int(extractBits(bytesHi, 0, 7))
+	inst.Addr = NewVRegOperand(bits, bits, addrRegCount)
+
+	bits = int(extractBits(bytesHi, 8, 15))
+	inst.Data = NewVRegOperand(bits, bits, 1)
+	inst.Dst = NewVRegOperand(bits, bits, 1)
+
+	bits = int(extractBits(bytesHi, 16, 20)) << 2
+	inst.Base = NewSRegOperand(bits, bits, 4)
+
+	inst.Offset, _ = getOperand(uint16(extractBits(bytesHi, 24, 31)))
+}
+
 //nolint:gocyclo,funlen
 func (d *Disassembler) decodeSMEM(inst *Inst, buf []byte) error {
 	bytesLo := binary.LittleEndian.Uint32(buf)
@@ -407,11 +623,11 @@ func (d *Disassembler) decodeSMEM(inst *Inst, buf []byte) error {
 	}
 
 	switch inst.Opcode {
-	case 0:
+	case 0, 5, 21:
 		inst.Data.RegCount = 1
-	case 1, 9, 17, 25:
+	case 1, 6, 9, 17, 22, 25:
 		inst.Data.RegCount = 2
-	case 2, 10, 18, 26:
+	case 2, 7, 10, 18, 23, 26:
 		inst.Data.RegCount = 4
 	case 3, 11, 19, 27:
 		inst.Data.RegCount = 8
@@ -419,6 +635,11 @@ func (d *Disassembler) decodeSMEM(inst *Inst, buf []byte) error {
 		inst.Data.RegCount = 16
 	}
 
+	if d.arch == GFX9 {
+		d.decodeSMEMOffsetGFX9(inst, bytesLo, bytesHi)
+		return nil
+	}
+
 	if inst.Imm {
 		bits64 := int64(extractBits(bytesHi, 0, 19))
 		inst.Offset = NewIntOperand(0, bits64)
@@ -429,6 +650,30 @@ func (d *Disassembler) decodeSMEM(inst *Inst, buf []byte) error {
 	return nil
 }
 
+// decodeSMEMOffsetGFX9 decodes the offset of GFX9 SMEM instructions, which
+// can add an SGPR (SOFFSET, enabled by the SOE bit) to the 21-bit immediate
+// offset.
+func (d *Disassembler) decodeSMEMOffsetGFX9(
+	inst *Inst,
+	bytesLo, bytesHi uint32,
+) {
+	soe := extractBits(bytesLo, 14, 14) != 0
+	soffset := int(extractBits(bytesHi, 25, 31))
+
+	switch {
+	case inst.Imm && soe:
+		inst.Offset = NewIntOperand(0, int64(extractBits(bytesHi, 0, 20)))
+		inst.SOffset = NewSRegOperand(soffset, soffset, 1)
+	case inst.Imm:
+		inst.Offset = NewIntOperand(0, int64(extractBits(bytesHi, 0, 20)))
+	case soe:
+		inst.Offset = NewSRegOperand(soffset, soffset, 1)
+	default:
+		bits := int(extractBits(bytesHi, 0, 20))
+		inst.Offset = NewSRegOperand(bits, bits, 1)
+	}
+}
+
 func (d *Disassembler) decodeSOPP(inst *Inst, buf []byte) error {
 	bytes := binary.LittleEndian.Uint32(buf)
 
@@ -553,6 +798,10 @@ func (d *Disassembler) decodeVOP3a(inst *Inst, buf []byte) error {
 	inst.Abs = int(extractBits(bytesLo, 8, 10))
 	d.parseAbs(inst, inst.Abs)
 
+	if d.arch == GFX9 {
+		inst.OpSel = int(extractBits(bytesLo, 11, 14))
+	}
+
 	if extractBits(bytesLo, 15, 15) != 0 {
 		inst.Clamp = true
 	}
@@ -580,6 +829,33 @@ func (d *Disassembler) decodeVOP3a(inst *Inst, buf []byte) error {
 	return nil
 }
 
+func (d *Disassembler) decodeVOP3P(inst *Inst, buf []byte) error {
+	bytesLo := binary.LittleEndian.Uint32(buf)
+	bytesHi := binary.LittleEndian.Uint32(buf[4:])
+
+	bits := int(extractBits(bytesLo, 0, 7))
+	inst.Dst = NewVRegOperand(bits, bits, 0)
+
+	inst.NegHi = int(extractBits(bytesLo, 8, 10))
+	inst.OpSel = int(extractBits(bytesLo, 11, 13))
+	inst.OpSelHi = int(extractBits(bytesLo, 14, 14))<<2 |
+		int(extractBits(bytesHi, 27, 28))
+
+	if extractBits(bytesLo, 15, 15) != 0 {
+		inst.Clamp = true
+	}
+
+	inst.Src0, _ = getOperand(uint16(extractBits(bytesHi, 0, 8)))
+	inst.Src1, _ = getOperand(uint16(extractBits(bytesHi, 9, 17)))
+	if inst.SRC2Width != 0 {
+		inst.Src2, _ = getOperand(uint16(extractBits(bytesHi, 18, 26)))
+	}
+
+	inst.Neg = int(extractBits(bytesHi, 29, 31))
+
+	return nil
+}
+
 func (d *Disassembler) parseNeg(inst *Inst, neg int) {
 	if neg&0b001 > 0 {
 		inst.Src0Neg = true
@@ -635,6 +911,20 @@ func (d *Disassembler) decodeSOPK(inst *Inst, buf []byte) error {
 	bytes := binary.LittleEndian.Uint32(buf)
 	inst.SImm16 = NewIntOperand(0, int64(extractBits(bytes, 0, 15)))
 	inst.Dst, _ = getOperand(uint16(extractBits(bytes, 16, 22)))
+	if inst.DSTWidth == 64 {
+		inst.Dst.RegCount = 2
+	}
+
+	if inst.Opcode == 20 { // s_setreg_imm32_b32
+		if len(buf) < 8 {
+			return errors.New("no enough bytes")
+		}
+
+		inst.ByteSize += 4
+		inst.Src0, _ = getOperand(255)
+		inst.Src0.LiteralConstant = BytesToUint32(buf[4:8])
+	}
+
 	return nil
 }
 
@@ -707,7 +997,13 @@ func (d *Disassembler) Decode(buf []byte) (*Inst, error) {
 	}
 
 	opcode := format.retrieveOpcode(binary.LittleEndian.Uint32(buf))
-	instType, err := d.lookUp(format, opcode)
+	var instType *InstType
+	if format.FormatType == FLAT && d.arch == GFX9 {
+		seg := FlatSegment(extractBits(binary.LittleEndian.Uint32(buf), 14, 15))
+		instType, err = d.lookUpFlat(seg, opcode)
+	} else {
+		instType, err = d.lookUp(format, opcode)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -732,6 +1028,10 @@ func (d *Disassembler) Decode(buf []byte) (*Inst, error) {
 		err = d.decodeVOP1(inst, buf)
 	case FLAT:
 		err = d.decodeFLAT(inst, buf)
+	case MUBUF:
+		err = d.decodeMUBUF(inst, buf)
+	case MTBUF:
+		err = d.decodeMTBUF(inst, buf)
 	case SOPP:
 		err = d.decodeSOPP(inst, buf)
 	case VOPC:
@@ -742,6 +1042,8 @@ func (d *Disassembler) Decode(buf []byte) (*Inst, error) {
 		err = d.decodeVOP3a(inst, buf)
 	case VOP3b:
 		err = d.decodeVOP3b(inst, buf)
+	case VOP3P:
+		err = d.decodeVOP3P(inst, buf)
 	case SOP1:
 		err = d.decodeSOP1(inst, buf)
 	case SOPK:
@@ -760,6 +1062,17 @@ func (d *Disassembler) Decode(buf []byte) (*Inst, error) {
 	return inst, nil
 }
 
+// DecodeArch decodes the head of the buffer as an instruction of the given
+// architecture.
+func (d *Disassembler) DecodeArch(arch Arch, buf []byte) (*Inst, error) {
+	archDisassembler, ok := d.archDisassemblers[arch]
+	if !ok {
+		return nil, fmt.Errorf("architecture %s is not supported", arch)
+	}
+
+	return archDisassembler.Decode(buf)
+}
+
 // Disassemble take a binary file as an input and put the assembly code in a
 // writer
 func (d *Disassembler) Disassemble(
@@ -842,8 +1155,12 @@ func (d *Disassembler) isNewKenrelStart(file *elf.File, offset uint64) bool {
 }
 
 func (d *Disassembler) initFormatList() {
-	d.formatList = make([]*Format, 0, 17)
+	d.formatList = make([]*Format, 0, int(formatTypeCount))
 	for _, value := range FormatTable {
+		if value.FormatType == VOP3P && d.arch != GFX9 {
+			continue
+		}
+
 		d.formatList = append(d.formatList, value)
 	}
 	sort.Slice(d.formatList,
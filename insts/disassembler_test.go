@@ -23,6 +23,19 @@ var _ = Describe("Disassembler", func() {
 		disassembler = insts.NewDisassembler()
 	})
 
+	It("should list the instruction types of a format", func() {
+		types := disassembler.InstTypes(insts.SOPK)
+
+		Expect(types).NotTo(BeEmpty())
+		Expect(types[0].InstName).To(Equal("s_movk_i32"))
+		for i, t := range types {
+			Expect(t.Format.FormatType).To(Equal(insts.SOPK))
+			if i > 0 {
+				Expect(t.Opcode).To(BeNumerically(">", types[i-1].Opcode))
+			}
+		}
+	})
+
 	It("should decode BF8C0F70", func() {
 		buf := []byte{0x70, 0x0f, 0x8c, 0xbf}
 
@@ -32,6 +45,17 @@ var _ = Describe("Disassembler", func() {
 		Expect(inst.String(nil)).To(Equal("s_waitcnt vmcnt(0)"))
 	})
 
+	It("should decode BA000901 00000003", func() {
+		buf := []byte{0x01, 0x09, 0x00, 0xba, 0x03, 0x00, 0x00, 0x00}
+
+		inst, err := disassembler.Decode(buf)
+
+		Expect(err).To(BeNil())
+		Expect(inst.ByteSize).To(Equal(8))
+		Expect(inst.String(nil)).
+			To(Equal("s_setreg_imm32_b32 0x901, 0x3"))
+	})
+
 	It("should decode BF8C0171", func() {
 		buf := []byte{0x71, 0x01, 0x8c, 0xbf}
 
@@ -143,6 +167,17 @@ var _ = Describe("Disassembler", func() {
 			To(Equal("v_madak_f32 v79, v22, v79, 0xbe2aaa9d"))
 	})
 
+	It("should decode 2E9E9F16 BE2AAA9D", func() {
+		buf := []byte{0x16, 0x9f, 0x9e, 0x2e, 0x9d, 0xaa, 0x2a, 0xbe}
+
+		inst, err := disassembler.Decode(buf)
+
+		Expect(err).To(BeNil())
+		Expect(inst.ByteSize).To(Equal(8))
+		Expect(inst.String(nil)).
+			To(Equal("v_madmk_f32 v79, v22, 0xbe2aaa9d, v79"))
+	})
+
 	It("should decode D81C4200 002E1411", func() {
 		buf := []byte{0x00, 0x42, 0x1c, 0xd8, 0x11, 0x14, 0x2e, 0x00}
 
@@ -233,6 +268,25 @@ var _ = Describe("Disassembler", func() {
 			To(Equal("v_rcp_f64_e32 v[10:11], v[8:9]"))
 	})
 
+	It("should decode 7E002F02", func() {
+		buf := []byte{0x02, 0x2f, 0x00, 0x7e}
+
+		inst, err := disassembler.Decode(buf)
+
+		Expect(err).To(BeNil())
+		Expect(inst.String(nil)).To(Equal("v_trunc_f64 v[0:1], v[2:3]"))
+	})
+
+	It("should decode D1570000 00000102", func() {
+		buf := []byte{0x00, 0x00, 0x57, 0xd1, 0x02, 0x01, 0x00, 0x00}
+
+		inst, err := disassembler.Decode(buf)
+
+		Expect(err).To(BeNil())
+		Expect(inst.Dst.RegCount).To(Equal(2))
+		Expect(inst.Src0.RegCount).To(Equal(2))
+	})
+
 	It("should decode D1E30008 043A1508", func() {
 		buf := []byte{0x08, 0x00, 0xe3, 0xd1, 0x08, 0x15, 0x3a, 0x04}
 
@@ -272,4 +326,304 @@ var _ = Describe("Disassembler", func() {
 		Expect(inst.String(nil)).
 			To(Equal("ds_read_b128 v[17:20], v1 offset:128"))
 	})
+
+	It("should decode E0501010 80010100", func() {
+		buf := []byte{0x10, 0x10, 0x50, 0xe0, 0x00, 0x01, 0x01, 0x80}
+
+		inst, err := disassembler.Decode(buf)
+
+		Expect(err).To(BeNil())
+		Expect(inst.String(nil)).
+			To(Equal("buffer_load_dword v1, v0, s[4:7], 0 offen offset:16"))
+	})
+
+	It("should decode E0742000 80020302", func() {
+		buf := []byte{0x00, 0x20, 0x74, 0xe0, 0x02, 0x03, 0x02, 0x80}
+
+		inst, err := disassembler.Decode(buf)
+
+		Expect(err).To(BeNil())
+		Expect(inst.IdxEn).To(BeTrue())
+		Expect(inst.String(nil)).
+			To(Equal("buffer_store_dwordx2 v[3:4], v2, s[8:11], 0 idxen"))
+	})
+
+	It("should decode EBA22000 01020200", func() {
+		buf := []byte{0x00, 0x20, 0xa2, 0xeb, 0x00, 0x02, 0x02, 0x01}
+
+		inst, err := disassembler.Decode(buf)
+
+		Expect(err).To(BeNil())
+		Expect(inst.String(nil)).
+			To(Equal("tbuffer_store_format_x v2, v0, s[8:11], " +
+				"dfmt:4, nfmt:7, s1 idxen"))
+	})
+
+	It("should decode DD090000 00000301", func() {
+		buf := []byte{0x00, 0x00, 0x09, 0xdd, 0x01, 0x03, 0x00, 0x00}
+
+		inst, err := disassembler.Decode(buf)
+
+		Expect(err).To(BeNil())
+		Expect(inst.String(nil)).
+			To(Equal("flat_atomic_add v0, v[1:2], v3 glc"))
+	})
+
+	It("should decode D8000010 00000201", func() {
+		buf := []byte{0x10, 0x00, 0x00, 0xd8, 0x01, 0x02, 0x00, 0x00}
+
+		inst, err := disassembler.Decode(buf)
+
+		Expect(err).To(BeNil())
+		Expect(inst.String(nil)).To(Equal("ds_add_u32 v1, v2 offset:16"))
+	})
+
+	It("should decode D8600000 00030201", func() {
+		buf := []byte{0x00, 0x00, 0x60, 0xd8, 0x01, 0x02, 0x03, 0x00}
+
+		inst, err := disassembler.Decode(buf)
+
+		Expect(err).To(BeNil())
+		Expect(inst.String(nil)).
+			To(Equal("ds_cmpst_rtn_b32 v0, v1, v2, v3"))
+	})
+})
+
+var _ = Describe("GFX9 Disassembler", func() {
+
+	var (
+		disassembler *insts.Disassembler
+	)
+
+	BeforeEach(func() {
+		disassembler = insts.NewDisassemblerForArch(insts.GFX9)
+	})
+
+	It("should decode D38F4800 18020501", func() {
+		buf := []byte{0x00, 0x48, 0x8f, 0xd3, 0x01, 0x05, 0x02, 0x18}
+
+		inst, err := disassembler.Decode(buf)
+
+		Expect(err).To(BeNil())
+		Expect(inst.String(nil)).To(Equal("v_pk_add_f16 v0, v1, v2 op_sel:[1,0]"))
+	})
+
+	It("should decode D38F4200 38020501", func() {
+		buf := []byte{0x00, 0x42, 0x8f, 0xd3, 0x01, 0x05, 0x02, 0x38}
+
+		inst, err := disassembler.Decode(buf)
+
+		Expect(err).To(BeNil())
+		Expect(inst.String(nil)).To(Equal("v_pk_add_f16 v0, v1, v2 neg_lo:[1,0] neg_hi:[0,1]"))
+	})
+
+	It("should decode D38E4000 1C0DE501", func() {
+		buf := []byte{0x00, 0x40, 0x8e, 0xd3, 0x01, 0xe5, 0x0d, 0x1c}
+
+		inst, err := disassembler.Decode(buf)
+
+		Expect(err).To(BeNil())
+		Expect(inst.String(nil)).To(Equal("v_pk_fma_f16 v0, v1, 1.0, v3"))
+	})
+
+	It("should decode D3A00000 1C0E0501", func() {
+		buf := []byte{0x00, 0x00, 0xa0, 0xd3, 0x01, 0x05, 0x0e, 0x1c}
+
+		inst, err := disassembler.Decode(buf)
+
+		Expect(err).To(BeNil())
+		Expect(inst.String(nil)).To(Equal("v_mad_mix_f32 v0, v1, v2, v3 op_sel_hi:[1,1,0]"))
+	})
+
+	It("should decode D3A34000 1C0E0501", func() {
+		buf := []byte{0x00, 0x40, 0xa3, 0xd3, 0x01, 0x05, 0x0e, 0x1c}
+
+		inst, err := disassembler.Decode(buf)
+
+		Expect(err).To(BeNil())
+		Expect(inst.String(nil)).To(Equal("v_dot2_f32_f16 v0, v1, v2, v3"))
+	})
+
+	It("should decode D2044800 040E0501", func() {
+		buf := []byte{0x00, 0x48, 0x04, 0xd2, 0x01, 0x05, 0x0e, 0x04}
+
+		inst, err := disassembler.Decode(buf)
+
+		Expect(err).To(BeNil())
+		Expect(inst.String(nil)).To(Equal("v_mad_u16 v0, v1, v2, v3 op_sel:[1,0,0,1]"))
+	})
+
+	It("should decode D1FF0000 040E0501", func() {
+		buf := []byte{0x00, 0x00, 0xff, 0xd1, 0x01, 0x05, 0x0e, 0x04}
+
+		inst, err := disassembler.Decode(buf)
+
+		Expect(err).To(BeNil())
+		Expect(inst.String(nil)).To(Equal("v_add3_u32 v0, v1, v2, v3"))
+	})
+
+	It("should decode 68000501", func() {
+		buf := []byte{0x01, 0x05, 0x00, 0x68}
+
+		inst, err := disassembler.Decode(buf)
+
+		Expect(err).To(BeNil())
+		Expect(inst.String(nil)).To(Equal("v_add_u32_e32 v0, v1, v2"))
+	})
+
+	It("should decode 32000501", func() {
+		buf := []byte{0x01, 0x05, 0x00, 0x32}
+
+		inst, err := disassembler.Decode(buf)
+
+		Expect(err).To(BeNil())
+		Expect(inst.String(nil)).To(Equal("v_add_co_u32_e32 v0, vcc, v1, v2"))
+	})
+
+	It("should decode 680004F9 06850601", func() {
+		buf := []byte{0xf9, 0x04, 0x00, 0x68, 0x01, 0x06, 0x85, 0x06}
+
+		inst, err := disassembler.Decode(buf)
+
+		Expect(err).To(BeNil())
+		Expect(inst.String(nil)).To(Equal(
+			"v_add_u32_sdwa v0, s1, v2 dst_sel:DWORD dst_unused:UNUSED_PAD " +
+				"src0_sel:WORD_1 src1_sel:DWORD"))
+	})
+
+	It("should decode DC509FF0 017F0002", func() {
+		buf := []byte{0xf0, 0x9f, 0x50, 0xdc, 0x02, 0x00, 0x7f, 0x01}
+
+		inst, err := disassembler.Decode(buf)
+
+		Expect(err).To(BeNil())
+		Expect(inst.String(nil)).To(Equal("global_load_dword v1, v[2:3], off offset:-16"))
+	})
+
+	It("should decode DC508010 01040002", func() {
+		buf := []byte{0x10, 0x80, 0x50, 0xdc, 0x02, 0x00, 0x04, 0x01}
+
+		inst, err := disassembler.Decode(buf)
+
+		Expect(err).To(BeNil())
+		Expect(inst.String(nil)).To(Equal("global_load_dword v1, v2, s[4:5] offset:16"))
+	})
+
+	It("should decode DC708000 007F0102", func() {
+		buf := []byte{0x00, 0x80, 0x70, 0xdc, 0x02, 0x01, 0x7f, 0x00}
+
+		inst, err := disassembler.Decode(buf)
+
+		Expect(err).To(BeNil())
+		Expect(inst.String(nil)).To(Equal("global_store_dword v[2:3], v1, off"))
+	})
+
+	It("should decode DD098000 007F0402", func() {
+		buf := []byte{0x00, 0x80, 0x09, 0xdd, 0x02, 0x04, 0x7f, 0x00}
+
+		inst, err := disassembler.Decode(buf)
+
+		Expect(err).To(BeNil())
+		Expect(inst.String(nil)).To(Equal("global_atomic_add v0, v[2:3], v4, off glc"))
+	})
+
+	It("should decode DC500008 01000002", func() {
+		buf := []byte{0x08, 0x00, 0x50, 0xdc, 0x02, 0x00, 0x00, 0x01}
+
+		inst, err := disassembler.Decode(buf)
+
+		Expect(err).To(BeNil())
+		Expect(inst.String(nil)).To(Equal("flat_load_dword v1, v[2:3] offset:8"))
+	})
+
+	It("should decode DC504004 01020000", func() {
+		buf := []byte{0x04, 0x40, 0x50, 0xdc, 0x00, 0x00, 0x02, 0x01}
+
+		inst, err := disassembler.Decode(buf)
+
+		Expect(err).To(BeNil())
+		Expect(inst.String(nil)).To(Equal("scratch_load_dword v1, off, s2 offset:4"))
+	})
+
+	It("should decode C0020000 00000010", func() {
+		buf := []byte{0x00, 0x00, 0x02, 0xc0, 0x10, 0x00, 0x00, 0x00}
+
+		inst, err := disassembler.Decode(buf)
+
+		Expect(err).To(BeNil())
+		Expect(inst.String(nil)).To(Equal("s_load_dword s0, s[0:1], 0x10"))
+	})
+
+	It("should decode C0000000 00000002", func() {
+		buf := []byte{0x00, 0x00, 0x00, 0xc0, 0x02, 0x00, 0x00, 0x00}
+
+		inst, err := disassembler.Decode(buf)
+
+		Expect(err).To(BeNil())
+		Expect(inst.String(nil)).To(Equal("s_load_dword s0, s[0:1], s2"))
+	})
+
+	It("should decode C0024000 04000010", func() {
+		buf := []byte{0x00, 0x40, 0x02, 0xc0, 0x10, 0x00, 0x00, 0x04}
+
+		inst, err := disassembler.Decode(buf)
+
+		Expect(err).To(BeNil())
+		Expect(inst.String(nil)).To(Equal("s_load_dword s0, s[0:1], s2 offset:0x10"))
+	})
+
+	It("should decode C0160000 00000010", func() {
+		buf := []byte{0x00, 0x00, 0x16, 0xc0, 0x10, 0x00, 0x00, 0x00}
+
+		inst, err := disassembler.Decode(buf)
+
+		Expect(err).To(BeNil())
+		Expect(inst.String(nil)).To(Equal("s_scratch_load_dword s0, s[0:1], 0x10"))
+	})
+
+	It("should decode BA9E0004", func() {
+		buf := []byte{0x04, 0x00, 0x9e, 0xba}
+
+		inst, err := disassembler.Decode(buf)
+
+		Expect(err).To(BeNil())
+		Expect(inst.String(nil)).To(Equal("s_call_b64 s[30:31], 0x4"))
+	})
+
+	It("should decode BE9E1E04", func() {
+		buf := []byte{0x04, 0x1e, 0x9e, 0xbe}
+
+		inst, err := disassembler.Decode(buf)
+
+		Expect(err).To(BeNil())
+		Expect(inst.String(nil)).To(Equal("s_swappc_b64 s[30:31], s[4:5]"))
+	})
+
+	It("should decode 99000201", func() {
+		buf := []byte{0x01, 0x02, 0x00, 0x99}
+
+		inst, err := disassembler.Decode(buf)
+
+		Expect(err).To(BeNil())
+		Expect(inst.String(nil)).To(Equal("s_pack_ll_b32_b16 s0, s1, s2"))
+	})
+
+	It("should decode with the disassembler of the architecture", func() {
+		gcn3 := insts.NewDisassembler()
+		buf := []byte{0x01, 0x05, 0x00, 0x32}
+
+		inst, err := gcn3.DecodeArch(insts.GFX9, buf)
+
+		Expect(err).To(BeNil())
+		Expect(inst.String(nil)).To(Equal("v_add_co_u32_e32 v0, vcc, v1, v2"))
+	})
+
+	It("should not decode VOP3P instructions as GCN3", func() {
+		gcn3 := insts.NewDisassembler()
+		buf := []byte{0x00, 0x48, 0x8f, 0xd3, 0x01, 0x05, 0x02, 0x18}
+
+		inst, err := gcn3.Decode(buf)
+
+		Expect(err == nil && inst.FormatType == insts.VOP3P).To(BeFalse())
+	})
 })
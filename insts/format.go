@@ -3,7 +3,7 @@ package insts
 // FormatType is a enumeration of all the instruction formats defined by GCN3
 type FormatType int
 
-// All the GCN3 instruction formats
+// All the GCN3 instruction formats, and the VOP3P format that GFX9 adds
 const (
 	SOP2 FormatType = iota
 	SOPK
@@ -23,6 +23,7 @@ const (
 	MIMG
 	EXP
 	FLAT
+	VOP3P
 	formatTypeCount
 )
 
@@ -65,4 +66,5 @@ func initFormatTable() {
 	FormatTable[SOPK] = &Format{SOPK, "sopk", 0xB0000000, 0xF0000000, 4, 23, 27}
 	FormatTable[SOP2] = &Format{SOP2, "sop2", 0x80000000, 0xC0000000, 4, 23, 29}
 	FormatTable[VOP2] = &Format{VOP2, "vop2", 0x00000000, 0x80000000, 4, 25, 30}
+	FormatTable[VOP3P] = &Format{VOP3P, "vop3p", 0xD3800000, 0xFF800000, 8, 16, 22}
 }
@@ -11,15 +11,28 @@ import (
 
 func main() {
 	path := os.Args[1]
-	elfFile, err := elf.Open(path)
+	file, err := os.Open(path)
+	if err != nil {
+		_ = fmt.Errorf("failed to open file %v", path)
+	}
+	defer file.Close()
+
+	elfFile, err := elf.NewFile(file)
 	if err != nil {
 		_ = fmt.Errorf("failed to open file %v", path)
 	}
-	defer elfFile.Close()
 
 	_, filename := filepath.Split(path)
 
-	disasm := insts.NewDisassembler()
+	// The code objects of GFX9 GPUs are decoded with the GFX9 instructions.
+	arch := insts.GCN3
+	if flags, err := insts.ReadELFFlags(file); err == nil {
+		if a, ok := insts.ArchFromELFFlags(flags); ok {
+			arch = a
+		}
+	}
+
+	disasm := insts.NewDisassemblerForArch(arch)
 
 	disasm.Disassemble(elfFile, filename, os.Stdout)
 }
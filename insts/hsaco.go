@@ -5,6 +5,7 @@ import (
 	"debug/elf"
 	"encoding/binary"
 	"fmt"
+	"log"
 )
 
 // An HsaCo is the kernel code to be executed on an AMD GPU
@@ -12,6 +13,10 @@ type HsaCo struct {
 	*HsaCoHeader
 	Symbol *elf.Symbol
 	Data   []byte
+
+	// Arch is the architecture that the kernel targets, which selects the
+	// instruction set to decode the kernel with.
+	Arch Arch
 }
 
 // HsaCoHeader contains the header information of an HSACO
@@ -67,6 +72,62 @@ func NewHsaCoFromData(data []byte) *HsaCo {
 	header := new(HsaCoHeader)
 	binary.Read(bytes.NewReader(data), binary.LittleEndian, header)
 	o.HsaCoHeader = header
+	o.Arch = archFromMachineVersion(header.MachineVersionMajor)
+
+	return o
+}
+
+// KernelDescriptorSize is the number of bytes of a kernel descriptor, which
+// describes a kernel in the code objects of version 3 and later.
+const KernelDescriptorSize = 64
+
+// NewHsaCoFromKernelDescriptor creates an HsaCo from a kernel descriptor of
+// code object version 3 or later and the code of the kernel. Since the rest of
+// the simulator reads the kernel properties from a version 2 header, the
+// header is rebuilt from the descriptor and placed before the code.
+func NewHsaCoFromKernelDescriptor(kd, code []byte, arch Arch) *HsaCo {
+	if len(kd) < KernelDescriptorSize {
+		log.Panicf("kernel descriptor must have %d bytes, but has %d",
+			KernelDescriptorSize, len(kd))
+	}
+
+	header := new(HsaCoHeader)
+	header.WGGroupSegmentByteSize = binary.LittleEndian.Uint32(kd[0:])
+	header.WIPrivateSegmentByteSize = binary.LittleEndian.Uint32(kd[4:])
+	header.KernargSegmentByteSize = uint64(binary.LittleEndian.Uint32(kd[8:]))
+	header.ComputePgmRsrc1 = binary.LittleEndian.Uint32(kd[48:])
+	header.ComputePgmRsrc2 = binary.LittleEndian.Uint32(kd[52:])
+
+	// The lowest 7 bits of the kernel code properties enable the same user
+	// SGPRs as the lowest 7 bits of the flags in the version 2 header.
+	header.Flags = uint32(binary.LittleEndian.Uint16(kd[56:])) & 0x7f
+
+	// The descriptor only has the granulated register counts, which are
+	// rounded up to the allocation granularity.
+	header.WIVgprCount = uint16((header.WorkItemVgprCount() + 1) * 4)
+	header.WFSgprCount = uint16((header.WavefrontSgprCount() + 1) * 8)
+	header.WavefrontSize = 6
+
+	header.CodeVersionMajor = 1
+	header.MachineKind = 1
+	header.MachineVersionMajor = 8
+	if arch == GFX9 {
+		header.MachineVersionMajor = 9
+	}
+
+	header.KernelCodeEntryByteOffset = uint64(binary.Size(header))
+
+	buf := new(bytes.Buffer)
+	err := binary.Write(buf, binary.LittleEndian, header)
+	if err != nil {
+		panic(err)
+	}
+	buf.Write(code)
+
+	o := new(HsaCo)
+	o.HsaCoHeader = header
+	o.Data = buf.Bytes()
+	o.Arch = arch
 
 	return o
 }
@@ -91,6 +152,22 @@ func (h *HsaCoHeader) Priority() uint32 {
 	return extractBits(h.ComputePgmRsrc1, 10, 11)
 }
 
+// FloatMode returns the float rounding and denormal modes that the
+// wavefronts start with
+func (h *HsaCoHeader) FloatMode() uint32 {
+	return extractBits(h.ComputePgmRsrc1, 12, 19)
+}
+
+// EnableDX10Clamp tells if the wavefronts start with the DX10 clamp mode
+func (h *HsaCoHeader) EnableDX10Clamp() bool {
+	return extractBits(h.ComputePgmRsrc1, 21, 21) != 0
+}
+
+// EnableIEEEMode tells if the wavefronts start with the IEEE mode
+func (h *HsaCoHeader) EnableIEEEMode() bool {
+	return extractBits(h.ComputePgmRsrc1, 23, 23) != 0
+}
+
 // EnableSgprPrivateSegmentWaveByteOffset enable wavebyteoffset
 func (h *HsaCoHeader) EnableSgprPrivateSegmentWaveByteOffset() bool {
 	return extractBits(h.ComputePgmRsrc2, 0, 0) != 0
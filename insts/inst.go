@@ -21,6 +21,17 @@ const (
 	ExeUnitSpecial
 )
 
+// FlatSegment is the address space that a FLAT instruction accesses. Before
+// GFX9, all the FLAT instructions access the flat address space.
+type FlatSegment int
+
+// Defines all the segments that FLAT instructions can access.
+const (
+	FlatSegmentFlat FlatSegment = iota
+	FlatSegmentScratch
+	FlatSegmentGlobal
+)
+
 // A InstType represents an instruction type. For example s_barrier instruction
 // is a instruction type
 type InstType struct {
@@ -70,6 +81,13 @@ type Inst struct {
 	VMCNT               int
 	LKGMCNT             int
 
+	// Fields for MUBUF and MTBUF instructions
+	IdxEn      bool
+	OffEn      bool
+	LDS        bool
+	DataFormat int
+	NumFormat  int
+
 	//Fields for SDWA extensions
 	IsSdwa    bool
 	DstSel    SDWASelect
@@ -84,6 +102,14 @@ type Inst struct {
 	Src1Abs   bool
 	Src2Neg   bool
 	Src2Abs   bool
+
+	// Fields for the encodings that GFX9 adds
+	OpSel   int         // VOP3a and VOP3P, the lowest bit is for Src0
+	OpSelHi int         // VOP3P
+	NegHi   int         // VOP3P, while Neg is applied to the low halves
+	Seg     FlatSegment // FLAT
+	SAddr   *Operand    // FLAT, the global and scratch segments only
+	SOffset *Operand    // SMEM, set if the SOE bit is set
 }
 
 // NewInst creates a zero-filled instruction
@@ -108,19 +134,153 @@ func (i Inst) vop1String() string {
 }
 
 func (i Inst) flatString() string {
-	var s string
-	if i.Opcode >= 16 && i.Opcode <= 23 {
-		s = i.InstName + " " + i.Dst.String() + ", " +
-			i.Addr.String()
-	} else if i.Opcode >= 24 && i.Opcode <= 31 {
-		s = i.InstName + " " + i.Addr.String() + ", " +
-			i.Data.String()
+	s := i.InstName + " "
+	switch {
+	case i.isFlatLoad():
+		s += i.Dst.String() + ", " + i.flatAddrString()
+	case i.Opcode >= 24 && i.Opcode <= 31:
+		s += i.flatAddrString() + ", " + i.Data.String()
+	case i.Opcode >= 64:
+		if i.GlobalLevelCoherent {
+			s += i.Dst.String() + ", "
+		}
+		s += i.flatAddrString() + ", " + i.Data.String()
+	default:
+		return ""
+	}
+
+	s += i.flatSegmentString()
+
+	if i.Opcode >= 64 && i.GlobalLevelCoherent {
+		s += " glc"
+	}
+
+	return s
+}
+
+func (i Inst) isFlatLoad() bool {
+	return (i.Opcode >= 16 && i.Opcode <= 23) ||
+		(i.Opcode >= 32 && i.Opcode <= 37) // The GFX9 d16 loads
+}
+
+func (i Inst) flatAddrString() string {
+	if i.Addr == nil {
+		return "off"
 	}
+
+	return i.Addr.String()
+}
+
+// flatSegmentString returns the scalar address and the offset, which only
+// GFX9 instructions have.
+func (i Inst) flatSegmentString() string {
+	s := ""
+
+	if i.Seg != FlatSegmentFlat {
+		if i.SAddr == nil {
+			s += ", off"
+		} else {
+			s += ", " + i.SAddr.String()
+		}
+	}
+
+	if i.Offset != nil && i.Offset.IntValue != 0 {
+		s += fmt.Sprintf(" offset:%d", i.Offset.IntValue)
+	}
+
+	return s
+}
+
+func (i Inst) bufferString() string {
+	s := i.InstName + " "
+
+	switch {
+	case i.FormatType == MUBUF && i.Opcode >= 62 && i.Opcode <= 63:
+		return i.InstName // buffer_wbinvl1
+	case i.FormatType == MUBUF && i.Opcode >= 64:
+		s += i.Data.String()
+	case i.isBufferStore():
+		s += i.Data.String()
+	default:
+		s += i.Dst.String()
+	}
+
+	if i.IdxEn || i.OffEn {
+		s += ", " + i.Addr.String()
+	} else {
+		s += ", off"
+	}
+
+	s += ", " + i.Base.String()
+
+	if i.FormatType == MTBUF {
+		s += fmt.Sprintf(", dfmt:%d, nfmt:%d", i.DataFormat, i.NumFormat)
+	}
+
+	s += ", " + i.Offset.String()
+	s += i.bufferModifierString()
+
+	return s
+}
+
+func (i Inst) isBufferStore() bool {
+	switch i.Opcode {
+	case 4, 5, 6, 7, 12, 13, 14, 15:
+		return true
+	case 24, 25, 26, 27, 28, 29, 30, 31:
+		return i.FormatType == MUBUF
+	}
+
+	return false
+}
+
+func (i Inst) bufferModifierString() string {
+	s := ""
+
+	if i.IdxEn {
+		s += " idxen"
+	}
+
+	if i.OffEn {
+		s += " offen"
+	}
+
+	if i.Offset0 > 0 {
+		s += fmt.Sprintf(" offset:%d", i.Offset0)
+	}
+
+	if i.GlobalLevelCoherent {
+		s += " glc"
+	}
+
+	if i.SystemLevelCoherent {
+		s += " slc"
+	}
+
+	if i.LDS {
+		s += " lds"
+	}
+
+	if i.TextureFailEnable {
+		s += " tfe"
+	}
+
 	return s
 }
 
 func (i Inst) smemString() string {
 	// TODO: Consider store instructions, and the case if imm = 0
+	if i.SOffset != nil {
+		return fmt.Sprintf("%s %s, %s, %s offset:%#x",
+			i.InstName, i.Data.String(), i.Base.String(), i.SOffset.String(),
+			i.Offset.IntValue)
+	}
+
+	if i.Offset.OperandType == RegOperand {
+		return fmt.Sprintf("%s %s, %s, %s",
+			i.InstName, i.Data.String(), i.Base.String(), i.Offset.String())
+	}
+
 	s := fmt.Sprintf("%s %s, %s, %#x",
 		i.InstName, i.Data.String(), i.Base.String(), uint16(i.Offset.IntValue))
 	return s
@@ -175,7 +335,14 @@ func (i Inst) vop2String() string {
 		s += ", vcc"
 	}
 
-	s += fmt.Sprintf(", %s, %s", i.Src0.String(), i.Src1.String())
+	s += ", " + i.Src0.String()
+
+	switch i.Opcode {
+	case 23, 36: // madmk
+		s += ", " + i.Src2.String()
+	}
+
+	s += ", " + i.Src1.String()
 
 	switch i.Opcode {
 	case 0, 28, 29:
@@ -235,16 +402,76 @@ func (i Inst) vop3aString() string {
 		i.Src1Abs)
 
 	if i.Src2 == nil {
-		return s
+		return s + i.vop3aOpSelString()
 	}
 
 	s += ", " + i.vop3aInputOperandString(*i.Src2,
 		i.Src2Neg,
 		i.Src2Abs)
 
+	return s + i.vop3aOpSelString()
+}
+
+// vop3aOpSelString prints the op_sel modifier of GFX9 VOP3a instructions, in
+// which the destination follows the sources.
+func (i Inst) vop3aOpSelString() string {
+	if i.OpSel == 0 {
+		return ""
+	}
+
+	numSrc := 2
+	if i.Src2 != nil {
+		numSrc = 3
+	}
+
+	bits := i.OpSel&(1<<numSrc-1) | (i.OpSel>>3&1)<<numSrc
+
+	return vop3pModifierString("op_sel", bits, 0, numSrc+1)
+}
+
+func (i Inst) vop3pString() string {
+	s := fmt.Sprintf("%s %s, %s, %s",
+		i.InstName, i.Dst.String(), i.Src0.String(), i.Src1.String())
+
+	numSrc := 2
+	if i.Src2 != nil {
+		s += ", " + i.Src2.String()
+		numSrc = 3
+	}
+
+	// The mixed precision instructions read 32-bit sources by default, while
+	// the packed instructions read the high halves of the sources.
+	opSelHiDefault := 0b111
+	if i.Opcode >= 32 && i.Opcode <= 34 {
+		opSelHiDefault = 0
+	}
+
+	s += vop3pModifierString("op_sel", i.OpSel, 0, numSrc)
+	s += vop3pModifierString("op_sel_hi", i.OpSelHi, opSelHiDefault, numSrc)
+	s += vop3pModifierString("neg_lo", i.Neg, 0, numSrc)
+	s += vop3pModifierString("neg_hi", i.NegHi, 0, numSrc)
+
+	if i.Clamp {
+		s += " clamp"
+	}
+
 	return s
 }
 
+func vop3pModifierString(name string, bits, defaultBits, n int) string {
+	mask := 1<<n - 1
+	if bits&mask == defaultBits&mask {
+		return ""
+	}
+
+	values := make([]string, n)
+	for k := 0; k < n; k++ {
+		values[k] = fmt.Sprint(bits >> k & 1)
+	}
+
+	return fmt.Sprintf(" %s:[%s]", name, strings.Join(values, ","))
+}
+
 func (i Inst) vop3aInputOperandString(operand Operand, neg, abs bool) string {
 	s := ""
 
@@ -290,6 +517,11 @@ func (i Inst) sop1String() string {
 }
 
 func (i Inst) sopkString() string {
+	if i.Src0 != nil { // s_setreg_imm32_b32
+		return fmt.Sprintf("%s 0x%x, %s",
+			i.InstName, i.SImm16.IntValue, i.Src0.String())
+	}
+
 	s := fmt.Sprintf("%s %s, 0x%x",
 		i.InstName, i.Dst.String(), i.SImm16.IntValue)
 
@@ -298,8 +530,7 @@ func (i Inst) sopkString() string {
 
 func (i Inst) dsString() string {
 	s := i.InstName + " "
-	switch i.Opcode {
-	case 54, 55, 56, 57, 58, 59, 60, 118, 119, 120, 254, 255:
+	if i.Dst != nil {
 		s += i.Dst.String() + ", "
 	}
 
@@ -313,8 +544,8 @@ func (i Inst) dsString() string {
 		s += ", " + i.Data1.String()
 	}
 
-	switch i.Opcode {
-	case 13, 54, 254, 255:
+	switch {
+	case i.isSingleOffsetDS():
 		if i.Offset0 > 0 {
 			s += fmt.Sprintf(" offset:%d", i.Offset0)
 		}
@@ -331,6 +562,30 @@ func (i Inst) dsString() string {
 	return s
 }
 
+func (i Inst) isSingleOffsetDS() bool {
+	switch i.Opcode {
+	case 13, 54, 254, 255:
+		return true
+	}
+
+	return i.isDSAtomic()
+}
+
+func (i Inst) isDSAtomic() bool {
+	switch {
+	case i.Opcode <= 12, i.Opcode >= 16 && i.Opcode <= 19, i.Opcode == 21:
+		return true
+	case i.Opcode >= 32 && i.Opcode <= 45, i.Opcode >= 48 && i.Opcode <= 51:
+		return true
+	case i.Opcode >= 64 && i.Opcode <= 76, i.Opcode >= 80 && i.Opcode <= 83:
+		return true
+	case i.Opcode >= 96 && i.Opcode <= 109, i.Opcode >= 112 && i.Opcode <= 115:
+		return true
+	}
+
+	return false
+}
+
 //nolint:gocyclo
 // String returns the disassembly of an instruction
 func (i Inst) String(file *elf.File) string {
@@ -345,6 +600,8 @@ func (i Inst) String(file *elf.File) string {
 		return i.vop2String()
 	case FLAT:
 		return i.flatString()
+	case MUBUF, MTBUF:
+		return i.bufferString()
 	case SOPP:
 		return i.soppString(file)
 	case VOPC:
@@ -355,6 +612,8 @@ func (i Inst) String(file *elf.File) string {
 		return i.vop3aString()
 	case VOP3b:
 		return i.vop3bString()
+	case VOP3P:
+		return i.vop3pString()
 	case SOP1:
 		return i.sop1String()
 	case SOPK:
@@ -14,6 +14,18 @@ func BytesToUint8(data []byte) uint8 {
 	return data[0]
 }
 
+// Uint16ToBytes returns the bytes representation of a uint16 value
+func Uint16ToBytes(num uint16) []byte {
+	data := make([]byte, 2)
+	binary.LittleEndian.PutUint16(data, num)
+	return data
+}
+
+// BytesToUint16 decode a uint16 number from bytes
+func BytesToUint16(data []byte) uint16 {
+	return binary.LittleEndian.Uint16(data)
+}
+
 // Uint32ToBytes returns the bytes representation of a uint32 value
 func Uint32ToBytes(num uint32) []byte {
 	data := make([]byte, 4)
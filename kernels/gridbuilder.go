@@ -14,6 +14,9 @@ type KernelLaunchInfo struct {
 	Packet     *HsaKernelDispatchPacket
 	PacketAddr uint64
 	WGFilter   WGFilterFunc
+
+	// WGRange, if set, limits the grid to the work-groups in the range.
+	WGRange *WGRange
 }
 
 // A GridBuilder is the unit that can build a grid and its internal structure
@@ -34,6 +37,7 @@ type gridBuilderImpl struct {
 	hsaco      *insts.HsaCo
 	packet     *HsaKernelDispatchPacket
 	filter     WGFilterFunc
+	wgRange    *WGRange
 	packetAddr uint64
 	numWG      int
 
@@ -47,10 +51,18 @@ func (b *gridBuilderImpl) SetKernel(
 	b.packet = info.Packet
 	b.packetAddr = info.PacketAddr
 	b.filter = info.WGFilter
+	b.wgRange = info.WGRange
 	b.xid = 0
 	b.yid = 0
 	b.zid = 0
 
+	if b.wgRange != nil {
+		x, y, _ := b.numWGInGrid()
+		b.xid = b.wgRange.Start % x
+		b.yid = b.wgRange.Start / x % y
+		b.zid = b.wgRange.Start / (x * y)
+	}
+
 	b.countWG()
 }
 
@@ -60,30 +72,37 @@ func (b *gridBuilderImpl) Skip(n int) {
 	}
 }
 
+func (b *gridBuilderImpl) numWGInGrid() (x, y, z int) {
+	x = int(b.packet.GridSizeX-1)/int(b.packet.WorkgroupSizeX) + 1
+	y = int(b.packet.GridSizeY-1)/int(b.packet.WorkgroupSizeY) + 1
+	z = int(b.packet.GridSizeZ-1)/int(b.packet.WorkgroupSizeZ) + 1
+
+	return x, y, z
+}
+
 func (b *gridBuilderImpl) countWG() {
-	x := int(b.packet.GridSizeX-1)/int(b.packet.WorkgroupSizeX) + 1
-	y := int(b.packet.GridSizeY-1)/int(b.packet.WorkgroupSizeY) + 1
-	z := int(b.packet.GridSizeZ-1)/int(b.packet.WorkgroupSizeZ) + 1
+	x, y, z := b.numWGInGrid()
+
+	start, end := 0, x*y*z
+	if b.wgRange != nil {
+		start, end = b.wgRange.Start, b.wgRange.End
+	}
 
 	if b.filter == nil {
-		b.numWG = x * y * z
+		b.numWG = end - start
 		return
 	}
 
 	b.numWG = 0
-	for i := 0; i < x; i++ {
-		for j := 0; j < y; j++ {
-			for k := 0; k < z; k++ {
-				wg := WorkGroup{
-					IDX: i,
-					IDY: j,
-					IDZ: k,
-				}
-
-				if b.filter(b.packet, &wg) {
-					b.numWG++
-				}
-			}
+	for id := start; id < end; id++ {
+		wg := WorkGroup{
+			IDX: id % x,
+			IDY: id / x % y,
+			IDZ: id / (x * y),
+		}
+
+		if b.filter(b.packet, &wg) {
+			b.numWG++
 		}
 	}
 }
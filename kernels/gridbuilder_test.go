@@ -192,4 +192,34 @@ var _ = Describe("GridBuilder", func() {
 
 		Expect(wg7).To(BeNil())
 	})
+
+	It("should build the work-groups in the range", func() {
+		codeObject := new(insts.HsaCo)
+		packet := new(HsaKernelDispatchPacket)
+		packet.WorkgroupSizeX = 16
+		packet.WorkgroupSizeY = 16
+		packet.WorkgroupSizeZ = 1
+		packet.GridSizeX = 33
+		packet.GridSizeY = 17
+		packet.GridSizeZ = 1
+		builder.SetKernel(KernelLaunchInfo{
+			CodeObject: codeObject,
+			Packet:     packet,
+			PacketAddr: 0,
+			WGRange:    &WGRange{Start: 2, End: 5},
+		})
+
+		Expect(builder.NumWG()).To(Equal(3))
+
+		wg1 := builder.NextWG()
+		wg2 := builder.NextWG()
+		wg3 := builder.NextWG()
+
+		Expect(wg1.IDX).To(Equal(2))
+		Expect(wg1.IDY).To(Equal(0))
+		Expect(wg2.IDX).To(Equal(0))
+		Expect(wg2.IDY).To(Equal(1))
+		Expect(wg3.IDX).To(Equal(1))
+		Expect(wg3.IDY).To(Equal(1))
+	})
 })
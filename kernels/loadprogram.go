@@ -3,99 +3,286 @@ package kernels
 import (
 	"bytes"
 	"debug/elf"
+	"errors"
+	"fmt"
+	"io"
 	"log"
+	"os"
+	"strings"
 
 	"github.com/sarchlab/mgpusim/v3/insts"
 )
 
 // LoadProgram loads program
 func LoadProgram(filePath, kernelName string) *insts.HsaCo {
-	executable, err := elf.Open(filePath)
+	data, err := os.ReadFile(filePath)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	symbols, err := executable.Symbols()
+	return LoadProgramFromMemory(data, kernelName)
+}
+
+// LoadProgramFromMemory loads program
+func LoadProgramFromMemory(data []byte, kernelName string) *insts.HsaCo {
+	hsaco, err := ParseProgramFromMemory(data, kernelName)
+	if errors.Is(err, ErrKernelNotFound) {
+		return nil
+	}
+
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	return hsaco
+}
+
+// ErrKernelNotFound is returned when the code object does not have a symbol
+// with the kernel name.
+var ErrKernelNotFound = errors.New("kernel not found")
+
+// ErrPrivateSegmentNotSupported is returned when the kernel uses the private
+// (scratch) memory of the work-items, which the simulator does not model.
+var ErrPrivateSegmentNotSupported = errors.New(
+	"private segment is not supported")
+
+// ParseProgramFromMemory extracts a kernel from an ELF code object. Different
+// from LoadProgramFromMemory, it returns an error if the data is not a valid
+// code object or if the kernel cannot be found.
+func ParseProgramFromMemory(
+	data []byte,
+	kernelName string,
+) (*insts.HsaCo, error) {
+	reader := bytes.NewReader(data)
+	executable, err := elf.NewFile(reader)
+	if err != nil {
+		return nil, err
+	}
+
 	textSection := executable.Section(".text")
 	if textSection == nil {
-		log.Fatal(".text section is not found")
+		return nil, errors.New(".text section is not found")
 	}
 
 	textSectionData, err := textSection.Data()
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 
 	// An empty kernel name is for the case where the symbol is not generated.
 	// Use the whole text section in this case.
 	if kernelName == "" {
 		hsaco := insts.NewHsaCoFromData(textSectionData)
-		return hsaco
+		setArch(hsaco, reader)
+		return hsaco, nil
+	}
+
+	symbols, err := executable.Symbols()
+	if err != nil {
+		return nil, err
 	}
 
 	for _, symbol := range symbols {
-		if symbol.Name == kernelName {
-			offset := symbol.Value - textSection.Offset
-			hsacoData := textSectionData[offset : offset+symbol.Size]
-			hsaco := insts.NewHsaCoFromData(hsacoData)
-			hsaco.Symbol = &symbol
+		if symbol.Name != kernelName {
+			continue
+		}
 
-			//fmt.Println(hsaco.Info())
+		var hsaco *insts.HsaCo
+		kd := findKernelDescriptor(symbols, kernelName)
+		if elf.ST_TYPE(symbol.Info) == elf.STT_FUNC && kd != nil {
+			hsaco, err = parseKernelWithDescriptor(
+				executable, reader, symbol, *kd)
+		} else {
+			hsaco, err = parseKernelWithHeader(
+				reader, symbol, textSection, textSectionData)
+		}
+
+		if err != nil {
+			return nil, err
+		}
 
-			return hsaco
+		if hsaco.WIPrivateSegmentByteSize > 0 {
+			return nil, fmt.Errorf("%w: kernel %s uses %d bytes per work-item",
+				ErrPrivateSegmentNotSupported, kernelName,
+				hsaco.WIPrivateSegmentByteSize)
+		}
+
+		return hsaco, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrKernelNotFound, kernelName)
+}
+
+// parseKernelWithHeader extracts a kernel from a code object of version 2,
+// where the kernel symbol covers both the header and the code in the .text
+// section.
+func parseKernelWithHeader(
+	reader io.ReaderAt,
+	symbol elf.Symbol,
+	textSection *elf.Section,
+	textSectionData []byte,
+) (*insts.HsaCo, error) {
+	offset := symbol.Value - textSection.Offset
+	if symbol.Value < textSection.Offset ||
+		offset+symbol.Size > uint64(len(textSectionData)) {
+		return nil, fmt.Errorf(
+			"kernel %s is out of the .text section", symbol.Name)
+	}
+
+	hsacoData := textSectionData[offset : offset+symbol.Size]
+	hsaco := insts.NewHsaCoFromData(hsacoData)
+	hsaco.Symbol = &symbol
+	setArch(hsaco, reader)
+
+	return hsaco, nil
+}
+
+// kernelDescriptorSuffix is appended to the kernel name to form the name of
+// the symbol of the kernel descriptor in code object v3 and later.
+const kernelDescriptorSuffix = ".kd"
+
+func findKernelDescriptor(
+	symbols []elf.Symbol,
+	kernelName string,
+) *elf.Symbol {
+	for i, symbol := range symbols {
+		if symbol.Name == kernelName+kernelDescriptorSuffix &&
+			elf.ST_TYPE(symbol.Info) == elf.STT_OBJECT {
+			return &symbols[i]
 		}
 	}
 
 	return nil
 }
 
-// LoadProgramFromMemory loads program
-func LoadProgramFromMemory(data []byte, kernelName string) *insts.HsaCo {
-	reader := bytes.NewReader(data)
-	executable, err := elf.NewFile(reader)
+// parseKernelWithDescriptor extracts a kernel from a code object of version 3
+// or later. These code objects keep the kernel properties in a kernel
+// descriptor in a data section and the code in a function symbol. Both
+// symbols hold virtual addresses.
+func parseKernelWithDescriptor(
+	executable *elf.File,
+	reader io.ReaderAt,
+	kernel, kd elf.Symbol,
+) (*insts.HsaCo, error) {
+	kdData, err := readSymbol(executable, kd, insts.KernelDescriptorSize)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 
-	symbols, err := executable.Symbols()
+	code, err := readSymbol(executable, kernel, kernel.Size)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 
-	textSection := executable.Section(".text")
-	if textSection == nil {
-		log.Fatal(".text section is not found")
+	flags, err := insts.ReadELFFlags(reader)
+	if err != nil {
+		return nil, err
 	}
 
-	textSectionData, err := textSection.Data()
+	arch, ok := insts.ArchFromELFFlags(flags)
+	if !ok {
+		return nil, fmt.Errorf(
+			"code object targets an unsupported GPU (e_flags 0x%x)", flags)
+	}
+
+	hsaco := insts.NewHsaCoFromKernelDescriptor(kdData, code, arch)
+
+	// As in code object v2, the symbol covers both the header and the code,
+	// which tells the compute units where the kernel ends.
+	kernel.Size = uint64(len(hsaco.Data))
+	hsaco.Symbol = &kernel
+
+	return hsaco, nil
+}
+
+func readSymbol(
+	executable *elf.File,
+	symbol elf.Symbol,
+	size uint64,
+) ([]byte, error) {
+	if int(symbol.Section) >= len(executable.Sections) {
+		return nil, fmt.Errorf("symbol %s is not in a section", symbol.Name)
+	}
+
+	section := executable.Sections[symbol.Section]
+	if symbol.Value < section.Addr ||
+		symbol.Value-section.Addr+size > section.Size {
+		return nil, fmt.Errorf(
+			"symbol %s is out of the %s section", symbol.Name, section.Name)
+	}
+
+	data := make([]byte, size)
+	_, err := section.ReadAt(data, int64(symbol.Value-section.Addr))
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 
-	// An empty kernel name is for the case where the symbol is not generated.
-	// Use the whole text section in this case.
-	if kernelName == "" {
-		hsaco := insts.NewHsaCoFromData(textSectionData)
-		return hsaco
+	return data, nil
+}
+
+// setArch sets the architecture of the kernel to the GPU that the e_flags of
+// the ELF file names. If the flags do not name a GPU, the kernel keeps the
+// architecture that its header implies.
+func setArch(hsaco *insts.HsaCo, r io.ReaderAt) {
+	flags, err := insts.ReadELFFlags(r)
+	if err != nil {
+		return
 	}
 
-	for _, symbol := range symbols {
-		if symbol.Name == kernelName {
-			offset := symbol.Value - textSection.Offset
-			hsacoData := textSectionData[offset : offset+symbol.Size]
-			hsaco := insts.NewHsaCoFromData(hsacoData)
-			symbolCopy := symbol
-			hsaco.Symbol = &symbolCopy
+	if arch, ok := insts.ArchFromELFFlags(flags); ok {
+		hsaco.Arch = arch
+	}
+}
+
+// symTypeAMDGPUHSAKernel is the ELF symbol type of the kernels in code object
+// v2 (STT_AMDGPU_HSA_KERNEL).
+const symTypeAMDGPUHSAKernel = elf.SymType(10)
 
-			//fmt.Println(hsaco.Info())
+// KernelNames returns the names of all the kernels in an ELF code object, in
+// the order that they appear in the symbol table. Code object v2 marks the
+// kernels with STT_AMDGPU_HSA_KERNEL, while later versions use functions that
+// have a kernel descriptor.
+func KernelNames(data []byte) ([]string, error) {
+	executable, err := elf.NewFile(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	symbols, err := executable.Symbols()
+	if err != nil {
+		return nil, err
+	}
 
-			return hsaco
+	descriptors := make(map[string]bool)
+	for _, symbol := range symbols {
+		if elf.ST_TYPE(symbol.Info) == elf.STT_OBJECT &&
+			strings.HasSuffix(symbol.Name, kernelDescriptorSuffix) {
+			descriptors[symbol.Name] = true
 		}
 	}
 
-	return nil
+	names := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, symbol := range symbols {
+		if !isKernelSymbol(symbol, descriptors) ||
+			symbol.Size == 0 || seen[symbol.Name] {
+			continue
+		}
+
+		seen[symbol.Name] = true
+		names = append(names, symbol.Name)
+	}
+
+	return names, nil
+}
+
+func isKernelSymbol(symbol elf.Symbol, descriptors map[string]bool) bool {
+	switch elf.ST_TYPE(symbol.Info) {
+	case symTypeAMDGPUHSAKernel:
+		return true
+	case elf.STT_FUNC:
+		return descriptors[symbol.Name+kernelDescriptorSuffix]
+	default:
+		return false
+	}
 }
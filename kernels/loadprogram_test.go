@@ -0,0 +1,124 @@
+package kernels
+
+import (
+	"bytes"
+	"debug/elf"
+	"encoding/binary"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/mgpusim/v3/insts"
+)
+
+var _ = Describe("ParseProgramFromMemory", func() {
+	var data []byte
+
+	BeforeEach(func() {
+		var err error
+		data, err = os.ReadFile("../driver/memcopy.hsaco")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should parse a kernel", func() {
+		hsaco, err := ParseProgramFromMemory(data, "copyKernel")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hsaco.Symbol.Name).To(Equal("copyKernel"))
+		Expect(hsaco.KernargSegmentByteSize).NotTo(BeZero())
+	})
+
+	It("should return an error if the kernel does not exist", func() {
+		_, err := ParseProgramFromMemory(data, "noSuchKernel")
+
+		Expect(err).To(MatchError(ErrKernelNotFound))
+	})
+
+	It("should return an error if the data is not an ELF file", func() {
+		_, err := ParseProgramFromMemory([]byte("not an elf"), "copyKernel")
+
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should list the kernels", func() {
+		names, err := KernelNames(data)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names).To(Equal([]string{"copyKernel"}))
+	})
+})
+
+var _ = Describe("ParseProgramFromMemory with a kernel descriptor", func() {
+	var data []byte
+
+	BeforeEach(func() {
+		var err error
+		data, err = os.ReadFile("testdata/addone_gfx900.hsaco")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should parse a kernel", func() {
+		hsaco, err := ParseProgramFromMemory(data, "addOne")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hsaco.Symbol.Name).To(Equal("addOne"))
+		Expect(hsaco.Arch).To(Equal(insts.GFX9))
+		Expect(hsaco.KernargSegmentByteSize).To(Equal(uint64(16)))
+		Expect(hsaco.EnableSgprPrivateSegmentBuffer()).To(BeTrue())
+		Expect(hsaco.EnableSgprKernelArgSegmentPtr()).To(BeTrue())
+		Expect(hsaco.EnableSgprDispatchPtr()).To(BeFalse())
+		Expect(hsaco.EnableSgprWorkGroupIDX()).To(BeTrue())
+		Expect(hsaco.WFSgprCount).To(Equal(uint16(16)))
+		Expect(hsaco.WIVgprCount).To(Equal(uint16(4)))
+		Expect(hsaco.InstructionData()).To(HaveLen(84))
+		Expect(hsaco.Symbol.Size).To(Equal(uint64(len(hsaco.Data))))
+	})
+
+	It("should keep the header when the data is parsed again", func() {
+		hsaco, err := ParseProgramFromMemory(data, "addOne")
+		Expect(err).NotTo(HaveOccurred())
+
+		reparsed := insts.NewHsaCoFromData(hsaco.Data)
+
+		Expect(*reparsed.HsaCoHeader).To(Equal(*hsaco.HsaCoHeader))
+		Expect(reparsed.Arch).To(Equal(insts.GFX9))
+	})
+
+	It("should decode the kernel", func() {
+		hsaco, err := ParseProgramFromMemory(data, "addOne")
+		Expect(err).NotTo(HaveOccurred())
+
+		disassembler := insts.NewDisassembler()
+		buf := hsaco.InstructionData()
+		names := []string{}
+		for len(buf) > 0 {
+			inst, err := disassembler.DecodeArch(hsaco.Arch, buf)
+			Expect(err).NotTo(HaveOccurred())
+
+			names = append(names, inst.InstName)
+			buf = buf[inst.ByteSize:]
+		}
+
+		Expect(names).To(ContainElements(
+			"global_load_dword", "global_store_dword"))
+		Expect(names[len(names)-1]).To(Equal("s_endpgm"))
+	})
+
+	It("should reject the kernels that use the private segment", func() {
+		executable, err := elf.NewFile(bytes.NewReader(data))
+		Expect(err).NotTo(HaveOccurred())
+		kdOffset := executable.Section(".rodata").Offset
+		binary.LittleEndian.PutUint32(data[kdOffset+4:], 16)
+
+		_, err = ParseProgramFromMemory(data, "addOne")
+
+		Expect(err).To(MatchError(ErrPrivateSegmentNotSupported))
+	})
+
+	It("should list the kernels", func() {
+		names, err := KernelNames(data)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names).To(Equal([]string{"addOne"}))
+	})
+})
@@ -0,0 +1,26 @@
+; A kernel that adds one to each element of the input, used to test loading
+; code objects of version 3 and later. Build the code object with:
+;
+;   llc -mtriple=amdgcn-amd-amdhsa -mcpu=gfx900 -filetype=obj \
+;       -o addone_gfx900.o addone_gfx900.ll
+;   ld.lld -shared -o addone_gfx900.hsaco addone_gfx900.o
+
+target triple = "amdgcn-amd-amdhsa"
+
+declare i32 @llvm.amdgcn.workitem.id.x()
+declare i32 @llvm.amdgcn.workgroup.id.x()
+
+define amdgpu_kernel void @addOne(i32 addrspace(1)* %out, i32 addrspace(1)* %in) {
+entry:
+  %tid = call i32 @llvm.amdgcn.workitem.id.x()
+  %wg = call i32 @llvm.amdgcn.workgroup.id.x()
+  %base = mul i32 %wg, 64
+  %id = add i32 %base, %tid
+  %idx = zext i32 %id to i64
+  %src = getelementptr i32, i32 addrspace(1)* %in, i64 %idx
+  %v = load i32, i32 addrspace(1)* %src, align 4
+  %r = add i32 %v, 1
+  %dst = getelementptr i32, i32 addrspace(1)* %out, i64 %idx
+  store i32 %r, i32 addrspace(1)* %dst, align 4
+  ret void
+}
@@ -0,0 +1,139 @@
+package kernels
+
+import "github.com/sarchlab/mgpusim/v3/insts"
+
+// WfStateByteSize is the number of bytes at the beginning of the saved
+// context of a wavefront that hold the PC, EXEC, VCC, M0, SCC and the state
+// of the wavefront.
+const WfStateByteSize = 64
+
+// The layout of the saved context of a work-group is as follows. The contexts
+// of the wavefronts come first, each taking WfContextByteSize bytes. A
+// wavefront context starts with the wavefront state, followed by the SGPRs and
+// then the VGPRs of each lane. The content of the LDS follows the contexts of
+// all the wavefronts. All the parts are aligned to 64 bytes so that they start
+// at the beginning of a cache line.
+const contextAlignment = 64
+
+func alignContext(n uint64) uint64 {
+	return (n + contextAlignment - 1) / contextAlignment * contextAlignment
+}
+
+// WfContextByteSize returns the number of bytes that the saved context of a
+// wavefront of the kernel takes.
+func WfContextByteSize(co *insts.HsaCo) uint64 {
+	return alignContext(WfStateByteSize +
+		uint64(co.WFSgprCount)*4 +
+		uint64(co.WIVgprCount)*4*64)
+}
+
+// WfVGPRContextOffset returns the offset of the VGPRs of a lane in the saved
+// context of a wavefront.
+func WfVGPRContextOffset(co *insts.HsaCo, laneID int) uint64 {
+	return WfStateByteSize +
+		uint64(co.WFSgprCount)*4 +
+		uint64(laneID)*uint64(co.WIVgprCount)*4
+}
+
+// NumWfInWG returns the number of wavefronts in a full work-group of the
+// kernel.
+func NumWfInWG(pkt *HsaKernelDispatchPacket) int {
+	numWI := int(pkt.WorkgroupSizeX) *
+		int(pkt.WorkgroupSizeY) *
+		int(pkt.WorkgroupSizeZ)
+
+	return (numWI + 63) / 64
+}
+
+// WGLDSContextOffset returns the offset of the LDS content in the saved
+// context of a work-group.
+func WGLDSContextOffset(
+	co *insts.HsaCo,
+	pkt *HsaKernelDispatchPacket,
+) uint64 {
+	return uint64(NumWfInWG(pkt)) * WfContextByteSize(co)
+}
+
+// WGContextByteSize returns the number of bytes that the saved context of a
+// work-group of the kernel takes.
+func WGContextByteSize(co *insts.HsaCo, pkt *HsaKernelDispatchPacket) uint64 {
+	return WGLDSContextOffset(co, pkt) +
+		alignContext(uint64(pkt.GroupSegmentSize))
+}
+
+// The resources of a compute unit. They bound the number of work-groups of a
+// kernel that a compute unit can hold at the same time.
+const (
+	cuNumSIMD           = 4
+	simdNumWfSlots      = 10
+	simdNumVGPRsPerLane = 256
+	cuNumSGPRs          = 3200
+	cuLDSByteSize       = 64 * 1024
+)
+
+// NumWG returns the number of work-groups in the grid of the kernel.
+func NumWG(pkt *HsaKernelDispatchPacket) int {
+	numWGX := (int(pkt.GridSizeX)-1)/int(pkt.WorkgroupSizeX) + 1
+	numWGY := (int(pkt.GridSizeY)-1)/int(pkt.WorkgroupSizeY) + 1
+	numWGZ := (int(pkt.GridSizeZ)-1)/int(pkt.WorkgroupSizeZ) + 1
+
+	return numWGX * numWGY * numWGZ
+}
+
+// MaxNumWGPerCU returns the largest number of work-groups of the kernel that
+// a compute unit can hold at the same time, as limited by the wavefront
+// slots, the registers and the LDS.
+func MaxNumWGPerCU(co *insts.HsaCo, pkt *HsaKernelDispatchPacket) int {
+	numWfPerSIMD := simdNumWfSlots
+	if co.WIVgprCount > 0 {
+		numWfPerSIMD = min(numWfPerSIMD,
+			simdNumVGPRsPerLane/int(co.WIVgprCount))
+	}
+
+	numWf := cuNumSIMD * numWfPerSIMD
+	if co.WFSgprCount > 0 {
+		numWf = min(numWf, cuNumSGPRs/int(co.WFSgprCount))
+	}
+
+	numWG := numWf / NumWfInWG(pkt)
+	if pkt.GroupSegmentSize > 0 {
+		numWG = min(numWG, cuLDSByteSize/int(pkt.GroupSegmentSize))
+	}
+
+	return numWG
+}
+
+// NumContextSaveSlots returns the number of work-group contexts that the
+// context save area of the kernel holds. Only the work-groups that run at the
+// same time can be preempted together, so the area does not need a slot for
+// every work-group in the grid.
+func NumContextSaveSlots(
+	co *insts.HsaCo,
+	pkt *HsaKernelDispatchPacket,
+	numCU int,
+) int {
+	return min(NumWG(pkt), numCU*MaxNumWGPerCU(co, pkt))
+}
+
+// ContextSaveAreaByteSize returns the number of bytes needed to save the
+// contexts of the work-groups of the kernel that a GPU with numCU compute
+// units can preempt at the same time.
+func ContextSaveAreaByteSize(
+	co *insts.HsaCo,
+	pkt *HsaKernelDispatchPacket,
+	numCU int,
+) uint64 {
+	return uint64(NumContextSaveSlots(co, pkt, numCU)) *
+		WGContextByteSize(co, pkt)
+}
+
+// WGContextAddr returns the address of a slot in the context save area of the
+// kernel.
+func WGContextAddr(
+	saveArea uint64,
+	co *insts.HsaCo,
+	pkt *HsaKernelDispatchPacket,
+	slot int,
+) uint64 {
+	return saveArea + uint64(slot)*WGContextByteSize(co, pkt)
+}
@@ -0,0 +1,77 @@
+package kernels
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/mgpusim/v3/insts"
+)
+
+var _ = Describe("Work-group context", func() {
+	var (
+		co  *insts.HsaCo
+		pkt *HsaKernelDispatchPacket
+	)
+
+	BeforeEach(func() {
+		co = insts.NewHsaCo()
+		co.HsaCoHeader = new(insts.HsaCoHeader)
+		co.WFSgprCount = 10
+		co.WIVgprCount = 3
+
+		pkt = &HsaKernelDispatchPacket{
+			WorkgroupSizeX:   100,
+			WorkgroupSizeY:   1,
+			WorkgroupSizeZ:   1,
+			GridSizeX:        250,
+			GridSizeY:        2,
+			GridSizeZ:        1,
+			GroupSegmentSize: 100,
+		}
+	})
+
+	It("should lay out the context of a wavefront", func() {
+		Expect(WfContextByteSize(co)).To(Equal(uint64(896)))
+		Expect(WfVGPRContextOffset(co, 0)).To(Equal(uint64(104)))
+		Expect(WfVGPRContextOffset(co, 2)).To(Equal(uint64(128)))
+	})
+
+	It("should lay out the context of a work-group", func() {
+		Expect(NumWfInWG(pkt)).To(Equal(2))
+		Expect(WGLDSContextOffset(co, pkt)).To(Equal(uint64(1792)))
+		Expect(WGContextByteSize(co, pkt)).To(Equal(uint64(1920)))
+	})
+
+	It("should size the save area by the work-groups that run together",
+		func() {
+			Expect(NumWG(pkt)).To(Equal(6))
+			Expect(MaxNumWGPerCU(co, pkt)).To(Equal(20))
+			Expect(ContextSaveAreaByteSize(co, pkt, 64)).
+				To(Equal(uint64(6 * 1920)))
+
+			pkt.GridSizeX = 100 * 1000
+			pkt.GridSizeY = 1000
+
+			Expect(NumContextSaveSlots(co, pkt, 4)).To(Equal(80))
+			Expect(ContextSaveAreaByteSize(co, pkt, 4)).
+				To(Equal(uint64(80 * 1920)))
+		})
+
+	It("should limit the work-groups per CU by the registers and the LDS",
+		func() {
+			co.WIVgprCount = 100
+			Expect(MaxNumWGPerCU(co, pkt)).To(Equal(4))
+
+			co.WIVgprCount = 3
+			co.WFSgprCount = 800
+			Expect(MaxNumWGPerCU(co, pkt)).To(Equal(2))
+
+			co.WFSgprCount = 10
+			pkt.GroupSegmentSize = 16 * 1024
+			Expect(MaxNumWGPerCU(co, pkt)).To(Equal(4))
+		})
+
+	It("should find the slots in the save area", func() {
+		Expect(WGContextAddr(0x10000, co, pkt, 4)).
+			To(Equal(uint64(0x10000 + 4*1920)))
+	})
+})
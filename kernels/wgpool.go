@@ -0,0 +1,56 @@
+package kernels
+
+import "sync"
+
+// A WGRange is a range of work-groups, identified by their flattened IDs. The
+// range includes Start and excludes End.
+type WGRange struct {
+	Start, End int
+}
+
+// A WGPool holds the work-groups of a kernel that several GPUs run together.
+// The dispatchers of the GPUs take chunks of consecutive work-groups from the
+// pool whenever they run out of work-groups to dispatch, so that the GPUs that
+// run ahead take over the work of the others.
+type WGPool struct {
+	lock      sync.Mutex
+	numWG     int
+	chunkSize int
+	nextWG    int
+}
+
+// NewWGPool creates a pool that hands out the given number of work-groups in
+// chunks of the given size.
+func NewWGPool(numWG, chunkSize int) *WGPool {
+	return &WGPool{
+		numWG:     numWG,
+		chunkSize: chunkSize,
+	}
+}
+
+// Claim takes the next chunk of work-groups from the pool. It returns false if
+// the pool is empty.
+func (p *WGPool) Claim() (WGRange, bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.nextWG >= p.numWG {
+		return WGRange{}, false
+	}
+
+	wgs := WGRange{
+		Start: p.nextWG,
+		End:   min(p.nextWG+p.chunkSize, p.numWG),
+	}
+	p.nextWG = wgs.End
+
+	return wgs, true
+}
+
+// IsEmpty checks if all the work-groups have been claimed.
+func (p *WGPool) IsEmpty() bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return p.nextWG >= p.numWG
+}
@@ -0,0 +1,29 @@
+package kernels
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WGPool", func() {
+	It("should hand out chunks until the pool is empty", func() {
+		pool := NewWGPool(5, 2)
+
+		wgs, ok := pool.Claim()
+		Expect(ok).To(BeTrue())
+		Expect(wgs).To(Equal(WGRange{Start: 0, End: 2}))
+
+		wgs, ok = pool.Claim()
+		Expect(ok).To(BeTrue())
+		Expect(wgs).To(Equal(WGRange{Start: 2, End: 4}))
+		Expect(pool.IsEmpty()).To(BeFalse())
+
+		wgs, ok = pool.Claim()
+		Expect(ok).To(BeTrue())
+		Expect(wgs).To(Equal(WGRange{Start: 4, End: 5}))
+		Expect(pool.IsEmpty()).To(BeTrue())
+
+		_, ok = pool.Claim()
+		Expect(ok).To(BeFalse())
+	})
+})
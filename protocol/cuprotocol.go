@@ -206,6 +206,12 @@ type MapWGReq struct {
 	WorkGroup  *kernels.WorkGroup
 	PID        vm.PID
 	Wavefronts []WfDispatchLocation
+
+	// RestoreContext asks the compute unit to load the context of a
+	// preempted work-group from ContextAddr, rather than to start the
+	// work-group from the beginning.
+	RestoreContext bool
+	ContextAddr    uint64
 }
 
 // Meta returns the meta data associated with the MapWGReq.
@@ -220,6 +226,9 @@ type MapWGReqBuilder struct {
 	pid      vm.PID
 	wg       *kernels.WorkGroup
 	wfs      []WfDispatchLocation
+
+	restoreContext bool
+	contextAddr    uint64
 }
 
 // WithSendTime sets the send time.
@@ -258,6 +267,14 @@ func (b MapWGReqBuilder) AddWf(wf WfDispatchLocation) MapWGReqBuilder {
 	return b
 }
 
+// WithRestoredContext makes the work-group continue from the context saved at
+// the given address.
+func (b MapWGReqBuilder) WithRestoredContext(addr uint64) MapWGReqBuilder {
+	b.restoreContext = true
+	b.contextAddr = addr
+	return b
+}
+
 // Build creates the MapWGReq.
 func (b MapWGReqBuilder) Build() *MapWGReq {
 	r := &MapWGReq{}
@@ -268,9 +285,137 @@ func (b MapWGReqBuilder) Build() *MapWGReq {
 	r.PID = b.pid
 	r.WorkGroup = b.wg
 	r.Wavefronts = b.wfs
+	r.RestoreContext = b.restoreContext
+	r.ContextAddr = b.contextAddr
+	return r
+}
+
+// PreemptWGReq asks a compute unit to stop a work-group at the next
+// instruction boundary and to save its context to the memory.
+type PreemptWGReq struct {
+	sim.MsgMeta
+
+	// MapWGReqID is the ID of the MapWGReq that dispatched the work-group.
+	MapWGReqID  string
+	ContextAddr uint64
+}
+
+// Meta returns the meta data associated with the PreemptWGReq.
+func (r *PreemptWGReq) Meta() *sim.MsgMeta {
+	return &r.MsgMeta
+}
+
+// PreemptWGReqBuilder can build PreemptWGReqs.
+type PreemptWGReqBuilder struct {
+	sendTime    sim.VTimeInSec
+	src, dst    sim.Port
+	mapWGReqID  string
+	contextAddr uint64
+}
+
+// WithSendTime sets the send time.
+func (b PreemptWGReqBuilder) WithSendTime(
+	t sim.VTimeInSec,
+) PreemptWGReqBuilder {
+	b.sendTime = t
+	return b
+}
+
+// WithSrc sets the source of the message.
+func (b PreemptWGReqBuilder) WithSrc(src sim.Port) PreemptWGReqBuilder {
+	b.src = src
+	return b
+}
+
+// WithDst sets the destination of the message.
+func (b PreemptWGReqBuilder) WithDst(dst sim.Port) PreemptWGReqBuilder {
+	b.dst = dst
+	return b
+}
+
+// WithMapWGReqID sets the ID of the MapWGReq that dispatched the work-group
+// to preempt.
+func (b PreemptWGReqBuilder) WithMapWGReqID(id string) PreemptWGReqBuilder {
+	b.mapWGReqID = id
+	return b
+}
+
+// WithContextAddr sets the address to save the context of the work-group to.
+func (b PreemptWGReqBuilder) WithContextAddr(addr uint64) PreemptWGReqBuilder {
+	b.contextAddr = addr
+	return b
+}
+
+// Build creates the PreemptWGReq.
+func (b PreemptWGReqBuilder) Build() *PreemptWGReq {
+	r := &PreemptWGReq{}
+	r.ID = sim.GetIDGenerator().Generate()
+	r.SendTime = b.sendTime
+	r.Src = b.src
+	r.Dst = b.dst
+	r.MapWGReqID = b.mapWGReqID
+	r.ContextAddr = b.contextAddr
 	return r
 }
 
+// WGPreemptedMsg notifies the dispatcher that the context of a work-group is
+// saved and that the work-group no longer occupies the compute unit.
+type WGPreemptedMsg struct {
+	sim.MsgMeta
+
+	// RspTo is the ID of the MapWGReq that dispatched the work-group.
+	RspTo string
+}
+
+// Meta returns the meta data associated with the WGPreemptedMsg.
+func (r *WGPreemptedMsg) Meta() *sim.MsgMeta {
+	return &r.MsgMeta
+}
+
+// WGPreemptedMsgBuilder can build WGPreemptedMsgs.
+type WGPreemptedMsgBuilder struct {
+	sendTime sim.VTimeInSec
+	src, dst sim.Port
+	rspTo    string
+}
+
+// WithSendTime sets the send time.
+func (b WGPreemptedMsgBuilder) WithSendTime(
+	t sim.VTimeInSec,
+) WGPreemptedMsgBuilder {
+	b.sendTime = t
+	return b
+}
+
+// WithSrc sets the source of the message.
+func (b WGPreemptedMsgBuilder) WithSrc(src sim.Port) WGPreemptedMsgBuilder {
+	b.src = src
+	return b
+}
+
+// WithDst sets the destination of the message.
+func (b WGPreemptedMsgBuilder) WithDst(dst sim.Port) WGPreemptedMsgBuilder {
+	b.dst = dst
+	return b
+}
+
+// WithRspTo sets the ID of the MapWGReq that dispatched the work-group.
+func (b WGPreemptedMsgBuilder) WithRspTo(rspTo string) WGPreemptedMsgBuilder {
+	b.rspTo = rspTo
+	return b
+}
+
+// Build creates the WGPreemptedMsg.
+func (b WGPreemptedMsgBuilder) Build() *WGPreemptedMsg {
+	msg := &WGPreemptedMsg{}
+	msg.ID = sim.GetIDGenerator().Generate()
+	msg.SendTime = b.sendTime
+	msg.Src = b.src
+	msg.Dst = b.dst
+	msg.RspTo = b.rspTo
+	return msg
+}
+
 // WGCompletionMsg notifies the dispatcher that a work-group is completed
 // execution
 type WGCompletionMsg struct {
@@ -38,6 +38,28 @@ type LaunchKernelReq struct {
 	PacketAddress uint64
 	HsaCo         *insts.HsaCo
 	WGFilter      kernels.WGFilterFunc
+
+	// WGPool, if set, is the pool that the GPU takes the work-groups of the
+	// kernel from. The GPUs that run the kernel together share the pool.
+	WGPool *kernels.WGPool
+
+	// Priority is the priority of the queue that launches the kernel. When
+	// kernels compete for the same CUs, the kernel with the higher priority
+	// gets the CU resources first.
+	Priority int
+
+	// CUMask limits the CUs that the kernel can run on. Bit i of word i/32
+	// enables the i-th CU. An empty mask enables all the CUs.
+	CUMask []uint32
+
+	// ContextSaveArea is the address of the memory where the CUs save the
+	// contexts of the preempted work-groups. Zero means that the kernel can
+	// only be preempted at the work-group boundaries.
+	ContextSaveArea uint64
+
+	// NumContextSaveSlots is the number of work-group contexts that the
+	// context save area holds.
+	NumContextSaveSlots int
 }
 
 // Meta returns the meta data associated with the message.
@@ -10,9 +10,22 @@ import (
 	"github.com/sarchlab/mgpusim/v3/samples/runner"
 )
 
+var firPriority = flag.Int("fir-priority", 0,
+	"The priority of the queues that run FIR. Higher values run first.")
+var firCUs = flag.String("fir-cus", "",
+	"The CUs that FIR can run on, such as 0-31,40. Empty means all the CUs.")
+var bsPriority = flag.Int("bs-priority", 0,
+	"The priority of the queues that run bitonic sort. Higher values run first.")
+var bsCUs = flag.String("bs-cus", "",
+	"The CUs that bitonic sort can run on, such as 32-63. Empty means all "+
+		"the CUs.")
+
 func main() {
 	flag.Parse()
 
+	firCUMask := runner.ParseCUMask(*firCUs)
+	bsCUMask := runner.ParseCUMask(*bsCUs)
+
 	runner := new(runner.Runner).ParseFlag().Init()
 
 	firBenchmark := fir.NewBenchmark(runner.Driver())
@@ -23,6 +36,12 @@ func main() {
 	bsBenchmark.Length = 64
 	bsBenchmark.SelectGPU([]int{1})
 
+	driver := runner.Driver()
+	driver.SetQueuePriority(firBenchmark.Context(), *firPriority)
+	driver.SetQueueCUMask(firBenchmark.Context(), firCUMask)
+	driver.SetQueuePriority(bsBenchmark.Context(), *bsPriority)
+	driver.SetQueueCUMask(bsBenchmark.Context(), bsCUMask)
+
 	runner.AddBenchmarkWithoutSettingGPUsToUse(firBenchmark)
 	runner.AddBenchmarkWithoutSettingGPUsToUse(bsBenchmark)
 
@@ -0,0 +1,429 @@
+package runner
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/sarchlab/akita/v3/mem/mem"
+	"gopkg.in/yaml.v3"
+)
+
+// log2CacheLineSize is the cache line size that all the caches use.
+const log2CacheLineSize = 6
+
+// maxDRAMSize is the largest DRAM that a GPU can have.
+const maxDRAMSize = ByteSize(4 * mem.GB)
+
+// A ByteSize is a number of bytes. In a configuration file, it can be written
+// as a number or as a string with a KB, MB or GB suffix, for example, "16KB".
+// The suffixes are powers of 1024.
+type ByteSize uint64
+
+// ParseByteSize converts a string like "16KB" to a ByteSize.
+func ParseByteSize(s string) (ByteSize, error) {
+	units := []struct {
+		suffix string
+		size   uint64
+	}{
+		{"GB", mem.GB}, {"MB", mem.MB}, {"KB", mem.KB}, {"B", 1},
+	}
+
+	str := strings.ToUpper(strings.TrimSpace(s))
+	unit := uint64(1)
+	for _, u := range units {
+		if strings.HasSuffix(str, u.suffix) {
+			str = strings.TrimSpace(strings.TrimSuffix(str, u.suffix))
+			unit = u.size
+			break
+		}
+	}
+
+	n, err := strconv.ParseUint(str, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+
+	if n > math.MaxUint64/unit {
+		return 0, fmt.Errorf("size %q is too large", s)
+	}
+
+	return ByteSize(n * unit), nil
+}
+
+// UnmarshalJSON accepts both numbers and strings with units.
+func (s *ByteSize) UnmarshalJSON(data []byte) error {
+	var n uint64
+	if err := json.Unmarshal(data, &n); err == nil {
+		*s = ByteSize(n)
+		return nil
+	}
+
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return fmt.Errorf("invalid size %s", data)
+	}
+
+	size, err := ParseByteSize(str)
+	if err != nil {
+		return err
+	}
+
+	*s = size
+
+	return nil
+}
+
+// UnmarshalYAML accepts both numbers and strings with units.
+func (s *ByteSize) UnmarshalYAML(value *yaml.Node) error {
+	var n uint64
+	if err := value.Decode(&n); err == nil {
+		*s = ByteSize(n)
+		return nil
+	}
+
+	size, err := ParseByteSize(value.Value)
+	if err != nil {
+		return fmt.Errorf("line %d: %w", value.Line, err)
+	}
+
+	*s = size
+
+	return nil
+}
+
+// A CacheConfig sets the capacity and the timing of a cache.
+type CacheConfig struct {
+	Size        ByteSize `json:"size" yaml:"size"`
+	Ways        int      `json:"ways" yaml:"ways"`
+	BankLatency int      `json:"bank_latency" yaml:"bank_latency"`
+	MSHREntries int      `json:"mshr_entries" yaml:"mshr_entries"`
+}
+
+// A TLBConfig sets the capacity of a TLB.
+type TLBConfig struct {
+	Sets        int `json:"sets" yaml:"sets"`
+	Ways        int `json:"ways" yaml:"ways"`
+	MSHREntries int `json:"mshr_entries" yaml:"mshr_entries"`
+}
+
+// A DRAMConfig sets the capacity and the timing of the DRAM of a GPU. The
+// DRAM is split evenly between the memory controllers. The timing parameters
+// are in DRAM cycles.
+type DRAMConfig struct {
+	Size                 ByteSize `json:"size" yaml:"size"`
+	FreqMHz              float64  `json:"freq_mhz" yaml:"freq_mhz"`
+	BusWidth             int      `json:"bus_width" yaml:"bus_width"`
+	DeviceWidth          int      `json:"device_width" yaml:"device_width"`
+	BurstLength          int      `json:"burst_length" yaml:"burst_length"`
+	NumBankGroups        int      `json:"num_bank_groups" yaml:"num_bank_groups"`
+	NumBanks             int      `json:"num_banks" yaml:"num_banks"`
+	NumRows              int      `json:"num_rows" yaml:"num_rows"`
+	NumCols              int      `json:"num_cols" yaml:"num_cols"`
+	CommandQueueSize     int      `json:"command_queue_size" yaml:"command_queue_size"`
+	TransactionQueueSize int      `json:"transaction_queue_size" yaml:"transaction_queue_size"`
+	TCL                  int      `json:"tCL" yaml:"tCL"`
+	TCWL                 int      `json:"tCWL" yaml:"tCWL"`
+	TRCDRD               int      `json:"tRCDRD" yaml:"tRCDRD"`
+	TRCDWR               int      `json:"tRCDWR" yaml:"tRCDWR"`
+	TRP                  int      `json:"tRP" yaml:"tRP"`
+	TRAS                 int      `json:"tRAS" yaml:"tRAS"`
+}
+
+// rankSize returns the number of bytes in a rank.
+func (c DRAMConfig) rankSize() uint64 {
+	bankSize := c.NumCols * c.NumRows * c.DeviceWidth
+	devicePerRank := c.BusWidth / c.DeviceWidth
+
+	return uint64(bankSize*devicePerRank*c.NumBanks) / 8
+}
+
+// numRanks returns the number of ranks that each memory controller needs to
+// provide its share of the DRAM.
+func (c DRAMConfig) numRanks(numMemoryBanks int) int {
+	memBankSize := uint64(c.Size) / uint64(numMemoryBanks)
+
+	return int(memBankSize / c.rankSize())
+}
+
+// A GPUConfig sets the shape of a GPU. Each shader array has a number of
+// CUs, each with an L1V cache and an L1V TLB, and shares an L1S cache, an
+// L1I cache and their TLBs among the CUs. Each memory bank has an L2 cache
+// and a memory controller. The L2 cache size is the total of all the banks.
+// An L2 TLB with zero sets has as many entries as there are pages in the
+// DRAM.
+type GPUConfig struct {
+	NumShaderArrays                int         `json:"num_shader_arrays" yaml:"num_shader_arrays"`
+	NumCUsPerShaderArray           int         `json:"num_cus_per_shader_array" yaml:"num_cus_per_shader_array"`
+	NumMemoryBanks                 int         `json:"num_memory_banks" yaml:"num_memory_banks"`
+	Log2MemoryBankInterleavingSize uint64      `json:"log2_memory_bank_interleaving_size" yaml:"log2_memory_bank_interleaving_size"`
+	L1VCache                       CacheConfig `json:"l1v_cache" yaml:"l1v_cache"`
+	L1SCache                       CacheConfig `json:"l1s_cache" yaml:"l1s_cache"`
+	L1ICache                       CacheConfig `json:"l1i_cache" yaml:"l1i_cache"`
+	L2Cache                        CacheConfig `json:"l2_cache" yaml:"l2_cache"`
+	L1VTLB                         TLBConfig   `json:"l1v_tlb" yaml:"l1v_tlb"`
+	L1STLB                         TLBConfig   `json:"l1s_tlb" yaml:"l1s_tlb"`
+	L1ITLB                         TLBConfig   `json:"l1i_tlb" yaml:"l1i_tlb"`
+	L2TLB                          TLBConfig   `json:"l2_tlb" yaml:"l2_tlb"`
+	DRAM                           DRAMConfig  `json:"dram" yaml:"dram"`
+}
+
+// A PlatformConfig sets the number and the shape of the GPUs of a timing
+// simulation platform. A NumGPUs of zero builds as many GPUs as the -gpus or
+// -unified-gpus flag requires.
+type PlatformConfig struct {
+	NumGPUs      int       `json:"num_gpus" yaml:"num_gpus"`
+	Log2PageSize uint64    `json:"log2_page_size" yaml:"log2_page_size"`
+	GPU          GPUConfig `json:"gpu" yaml:"gpu"`
+}
+
+// DefaultGPUConfig returns the configuration of an R9 Nano GPU.
+func DefaultGPUConfig() GPUConfig {
+	return GPUConfig{
+		NumShaderArrays:                16,
+		NumCUsPerShaderArray:           4,
+		NumMemoryBanks:                 16,
+		Log2MemoryBankInterleavingSize: 7,
+		L1VCache: CacheConfig{
+			Size: ByteSize(16 * mem.KB), Ways: 4, BankLatency: 60, MSHREntries: 16},
+		L1SCache: CacheConfig{
+			Size: ByteSize(16 * mem.KB), Ways: 4, BankLatency: 1, MSHREntries: 16},
+		L1ICache: CacheConfig{
+			Size: ByteSize(32 * mem.KB), Ways: 4, BankLatency: 1, MSHREntries: 16},
+		L2Cache: CacheConfig{
+			Size: ByteSize(2 * mem.MB), Ways: 16, BankLatency: 10, MSHREntries: 64},
+		L1VTLB: TLBConfig{Sets: 1, Ways: 64, MSHREntries: 4},
+		L1STLB: TLBConfig{Sets: 1, Ways: 64, MSHREntries: 4},
+		L1ITLB: TLBConfig{Sets: 1, Ways: 64, MSHREntries: 4},
+		L2TLB:  TLBConfig{Sets: 0, Ways: 64, MSHREntries: 64},
+		DRAM: DRAMConfig{
+			Size:                 ByteSize(4 * mem.GB),
+			FreqMHz:              500,
+			BusWidth:             256,
+			DeviceWidth:          128,
+			BurstLength:          4,
+			NumBankGroups:        4,
+			NumBanks:             4,
+			NumRows:              16384,
+			NumCols:              64,
+			CommandQueueSize:     8,
+			TransactionQueueSize: 32,
+			TCL:                  7,
+			TCWL:                 2,
+			TRCDRD:               7,
+			TRCDWR:               7,
+			TRP:                  7,
+			TRAS:                 17,
+		},
+	}
+}
+
+// DefaultPlatformConfig returns the configuration of a platform with R9 Nano
+// GPUs.
+func DefaultPlatformConfig() PlatformConfig {
+	return PlatformConfig{
+		Log2PageSize: 12,
+		GPU:          DefaultGPUConfig(),
+	}
+}
+
+// LoadPlatformConfig reads a platform configuration from a YAML or a JSON
+// file, depending on the file extension. The fields that the file does not
+// set keep their default values. Unknown fields are errors, so that typos do
+// not go unnoticed.
+func LoadPlatformConfig(path string) (PlatformConfig, error) {
+	c := DefaultPlatformConfig()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return c, err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		err = decoder.Decode(&c)
+	case ".json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&c)
+	default:
+		return c, fmt.Errorf(
+			"%s: unknown config file extension, use .yaml, .yml or .json",
+			path)
+	}
+
+	if err != nil {
+		return c, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if err := c.Validate(); err != nil {
+		return c, fmt.Errorf("%s: invalid config:\n%w", path, err)
+	}
+
+	return c, nil
+}
+
+// configErrors collects the problems found in a configuration.
+type configErrors []error
+
+func (e *configErrors) addf(field, format string, args ...interface{}) {
+	*e = append(*e, fmt.Errorf("%s: "+format, append([]interface{}{field}, args...)...))
+}
+
+func (e *configErrors) positive(field string, value int) {
+	if value <= 0 {
+		e.addf(field, "must be positive, got %d", value)
+	}
+}
+
+// Validate returns an error that lists all the invalid fields, or nil if
+// the platform can be built.
+func (c PlatformConfig) Validate() error {
+	errs := configErrors{}
+
+	if c.NumGPUs < 0 {
+		errs.addf("num_gpus", "must not be negative, got %d", c.NumGPUs)
+	}
+
+	if c.Log2PageSize < 12 || c.Log2PageSize > 30 {
+		errs.addf("log2_page_size", "must be between 12 and 30, got %d",
+			c.Log2PageSize)
+	}
+
+	c.GPU.validate(&errs, "gpu", c.Log2PageSize)
+
+	return errors.Join(errs...)
+}
+
+func (c GPUConfig) validate(errs *configErrors, prefix string, log2PageSize uint64) {
+	errs.positive(prefix+".num_shader_arrays", c.NumShaderArrays)
+	errs.positive(prefix+".num_cus_per_shader_array", c.NumCUsPerShaderArray)
+	errs.positive(prefix+".num_memory_banks", c.NumMemoryBanks)
+
+	if c.Log2MemoryBankInterleavingSize < log2CacheLineSize {
+		errs.addf(prefix+".log2_memory_bank_interleaving_size",
+			"must be at least %d, the log2 of the cache line size, got %d",
+			log2CacheLineSize, c.Log2MemoryBankInterleavingSize)
+	}
+
+	c.L1VCache.validate(errs, prefix+".l1v_cache", 1)
+	c.L1SCache.validate(errs, prefix+".l1s_cache", 1)
+	c.L1ICache.validate(errs, prefix+".l1i_cache", 1)
+	if c.NumMemoryBanks > 0 {
+		c.L2Cache.validate(errs, prefix+".l2_cache", c.NumMemoryBanks)
+	}
+
+	c.L1VTLB.validate(errs, prefix+".l1v_tlb", false)
+	c.L1STLB.validate(errs, prefix+".l1s_tlb", false)
+	c.L1ITLB.validate(errs, prefix+".l1i_tlb", false)
+	c.L2TLB.validate(errs, prefix+".l2_tlb", true)
+
+	c.DRAM.validate(errs, prefix+".dram", c.NumMemoryBanks, log2PageSize)
+}
+
+func (c CacheConfig) validate(errs *configErrors, prefix string, numBanks int) {
+	errs.positive(prefix+".ways", c.Ways)
+	errs.positive(prefix+".mshr_entries", c.MSHREntries)
+
+	if c.BankLatency < 0 {
+		errs.addf(prefix+".bank_latency", "must not be negative, got %d",
+			c.BankLatency)
+	}
+
+	if c.Ways <= 0 {
+		return
+	}
+
+	unit := uint64(numBanks) * uint64(c.Ways) << log2CacheLineSize
+	if c.Size == 0 || uint64(c.Size)%unit != 0 {
+		errs.addf(prefix+".size",
+			"must be a positive multiple of %d bytes "+
+				"(%d bank(s) x %d ways x %d-byte lines), got %d",
+			unit, numBanks, c.Ways, 1<<log2CacheLineSize, c.Size)
+	}
+}
+
+func (c TLBConfig) validate(errs *configErrors, prefix string, zeroSets bool) {
+	if zeroSets {
+		if c.Sets < 0 {
+			errs.addf(prefix+".sets", "must not be negative, got %d", c.Sets)
+		}
+	} else {
+		errs.positive(prefix+".sets", c.Sets)
+	}
+
+	errs.positive(prefix+".ways", c.Ways)
+	errs.positive(prefix+".mshr_entries", c.MSHREntries)
+}
+
+func (c DRAMConfig) validate(
+	errs *configErrors,
+	prefix string,
+	numMemoryBanks int,
+	log2PageSize uint64,
+) {
+	numErrs := len(*errs)
+
+	if c.Size == 0 || c.Size > maxDRAMSize {
+		errs.addf(prefix+".size", "must be between 1 byte and %d bytes, got %d",
+			maxDRAMSize, c.Size)
+	} else if uint64(c.Size)%(1<<log2PageSize) != 0 {
+		errs.addf(prefix+".size", "must be a multiple of the page size, got %d",
+			c.Size)
+	}
+
+	if c.FreqMHz <= 0 {
+		errs.addf(prefix+".freq_mhz", "must be positive, got %g", c.FreqMHz)
+	}
+
+	for _, f := range []struct {
+		name  string
+		value int
+	}{
+		{"bus_width", c.BusWidth},
+		{"device_width", c.DeviceWidth},
+		{"burst_length", c.BurstLength},
+		{"num_bank_groups", c.NumBankGroups},
+		{"num_banks", c.NumBanks},
+		{"num_rows", c.NumRows},
+		{"num_cols", c.NumCols},
+		{"command_queue_size", c.CommandQueueSize},
+		{"transaction_queue_size", c.TransactionQueueSize},
+		{"tCL", c.TCL},
+		{"tCWL", c.TCWL},
+		{"tRCDRD", c.TRCDRD},
+		{"tRCDWR", c.TRCDWR},
+		{"tRP", c.TRP},
+		{"tRAS", c.TRAS},
+	} {
+		errs.positive(prefix+"."+f.name, f.value)
+	}
+
+	if c.DeviceWidth > 0 && c.BusWidth%c.DeviceWidth != 0 {
+		errs.addf(prefix+".bus_width",
+			"must be a multiple of device_width (%d), got %d",
+			c.DeviceWidth, c.BusWidth)
+	}
+
+	if numMemoryBanks <= 0 || len(*errs) > numErrs {
+		return
+	}
+
+	if uint64(c.Size)%uint64(numMemoryBanks) != 0 {
+		errs.addf(prefix+".size",
+			"must be a multiple of num_memory_banks (%d), got %d",
+			numMemoryBanks, c.Size)
+	} else if c.numRanks(numMemoryBanks) < 1 {
+		errs.addf(prefix+".size",
+			"%d bytes per memory controller is less than a rank of %d bytes",
+			uint64(c.Size)/uint64(numMemoryBanks), c.rankSize())
+	}
+}
@@ -0,0 +1,177 @@
+package runner
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/akita/v3/mem/mem"
+)
+
+var _ = Describe("ParseByteSize", func() {
+	DescribeTable("valid sizes",
+		func(s string, expected ByteSize) {
+			size, err := ParseByteSize(s)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(size).To(Equal(expected))
+		},
+		Entry("bytes", "1024", ByteSize(1024)),
+		Entry("B suffix", "64B", ByteSize(64)),
+		Entry("KB suffix", "16KB", ByteSize(16*mem.KB)),
+		Entry("MB suffix", "2MB", ByteSize(2*mem.MB)),
+		Entry("GB suffix", "4GB", ByteSize(4*mem.GB)),
+		Entry("lower case and spaces", " 8 kb ", ByteSize(8*mem.KB)),
+	)
+
+	DescribeTable("invalid sizes",
+		func(s string) {
+			_, err := ParseByteSize(s)
+
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("no number", "KB"),
+		Entry("negative", "-1KB"),
+		Entry("fraction", "1.5MB"),
+		Entry("unknown unit", "1TB"),
+		Entry("overflow", "17179869184GB"),
+	)
+})
+
+var _ = Describe("LoadPlatformConfig", func() {
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "runner-config")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(os.RemoveAll, dir)
+	})
+
+	writeConfig := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		Expect(os.WriteFile(path, []byte(content), 0o644)).To(Succeed())
+		return path
+	}
+
+	It("should load the default platform from configs/r9nano.yaml", func() {
+		c, err := LoadPlatformConfig("configs/r9nano.yaml")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c).To(Equal(DefaultPlatformConfig()))
+	})
+
+	It("should keep the defaults of the fields that are left out", func() {
+		path := writeConfig("c.yaml", `
+num_gpus: 2
+gpu:
+  l2_cache: {size: 4MB}
+`)
+
+		c, err := LoadPlatformConfig(path)
+
+		Expect(err).NotTo(HaveOccurred())
+		expected := DefaultPlatformConfig()
+		expected.NumGPUs = 2
+		expected.GPU.L2Cache.Size = ByteSize(4 * mem.MB)
+		Expect(c).To(Equal(expected))
+	})
+
+	It("should load sizes with units from JSON", func() {
+		path := writeConfig("c.json",
+			`{"gpu": {"l1v_cache": {"size": "32KB"}, "dram": {"size": 2147483648}}}`)
+
+		c, err := LoadPlatformConfig(path)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.GPU.L1VCache.Size).To(Equal(ByteSize(32 * mem.KB)))
+		Expect(c.GPU.DRAM.Size).To(Equal(ByteSize(2 * mem.GB)))
+	})
+
+	It("should reject unknown fields in YAML", func() {
+		path := writeConfig("c.yaml", "gpu:\n  num_shader_array: 8\n")
+
+		_, err := LoadPlatformConfig(path)
+
+		Expect(err).To(MatchError(ContainSubstring("num_shader_array")))
+	})
+
+	It("should reject unknown fields in JSON", func() {
+		path := writeConfig("c.json", `{"gpu": {"l2_cache": {"latency": 3}}}`)
+
+		_, err := LoadPlatformConfig(path)
+
+		Expect(err).To(MatchError(ContainSubstring("latency")))
+	})
+
+	It("should reject sizes that overflow", func() {
+		path := writeConfig("c.yaml", "gpu:\n  dram: {size: 17179869184GB}\n")
+
+		_, err := LoadPlatformConfig(path)
+
+		Expect(err).To(MatchError(ContainSubstring("too large")))
+	})
+
+	It("should reject DRAM larger than the address space of a GPU", func() {
+		path := writeConfig("c.yaml", "gpu:\n  dram: {size: 8GB}\n")
+
+		_, err := LoadPlatformConfig(path)
+
+		Expect(err).To(MatchError(ContainSubstring("gpu.dram.size")))
+	})
+
+	It("should reject unknown file extensions", func() {
+		path := writeConfig("c.toml", "num_gpus = 1\n")
+
+		_, err := LoadPlatformConfig(path)
+
+		Expect(err).To(MatchError(ContainSubstring("extension")))
+	})
+})
+
+var _ = Describe("PlatformConfig.Validate", func() {
+	var c PlatformConfig
+
+	BeforeEach(func() {
+		c = DefaultPlatformConfig()
+	})
+
+	It("should accept the default platform", func() {
+		Expect(c.Validate()).To(Succeed())
+	})
+
+	It("should reject a cache size that the ways do not divide", func() {
+		c.GPU.L1VCache.Size = ByteSize(1000)
+
+		Expect(c.Validate()).To(
+			MatchError(ContainSubstring("gpu.l1v_cache.size")))
+	})
+
+	It("should reject an L2 size that the banks do not divide", func() {
+		c.GPU.L2Cache.Size = ByteSize(2*mem.MB + 16*64)
+
+		Expect(c.Validate()).To(
+			MatchError(ContainSubstring("gpu.l2_cache.size")))
+	})
+
+	It("should reject a DRAM size that the memory banks do not divide", func() {
+		c.GPU.NumMemoryBanks = 3
+
+		err := c.Validate()
+
+		Expect(err).To(MatchError(ContainSubstring("gpu.dram.size")))
+	})
+
+	It("should list all the invalid fields", func() {
+		c.NumGPUs = -1
+		c.GPU.NumShaderArrays = 0
+		c.GPU.DRAM.Size = ByteSize(8 * mem.GB)
+
+		err := c.Validate()
+
+		Expect(err).To(MatchError(ContainSubstring("num_gpus")))
+		Expect(err).To(MatchError(ContainSubstring("gpu.num_shader_arrays")))
+		Expect(err).To(MatchError(ContainSubstring("gpu.dram.size")))
+	})
+})
@@ -0,0 +1,42 @@
+package runner
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ParseCUMask converts a list of CUs, such as "0-31,40", to a CU mask that
+// the driver accepts. An empty list returns an empty mask, which enables all
+// the CUs.
+func ParseCUMask(cuList string) []uint32 {
+	var mask []uint32
+	if cuList == "" {
+		return mask
+	}
+
+	for _, t := range strings.Split(cuList, ",") {
+		first, last, isRange := strings.Cut(t, "-")
+		if !isRange {
+			last = first
+		}
+
+		from, err := strconv.Atoi(first)
+		if err != nil {
+			panic(err)
+		}
+
+		to, err := strconv.Atoi(last)
+		if err != nil {
+			panic(err)
+		}
+
+		for cu := from; cu <= to; cu++ {
+			for len(mask) <= cu/32 {
+				mask = append(mask, 0)
+			}
+			mask[cu/32] |= 1 << (cu % 32)
+		}
+	}
+
+	return mask
+}
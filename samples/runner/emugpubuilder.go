@@ -36,6 +36,8 @@ type EmuGPUBuilder struct {
 
 	enableISADebug   bool
 	enableMemTracing bool
+	enableSanitizer  bool
+	detectRaces      bool
 }
 
 // MakeEmuGPUBuilder creates a new EmuGPUBuilder
@@ -103,6 +105,20 @@ func (b EmuGPUBuilder) WithMemTracing() EmuGPUBuilder {
 	return b
 }
 
+// WithSanitizer makes the compute units check every lane's address against
+// the allocations that the driver tracks.
+func (b EmuGPUBuilder) WithSanitizer() EmuGPUBuilder {
+	b.enableSanitizer = true
+	return b
+}
+
+// WithRaceDetection makes the compute units report the data races between
+// the wavefronts of a work-group.
+func (b EmuGPUBuilder) WithRaceDetection() EmuGPUBuilder {
+	b.detectRaces = true
+	return b
+}
+
 // Build creates a very simple GPU for emulation purposes
 func (b EmuGPUBuilder) Build(name string) *GPU {
 	b.clear()
@@ -139,6 +155,15 @@ func (b *EmuGPUBuilder) buildComputeUnits() {
 			fmt.Sprintf("%s.CU%d", b.gpuName, i),
 			b.engine, disassembler, b.pageTable,
 			b.log2PageSize, b.gpuMem.Storage, nil)
+		computeUnit.MemoryFaultHandler = b.driver
+
+		if b.enableSanitizer {
+			computeUnit.SetAddressChecker(b.driver)
+		}
+
+		if b.detectRaces {
+			computeUnit.EnableRaceDetection()
+		}
 
 		b.computeUnits = append(b.computeUnits, computeUnit)
 
@@ -18,7 +18,13 @@ type EmuBuilder struct {
 	numGPU             int
 	log2PageSize       uint64
 	useMagicMemoryCopy bool
+	continueOnFault    bool
+	useSanitizer       bool
+	detectRaces        bool
 	gpus               []*GPU
+
+	wgDistributionPolicy    driver.WGDistributionPolicy
+	wgDistributionChunkSize int
 }
 
 // MakeEmuBuilder creates a EmuBuilder with default parameters.
@@ -72,6 +78,38 @@ func (b EmuBuilder) WithMagicMemoryCopy() EmuBuilder {
 	return b
 }
 
+// WithContinueOnMemoryFault keeps the emulation running after a kernel
+// accesses an unmapped address.
+func (b EmuBuilder) WithContinueOnMemoryFault() EmuBuilder {
+	b.continueOnFault = true
+	return b
+}
+
+// WithSanitizer makes the compute units check every lane's address against
+// the allocations that the driver tracks.
+func (b EmuBuilder) WithSanitizer() EmuBuilder {
+	b.useSanitizer = true
+	return b
+}
+
+// WithRaceDetection reports the data races between the wavefronts of a
+// work-group.
+func (b EmuBuilder) WithRaceDetection() EmuBuilder {
+	b.detectRaces = true
+	return b
+}
+
+// WithWGDistribution sets how the driver splits the work-groups of the
+// kernels launched on unified GPUs among the GPUs.
+func (b EmuBuilder) WithWGDistribution(
+	policy driver.WGDistributionPolicy,
+	chunkSize int,
+) EmuBuilder {
+	b.wgDistributionPolicy = policy
+	b.wgDistributionChunkSize = chunkSize
+	return b
+}
+
 // Build builds a emulation platform.
 func (b EmuBuilder) Build() *Platform {
 	var engine sim.Engine
@@ -134,6 +172,15 @@ func (b *EmuBuilder) createGPUBuilder(
 	if b.traceMem {
 		gpuBuilder = gpuBuilder.WithMemTracing()
 	}
+
+	if b.useSanitizer {
+		gpuBuilder = gpuBuilder.WithSanitizer()
+	}
+
+	if b.detectRaces {
+		gpuBuilder = gpuBuilder.WithRaceDetection()
+	}
+
 	return gpuBuilder
 }
 
@@ -148,6 +195,20 @@ func (b *EmuBuilder) buildGPUDriver(
 		gpuDriverBuilder = gpuDriverBuilder.WithMagicMemoryCopyMiddleware()
 	}
 
+	if b.continueOnFault {
+		gpuDriverBuilder = gpuDriverBuilder.WithContinueOnMemoryFault()
+	}
+
+	if b.useSanitizer {
+		gpuDriverBuilder = gpuDriverBuilder.WithSanitizer()
+	}
+
+	if b.wgDistributionPolicy != "" {
+		gpuDriverBuilder = gpuDriverBuilder.
+			WithWGDistributionPolicy(b.wgDistributionPolicy).
+			WithWGDistributionChunkSize(b.wgDistributionChunkSize)
+	}
+
 	gpuDriver := gpuDriverBuilder.
 		WithEngine(engine).
 		WithPageTable(pageTable).
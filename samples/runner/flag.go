@@ -1,6 +1,10 @@
 package runner
 
-import "flag"
+import (
+	"flag"
+
+	"github.com/sarchlab/mgpusim/v3/samples/runner/metrics"
+)
 
 var timingFlag = flag.Bool("timing", false, "Run detailed timing simulation.")
 var maxInstCount = flag.Uint64("max-inst", 0,
@@ -15,6 +19,25 @@ var instCountReportFlag = flag.Bool("report-inst-count", false,
 	"Report the number of instructions executed in each compute unit.")
 var cacheLatencyReportFlag = flag.Bool("report-cache-latency", false,
 	"Report the average cache latency.")
+var atomicLatencyReportFlag = flag.Bool("report-atomic-latency", false,
+	"Report the average latency of the atomic memory operations.")
+var atomicExecutionSiteFlag = flag.String("atomic-execution-site", "l2",
+	"Where the atomic memory operations are executed. Possible values are "+
+		"l2 and dram.")
+var issuePolicyFlag = flag.String("issue-policy", "oldest-first",
+	"The policy that the compute units use to select the wavefronts that "+
+		"issue instructions. Possible values are oldest-first, gto, lrr, "+
+		"two-level, and criticality.")
+var preemptionFlag = flag.String("preemption", "none",
+	"How the command processors preempt the running kernels when a kernel "+
+		"with a higher priority arrives. Possible values are none, drain, "+
+		"and context-switch. Timing simulation only.")
+var latencyTableFlag = flag.String("latency-table", "",
+	"A JSON file that sets the latency and the issue interval of the "+
+		"instructions executed by the SIMD units and the scalar units.")
+var configFlag = flag.String("config", "",
+	"A YAML or JSON file that sets the number of GPUs, the page size, and "+
+		"the CUs, caches, TLBs and DRAM of each GPU. Timing simulation only.")
 var cacheHitRateReportFlag = flag.Bool("report-cache-hit-rate", false,
 	"Report the cache hit rate of each cache.")
 var tlbHitRateReportFlag = flag.Bool("report-tlb-hit-rate", false,
@@ -28,19 +51,43 @@ var gpuFlag = flag.String("gpus", "",
 var unifiedGPUFlag = flag.String("unified-gpus", "",
 	`Run multi-GPU benchmark in a unified mode.
 Use a format like 1,2,3,4. Cannot coexist with -gpus.`)
+var wgDistributionFlag = flag.String("wg-distribution", "static",
+	"The policy that splits the work-groups of a kernel among the GPUs of "+
+		"the unified GPU. Possible values are static, interleaved, "+
+		"work-stealing, and page-ownership.")
+var wgDistributionChunkSizeFlag = flag.Int("wg-distribution-chunk-size", 16,
+	"The number of consecutive work-groups that the interleaved and the "+
+		"work-stealing policies hand out at a time.")
 var useUnifiedMemoryFlag = flag.Bool("use-unified-memory", false,
 	"Run benchmark with Unified Memory or not")
 var reportAll = flag.Bool("report-all", false, "Report all metrics to .csv file.")
 var filenameFlag = flag.String("metric-file-name", "metrics",
 	"Modify the name of the output csv file.")
+var metricFormatFlag = flag.String("metric-format", metrics.FormatCSV,
+	`The format of the metric file, one of:
+csv: the original format, written to <metric-file-name>.csv;
+tidy-csv: one metric per row, written to <metric-file-name>.csv, with the run
+metadata written to <metric-file-name>.meta.json;
+json: the run metadata and the metrics, written to <metric-file-name>.json.`)
 var magicMemoryCopy = flag.Bool("magic-memory-copy", false,
 	"Copy data from CPU directly to global memory")
+var continueOnMemoryFaultFlag = flag.Bool("continue-on-memory-fault", false,
+	"Report memory faults and keep running, instead of aborting.")
+var sanitizerFlag = flag.Bool("sanitizer", false,
+	"Check every memory access of the kernels against the allocated and "+
+		"freed buffers and report out-of-bounds and use-after-free accesses.")
+var raceDetectionFlag = flag.Bool("race-detection", false,
+	"Report the data races between the wavefronts of a work-group in the "+
+		"LDS and the global memory. Only supported in emulation mode.")
 var bufferLevelTraceDirFlag = flag.String("buffer-level-trace-dir", "",
 	"The directory to dump the buffer level traces.")
 var bufferLevelTracePeriodFlag = flag.Float64("buffer-level-trace-period", 0.0,
 	"The period to dump the buffer level trace.")
 var simdBusyTimeTracerFlag = flag.Bool("report-busy-time", false, "Report SIMD Unit's busy time")
 var reportCPIStackFlag = flag.Bool("report-cpi-stack", false, "Report CPI stack")
+var reportKernelStatsFlag = flag.Bool("report-kernel-stats", false,
+	"Report the time, instruction count, cache hit rates and DRAM "+
+		"transactions of each kernel launch.")
 var customPortForAkitaRTM = flag.Int("akitartm-port", 0,
 	`Custom port to host AkitaRTM. A 4-digit or 5-digit port number is required. If 
 this number is not given or a invalid number is given number, a random port 
@@ -98,6 +145,10 @@ func (r *Runner) ParseFlag() *Runner {
 		r.ReportCacheLatency = true
 	}
 
+	if *atomicLatencyReportFlag {
+		r.ReportAtomicLatency = true
+	}
+
 	if *cacheHitRateReportFlag {
 		r.ReportCacheHitRate = true
 	}
@@ -122,15 +173,21 @@ func (r *Runner) ParseFlag() *Runner {
 		r.ReportCPIStack = true
 	}
 
+	if *reportKernelStatsFlag {
+		r.ReportKernelStats = true
+	}
+
 	if *reportAll {
 		r.ReportInstCount = true
 		r.ReportCacheLatency = true
+		r.ReportAtomicLatency = true
 		r.ReportCacheHitRate = true
 		r.ReportTLBHitRate = true
 		r.ReportSIMDBusyTime = true
 		r.ReportDRAMTransactionCount = true
 		r.ReportRDMATransactionCount = true
 		r.ReportCPIStack = true
+		r.ReportKernelStats = true
 	}
 
 	return r
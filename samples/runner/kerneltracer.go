@@ -0,0 +1,183 @@
+package runner
+
+import (
+	"sync"
+
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/akita/v3/tracing"
+	"github.com/sarchlab/mgpusim/v3/protocol"
+)
+
+// kernelStatsCacheLevels are the cache levels that the kernel launch records
+// report the hit rates of.
+var kernelStatsCacheLevels = []string{"L1V", "L1S", "L1I", "L2"}
+
+// kernelCounters are the counters of a GPU that a kernel launch record
+// reports the increase of.
+type kernelCounters struct {
+	instCount   uint64
+	cacheHit    map[string]uint64
+	cacheAccess map[string]uint64
+	dramRead    int
+	dramWrite   int
+}
+
+func (c kernelCounters) sub(o kernelCounters) kernelCounters {
+	d := kernelCounters{
+		instCount:   c.instCount - o.instCount,
+		cacheHit:    make(map[string]uint64),
+		cacheAccess: make(map[string]uint64),
+		dramRead:    c.dramRead - o.dramRead,
+		dramWrite:   c.dramWrite - o.dramWrite,
+	}
+
+	for level, n := range c.cacheHit {
+		d.cacheHit[level] = n - o.cacheHit[level]
+	}
+
+	for level, n := range c.cacheAccess {
+		d.cacheAccess[level] = n - o.cacheAccess[level]
+	}
+
+	return d
+}
+
+// gpuCounterTracers are the tracers that count the activities of all the
+// components of a GPU, no matter which kernel causes them.
+type gpuCounterTracers struct {
+	instTracers  []*instTracer
+	cacheTracers map[string][]*tracing.StepCountTracer
+	dramTracers  []*dramTracer
+}
+
+func (t *gpuCounterTracers) read() kernelCounters {
+	c := kernelCounters{
+		cacheHit:    make(map[string]uint64),
+		cacheAccess: make(map[string]uint64),
+	}
+
+	for _, it := range t.instTracers {
+		c.instCount += it.count
+	}
+
+	for level, tracers := range t.cacheTracers {
+		for _, ct := range tracers {
+			hit := ct.GetStepCount("read-hit") + ct.GetStepCount("write-hit")
+			c.cacheHit[level] += hit
+			c.cacheAccess[level] += hit +
+				ct.GetStepCount("read-miss") +
+				ct.GetStepCount("read-mshr-miss") +
+				ct.GetStepCount("write-miss") +
+				ct.GetStepCount("write-mshr-miss")
+		}
+	}
+
+	for _, dt := range t.dramTracers {
+		dt.Lock()
+		c.dramRead += dt.readCount
+		c.dramWrite += dt.writeCount
+		dt.Unlock()
+	}
+
+	return c
+}
+
+// A kernelLaunchRecord describes a kernel launch on one GPU. A kernel that
+// runs on a unified GPU has one record for each of the GPUs. If multiple
+// kernels run on a GPU at the same time, the counters of each include the
+// activities of the others.
+type kernelLaunchRecord struct {
+	kernel     string
+	gpuID      int
+	gridSize   [3]uint32
+	wgSize     [3]uint16
+	startTime  sim.VTimeInSec
+	endTime    sim.VTimeInSec
+	completed  bool
+	startCount kernelCounters
+	counters   kernelCounters
+}
+
+// kernelLaunchLog keeps the kernel launch records of all the GPUs in the
+// order that the kernels start.
+type kernelLaunchLog struct {
+	sync.Mutex
+	records []*kernelLaunchRecord
+}
+
+// kernelLaunchTracer is attached to the Command Processor of a GPU and
+// creates a record for every kernel launch request that the Command
+// Processor receives.
+type kernelLaunchTracer struct {
+	timeTeller sim.TimeTeller
+	gpuID      int
+	counters   *gpuCounterTracers
+	log        *kernelLaunchLog
+	inflight   map[string]*kernelLaunchRecord
+}
+
+func newKernelLaunchTracer(
+	timeTeller sim.TimeTeller,
+	gpuID int,
+	counters *gpuCounterTracers,
+	log *kernelLaunchLog,
+) *kernelLaunchTracer {
+	return &kernelLaunchTracer{
+		timeTeller: timeTeller,
+		gpuID:      gpuID,
+		counters:   counters,
+		log:        log,
+		inflight:   make(map[string]*kernelLaunchRecord),
+	}
+}
+
+// StartTask creates a record if the task is a kernel launch.
+func (t *kernelLaunchTracer) StartTask(task tracing.Task) {
+	req, ok := task.Detail.(*protocol.LaunchKernelReq)
+	if !ok {
+		return
+	}
+
+	record := &kernelLaunchRecord{
+		kernel:     "<unknown>",
+		gpuID:      t.gpuID,
+		startTime:  t.timeTeller.CurrentTime(),
+		startCount: t.counters.read(),
+	}
+
+	if req.HsaCo != nil && req.HsaCo.Symbol != nil {
+		record.kernel = req.HsaCo.Symbol.Name
+	}
+
+	if pkt := req.Packet; pkt != nil {
+		record.gridSize = [3]uint32{pkt.GridSizeX, pkt.GridSizeY, pkt.GridSizeZ}
+		record.wgSize = [3]uint16{
+			pkt.WorkgroupSizeX, pkt.WorkgroupSizeY, pkt.WorkgroupSizeZ}
+	}
+
+	t.inflight[task.ID] = record
+
+	t.log.Lock()
+	t.log.records = append(t.log.records, record)
+	t.log.Unlock()
+}
+
+// StepTask does nothing.
+func (t *kernelLaunchTracer) StepTask(task tracing.Task) {
+	// Do nothing
+}
+
+// EndTask completes the record of the kernel launch.
+func (t *kernelLaunchTracer) EndTask(task tracing.Task) {
+	record, ok := t.inflight[task.ID]
+	if !ok {
+		return
+	}
+	delete(t.inflight, task.ID)
+
+	t.log.Lock()
+	record.endTime = t.timeTeller.CurrentTime()
+	record.counters = t.counters.read().sub(record.startCount)
+	record.completed = true
+	t.log.Unlock()
+}
@@ -1,52 +1,23 @@
 package runner
 
-import (
-	"fmt"
-	"os"
-)
+import "time"
 
-type metric struct {
-	where      string
-	what       string
-	value      float64
-	header     string
-	metricType string
+// gpuMetadata describes a GPU of the simulated platform.
+type gpuMetadata struct {
+	ID     int `json:"id"`
+	NumCUs int `json:"num_cus,omitempty"`
 }
 
-type collector struct {
-	metrics []metric
-}
-
-func (c *collector) Collect(where, what string, value float64) {
-	c.metrics = append(c.metrics, metric{
-		where:      where,
-		what:       what,
-		value:      value,
-		metricType: "data",
-	})
-}
-
-func (c *collector) CollectHeader(header string) {
-	c.metrics = append(c.metrics, metric{
-		header:     header,
-		metricType: "header",
-	})
-}
-
-func (c *collector) Dump(name string) {
-	f, err := os.Create(name + ".csv")
-	if err != nil {
-		panic(err)
-	}
-	defer f.Close()
-
-	fmt.Fprintf(f, ", where, what, value\n")
-	for i, m := range c.metrics {
-		if m.metricType == "data" {
-			fmt.Fprintf(f, "%d, %s, %s, %.12f\n",
-				i, m.where, m.what, m.value)
-		} else if m.metricType == "header" {
-			fmt.Fprintf(f, "%d, -, %s, -\n", i, m.header)
-		}
-	}
+// runMetadata describes the run that produces the metrics.
+type runMetadata struct {
+	Benchmarks       []string          `json:"benchmarks"`
+	Mode             string            `json:"mode"`
+	GPUs             []gpuMetadata     `json:"gpus"`
+	GPUIDsInUse      []int             `json:"gpu_ids_in_use"`
+	Flags            map[string]string `json:"flags"`
+	SimulatorVersion string            `json:"simulator_version"`
+	GoVersion        string            `json:"go_version"`
+	StartTime        time.Time         `json:"start_time"`
+	WallTime         float64           `json:"wall_time"`
+	SimulatedTime    float64           `json:"simulated_time"`
 }
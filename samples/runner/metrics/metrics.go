@@ -0,0 +1,190 @@
+// Package metrics collects the metrics that a simulation reports and dumps
+// them to files.
+package metrics
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"strconv"
+)
+
+// The formats that the metrics can be dumped in.
+const (
+	// FormatCSV is the original CSV format, with the header rows mixed into
+	// the data rows.
+	FormatCSV = "csv"
+
+	// FormatTidyCSV has one metric per row and a where, what and value
+	// column. The run metadata goes to a separate JSON file.
+	FormatTidyCSV = "tidy-csv"
+
+	// FormatJSON has both the run metadata and the metrics.
+	FormatJSON = "json"
+)
+
+// IsValidFormat checks if the metrics can be dumped in the format.
+func IsValidFormat(format string) bool {
+	switch format {
+	case FormatCSV, FormatTidyCSV, FormatJSON:
+		return true
+	}
+
+	return false
+}
+
+type metric struct {
+	where      string
+	what       string
+	value      float64
+	header     string
+	metricType string
+}
+
+// A Collector collects the metrics in the order that they are reported.
+type Collector struct {
+	metrics []metric
+}
+
+// Collect records the value of a metric of a component.
+func (c *Collector) Collect(where, what string, value float64) {
+	c.metrics = append(c.metrics, metric{
+		where:      where,
+		what:       what,
+		value:      value,
+		metricType: "data",
+	})
+}
+
+// CollectHeader records a header row, which only the original CSV format
+// keeps.
+func (c *Collector) CollectHeader(header string) {
+	c.metrics = append(c.metrics, metric{
+		header:     header,
+		metricType: "header",
+	})
+}
+
+// DumpAs writes the metrics to files named after name in the given format.
+// The metadata describes the run and is encoded as JSON.
+func (c *Collector) DumpAs(name, format string, meta interface{}) {
+	switch format {
+	case FormatCSV:
+		c.Dump(name)
+	case FormatTidyCSV:
+		c.dumpTidyCSV(name)
+		dumpJSON(name+".meta.json", meta)
+	case FormatJSON:
+		c.dumpJSON(name, meta)
+	default:
+		log.Panicf("unknown metric format %s", format)
+	}
+}
+
+// Dump writes the metrics to <name>.csv in the original CSV format.
+func (c *Collector) Dump(name string) {
+	f, err := os.Create(name + ".csv")
+	if err != nil {
+		panic(err)
+	}
+	defer f.Close()
+
+	fmt.Fprintf(f, ", where, what, value\n")
+	for i, m := range c.metrics {
+		if m.metricType == "data" {
+			fmt.Fprintf(f, "%d, %s, %s, %.12f\n",
+				i, m.where, m.what, m.value)
+		} else if m.metricType == "header" {
+			fmt.Fprintf(f, "%d, -, %s, -\n", i, m.header)
+		}
+	}
+}
+
+func (c *Collector) dumpTidyCSV(name string) {
+	f, err := os.Create(name + ".csv")
+	if err != nil {
+		panic(err)
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	defer w.Flush()
+
+	err = w.Write([]string{"where", "what", "value"})
+	if err != nil {
+		panic(err)
+	}
+
+	for _, m := range c.dataMetrics() {
+		err = w.Write([]string{
+			m.Where, m.What, strconv.FormatFloat(float64(m.Value), 'g', -1, 64),
+		})
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+func (c *Collector) dumpJSON(name string, meta interface{}) {
+	dumpJSON(name+".json", struct {
+		Metadata interface{}  `json:"metadata"`
+		Metrics  []jsonMetric `json:"metrics"`
+	}{
+		Metadata: meta,
+		Metrics:  c.dataMetrics(),
+	})
+}
+
+type jsonMetric struct {
+	Where string      `json:"where"`
+	What  string      `json:"what"`
+	Value metricValue `json:"value"`
+}
+
+// metricValue is a float64 that encodes NaN and infinities, which some
+// metrics take when nothing is measured, as JSON null.
+type metricValue float64
+
+func (v metricValue) MarshalJSON() ([]byte, error) {
+	f := float64(v)
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(f)
+}
+
+// dataMetrics returns the metrics without the header rows, which only
+// make sense in the original CSV format.
+func (c *Collector) dataMetrics() []jsonMetric {
+	metrics := make([]jsonMetric, 0, len(c.metrics))
+	for _, m := range c.metrics {
+		if m.metricType != "data" {
+			continue
+		}
+
+		metrics = append(metrics,
+			jsonMetric{Where: m.where, What: m.what, Value: metricValue(m.value)})
+	}
+
+	return metrics
+}
+
+func dumpJSON(filename string, v interface{}) {
+	f, err := os.Create(filename)
+	if err != nil {
+		panic(err)
+	}
+	defer f.Close()
+
+	encoder := json.NewEncoder(f)
+	encoder.SetIndent("", "  ")
+
+	err = encoder.Encode(v)
+	if err != nil {
+		panic(err)
+	}
+}
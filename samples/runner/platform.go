@@ -4,6 +4,7 @@ import (
 	"github.com/sarchlab/akita/v3/sim"
 	"github.com/sarchlab/akita/v3/tracing"
 	"github.com/sarchlab/mgpusim/v3/driver"
+	"github.com/sarchlab/mgpusim/v3/timing/atomics"
 	"github.com/sarchlab/mgpusim/v3/timing/cp"
 	"github.com/sarchlab/mgpusim/v3/timing/pagemigrationcontroller"
 	"github.com/sarchlab/mgpusim/v3/timing/rdma"
@@ -27,6 +28,7 @@ type GPU struct {
 	Domain           *sim.Domain
 	CommandProcessor *cp.CommandProcessor
 	RDMAEngine       *rdma.Comp
+	AtomicUnit       *atomics.Comp
 	PMC              *pagemigrationcontroller.PageMigrationController
 	CUs              []TraceableComponent
 	SIMDs            []TraceableComponent
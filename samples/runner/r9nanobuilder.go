@@ -17,12 +17,29 @@ import (
 	"github.com/sarchlab/akita/v3/monitoring"
 	"github.com/sarchlab/akita/v3/sim"
 	"github.com/sarchlab/akita/v3/tracing"
+	"github.com/sarchlab/mgpusim/v3/emu"
+	"github.com/sarchlab/mgpusim/v3/timing/atomics"
 	"github.com/sarchlab/mgpusim/v3/timing/cp"
 	"github.com/sarchlab/mgpusim/v3/timing/cu"
 	"github.com/sarchlab/mgpusim/v3/timing/pagemigrationcontroller"
 	"github.com/sarchlab/mgpusim/v3/timing/rdma"
 )
 
+// AtomicExecutionSite decides where the atomic memory operations are executed.
+type AtomicExecutionSite int
+
+const (
+	// AtomicAtL2 executes the atomic operations at the L2 caches.
+	AtomicAtL2 AtomicExecutionSite = iota
+
+	// AtomicAtDRAM executes the atomic operations at the DRAM controllers,
+	// bypassing the L2 caches. The L2 caches are written back before each
+	// kernel starts. Within a kernel, the L2 caches do not observe the
+	// updates, so plain accesses to the data that atomics update are not
+	// coherent with the atomics.
+	AtomicAtDRAM
+)
+
 // R9NanoGPUBuilder can build R9 Nano GPUs.
 type R9NanoGPUBuilder struct {
 	engine                         sim.Engine
@@ -32,11 +49,24 @@ type R9NanoGPUBuilder struct {
 	numShaderArray                 int
 	numCUPerShaderArray            int
 	numMemoryBank                  int
-	dramSize                       uint64
-	l2CacheSize                    uint64
 	log2PageSize                   uint64
 	log2CacheLineSize              uint64
 	log2MemoryBankInterleavingSize uint64
+	l1vCache                       CacheConfig
+	l1sCache                       CacheConfig
+	l1iCache                       CacheConfig
+	l2Cache                        CacheConfig
+	l1vTLB                         TLBConfig
+	l1sTLB                         TLBConfig
+	l1iTLB                         TLBConfig
+	l2TLB                          TLBConfig
+	dram                           DRAMConfig
+	atomicExecutionSite            AtomicExecutionSite
+	issuePolicy                    cu.IssuePolicy
+	preemption                     cp.PreemptionMode
+	latencyTable                   *cu.LatencyTable
+	addressChecker                 emu.AddressChecker
+	memoryFaultHandler             emu.MemoryFaultHandler
 
 	enableISADebugging bool
 	enableMemTracing   bool
@@ -71,6 +101,7 @@ type R9NanoGPUBuilder struct {
 	lowModuleFinderForPMC   *mem.InterleavedLowModuleFinder
 	dmaEngine               *cp.DMAEngine
 	rdmaEngine              *rdma.Comp
+	atomicUnit              *atomics.Comp
 	pageMigrationController *pagemigrationcontroller.PageMigrationController
 	globalStorage           *mem.Storage
 
@@ -82,20 +113,49 @@ type R9NanoGPUBuilder struct {
 
 // MakeR9NanoGPUBuilder provides a GPU builder that can builds the R9Nano GPU.
 func MakeR9NanoGPUBuilder() R9NanoGPUBuilder {
+	defaults := DefaultGPUConfig()
 	b := R9NanoGPUBuilder{
 		freq:                           1 * sim.GHz,
 		numShaderArray:                 16,
 		numCUPerShaderArray:            4,
 		numMemoryBank:                  16,
-		log2CacheLineSize:              6,
+		log2CacheLineSize:              log2CacheLineSize,
 		log2PageSize:                   12,
 		log2MemoryBankInterleavingSize: 12,
-		l2CacheSize:                    2 * mem.MB,
-		dramSize:                       4 * mem.GB,
+		l1vCache:                       defaults.L1VCache,
+		l1sCache:                       defaults.L1SCache,
+		l1iCache:                       defaults.L1ICache,
+		l2Cache:                        defaults.L2Cache,
+		l1vTLB:                         defaults.L1VTLB,
+		l1sTLB:                         defaults.L1STLB,
+		l1iTLB:                         defaults.L1ITLB,
+		l2TLB:                          defaults.L2TLB,
+		dram:                           defaults.DRAM,
+		issuePolicy:                    cu.IssuePolicyOldestFirst,
+		preemption:                     cp.PreemptionNone,
 	}
 	return b
 }
 
+// WithConfig sets the shape of the GPU. The configuration should be
+// validated.
+func (b R9NanoGPUBuilder) WithConfig(c GPUConfig) R9NanoGPUBuilder {
+	b.numShaderArray = c.NumShaderArrays
+	b.numCUPerShaderArray = c.NumCUsPerShaderArray
+	b.numMemoryBank = c.NumMemoryBanks
+	b.log2MemoryBankInterleavingSize = c.Log2MemoryBankInterleavingSize
+	b.l1vCache = c.L1VCache
+	b.l1sCache = c.L1SCache
+	b.l1iCache = c.L1ICache
+	b.l2Cache = c.L2Cache
+	b.l1vTLB = c.L1VTLB
+	b.l1sTLB = c.L1STLB
+	b.l1iTLB = c.L1ITLB
+	b.l2TLB = c.L2TLB
+	b.dram = c.DRAM
+	return b
+}
+
 // WithEngine sets the engine that the GPU use.
 func (b R9NanoGPUBuilder) WithEngine(engine sim.Engine) R9NanoGPUBuilder {
 	b.engine = engine
@@ -206,13 +266,60 @@ func (b R9NanoGPUBuilder) WithPerfAnalyzer(
 // WithL2CacheSize set the total L2 cache size. The size of the L2 cache is
 // split between memory banks.
 func (b R9NanoGPUBuilder) WithL2CacheSize(size uint64) R9NanoGPUBuilder {
-	b.l2CacheSize = size
+	b.l2Cache.Size = ByteSize(size)
 	return b
 }
 
 // WithDRAMSize sets the size of DRAMs in the GPU.
 func (b R9NanoGPUBuilder) WithDRAMSize(size uint64) R9NanoGPUBuilder {
-	b.dramSize = size
+	b.dram.Size = ByteSize(size)
+	return b
+}
+
+// WithAtomicExecutionSite sets where the atomic memory operations are
+// executed.
+func (b R9NanoGPUBuilder) WithAtomicExecutionSite(
+	site AtomicExecutionSite,
+) R9NanoGPUBuilder {
+	b.atomicExecutionSite = site
+	return b
+}
+
+// WithIssuePolicy sets the policy that the compute units use to select the
+// wavefronts that issue instructions.
+func (b R9NanoGPUBuilder) WithIssuePolicy(
+	policy cu.IssuePolicy,
+) R9NanoGPUBuilder {
+	b.issuePolicy = policy
+	return b
+}
+
+// WithPreemption sets how the command processor preempts the running kernels
+// when a kernel with a higher priority arrives.
+func (b R9NanoGPUBuilder) WithPreemption(
+	mode cp.PreemptionMode,
+) R9NanoGPUBuilder {
+	b.preemption = mode
+	return b
+}
+
+// WithAddressChecker makes the compute units check the address that every lane
+// accesses and report the rejected accesses to the handler.
+func (b R9NanoGPUBuilder) WithAddressChecker(
+	checker emu.AddressChecker,
+	handler emu.MemoryFaultHandler,
+) R9NanoGPUBuilder {
+	b.addressChecker = checker
+	b.memoryFaultHandler = handler
+	return b
+}
+
+// WithLatencyTable sets the timing of the instructions that the SIMD units and
+// the scalar units execute.
+func (b R9NanoGPUBuilder) WithLatencyTable(
+	t *cu.LatencyTable,
+) R9NanoGPUBuilder {
+	b.latencyTable = t
 	return b
 }
 
@@ -233,11 +340,13 @@ func (b R9NanoGPUBuilder) Build(name string, id uint64) *GPU {
 	b.buildDRAMControllers()
 	b.buildCP()
 	b.buildL2TLB()
+	b.buildAtomicUnit()
 
 	b.connectCP()
 	b.connectL2AndDRAM()
 	b.connectL1ToL2()
 	b.connectL1TLBToL2TLB()
+	b.connectAtomicUnit()
 
 	b.populateExternalPorts()
 
@@ -299,10 +408,12 @@ func (b *R9NanoGPUBuilder) connectL1ToL2() {
 	lowModuleFinder.ModuleForOtherAddresses = b.rdmaEngine.ToL1
 	lowModuleFinder.UseAddressSpaceLimitation = true
 	lowModuleFinder.LowAddress = b.memAddrOffset
-	lowModuleFinder.HighAddress = b.memAddrOffset + 4*mem.GB
+	lowModuleFinder.HighAddress = b.memAddrOffset + uint64(b.dram.Size)
 
 	l1ToL2Conn := sim.NewDirectConnection(b.gpuName+".L1ToL2",
 		b.engine, b.freq)
+	b.l1ToL2Connection = l1ToL2Conn
+	b.lowModuleFinderForL1 = lowModuleFinder
 
 	b.rdmaEngine.SetLocalModuleFinder(lowModuleFinder)
 	l1ToL2Conn.PlugIn(b.rdmaEngine.ToL1, 64)
@@ -361,6 +472,7 @@ func (b *R9NanoGPUBuilder) connectL2AndDRAM() {
 func (b *R9NanoGPUBuilder) connectL1TLBToL2TLB() {
 	tlbConn := sim.NewDirectConnection(b.gpuName+".L1TLBToL2TLB",
 		b.engine, b.freq)
+	b.l1TLBToL2TLBConnection = tlbConn
 
 	tlbConn.PlugIn(b.l2TLBs[0].GetPortByName("Top"), 64)
 
@@ -380,6 +492,51 @@ func (b *R9NanoGPUBuilder) connectL1TLBToL2TLB() {
 	}
 }
 
+func (b *R9NanoGPUBuilder) connectAtomicUnit() {
+	topPort := b.atomicUnit.GetPortByName("Top")
+	robToAtomicConn := sim.NewDirectConnection(b.gpuName+".ROBToAtomicUnit",
+		b.engine, b.freq)
+	robToAtomicConn.PlugIn(topPort, 64)
+
+	for _, rob := range b.l1vReorderBuffers {
+		rob.AtomicUnit = topPort
+		robToAtomicConn.PlugIn(rob.GetPortByName("Atomic"), 8)
+	}
+
+	b.atomicUnit.TranslationProvider = b.l2TLBs[0].GetPortByName("Top")
+	b.l1TLBToL2TLBConnection.PlugIn(
+		b.atomicUnit.GetPortByName("Translation"), 16)
+
+	remotePort := b.atomicUnit.GetPortByName("Remote")
+	b.atomicUnit.RemoteModule = b.rdmaEngine.ToL1
+	b.rdmaEngine.SetLocalAtomicUnit(remotePort)
+	b.l1ToL2Connection.PlugIn(remotePort, 16)
+
+	bottomPort := b.atomicUnit.GetPortByName("Bottom")
+	switch b.atomicExecutionSite {
+	case AtomicAtL2:
+		b.atomicUnit.SetLowModuleFinder(b.lowModuleFinderForL1)
+		b.l1ToL2Connection.PlugIn(bottomPort, 16)
+	case AtomicAtDRAM:
+		lowModuleFinder := mem.NewInterleavedLowModuleFinder(
+			1 << b.log2MemoryBankInterleavingSize)
+		lowModuleFinder.ModuleForOtherAddresses = b.rdmaEngine.ToL1
+		lowModuleFinder.UseAddressSpaceLimitation = true
+		lowModuleFinder.LowAddress = b.memAddrOffset
+		lowModuleFinder.HighAddress = b.memAddrOffset + uint64(b.dram.Size)
+
+		for _, dram := range b.drams {
+			lowModuleFinder.LowModules = append(lowModuleFinder.LowModules,
+				dram.GetPortByName("Top"))
+		}
+
+		b.atomicUnit.SetLowModuleFinder(lowModuleFinder)
+		b.l2ToDramConnection.PlugIn(bottomPort, 16)
+	default:
+		panic("unknown atomic execution site")
+	}
+}
+
 func (b *R9NanoGPUBuilder) connectCPWithCUs() {
 	for _, cu := range b.cus {
 		b.cp.RegisterCU(cu)
@@ -488,7 +645,16 @@ func (b *R9NanoGPUBuilder) buildSAs() {
 		withGPUID(b.gpuID).
 		withLog2CachelineSize(b.log2CacheLineSize).
 		withLog2PageSize(b.log2PageSize).
-		withNumCU(b.numCUPerShaderArray)
+		withIssuePolicy(b.issuePolicy).
+		withLatencyTable(b.latencyTable).
+		withNumCU(b.numCUPerShaderArray).
+		withL1Caches(b.l1vCache, b.l1sCache, b.l1iCache).
+		withL1TLBs(b.l1vTLB, b.l1sTLB, b.l1iTLB)
+
+	if b.addressChecker != nil {
+		saBuilder = saBuilder.withAddressChecker(
+			b.addressChecker, b.memoryFaultHandler)
+	}
 
 	if b.enableISADebugging {
 		saBuilder = saBuilder.withIsaDebugging()
@@ -509,14 +675,15 @@ func (b *R9NanoGPUBuilder) buildSAs() {
 }
 
 func (b *R9NanoGPUBuilder) buildL2Caches() {
-	byteSize := b.l2CacheSize / uint64(b.numMemoryBank)
+	byteSize := uint64(b.l2Cache.Size) / uint64(b.numMemoryBank)
 	l2Builder := writeback.MakeBuilder().
 		WithEngine(b.engine).
 		WithFreq(b.freq).
 		WithLog2BlockSize(b.log2CacheLineSize).
-		WithWayAssociativity(16).
+		WithWayAssociativity(b.l2Cache.Ways).
 		WithByteSize(byteSize).
-		WithNumMSHREntry(64).
+		WithBankLatency(b.l2Cache.BankLatency).
+		WithNumMSHREntry(b.l2Cache.MSHREntries).
 		WithNumReqPerCycle(16)
 
 	for i := 0; i < b.numMemoryBank; i++ {
@@ -567,43 +734,32 @@ func (b *R9NanoGPUBuilder) buildDRAMControllers() {
 }
 
 func (b *R9NanoGPUBuilder) createDramControllerBuilder() dram.Builder {
-	memBankSize := 4 * mem.GB / uint64(b.numMemoryBank)
-	if 4*mem.GB%uint64(b.numMemoryBank) != 0 {
+	if uint64(b.dram.Size)%uint64(b.numMemoryBank) != 0 {
 		panic("GPU memory size is not a multiple of the number of memory banks")
 	}
 
-	dramCol := 64
-	dramRow := 16384
-	dramDeviceWidth := 128
-	dramBankSize := dramCol * dramRow * dramDeviceWidth
-	dramBank := 4
-	dramBankGroup := 4
-	dramBusWidth := 256
-	dramDevicePerRank := dramBusWidth / dramDeviceWidth
-	dramRankSize := dramBankSize * dramDevicePerRank * dramBank
-	dramRank := int(memBankSize * 8 / uint64(dramRankSize))
-
+	c := b.dram
 	memCtrlBuilder := dram.MakeBuilder().
 		WithEngine(b.engine).
-		WithFreq(500 * sim.MHz).
+		WithFreq(sim.Freq(c.FreqMHz) * sim.MHz).
 		WithProtocol(dram.HBM).
-		WithBurstLength(4).
-		WithDeviceWidth(dramDeviceWidth).
-		WithBusWidth(dramBusWidth).
+		WithBurstLength(c.BurstLength).
+		WithDeviceWidth(c.DeviceWidth).
+		WithBusWidth(c.BusWidth).
 		WithNumChannel(1).
-		WithNumRank(dramRank).
-		WithNumBankGroup(dramBankGroup).
-		WithNumBank(dramBank).
-		WithNumCol(dramCol).
-		WithNumRow(dramRow).
-		WithCommandQueueSize(8).
-		WithTransactionQueueSize(32).
-		WithTCL(7).
-		WithTCWL(2).
-		WithTRCDRD(7).
-		WithTRCDWR(7).
-		WithTRP(7).
-		WithTRAS(17).
+		WithNumRank(c.numRanks(b.numMemoryBank)).
+		WithNumBankGroup(c.NumBankGroups).
+		WithNumBank(c.NumBanks).
+		WithNumCol(c.NumCols).
+		WithNumRow(c.NumRows).
+		WithCommandQueueSize(c.CommandQueueSize).
+		WithTransactionQueueSize(c.TransactionQueueSize).
+		WithTCL(c.TCL).
+		WithTCWL(c.TCWL).
+		WithTRCDRD(c.TRCDRD).
+		WithTRCDWR(c.TRCDWR).
+		WithTRP(c.TRP).
+		WithTRAS(c.TRAS).
 		WithTREFI(1950).
 		WithTRRDS(2).
 		WithTRRDL(3).
@@ -796,7 +952,12 @@ func (b *R9NanoGPUBuilder) buildCP() {
 		WithEngine(b.engine).
 		WithFreq(b.freq).
 		WithMonitor(b.monitor).
-		WithPerfAnalyzer(b.perfAnalyzer)
+		WithPerfAnalyzer(b.perfAnalyzer).
+		WithPreemption(b.preemption)
+
+	if b.atomicExecutionSite == AtomicAtDRAM {
+		builder = builder.WithL2FlushBeforeKernels()
+	}
 
 	if b.enableVisTracing {
 		builder = builder.WithVisTracer(b.visTracer)
@@ -815,13 +976,18 @@ func (b *R9NanoGPUBuilder) buildCP() {
 }
 
 func (b *R9NanoGPUBuilder) buildL2TLB() {
-	numWays := 64
+	numSets := b.l2TLB.Sets
+	if numSets == 0 {
+		numPages := uint64(b.dram.Size) >> b.log2PageSize
+		numSets = int(numPages / uint64(b.l2TLB.Ways))
+	}
+
 	builder := tlb.MakeBuilder().
 		WithEngine(b.engine).
 		WithFreq(b.freq).
-		WithNumWays(numWays).
-		WithNumSets(int(b.dramSize / (1 << b.log2PageSize) / uint64(numWays))).
-		WithNumMSHREntry(64).
+		WithNumWays(b.l2TLB.Ways).
+		WithNumSets(numSets).
+		WithNumMSHREntry(b.l2TLB.MSHREntries).
 		WithNumReqPerCycle(1024).
 		WithPageSize(1 << b.log2PageSize).
 		WithLowModule(b.mmu.GetPortByName("Top"))
@@ -839,6 +1005,28 @@ func (b *R9NanoGPUBuilder) buildL2TLB() {
 	}
 }
 
+func (b *R9NanoGPUBuilder) buildAtomicUnit() {
+	b.atomicUnit = atomics.MakeBuilder().
+		WithEngine(b.engine).
+		WithFreq(b.freq).
+		WithDeviceID(b.gpuID).
+		WithLog2BlockSize(b.log2CacheLineSize).
+		Build(fmt.Sprintf("%s.AtomicUnit", b.gpuName))
+	b.gpu.AtomicUnit = b.atomicUnit
+
+	if b.enableVisTracing {
+		tracing.CollectTrace(b.atomicUnit, b.visTracer)
+	}
+
+	if b.enableMemTracing {
+		tracing.CollectTrace(b.atomicUnit, b.memTracer)
+	}
+
+	if b.monitor != nil {
+		b.monitor.RegisterComponent(b.atomicUnit)
+	}
+}
+
 func (b *R9NanoGPUBuilder) numCU() int {
 	return b.numCUPerShaderArray * b.numShaderArray
 }
@@ -1,11 +1,20 @@
 package runner
 
 import (
+	"flag"
+	"fmt"
+	"log"
+	"reflect"
+	"runtime"
+	"runtime/debug"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/sarchlab/akita/v3/sim"
 	"github.com/sarchlab/akita/v3/tracing"
+	"github.com/sarchlab/mgpusim/v3/benchmarks"
+	"github.com/sarchlab/mgpusim/v3/samples/runner/metrics"
 	"github.com/sarchlab/mgpusim/v3/timing/cu"
 	"github.com/sarchlab/mgpusim/v3/timing/rdma"
 	"github.com/tebeka/atexit"
@@ -21,6 +30,11 @@ type cacheLatencyTracer struct {
 	cache  TraceableComponent
 }
 
+type atomicLatencyTracer struct {
+	tracer     *tracing.AverageTimeTracer
+	atomicUnit TraceableComponent
+}
+
 type cacheHitRateTracer struct {
 	tracer *tracing.StepCountTracer
 	cache  TraceableComponent
@@ -53,12 +67,18 @@ type cuCPIStackTracer struct {
 }
 
 func (r *Runner) defineMetrics() {
-	r.metricsCollector = &collector{}
+	if !metrics.IsValidFormat(*metricFormatFlag) {
+		log.Panicf("unknown metric format %s", *metricFormatFlag)
+	}
+
+	r.metricsCollector = &metrics.Collector{}
 	r.addMaxInstStopper()
 	r.addKernelTimeTracer()
+	r.addKernelLaunchTracer()
 	r.addInstCountTracer()
 	r.addCUCPIHook()
 	r.addCacheLatencyTracer()
+	r.addAtomicLatencyTracer()
 	r.addCacheHitRateTracer()
 	r.addTLBHitRateTracer()
 	r.addRDMAEngineTracer()
@@ -88,6 +108,49 @@ func (r *Runner) addKernelTimeTracer() {
 	}
 }
 
+func (r *Runner) addKernelLaunchTracer() {
+	if !r.ReportKernelStats {
+		return
+	}
+
+	r.kernelLaunchLog = &kernelLaunchLog{}
+
+	for i, gpu := range r.platform.GPUs {
+		counters := &gpuCounterTracers{
+			cacheTracers: make(map[string][]*tracing.StepCountTracer),
+		}
+
+		for _, cu := range gpu.CUs {
+			tracer := newInstTracer()
+			counters.instTracers = append(counters.instTracers, tracer)
+			tracing.CollectTrace(cu.(tracing.NamedHookable), tracer)
+		}
+
+		caches := [][]TraceableComponent{
+			gpu.L1VCaches, gpu.L1SCaches, gpu.L1ICaches, gpu.L2Caches,
+		}
+		for j, level := range kernelStatsCacheLevels {
+			for _, cache := range caches[j] {
+				tracer := tracing.NewStepCountTracer(
+					func(task tracing.Task) bool { return true })
+				counters.cacheTracers[level] = append(
+					counters.cacheTracers[level], tracer)
+				tracing.CollectTrace(cache, tracer)
+			}
+		}
+
+		for _, dram := range gpu.MemControllers {
+			tracer := newDramTracer(r.platform.Engine)
+			counters.dramTracers = append(counters.dramTracers, tracer)
+			tracing.CollectTrace(dram, tracer)
+		}
+
+		tracer := newKernelLaunchTracer(
+			r.platform.Engine, i+1, counters, r.kernelLaunchLog)
+		tracing.CollectTrace(gpu.CommandProcessor, tracer)
+	}
+}
+
 func (r *Runner) addInstCountTracer() {
 	if !r.ReportInstCount {
 		return
@@ -178,6 +241,27 @@ func (r *Runner) addCacheLatencyTracer() {
 	}
 }
 
+func (r *Runner) addAtomicLatencyTracer() {
+	if !r.ReportAtomicLatency {
+		return
+	}
+
+	for _, gpu := range r.platform.GPUs {
+		if gpu.AtomicUnit == nil {
+			continue
+		}
+
+		tracer := tracing.NewAverageTimeTracer(
+			r.platform.Engine,
+			func(task tracing.Task) bool {
+				return task.Kind == "req_in"
+			})
+		r.atomicLatencyTracers = append(r.atomicLatencyTracers,
+			atomicLatencyTracer{tracer: tracer, atomicUnit: gpu.AtomicUnit})
+		tracing.CollectTrace(gpu.AtomicUnit, tracer)
+	}
+}
+
 func (r *Runner) addCacheHitRateTracer() {
 	if !r.ReportCacheHitRate {
 		return
@@ -350,10 +434,12 @@ func (r *Runner) addSIMDBusyTimeTracer() {
 
 func (r *Runner) reportStats() {
 	r.reportExecutionTime()
+	r.reportKernelStats()
 	r.reportInstCount()
 	r.reportCPIStack()
 	r.reportSIMDBusyTime()
 	r.reportCacheLatency()
+	r.reportAtomicLatency()
 	r.reportCacheHitRate()
 	r.reportTLBHitRate()
 	r.reportRDMATransactionCount()
@@ -442,6 +528,55 @@ func (r *Runner) reportExecutionTime() {
 	}
 }
 
+func (r *Runner) reportKernelStats() {
+	if r.kernelLaunchLog == nil {
+		return
+	}
+
+	r.kernelLaunchLog.Lock()
+	defer r.kernelLaunchLog.Unlock()
+
+	for i, record := range r.kernelLaunchLog.records {
+		if !record.completed {
+			continue
+		}
+
+		where := fmt.Sprintf("Kernel[%d].%s", i, record.kernel)
+		collect := func(what string, value float64) {
+			r.metricsCollector.Collect(where, what, value)
+		}
+
+		collect("gpu", float64(record.gpuID))
+		dims := []string{"x", "y", "z"}
+		for d, dim := range dims {
+			collect("grid_size_"+dim, float64(record.gridSize[d]))
+		}
+		for d, dim := range dims {
+			collect("wg_size_"+dim, float64(record.wgSize[d]))
+		}
+		collect("start_time", float64(record.startTime))
+		collect("end_time", float64(record.endTime))
+		collect("kernel_time", float64(record.endTime-record.startTime))
+
+		if !r.Timing {
+			continue
+		}
+
+		c := record.counters
+		collect("inst_count", float64(c.instCount))
+		for _, level := range kernelStatsCacheLevels {
+			if c.cacheAccess[level] == 0 {
+				continue
+			}
+
+			collect(level+"_hit_rate",
+				float64(c.cacheHit[level])/float64(c.cacheAccess[level]))
+		}
+		collect("dram_read_trans_count", float64(c.dramRead))
+		collect("dram_write_trans_count", float64(c.dramWrite))
+	}
+}
+
 func (r *Runner) reportCacheLatency() {
 	for _, tracer := range r.cacheLatencyTracers {
 		if tracer.tracer.AverageTime() == 0 {
@@ -456,6 +591,25 @@ func (r *Runner) reportCacheLatency() {
 	}
 }
 
+func (r *Runner) reportAtomicLatency() {
+	for _, tracer := range r.atomicLatencyTracers {
+		if tracer.tracer.TotalCount() == 0 {
+			continue
+		}
+
+		r.metricsCollector.Collect(
+			tracer.atomicUnit.Name(),
+			"atomic_count",
+			float64(tracer.tracer.TotalCount()),
+		)
+		r.metricsCollector.Collect(
+			tracer.atomicUnit.Name(),
+			"atomic_average_latency",
+			float64(tracer.tracer.AverageTime()),
+		)
+	}
+}
+
 func (r *Runner) reportCacheHitRate() {
 	for _, tracer := range r.cacheHitRateTracers {
 		readHit := tracer.tracer.GetStepCount("read-hit")
@@ -559,5 +713,81 @@ func (r *Runner) reportDRAMTransactionCount() {
 }
 
 func (r *Runner) dumpMetrics() {
-	r.metricsCollector.Dump(*filenameFlag)
+	r.metricsCollector.DumpAs(*filenameFlag, *metricFormatFlag, r.runMetadata())
+}
+
+func (r *Runner) runMetadata() runMetadata {
+	meta := runMetadata{
+		Mode:             "emulation",
+		GPUIDsInUse:      r.GPUIDs,
+		Flags:            make(map[string]string),
+		SimulatorVersion: simulatorVersion(),
+		GoVersion:        runtime.Version(),
+		StartTime:        r.startTime,
+		WallTime:         time.Since(r.startTime).Seconds(),
+		SimulatedTime:    float64(r.platform.Engine.CurrentTime()),
+	}
+
+	if r.Timing {
+		meta.Mode = "timing"
+	}
+
+	for _, b := range r.benchmarks {
+		meta.Benchmarks = append(meta.Benchmarks, benchmarkName(b))
+	}
+
+	for i, gpu := range r.platform.GPUs {
+		meta.GPUs = append(meta.GPUs,
+			gpuMetadata{ID: i + 1, NumCUs: len(gpu.CUs)})
+	}
+
+	flag.VisitAll(func(f *flag.Flag) {
+		meta.Flags[f.Name] = f.Value.String()
+	})
+
+	return meta
+}
+
+// benchmarkName returns the name of the package that defines the benchmark,
+// for example, fir.
+func benchmarkName(b benchmarks.Benchmark) string {
+	t := reflect.TypeOf(b)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	pkg := t.PkgPath()
+	return pkg[strings.LastIndex(pkg, "/")+1:]
+}
+
+// simulatorVersion returns the version of the MGPUSim module that the
+// program is built with. If MGPUSim is the main module, it is the commit
+// that the program is built from, if known.
+func simulatorVersion() string {
+	const modulePath = "github.com/sarchlab/mgpusim/v3"
+
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+
+	for _, dep := range info.Deps {
+		if dep.Path == modulePath {
+			return dep.Version
+		}
+	}
+
+	version := info.Main.Version
+	for _, s := range info.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			version += " " + s.Value
+		case "vcs.modified":
+			if s.Value == "true" {
+				version += " (modified)"
+			}
+		}
+	}
+
+	return version
 }
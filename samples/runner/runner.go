@@ -9,12 +9,16 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/sarchlab/akita/v3/monitoring"
 	"github.com/sarchlab/akita/v3/sim"
 	"github.com/sarchlab/akita/v3/tracing"
 	"github.com/sarchlab/mgpusim/v3/benchmarks"
 	"github.com/sarchlab/mgpusim/v3/driver"
+	"github.com/sarchlab/mgpusim/v3/samples/runner/metrics"
+	"github.com/sarchlab/mgpusim/v3/timing/cp"
+	"github.com/sarchlab/mgpusim/v3/timing/cu"
 	"github.com/tebeka/atexit"
 )
 
@@ -32,21 +36,25 @@ type Runner struct {
 	perGPUKernelTimeCounter []*tracing.BusyTimeTracer
 	instCountTracers        []instCountTracer
 	cacheLatencyTracers     []cacheLatencyTracer
+	atomicLatencyTracers    []atomicLatencyTracer
 	cacheHitRateTracers     []cacheHitRateTracer
 	tlbHitRateTracers       []tlbHitRateTracer
 	rdmaTransactionCounters []rdmaTransactionCountTracer
 	dramTracers             []dramTransactionCountTracer
 	benchmarks              []benchmarks.Benchmark
 	monitor                 *monitoring.Monitor
-	metricsCollector        *collector
+	metricsCollector        *metrics.Collector
 	simdBusyTimeTracers     []simdBusyTimeTracer
 	cuCPITraces             []cuCPIStackTracer
+	kernelLaunchLog         *kernelLaunchLog
+	startTime               time.Time
 
 	Timing                     bool
 	Verify                     bool
 	Parallel                   bool
 	ReportInstCount            bool
 	ReportCacheLatency         bool
+	ReportAtomicLatency        bool
 	ReportCacheHitRate         bool
 	ReportTLBHitRate           bool
 	ReportRDMATransactionCount bool
@@ -54,12 +62,15 @@ type Runner struct {
 	UseUnifiedMemory           bool
 	ReportSIMDBusyTime         bool
 	ReportCPIStack             bool
+	ReportKernelStats          bool
 
 	GPUIDs []int
 }
 
 // Init initializes the platform simulate
 func (r *Runner) Init() *Runner {
+	r.startTime = time.Now()
+
 	r.ParseFlag()
 	r.parseGPUFlag()
 
@@ -79,6 +90,10 @@ func (r *Runner) Init() *Runner {
 }
 
 func (r *Runner) buildEmuPlatform() {
+	if *configFlag != "" {
+		log.Panic("-config is only supported in timing simulation")
+	}
+
 	b := MakeEmuBuilder().
 		WithNumGPU(r.GPUIDs[len(r.GPUIDs)-1])
 
@@ -102,6 +117,20 @@ func (r *Runner) buildEmuPlatform() {
 		b = b.WithMagicMemoryCopy()
 	}
 
+	if *continueOnMemoryFaultFlag {
+		b = b.WithContinueOnMemoryFault()
+	}
+
+	if *sanitizerFlag {
+		b = b.WithSanitizer()
+	}
+
+	if *raceDetectionFlag {
+		b = b.WithRaceDetection()
+	}
+
+	b = b.WithWGDistribution(r.wgDistributionPolicy(), *wgDistributionChunkSizeFlag)
+
 	r.platform = b.Build()
 }
 
@@ -109,6 +138,8 @@ func (r *Runner) buildTimingPlatform() {
 	b := MakeR9NanoBuilder().
 		WithNumGPU(r.GPUIDs[len(r.GPUIDs)-1])
 
+	b = r.setPlatformConfig(b)
+
 	if r.Parallel {
 		b = b.WithParallelEngine()
 	}
@@ -128,6 +159,12 @@ func (r *Runner) buildTimingPlatform() {
 		b = b.WithMemTracing()
 	}
 
+	b = r.setAtomicExecutionSite(b)
+	b = r.setIssuePolicy(b)
+	b = r.setPreemption(b)
+	b = r.setLatencyTable(b)
+	b = b.WithWGDistribution(r.wgDistributionPolicy(), *wgDistributionChunkSizeFlag)
+
 	r.monitor = monitoring.NewMonitor()
 	if *customPortForAkitaRTM != 0 {
 		r.monitor = r.monitor.WithPortNumber(*customPortForAkitaRTM)
@@ -141,11 +178,124 @@ func (r *Runner) buildTimingPlatform() {
 		b = b.WithMagicMemoryCopy()
 	}
 
+	if *continueOnMemoryFaultFlag {
+		b = b.WithContinueOnMemoryFault()
+	}
+
+	if *sanitizerFlag {
+		b = b.WithSanitizer()
+	}
+
 	r.platform = b.Build()
 
 	r.monitor.StartServer()
 }
 
+func (r *Runner) setPlatformConfig(
+	b R9NanoPlatformBuilder,
+) R9NanoPlatformBuilder {
+	if *configFlag == "" {
+		return b
+	}
+
+	c, err := LoadPlatformConfig(*configFlag)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	maxGPUID := r.GPUIDs[len(r.GPUIDs)-1]
+	if c.NumGPUs > 0 && c.NumGPUs < maxGPUID {
+		log.Panicf("%s: num_gpus is %d, but GPU %d is in use",
+			*configFlag, c.NumGPUs, maxGPUID)
+	}
+
+	return b.WithConfig(c)
+}
+
+func (*Runner) setAtomicExecutionSite(
+	b R9NanoPlatformBuilder,
+) R9NanoPlatformBuilder {
+	switch *atomicExecutionSiteFlag {
+	case "l2":
+		return b.WithAtomicExecutionSite(AtomicAtL2)
+	case "dram":
+		return b.WithAtomicExecutionSite(AtomicAtDRAM)
+	default:
+		log.Panicf("unknown atomic execution site %s",
+			*atomicExecutionSiteFlag)
+	}
+
+	return b
+}
+
+func (*Runner) setIssuePolicy(
+	b R9NanoPlatformBuilder,
+) R9NanoPlatformBuilder {
+	policy := cu.IssuePolicy(*issuePolicyFlag)
+	switch policy {
+	case cu.IssuePolicyOldestFirst,
+		cu.IssuePolicyGTO,
+		cu.IssuePolicyLRR,
+		cu.IssuePolicyTwoLevel,
+		cu.IssuePolicyCriticality:
+		return b.WithIssuePolicy(policy)
+	default:
+		log.Panicf("unknown issue policy %s", *issuePolicyFlag)
+	}
+
+	return b
+}
+
+func (*Runner) setPreemption(
+	b R9NanoPlatformBuilder,
+) R9NanoPlatformBuilder {
+	mode := cp.PreemptionMode(*preemptionFlag)
+	switch mode {
+	case cp.PreemptionNone,
+		cp.PreemptionDrain,
+		cp.PreemptionContextSwitch:
+		return b.WithPreemption(mode)
+	default:
+		log.Panicf("unknown preemption mode %s", *preemptionFlag)
+	}
+
+	return b
+}
+
+func (*Runner) wgDistributionPolicy() driver.WGDistributionPolicy {
+	policy := driver.WGDistributionPolicy(*wgDistributionFlag)
+	switch policy {
+	case driver.WGDistributionStatic,
+		driver.WGDistributionInterleaved,
+		driver.WGDistributionWorkStealing,
+		driver.WGDistributionPageOwnership:
+	default:
+		log.Panicf("unknown work-group distribution policy %s",
+			*wgDistributionFlag)
+	}
+
+	if *wgDistributionChunkSizeFlag <= 0 {
+		log.Panic("-wg-distribution-chunk-size must be positive")
+	}
+
+	return policy
+}
+
+func (*Runner) setLatencyTable(
+	b R9NanoPlatformBuilder,
+) R9NanoPlatformBuilder {
+	if *latencyTableFlag == "" {
+		return b
+	}
+
+	t, err := cu.LoadLatencyTable(*latencyTableFlag)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	return b.WithLatencyTable(t)
+}
+
 func (*Runner) setAnalyszer(
 	b R9NanoPlatformBuilder,
 ) R9NanoPlatformBuilder {
@@ -12,6 +12,7 @@ import (
 	"github.com/sarchlab/akita/v3/mem/vm/tlb"
 	"github.com/sarchlab/akita/v3/sim"
 	"github.com/sarchlab/akita/v3/tracing"
+	"github.com/sarchlab/mgpusim/v3/emu"
 	"github.com/sarchlab/mgpusim/v3/timing/cu"
 	"github.com/sarchlab/mgpusim/v3/timing/rob"
 )
@@ -45,6 +46,18 @@ type shaderArrayBuilder struct {
 	freq              sim.Freq
 	log2CacheLineSize uint64
 	log2PageSize      uint64
+	issuePolicy       cu.IssuePolicy
+	latencyTable      *cu.LatencyTable
+
+	l1vCache CacheConfig
+	l1sCache CacheConfig
+	l1iCache CacheConfig
+	l1vTLB   TLBConfig
+	l1sTLB   TLBConfig
+	l1iTLB   TLBConfig
+
+	addressChecker     emu.AddressChecker
+	memoryFaultHandler emu.MemoryFaultHandler
 
 	isaDebugging bool
 	visTracer    tracing.Tracer
@@ -54,13 +67,21 @@ type shaderArrayBuilder struct {
 }
 
 func makeShaderArrayBuilder() shaderArrayBuilder {
+	defaults := DefaultGPUConfig()
 	b := shaderArrayBuilder{
 		gpuID:             0,
 		name:              "SA",
 		numCU:             4,
 		freq:              1 * sim.GHz,
-		log2CacheLineSize: 6,
+		log2CacheLineSize: log2CacheLineSize,
 		log2PageSize:      12,
+		issuePolicy:       cu.IssuePolicyOldestFirst,
+		l1vCache:          defaults.L1VCache,
+		l1sCache:          defaults.L1SCache,
+		l1iCache:          defaults.L1ICache,
+		l1vTLB:            defaults.L1VTLB,
+		l1sTLB:            defaults.L1STLB,
+		l1iTLB:            defaults.L1ITLB,
 	}
 	return b
 }
@@ -85,6 +106,24 @@ func (b shaderArrayBuilder) withNumCU(n int) shaderArrayBuilder {
 	return b
 }
 
+func (b shaderArrayBuilder) withL1Caches(
+	l1v, l1s, l1i CacheConfig,
+) shaderArrayBuilder {
+	b.l1vCache = l1v
+	b.l1sCache = l1s
+	b.l1iCache = l1i
+	return b
+}
+
+func (b shaderArrayBuilder) withL1TLBs(
+	l1v, l1s, l1i TLBConfig,
+) shaderArrayBuilder {
+	b.l1vTLB = l1v
+	b.l1sTLB = l1s
+	b.l1iTLB = l1i
+	return b
+}
+
 func (b shaderArrayBuilder) withLog2CachelineSize(
 	log2Size uint64,
 ) shaderArrayBuilder {
@@ -99,6 +138,29 @@ func (b shaderArrayBuilder) withLog2PageSize(
 	return b
 }
 
+func (b shaderArrayBuilder) withIssuePolicy(
+	policy cu.IssuePolicy,
+) shaderArrayBuilder {
+	b.issuePolicy = policy
+	return b
+}
+
+func (b shaderArrayBuilder) withAddressChecker(
+	checker emu.AddressChecker,
+	handler emu.MemoryFaultHandler,
+) shaderArrayBuilder {
+	b.addressChecker = checker
+	b.memoryFaultHandler = handler
+	return b
+}
+
+func (b shaderArrayBuilder) withLatencyTable(
+	t *cu.LatencyTable,
+) shaderArrayBuilder {
+	b.latencyTable = t
+	return b
+}
+
 func (b shaderArrayBuilder) withIsaDebugging() shaderArrayBuilder {
 	b.isaDebugging = true
 	return b
@@ -266,7 +328,18 @@ func (b *shaderArrayBuilder) buildCUs(sa *shaderArray) {
 	cuBuilder := cu.MakeBuilder().
 		WithEngine(b.engine).
 		WithFreq(b.freq).
-		WithLog2CachelineSize(b.log2CacheLineSize)
+		WithLog2CachelineSize(b.log2CacheLineSize).
+		WithIssuePolicy(b.issuePolicy)
+
+	if b.latencyTable != nil {
+		cuBuilder = cuBuilder.WithLatencyTable(b.latencyTable)
+	}
+
+	if b.addressChecker != nil {
+		cuBuilder = cuBuilder.
+			WithAddressChecker(b.addressChecker).
+			WithMemoryFaultHandler(b.memoryFaultHandler)
+	}
 
 	for i := 0; i < b.numCU; i++ {
 		cuName := fmt.Sprintf("%s.CU[%d]", b.name, i)
@@ -296,7 +369,8 @@ func (b *shaderArrayBuilder) buildL1VReorderBuffers(sa *shaderArray) {
 		WithEngine(b.engine).
 		WithFreq(b.freq).
 		WithBufferSize(128).
-		WithNumReqPerCycle(4)
+		WithNumReqPerCycle(4).
+		WithLog2BlockSize(b.log2CacheLineSize)
 
 	for i := 0; i < b.numCU; i++ {
 		name := fmt.Sprintf("%s.L1VROB[%d]", b.name, i)
@@ -331,9 +405,9 @@ func (b *shaderArrayBuilder) buildL1VTLBs(sa *shaderArray) {
 	builder := tlb.MakeBuilder().
 		WithEngine(b.engine).
 		WithFreq(b.freq).
-		WithNumMSHREntry(4).
-		WithNumSets(1).
-		WithNumWays(64).
+		WithNumMSHREntry(b.l1vTLB.MSHREntries).
+		WithNumSets(b.l1vTLB.Sets).
+		WithNumWays(b.l1vTLB.Ways).
 		WithNumReqPerCycle(4)
 
 	for i := 0; i < b.numCU; i++ {
@@ -351,12 +425,12 @@ func (b *shaderArrayBuilder) buildL1VCaches(sa *shaderArray) {
 	builder := writearound.NewBuilder().
 		WithEngine(b.engine).
 		WithFreq(b.freq).
-		WithBankLatency(60).
+		WithBankLatency(b.l1vCache.BankLatency).
 		WithNumBanks(1).
 		WithLog2BlockSize(b.log2CacheLineSize).
-		WithWayAssociativity(4).
-		WithNumMSHREntry(16).
-		WithTotalByteSize(16 * mem.KB)
+		WithWayAssociativity(b.l1vCache.Ways).
+		WithNumMSHREntry(b.l1vCache.MSHREntries).
+		WithTotalByteSize(uint64(b.l1vCache.Size))
 
 	if b.visTracer != nil {
 		builder = builder.WithVisTracer(b.visTracer)
@@ -409,9 +483,9 @@ func (b *shaderArrayBuilder) buildL1STLB(sa *shaderArray) {
 	builder := tlb.MakeBuilder().
 		WithEngine(b.engine).
 		WithFreq(b.freq).
-		WithNumMSHREntry(4).
-		WithNumSets(1).
-		WithNumWays(64).
+		WithNumMSHREntry(b.l1sTLB.MSHREntries).
+		WithNumSets(b.l1sTLB.Sets).
+		WithNumWays(b.l1sTLB.Ways).
 		WithNumReqPerCycle(4)
 
 	name := fmt.Sprintf("%s.L1STLB", b.name)
@@ -427,12 +501,12 @@ func (b *shaderArrayBuilder) buildL1SCache(sa *shaderArray) {
 	builder := writethrough.NewBuilder().
 		WithEngine(b.engine).
 		WithFreq(b.freq).
-		WithBankLatency(1).
+		WithBankLatency(b.l1sCache.BankLatency).
 		WithNumBanks(1).
 		WithLog2BlockSize(b.log2CacheLineSize).
-		WithWayAssociativity(4).
-		WithNumMSHREntry(16).
-		WithTotalByteSize(16 * mem.KB)
+		WithWayAssociativity(b.l1sCache.Ways).
+		WithNumMSHREntry(b.l1sCache.MSHREntries).
+		WithTotalByteSize(uint64(b.l1sCache.Size))
 
 	name := fmt.Sprintf("%s.L1SCache", b.name)
 	cache := builder.Build(name)
@@ -483,9 +557,9 @@ func (b *shaderArrayBuilder) buildL1ITLB(sa *shaderArray) {
 	builder := tlb.MakeBuilder().
 		WithEngine(b.engine).
 		WithFreq(b.freq).
-		WithNumMSHREntry(4).
-		WithNumSets(1).
-		WithNumWays(64).
+		WithNumMSHREntry(b.l1iTLB.MSHREntries).
+		WithNumSets(b.l1iTLB.Sets).
+		WithNumWays(b.l1iTLB.Ways).
 		WithNumReqPerCycle(4)
 
 	name := fmt.Sprintf("%s.L1ITLB", b.name)
@@ -501,12 +575,12 @@ func (b *shaderArrayBuilder) buildL1ICache(sa *shaderArray) {
 	builder := writethrough.NewBuilder().
 		WithEngine(b.engine).
 		WithFreq(b.freq).
-		WithBankLatency(1).
+		WithBankLatency(b.l1iCache.BankLatency).
 		WithNumBanks(1).
 		WithLog2BlockSize(b.log2CacheLineSize).
-		WithWayAssociativity(4).
-		WithNumMSHREntry(16).
-		WithTotalByteSize(32 * mem.KB).
+		WithWayAssociativity(b.l1iCache.Ways).
+		WithNumMSHREntry(b.l1iCache.MSHREntries).
+		WithTotalByteSize(uint64(b.l1iCache.Size)).
 		WithNumReqsPerCycle(4)
 
 	name := fmt.Sprintf("%s.L1ICache", b.name)
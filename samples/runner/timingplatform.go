@@ -16,8 +16,15 @@ import (
 	"github.com/sarchlab/akita/v3/sim"
 	"github.com/sarchlab/akita/v3/tracing"
 	"github.com/sarchlab/mgpusim/v3/driver"
+	"github.com/sarchlab/mgpusim/v3/timing/cp"
+	"github.com/sarchlab/mgpusim/v3/timing/cu"
 )
 
+// cpuMemorySize is the size of the CPU memory. The driver places the CPU
+// memory at the beginning of the physical address space and the DRAMs of the
+// GPUs right after it, back to back.
+const cpuMemorySize = 4 * mem.GB
+
 // R9NanoPlatformBuilder can build a platform that equips R9Nano GPU.
 type R9NanoPlatformBuilder struct {
 	useParallelEngine                  bool
@@ -26,10 +33,17 @@ type R9NanoPlatformBuilder struct {
 	traceVisStartTime, traceVisEndTime sim.VTimeInSec
 	traceMem                           bool
 	numGPU                             int
-	numSAPerGPU                        int
-	numCUPerSA                         int
+	gpuConfig                          GPUConfig
 	useMagicMemoryCopy                 bool
 	log2PageSize                       uint64
+	atomicExecutionSite                AtomicExecutionSite
+	issuePolicy                        cu.IssuePolicy
+	preemption                         cp.PreemptionMode
+	latencyTable                       *cu.LatencyTable
+	useSanitizer                       bool
+	continueOnMemoryFault              bool
+	wgDistributionPolicy               driver.WGDistributionPolicy
+	wgDistributionChunkSize            int
 
 	engine               sim.Engine
 	monitor              *monitoring.Monitor
@@ -47,11 +61,12 @@ type R9NanoPlatformBuilder struct {
 func MakeR9NanoBuilder() R9NanoPlatformBuilder {
 	b := R9NanoPlatformBuilder{
 		numGPU:            4,
-		numSAPerGPU:       16,
-		numCUPerSA:        4,
+		gpuConfig:         DefaultGPUConfig(),
 		log2PageSize:      12,
 		traceVisStartTime: -1,
 		traceVisEndTime:   -1,
+		issuePolicy:       cu.IssuePolicyOldestFirst,
+		preemption:        cp.PreemptionNone,
 	}
 	return b
 }
@@ -100,6 +115,22 @@ func (b R9NanoPlatformBuilder) WithNumGPU(n int) R9NanoPlatformBuilder {
 	return b
 }
 
+// WithConfig sets the page size and the shape of the GPUs. It also sets the
+// number of GPUs, unless the configuration leaves it as zero. The
+// configuration should be validated.
+func (b R9NanoPlatformBuilder) WithConfig(
+	c PlatformConfig,
+) R9NanoPlatformBuilder {
+	if c.NumGPUs > 0 {
+		b.numGPU = c.NumGPUs
+	}
+
+	b.log2PageSize = c.Log2PageSize
+	b.gpuConfig = c.GPU
+
+	return b
+}
+
 // WithLog2PageSize sets the page size as a power of 2.
 func (b R9NanoPlatformBuilder) WithLog2PageSize(
 	n uint64,
@@ -108,6 +139,68 @@ func (b R9NanoPlatformBuilder) WithLog2PageSize(
 	return b
 }
 
+// WithAtomicExecutionSite sets where the atomic memory operations are
+// executed.
+func (b R9NanoPlatformBuilder) WithAtomicExecutionSite(
+	site AtomicExecutionSite,
+) R9NanoPlatformBuilder {
+	b.atomicExecutionSite = site
+	return b
+}
+
+// WithIssuePolicy sets the policy that the compute units use to select the
+// wavefronts that issue instructions.
+func (b R9NanoPlatformBuilder) WithIssuePolicy(
+	policy cu.IssuePolicy,
+) R9NanoPlatformBuilder {
+	b.issuePolicy = policy
+	return b
+}
+
+// WithPreemption sets how the command processors preempt the running kernels
+// when a kernel with a higher priority arrives. Context switching also makes
+// the driver allocate a context save area for every kernel.
+func (b R9NanoPlatformBuilder) WithPreemption(
+	mode cp.PreemptionMode,
+) R9NanoPlatformBuilder {
+	b.preemption = mode
+	return b
+}
+
+// WithSanitizer makes the compute units check every lane's address against
+// the allocations that the driver tracks.
+func (b R9NanoPlatformBuilder) WithSanitizer() R9NanoPlatformBuilder {
+	b.useSanitizer = true
+	return b
+}
+
+// WithContinueOnMemoryFault keeps the simulation running after the sanitizer
+// reports an invalid access.
+func (b R9NanoPlatformBuilder) WithContinueOnMemoryFault() R9NanoPlatformBuilder {
+	b.continueOnMemoryFault = true
+	return b
+}
+
+// WithWGDistribution sets how the driver splits the work-groups of the
+// kernels launched on unified GPUs among the GPUs.
+func (b R9NanoPlatformBuilder) WithWGDistribution(
+	policy driver.WGDistributionPolicy,
+	chunkSize int,
+) R9NanoPlatformBuilder {
+	b.wgDistributionPolicy = policy
+	b.wgDistributionChunkSize = chunkSize
+	return b
+}
+
+// WithLatencyTable sets the timing of the instructions that the SIMD units and
+// the scalar units execute.
+func (b R9NanoPlatformBuilder) WithLatencyTable(
+	t *cu.LatencyTable,
+) R9NanoPlatformBuilder {
+	b.latencyTable = t
+	return b
+}
+
 // WithMonitor sets the monitor that is used to monitor the simulation
 func (b R9NanoPlatformBuilder) WithMonitor(
 	m *monitoring.Monitor,
@@ -142,7 +235,8 @@ func (b R9NanoPlatformBuilder) Build() *Platform {
 	b.setupPerformanceAnalyzer()
 	b.setupVisTracing()
 
-	b.globalStorage = mem.NewStorage(uint64(1+b.numGPU) * 4 * mem.GB)
+	b.globalStorage = mem.NewStorage(
+		cpuMemorySize + uint64(b.numGPU)*b.dramSize())
 
 	mmuComponent, pageTable := b.createMMU(b.engine)
 
@@ -178,6 +272,24 @@ func (b R9NanoPlatformBuilder) buildGPUDriver(
 	if b.useMagicMemoryCopy {
 		gpuDriverBuilder = gpuDriverBuilder.WithMagicMemoryCopyMiddleware()
 	}
+
+	if b.useSanitizer {
+		gpuDriverBuilder = gpuDriverBuilder.WithSanitizer()
+	}
+
+	if b.continueOnMemoryFault {
+		gpuDriverBuilder = gpuDriverBuilder.WithContinueOnMemoryFault()
+	}
+
+	if b.preemption == cp.PreemptionContextSwitch {
+		gpuDriverBuilder = gpuDriverBuilder.WithContextSaveArea()
+	}
+	if b.wgDistributionPolicy != "" {
+		gpuDriverBuilder = gpuDriverBuilder.
+			WithWGDistributionPolicy(b.wgDistributionPolicy).
+			WithWGDistributionChunkSize(b.wgDistributionChunkSize)
+	}
+
 	gpuDriver := gpuDriverBuilder.
 		WithEngine(b.engine).
 		WithPageTable(pageTable).
@@ -271,17 +383,50 @@ func (b *R9NanoPlatformBuilder) createGPUs(
 }
 
 func (b R9NanoPlatformBuilder) createPMCPageTable() *mem.BankedLowModuleFinder {
-	pmcAddressTable := new(mem.BankedLowModuleFinder)
-	pmcAddressTable.BankSize = 4 * mem.GB
-	pmcAddressTable.LowModules = append(pmcAddressTable.LowModules, nil)
-	return pmcAddressTable
+	return b.createGPUAddrTable()
 }
 
 func (b R9NanoPlatformBuilder) createRDMAAddrTable() *mem.BankedLowModuleFinder {
-	rdmaAddressTable := new(mem.BankedLowModuleFinder)
-	rdmaAddressTable.BankSize = 4 * mem.GB
-	rdmaAddressTable.LowModules = append(rdmaAddressTable.LowModules, nil)
-	return rdmaAddressTable
+	return b.createGPUAddrTable()
+}
+
+// createGPUAddrTable creates a table that finds the GPU that owns a physical
+// address. The banks are small enough to align with both the CPU memory and
+// the DRAM of each GPU. The banks of the CPU memory do not have a module.
+func (b R9NanoPlatformBuilder) createGPUAddrTable() *mem.BankedLowModuleFinder {
+	addrTable := new(mem.BankedLowModuleFinder)
+	addrTable.BankSize = b.addrTableBankSize()
+	addrTable.LowModules = make([]sim.Port, cpuMemorySize/addrTable.BankSize)
+	return addrTable
+}
+
+func (b R9NanoPlatformBuilder) addrTableBankSize() uint64 {
+	x, y := uint64(cpuMemorySize), b.dramSize()
+	for y != 0 {
+		x, y = y, x%y
+	}
+
+	return x
+}
+
+func (b R9NanoPlatformBuilder) dramSize() uint64 {
+	return uint64(b.gpuConfig.DRAM.Size)
+}
+
+// gpuMemAddrOffset returns the first physical address of the DRAM of the GPU
+// with the given index. The first GPU has index 1.
+func (b R9NanoPlatformBuilder) gpuMemAddrOffset(index int) uint64 {
+	return cpuMemorySize + uint64(index-1)*b.dramSize()
+}
+
+// addGPUToAddrTable maps the DRAM of the next GPU to the given port.
+func (b R9NanoPlatformBuilder) addGPUToAddrTable(
+	addrTable *mem.BankedLowModuleFinder,
+	port sim.Port,
+) {
+	for i := uint64(0); i < b.dramSize()/addrTable.BankSize; i++ {
+		addrTable.LowModules = append(addrTable.LowModules, port)
+	}
 }
 
 func (b R9NanoPlatformBuilder) createConnection(
@@ -353,13 +498,18 @@ func (b *R9NanoPlatformBuilder) createGPUBuilder(
 	gpuBuilder := MakeR9NanoGPUBuilder().
 		WithEngine(engine).
 		WithMMU(mmuComponent).
-		WithNumCUPerShaderArray(b.numCUPerSA).
-		WithNumShaderArray(b.numSAPerGPU).
-		WithNumMemoryBank(16).
-		WithLog2MemoryBankInterleavingSize(7).
+		WithConfig(b.gpuConfig).
 		WithLog2PageSize(b.log2PageSize).
+		WithAtomicExecutionSite(b.atomicExecutionSite).
+		WithIssuePolicy(b.issuePolicy).
+		WithPreemption(b.preemption).
+		WithLatencyTable(b.latencyTable).
 		WithGlobalStorage(b.globalStorage)
 
+	if b.useSanitizer {
+		gpuBuilder = gpuBuilder.WithAddressChecker(gpuDriver, gpuDriver)
+	}
+
 	if b.monitor != nil {
 		gpuBuilder = gpuBuilder.WithMonitor(b.monitor)
 	}
@@ -416,15 +566,15 @@ func (b *R9NanoPlatformBuilder) createGPU(
 	pcieSwitchID int,
 ) *GPU {
 	name := fmt.Sprintf("GPU[%d]", index)
-	memAddrOffset := uint64(index) * 4 * mem.GB
+	memAddrOffset := b.gpuMemAddrOffset(index)
 	gpu := gpuBuilder.
 		WithMemAddrOffset(memAddrOffset).
 		Build(name, uint64(index))
 	gpuDriver.RegisterGPU(
 		gpu.Domain.GetPortByName("CommandProcessor"),
 		driver.DeviceProperties{
-			CUCount:  b.numCUPerSA * b.numSAPerGPU,
-			DRAMSize: 4 * mem.GB,
+			CUCount:  b.gpuConfig.NumCUsPerShaderArray * b.gpuConfig.NumShaderArrays,
+			DRAMSize: uint64(b.gpuConfig.DRAM.Size),
 		},
 	)
 	gpu.CommandProcessor.Driver = gpuDriver.GetPortByName("GPU")
@@ -444,9 +594,7 @@ func (b *R9NanoPlatformBuilder) configRDMAEngine(
 	addrTable *mem.BankedLowModuleFinder,
 ) {
 	gpu.RDMAEngine.RemoteRDMAAddressTable = addrTable
-	addrTable.LowModules = append(
-		addrTable.LowModules,
-		gpu.RDMAEngine.ToOutside)
+	b.addGPUToAddrTable(addrTable, gpu.RDMAEngine.ToOutside)
 }
 
 func (b *R9NanoPlatformBuilder) configPMC(
@@ -455,9 +603,7 @@ func (b *R9NanoPlatformBuilder) configPMC(
 	addrTable *mem.BankedLowModuleFinder,
 ) {
 	gpu.PMC.RemotePMCAddressTable = addrTable
-	addrTable.LowModules = append(
-		addrTable.LowModules,
-		gpu.PMC.GetPortByName("Remote"))
+	b.addGPUToAddrTable(addrTable, gpu.PMC.GetPortByName("Remote"))
 	gpuDriver.RemotePMCPorts = append(
 		gpuDriver.RemotePMCPorts, gpu.PMC.GetPortByName("Remote"))
 }
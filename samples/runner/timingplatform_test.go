@@ -0,0 +1,45 @@
+package runner
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+type namedPort struct {
+	sim.Port
+	name string
+}
+
+var _ = Describe("R9NanoPlatformBuilder address layout", func() {
+	var b R9NanoPlatformBuilder
+
+	BeforeEach(func() {
+		b = MakeR9NanoBuilder()
+	})
+
+	It("should place the GPUs back to back after the CPU memory", func() {
+		b.gpuConfig.DRAM.Size = ByteSize(3 * mem.GB)
+
+		Expect(b.gpuMemAddrOffset(1)).To(Equal(uint64(4 * mem.GB)))
+		Expect(b.gpuMemAddrOffset(2)).To(Equal(uint64(7 * mem.GB)))
+	})
+
+	It("should find the GPU that owns an address", func() {
+		b.gpuConfig.DRAM.Size = ByteSize(3 * mem.GB)
+		gpu1 := &namedPort{name: "GPU1"}
+		gpu2 := &namedPort{name: "GPU2"}
+
+		table := b.createGPUAddrTable()
+		b.addGPUToAddrTable(table, gpu1)
+		b.addGPUToAddrTable(table, gpu2)
+
+		Expect(table.Find(0)).To(BeNil())
+		Expect(table.Find(4*mem.GB - 1)).To(BeNil())
+		Expect(table.Find(4 * mem.GB)).To(BeIdenticalTo(gpu1))
+		Expect(table.Find(7*mem.GB - 1)).To(BeIdenticalTo(gpu1))
+		Expect(table.Find(7 * mem.GB)).To(BeIdenticalTo(gpu2))
+		Expect(table.Find(10*mem.GB - 1)).To(BeIdenticalTo(gpu2))
+	})
+})
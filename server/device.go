@@ -1,7 +1,6 @@
 package server
 
 import (
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -21,12 +20,7 @@ func handleDeviceCount(w http.ResponseWriter, r *http.Request) {
 
 	fmt.Println(rsp)
 
-	rspData, err := json.Marshal(rsp)
-	if err != nil {
-		panic(err)
-	}
-
-	w.Write(rspData)
+	writeOutput(w, rsp)
 
 	fmt.Println("done")
 }
@@ -88,25 +82,20 @@ func handleDeviceProperties(w http.ResponseWriter, r *http.Request) {
 
 	deviceID, err := strconv.Atoi(deviceIDStr)
 	if err != nil {
-		http.Error(w, err.Error(), 400)
-	}
-
-	if deviceID < 0 {
-		http.Error(w, "device id must be a positive number", 400)
+		writeError(w, http.StatusBadRequest, hipErrorInvalidDevice,
+			"device id %s is not valid", deviceIDStr)
+		return
 	}
 
-	if deviceID > len(serverInstance.driver.GPUs) {
-		http.Error(w, "GPU does not exist", 404)
+	if deviceID < 0 || deviceID > len(serverInstance.driver.GPUs) {
+		writeError(w, http.StatusNotFound, hipErrorInvalidDevice,
+			"GPU %d does not exist", deviceID)
+		return
 	}
 
 	deviceProperty := getDeviceProperty(deviceID)
 
-	rspData, err := json.Marshal(deviceProperty)
-	if err != nil {
-		panic(err)
-	}
-
-	w.Write(rspData)
+	writeOutput(w, deviceProperty)
 }
 
 func getDeviceProperty(deviceID int) DeviceProperty {
@@ -0,0 +1,132 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// A hipError is an error code that follows the hipError_t enumeration of HIP,
+// so that the clients can return the code to the applications directly.
+type hipError int
+
+const (
+	hipErrorInvalidValue         hipError = 1
+	hipErrorOutOfMemory          hipError = 2
+	hipErrorInvalidConfiguration hipError = 9
+	hipErrorInvalidDevicePointer hipError = 17
+	hipErrorInvalidDevice        hipError = 101
+	hipErrorInvalidImage         hipError = 200
+	hipErrorInvalidContext       hipError = 201
+	hipErrorInvalidHandle        hipError = 400
+	hipErrorNotFound             hipError = 500
+	hipErrorNotReady             hipError = 600
+	hipErrorUnknown              hipError = 999
+)
+
+var hipErrorNames = map[hipError]string{
+	hipErrorInvalidValue:         "hipErrorInvalidValue",
+	hipErrorOutOfMemory:          "hipErrorOutOfMemory",
+	hipErrorInvalidConfiguration: "hipErrorInvalidConfiguration",
+	hipErrorInvalidDevicePointer: "hipErrorInvalidDevicePointer",
+	hipErrorInvalidDevice:        "hipErrorInvalidDevice",
+	hipErrorInvalidImage:         "hipErrorInvalidImage",
+	hipErrorInvalidContext:       "hipErrorInvalidContext",
+	hipErrorInvalidHandle:        "hipErrorInvalidHandle",
+	hipErrorNotFound:             "hipErrorNotFound",
+	hipErrorNotReady:             "hipErrorNotReady",
+	hipErrorUnknown:              "hipErrorUnknown",
+}
+
+func (e hipError) String() string {
+	return hipErrorNames[e]
+}
+
+type errorDetail struct {
+	Code    int    `json:"code"`
+	Name    string `json:"name"`
+	Message string `json:"message"`
+}
+
+type errorOutput struct {
+	Error errorDetail `json:"error"`
+}
+
+// writeError responds with a JSON error object, for example:
+//
+//	{"error": {"code": 1, "name": "hipErrorInvalidValue", "message": "..."}}
+func writeError(
+	w http.ResponseWriter,
+	status int,
+	code hipError,
+	format string,
+	args ...interface{},
+) {
+	output := errorOutput{
+		Error: errorDetail{
+			Code:    int(code),
+			Name:    code.String(),
+			Message: fmt.Sprintf(format, args...),
+		},
+	}
+
+	rspData, _ := json.Marshal(output)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(rspData)
+}
+
+// writeOutput responds with the JSON encoding of the output.
+func writeOutput(w http.ResponseWriter, output interface{}) {
+	rspData, err := json.Marshal(output)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, hipErrorUnknown,
+			"cannot encode the response: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(rspData)
+}
+
+// decodeBody decodes the JSON request body into input. If the body is not
+// valid, it writes the error to the response and returns false.
+func decodeBody(
+	w http.ResponseWriter,
+	r *http.Request,
+	input interface{},
+) bool {
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, hipErrorInvalidValue,
+			"cannot read the request body: %v", err)
+		return false
+	}
+
+	return decodeJSON(w, data, input)
+}
+
+// decodeQuery decodes the JSON in the data query parameter into input. If the
+// parameter is not valid, it writes the error to the response and returns
+// false.
+func decodeQuery(
+	w http.ResponseWriter,
+	r *http.Request,
+	input interface{},
+) bool {
+	data := r.URL.Query().Get("data")
+	return decodeJSON(w, []byte(data), input)
+}
+
+func decodeJSON(w http.ResponseWriter, data []byte, input interface{}) bool {
+	err := json.Unmarshal(data, input)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, hipErrorInvalidValue,
+			"invalid input: %v", err)
+		return false
+	}
+
+	return true
+}
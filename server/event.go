@@ -0,0 +1,220 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/sarchlab/mgpusim/v3/driver"
+)
+
+// An event marks a point in a stream. The command is nil before the event is
+// recorded.
+type event struct {
+	cmd   *driver.EventCommand
+	queue *driver.CommandQueue
+}
+
+type eventInput struct {
+	Event  uint64 `json:"event"`
+	Stream uint64 `json:"stream"`
+}
+
+type eventOutput struct {
+	Event uint64 `json:"event"`
+}
+
+type eventQueryOutput struct {
+	Completed bool `json:"completed"`
+}
+
+type eventElapsedTimeInput struct {
+	Start uint64 `json:"start"`
+	End   uint64 `json:"end"`
+}
+
+type eventElapsedTimeOutput struct {
+	MS float64 `json:"ms"`
+}
+
+func (s *session) findEvent(handle uint64) (*event, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	e, ok := s.events[handle]
+	return e, ok
+}
+
+func handleEventCreate(w http.ResponseWriter, r *http.Request) {
+	s, ok := sessionOf(w, r)
+	if !ok {
+		return
+	}
+
+	s.mutex.Lock()
+	handle := s.newHandle()
+	s.events[handle] = &event{}
+	s.mutex.Unlock()
+
+	writeOutput(w, eventOutput{Event: handle})
+}
+
+func handleEventDestroy(w http.ResponseWriter, r *http.Request) {
+	s, ok := sessionOf(w, r)
+	if !ok {
+		return
+	}
+
+	input := eventInput{}
+	if !decodeBody(w, r, &input) {
+		return
+	}
+
+	s.mutex.Lock()
+	_, ok = s.events[input.Event]
+	delete(s.events, input.Event)
+	s.mutex.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusBadRequest, hipErrorInvalidHandle,
+			"event %d does not exist", input.Event)
+		return
+	}
+
+	writeOutput(w, struct{}{})
+}
+
+func handleEventRecord(w http.ResponseWriter, r *http.Request) {
+	s, ok := sessionOf(w, r)
+	if !ok {
+		return
+	}
+
+	input := eventInput{}
+	if !decodeBody(w, r, &input) {
+		return
+	}
+
+	e, ok := s.findEvent(input.Event)
+	if !ok {
+		writeError(w, http.StatusBadRequest, hipErrorInvalidHandle,
+			"event %d does not exist", input.Event)
+		return
+	}
+
+	q, ok := s.streamQueue(w, input.Stream)
+	if !ok {
+		return
+	}
+
+	cmd := s.driver.EnqueueEvent(q)
+
+	s.mutex.Lock()
+	e.cmd = cmd
+	e.queue = q
+	s.mutex.Unlock()
+
+	s.submit(q, input.Stream)
+
+	writeOutput(w, struct{}{})
+}
+
+func handleEventQuery(w http.ResponseWriter, r *http.Request) {
+	s, ok := sessionOf(w, r)
+	if !ok {
+		return
+	}
+
+	input := eventInput{}
+	if !decodeQuery(w, r, &input) {
+		return
+	}
+
+	cmd, _, ok := recordedEvent(w, s, input.Event)
+	if !ok {
+		return
+	}
+
+	writeOutput(w, eventQueryOutput{Completed: cmd.IsCompleted()})
+}
+
+func handleEventSynchronize(w http.ResponseWriter, r *http.Request) {
+	s, ok := sessionOf(w, r)
+	if !ok {
+		return
+	}
+
+	input := eventInput{}
+	if !decodeBody(w, r, &input) {
+		return
+	}
+
+	cmd, q, ok := recordedEvent(w, s, input.Event)
+	if !ok {
+		return
+	}
+
+	if !cmd.IsCompleted() {
+		s.driver.DrainCommandQueue(q)
+	}
+
+	writeOutput(w, struct{}{})
+}
+
+func handleEventElapsedTime(w http.ResponseWriter, r *http.Request) {
+	s, ok := sessionOf(w, r)
+	if !ok {
+		return
+	}
+
+	input := eventElapsedTimeInput{}
+	if !decodeQuery(w, r, &input) {
+		return
+	}
+
+	start, _, ok := recordedEvent(w, s, input.Start)
+	if !ok {
+		return
+	}
+
+	end, _, ok := recordedEvent(w, s, input.End)
+	if !ok {
+		return
+	}
+
+	if !start.IsCompleted() || !end.IsCompleted() {
+		writeError(w, http.StatusBadRequest, hipErrorNotReady,
+			"event has not completed")
+		return
+	}
+
+	writeOutput(w, eventElapsedTimeOutput{
+		MS: float64(end.Time()-start.Time()) * 1000,
+	})
+}
+
+// recordedEvent returns the command and the queue of an event that has been
+// recorded. If the event does not exist or has not been recorded, it writes
+// the error to the response and returns false.
+func recordedEvent(
+	w http.ResponseWriter,
+	s *session,
+	handle uint64,
+) (*driver.EventCommand, *driver.CommandQueue, bool) {
+	e, ok := s.findEvent(handle)
+	if !ok {
+		writeError(w, http.StatusBadRequest, hipErrorInvalidHandle,
+			"event %d does not exist", handle)
+		return nil, nil, false
+	}
+
+	s.mutex.Lock()
+	cmd, q := e.cmd, e.queue
+	s.mutex.Unlock()
+
+	if cmd == nil {
+		writeError(w, http.StatusBadRequest, hipErrorInvalidHandle,
+			"event %d has not been recorded", handle)
+		return nil, nil, false
+	}
+
+	return cmd, q, true
+}
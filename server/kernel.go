@@ -1,65 +1,173 @@
 package server
 
 import (
+	"bytes"
+	"debug/elf"
 	"encoding/base64"
-	"encoding/json"
-	"io/ioutil"
+	"encoding/binary"
+	"errors"
 	"net/http"
 
 	"github.com/sarchlab/mgpusim/v3/insts"
+	"github.com/sarchlab/mgpusim/v3/kernels"
 )
 
+const maxThreadsPerBlock = 1024
+
 type dim3 struct {
 	X, Y, Z int
 }
 
-type launchKernelInput struct {
-	CodeObject     string `json:"code_object,omitempty"`
+// launchConfig is the part of the launch input that is shared by all the
+// ways of launching a kernel.
+type launchConfig struct {
 	Args           string `json:"args,omitempty"`
 	NumBlocks      dim3   `json:"num_blocks,omitempty"`
 	DimBlocks      dim3   `json:"dim_blocks,omitempty"`
 	SharedMemBytes int    `json:"shared_mem_bytes,omitempty"`
+	Stream         uint64 `json:"stream,omitempty"`
+}
+
+type launchKernelInput struct {
+	CodeObject string `json:"code_object,omitempty"`
+	KernelName string `json:"kernel_name,omitempty"`
+	launchConfig
 }
 
 func handleLaunchKernel(w http.ResponseWriter, r *http.Request) {
-	dataStr, err := ioutil.ReadAll(r.Body)
-	if err != nil {
-		panic(err)
+	s, ok := sessionOf(w, r)
+	if !ok {
+		return
 	}
 
 	dataJSON := launchKernelInput{}
-	err = json.Unmarshal(dataStr, &dataJSON)
-	if err != nil {
-		http.Error(w, "invalid input", 400)
+	if !decodeBody(w, r, &dataJSON) {
+		return
 	}
 
 	rawCodeObject, err := base64.StdEncoding.DecodeString(
 		dataJSON.CodeObject)
 	if err != nil {
-		panic(err)
+		writeError(w, http.StatusBadRequest, hipErrorInvalidValue,
+			"code object is not valid base64: %v", err)
+		return
+	}
+
+	hsaCo, code, err := parseCodeObject(rawCodeObject, dataJSON.KernelName)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, code, "%v", err)
+		return
 	}
-	hsaCo := insts.NewHsaCoFromData(rawCodeObject)
 
-	rawArgs, err := base64.StdEncoding.DecodeString(dataJSON.Args)
+	s.launch(w, hsaCo, dataJSON.launchConfig)
+}
+
+// launch enqueues a kernel launch to the stream given in the config. If the
+// config is not valid, it writes the error to the response.
+func (s *session) launch(
+	w http.ResponseWriter,
+	hsaCo *insts.HsaCo,
+	config launchConfig,
+) {
+	rawArgs, err := base64.StdEncoding.DecodeString(config.Args)
 	if err != nil {
-		panic(err)
+		writeError(w, http.StatusBadRequest, hipErrorInvalidValue,
+			"args is not valid base64: %v", err)
+		return
+	}
+
+	if uint64(len(rawArgs)) > hsaCo.KernargSegmentByteSize {
+		writeError(w, http.StatusBadRequest, hipErrorInvalidValue,
+			"%d bytes of arguments exceed the %d-byte kernarg segment",
+			len(rawArgs), hsaCo.KernargSegmentByteSize)
+		return
+	}
+
+	if !checkLaunchConfig(w, config.NumBlocks, config.DimBlocks) {
+		return
+	}
+
+	q, ok := s.streamQueue(w, config.Stream)
+	if !ok {
+		return
 	}
 
-	serverInstance.driver.LaunchKernel(
-		serverInstance.ctx,
+	s.driver.EnqueueLaunchKernel(
+		q,
 		hsaCo,
 		[3]uint32{
-			uint32(dataJSON.NumBlocks.X * dataJSON.DimBlocks.X),
-			uint32(dataJSON.NumBlocks.Y * dataJSON.DimBlocks.Y),
-			uint32(dataJSON.NumBlocks.Z * dataJSON.DimBlocks.Z),
+			uint32(config.NumBlocks.X * config.DimBlocks.X),
+			uint32(config.NumBlocks.Y * config.DimBlocks.Y),
+			uint32(config.NumBlocks.Z * config.DimBlocks.Z),
 		},
 		[3]uint16{
-			uint16(dataJSON.DimBlocks.X),
-			uint16(dataJSON.DimBlocks.Y),
-			uint16(dataJSON.DimBlocks.Z),
+			uint16(config.DimBlocks.X),
+			uint16(config.DimBlocks.Y),
+			uint16(config.DimBlocks.Z),
 		},
 		rawArgs,
 	)
+	s.submit(q, config.Stream)
+
+	writeOutput(w, struct{}{})
+}
+
+// parseCodeObject creates an HsaCo from the code object sent by the client.
+// The code object can either be an ELF file, from which the kernel with the
+// given name is extracted, or the kernel code object itself, which starts
+// with the 256-byte header. On failure, it also returns the HIP error code.
+func parseCodeObject(
+	data []byte,
+	kernelName string,
+) (*insts.HsaCo, hipError, error) {
+	var elfCo *insts.HsaCo
+	if bytes.HasPrefix(data, []byte(elf.ELFMAG)) {
+		var err error
+		elfCo, err = kernels.ParseProgramFromMemory(data, kernelName)
+		if errors.Is(err, kernels.ErrKernelNotFound) {
+			return nil, hipErrorNotFound, err
+		}
+
+		if err != nil {
+			return nil, hipErrorInvalidImage, err
+		}
+
+		data = elfCo.Data
+	}
+
+	headerSize := binary.Size(insts.HsaCoHeader{})
+	if len(data) < headerSize {
+		return nil, hipErrorInvalidImage,
+			errors.New("code object is shorter than the header")
+	}
+
+	hsaCo := insts.NewHsaCoFromData(data)
+	if elfCo != nil {
+		// Only the ELF file tells the exact GPU that the kernel targets.
+		hsaCo.Arch = elfCo.Arch
+	}
+
+	if hsaCo.KernelCodeEntryByteOffset >= uint64(len(data)) {
+		return nil, hipErrorInvalidImage,
+			errors.New("kernel entry is out of the code object")
+	}
+
+	return hsaCo, 0, nil
+}
+
+func checkLaunchConfig(w http.ResponseWriter, numBlocks, dimBlocks dim3) bool {
+	if numBlocks.X < 1 || numBlocks.Y < 1 || numBlocks.Z < 1 ||
+		dimBlocks.X < 1 || dimBlocks.Y < 1 || dimBlocks.Z < 1 {
+		writeError(w, http.StatusBadRequest, hipErrorInvalidConfiguration,
+			"grid and block dimensions must be positive")
+		return false
+	}
+
+	if dimBlocks.X*dimBlocks.Y*dimBlocks.Z > maxThreadsPerBlock {
+		writeError(w, http.StatusBadRequest, hipErrorInvalidConfiguration,
+			"a block cannot have more than %d threads", maxThreadsPerBlock)
+		return false
+	}
 
-	w.Write([]byte("{}"))
+	return true
 }
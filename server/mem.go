@@ -2,8 +2,7 @@ package server
 
 import (
 	"encoding/base64"
-	"encoding/json"
-	"io/ioutil"
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -20,76 +19,164 @@ type mallocOutput struct {
 }
 
 func handleMalloc(w http.ResponseWriter, r *http.Request) {
-	query := r.URL.Query()
-	dataStr := query.Get("data")
-	dataJSON := mallocInput{}
+	s, ok := sessionOf(w, r)
+	if !ok {
+		return
+	}
 
-	err := json.Unmarshal([]byte(dataStr), &dataJSON)
-	if err != nil {
-		http.Error(w, "invalid input", 400)
+	dataJSON := mallocInput{}
+	if !decodeQuery(w, r, &dataJSON) {
+		return
 	}
 
-	ptr := serverInstance.driver.AllocateMemory(
-		serverInstance.ctx, dataJSON.Size)
+	if dataJSON.Size == 0 {
+		writeError(w, http.StatusBadRequest, hipErrorInvalidValue,
+			"size must be positive")
+		return
+	}
 
-	output := mallocOutput{Ptr: uint64(ptr)}
+	ptr, err := s.driver.AllocateMemory(s.ctx, dataJSON.Size)
+	if errors.Is(err, driver.ErrOutOfMemory) {
+		writeError(w, http.StatusBadRequest, hipErrorOutOfMemory,
+			"cannot allocate %d bytes: %v", dataJSON.Size, err)
+		return
+	}
 
-	rspData, err := json.Marshal(output)
 	if err != nil {
-		panic(err)
+		writeError(w, http.StatusBadRequest, hipErrorUnknown, "%v", err)
+		return
 	}
 
-	w.Write(rspData)
+	s.mutex.Lock()
+	s.allocations[uint64(ptr)] = dataJSON.Size
+	s.mutex.Unlock()
+
+	writeOutput(w, mallocOutput{Ptr: uint64(ptr)})
 }
 
 func handleFree(w http.ResponseWriter, r *http.Request) {
+	s, ok := sessionOf(w, r)
+	if !ok {
+		return
+	}
+
 	ptrStr := mux.Vars(r)["ptr"]
 	ptr, err := strconv.ParseUint(ptrStr, 10, 64)
 	if err != nil {
-		http.Error(w, "ptr is not valid", 400)
+		writeError(w, http.StatusBadRequest, hipErrorInvalidValue,
+			"ptr %s is not valid", ptrStr)
+		return
+	}
+
+	s.mutex.Lock()
+	_, ok = s.allocations[ptr]
+	delete(s.allocations, ptr)
+	s.mutex.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusBadRequest, hipErrorInvalidDevicePointer,
+			"address %d is not allocated", ptr)
+		return
 	}
 
-	err = serverInstance.driver.FreeMemory(
-		serverInstance.ctx, driver.Ptr(ptr))
+	err = s.driver.FreeMemory(s.ctx, driver.Ptr(ptr))
 	if err != nil {
-		http.Error(w, "failed to free the memory", 400)
+		writeError(w, http.StatusBadRequest, hipErrorInvalidDevicePointer,
+			"failed to free the memory: %v", err)
+		return
+	}
+
+	writeOutput(w, struct{}{})
+}
+
+type memsetInput struct {
+	Ptr    uint64 `json:"ptr"`
+	Value  byte   `json:"value"`
+	Size   uint64 `json:"size"`
+	Stream uint64 `json:"stream"`
+}
+
+func handleMemset(w http.ResponseWriter, r *http.Request) {
+	s, ok := sessionOf(w, r)
+	if !ok {
+		return
+	}
+
+	input := memsetInput{}
+	if !decodeBody(w, r, &input) {
+		return
+	}
+
+	if !s.checkAllocated(w, input.Ptr, input.Size) {
+		return
 	}
 
-	w.Write([]byte("{}"))
+	// Like HIP, copying or setting zero bytes does nothing.
+	if input.Size == 0 {
+		writeOutput(w, struct{}{})
+		return
+	}
+
+	q, ok := s.streamQueue(w, input.Stream)
+	if !ok {
+		return
+	}
+
+	s.driver.EnqueueMemSet(q,
+		driver.Ptr(input.Ptr), input.Value, int(input.Size))
+	s.submit(q, input.Stream)
+
+	writeOutput(w, struct{}{})
 }
 
 type memcopyH2DInput struct {
-	Ptr  uint64
-	Data string
+	Ptr    uint64
+	Data   string
+	Stream uint64
 }
 
 func handleMemcopyH2D(w http.ResponseWriter, r *http.Request) {
-	dataStr, err := ioutil.ReadAll(r.Body)
-	if err != nil {
-		panic(err)
+	s, ok := sessionOf(w, r)
+	if !ok {
+		return
 	}
 
 	input := memcopyH2DInput{}
-	err = json.Unmarshal(dataStr, &input)
-	if err != nil {
-		http.Error(w, "invalid input", 400)
+	if !decodeBody(w, r, &input) {
+		return
 	}
 
 	rawData, err := base64.StdEncoding.DecodeString(input.Data)
 	if err != nil {
-		http.Error(w, "invalid input", 400)
+		writeError(w, http.StatusBadRequest, hipErrorInvalidValue,
+			"data is not valid base64: %v", err)
+		return
 	}
 
-	serverInstance.driver.MemCopyH2D(serverInstance.ctx,
-		driver.Ptr(input.Ptr), rawData)
+	if !s.checkAllocated(w, input.Ptr, uint64(len(rawData))) {
+		return
+	}
 
-	output := "{}"
-	w.Write([]byte(output))
+	if len(rawData) == 0 {
+		writeOutput(w, struct{}{})
+		return
+	}
+
+	q, ok := s.streamQueue(w, input.Stream)
+	if !ok {
+		return
+	}
+
+	s.driver.EnqueueMemCopyH2D(q, driver.Ptr(input.Ptr), rawData)
+	s.submit(q, input.Stream)
+
+	writeOutput(w, struct{}{})
 }
 
 type memcopyD2HInput struct {
-	Ptr  uint64
-	Size uint64
+	Ptr    uint64
+	Size   uint64
+	Stream uint64
 }
 
 type memcopyD2HOutput struct {
@@ -97,27 +184,77 @@ type memcopyD2HOutput struct {
 }
 
 func handleMemcopyD2H(w http.ResponseWriter, r *http.Request) {
-	query := r.URL.Query()
-	dataStr := query.Get("data")
+	s, ok := sessionOf(w, r)
+	if !ok {
+		return
+	}
+
 	dataJSON := memcopyD2HInput{}
+	if !decodeQuery(w, r, &dataJSON) {
+		return
+	}
 
-	err := json.Unmarshal([]byte(dataStr), &dataJSON)
-	if err != nil {
-		http.Error(w, "invalid input", 400)
+	if !s.checkAllocated(w, dataJSON.Ptr, dataJSON.Size) {
+		return
 	}
 
-	rawData := make([]byte, dataJSON.Size)
+	if dataJSON.Size == 0 {
+		writeOutput(w, memcopyD2HOutput{})
+		return
+	}
 
-	serverInstance.driver.MemCopyD2H(serverInstance.ctx,
-		rawData, driver.Ptr(dataJSON.Ptr))
+	q, ok := s.streamQueue(w, dataJSON.Stream)
+	if !ok {
+		return
+	}
+
+	// The data is returned in the response, so the copy always waits for the
+	// stream to complete.
+	rawData := make([]byte, dataJSON.Size)
+	s.driver.EnqueueMemCopyD2H(q, rawData, driver.Ptr(dataJSON.Ptr))
+	s.driver.DrainCommandQueue(q)
 
 	encodedData := base64.StdEncoding.EncodeToString(rawData)
 
-	output := memcopyD2HOutput{Data: encodedData}
-	rspData, err := json.Marshal(output)
-	if err != nil {
-		panic(err)
+	writeOutput(w, memcopyD2HOutput{Data: encodedData})
+}
+
+type memcopyD2DInput struct {
+	Dst    uint64 `json:"dst"`
+	Src    uint64 `json:"src"`
+	Size   uint64 `json:"size"`
+	Stream uint64 `json:"stream"`
+}
+
+func handleMemcopyD2D(w http.ResponseWriter, r *http.Request) {
+	s, ok := sessionOf(w, r)
+	if !ok {
+		return
+	}
+
+	input := memcopyD2DInput{}
+	if !decodeBody(w, r, &input) {
+		return
 	}
 
-	w.Write(rspData)
+	if !s.checkAllocated(w, input.Src, input.Size) ||
+		!s.checkAllocated(w, input.Dst, input.Size) {
+		return
+	}
+
+	if input.Size == 0 {
+		writeOutput(w, struct{}{})
+		return
+	}
+
+	q, ok := s.streamQueue(w, input.Stream)
+	if !ok {
+		return
+	}
+
+	s.driver.EnqueueMemCopyD2D(q,
+		driver.Ptr(input.Dst), driver.Ptr(input.Src), int(input.Size))
+	s.submit(q, input.Stream)
+
+	writeOutput(w, struct{}{})
 }
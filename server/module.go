@@ -0,0 +1,144 @@
+package server
+
+import (
+	"encoding/base64"
+	"net/http"
+
+	"github.com/sarchlab/mgpusim/v3/insts"
+	"github.com/sarchlab/mgpusim/v3/kernels"
+)
+
+// A module is a loaded ELF code object, similar to hipModule_t.
+type module struct {
+	kernels map[string]*insts.HsaCo
+}
+
+type moduleLoadInput struct {
+	Image string `json:"image"`
+}
+
+type moduleKernel struct {
+	Name                   string `json:"name"`
+	KernargSegmentByteSize uint64 `json:"kernarg_segment_byte_size"`
+}
+
+type moduleLoadOutput struct {
+	Module  uint64         `json:"module"`
+	Kernels []moduleKernel `json:"kernels"`
+}
+
+type moduleInput struct {
+	Module uint64 `json:"module"`
+}
+
+type moduleLaunchKernelInput struct {
+	Module     uint64 `json:"module"`
+	KernelName string `json:"kernel_name"`
+	launchConfig
+}
+
+func handleModuleLoad(w http.ResponseWriter, r *http.Request) {
+	s, ok := sessionOf(w, r)
+	if !ok {
+		return
+	}
+
+	input := moduleLoadInput{}
+	if !decodeBody(w, r, &input) {
+		return
+	}
+
+	image, err := base64.StdEncoding.DecodeString(input.Image)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, hipErrorInvalidValue,
+			"image is not valid base64: %v", err)
+		return
+	}
+
+	names, err := kernels.KernelNames(image)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, hipErrorInvalidImage,
+			"image is not a valid ELF file: %v", err)
+		return
+	}
+
+	m := &module{kernels: make(map[string]*insts.HsaCo)}
+	output := moduleLoadOutput{Kernels: make([]moduleKernel, 0, len(names))}
+	for _, name := range names {
+		hsaCo, code, err := parseCodeObject(image, name)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, code,
+				"cannot load kernel %s: %v", name, err)
+			return
+		}
+
+		m.kernels[name] = hsaCo
+		output.Kernels = append(output.Kernels, moduleKernel{
+			Name:                   name,
+			KernargSegmentByteSize: hsaCo.KernargSegmentByteSize,
+		})
+	}
+
+	s.mutex.Lock()
+	output.Module = s.newHandle()
+	s.modules[output.Module] = m
+	s.mutex.Unlock()
+
+	writeOutput(w, output)
+}
+
+func handleModuleUnload(w http.ResponseWriter, r *http.Request) {
+	s, ok := sessionOf(w, r)
+	if !ok {
+		return
+	}
+
+	input := moduleInput{}
+	if !decodeBody(w, r, &input) {
+		return
+	}
+
+	s.mutex.Lock()
+	_, ok = s.modules[input.Module]
+	delete(s.modules, input.Module)
+	s.mutex.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusBadRequest, hipErrorInvalidHandle,
+			"module %d does not exist", input.Module)
+		return
+	}
+
+	writeOutput(w, struct{}{})
+}
+
+func handleModuleLaunchKernel(w http.ResponseWriter, r *http.Request) {
+	s, ok := sessionOf(w, r)
+	if !ok {
+		return
+	}
+
+	input := moduleLaunchKernelInput{}
+	if !decodeBody(w, r, &input) {
+		return
+	}
+
+	s.mutex.Lock()
+	m, ok := s.modules[input.Module]
+	s.mutex.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusBadRequest, hipErrorInvalidHandle,
+			"module %d does not exist", input.Module)
+		return
+	}
+
+	hsaCo, ok := m.kernels[input.KernelName]
+	if !ok {
+		writeError(w, http.StatusBadRequest, hipErrorNotFound,
+			"kernel %s is not in module %d", input.KernelName, input.Module)
+		return
+	}
+
+	s.launch(w, hsaCo, input.launchConfig)
+}
@@ -4,6 +4,7 @@ package server
 
 import (
 	"net/http"
+	"sync"
 
 	"github.com/gorilla/mux"
 
@@ -12,7 +13,10 @@ import (
 
 type server struct {
 	driver *driver.Driver
-	ctx    *driver.Context
+
+	mutex       sync.Mutex
+	nextSession uint64
+	sessions    map[uint64]*session
 }
 
 var serverInstance server
@@ -38,22 +42,49 @@ func (b Builder) WithDriver(d *driver.Driver) Builder {
 // to a port.
 func (b Builder) Build() {
 	serverInstance = server{
-		driver: b.driver,
+		driver:      b.driver,
+		nextSession: 1,
+		sessions:    make(map[uint64]*session),
 	}
 
 	b.driver.Run()
 
-	serverInstance.ctx = serverInstance.driver.Init()
+	serverInstance.sessions[defaultSession] = newSession(b.driver)
 }
 
 // RegisterHandlers registers all the handlers of the MGPUSim server
 func RegisterHandlers() {
+	http.Handle("/", newRouter())
+}
+
+func newRouter() *mux.Router {
 	r := mux.NewRouter()
 	r.HandleFunc("/device_count", handleDeviceCount)
 	r.HandleFunc("/device_properties/{id:[0-9]+}", handleDeviceProperties)
+	r.HandleFunc("/session_create", handleSessionCreate)
+	r.HandleFunc("/session_destroy", handleSessionDestroy)
+	r.HandleFunc("/set_device", handleSetDevice)
+	r.HandleFunc("/get_device", handleGetDevice)
 	r.HandleFunc("/malloc", handleMalloc)
+	r.HandleFunc("/free/{ptr:[0-9]+}", handleFree)
+	r.HandleFunc("/memset", handleMemset)
 	r.HandleFunc("/memcopy_h2d", handleMemcopyH2D)
 	r.HandleFunc("/memcopy_d2h", handleMemcopyD2H)
+	r.HandleFunc("/memcopy_d2d", handleMemcopyD2D)
 	r.HandleFunc("/launch_kernel", handleLaunchKernel)
-	http.Handle("/", r)
+	r.HandleFunc("/module_load", handleModuleLoad)
+	r.HandleFunc("/module_unload", handleModuleUnload)
+	r.HandleFunc("/module_launch_kernel", handleModuleLaunchKernel)
+	r.HandleFunc("/stream_create", handleStreamCreate)
+	r.HandleFunc("/stream_destroy", handleStreamDestroy)
+	r.HandleFunc("/stream_synchronize", handleStreamSynchronize)
+	r.HandleFunc("/event_create", handleEventCreate)
+	r.HandleFunc("/event_destroy", handleEventDestroy)
+	r.HandleFunc("/event_record", handleEventRecord)
+	r.HandleFunc("/event_query", handleEventQuery)
+	r.HandleFunc("/event_synchronize", handleEventSynchronize)
+	r.HandleFunc("/event_elapsed_time", handleEventElapsedTime)
+	r.HandleFunc("/device_synchronize", handleDeviceSynchronize)
+
+	return r
 }
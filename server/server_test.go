@@ -0,0 +1,498 @@
+package server
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+
+	"github.com/gorilla/mux"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/mem/vm"
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/driver"
+	"github.com/sarchlab/mgpusim/v3/insts"
+)
+
+var _ = Describe("Server", func() {
+	var (
+		gpuDriver *driver.Driver
+		router    *mux.Router
+	)
+
+	request := func(
+		method, path string,
+		session uint64,
+		body string,
+	) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		if session != defaultSession {
+			req.Header.Set(SessionHeader, fmt.Sprint(session))
+		}
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	post := func(path string, session uint64, body string) *httptest.ResponseRecorder {
+		return request(http.MethodPost, path, session, body)
+	}
+
+	get := func(path string, session uint64, data string) *httptest.ResponseRecorder {
+		return request(http.MethodGet,
+			path+"?data="+url.QueryEscape(data), session, "")
+	}
+
+	decode := func(rec *httptest.ResponseRecorder, output interface{}) {
+		Expect(rec.Code).To(Equal(http.StatusOK), rec.Body.String())
+		Expect(json.Unmarshal(rec.Body.Bytes(), output)).To(Succeed())
+	}
+
+	expectError := func(
+		rec *httptest.ResponseRecorder,
+		status int,
+		code hipError,
+	) {
+		Expect(rec.Code).To(Equal(status), rec.Body.String())
+
+		output := errorOutput{}
+		Expect(json.Unmarshal(rec.Body.Bytes(), &output)).To(Succeed())
+		Expect(output.Error.Code).To(Equal(int(code)))
+		Expect(output.Error.Name).To(Equal(code.String()))
+		Expect(output.Error.Message).NotTo(BeEmpty())
+	}
+
+	malloc := func(session uint64, size uint64) uint64 {
+		output := mallocOutput{}
+		decode(get("/malloc", session, fmt.Sprintf(`{"size": %d}`, size)),
+			&output)
+		return output.Ptr
+	}
+
+	memcopyH2D := func(session uint64, ptr uint64, data []byte) {
+		rec := post("/memcopy_h2d", session, fmt.Sprintf(
+			`{"ptr": %d, "data": "%s"}`,
+			ptr, base64.StdEncoding.EncodeToString(data)))
+		Expect(rec.Code).To(Equal(http.StatusOK), rec.Body.String())
+	}
+
+	memcopyD2H := func(session uint64, ptr uint64, size uint64) []byte {
+		output := memcopyD2HOutput{}
+		decode(get("/memcopy_d2h", session,
+			fmt.Sprintf(`{"ptr": %d, "size": %d}`, ptr, size)), &output)
+
+		data, err := base64.StdEncoding.DecodeString(output.Data)
+		Expect(err).NotTo(HaveOccurred())
+
+		return data
+	}
+
+	createSession := func() uint64 {
+		output := sessionOutput{}
+		decode(post("/session_create", defaultSession, ""), &output)
+		return output.Session
+	}
+
+	BeforeEach(func() {
+		log2PageSize := uint64(12)
+		gpuDriver = driver.MakeBuilder().
+			WithEngine(sim.NewSerialEngine()).
+			WithLog2PageSize(log2PageSize).
+			WithPageTable(vm.NewPageTable(log2PageSize)).
+			WithGlobalStorage(mem.NewStorage(5 * mem.GB)).
+			WithMagicMemoryCopyMiddleware().
+			Build("Driver")
+		gpuDriver.RegisterGPU(nil, driver.DeviceProperties{
+			CUCount:  4,
+			DRAMSize: 1 * mem.MB,
+		})
+
+		MakeBuilder().WithDriver(gpuDriver).Build()
+		router = newRouter()
+	})
+
+	AfterEach(func() {
+		gpuDriver.Terminate()
+	})
+
+	Context("memory", func() {
+		It("should copy data to and from the device", func() {
+			ptr := malloc(defaultSession, 64)
+
+			memcopyH2D(defaultSession, ptr, []byte{1, 2, 3, 4})
+
+			Expect(memcopyD2H(defaultSession, ptr, 4)).
+				To(Equal([]byte{1, 2, 3, 4}))
+		})
+
+		It("should set memory", func() {
+			ptr := malloc(defaultSession, 64)
+
+			rec := post("/memset", defaultSession,
+				fmt.Sprintf(`{"ptr": %d, "value": 7, "size": 8}`, ptr))
+			Expect(rec.Code).To(Equal(http.StatusOK), rec.Body.String())
+
+			Expect(memcopyD2H(defaultSession, ptr, 8)).
+				To(Equal(bytes.Repeat([]byte{7}, 8)))
+		})
+
+		It("should reject malformed input", func() {
+			expectError(get("/malloc", defaultSession, "{"),
+				http.StatusBadRequest, hipErrorInvalidValue)
+		})
+
+		It("should reject zero-byte allocations", func() {
+			expectError(get("/malloc", defaultSession, `{"size": 0}`),
+				http.StatusBadRequest, hipErrorInvalidValue)
+		})
+
+		It("should report out of memory", func() {
+			expectError(get("/malloc", defaultSession, `{"size": 4194304}`),
+				http.StatusBadRequest, hipErrorOutOfMemory)
+		})
+
+		It("should reject freeing unallocated memory", func() {
+			ptr := malloc(defaultSession, 64)
+
+			rec := request(http.MethodGet,
+				fmt.Sprintf("/free/%d", ptr), defaultSession, "")
+			Expect(rec.Code).To(Equal(http.StatusOK), rec.Body.String())
+
+			expectError(
+				request(http.MethodGet,
+					fmt.Sprintf("/free/%d", ptr), defaultSession, ""),
+				http.StatusBadRequest, hipErrorInvalidDevicePointer)
+		})
+
+		It("should reject copies out of the allocation", func() {
+			ptr := malloc(defaultSession, 16)
+
+			expectError(
+				post("/memcopy_h2d", defaultSession, fmt.Sprintf(
+					`{"ptr": %d, "data": "%s"}`, ptr,
+					base64.StdEncoding.EncodeToString(make([]byte, 32)))),
+				http.StatusBadRequest, hipErrorInvalidDevicePointer)
+			expectError(
+				get("/memcopy_d2h", defaultSession,
+					fmt.Sprintf(`{"ptr": %d, "size": 4}`, ptr+16)),
+				http.StatusBadRequest, hipErrorInvalidDevicePointer)
+			expectError(
+				post("/memcopy_d2d", defaultSession, fmt.Sprintf(
+					`{"dst": %d, "src": %d, "size": 16}`, ptr+8, ptr)),
+				http.StatusBadRequest, hipErrorInvalidDevicePointer)
+		})
+
+		It("should reject invalid base64 data", func() {
+			ptr := malloc(defaultSession, 16)
+
+			expectError(
+				post("/memcopy_h2d", defaultSession,
+					fmt.Sprintf(`{"ptr": %d, "data": "!!"}`, ptr)),
+				http.StatusBadRequest, hipErrorInvalidValue)
+		})
+	})
+
+	Context("sessions", func() {
+		It("should isolate the memory of sessions", func() {
+			s1 := createSession()
+			s2 := createSession()
+
+			ptr1 := malloc(s1, 64)
+			memcopyH2D(s1, ptr1, []byte{1, 1, 1, 1})
+
+			expectError(
+				get("/memcopy_d2h", s2,
+					fmt.Sprintf(`{"ptr": %d, "size": 4}`, ptr1)),
+				http.StatusBadRequest, hipErrorInvalidDevicePointer)
+
+			ptr2 := malloc(s2, 64)
+			memcopyH2D(s2, ptr2, []byte{2, 2, 2, 2})
+
+			Expect(memcopyD2H(s1, ptr1, 4)).To(Equal([]byte{1, 1, 1, 1}))
+			Expect(memcopyD2H(s2, ptr2, 4)).To(Equal([]byte{2, 2, 2, 2}))
+		})
+
+		It("should release the memory of destroyed sessions", func() {
+			s := createSession()
+			malloc(s, 768*1024)
+			stream := streamOutput{}
+			decode(post("/stream_create", s, ""), &stream)
+
+			rec := post("/session_destroy", s, "")
+			Expect(rec.Code).To(Equal(http.StatusOK), rec.Body.String())
+
+			malloc(defaultSession, 768*1024)
+			expectError(get("/malloc", s, `{"size": 64}`),
+				http.StatusBadRequest, hipErrorInvalidContext)
+		})
+
+		It("should reject unknown sessions", func() {
+			expectError(get("/malloc", 42, `{"size": 64}`),
+				http.StatusBadRequest, hipErrorInvalidContext)
+		})
+
+		It("should use the default stream of the selected GPU", func() {
+			gpuDriver.RegisterGPU(nil, driver.DeviceProperties{
+				CUCount:  4,
+				DRAMSize: 1 * mem.MB,
+			})
+			s := serverInstance.sessions[defaultSession]
+
+			q1, _ := s.queueOfStream(0)
+			rec := post("/set_device", defaultSession, `{"device": 2}`)
+			Expect(rec.Code).To(Equal(http.StatusOK), rec.Body.String())
+			q2, _ := s.queueOfStream(0)
+			post("/set_device", defaultSession, `{"device": 1}`)
+			q3, _ := s.queueOfStream(0)
+
+			Expect(q1.GPUID).To(Equal(1))
+			Expect(q2.GPUID).To(Equal(2))
+			Expect(q3).To(BeIdenticalTo(q1))
+		})
+
+		It("should reject selecting a GPU that does not exist", func() {
+			expectError(post("/set_device", defaultSession, `{"device": 2}`),
+				http.StatusNotFound, hipErrorInvalidDevice)
+		})
+	})
+
+	Context("streams and events", func() {
+		It("should complete events recorded on a stream", func() {
+			ptr := malloc(defaultSession, 64)
+
+			stream := streamOutput{}
+			decode(post("/stream_create", defaultSession, ""), &stream)
+			start := eventOutput{}
+			decode(post("/event_create", defaultSession, ""), &start)
+			end := eventOutput{}
+			decode(post("/event_create", defaultSession, ""), &end)
+
+			post("/event_record", defaultSession, fmt.Sprintf(
+				`{"event": %d, "stream": %d}`, start.Event, stream.Stream))
+			post("/memset", defaultSession, fmt.Sprintf(
+				`{"ptr": %d, "value": 1, "size": 64, "stream": %d}`,
+				ptr, stream.Stream))
+			post("/event_record", defaultSession, fmt.Sprintf(
+				`{"event": %d, "stream": %d}`, end.Event, stream.Stream))
+			rec := post("/event_synchronize", defaultSession,
+				fmt.Sprintf(`{"event": %d}`, end.Event))
+			Expect(rec.Code).To(Equal(http.StatusOK), rec.Body.String())
+
+			query := eventQueryOutput{}
+			decode(get("/event_query", defaultSession,
+				fmt.Sprintf(`{"event": %d}`, end.Event)), &query)
+			Expect(query.Completed).To(BeTrue())
+
+			elapsed := eventElapsedTimeOutput{}
+			decode(get("/event_elapsed_time", defaultSession,
+				fmt.Sprintf(`{"start": %d, "end": %d}`,
+					start.Event, end.Event)), &elapsed)
+			Expect(elapsed.MS).To(BeNumerically(">=", 0))
+		})
+
+		It("should reuse the queue of the default stream", func() {
+			s := serverInstance.sessions[defaultSession]
+			ptr := malloc(defaultSession, 64)
+
+			q, _ := s.queueOfStream(0)
+			post("/memset", defaultSession,
+				fmt.Sprintf(`{"ptr": %d, "value": 1, "size": 64}`, ptr))
+
+			q2, _ := s.queueOfStream(0)
+			Expect(q2).To(BeIdenticalTo(q))
+		})
+
+		It("should drain a stream with one goroutine", func() {
+			s := serverInstance.sessions[defaultSession]
+			ptr := malloc(defaultSession, 64)
+			stream := streamOutput{}
+			decode(post("/stream_create", defaultSession, ""), &stream)
+
+			for i := 0; i < 8; i++ {
+				post("/memset", defaultSession, fmt.Sprintf(
+					`{"ptr": %d, "value": %d, "size": 64, "stream": %d}`,
+					ptr, i, stream.Stream))
+			}
+
+			s.mutex.Lock()
+			Expect(len(s.draining)).To(BeNumerically("<=", 1))
+			s.mutex.Unlock()
+
+			rec := post("/stream_synchronize", defaultSession,
+				fmt.Sprintf(`{"stream": %d}`, stream.Stream))
+			Expect(rec.Code).To(Equal(http.StatusOK), rec.Body.String())
+			Expect(memcopyD2H(defaultSession, ptr, 4)).
+				To(Equal([]byte{7, 7, 7, 7}))
+			Eventually(func() int {
+				s.mutex.Lock()
+				defer s.mutex.Unlock()
+				return len(s.draining)
+			}).Should(BeZero())
+		})
+
+		It("should reject unknown streams", func() {
+			ptr := malloc(defaultSession, 64)
+
+			expectError(
+				post("/memset", defaultSession, fmt.Sprintf(
+					`{"ptr": %d, "value": 1, "size": 64, "stream": 9}`, ptr)),
+				http.StatusBadRequest, hipErrorInvalidHandle)
+		})
+
+		It("should reject events that have not been recorded", func() {
+			event := eventOutput{}
+			decode(post("/event_create", defaultSession, ""), &event)
+
+			expectError(
+				get("/event_query", defaultSession,
+					fmt.Sprintf(`{"event": %d}`, event.Event)),
+				http.StatusBadRequest, hipErrorInvalidHandle)
+		})
+	})
+
+	Context("kernel launch", func() {
+		var codeObject []byte
+
+		launch := func(codeObject, args []byte, blocks string) *httptest.ResponseRecorder {
+			return post("/launch_kernel", defaultSession, fmt.Sprintf(
+				`{"code_object": "%s", "kernel_name": "copyKernel",
+				  "args": "%s", "num_blocks": %s,
+				  "dim_blocks": {"x": 64, "y": 1, "z": 1}}`,
+				base64.StdEncoding.EncodeToString(codeObject),
+				base64.StdEncoding.EncodeToString(args),
+				blocks))
+		}
+
+		oneBlock := `{"x": 1, "y": 1, "z": 1}`
+
+		BeforeEach(func() {
+			header := insts.HsaCoHeader{
+				KernelCodeEntryByteOffset: 256,
+				KernargSegmentByteSize:    8,
+			}
+			buf := bytes.NewBuffer(nil)
+			Expect(binary.Write(buf, binary.LittleEndian, header)).To(Succeed())
+			buf.Write(make([]byte, 16))
+			codeObject = buf.Bytes()
+		})
+
+		It("should reject invalid base64 code objects", func() {
+			expectError(
+				post("/launch_kernel", defaultSession,
+					`{"code_object": "!!", "args": ""}`),
+				http.StatusBadRequest, hipErrorInvalidValue)
+		})
+
+		It("should reject truncated code objects", func() {
+			expectError(launch(codeObject[:100], nil, oneBlock),
+				http.StatusBadRequest, hipErrorInvalidImage)
+		})
+
+		It("should reject invalid ELF files", func() {
+			expectError(launch([]byte("\x7fELF-broken"), nil, oneBlock),
+				http.StatusBadRequest, hipErrorInvalidImage)
+		})
+
+		It("should reject kernels that are not in the ELF file", func() {
+			data, err := os.ReadFile("../driver/memcopy.hsaco")
+			Expect(err).NotTo(HaveOccurred())
+
+			rec := post("/launch_kernel", defaultSession, fmt.Sprintf(
+				`{"code_object": "%s", "kernel_name": "noSuchKernel"}`,
+				base64.StdEncoding.EncodeToString(data)))
+
+			expectError(rec, http.StatusBadRequest, hipErrorNotFound)
+		})
+
+		It("should reject arguments larger than the kernarg segment", func() {
+			expectError(launch(codeObject, make([]byte, 16), oneBlock),
+				http.StatusBadRequest, hipErrorInvalidValue)
+		})
+
+		It("should reject empty grids", func() {
+			expectError(
+				launch(codeObject, make([]byte, 8), `{"x": 0, "y": 1, "z": 1}`),
+				http.StatusBadRequest, hipErrorInvalidConfiguration)
+		})
+	})
+
+	Context("modules", func() {
+		var image []byte
+
+		BeforeEach(func() {
+			var err error
+			image, err = os.ReadFile("../driver/memcopy.hsaco")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		loadModule := func() moduleLoadOutput {
+			output := moduleLoadOutput{}
+			decode(post("/module_load", defaultSession, fmt.Sprintf(
+				`{"image": "%s"}`, base64.StdEncoding.EncodeToString(image))),
+				&output)
+			return output
+		}
+
+		It("should list the kernels in the module", func() {
+			output := loadModule()
+
+			Expect(output.Module).NotTo(BeZero())
+			Expect(output.Kernels).To(HaveLen(1))
+			Expect(output.Kernels[0].Name).To(Equal("copyKernel"))
+			Expect(output.Kernels[0].KernargSegmentByteSize).NotTo(BeZero())
+		})
+
+		It("should reject images that are not ELF files", func() {
+			expectError(
+				post("/module_load", defaultSession, fmt.Sprintf(
+					`{"image": "%s"}`,
+					base64.StdEncoding.EncodeToString([]byte("not an elf")))),
+				http.StatusBadRequest, hipErrorInvalidImage)
+		})
+
+		It("should reject kernels that are not in the module", func() {
+			output := loadModule()
+
+			expectError(
+				post("/module_launch_kernel", defaultSession, fmt.Sprintf(
+					`{"module": %d, "kernel_name": "noSuchKernel"}`,
+					output.Module)),
+				http.StatusBadRequest, hipErrorNotFound)
+		})
+
+		It("should reject unloaded modules", func() {
+			output := loadModule()
+
+			rec := post("/module_unload", defaultSession,
+				fmt.Sprintf(`{"module": %d}`, output.Module))
+			Expect(rec.Code).To(Equal(http.StatusOK), rec.Body.String())
+
+			expectError(
+				post("/module_launch_kernel", defaultSession, fmt.Sprintf(
+					`{"module": %d, "kernel_name": "copyKernel"}`,
+					output.Module)),
+				http.StatusBadRequest, hipErrorInvalidHandle)
+		})
+
+		It("should check the arguments against the kernarg segment", func() {
+			output := loadModule()
+			args := make([]byte, output.Kernels[0].KernargSegmentByteSize+1)
+
+			expectError(
+				post("/module_launch_kernel", defaultSession, fmt.Sprintf(
+					`{"module": %d, "kernel_name": "copyKernel",
+					  "args": "%s"}`,
+					output.Module, base64.StdEncoding.EncodeToString(args))),
+				http.StatusBadRequest, hipErrorInvalidValue)
+		})
+	})
+})
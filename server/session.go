@@ -0,0 +1,232 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/sarchlab/mgpusim/v3/driver"
+)
+
+// SessionHeader is the HTTP header that carries the session handle. Requests
+// without the header use the default session.
+const SessionHeader = "X-MGPUSim-Session"
+
+const defaultSession = 0
+
+// A session holds the state of a client. Each session has its own driver
+// context, so that sessions have isolated address spaces and can select GPUs
+// independently.
+type session struct {
+	driver *driver.Driver
+	ctx    *driver.Context
+	device int
+
+	// defaultQueues are the command queues of the default stream, one for
+	// each device that the session has selected. A command queue sends its
+	// commands to the GPU that was selected when the queue was created.
+	defaultQueues map[int]*driver.CommandQueue
+
+	mutex       sync.Mutex
+	nextHandle  uint64
+	streams     map[uint64]*driver.CommandQueue
+	draining    map[*driver.CommandQueue]bool
+	events      map[uint64]*event
+	modules     map[uint64]*module
+	allocations map[uint64]uint64
+}
+
+func newSession(d *driver.Driver) *session {
+	ctx := d.Init()
+
+	return &session{
+		driver: d,
+		ctx:    ctx,
+		device: 1,
+		defaultQueues: map[int]*driver.CommandQueue{
+			1: d.CreateCommandQueue(ctx),
+		},
+		nextHandle:  1,
+		streams:     make(map[uint64]*driver.CommandQueue),
+		draining:    make(map[*driver.CommandQueue]bool),
+		events:      make(map[uint64]*event),
+		modules:     make(map[uint64]*module),
+		allocations: make(map[uint64]uint64),
+	}
+}
+
+// isAllocated checks if the address range [ptr, ptr+size) is within one of
+// the allocations of the session.
+func (s *session) isAllocated(ptr, size uint64) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for start, allocSize := range s.allocations {
+		if ptr >= start && ptr+size <= start+allocSize && ptr+size >= ptr {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkAllocated writes an error to the response and returns false if the
+// address range [ptr, ptr+size) is not allocated.
+func (s *session) checkAllocated(
+	w http.ResponseWriter,
+	ptr, size uint64,
+) bool {
+	if !s.isAllocated(ptr, size) {
+		writeError(w, http.StatusBadRequest, hipErrorInvalidDevicePointer,
+			"address range [%d, %d) is not allocated", ptr, ptr+size)
+		return false
+	}
+
+	return true
+}
+
+// sessionOf returns the session that a request belongs to. If the session
+// does not exist, it writes the error to the response and returns false.
+func sessionOf(w http.ResponseWriter, r *http.Request) (*session, bool) {
+	handle := uint64(defaultSession)
+
+	handleStr := r.Header.Get(SessionHeader)
+	if handleStr != "" {
+		var err error
+		handle, err = strconv.ParseUint(handleStr, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, hipErrorInvalidContext,
+				"invalid session %q", handleStr)
+			return nil, false
+		}
+	}
+
+	serverInstance.mutex.Lock()
+	s, ok := serverInstance.sessions[handle]
+	serverInstance.mutex.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusBadRequest, hipErrorInvalidContext,
+			"session %d does not exist", handle)
+		return nil, false
+	}
+
+	return s, true
+}
+
+type sessionOutput struct {
+	Session uint64 `json:"session"`
+}
+
+func handleSessionCreate(w http.ResponseWriter, r *http.Request) {
+	s := newSession(serverInstance.driver)
+
+	serverInstance.mutex.Lock()
+	handle := serverInstance.nextSession
+	serverInstance.nextSession++
+	serverInstance.sessions[handle] = s
+	serverInstance.mutex.Unlock()
+
+	writeOutput(w, sessionOutput{Session: handle})
+}
+
+func handleSessionDestroy(w http.ResponseWriter, r *http.Request) {
+	s, ok := sessionOf(w, r)
+	if !ok {
+		return
+	}
+
+	serverInstance.mutex.Lock()
+	isDefault := s == serverInstance.sessions[defaultSession]
+	if !isDefault {
+		for handle, session := range serverInstance.sessions {
+			if session == s {
+				delete(serverInstance.sessions, handle)
+			}
+		}
+	}
+	serverInstance.mutex.Unlock()
+
+	if isDefault {
+		writeError(w, http.StatusBadRequest, hipErrorInvalidContext,
+			"cannot destroy the default session")
+		return
+	}
+
+	s.release()
+
+	writeOutput(w, struct{}{})
+}
+
+// release waits for the commands of the session to complete, frees the memory
+// that the session has allocated, and releases the driver context together
+// with its command queues.
+func (s *session) release() {
+	s.synchronize()
+
+	s.mutex.Lock()
+	allocations := s.allocations
+	s.allocations = make(map[uint64]uint64)
+	s.streams = make(map[uint64]*driver.CommandQueue)
+	s.events = make(map[uint64]*event)
+	s.modules = make(map[uint64]*module)
+	s.mutex.Unlock()
+
+	for ptr := range allocations {
+		err := s.driver.FreeMemory(s.ctx, driver.Ptr(ptr))
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	s.driver.ReleaseContext(s.ctx)
+}
+
+type deviceInput struct {
+	Device int `json:"device"`
+}
+
+type deviceOutput struct {
+	Device int `json:"device"`
+}
+
+func handleSetDevice(w http.ResponseWriter, r *http.Request) {
+	s, ok := sessionOf(w, r)
+	if !ok {
+		return
+	}
+
+	input := deviceInput{}
+	if !decodeBody(w, r, &input) {
+		return
+	}
+
+	if input.Device < 1 || input.Device > len(s.driver.GPUs) {
+		writeError(w, http.StatusNotFound, hipErrorInvalidDevice,
+			"GPU %d does not exist", input.Device)
+		return
+	}
+
+	s.mutex.Lock()
+	s.driver.SelectGPU(s.ctx, input.Device)
+	s.device = input.Device
+	if _, ok := s.defaultQueues[input.Device]; !ok {
+		s.defaultQueues[input.Device] = s.driver.CreateCommandQueue(s.ctx)
+	}
+	s.mutex.Unlock()
+
+	writeOutput(w, struct{}{})
+}
+
+func handleGetDevice(w http.ResponseWriter, r *http.Request) {
+	s, ok := sessionOf(w, r)
+	if !ok {
+		return
+	}
+
+	s.mutex.Lock()
+	output := deviceOutput{Device: s.device}
+	s.mutex.Unlock()
+
+	writeOutput(w, output)
+}
@@ -0,0 +1,179 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/sarchlab/mgpusim/v3/driver"
+)
+
+type streamInput struct {
+	Stream uint64 `json:"stream"`
+}
+
+type streamOutput struct {
+	Stream uint64 `json:"stream"`
+}
+
+// queueOfStream returns the command queue that the commands of a stream
+// should be enqueued to. Stream 0 is the default stream of the selected
+// device, which has a command queue of its own and runs the operations
+// synchronously.
+func (s *session) queueOfStream(stream uint64) (*driver.CommandQueue, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if stream == 0 {
+		return s.defaultQueues[s.device], true
+	}
+
+	q, ok := s.streams[stream]
+	return q, ok
+}
+
+// streamQueue returns the command queue of a stream. If the stream does not
+// exist, it writes the error to the response and returns false.
+func (s *session) streamQueue(
+	w http.ResponseWriter,
+	stream uint64,
+) (*driver.CommandQueue, bool) {
+	q, ok := s.queueOfStream(stream)
+	if !ok {
+		writeError(w, http.StatusBadRequest, hipErrorInvalidHandle,
+			"stream %d does not exist", stream)
+		return nil, false
+	}
+
+	return q, true
+}
+
+// submit starts executing the commands in the queue. Operations on the
+// default stream return after the commands complete, while operations on
+// other streams return immediately.
+func (s *session) submit(q *driver.CommandQueue, stream uint64) {
+	if stream == 0 {
+		s.driver.DrainCommandQueue(q)
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.draining[q] {
+		return
+	}
+
+	s.draining[q] = true
+	go s.drain(q)
+}
+
+// drain keeps draining the queue until it is empty. Since submit checks the
+// flag under the same lock, the commands enqueued in the meantime are never
+// left behind.
+func (s *session) drain(q *driver.CommandQueue) {
+	for {
+		s.driver.DrainCommandQueue(q)
+
+		s.mutex.Lock()
+		if q.NumCommand() == 0 {
+			delete(s.draining, q)
+			s.mutex.Unlock()
+			return
+		}
+		s.mutex.Unlock()
+	}
+}
+
+func (s *session) newHandle() uint64 {
+	h := s.nextHandle
+	s.nextHandle++
+	return h
+}
+
+// synchronize waits for all the streams of the session to complete.
+func (s *session) synchronize() {
+	s.mutex.Lock()
+	queues := make([]*driver.CommandQueue, 0, len(s.streams))
+	for _, q := range s.streams {
+		queues = append(queues, q)
+	}
+	s.mutex.Unlock()
+
+	for _, q := range queues {
+		s.driver.DrainCommandQueue(q)
+	}
+}
+
+func handleStreamCreate(w http.ResponseWriter, r *http.Request) {
+	s, ok := sessionOf(w, r)
+	if !ok {
+		return
+	}
+
+	q := s.driver.CreateCommandQueue(s.ctx)
+
+	s.mutex.Lock()
+	handle := s.newHandle()
+	s.streams[handle] = q
+	s.mutex.Unlock()
+
+	writeOutput(w, streamOutput{Stream: handle})
+}
+
+func handleStreamDestroy(w http.ResponseWriter, r *http.Request) {
+	s, ok := sessionOf(w, r)
+	if !ok {
+		return
+	}
+
+	input := streamInput{}
+	if !decodeBody(w, r, &input) {
+		return
+	}
+
+	s.mutex.Lock()
+	q, ok := s.streams[input.Stream]
+	delete(s.streams, input.Stream)
+	s.mutex.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusBadRequest, hipErrorInvalidHandle,
+			"stream %d does not exist", input.Stream)
+		return
+	}
+
+	s.driver.ReleaseCommandQueue(q)
+
+	writeOutput(w, struct{}{})
+}
+
+func handleStreamSynchronize(w http.ResponseWriter, r *http.Request) {
+	s, ok := sessionOf(w, r)
+	if !ok {
+		return
+	}
+
+	input := streamInput{}
+	if !decodeBody(w, r, &input) {
+		return
+	}
+
+	q, ok := s.streamQueue(w, input.Stream)
+	if !ok {
+		return
+	}
+
+	s.driver.DrainCommandQueue(q)
+
+	writeOutput(w, struct{}{})
+}
+
+func handleDeviceSynchronize(w http.ResponseWriter, r *http.Request) {
+	s, ok := sessionOf(w, r)
+	if !ok {
+		return
+	}
+
+	s.synchronize()
+
+	writeOutput(w, struct{}{})
+}
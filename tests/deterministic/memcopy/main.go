@@ -53,10 +53,10 @@ func (b *Benchmark) Run() {
 	for i := uint64(0); i < b.ByteSize; i++ {
 		b.data[i] = byte(rand.Int())
 	}
-	gpuData := b.driver.AllocateMemory(b.context, b.ByteSize)
+	gpuData := b.driver.MustAllocateMemory(b.context, b.ByteSize)
 
 	if b.useUnifiedMemory {
-		gpuData = b.driver.AllocateUnifiedMemory(b.context, b.ByteSize)
+		gpuData = b.driver.MustAllocateUnifiedMemory(b.context, b.ByteSize)
 	}
 	b.driver.MemCopyH2D(b.context, gpuData, b.data)
 	b.driver.MemCopyD2H(b.context, b.retData, gpuData)
@@ -0,0 +1,98 @@
+package atomics
+
+import (
+	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+// A Builder can build atomic units.
+type Builder struct {
+	engine          sim.Engine
+	freq            sim.Freq
+	deviceID        uint64
+	log2BlockSize   uint64
+	numReqPerCycle  int
+	maxTransactions int
+	lowModuleFinder mem.LowModuleFinder
+}
+
+// MakeBuilder creates a builder with default parameters.
+func MakeBuilder() Builder {
+	return Builder{
+		freq:            1 * sim.GHz,
+		log2BlockSize:   6,
+		numReqPerCycle:  4,
+		maxTransactions: 64,
+	}
+}
+
+// WithEngine sets the engine to use.
+func (b Builder) WithEngine(engine sim.Engine) Builder {
+	b.engine = engine
+	return b
+}
+
+// WithFreq sets the frequency that the unit works at.
+func (b Builder) WithFreq(freq sim.Freq) Builder {
+	b.freq = freq
+	return b
+}
+
+// WithDeviceID sets the ID of the GPU that the unit belongs to.
+func (b Builder) WithDeviceID(id uint64) Builder {
+	b.deviceID = id
+	return b
+}
+
+// WithLog2BlockSize sets the cache line size as a power of 2.
+func (b Builder) WithLog2BlockSize(n uint64) Builder {
+	b.log2BlockSize = n
+	return b
+}
+
+// WithNumReqPerCycle sets the number of requests that the unit can accept
+// in each cycle.
+func (b Builder) WithNumReqPerCycle(n int) Builder {
+	b.numReqPerCycle = n
+	return b
+}
+
+// WithMaxNumTransactions sets the number of atomic requests that the unit can
+// process at the same time.
+func (b Builder) WithMaxNumTransactions(n int) Builder {
+	b.maxTransactions = n
+	return b
+}
+
+// WithLowModuleFinder sets the table that decides where the cache lines are
+// read from and written to.
+func (b Builder) WithLowModuleFinder(lmf mem.LowModuleFinder) Builder {
+	b.lowModuleFinder = lmf
+	return b
+}
+
+// Build creates an atomic unit with the given parameters.
+func (b Builder) Build(name string) *Comp {
+	c := &Comp{}
+	c.TickingComponent = sim.NewTickingComponent(name, b.engine, b.freq, c)
+
+	c.deviceID = b.deviceID
+	c.log2BlockSize = b.log2BlockSize
+	c.numReqPerCycle = b.numReqPerCycle
+	c.maxTransactions = b.maxTransactions
+	c.lowModuleFinder = b.lowModuleFinder
+
+	c.topPort = sim.NewLimitNumMsgPort(c, 2*b.numReqPerCycle, name+".TopPort")
+	c.AddPort("Top", c.topPort)
+	c.bottomPort = sim.NewLimitNumMsgPort(c, 2*b.numReqPerCycle,
+		name+".BottomPort")
+	c.AddPort("Bottom", c.bottomPort)
+	c.remotePort = sim.NewLimitNumMsgPort(c, 2*b.numReqPerCycle,
+		name+".RemotePort")
+	c.AddPort("Remote", c.remotePort)
+	c.translationPort = sim.NewLimitNumMsgPort(c, 2*b.numReqPerCycle,
+		name+".TranslationPort")
+	c.AddPort("Translation", c.translationPort)
+
+	return c
+}
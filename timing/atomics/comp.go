@@ -0,0 +1,623 @@
+// Package atomics provides a unit that executes atomic memory operations
+// next to the shared memory hierarchy of a GPU.
+package atomics
+
+import (
+	"log"
+	"reflect"
+
+	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/mem/vm"
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/akita/v3/tracing"
+	"github.com/sarchlab/mgpusim/v3/emu"
+	"github.com/sarchlab/mgpusim/v3/insts"
+)
+
+type transactionState int
+
+const (
+	transactionStateTranslating transactionState = iota
+	transactionStateReady
+	transactionStateReading
+	transactionStateWriting
+	transactionStateRemote
+	transactionStateDone
+)
+
+type transaction struct {
+	req   *AtomicReq
+	port  sim.Port
+	state transactionState
+	pAddr uint64
+
+	translationReq *vm.TranslationReq
+	readReq        *mem.ReadReq
+	writeReq       *mem.WriteReq
+	remoteReq      *AtomicReq
+
+	preOpValues []uint64
+
+	// access is a plain read or write that arrives at the top port. An
+	// access is forwarded to the memory with the readReq or the writeReq,
+	// or to the remote GPU with the remoteAccess.
+	access       mem.AccessReq
+	remoteAccess mem.AccessReq
+	data         []byte
+}
+
+// topReq returns the request that the transaction serves.
+func (t *transaction) topReq() mem.AccessReq {
+	if t.access != nil {
+		return t.access
+	}
+
+	return t.req
+}
+
+// A Comp executes atomic requests. The Comp reads the cache line from the
+// memory module that it connects to (either the L2 cache or the DRAM
+// controller), applies the operations, and writes the cache line back. When
+// the Comp connects to the DRAM controllers, the L2 caches must not hold
+// dirty copies of the lines that the atomics update. Atomic
+// requests that access the same cache line are serialized. Requests to the
+// memory of other GPUs are forwarded to the RDMA engine.
+//
+// The top port also accepts plain reads and writes, which are forwarded to
+// the same memory modules as the atomic requests, in order with the atomic
+// requests to the same cache line. The reorder buffers send the accesses to
+// the lines that atomics have updated here, around the L1 caches.
+//
+// Requests that arrive at the top port carry virtual addresses and are
+// translated with the TranslationProvider. Requests that arrive at the
+// remote port are sent by the RDMA engine and carry physical addresses.
+type Comp struct {
+	*sim.TickingComponent
+
+	topPort         sim.Port
+	bottomPort      sim.Port
+	remotePort      sim.Port
+	translationPort sim.Port
+
+	// TranslationProvider is the port that translates the addresses of the
+	// requests from the top port. If it is nil, the requests from the top
+	// port are considered to carry physical addresses.
+	TranslationProvider sim.Port
+
+	// RemoteModule is the port of the RDMA engine that accepts the requests
+	// to other GPUs.
+	RemoteModule sim.Port
+
+	lowModuleFinder mem.LowModuleFinder
+
+	deviceID        uint64
+	log2BlockSize   uint64
+	numReqPerCycle  int
+	maxTransactions int
+	transactions    []*transaction
+}
+
+// SetLowModuleFinder sets the table that decides where the cache lines are
+// read from and written to.
+func (c *Comp) SetLowModuleFinder(lmf mem.LowModuleFinder) {
+	c.lowModuleFinder = lmf
+}
+
+// Tick updates the state of the Comp.
+func (c *Comp) Tick(now sim.VTimeInSec) bool {
+	madeProgress := false
+
+	madeProgress = c.respond(now) || madeProgress
+	madeProgress = c.parseBottom(now) || madeProgress
+	madeProgress = c.parseRemote(now) || madeProgress
+	madeProgress = c.parseTranslation(now) || madeProgress
+	madeProgress = c.issue(now) || madeProgress
+	madeProgress = c.parseTop(now) || madeProgress
+
+	return madeProgress
+}
+
+func (c *Comp) parseTop(now sim.VTimeInSec) bool {
+	madeProgress := false
+
+	for i := 0; i < c.numReqPerCycle; i++ {
+		if len(c.transactions) >= c.maxTransactions {
+			return madeProgress
+		}
+
+		item := c.topPort.Peek()
+		if item == nil {
+			return madeProgress
+		}
+
+		req := item.(mem.AccessReq)
+		trans := &transaction{
+			port:  c.topPort,
+			state: transactionStateReady,
+			pAddr: req.GetAddress(),
+		}
+
+		switch req := req.(type) {
+		case *AtomicReq:
+			trans.req = req
+		case *mem.ReadReq, *mem.WriteReq:
+			trans.access = req
+		default:
+			log.Panicf("cannot process request of type %s",
+				reflect.TypeOf(req))
+		}
+
+		if c.TranslationProvider != nil {
+			if !c.translate(now, trans) {
+				return madeProgress
+			}
+		}
+
+		c.topPort.Retrieve(now)
+		c.transactions = append(c.transactions, trans)
+		tracing.TraceReqReceive(req, c)
+
+		madeProgress = true
+	}
+
+	return madeProgress
+}
+
+func (c *Comp) translate(now sim.VTimeInSec, trans *transaction) bool {
+	req := vm.TranslationReqBuilder{}.
+		WithSendTime(now).
+		WithSrc(c.translationPort).
+		WithDst(c.TranslationProvider).
+		WithPID(trans.topReq().GetPID()).
+		WithVAddr(trans.topReq().GetAddress()).
+		WithDeviceID(c.deviceID).
+		Build()
+
+	err := c.translationPort.Send(req)
+	if err != nil {
+		return false
+	}
+
+	trans.translationReq = req
+	trans.state = transactionStateTranslating
+
+	return true
+}
+
+func (c *Comp) parseTranslation(now sim.VTimeInSec) bool {
+	item := c.translationPort.Peek()
+	if item == nil {
+		return false
+	}
+
+	rsp := item.(*vm.TranslationRsp)
+	for _, trans := range c.transactions {
+		if trans.translationReq == nil ||
+			trans.translationReq.ID != rsp.RespondTo {
+			continue
+		}
+
+		page := rsp.Page
+		trans.pAddr = page.PAddr + trans.topReq().GetAddress() - page.VAddr
+		trans.state = transactionStateReady
+	}
+
+	c.translationPort.Retrieve(now)
+
+	return true
+}
+
+func (c *Comp) parseRemote(now sim.VTimeInSec) bool {
+	item := c.remotePort.Peek()
+	if item == nil {
+		return false
+	}
+
+	switch msg := item.(type) {
+	case *AtomicReq:
+		if len(c.transactions) >= c.maxTransactions {
+			return false
+		}
+
+		c.transactions = append(c.transactions, &transaction{
+			req:   msg,
+			port:  c.remotePort,
+			state: transactionStateReady,
+			pAddr: msg.Address,
+		})
+		tracing.TraceReqReceive(msg, c)
+	case *AtomicRsp:
+		trans := c.findTransaction(func(t *transaction) bool {
+			return t.remoteReq != nil && t.remoteReq.ID == msg.RespondTo
+		})
+		trans.preOpValues = msg.PreOpValues
+		trans.state = transactionStateDone
+		tracing.TraceReqFinalize(trans.remoteReq, c)
+	case mem.AccessRsp:
+		trans := c.findTransaction(func(t *transaction) bool {
+			return t.remoteAccess != nil &&
+				t.remoteAccess.Meta().ID == msg.GetRspTo()
+		})
+		if rsp, ok := msg.(*mem.DataReadyRsp); ok {
+			trans.data = rsp.Data
+		}
+		trans.state = transactionStateDone
+		tracing.TraceReqFinalize(trans.remoteAccess, c)
+	default:
+		log.Panicf("cannot process message of type %s", reflect.TypeOf(msg))
+	}
+
+	c.remotePort.Retrieve(now)
+
+	return true
+}
+
+func (c *Comp) issue(now sim.VTimeInSec) bool {
+	madeProgress := false
+
+	for i, trans := range c.transactions {
+		if trans.state != transactionStateReady {
+			continue
+		}
+
+		dst := c.lowModuleFinder.Find(trans.pAddr)
+		if dst == c.RemoteModule {
+			if !c.forwardToRemote(now, trans, dst) {
+				return madeProgress
+			}
+
+			madeProgress = true
+			continue
+		}
+
+		if c.isLineBusy(i) {
+			continue
+		}
+
+		if trans.access != nil {
+			if !c.forwardAccess(now, trans, dst) {
+				return madeProgress
+			}
+
+			madeProgress = true
+			continue
+		}
+
+		if !c.readLine(now, trans, dst) {
+			return madeProgress
+		}
+
+		madeProgress = true
+	}
+
+	return madeProgress
+}
+
+func (c *Comp) forwardToRemote(
+	now sim.VTimeInSec,
+	trans *transaction,
+	dst sim.Port,
+) bool {
+	if trans.access != nil {
+		return c.forwardAccessToRemote(now, trans, dst)
+	}
+
+	builder := AtomicReqBuilder{}.
+		WithSendTime(now).
+		WithSrc(c.remotePort).
+		WithDst(dst).
+		WithPID(trans.req.PID).
+		WithAddress(trans.pAddr).
+		WithOp(trans.req.Op).
+		WithLanes(trans.req.Lanes)
+	if trans.req.Is64Bit {
+		builder = builder.With64Bit()
+	}
+	req := builder.Build()
+
+	err := c.remotePort.Send(req)
+	if err != nil {
+		return false
+	}
+
+	trans.remoteReq = req
+	trans.state = transactionStateRemote
+	tracing.TraceReqInitiate(req, c, tracing.MsgIDAtReceiver(trans.req, c))
+
+	return true
+}
+
+func (c *Comp) forwardAccessToRemote(
+	now sim.VTimeInSec,
+	trans *transaction,
+	dst sim.Port,
+) bool {
+	req := c.cloneAccess(now, trans, c.remotePort, dst)
+
+	err := c.remotePort.Send(req)
+	if err != nil {
+		return false
+	}
+
+	trans.remoteAccess = req
+	trans.state = transactionStateRemote
+	tracing.TraceReqInitiate(req, c, tracing.MsgIDAtReceiver(trans.access, c))
+
+	return true
+}
+
+// forwardAccess sends a plain read or write to the memory module that the
+// atomic requests to the same cache line are executed at.
+func (c *Comp) forwardAccess(
+	now sim.VTimeInSec,
+	trans *transaction,
+	dst sim.Port,
+) bool {
+	req := c.cloneAccess(now, trans, c.bottomPort, dst)
+
+	err := c.bottomPort.Send(req)
+	if err != nil {
+		return false
+	}
+
+	switch req := req.(type) {
+	case *mem.ReadReq:
+		trans.readReq = req
+		trans.state = transactionStateReading
+	case *mem.WriteReq:
+		trans.writeReq = req
+		trans.state = transactionStateWriting
+	}
+
+	tracing.TraceReqInitiate(req, c, tracing.MsgIDAtReceiver(trans.access, c))
+
+	return true
+}
+
+// cloneAccess creates the request that carries a plain read or write to the
+// physical address.
+func (c *Comp) cloneAccess(
+	now sim.VTimeInSec,
+	trans *transaction,
+	src, dst sim.Port,
+) mem.AccessReq {
+	switch access := trans.access.(type) {
+	case *mem.ReadReq:
+		return mem.ReadReqBuilder{}.
+			WithSendTime(now).
+			WithSrc(src).
+			WithDst(dst).
+			WithPID(access.PID).
+			WithAddress(trans.pAddr).
+			WithByteSize(access.AccessByteSize).
+			Build()
+	case *mem.WriteReq:
+		return mem.WriteReqBuilder{}.
+			WithSendTime(now).
+			WithSrc(src).
+			WithDst(dst).
+			WithPID(access.PID).
+			WithAddress(trans.pAddr).
+			WithData(access.Data).
+			WithDirtyMask(access.DirtyMask).
+			Build()
+	}
+
+	panic("never")
+}
+
+// isLineBusy checks if an earlier transaction is accessing the same cache
+// line as the i-th transaction.
+func (c *Comp) isLineBusy(i int) bool {
+	line := c.lineAddr(c.transactions[i].pAddr)
+
+	for _, trans := range c.transactions[:i] {
+		switch trans.state {
+		case transactionStateReady,
+			transactionStateReading,
+			transactionStateWriting:
+			if c.lineAddr(trans.pAddr) == line {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (c *Comp) readLine(
+	now sim.VTimeInSec,
+	trans *transaction,
+	dst sim.Port,
+) bool {
+	req := mem.ReadReqBuilder{}.
+		WithSendTime(now).
+		WithSrc(c.bottomPort).
+		WithDst(dst).
+		WithPID(trans.req.PID).
+		WithAddress(c.lineAddr(trans.pAddr)).
+		WithByteSize(1 << c.log2BlockSize).
+		Build()
+
+	err := c.bottomPort.Send(req)
+	if err != nil {
+		return false
+	}
+
+	trans.readReq = req
+	trans.state = transactionStateReading
+	tracing.TraceReqInitiate(req, c, tracing.MsgIDAtReceiver(trans.req, c))
+
+	return true
+}
+
+func (c *Comp) parseBottom(now sim.VTimeInSec) bool {
+	item := c.bottomPort.Peek()
+	if item == nil {
+		return false
+	}
+
+	switch rsp := item.(type) {
+	case *mem.DataReadyRsp:
+		return c.handleDataReady(now, rsp)
+	case *mem.WriteDoneRsp:
+		trans := c.findTransaction(func(t *transaction) bool {
+			return t.writeReq != nil && t.writeReq.ID == rsp.RespondTo
+		})
+		trans.state = transactionStateDone
+		tracing.TraceReqFinalize(trans.writeReq, c)
+	default:
+		log.Panicf("cannot process message of type %s", reflect.TypeOf(rsp))
+	}
+
+	c.bottomPort.Retrieve(now)
+
+	return true
+}
+
+func (c *Comp) handleDataReady(
+	now sim.VTimeInSec,
+	rsp *mem.DataReadyRsp,
+) bool {
+	trans := c.findTransaction(func(t *transaction) bool {
+		return t.readReq != nil && t.readReq.ID == rsp.RespondTo
+	})
+
+	if trans.access != nil {
+		trans.data = rsp.Data
+		trans.state = transactionStateDone
+		c.bottomPort.Retrieve(now)
+		tracing.TraceReqFinalize(trans.readReq, c)
+
+		return true
+	}
+
+	data := make([]byte, len(rsp.Data))
+	copy(data, rsp.Data)
+	preOpValues, dirtyMask := c.apply(trans, data)
+
+	write := mem.WriteReqBuilder{}.
+		WithSendTime(now).
+		WithSrc(c.bottomPort).
+		WithDst(trans.readReq.Dst).
+		WithPID(trans.req.PID).
+		WithAddress(trans.readReq.Address).
+		WithData(data).
+		WithDirtyMask(dirtyMask).
+		Build()
+
+	err := c.bottomPort.Send(write)
+	if err != nil {
+		return false
+	}
+
+	c.bottomPort.Retrieve(now)
+
+	tracing.TraceReqFinalize(trans.readReq, c)
+	tracing.TraceReqInitiate(write, c, tracing.MsgIDAtReceiver(trans.req, c))
+
+	trans.preOpValues = preOpValues
+	trans.writeReq = write
+	trans.state = transactionStateWriting
+
+	return true
+}
+
+// apply updates the cache line with the operations of the lanes, in order.
+// It returns the values before each operation and the dirty mask of the
+// cache line.
+func (c *Comp) apply(trans *transaction, data []byte) ([]uint64, []bool) {
+	req := trans.req
+	base := trans.pAddr - c.lineAddr(trans.pAddr)
+	byteSize := req.GetByteSize()
+	preOpValues := make([]uint64, len(req.Lanes))
+	dirtyMask := make([]bool, len(data))
+
+	for i, lane := range req.Lanes {
+		offset := base + lane.Offset
+		buf := data[offset : offset+byteSize]
+
+		if req.Is64Bit {
+			old := insts.BytesToUint64(buf)
+			value := emu.ApplyAtomic64(req.Op, old, lane.Src, lane.Cmp)
+			copy(buf, insts.Uint64ToBytes(value))
+			preOpValues[i] = old
+		} else {
+			old := insts.BytesToUint32(buf)
+			value := emu.ApplyAtomic32(req.Op, old,
+				uint32(lane.Src), uint32(lane.Cmp))
+			copy(buf, insts.Uint32ToBytes(value))
+			preOpValues[i] = uint64(old)
+		}
+
+		for j := offset; j < offset+byteSize; j++ {
+			dirtyMask[j] = true
+		}
+	}
+
+	return preOpValues, dirtyMask
+}
+
+func (c *Comp) respond(now sim.VTimeInSec) bool {
+	for i, trans := range c.transactions {
+		if trans.state != transactionStateDone {
+			continue
+		}
+
+		rsp := c.rspOf(now, trans)
+
+		err := trans.port.Send(rsp)
+		if err != nil {
+			return false
+		}
+
+		c.transactions = append(c.transactions[:i], c.transactions[i+1:]...)
+		tracing.TraceReqComplete(trans.topReq(), c)
+
+		return true
+	}
+
+	return false
+}
+
+func (c *Comp) rspOf(now sim.VTimeInSec, trans *transaction) sim.Msg {
+	switch access := trans.access.(type) {
+	case *mem.ReadReq:
+		return mem.DataReadyRspBuilder{}.
+			WithSendTime(now).
+			WithSrc(trans.port).
+			WithDst(access.Src).
+			WithRspTo(access.ID).
+			WithData(trans.data).
+			Build()
+	case *mem.WriteReq:
+		return mem.WriteDoneRspBuilder{}.
+			WithSendTime(now).
+			WithSrc(trans.port).
+			WithDst(access.Src).
+			WithRspTo(access.ID).
+			Build()
+	}
+
+	return AtomicRspBuilder{}.
+		WithSendTime(now).
+		WithSrc(trans.port).
+		WithDst(trans.req.Src).
+		WithRspTo(trans.req.ID).
+		WithPreOpValues(trans.preOpValues).
+		Build()
+}
+
+func (c *Comp) findTransaction(match func(*transaction) bool) *transaction {
+	for _, trans := range c.transactions {
+		if match(trans) {
+			return trans
+		}
+	}
+
+	panic("transaction not found")
+}
+
+func (c *Comp) lineAddr(addr uint64) uint64 {
+	return addr >> c.log2BlockSize << c.log2BlockSize
+}
@@ -0,0 +1,435 @@
+package atomics
+
+import (
+	"log"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/akita/v3/mem/idealmemcontroller"
+	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/mem/vm"
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/emu"
+	"github.com/sarchlab/mgpusim/v3/insts"
+)
+
+//go:generate mockgen -destination "mock_sim_test.go" -package $GOPACKAGE -write_package_comment=false github.com/sarchlab/akita/v3/sim Port,Engine
+
+func TestAtomics(t *testing.T) {
+	log.SetOutput(GinkgoWriter)
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Atomics")
+}
+
+var _ = Describe("Comp", func() {
+	var (
+		mockCtrl            *gomock.Controller
+		comp                *Comp
+		topPort             *MockPort
+		bottomPort          *MockPort
+		remotePort          *MockPort
+		translationPort     *MockPort
+		lowModule           *MockPort
+		remoteModule        *MockPort
+		translationProvider *MockPort
+		lowModuleFinder     *mem.InterleavedLowModuleFinder
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		topPort = NewMockPort(mockCtrl)
+		bottomPort = NewMockPort(mockCtrl)
+		remotePort = NewMockPort(mockCtrl)
+		translationPort = NewMockPort(mockCtrl)
+		lowModule = NewMockPort(mockCtrl)
+		remoteModule = NewMockPort(mockCtrl)
+		translationProvider = NewMockPort(mockCtrl)
+
+		lowModuleFinder = mem.NewInterleavedLowModuleFinder(4096)
+		lowModuleFinder.LowModules = []sim.Port{lowModule}
+		lowModuleFinder.UseAddressSpaceLimitation = true
+		lowModuleFinder.LowAddress = 0
+		lowModuleFinder.HighAddress = 0x10000
+		lowModuleFinder.ModuleForOtherAddresses = remoteModule
+
+		comp = MakeBuilder().
+			WithDeviceID(1).
+			WithLowModuleFinder(lowModuleFinder).
+			Build("AtomicUnit")
+		comp.topPort = topPort
+		comp.bottomPort = bottomPort
+		comp.remotePort = remotePort
+		comp.translationPort = translationPort
+		comp.TranslationProvider = translationProvider
+		comp.RemoteModule = remoteModule
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	It("should translate requests from the top port", func() {
+		req := AtomicReqBuilder{}.
+			WithAddress(0x1040).
+			WithPID(2).
+			Build()
+
+		topPort.EXPECT().Peek().Return(req)
+		topPort.EXPECT().Peek().Return(nil)
+		topPort.EXPECT().Retrieve(sim.VTimeInSec(10))
+		translationPort.EXPECT().
+			Send(gomock.Any()).
+			Do(func(t *vm.TranslationReq) {
+				Expect(t.Dst).To(BeIdenticalTo(translationProvider))
+				Expect(t.VAddr).To(Equal(uint64(0x1040)))
+				Expect(t.PID).To(Equal(vm.PID(2)))
+				Expect(t.DeviceID).To(Equal(uint64(1)))
+			}).
+			Return(nil)
+
+		madeProgress := comp.parseTop(10)
+
+		Expect(madeProgress).To(BeTrue())
+		Expect(comp.transactions).To(HaveLen(1))
+		Expect(comp.transactions[0].state).
+			To(Equal(transactionStateTranslating))
+	})
+
+	It("should set the physical address when translated", func() {
+		trans := &transaction{
+			req:            AtomicReqBuilder{}.WithAddress(0x1040).Build(),
+			state:          transactionStateTranslating,
+			translationReq: vm.TranslationReqBuilder{}.Build(),
+		}
+		comp.transactions = append(comp.transactions, trans)
+		rsp := vm.TranslationRspBuilder{}.
+			WithRspTo(trans.translationReq.ID).
+			WithPage(vm.Page{VAddr: 0x1000, PAddr: 0x8000}).
+			Build()
+
+		translationPort.EXPECT().Peek().Return(rsp)
+		translationPort.EXPECT().Retrieve(sim.VTimeInSec(10))
+
+		madeProgress := comp.parseTranslation(10)
+
+		Expect(madeProgress).To(BeTrue())
+		Expect(trans.pAddr).To(Equal(uint64(0x8040)))
+		Expect(trans.state).To(Equal(transactionStateReady))
+	})
+
+	It("should serialize the requests to the same cache line", func() {
+		for i := 0; i < 2; i++ {
+			comp.transactions = append(comp.transactions, &transaction{
+				req:   AtomicReqBuilder{}.Build(),
+				state: transactionStateReady,
+				pAddr: 0x1040,
+			})
+		}
+
+		bottomPort.EXPECT().
+			Send(gomock.Any()).
+			Do(func(read *mem.ReadReq) {
+				Expect(read.Dst).To(BeIdenticalTo(lowModule))
+				Expect(read.Address).To(Equal(uint64(0x1040)))
+				Expect(read.AccessByteSize).To(Equal(uint64(64)))
+			}).
+			Return(nil)
+
+		madeProgress := comp.issue(10)
+
+		Expect(madeProgress).To(BeTrue())
+		Expect(comp.transactions[0].state).To(Equal(transactionStateReading))
+		Expect(comp.transactions[1].state).To(Equal(transactionStateReady))
+	})
+
+	It("should forward requests to remote memory", func() {
+		req := AtomicReqBuilder{}.
+			WithOp(emu.AtomicAdd).
+			WithLanes([]LaneOp{{Offset: 4, Src: 1}}).
+			With64Bit().
+			Build()
+		comp.transactions = append(comp.transactions, &transaction{
+			req:   req,
+			state: transactionStateReady,
+			pAddr: 0x20040,
+		})
+
+		remotePort.EXPECT().
+			Send(gomock.Any()).
+			Do(func(fwd *AtomicReq) {
+				Expect(fwd.Dst).To(BeIdenticalTo(remoteModule))
+				Expect(fwd.Address).To(Equal(uint64(0x20040)))
+				Expect(fwd.Lanes).To(Equal(req.Lanes))
+				Expect(fwd.Is64Bit).To(BeTrue())
+			}).
+			Return(nil)
+
+		madeProgress := comp.issue(10)
+
+		Expect(madeProgress).To(BeTrue())
+		Expect(comp.transactions[0].state).To(Equal(transactionStateRemote))
+	})
+
+	It("should apply the operations and write the line back", func() {
+		read := mem.ReadReqBuilder{}.
+			WithDst(lowModule).
+			WithAddress(0x1040).
+			WithByteSize(64).
+			Build()
+		trans := &transaction{
+			req: AtomicReqBuilder{}.
+				WithOp(emu.AtomicAdd).
+				WithLanes([]LaneOp{
+					{Offset: 4, Src: 1},
+					{Offset: 4, Src: 2},
+				}).
+				Build(),
+			state:   transactionStateReading,
+			pAddr:   0x1040,
+			readReq: read,
+		}
+		comp.transactions = append(comp.transactions, trans)
+
+		data := make([]byte, 64)
+		copy(data[4:], insts.Uint32ToBytes(10))
+		rsp := mem.DataReadyRspBuilder{}.
+			WithRspTo(read.ID).
+			WithData(data).
+			Build()
+
+		bottomPort.EXPECT().Peek().Return(rsp)
+		bottomPort.EXPECT().Retrieve(sim.VTimeInSec(10))
+		bottomPort.EXPECT().
+			Send(gomock.Any()).
+			Do(func(write *mem.WriteReq) {
+				Expect(write.Dst).To(BeIdenticalTo(lowModule))
+				Expect(write.Address).To(Equal(uint64(0x1040)))
+				Expect(insts.BytesToUint32(write.Data[4:8])).
+					To(Equal(uint32(13)))
+				Expect(write.DirtyMask[3]).To(BeFalse())
+				Expect(write.DirtyMask[4]).To(BeTrue())
+			}).
+			Return(nil)
+
+		madeProgress := comp.parseBottom(10)
+
+		Expect(madeProgress).To(BeTrue())
+		Expect(trans.state).To(Equal(transactionStateWriting))
+		Expect(trans.preOpValues).To(Equal([]uint64{10, 11}))
+		Expect(data[4]).To(Equal(byte(10)))
+	})
+
+	It("should respond when done", func() {
+		src := NewMockPort(mockCtrl)
+		req := AtomicReqBuilder{}.WithSrc(src).Build()
+		comp.transactions = append(comp.transactions, &transaction{
+			req:         req,
+			port:        topPort,
+			state:       transactionStateDone,
+			preOpValues: []uint64{1, 2},
+		})
+
+		topPort.EXPECT().
+			Send(gomock.Any()).
+			Do(func(rsp *AtomicRsp) {
+				Expect(rsp.Dst).To(BeIdenticalTo(src))
+				Expect(rsp.RespondTo).To(Equal(req.ID))
+				Expect(rsp.PreOpValues).To(Equal([]uint64{1, 2}))
+			}).
+			Return(nil)
+
+		madeProgress := comp.respond(10)
+
+		Expect(madeProgress).To(BeTrue())
+		Expect(comp.transactions).To(BeEmpty())
+	})
+
+	It("should forward reads after the atomics to the same line", func() {
+		comp.transactions = append(comp.transactions,
+			&transaction{
+				req:   AtomicReqBuilder{}.Build(),
+				state: transactionStateWriting,
+				pAddr: 0x1040,
+			},
+			&transaction{
+				access: mem.ReadReqBuilder{}.WithByteSize(4).Build(),
+				state:  transactionStateReady,
+				pAddr:  0x1048,
+			})
+
+		madeProgress := comp.issue(10)
+
+		Expect(madeProgress).To(BeFalse())
+
+		comp.transactions[0].state = transactionStateDone
+		bottomPort.EXPECT().
+			Send(gomock.Any()).
+			Do(func(read *mem.ReadReq) {
+				Expect(read.Dst).To(BeIdenticalTo(lowModule))
+				Expect(read.Address).To(Equal(uint64(0x1048)))
+				Expect(read.AccessByteSize).To(Equal(uint64(4)))
+			}).
+			Return(nil)
+
+		madeProgress = comp.issue(10)
+
+		Expect(madeProgress).To(BeTrue())
+		Expect(comp.transactions[1].state).To(Equal(transactionStateReading))
+	})
+
+	It("should forward writes to remote memory", func() {
+		write := mem.WriteReqBuilder{}.
+			WithData([]byte{1, 2, 3, 4}).
+			Build()
+		comp.transactions = append(comp.transactions, &transaction{
+			access: write,
+			state:  transactionStateReady,
+			pAddr:  0x20040,
+		})
+
+		remotePort.EXPECT().
+			Send(gomock.Any()).
+			Do(func(fwd *mem.WriteReq) {
+				Expect(fwd.Dst).To(BeIdenticalTo(remoteModule))
+				Expect(fwd.Address).To(Equal(uint64(0x20040)))
+				Expect(fwd.Data).To(Equal(write.Data))
+			}).
+			Return(nil)
+
+		madeProgress := comp.issue(10)
+
+		Expect(madeProgress).To(BeTrue())
+		Expect(comp.transactions[0].state).To(Equal(transactionStateRemote))
+	})
+
+	It("should respond to reads with the data", func() {
+		src := NewMockPort(mockCtrl)
+		read := mem.ReadReqBuilder{}.WithSrc(src).WithByteSize(4).Build()
+		forwarded := mem.ReadReqBuilder{}.WithByteSize(4).Build()
+		trans := &transaction{
+			access:  read,
+			port:    topPort,
+			state:   transactionStateReading,
+			pAddr:   0x1048,
+			readReq: forwarded,
+		}
+		comp.transactions = append(comp.transactions, trans)
+
+		dataReady := mem.DataReadyRspBuilder{}.
+			WithRspTo(forwarded.ID).
+			WithData([]byte{1, 2, 3, 4}).
+			Build()
+		bottomPort.EXPECT().Peek().Return(dataReady)
+		bottomPort.EXPECT().Retrieve(sim.VTimeInSec(10))
+
+		madeProgress := comp.parseBottom(10)
+
+		Expect(madeProgress).To(BeTrue())
+		Expect(trans.state).To(Equal(transactionStateDone))
+
+		topPort.EXPECT().
+			Send(gomock.Any()).
+			Do(func(rsp *mem.DataReadyRsp) {
+				Expect(rsp.Dst).To(BeIdenticalTo(src))
+				Expect(rsp.RespondTo).To(Equal(read.ID))
+				Expect(rsp.Data).To(Equal([]byte{1, 2, 3, 4}))
+			}).
+			Return(nil)
+
+		madeProgress = comp.respond(10)
+
+		Expect(madeProgress).To(BeTrue())
+		Expect(comp.transactions).To(BeEmpty())
+	})
+})
+
+type agent struct {
+	*sim.TickingComponent
+
+	port     sim.Port
+	toSend   []*AtomicReq
+	received []*AtomicRsp
+}
+
+func (a *agent) Tick(now sim.VTimeInSec) bool {
+	madeProgress := false
+
+	if len(a.toSend) > 0 {
+		req := a.toSend[0]
+		req.SendTime = now
+		if a.port.Send(req) == nil {
+			a.toSend = a.toSend[1:]
+			madeProgress = true
+		}
+	}
+
+	if msg := a.port.Retrieve(now); msg != nil {
+		a.received = append(a.received, msg.(*AtomicRsp))
+		madeProgress = true
+	}
+
+	return madeProgress
+}
+
+var _ = Describe("Comp with memory", func() {
+	It("should execute atomics from multiple sources atomically", func() {
+		engine := sim.NewSerialEngine()
+		memCtrl := idealmemcontroller.MakeBuilder().
+			WithEngine(engine).
+			WithNewStorage(1 * mem.MB).
+			WithLatency(20).
+			Build("DRAM")
+		comp := MakeBuilder().
+			WithEngine(engine).
+			WithLowModuleFinder(&mem.SingleLowModuleFinder{
+				LowModule: memCtrl.GetPortByName("Top"),
+			}).
+			Build("AtomicUnit")
+
+		agents := make([]*agent, 4)
+		conn := sim.NewDirectConnection("Conn", engine, 1*sim.GHz)
+		conn.PlugIn(comp.GetPortByName("Top"), 16)
+		conn.PlugIn(comp.GetPortByName("Bottom"), 16)
+		conn.PlugIn(memCtrl.GetPortByName("Top"), 16)
+
+		for i := range agents {
+			a := &agent{}
+			a.TickingComponent = sim.NewTickingComponent(
+				"Agent", engine, 1*sim.GHz, a)
+			a.port = sim.NewLimitNumMsgPort(a, 4, "Agent.Port")
+			conn.PlugIn(a.port, 4)
+
+			for j := 0; j < 8; j++ {
+				a.toSend = append(a.toSend, AtomicReqBuilder{}.
+					WithSrc(a.port).
+					WithDst(comp.GetPortByName("Top")).
+					WithAddress(0x100).
+					WithOp(emu.AtomicAdd).
+					WithLanes([]LaneOp{{Offset: 8, Src: 1}, {Offset: 8, Src: 1}}).
+					Build())
+			}
+
+			agents[i] = a
+			a.TickLater(0)
+		}
+
+		Expect(engine.Run()).To(Succeed())
+
+		preOpValues := map[uint64]bool{}
+		for _, a := range agents {
+			Expect(a.received).To(HaveLen(8))
+			for _, rsp := range a.received {
+				for _, v := range rsp.PreOpValues {
+					preOpValues[v] = true
+				}
+			}
+		}
+		Expect(preOpValues).To(HaveLen(64))
+
+		data, err := memCtrl.Storage.Read(0x108, 4)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(insts.BytesToUint32(data)).To(Equal(uint32(64)))
+	})
+})
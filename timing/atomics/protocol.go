@@ -0,0 +1,216 @@
+package atomics
+
+import (
+	"github.com/sarchlab/akita/v3/mem/vm"
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/emu"
+)
+
+var atomicReqByteOverhead = 12
+var atomicRspByteOverhead = 4
+
+// LaneOp is the atomic operation that one lane applies to one element in
+// the cache line.
+type LaneOp struct {
+	// Offset is the byte offset of the element in the cache line.
+	Offset uint64
+	Src    uint64
+	Cmp    uint64
+}
+
+// An AtomicReq asks the receiver to apply an atomic operation on some elements
+// of a cache line. The operations of the lanes are applied in order.
+type AtomicReq struct {
+	sim.MsgMeta
+
+	Address            uint64
+	PID                vm.PID
+	Op                 emu.AtomicOp
+	Is64Bit            bool
+	Lanes              []LaneOp
+	CanWaitForCoalesce bool
+}
+
+// Meta returns the message meta.
+func (r *AtomicReq) Meta() *sim.MsgMeta {
+	return &r.MsgMeta
+}
+
+// GetAddress returns the address of the cache line that the request accesses.
+func (r *AtomicReq) GetAddress() uint64 {
+	return r.Address
+}
+
+// GetByteSize returns the size of one element that the request updates.
+func (r *AtomicReq) GetByteSize() uint64 {
+	if r.Is64Bit {
+		return 8
+	}
+
+	return 4
+}
+
+// GetPID returns the process ID that the request is working on.
+func (r *AtomicReq) GetPID() vm.PID {
+	return r.PID
+}
+
+// AtomicReqBuilder can build atomic requests.
+type AtomicReqBuilder struct {
+	sendTime           sim.VTimeInSec
+	src, dst           sim.Port
+	pid                vm.PID
+	address            uint64
+	op                 emu.AtomicOp
+	is64Bit            bool
+	lanes              []LaneOp
+	canWaitForCoalesce bool
+}
+
+// WithSendTime sets the send time of the request to build.
+func (b AtomicReqBuilder) WithSendTime(t sim.VTimeInSec) AtomicReqBuilder {
+	b.sendTime = t
+	return b
+}
+
+// WithSrc sets the source of the request to build.
+func (b AtomicReqBuilder) WithSrc(src sim.Port) AtomicReqBuilder {
+	b.src = src
+	return b
+}
+
+// WithDst sets the destination of the request to build.
+func (b AtomicReqBuilder) WithDst(dst sim.Port) AtomicReqBuilder {
+	b.dst = dst
+	return b
+}
+
+// WithPID sets the PID of the request to build.
+func (b AtomicReqBuilder) WithPID(pid vm.PID) AtomicReqBuilder {
+	b.pid = pid
+	return b
+}
+
+// WithAddress sets the address of the cache line that the request accesses.
+func (b AtomicReqBuilder) WithAddress(address uint64) AtomicReqBuilder {
+	b.address = address
+	return b
+}
+
+// WithOp sets the atomic operation to apply.
+func (b AtomicReqBuilder) WithOp(op emu.AtomicOp) AtomicReqBuilder {
+	b.op = op
+	return b
+}
+
+// With64Bit makes the request operate on 64-bit elements.
+func (b AtomicReqBuilder) With64Bit() AtomicReqBuilder {
+	b.is64Bit = true
+	return b
+}
+
+// WithLanes sets the operations of the lanes.
+func (b AtomicReqBuilder) WithLanes(lanes []LaneOp) AtomicReqBuilder {
+	b.lanes = lanes
+	return b
+}
+
+// CanWaitForCoalesce allows the request to be coalesced with following
+// requests.
+func (b AtomicReqBuilder) CanWaitForCoalesce() AtomicReqBuilder {
+	b.canWaitForCoalesce = true
+	return b
+}
+
+// Build creates a new AtomicReq.
+func (b AtomicReqBuilder) Build() *AtomicReq {
+	r := &AtomicReq{}
+	r.ID = sim.GetIDGenerator().Generate()
+	r.Src = b.src
+	r.Dst = b.dst
+	r.SendTime = b.sendTime
+	r.Address = b.address
+	r.PID = b.pid
+	r.Op = b.op
+	r.Is64Bit = b.is64Bit
+	r.Lanes = b.lanes
+	r.CanWaitForCoalesce = b.canWaitForCoalesce
+	r.TrafficBytes = atomicReqByteOverhead + len(b.lanes)*int(r.GetByteSize())
+
+	if r.Op == emu.AtomicCmpSwap || r.Op == emu.AtomicFCmpSwap ||
+		r.Op == emu.AtomicMskOr {
+		r.TrafficBytes += len(b.lanes) * int(r.GetByteSize())
+	}
+
+	return r
+}
+
+// An AtomicRsp is the respond of an AtomicReq. It carries the values in the
+// memory before each lane applies its operation.
+type AtomicRsp struct {
+	sim.MsgMeta
+
+	RespondTo   string
+	PreOpValues []uint64
+}
+
+// Meta returns the message meta.
+func (r *AtomicRsp) Meta() *sim.MsgMeta {
+	return &r.MsgMeta
+}
+
+// GetRspTo returns the ID of the request that the respond is responding to.
+func (r *AtomicRsp) GetRspTo() string {
+	return r.RespondTo
+}
+
+// AtomicRspBuilder can build atomic responds.
+type AtomicRspBuilder struct {
+	sendTime    sim.VTimeInSec
+	src, dst    sim.Port
+	rspTo       string
+	preOpValues []uint64
+}
+
+// WithSendTime sets the send time of the respond to build.
+func (b AtomicRspBuilder) WithSendTime(t sim.VTimeInSec) AtomicRspBuilder {
+	b.sendTime = t
+	return b
+}
+
+// WithSrc sets the source of the respond to build.
+func (b AtomicRspBuilder) WithSrc(src sim.Port) AtomicRspBuilder {
+	b.src = src
+	return b
+}
+
+// WithDst sets the destination of the respond to build.
+func (b AtomicRspBuilder) WithDst(dst sim.Port) AtomicRspBuilder {
+	b.dst = dst
+	return b
+}
+
+// WithRspTo sets the ID of the request that the respond is responding to.
+func (b AtomicRspBuilder) WithRspTo(id string) AtomicRspBuilder {
+	b.rspTo = id
+	return b
+}
+
+// WithPreOpValues sets the values before the operations of each lane.
+func (b AtomicRspBuilder) WithPreOpValues(values []uint64) AtomicRspBuilder {
+	b.preOpValues = values
+	return b
+}
+
+// Build creates a new AtomicRsp.
+func (b AtomicRspBuilder) Build() *AtomicRsp {
+	r := &AtomicRsp{}
+	r.ID = sim.GetIDGenerator().Generate()
+	r.Src = b.src
+	r.Dst = b.dst
+	r.SendTime = b.sendTime
+	r.RespondTo = b.rspTo
+	r.PreOpValues = b.preOpValues
+	r.TrafficBytes = atomicRspByteOverhead + len(b.preOpValues)*8
+	return r
+}
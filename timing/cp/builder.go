@@ -21,6 +21,9 @@ type Builder struct {
 	monitor        *monitoring.Monitor
 	perfAnalyzer   *analysis.PerfAnalyzer
 	numDispatchers int
+	preemption     PreemptionMode
+
+	flushL2BeforeKernels bool
 }
 
 // MakeBuilder creates a new builder with default configuration values.
@@ -28,6 +31,7 @@ func MakeBuilder() Builder {
 	b := Builder{
 		freq:           1 * sim.GHz,
 		numDispatchers: 8,
+		preemption:     PreemptionNone,
 	}
 	return b
 }
@@ -66,6 +70,23 @@ func (b Builder) WithPerfAnalyzer(
 	return b
 }
 
+// WithPreemption sets how the Command Processor preempts the running kernels
+// when a kernel with a higher priority arrives.
+func (b Builder) WithPreemption(mode PreemptionMode) Builder {
+	mustBeKnownPreemptionMode(mode)
+	b.preemption = mode
+	return b
+}
+
+// WithL2FlushBeforeKernels makes the Command Processor write the dirty lines
+// of the L2 caches back to the DRAM before it starts each kernel. This is
+// required when the atomic memory operations are executed at the DRAM, so that
+// the atomics operate on the latest data.
+func (b Builder) WithL2FlushBeforeKernels() Builder {
+	b.flushL2BeforeKernels = true
+	return b
+}
+
 // Build builds a new Command Processor
 func (b Builder) Build(name string) *CommandProcessor {
 	cp := new(CommandProcessor)
@@ -80,6 +101,11 @@ func (b Builder) Build(name string) *CommandProcessor {
 	cp.bottomMemCopyD2HReqIDToTopReqMap =
 		make(map[string]*protocol.MemCopyD2HReq)
 
+	cp.preemption = b.preemption
+	cp.flushL2BeforeKernels = b.flushL2BeforeKernels
+	cp.dispatchingKernels =
+		make(map[dispatching.Dispatcher]*protocol.LaunchKernelReq)
+
 	b.buildDispatchers(cp)
 
 	if b.visTracer != nil {
@@ -63,6 +63,13 @@ type CommandProcessor struct {
 
 	shootDownInProcess bool
 
+	flushL2BeforeKernels bool
+	l2FlushingFor        *protocol.LaunchKernelReq
+	l2FlushedFor         *protocol.LaunchKernelReq
+
+	preemption         PreemptionMode
+	dispatchingKernels map[dispatching.Dispatcher]*protocol.LaunchKernelReq
+
 	bottomKernelLaunchReqIDToTopReqMap map[string]*protocol.LaunchKernelReq
 	bottomMemCopyH2DReqIDToTopReqMap   map[string]*protocol.MemCopyH2DReq
 	bottomMemCopyD2HReqIDToTopReqMap   map[string]*protocol.MemCopyD2HReq
@@ -91,6 +98,7 @@ func (p *CommandProcessor) Tick(now sim.VTimeInSec) bool {
 
 	madeProgress = p.sendMsgsOut(now) || madeProgress
 	madeProgress = p.tickDispatchers(now) || madeProgress
+	madeProgress = p.resumePreemptedKernels() || madeProgress
 	madeProgress = p.processReqFromDriver(now) || madeProgress
 	madeProgress = p.processRspFromInternal(now) || madeProgress
 
@@ -298,7 +306,14 @@ func (p *CommandProcessor) processLaunchKernelReq(
 		return false
 	}
 
+	if p.flushL2BeforeKernels && p.l2FlushedFor != req {
+		return p.flushL2BeforeKernel(now, req)
+	}
+
+	p.l2FlushedFor = nil
 	d.StartDispatching(req)
+	p.dispatchingKernels[d] = req
+	p.preemptLowerPriorityKernels(d, req)
 	p.ToDriver.Retrieve(now)
 
 	tracing.TraceReqReceive(req, p)
@@ -308,6 +323,30 @@ func (p *CommandProcessor) processLaunchKernelReq(
 	return true
 }
 
+// flushL2BeforeKernel writes the dirty lines of the L2 caches back to the
+// DRAM. The kernel stays in the port until all the L2 caches respond.
+func (p *CommandProcessor) flushL2BeforeKernel(
+	now sim.VTimeInSec,
+	req *protocol.LaunchKernelReq,
+) bool {
+	if p.numCacheACK > 0 || p.l2FlushingFor != nil {
+		return false
+	}
+
+	if len(p.L2Caches) == 0 {
+		p.l2FlushedFor = req
+		return true
+	}
+
+	for _, port := range p.L2Caches {
+		p.flushCache(now, port)
+	}
+
+	p.l2FlushingFor = req
+
+	return true
+}
+
 func (p *CommandProcessor) findAvailableDispatcher() dispatching.Dispatcher {
 	for _, d := range p.Dispatchers {
 		if !d.IsDispatching() {
@@ -463,6 +502,12 @@ func (p *CommandProcessor) processCacheFlushRsp(
 	p.ToCaches.Retrieve(now)
 
 	if p.numCacheACK == 0 {
+		if p.l2FlushingFor != nil {
+			p.l2FlushedFor = p.l2FlushingFor
+			p.l2FlushingFor = nil
+			return true
+		}
+
 		if p.shootDownInProcess {
 			return p.processCacheFlushCausedByTLBShootdown(now, rsp)
 		}
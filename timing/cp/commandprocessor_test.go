@@ -176,6 +176,167 @@ var _ = Describe("CommandProcessor", func() {
 		Expect(madeProgress).To(BeFalse())
 	})
 
+	Context("when flushing the L2 caches before kernels", func() {
+		var req *protocol.LaunchKernelReq
+
+		BeforeEach(func() {
+			commandProcessor.flushL2BeforeKernels = true
+			req = protocol.NewLaunchKernelReq(10,
+				driver, commandProcessor.ToDriver)
+		})
+
+		It("should flush the L2 caches before dispatching", func() {
+			dispatcher.EXPECT().IsDispatching().Return(false)
+			toCachesSender.EXPECT().
+				Send(gomock.AssignableToTypeOf(&cache.FlushReq{})).
+				Times(10)
+
+			madeProgress := commandProcessor.processLaunchKernelReq(10, req)
+
+			Expect(madeProgress).To(BeTrue())
+			Expect(commandProcessor.numCacheACK).To(Equal(uint64(10)))
+			Expect(commandProcessor.l2FlushingFor).To(BeIdenticalTo(req))
+		})
+
+		It("should wait for the L2 caches to respond", func() {
+			commandProcessor.numCacheACK = 10
+			commandProcessor.l2FlushingFor = req
+
+			dispatcher.EXPECT().IsDispatching().Return(false)
+
+			madeProgress := commandProcessor.processLaunchKernelReq(10, req)
+
+			Expect(madeProgress).To(BeFalse())
+		})
+
+		It("should mark the kernel flushed when all the L2 caches respond",
+			func() {
+				commandProcessor.numCacheACK = 1
+				commandProcessor.l2FlushingFor = req
+				rsp := cache.FlushRspBuilder{}.Build()
+
+				toCaches.EXPECT().Retrieve(sim.VTimeInSec(10))
+
+				madeProgress := commandProcessor.processCacheFlushRsp(10, rsp)
+
+				Expect(madeProgress).To(BeTrue())
+				Expect(commandProcessor.l2FlushingFor).To(BeNil())
+				Expect(commandProcessor.l2FlushedFor).To(BeIdenticalTo(req))
+			})
+
+		It("should dispatch the kernel after the flush", func() {
+			commandProcessor.l2FlushedFor = req
+
+			dispatcher.EXPECT().IsDispatching().Return(false)
+			dispatcher.EXPECT().StartDispatching(req)
+			toDriver.EXPECT().Retrieve(sim.VTimeInSec(10))
+
+			madeProgress := commandProcessor.processLaunchKernelReq(10, req)
+
+			Expect(madeProgress).To(BeTrue())
+			Expect(commandProcessor.l2FlushedFor).To(BeNil())
+		})
+	})
+
+	Context("when preempting kernels", func() {
+		var (
+			lowPriority *MockDispatcher
+			lowReq      *protocol.LaunchKernelReq
+		)
+
+		BeforeEach(func() {
+			lowPriority = NewMockDispatcher(mockCtrl)
+			commandProcessor.Dispatchers = []dispatching.Dispatcher{
+				lowPriority, dispatcher,
+			}
+
+			lowReq = protocol.NewLaunchKernelReq(10,
+				driver, commandProcessor.ToDriver)
+			commandProcessor.dispatchingKernels[lowPriority] = lowReq
+		})
+
+		It("should save the context of the kernels with lower priorities",
+			func() {
+				commandProcessor.preemption = PreemptionContextSwitch
+				req := protocol.NewLaunchKernelReq(10,
+					driver, commandProcessor.ToDriver)
+				req.Priority = 1
+
+				lowPriority.EXPECT().IsDispatching().Return(true).AnyTimes()
+				lowPriority.EXPECT().IsPreempted().Return(false)
+				dispatcher.EXPECT().IsDispatching().Return(false)
+				dispatcher.EXPECT().StartDispatching(req)
+				lowPriority.EXPECT().Preempt(true)
+				toDriver.EXPECT().Retrieve(sim.VTimeInSec(10))
+
+				madeProgress := commandProcessor.processLaunchKernelReq(10, req)
+
+				Expect(madeProgress).To(BeTrue())
+			})
+
+		It("should not preempt kernels on other CUs", func() {
+			commandProcessor.preemption = PreemptionDrain
+			lowReq.CUMask = []uint32{0x1}
+			req := protocol.NewLaunchKernelReq(10,
+				driver, commandProcessor.ToDriver)
+			req.Priority = 1
+			req.CUMask = []uint32{0x2}
+
+			lowPriority.EXPECT().IsDispatching().Return(true).AnyTimes()
+			lowPriority.EXPECT().IsPreempted().Return(false)
+			dispatcher.EXPECT().IsDispatching().Return(false)
+			dispatcher.EXPECT().StartDispatching(req)
+			toDriver.EXPECT().Retrieve(sim.VTimeInSec(10))
+
+			commandProcessor.processLaunchKernelReq(10, req)
+		})
+
+		It("should not preempt if preemption is disabled", func() {
+			req := protocol.NewLaunchKernelReq(10,
+				driver, commandProcessor.ToDriver)
+			req.Priority = 1
+
+			lowPriority.EXPECT().IsDispatching().Return(true)
+			dispatcher.EXPECT().IsDispatching().Return(false)
+			dispatcher.EXPECT().StartDispatching(req)
+			toDriver.EXPECT().Retrieve(sim.VTimeInSec(10))
+
+			commandProcessor.processLaunchKernelReq(10, req)
+		})
+
+		It("should not resume while a kernel with a higher priority runs",
+			func() {
+				commandProcessor.preemption = PreemptionDrain
+				highReq := protocol.NewLaunchKernelReq(10,
+					driver, commandProcessor.ToDriver)
+				highReq.Priority = 1
+				commandProcessor.dispatchingKernels[dispatcher] = highReq
+
+				lowPriority.EXPECT().IsDispatching().Return(true).AnyTimes()
+				lowPriority.EXPECT().IsPreempted().Return(true).AnyTimes()
+				dispatcher.EXPECT().IsDispatching().Return(true).AnyTimes()
+				dispatcher.EXPECT().IsPreempted().Return(false).AnyTimes()
+
+				madeProgress := commandProcessor.resumePreemptedKernels()
+
+				Expect(madeProgress).To(BeFalse())
+			})
+
+		It("should resume after the kernel with a higher priority completes",
+			func() {
+				commandProcessor.preemption = PreemptionDrain
+
+				lowPriority.EXPECT().IsDispatching().Return(true).AnyTimes()
+				lowPriority.EXPECT().IsPreempted().Return(true).AnyTimes()
+				dispatcher.EXPECT().IsDispatching().Return(false).AnyTimes()
+				lowPriority.EXPECT().Resume()
+
+				madeProgress := commandProcessor.resumePreemptedKernels()
+
+				Expect(madeProgress).To(BeTrue())
+			})
+	})
+
 	It("should handle a RDMA drain req from driver", func() {
 		cmd := protocol.NewRDMADrainCmdFromDriver(
 			10, nil, commandProcessor.ToDriver)
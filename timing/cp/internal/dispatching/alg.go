@@ -13,6 +13,16 @@ type dispatchLocation struct {
 	cu        sim.Port
 	wg        *kernels.WorkGroup
 	locations []protocol.WfDispatchLocation
+
+	// restore is set when the work-group has been preempted and its context
+	// has to be read from contextAddr.
+	restore     bool
+	contextAddr uint64
+
+	// A work-group takes a slot in the context save area when it is
+	// preempted for the first time and keeps the slot until it completes.
+	hasContextSlot bool
+	contextSlot    int
 }
 
 // algorithm defines the CTA scheduling scheme.
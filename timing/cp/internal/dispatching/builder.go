@@ -17,12 +17,14 @@ type Builder struct {
 	respondingPort  sim.Port
 	dispatchingPort sim.Port
 	monitor         *monitoring.Monitor
+	arbiter         *priorityArbiter
 }
 
 // MakeBuilder creates a builder with default dispatching configureations.
 func MakeBuilder() Builder {
 	b := Builder{
-		alg: "partition",
+		alg:     "partition",
+		arbiter: &priorityArbiter{},
 	}
 	return b
 }
@@ -80,6 +82,7 @@ func (b Builder) Build(name string) Dispatcher {
 		dispatchingPort: b.dispatchingPort,
 		inflightWGs:     make(map[string]dispatchLocation),
 		originalReqs:    make(map[string]*protocol.MapWGReq),
+		preemptReqs:     make(map[string]*protocol.PreemptWGReq),
 		latencyTable: []int{
 			1,
 			4, 4, 4, 4,
@@ -89,22 +92,25 @@ func (b Builder) Build(name string) Dispatcher {
 		},
 		constantKernelOverhead: 0,
 		monitor:                b.monitor,
+		cus:                    resource.NewMaskedCUResourcePool(b.cuResourcePool),
+		arbiter:                b.arbiter,
 	}
+	b.arbiter.register(d)
 
 	switch b.alg {
 	case "round-robin":
 		d.alg = &roundRobinAlgorithm{
 			gridBuilder: kernels.NewGridBuilder(),
-			cuPool:      b.cuResourcePool,
+			cuPool:      d.cus,
 		}
 	case "greedy":
 		d.alg = &greedyAlgorithm{
 			gridBuilder: kernels.NewGridBuilder(),
-			cuPool:      b.cuResourcePool,
+			cuPool:      d.cus,
 		}
 	case "partition":
 		d.alg = &partitionAlgorithm{
-			cuPool: b.cuResourcePool,
+			cuPool: d.cus,
 		}
 	default:
 		panic("unknown dispatching algorithm " + b.alg)
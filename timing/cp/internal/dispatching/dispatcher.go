@@ -2,7 +2,6 @@ package dispatching
 
 import (
 	"fmt"
-	"log"
 
 	"github.com/sarchlab/akita/v3/monitoring"
 	"github.com/sarchlab/akita/v3/sim"
@@ -20,6 +19,19 @@ type Dispatcher interface {
 	IsDispatching() bool
 	StartDispatching(req *protocol.LaunchKernelReq)
 	Tick(now sim.VTimeInSec) (madeProgress bool)
+
+	// Preempt stops dispatching the work-groups of the current kernel. If
+	// saveContext is set and the kernel has a context save area, the
+	// running work-groups are also stopped and their contexts are saved to
+	// the memory. Otherwise, the running work-groups drain.
+	Preempt(saveContext bool)
+
+	// Resume continues dispatching the preempted kernel, starting with the
+	// work-groups that have been preempted.
+	Resume()
+
+	// IsPreempted checks if the dispatcher is holding back the kernel.
+	IsPreempted() bool
 }
 
 // A DispatcherImpl is a ticking component that can dispatch work-groups.
@@ -36,11 +48,23 @@ type DispatcherImpl struct {
 	cycleLeft              int
 	numDispatchedWGs       int
 	numCompletedWGs        int
+	numClaimedWGs          int
 	inflightWGs            map[string]dispatchLocation
 	originalReqs           map[string]*protocol.MapWGReq
 	latencyTable           []int
 	constantKernelOverhead int
 
+	cus          *resource.MaskedCUResourcePool
+	arbiter      *priorityArbiter
+	priority     int
+	waitingForCU bool
+
+	preempted        bool
+	toPreempt        []string
+	preemptReqs      map[string]*protocol.PreemptWGReq
+	preemptedWGs     []dispatchLocation
+	freeContextSlots []int
+
 	monitor     *monitoring.Monitor
 	progressBar *monitoring.ProgressBar
 }
@@ -64,25 +88,94 @@ func (d *DispatcherImpl) IsDispatching() bool {
 func (d *DispatcherImpl) StartDispatching(req *protocol.LaunchKernelReq) {
 	d.mustNotBeDispatchingAnotherKernel()
 
-	d.alg.StartNewKernel(kernels.KernelLaunchInfo{
-		CodeObject: req.HsaCo,
-		Packet:     req.Packet,
-		PacketAddr: req.PacketAddress,
-		WGFilter:   req.WGFilter,
-	})
+	d.cus.SetCUMask(req.CUMask)
+	d.priority = req.Priority
+	d.waitingForCU = false
+	d.preempted = false
+	d.toPreempt = nil
+	d.preemptedWGs = nil
+	d.freeContextSlots = d.freeContextSlots[:0]
+	for i := 0; i < req.NumContextSaveSlots; i++ {
+		d.freeContextSlots = append(d.freeContextSlots, i)
+	}
+
 	d.dispatching = req
 
 	d.numDispatchedWGs = 0
 	d.numCompletedWGs = 0
+	d.numClaimedWGs = 0
+
+	if req.WGPool == nil {
+		d.alg.StartNewKernel(d.launchInfo(nil))
+	}
 
 	d.initializeProgressBar(req.ID)
+
+	if req.WGPool != nil {
+		d.claimWGs()
+	}
+}
+
+func (d *DispatcherImpl) launchInfo(
+	wgs *kernels.WGRange,
+) kernels.KernelLaunchInfo {
+	return kernels.KernelLaunchInfo{
+		CodeObject: d.dispatching.HsaCo,
+		Packet:     d.dispatching.Packet,
+		PacketAddr: d.dispatching.PacketAddress,
+		WGFilter:   d.dispatching.WGFilter,
+		WGRange:    wgs,
+	}
+}
+
+// claimWGs takes the next chunk of work-groups from the pool of the kernel
+// and lets the algorithm dispatch them. It returns false if the kernel does
+// not have a pool or the pool is empty.
+func (d *DispatcherImpl) claimWGs() bool {
+	if d.dispatching.WGPool == nil {
+		return false
+	}
+
+	wgs, ok := d.dispatching.WGPool.Claim()
+	if !ok {
+		return false
+	}
+
+	d.alg.StartNewKernel(d.launchInfo(&wgs))
+	d.numClaimedWGs += wgs.End - wgs.Start
+
+	if d.progressBar != nil {
+		d.progressBar.Lock()
+		d.progressBar.Total += uint64(wgs.End - wgs.Start)
+		d.progressBar.Unlock()
+	}
+
+	return true
+}
+
+// hasWGsToClaim checks if the pool of the kernel still has work-groups.
+func (d *DispatcherImpl) hasWGsToClaim() bool {
+	pool := d.dispatching.WGPool
+
+	return pool != nil && !pool.IsEmpty()
+}
+
+// numWG returns the number of work-groups that the dispatcher dispatches for
+// the kernel. If the kernel has a pool, only the work-groups claimed so far
+// count.
+func (d *DispatcherImpl) numWG() int {
+	if d.dispatching.WGPool != nil {
+		return d.numClaimedWGs
+	}
+
+	return d.alg.NumWG()
 }
 
 func (d *DispatcherImpl) initializeProgressBar(kernelID string) {
 	if d.monitor != nil {
 		d.progressBar = d.monitor.CreateProgressBar(
 			fmt.Sprintf("At %s, Kernel: %s, ", d.Name(), kernelID),
-			uint64(d.alg.NumWG()),
+			uint64(d.numWG()),
 		)
 	}
 }
@@ -103,6 +196,8 @@ func (d *DispatcherImpl) Tick(now sim.VTimeInSec) (madeProgress bool) {
 	if d.dispatching != nil {
 		if d.kernelCompleted() {
 			madeProgress = d.completeKernel(now) || madeProgress
+		} else if d.preempted {
+			madeProgress = d.preemptNextWG(now) || madeProgress
 		} else {
 			madeProgress = d.dispatchNextWG(now) || madeProgress
 		}
@@ -121,45 +216,66 @@ func (d *DispatcherImpl) processMessagesFromCU(now sim.VTimeInSec) bool {
 
 	switch msg := msg.(type) {
 	case *protocol.WGCompletionMsg:
-		count := 0
+		// In emulation, a message may report the work-groups of several
+		// dispatchers. The dispatcher takes its own work-groups and leaves
+		// the rest in the message for the others.
+		var othersWGs []string
 		for _, rspToID := range msg.RspTo {
-			_, ok := d.inflightWGs[rspToID]
-			if ok {
-				count += 1
+			if _, ok := d.inflightWGs[rspToID]; !ok {
+				othersWGs = append(othersWGs, rspToID)
+				continue
 			}
+
+			d.completeWG(rspToID)
 		}
 
-		if count == 0 {
+		if len(othersWGs) == len(msg.RspTo) {
 			return false
-		} else if count < len(msg.RspTo) {
-			log.Panic("In emulation all finished WGs from more than one dispatcher")
 		}
 
-		for _, rspToID := range msg.RspTo {
-			location := d.inflightWGs[rspToID]
-			d.alg.FreeResources(location)
-			delete(d.inflightWGs, rspToID)
-			d.numCompletedWGs++
-			if d.numCompletedWGs == d.alg.NumWG() {
-				d.cycleLeft = d.constantKernelOverhead
-			}
-
-			originalReq := d.originalReqs[rspToID]
-			delete(d.originalReqs, rspToID)
-			tracing.TraceReqFinalize(originalReq, d)
+		msg.RspTo = othersWGs
+		if len(othersWGs) > 0 {
+			return true
+		}
 
-			if d.progressBar != nil {
-				d.progressBar.MoveInProgressToFinished(1)
-			}
+		d.dispatchingPort.Retrieve(now)
+		return true
+	case *protocol.WGPreemptedMsg:
+		if _, ok := d.inflightWGs[msg.RspTo]; !ok {
+			return false
 		}
 
+		d.completePreemption(msg.RspTo)
 		d.dispatchingPort.Retrieve(now)
+
 		return true
 	}
 
 	return false
 }
 
+func (d *DispatcherImpl) completeWG(rspToID string) {
+	location := d.inflightWGs[rspToID]
+	d.alg.FreeResources(location)
+	if location.hasContextSlot {
+		d.freeContextSlots = append(d.freeContextSlots, location.contextSlot)
+	}
+	delete(d.inflightWGs, rspToID)
+	d.numCompletedWGs++
+	if d.numCompletedWGs == d.numWG() && !d.hasWGsToClaim() {
+		d.cycleLeft = d.constantKernelOverhead
+	}
+
+	originalReq := d.originalReqs[rspToID]
+	delete(d.originalReqs, rspToID)
+	tracing.TraceReqFinalize(originalReq, d)
+	d.finalizePreemptReq(rspToID)
+
+	if d.progressBar != nil {
+		d.progressBar.MoveInProgressToFinished(1)
+	}
+}
+
 func (d *DispatcherImpl) kernelCompleted() bool {
 	if d.currWG.valid {
 		return false
@@ -169,10 +285,18 @@ func (d *DispatcherImpl) kernelCompleted() bool {
 		return false
 	}
 
+	if len(d.preemptedWGs) > 0 {
+		return false
+	}
+
 	if d.numCompletedWGs < d.numDispatchedWGs {
 		return false
 	}
 
+	if d.hasWGsToClaim() {
+		return false
+	}
+
 	return true
 }
 
@@ -186,6 +310,7 @@ func (d *DispatcherImpl) completeKernel(now sim.VTimeInSec) (
 	err := d.respondingPort.Send(rsp)
 	if err == nil {
 		d.dispatching = nil
+		d.waitingForCU = false
 
 		if d.monitor != nil {
 			d.monitor.CompleteProgressBar(d.progressBar)
@@ -203,11 +328,26 @@ func (d *DispatcherImpl) dispatchNextWG(
 	now sim.VTimeInSec,
 ) (madeProgress bool) {
 	if !d.currWG.valid {
-		if !d.alg.HasNext() {
+		if len(d.preemptedWGs) == 0 && !d.alg.HasNext() &&
+			!d.hasWGsToClaim() {
+			return false
+		}
+
+		if d.arbiter.shouldYield(d) {
 			return false
 		}
 
-		d.currWG = d.alg.Next()
+		if len(d.preemptedWGs) > 0 {
+			d.currWG = d.nextPreemptedWG()
+		} else {
+			if !d.alg.HasNext() && !d.claimWGs() {
+				return false
+			}
+
+			d.currWG = d.alg.Next()
+		}
+
+		d.waitingForCU = !d.currWG.valid
 		if !d.currWG.valid {
 			return false
 		}
@@ -222,6 +362,9 @@ func (d *DispatcherImpl) dispatchNextWG(
 	for _, l := range d.currWG.locations {
 		reqBuilder = reqBuilder.AddWf(l)
 	}
+	if d.currWG.restore {
+		reqBuilder = reqBuilder.WithRestoredContext(d.currWG.contextAddr)
+	}
 	req := reqBuilder.Build()
 	err := d.dispatchingPort.Send(req)
 
@@ -234,7 +377,7 @@ func (d *DispatcherImpl) dispatchNextWG(
 		d.originalReqs[req.ID] = req
 		d.cycleLeft = d.latencyTable[len(d.currWG.locations)]
 
-		if d.progressBar != nil {
+		if d.progressBar != nil && !d.currWG.restore {
 			d.progressBar.IncrementInProgress(1)
 		}
 
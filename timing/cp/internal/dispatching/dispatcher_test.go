@@ -8,8 +8,19 @@ import (
 	"github.com/sarchlab/mgpusim/v3/insts"
 	"github.com/sarchlab/mgpusim/v3/kernels"
 	"github.com/sarchlab/mgpusim/v3/protocol"
+	"github.com/sarchlab/mgpusim/v3/timing/cp/internal/resource"
 )
 
+type fakeDispatchableCU struct {
+	name string
+}
+
+func (cu *fakeDispatchableCU) DispatchingPort() sim.Port { return nil }
+func (cu *fakeDispatchableCU) WfPoolSizes() []int        { return []int{10} }
+func (cu *fakeDispatchableCU) VRegCounts() []int         { return []int{-1} }
+func (cu *fakeDispatchableCU) SRegCount() int            { return -1 }
+func (cu *fakeDispatchableCU) LDSBytes() int             { return -1 }
+
 var _ = Describe("Dispatcher", func() {
 	var (
 		ctrl *gomock.Controller
@@ -68,6 +79,68 @@ var _ = Describe("Dispatcher", func() {
 		Expect(dispatcher.dispatching).To(BeIdenticalTo(req))
 	})
 
+	It("should start a kernel with the first chunk from the pool", func() {
+		hsaco := insts.NewHsaCo()
+		packet := &kernels.HsaKernelDispatchPacket{}
+
+		req := protocol.NewLaunchKernelReq(10, nil, respondingPort)
+		req.HsaCo = hsaco
+		req.Packet = packet
+		req.WGPool = kernels.NewWGPool(20, 8)
+
+		alg.EXPECT().StartNewKernel(kernels.KernelLaunchInfo{
+			CodeObject: hsaco,
+			Packet:     packet,
+			WGRange:    &kernels.WGRange{Start: 0, End: 8},
+		})
+
+		dispatcher.StartDispatching(req)
+
+		Expect(dispatcher.numWG()).To(Equal(8))
+	})
+
+	It("should take another chunk when the chunk is dispatched", func() {
+		req := protocol.NewLaunchKernelReq(10, nil, respondingPort)
+		req.WGPool = kernels.NewWGPool(20, 8)
+		req.WGPool.Claim()
+		dispatcher.dispatching = req
+		dispatcher.numClaimedWGs = 8
+		dispatcher.numDispatchedWGs = 8
+
+		alg.EXPECT().HasNext().Return(false).Times(3)
+		alg.EXPECT().StartNewKernel(kernels.KernelLaunchInfo{
+			WGRange: &kernels.WGRange{Start: 8, End: 16},
+		})
+		alg.EXPECT().Next().Return(dispatchLocation{valid: true})
+		dispatchingPort.EXPECT().Peek().Return(nil)
+		dispatchingPort.EXPECT().Send(gomock.Any()).Return(nil)
+
+		madeProgress := dispatcher.Tick(10)
+
+		Expect(madeProgress).To(BeTrue())
+		Expect(dispatcher.numWG()).To(Equal(16))
+		Expect(dispatcher.numDispatchedWGs).To(Equal(9))
+	})
+
+	It("should not complete the kernel while the pool has work-groups", func() {
+		req := protocol.NewLaunchKernelReq(10, nil, respondingPort)
+		req.WGPool = kernels.NewWGPool(20, 8)
+		req.WGPool.Claim()
+		dispatcher.dispatching = req
+		dispatcher.numClaimedWGs = 8
+		dispatcher.numDispatchedWGs = 8
+		dispatcher.numCompletedWGs = 8
+		dispatcher.preempted = true
+
+		alg.EXPECT().HasNext().Return(false).AnyTimes()
+		dispatchingPort.EXPECT().Peek().Return(nil)
+
+		madeProgress := dispatcher.Tick(10)
+
+		Expect(madeProgress).To(BeFalse())
+		Expect(dispatcher.IsDispatching()).To(BeTrue())
+	})
+
 	It("should panic if the dispatcher is dispatching another kernel", func() {
 		req := protocol.NewLaunchKernelReq(10, nil, respondingPort)
 		dispatcher.dispatching = req
@@ -121,6 +194,61 @@ var _ = Describe("Dispatcher", func() {
 		Expect(madeProgress).To(BeFalse())
 		Expect(dispatcher.currWG.valid).To(BeFalse())
 		Expect(dispatcher.numDispatchedWGs).To(Equal(0))
+		Expect(dispatcher.waitingForCU).To(BeTrue())
+	})
+
+	It("should hold back work-groups while a higher-priority kernel waits", func() {
+		other := &DispatcherImpl{
+			cus:          resource.NewMaskedCUResourcePool(nil),
+			dispatching:  protocol.NewLaunchKernelReq(10, nil, respondingPort),
+			priority:     1,
+			waitingForCU: true,
+		}
+		other.cus.SetCUMask(nil)
+		dispatcher.arbiter.register(other)
+
+		req := protocol.NewLaunchKernelReq(10, nil, respondingPort)
+		dispatcher.dispatching = req
+
+		dispatchingPort.EXPECT().Peek().Return(nil)
+		alg.EXPECT().HasNext().Return(true).AnyTimes()
+
+		madeProgress := dispatcher.Tick(10)
+
+		Expect(madeProgress).To(BeFalse())
+		Expect(dispatcher.numDispatchedWGs).To(Equal(0))
+	})
+
+	It("should not hold back work-groups for kernels on other CUs", func() {
+		pool := resource.NewCUResourcePool()
+		pool.RegisterCU(&fakeDispatchableCU{name: "CU0"})
+		pool.RegisterCU(&fakeDispatchableCU{name: "CU1"})
+
+		other := &DispatcherImpl{
+			cus:          resource.NewMaskedCUResourcePool(pool),
+			dispatching:  protocol.NewLaunchKernelReq(10, nil, respondingPort),
+			priority:     1,
+			waitingForCU: true,
+		}
+		other.cus.SetCUMask([]uint32{0x1})
+		dispatcher.arbiter.register(other)
+		dispatcher.cus = resource.NewMaskedCUResourcePool(pool)
+		dispatcher.cus.SetCUMask([]uint32{0x2})
+
+		req := protocol.NewLaunchKernelReq(10, nil, respondingPort)
+		dispatcher.dispatching = req
+
+		alg.EXPECT().HasNext().Return(true).AnyTimes()
+		alg.EXPECT().Next().Return(dispatchLocation{
+			valid: true,
+		})
+		dispatchingPort.EXPECT().Peek().Return(nil)
+		dispatchingPort.EXPECT().Send(gomock.Any()).Return(nil)
+
+		madeProgress := dispatcher.Tick(10)
+
+		Expect(madeProgress).To(BeTrue())
+		Expect(dispatcher.numDispatchedWGs).To(Equal(1))
 	})
 
 	It("should pause if send to CU failed", func() {
@@ -242,6 +370,37 @@ var _ = Describe("Dispatcher", func() {
 		Expect(madeProgress).To(BeFalse())
 	})
 
+	It("should leave the work-groups of other dispatchers in the message",
+		func() {
+			req := protocol.NewLaunchKernelReq(10, nil, respondingPort)
+			dispatcher.dispatching = req
+
+			mapWGReq := protocol.MapWGReqBuilder{}.Build()
+			location := dispatchLocation{}
+			dispatcher.inflightWGs[mapWGReq.ID] = location
+			dispatcher.originalReqs[mapWGReq.ID] = mapWGReq
+
+			wgCompletionMsg := &protocol.WGCompletionMsg{
+				RspTo: []string{"other", mapWGReq.ID},
+			}
+
+			dispatcher.numDispatchedWGs = 64
+			dispatcher.numCompletedWGs = 48
+
+			alg.EXPECT().HasNext().Return(false).AnyTimes()
+			alg.EXPECT().NumWG().Return(64)
+			alg.EXPECT().FreeResources(location)
+			dispatchingPort.EXPECT().
+				Peek().
+				Return(wgCompletionMsg)
+
+			madeProgress := dispatcher.Tick(10)
+
+			Expect(madeProgress).To(BeTrue())
+			Expect(dispatcher.inflightWGs).NotTo(HaveKey(mapWGReq.ID))
+			Expect(wgCompletionMsg.RspTo).To(Equal([]string{"other"}))
+		})
+
 	It("should send response when a kernel is completed", func() {
 		req := protocol.NewLaunchKernelReq(10, nil, respondingPort)
 		dispatcher.dispatching = req
@@ -279,4 +438,212 @@ var _ = Describe("Dispatcher", func() {
 		Expect(madeProgress).To(BeFalse())
 		Expect(dispatcher.dispatching).To(BeIdenticalTo(req))
 	})
+
+	Context("when preempting", func() {
+		var (
+			req *protocol.LaunchKernelReq
+			wg  *kernels.WorkGroup
+		)
+
+		BeforeEach(func() {
+			req = protocol.NewLaunchKernelReq(10, nil, respondingPort)
+			req.ContextSaveArea = 0x10000
+			dispatcher.dispatching = req
+
+			co := insts.NewHsaCo()
+			co.HsaCoHeader = new(insts.HsaCoHeader)
+			co.WFSgprCount = 16
+			wg = kernels.NewWorkGroup()
+			wg.IDX = 1
+			wg.CodeObject = co
+			wg.Packet = &kernels.HsaKernelDispatchPacket{
+				GridSizeX:      256,
+				GridSizeY:      1,
+				GridSizeZ:      1,
+				WorkgroupSizeX: 64,
+				WorkgroupSizeY: 1,
+				WorkgroupSizeZ: 1,
+			}
+			req.HsaCo = co
+			req.Packet = wg.Packet
+			req.NumContextSaveSlots = 2
+			dispatcher.freeContextSlots = []int{0, 1}
+		})
+
+		It("should stop dispatching work-groups", func() {
+			dispatcher.Preempt(false)
+
+			alg.EXPECT().HasNext().Return(true).AnyTimes()
+			dispatchingPort.EXPECT().Peek().Return(nil)
+
+			madeProgress := dispatcher.Tick(10)
+
+			Expect(madeProgress).To(BeFalse())
+			Expect(dispatcher.IsPreempted()).To(BeTrue())
+		})
+
+		It("should release the work-group that is not sent yet", func() {
+			dispatcher.currWG = dispatchLocation{valid: true, wg: wg}
+			alg.EXPECT().FreeResources(gomock.Any())
+
+			dispatcher.Preempt(false)
+
+			Expect(dispatcher.currWG.valid).To(BeFalse())
+			Expect(dispatcher.preemptedWGs).To(HaveLen(1))
+			Expect(dispatcher.preemptedWGs[0].restore).To(BeFalse())
+		})
+
+		It("should ask the CUs to save the running work-groups", func() {
+			mapWGReq := protocol.MapWGReqBuilder{}.Build()
+			dispatcher.inflightWGs[mapWGReq.ID] = dispatchLocation{wg: wg}
+			dispatcher.numDispatchedWGs = 1
+
+			dispatcher.Preempt(true)
+
+			var preemptReq *protocol.PreemptWGReq
+			alg.EXPECT().HasNext().Return(false).AnyTimes()
+			dispatchingPort.EXPECT().Peek().Return(nil)
+			dispatchingPort.EXPECT().
+				Send(gomock.Any()).
+				Do(func(msg sim.Msg) {
+					preemptReq = msg.(*protocol.PreemptWGReq)
+				}).
+				Return(nil)
+
+			madeProgress := dispatcher.Tick(10)
+
+			Expect(madeProgress).To(BeTrue())
+			Expect(preemptReq.MapWGReqID).To(Equal(mapWGReq.ID))
+			Expect(preemptReq.ContextAddr).To(Equal(uint64(0x10000)))
+			Expect(dispatcher.toPreempt).To(BeEmpty())
+			Expect(dispatcher.freeContextSlots).To(Equal([]int{1}))
+			Expect(dispatcher.inflightWGs[mapWGReq.ID].contextSlot).To(Equal(0))
+		})
+
+		It("should save a restored work-group to its own slot", func() {
+			mapWGReq := protocol.MapWGReqBuilder{}.Build()
+			dispatcher.inflightWGs[mapWGReq.ID] = dispatchLocation{
+				wg:             wg,
+				hasContextSlot: true,
+				contextSlot:    1,
+			}
+			dispatcher.freeContextSlots = []int{0}
+			dispatcher.numDispatchedWGs = 1
+
+			dispatcher.Preempt(true)
+
+			var preemptReq *protocol.PreemptWGReq
+			alg.EXPECT().HasNext().Return(false).AnyTimes()
+			dispatchingPort.EXPECT().Peek().Return(nil)
+			dispatchingPort.EXPECT().
+				Send(gomock.Any()).
+				Do(func(msg sim.Msg) {
+					preemptReq = msg.(*protocol.PreemptWGReq)
+				}).
+				Return(nil)
+
+			dispatcher.Tick(10)
+
+			Expect(preemptReq.ContextAddr).
+				To(Equal(0x10000 + kernels.WGContextByteSize(wg.CodeObject,
+					wg.Packet)))
+			Expect(dispatcher.freeContextSlots).To(Equal([]int{0}))
+		})
+
+		It("should free the slot when the work-group completes", func() {
+			mapWGReq := protocol.MapWGReqBuilder{}.Build()
+			location := dispatchLocation{
+				wg:             wg,
+				hasContextSlot: true,
+				contextSlot:    1,
+			}
+			dispatcher.inflightWGs[mapWGReq.ID] = location
+			dispatcher.originalReqs[mapWGReq.ID] = mapWGReq
+			dispatcher.freeContextSlots = []int{0}
+
+			alg.EXPECT().FreeResources(location)
+			alg.EXPECT().NumWG().Return(4).AnyTimes()
+
+			dispatcher.completeWG(mapWGReq.ID)
+
+			Expect(dispatcher.freeContextSlots).To(Equal([]int{0, 1}))
+		})
+
+		It("should not save the context if the kernel has no save area", func() {
+			req.ContextSaveArea = 0
+			mapWGReq := protocol.MapWGReqBuilder{}.Build()
+			dispatcher.inflightWGs[mapWGReq.ID] = dispatchLocation{wg: wg}
+
+			dispatcher.Preempt(true)
+
+			Expect(dispatcher.toPreempt).To(BeEmpty())
+		})
+
+		It("should release the resources of a preempted work-group", func() {
+			mapWGReq := protocol.MapWGReqBuilder{}.Build()
+			location := dispatchLocation{wg: wg}
+			dispatcher.inflightWGs[mapWGReq.ID] = location
+			dispatcher.originalReqs[mapWGReq.ID] = mapWGReq
+			dispatcher.numDispatchedWGs = 1
+			dispatcher.Preempt(true)
+			dispatcher.toPreempt = nil
+
+			alg.EXPECT().HasNext().Return(false).AnyTimes()
+			alg.EXPECT().FreeResources(location)
+			dispatchingPort.EXPECT().
+				Peek().
+				Return(&protocol.WGPreemptedMsg{RspTo: mapWGReq.ID})
+			dispatchingPort.EXPECT().Retrieve(sim.VTimeInSec(10))
+
+			madeProgress := dispatcher.Tick(10)
+
+			Expect(madeProgress).To(BeTrue())
+			Expect(dispatcher.inflightWGs).To(BeEmpty())
+			Expect(dispatcher.numDispatchedWGs).To(Equal(0))
+			Expect(dispatcher.preemptedWGs).To(HaveLen(1))
+			Expect(dispatcher.preemptedWGs[0].restore).To(BeTrue())
+			Expect(dispatcher.kernelCompleted()).To(BeFalse())
+		})
+
+		It("should restore the preempted work-groups after resuming", func() {
+			pool := NewMockCUResourcePool(ctrl)
+			cu := NewMockCUResource(ctrl)
+			cuPort := NewMockPort(ctrl)
+			pool.EXPECT().NumCU().Return(1).AnyTimes()
+			pool.EXPECT().GetCU(0).Return(cu).AnyTimes()
+			cu.EXPECT().
+				ReserveResourceForWG(wg).
+				Return([]resource.WfLocation{{}}, true)
+			cu.EXPECT().DispatchingPort().Return(cuPort)
+			dispatcher.cus = resource.NewMaskedCUResourcePool(pool)
+
+			dispatcher.preempted = true
+			dispatcher.preemptedWGs = []dispatchLocation{{
+				wg:          wg,
+				restore:     true,
+				contextAddr: 0x20000,
+			}}
+
+			dispatcher.Resume()
+
+			var mapWGReq *protocol.MapWGReq
+			alg.EXPECT().HasNext().Return(false).AnyTimes()
+			dispatchingPort.EXPECT().Peek().Return(nil)
+			dispatchingPort.EXPECT().
+				Send(gomock.Any()).
+				Do(func(msg sim.Msg) {
+					mapWGReq = msg.(*protocol.MapWGReq)
+				}).
+				Return(nil)
+
+			madeProgress := dispatcher.Tick(10)
+
+			Expect(madeProgress).To(BeTrue())
+			Expect(mapWGReq.Dst).To(BeIdenticalTo(cuPort))
+			Expect(mapWGReq.RestoreContext).To(BeTrue())
+			Expect(mapWGReq.ContextAddr).To(Equal(uint64(0x20000)))
+			Expect(dispatcher.preemptedWGs).To(BeEmpty())
+			Expect(dispatcher.numDispatchedWGs).To(Equal(1))
+		})
+	})
 })
@@ -0,0 +1,173 @@
+package dispatching
+
+import (
+	"sort"
+
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/akita/v3/tracing"
+	"github.com/sarchlab/mgpusim/v3/kernels"
+	"github.com/sarchlab/mgpusim/v3/protocol"
+)
+
+// Preempt stops dispatching the work-groups of the current kernel.
+func (d *DispatcherImpl) Preempt(saveContext bool) {
+	if d.dispatching == nil || d.preempted {
+		return
+	}
+
+	d.preempted = true
+	d.waitingForCU = false
+
+	if d.currWG.valid {
+		d.alg.FreeResources(d.currWG)
+		d.currWG.valid = false
+		d.preemptedWGs = append(d.preemptedWGs, d.currWG)
+	}
+
+	if !saveContext || d.dispatching.ContextSaveArea == 0 {
+		return
+	}
+
+	d.toPreempt = d.toPreempt[:0]
+	for id := range d.inflightWGs {
+		d.toPreempt = append(d.toPreempt, id)
+	}
+	sort.Strings(d.toPreempt)
+}
+
+// Resume continues dispatching the preempted kernel.
+func (d *DispatcherImpl) Resume() {
+	d.preempted = false
+	d.toPreempt = nil
+}
+
+// IsPreempted checks if the dispatcher is holding back the kernel.
+func (d *DispatcherImpl) IsPreempted() bool {
+	return d.preempted
+}
+
+func (d *DispatcherImpl) preemptNextWG(now sim.VTimeInSec) bool {
+	for len(d.toPreempt) > 0 {
+		if _, ok := d.inflightWGs[d.toPreempt[0]]; ok {
+			break
+		}
+
+		// The work-group has completed before being preempted.
+		d.toPreempt = d.toPreempt[1:]
+	}
+
+	if len(d.toPreempt) == 0 {
+		return false
+	}
+
+	mapWGReqID := d.toPreempt[0]
+	location := d.inflightWGs[mapWGReqID]
+	slot := location.contextSlot
+	if !location.hasContextSlot {
+		slot = d.nextFreeContextSlot()
+	}
+
+	req := protocol.PreemptWGReqBuilder{}.
+		WithSendTime(now).
+		WithSrc(d.dispatchingPort).
+		WithDst(location.cu).
+		WithMapWGReqID(mapWGReqID).
+		WithContextAddr(d.contextAddr(slot)).
+		Build()
+
+	err := d.dispatchingPort.Send(req)
+	if err != nil {
+		return false
+	}
+
+	if !location.hasContextSlot {
+		d.freeContextSlots = d.freeContextSlots[1:]
+		location.hasContextSlot = true
+		location.contextSlot = slot
+		d.inflightWGs[mapWGReqID] = location
+	}
+
+	d.toPreempt = d.toPreempt[1:]
+	d.preemptReqs[mapWGReqID] = req
+
+	tracing.TraceReqInitiate(req, d,
+		tracing.MsgIDAtReceiver(d.dispatching, d.cp))
+
+	return true
+}
+
+// completePreemption releases the resources of a work-group whose context has
+// been saved. The work-group will be dispatched again when the kernel
+// resumes.
+func (d *DispatcherImpl) completePreemption(mapWGReqID string) {
+	location := d.inflightWGs[mapWGReqID]
+	d.alg.FreeResources(location)
+	delete(d.inflightWGs, mapWGReqID)
+	d.numDispatchedWGs--
+
+	originalReq := d.originalReqs[mapWGReqID]
+	delete(d.originalReqs, mapWGReqID)
+	tracing.TraceReqFinalize(originalReq, d)
+	d.finalizePreemptReq(mapWGReqID)
+
+	location.restore = true
+	location.contextAddr = d.contextAddr(location.contextSlot)
+	d.preemptedWGs = append(d.preemptedWGs, location)
+}
+
+// nextFreeContextSlot returns the slot in the context save area that the next
+// preempted work-group takes. The save area has a slot for every work-group
+// that can run at the same time, so a slot is always available.
+func (d *DispatcherImpl) nextFreeContextSlot() int {
+	if len(d.freeContextSlots) == 0 {
+		panic("no free slot in the context save area")
+	}
+
+	return d.freeContextSlots[0]
+}
+
+func (d *DispatcherImpl) contextAddr(slot int) uint64 {
+	return kernels.WGContextAddr(d.dispatching.ContextSaveArea,
+		d.dispatching.HsaCo, d.dispatching.Packet, slot)
+}
+
+func (d *DispatcherImpl) finalizePreemptReq(mapWGReqID string) {
+	req, ok := d.preemptReqs[mapWGReqID]
+	if !ok {
+		return
+	}
+
+	delete(d.preemptReqs, mapWGReqID)
+	tracing.TraceReqFinalize(req, d)
+}
+
+// nextPreemptedWG finds a CU that can host the first preempted work-group.
+// Since the context of the work-group is moved through the memory, the
+// work-group can resume on any CU that the kernel can use.
+func (d *DispatcherImpl) nextPreemptedWG() dispatchLocation {
+	pending := d.preemptedWGs[0]
+
+	for i := 0; i < d.cus.NumCU(); i++ {
+		cu := d.cus.GetCU(i)
+
+		locations, ok := cu.ReserveResourceForWG(pending.wg)
+		if !ok {
+			continue
+		}
+
+		dispatch := pending
+		dispatch.valid = true
+		dispatch.cuID = i
+		dispatch.cu = cu.DispatchingPort()
+		dispatch.locations = make([]protocol.WfDispatchLocation, len(locations))
+		for j, location := range locations {
+			dispatch.locations[j] = protocol.WfDispatchLocation(location)
+		}
+
+		d.preemptedWGs = d.preemptedWGs[1:]
+
+		return dispatch
+	}
+
+	return dispatchLocation{}
+}
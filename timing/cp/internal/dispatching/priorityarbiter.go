@@ -0,0 +1,33 @@
+package dispatching
+
+import "github.com/sarchlab/mgpusim/v3/timing/cp/internal/resource"
+
+// priorityArbiter lets the dispatchers that share a CU resource pool see
+// each other. A dispatcher holds back its work-groups while a kernel with a
+// higher priority waits for the CUs that both kernels can use, so that the
+// freed resources go to the kernel with the higher priority.
+type priorityArbiter struct {
+	dispatchers []*DispatcherImpl
+}
+
+func (a *priorityArbiter) register(d *DispatcherImpl) {
+	a.dispatchers = append(a.dispatchers, d)
+}
+
+func (a *priorityArbiter) shouldYield(d *DispatcherImpl) bool {
+	for _, other := range a.dispatchers {
+		if other == d || !other.IsDispatching() || !other.waitingForCU {
+			continue
+		}
+
+		if other.priority <= d.priority {
+			continue
+		}
+
+		if resource.CUMasksOverlap(other.cus.CUMask(), d.cus.CUMask()) {
+			return true
+		}
+	}
+
+	return false
+}
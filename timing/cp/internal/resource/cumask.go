@@ -0,0 +1,97 @@
+package resource
+
+// CUEnabled tells if a CU mask enables the i-th CU. Like HSA CU masking,
+// each bit of the mask stands for a CU, starting from the lowest bit of the
+// first word. An empty mask enables all the CUs.
+func CUEnabled(mask []uint32, i int) bool {
+	if len(mask) == 0 {
+		return true
+	}
+
+	if i/32 >= len(mask) {
+		return false
+	}
+
+	return mask[i/32]&(1<<(i%32)) != 0
+}
+
+// CUMasksOverlap tells if there is a CU that both masks enable.
+func CUMasksOverlap(a, b []uint32) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return true
+	}
+
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i]&b[i] != 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MaskedCUResourcePool is a view of a CUResourcePool that only includes the
+// CUs that a CU mask enables. The CUs keep their order, but are renumbered
+// from 0.
+type MaskedCUResourcePool struct {
+	pool  CUResourcePool
+	mask  []uint32
+	cuIDs []int
+}
+
+// NewMaskedCUResourcePool creates a view of the pool that includes all the
+// CUs until a mask is set.
+func NewMaskedCUResourcePool(pool CUResourcePool) *MaskedCUResourcePool {
+	return &MaskedCUResourcePool{
+		pool: pool,
+	}
+}
+
+// SetCUMask limits the view to the CUs that the mask enables.
+func (p *MaskedCUResourcePool) SetCUMask(mask []uint32) {
+	p.mask = mask
+	p.cuIDs = nil
+
+	if len(mask) == 0 {
+		return
+	}
+
+	for i := 0; i < p.pool.NumCU(); i++ {
+		if CUEnabled(mask, i) {
+			p.cuIDs = append(p.cuIDs, i)
+		}
+	}
+
+	if len(p.cuIDs) == 0 {
+		panic("the CU mask does not enable any CU")
+	}
+}
+
+// CUMask returns the mask that limits the view.
+func (p *MaskedCUResourcePool) CUMask() []uint32 {
+	return p.mask
+}
+
+// NumCU returns the number of CUs that the mask enables.
+func (p *MaskedCUResourcePool) NumCU() int {
+	if len(p.mask) == 0 {
+		return p.pool.NumCU()
+	}
+
+	return len(p.cuIDs)
+}
+
+// GetCU returns the i-th CU that the mask enables.
+func (p *MaskedCUResourcePool) GetCU(i int) CUResource {
+	if len(p.mask) == 0 {
+		return p.pool.GetCU(i)
+	}
+
+	return p.pool.GetCU(p.cuIDs[i])
+}
+
+// RegisterCU puts the CU's resources into the underlying pool.
+func (p *MaskedCUResourcePool) RegisterCU(cu DispatchableCU) {
+	p.pool.RegisterCU(cu)
+	p.SetCUMask(p.mask)
+}
@@ -0,0 +1,69 @@
+package resource
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CU mask", func() {
+	var (
+		cus  []CUResource
+		pool *CUResourcePoolImpl
+		view *MaskedCUResourcePool
+	)
+
+	BeforeEach(func() {
+		cus = nil
+		for i := 0; i < 40; i++ {
+			cus = append(cus, &CUResourceImpl{})
+		}
+
+		pool = NewCUResourcePool()
+		pool.cus = cus
+		view = NewMaskedCUResourcePool(pool)
+	})
+
+	It("should tell if a CU is enabled", func() {
+		mask := []uint32{0x5, 0x1}
+
+		Expect(CUEnabled(mask, 0)).To(BeTrue())
+		Expect(CUEnabled(mask, 1)).To(BeFalse())
+		Expect(CUEnabled(mask, 2)).To(BeTrue())
+		Expect(CUEnabled(mask, 32)).To(BeTrue())
+		Expect(CUEnabled(mask, 33)).To(BeFalse())
+		Expect(CUEnabled(mask, 64)).To(BeFalse())
+		Expect(CUEnabled(nil, 64)).To(BeTrue())
+	})
+
+	It("should tell if two masks overlap", func() {
+		Expect(CUMasksOverlap([]uint32{0x3}, []uint32{0x2})).To(BeTrue())
+		Expect(CUMasksOverlap([]uint32{0x3}, []uint32{0x4})).To(BeFalse())
+		Expect(CUMasksOverlap([]uint32{0x3}, []uint32{0x0, 0x1})).To(BeFalse())
+		Expect(CUMasksOverlap(nil, []uint32{0x1})).To(BeTrue())
+	})
+
+	It("should include all the CUs without a mask", func() {
+		Expect(view.NumCU()).To(Equal(40))
+		Expect(view.GetCU(39)).To(BeIdenticalTo(cus[39]))
+	})
+
+	It("should only include the enabled CUs", func() {
+		view.SetCUMask([]uint32{0x80000002, 0x80})
+
+		Expect(view.NumCU()).To(Equal(3))
+		Expect(view.GetCU(0)).To(BeIdenticalTo(cus[1]))
+		Expect(view.GetCU(1)).To(BeIdenticalTo(cus[31]))
+		Expect(view.GetCU(2)).To(BeIdenticalTo(cus[39]))
+	})
+
+	It("should include all the CUs again after removing the mask", func() {
+		view.SetCUMask([]uint32{0x1})
+		view.SetCUMask(nil)
+
+		Expect(view.NumCU()).To(Equal(40))
+	})
+
+	It("should panic if the mask does not enable any CU", func() {
+		Expect(func() { view.SetCUMask([]uint32{0, 0x100}) }).To(Panic())
+	})
+})
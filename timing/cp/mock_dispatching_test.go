@@ -87,6 +87,20 @@ func (mr *MockDispatcherMockRecorder) IsDispatching() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsDispatching", reflect.TypeOf((*MockDispatcher)(nil).IsDispatching))
 }
 
+// IsPreempted mocks base method.
+func (m *MockDispatcher) IsPreempted() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsPreempted")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsPreempted indicates an expected call of IsPreempted.
+func (mr *MockDispatcherMockRecorder) IsPreempted() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsPreempted", reflect.TypeOf((*MockDispatcher)(nil).IsPreempted))
+}
+
 // Name mocks base method.
 func (m *MockDispatcher) Name() string {
 	m.ctrl.T.Helper()
@@ -115,6 +129,18 @@ func (mr *MockDispatcherMockRecorder) NumHooks() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NumHooks", reflect.TypeOf((*MockDispatcher)(nil).NumHooks))
 }
 
+// Preempt mocks base method.
+func (m *MockDispatcher) Preempt(arg0 bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Preempt", arg0)
+}
+
+// Preempt indicates an expected call of Preempt.
+func (mr *MockDispatcherMockRecorder) Preempt(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Preempt", reflect.TypeOf((*MockDispatcher)(nil).Preempt), arg0)
+}
+
 // RegisterCU mocks base method.
 func (m *MockDispatcher) RegisterCU(arg0 resource.DispatchableCU) {
 	m.ctrl.T.Helper()
@@ -127,6 +153,18 @@ func (mr *MockDispatcherMockRecorder) RegisterCU(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterCU", reflect.TypeOf((*MockDispatcher)(nil).RegisterCU), arg0)
 }
 
+// Resume mocks base method.
+func (m *MockDispatcher) Resume() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Resume")
+}
+
+// Resume indicates an expected call of Resume.
+func (mr *MockDispatcherMockRecorder) Resume() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resume", reflect.TypeOf((*MockDispatcher)(nil).Resume))
+}
+
 // StartDispatching mocks base method.
 func (m *MockDispatcher) StartDispatching(arg0 *protocol.LaunchKernelReq) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,123 @@
+package cp
+
+import (
+	"log"
+
+	"github.com/sarchlab/mgpusim/v3/protocol"
+	"github.com/sarchlab/mgpusim/v3/timing/cp/internal/dispatching"
+	"github.com/sarchlab/mgpusim/v3/timing/cp/internal/resource"
+)
+
+// PreemptionMode decides what the Command Processor does to the running
+// kernels when a kernel with a higher priority arrives.
+type PreemptionMode string
+
+// All the supported preemption modes.
+const (
+	// PreemptionNone lets the running kernels continue. The kernel with the
+	// higher priority only gets the resources that the running kernels free.
+	PreemptionNone PreemptionMode = "none"
+
+	// PreemptionDrain stops dispatching the work-groups of the kernels with
+	// lower priorities and waits for their running work-groups to complete.
+	PreemptionDrain PreemptionMode = "drain"
+
+	// PreemptionContextSwitch stops the running work-groups of the kernels
+	// with lower priorities at the next instruction boundary and saves their
+	// registers and LDS to the memory. The work-groups are restored when the
+	// kernel resumes. Kernels without a context save area are drained
+	// instead.
+	PreemptionContextSwitch PreemptionMode = "context-switch"
+)
+
+func mustBeKnownPreemptionMode(mode PreemptionMode) {
+	switch mode {
+	case PreemptionNone, PreemptionDrain, PreemptionContextSwitch:
+	default:
+		log.Panicf("unknown preemption mode %s", mode)
+	}
+}
+
+// preemptLowerPriorityKernels preempts the running kernels that share CUs with
+// the newly launched kernel and have lower priorities.
+func (p *CommandProcessor) preemptLowerPriorityKernels(
+	d dispatching.Dispatcher,
+	req *protocol.LaunchKernelReq,
+) {
+	if p.preemption == PreemptionNone {
+		return
+	}
+
+	for _, other := range p.Dispatchers {
+		if other == d || !other.IsDispatching() || other.IsPreempted() {
+			continue
+		}
+
+		otherReq := p.dispatchingKernels[other]
+		if otherReq.Priority >= req.Priority {
+			continue
+		}
+
+		if !resource.CUMasksOverlap(otherReq.CUMask, req.CUMask) {
+			continue
+		}
+
+		other.Preempt(p.preemption == PreemptionContextSwitch)
+	}
+}
+
+// resumePreemptedKernels resumes the preempted kernel with the highest
+// priority once no running kernel with a higher priority shares CUs with it.
+func (p *CommandProcessor) resumePreemptedKernels() bool {
+	if p.preemption == PreemptionNone {
+		return false
+	}
+
+	var toResume dispatching.Dispatcher
+	for _, d := range p.Dispatchers {
+		if !d.IsDispatching() || !d.IsPreempted() {
+			continue
+		}
+
+		if p.isBlockedByHigherPriorityKernel(d) {
+			continue
+		}
+
+		if toResume == nil ||
+			p.dispatchingKernels[d].Priority >
+				p.dispatchingKernels[toResume].Priority {
+			toResume = d
+		}
+	}
+
+	if toResume == nil {
+		return false
+	}
+
+	toResume.Resume()
+
+	return true
+}
+
+func (p *CommandProcessor) isBlockedByHigherPriorityKernel(
+	d dispatching.Dispatcher,
+) bool {
+	req := p.dispatchingKernels[d]
+
+	for _, other := range p.Dispatchers {
+		if other == d || !other.IsDispatching() || other.IsPreempted() {
+			continue
+		}
+
+		otherReq := p.dispatchingKernels[other]
+		if otherReq.Priority <= req.Priority {
+			continue
+		}
+
+		if resource.CUMasksOverlap(otherReq.CUMask, req.CUMask) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/sarchlab/mgpusim/v3/insts"
 	"github.com/sarchlab/mgpusim/v3/kernels"
 	"github.com/sarchlab/mgpusim/v3/protocol"
+	"github.com/sarchlab/mgpusim/v3/timing/atomics"
 	"github.com/sarchlab/mgpusim/v3/timing/wavefront"
 )
 
@@ -33,6 +34,9 @@ type ComputeUnit struct {
 	shadowInFlightScalarMemAccess []*ScalarMemAccessInfo
 	shadowInFlightVectorMemAccess []VectorMemAccessInfo
 
+	contextSwitches []*wgContextSwitch
+	contextAccesses map[string]*wgContextSwitch
+
 	running bool
 
 	Scheduler        Scheduler
@@ -118,6 +122,7 @@ func (cu *ComputeUnit) Tick(now sim.VTimeInSec) bool {
 	madeProgress = cu.sendToACE(now) || madeProgress
 	madeProgress = cu.sendToCP(now) || madeProgress
 	madeProgress = cu.processInput(now) || madeProgress
+	madeProgress = cu.switchContexts(now) || madeProgress
 	madeProgress = cu.doFlush(now) || madeProgress
 
 	return madeProgress
@@ -260,6 +265,7 @@ func (cu *ComputeUnit) flushPipeline(now sim.VTimeInSec) bool {
 	cu.shadowInFlightVectorMemAccess = nil
 
 	cu.populateShadowBuffers()
+	cu.requeueContextAccesses()
 	cu.setWavesToReady()
 	cu.Scheduler.Flush()
 	cu.flushInternalComponents()
@@ -304,6 +310,8 @@ func (cu *ComputeUnit) processInputFromACE(now sim.VTimeInSec) bool {
 	switch req := req.(type) {
 	case *protocol.MapWGReq:
 		return cu.handleMapWGReq(now, req)
+	case *protocol.PreemptWGReq:
+		return cu.handlePreemptWGReq(now, req)
 	default:
 		panic("unknown req type")
 	}
@@ -333,6 +341,10 @@ func (cu *ComputeUnit) handleMapWGReq(
 		)
 	}
 
+	if req.RestoreContext {
+		cu.startRestoringWG(wg)
+	}
+
 	cu.running = true
 	cu.TickLater(now)
 
@@ -487,11 +499,17 @@ func (cu *ComputeUnit) processInputFromVectorMem(now sim.VTimeInSec) bool {
 		return false
 	}
 
+	if cu.handleContextAccessRsp(rsp) {
+		return true
+	}
+
 	switch rsp := rsp.(type) {
 	case *mem.DataReadyRsp:
 		cu.handleVectorDataLoadReturn(now, rsp)
 	case *mem.WriteDoneRsp:
 		cu.handleVectorDataStoreRsp(now, rsp)
+	case *atomics.AtomicRsp:
+		cu.handleVectorAtomicRsp(now, rsp)
 	default:
 		log.Panicf("cannot handle request of type %s from ToInstMem port",
 			reflect.TypeOf(rsp))
@@ -532,10 +550,25 @@ func (cu *ComputeUnit) handleVectorDataLoadReturn(
 		access.Reg = laneInfo.reg
 		access.RegCount = laneInfo.regCount
 		access.LaneID = laneInfo.laneID
-		if inst.FormatType == insts.FLAT && inst.Opcode == 16 { // FLAT_LOAD_UBYTE
-			access.Data = insts.Uint32ToBytes(uint32(rsp.Data[offset]))
-		} else if inst.FormatType == insts.FLAT && inst.Opcode == 18 {
+		isFlatOrBuffer := inst.FormatType == insts.FLAT ||
+			inst.FormatType == insts.MUBUF
+		if emu.IsFlatD16Load(inst.Inst) {
+			access.Data = make([]byte, 4)
+			cu.VRegFile[wf.SIMDID].Read(access)
+			old := insts.BytesToUint32(access.Data)
+			access.Data = insts.Uint32ToBytes(
+				emu.FlatD16LoadMerge(inst.Inst, old, rsp.Data[offset:]))
+		} else if isFlatOrBuffer && inst.Opcode == 16 { // FLAT_LOAD_UBYTE
 			access.Data = insts.Uint32ToBytes(uint32(rsp.Data[offset]))
+		} else if isFlatOrBuffer && inst.Opcode == 18 { // FLAT_LOAD_USHORT
+			access.Data = insts.Uint32ToBytes(uint32(
+				insts.BytesToUint16(rsp.Data[offset : offset+2])))
+		} else if isFlatOrBuffer && inst.Opcode == 17 {
+			access.Data = insts.Uint32ToBytes(
+				uint32(int32(int8(rsp.Data[offset]))))
+		} else if isFlatOrBuffer && inst.Opcode == 19 {
+			access.Data = insts.Uint32ToBytes(uint32(int32(int16(
+				insts.BytesToUint16(rsp.Data[offset : offset+2])))))
 		} else {
 			access.Data = rsp.Data[offset : offset+uint64(4*laneInfo.regCount)]
 		}
@@ -552,6 +585,55 @@ func (cu *ComputeUnit) handleVectorDataLoadReturn(
 	}
 }
 
+// handleVectorAtomicRsp writes the values before the atomic operations into
+// the VGPRs if the instruction returns them.
+func (cu *ComputeUnit) handleVectorAtomicRsp(
+	now sim.VTimeInSec,
+	rsp *atomics.AtomicRsp,
+) {
+	if len(cu.InFlightVectorMemAccess) == 0 {
+		return
+	}
+
+	info := cu.InFlightVectorMemAccess[0]
+
+	if info.Atomic == nil {
+		return
+	}
+
+	if info.Atomic.ID != rsp.RespondTo {
+		return
+	}
+
+	cu.InFlightVectorMemAccess = cu.InFlightVectorMemAccess[1:]
+	tracing.TraceReqFinalize(info.Atomic, cu)
+
+	wf := info.Wavefront
+	atomic, _ := emu.VMemAtomicInfo(info.Inst.Inst)
+
+	if atomic.ReturnPreOp {
+		for i, laneInfo := range info.laneInfo {
+			access := RegisterAccess{}
+			access.WaveOffset = wf.VRegOffset
+			access.Reg = laneInfo.reg
+			access.RegCount = laneInfo.regCount
+			access.LaneID = laneInfo.laneID
+			if atomic.Is64Bit {
+				access.Data = insts.Uint64ToBytes(rsp.PreOpValues[i])
+			} else {
+				access.Data = insts.Uint32ToBytes(uint32(rsp.PreOpValues[i]))
+			}
+			cu.VRegFile[wf.SIMDID].Write(access)
+		}
+	}
+
+	if !info.Atomic.CanWaitForCoalesce {
+		wf.OutstandingVectorMemAccess--
+		wf.OutstandingScalarMemAccess--
+		cu.logInstTask(now, wf, info.Inst, true)
+	}
+}
+
 func (cu *ComputeUnit) handleVectorDataStoreRsp(
 	now sim.VTimeInSec,
 	rsp *mem.WriteDoneRsp,
@@ -742,6 +824,16 @@ func (cu *ComputeUnit) sendVectorShadowBufferAccesses(
 				cu.shadowInFlightVectorMemAccess = cu.shadowInFlightVectorMemAccess[1:]
 				return true
 			}
+		} else if info.Atomic != nil {
+			req := info.Atomic
+			req.ID = sim.GetIDGenerator().Generate()
+			req.SendTime = now
+			err := cu.ToVectorMem.Send(req)
+			if err == nil {
+				cu.InFlightVectorMemAccess = append(cu.InFlightVectorMemAccess, info)
+				cu.shadowInFlightVectorMemAccess = cu.shadowInFlightVectorMemAccess[1:]
+				return true
+			}
 		}
 	}
 	return false
@@ -810,5 +902,7 @@ func NewComputeUnit(
 	cu.ToVectorMem = sim.NewLimitNumMsgPort(cu, 4, name+".ToVectorMem")
 	cu.ToCP = sim.NewLimitNumMsgPort(cu, 4, name+".ToCP")
 
+	cu.contextAccesses = make(map[string]*wgContextSwitch)
+
 	return cu
 }
@@ -6,9 +6,11 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/sarchlab/akita/v3/mem/mem"
 	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/emu"
 	"github.com/sarchlab/mgpusim/v3/insts"
 	"github.com/sarchlab/mgpusim/v3/kernels"
 	"github.com/sarchlab/mgpusim/v3/protocol"
+	"github.com/sarchlab/mgpusim/v3/timing/atomics"
 	"github.com/sarchlab/mgpusim/v3/timing/wavefront"
 )
 
@@ -28,6 +30,9 @@ func (m *mockScheduler) Resume() {
 func (m *mockScheduler) Flush() {
 }
 
+func (m *mockScheduler) RemoveWG(wg *wavefront.WorkGroup) {
+}
+
 type mockDecoder struct {
 	Inst *insts.Inst
 }
@@ -397,6 +402,108 @@ var _ = Describe("ComputeUnit", func() {
 				Expect(insts.BytesToUint32(access.Data)).To(Equal(uint32(i)))
 			}
 		})
+
+		It("should keep the other half of the registers for d16 loads", func() {
+			inst.Opcode = 37 // flat_load_short_d16_hi
+			for i := 0; i < 4; i++ {
+				access := RegisterAccess{}
+				access.RegCount = 1
+				access.LaneID = i
+				access.Reg = insts.VReg(0)
+				access.Data = insts.Uint32ToBytes(0x1234abcd)
+				cu.VRegFile[0].Write(access)
+			}
+
+			cu.processInputFromVectorMem(10)
+
+			for i := 0; i < 4; i++ {
+				access := RegisterAccess{}
+				access.RegCount = 1
+				access.LaneID = i
+				access.Reg = insts.VReg(0)
+				access.Data = make([]byte, 4)
+				cu.VRegFile[0].Read(access)
+				Expect(insts.BytesToUint32(access.Data)).
+					To(Equal(uint32(i)<<16 | 0xabcd))
+			}
+		})
+	})
+
+	Context("handle atomic respond from ToVectorMem port", func() {
+		var (
+			inst   *wavefront.Inst
+			wf     *wavefront.Wavefront
+			atomic *atomics.AtomicReq
+		)
+
+		BeforeEach(func() {
+			inst = wavefront.NewInst(insts.NewInst())
+			inst.FormatType = insts.FLAT
+			inst.Opcode = 66 // flat_atomic_add
+			inst.GlobalLevelCoherent = true
+			wf = wavefront.NewWavefront(grid.WorkGroups[0].Wavefronts[0])
+			wf.SIMDID = 0
+			wf.SetDynamicInst(inst)
+			wf.OutstandingVectorMemAccess = 1
+			wf.OutstandingScalarMemAccess = 1
+
+			atomic = atomics.AtomicReqBuilder{}.
+				WithAddress(0x100).
+				WithOp(emu.AtomicAdd).
+				Build()
+
+			info := VectorMemAccessInfo{}
+			info.Atomic = atomic
+			info.Wavefront = wf
+			info.Inst = inst
+			info.laneInfo = []vectorMemAccessLaneInfo{
+				{0, insts.VReg(0), 1, 0},
+				{1, insts.VReg(0), 1, 0},
+			}
+			cu.InFlightVectorMemAccess = append(
+				cu.InFlightVectorMemAccess, info)
+
+			rsp := atomics.AtomicRspBuilder{}.
+				WithRspTo(atomic.ID).
+				WithPreOpValues([]uint64{5, 6}).
+				Build()
+			toVectorMem.EXPECT().Retrieve(gomock.Any()).Return(rsp)
+		})
+
+		It("should write the pre-op values", func() {
+			cu.processInputFromVectorMem(10)
+
+			for i := 0; i < 2; i++ {
+				access := RegisterAccess{}
+				access.RegCount = 1
+				access.LaneID = i
+				access.Reg = insts.VReg(0)
+				access.Data = make([]byte, 4)
+				cu.VRegFile[0].Read(access)
+				Expect(insts.BytesToUint32(access.Data)).
+					To(Equal(uint32(i + 5)))
+			}
+
+			Expect(cu.InFlightVectorMemAccess).To(HaveLen(0))
+			Expect(wf.OutstandingVectorMemAccess).To(Equal(0))
+			Expect(wf.OutstandingScalarMemAccess).To(Equal(0))
+		})
+
+		It("should not write the VGPRs if the inst does not return", func() {
+			inst.GlobalLevelCoherent = false
+			atomic.CanWaitForCoalesce = true
+
+			cu.processInputFromVectorMem(10)
+
+			access := RegisterAccess{}
+			access.RegCount = 1
+			access.Reg = insts.VReg(0)
+			access.Data = make([]byte, 4)
+			cu.VRegFile[0].Read(access)
+			Expect(insts.BytesToUint32(access.Data)).To(Equal(uint32(0)))
+			Expect(cu.InFlightVectorMemAccess).To(HaveLen(0))
+			Expect(wf.OutstandingVectorMemAccess).To(Equal(1))
+		})
 	})
 
 	Context("handle write done respond from ToVectorMem port", func() {
@@ -589,4 +696,244 @@ var _ = Describe("ComputeUnit", func() {
 			Expect(cu.isPaused).To(BeFalse())
 		})
 	})
+
+	Context("when switching the context of a work-group", func() {
+		var (
+			memPort *MockPort
+			mapReq  *protocol.MapWGReq
+		)
+
+		BeforeEach(func() {
+			grid.CodeObject.WFSgprCount = 2
+			grid.CodeObject.WIVgprCount = 1
+			grid.Packet.WorkgroupSizeX = 64
+			grid.Packet.WorkgroupSizeY = 1
+			grid.Packet.WorkgroupSizeZ = 1
+			grid.Packet.GroupSegmentSize = 8
+
+			memPort = NewMockPort(mockCtrl)
+			cu.VectorMemModules = &mem.SingleLowModuleFinder{
+				LowModule: memPort,
+			}
+		})
+
+		sendAll := func(numChunks int) []sim.Msg {
+			var reqs []sim.Msg
+			toVectorMem.EXPECT().
+				Send(gomock.Any()).
+				Do(func(req sim.Msg) { reqs = append(reqs, req) }).
+				Times(numChunks)
+
+			for i := 0; i < numChunks; i++ {
+				cu.switchContexts(11)
+			}
+
+			return reqs
+		}
+
+		Context("when saving", func() {
+			var (
+				wf *wavefront.Wavefront
+			)
+
+			BeforeEach(func() {
+				mapReq = protocol.MapWGReqBuilder{}.
+					WithWG(grid.WorkGroups[0]).
+					Build()
+				wg := cu.wrapWG(grid.WorkGroups[0], mapReq)
+				wf = wg.Wfs[0]
+				wf.State = wavefront.WfReady
+				wf.PC = 0x1234
+				wf.LDSOffset = 0
+				cu.WfPools[0].AddWf(wf)
+				copy(wg.LDS, []byte{1, 2, 3, 4, 5, 6, 7, 8})
+
+				cu.SRegFile.Write(RegisterAccess{
+					Reg:  insts.SReg(1),
+					Data: insts.Uint32ToBytes(0xabcd),
+				})
+				cu.VRegFile[0].Write(RegisterAccess{
+					Reg:    insts.VReg(0),
+					LaneID: 3,
+					Data:   insts.Uint32ToBytes(0x5678),
+				})
+
+				req := protocol.PreemptWGReqBuilder{}.
+					WithMapWGReqID(mapReq.ID).
+					WithContextAddr(0x10000).
+					Build()
+				toACE.EXPECT().Retrieve(gomock.Any()).Return(req)
+				engine.EXPECT().Schedule(gomock.Any())
+
+				cu.processInputFromACE(10)
+			})
+
+			It("should stop the work-group", func() {
+				Expect(wf.WG.Preempting).To(BeTrue())
+				Expect(cu.contextSwitches).To(HaveLen(1))
+			})
+
+			It("should wait for the wavefronts to stop", func() {
+				wf.OutstandingVectorMemAccess = 1
+
+				madeProgress := cu.switchContexts(11)
+
+				Expect(madeProgress).To(BeFalse())
+				Expect(cu.contextSwitches[0].started).To(BeFalse())
+			})
+
+			It("should write the context to the memory", func() {
+				reqs := sendAll(7)
+
+				write := reqs[0].(*mem.WriteReq)
+				Expect(write.Address).To(Equal(uint64(0x10000)))
+				Expect(write.Dst).To(BeIdenticalTo(memPort))
+				Expect(insts.BytesToUint64(write.Data[0:8])).
+					To(Equal(uint64(0x1234)))
+
+				regs := reqs[1].(*mem.WriteReq)
+				Expect(regs.Address).To(Equal(uint64(0x10040)))
+				Expect(insts.BytesToUint32(regs.Data[4:8])).
+					To(Equal(uint32(0xabcd)))
+				Expect(insts.BytesToUint32(regs.Data[20:24])).
+					To(Equal(uint32(0x5678)))
+
+				lds := reqs[6].(*mem.WriteReq)
+				Expect(lds.Address).To(Equal(uint64(0x10180)))
+				Expect(lds.Data[0:8]).
+					To(Equal([]byte{1, 2, 3, 4, 5, 6, 7, 8}))
+			})
+
+			It("should notify the dispatcher after all the writes complete", func() {
+				cu.toACESender = sim.NewBufferedSender(
+					toACE, sim.NewBuffer("ToACESenderBuffer", 1))
+
+				reqs := sendAll(7)
+				for _, req := range reqs {
+					rsp := mem.WriteDoneRspBuilder{}.
+						WithRspTo(req.Meta().ID).
+						Build()
+					Expect(cu.handleContextAccessRsp(rsp)).To(BeTrue())
+				}
+
+				cu.switchContexts(12)
+
+				var msg sim.Msg
+				toACE.EXPECT().
+					Send(gomock.Any()).
+					Do(func(m sim.Msg) { msg = m })
+				cu.sendToACE(13)
+
+				Expect(msg.(*protocol.WGPreemptedMsg).RspTo).
+					To(Equal(mapReq.ID))
+				Expect(cu.WfPools[0].wfs).To(BeEmpty())
+				Expect(cu.contextSwitches).To(BeEmpty())
+			})
+
+			It("should send the in-flight accesses again after a flush", func() {
+				sendAll(2)
+
+				cu.requeueContextAccesses()
+
+				cs := cu.contextSwitches[0]
+				Expect(cs.inflight).To(BeEmpty())
+				Expect(cs.toSend).To(HaveLen(7))
+				Expect(cs.toSend[0].offset).To(Equal(uint64(0)))
+			})
+		})
+
+		Context("when restoring", func() {
+			BeforeEach(func() {
+				wg := grid.WorkGroups[0]
+				mapReq = protocol.MapWGReqBuilder{}.
+					WithWG(wg).
+					AddWf(protocol.WfDispatchLocation{
+						Wavefront: wg.Wavefronts[0],
+					}).
+					WithRestoredContext(0x20000).
+					Build()
+				toACE.EXPECT().Retrieve(gomock.Any()).Return(mapReq)
+				wfDispatcher.EXPECT().DispatchWf(gomock.Any(), gomock.Any(),
+					gomock.Any())
+				engine.EXPECT().Schedule(gomock.Any())
+
+				cu.processInputFromACE(10)
+			})
+
+			It("should not run the wavefronts before the context is read", func() {
+				wf := cu.WfPools[0].wfs[0]
+				Expect(wf.State).To(Equal(wavefront.WfDispatching))
+			})
+
+			It("should restore the context", func() {
+				reqs := sendAll(7)
+				Expect(reqs[0].(*mem.ReadReq).Address).
+					To(Equal(uint64(0x20000)))
+
+				for i, req := range reqs {
+					data := make([]byte, 64)
+					switch i {
+					case 0:
+						copy(data[0:], insts.Uint64ToBytes(0x4321))
+					case 1:
+						copy(data[4:], insts.Uint32ToBytes(0xbeef))
+					case 6:
+						copy(data, []byte{8, 7, 6, 5, 4, 3, 2, 1})
+					}
+
+					rsp := mem.DataReadyRspBuilder{}.
+						WithRspTo(req.Meta().ID).
+						WithData(data).
+						Build()
+					Expect(cu.handleContextAccessRsp(rsp)).To(BeTrue())
+				}
+
+				cu.switchContexts(12)
+
+				wf := cu.WfPools[0].wfs[0]
+				Expect(wf.State).To(Equal(wavefront.WfReady))
+				Expect(wf.PC).To(Equal(uint64(0x4321)))
+				Expect(wf.WG.LDS).
+					To(Equal([]byte{8, 7, 6, 5, 4, 3, 2, 1}))
+
+				data := make([]byte, 4)
+				cu.SRegFile.Read(RegisterAccess{
+					Reg:  insts.SReg(1),
+					Data: data,
+				})
+				Expect(insts.BytesToUint32(data)).To(Equal(uint32(0xbeef)))
+			})
+
+			It("should fetch from the restored PC", func() {
+				wf := cu.WfPools[0].wfs[0]
+				wf.InstBuffer = append(wf.InstBuffer, make([]byte, 64)...)
+				wf.InstBufferStartPC = 0
+				wf.IsFetching = true
+				cu.InFlightInstFetch = append(cu.InFlightInstFetch,
+					&InstFetchReqInfo{Wavefront: wf, Address: 64})
+
+				reqs := sendAll(7)
+				for i, req := range reqs {
+					data := make([]byte, 64)
+					if i == 0 {
+						copy(data, insts.Uint64ToBytes(0x10008))
+					}
+
+					rsp := mem.DataReadyRspBuilder{}.
+						WithRspTo(req.Meta().ID).
+						WithData(data).
+						Build()
+					cu.handleContextAccessRsp(rsp)
+				}
+
+				cu.switchContexts(12)
+
+				Expect(wf.PC).To(Equal(uint64(0x10008)))
+				Expect(wf.InstBuffer).To(BeEmpty())
+				Expect(wf.InstBufferStartPC).To(Equal(uint64(0x10000)))
+				Expect(wf.IsFetching).To(BeFalse())
+				Expect(cu.InFlightInstFetch).To(BeEmpty())
+			})
+		})
+	})
 })
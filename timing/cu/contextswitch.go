@@ -0,0 +1,472 @@
+package cu
+
+import (
+	"encoding/binary"
+	"sort"
+
+	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/akita/v3/tracing"
+	"github.com/sarchlab/mgpusim/v3/insts"
+	"github.com/sarchlab/mgpusim/v3/kernels"
+	"github.com/sarchlab/mgpusim/v3/protocol"
+	"github.com/sarchlab/mgpusim/v3/timing/wavefront"
+)
+
+// contextChunk is a part of the context of a work-group that is transferred
+// with a single memory access. A chunk never crosses a cache line.
+type contextChunk struct {
+	offset uint64
+	size   uint64
+}
+
+// A wgContextSwitch moves the context of a work-group between the compute
+// unit and the memory. Saving starts once all the wavefronts of the
+// work-group stop, while restoring starts as soon as the work-group is
+// mapped.
+type wgContextSwitch struct {
+	wg         *wavefront.WorkGroup
+	isSave     bool
+	preemptReq *protocol.PreemptWGReq
+	addr       uint64
+	image      []byte
+
+	started       bool
+	toSend        []contextChunk
+	inflight      map[string]contextChunk
+	numDoneChunks int
+	numChunks     int
+}
+
+func (cs *wgContextSwitch) split(lineSize uint64) {
+	size := uint64(len(cs.image))
+	for offset := uint64(0); offset < size; {
+		chunkSize := lineSize - (cs.addr+offset)%lineSize
+		if offset+chunkSize > size {
+			chunkSize = size - offset
+		}
+
+		cs.toSend = append(cs.toSend, contextChunk{
+			offset: offset,
+			size:   chunkSize,
+		})
+		offset += chunkSize
+	}
+
+	cs.numChunks = len(cs.toSend)
+	cs.inflight = make(map[string]contextChunk)
+}
+
+func (cs *wgContextSwitch) done() bool {
+	return cs.numDoneChunks == cs.numChunks
+}
+
+const contextSwitchLineSize = 64
+
+func (cu *ComputeUnit) handlePreemptWGReq(
+	now sim.VTimeInSec,
+	req *protocol.PreemptWGReq,
+) bool {
+	tracing.TraceReqReceive(req, cu)
+
+	wg := cu.findWGByMapReqID(req.MapWGReqID)
+	if wg == nil {
+		// The work-group has already completed. The dispatcher learns about
+		// it from the completion message.
+		tracing.TraceReqComplete(req, cu)
+		return true
+	}
+
+	wg.Preempting = true
+	cu.contextSwitches = append(cu.contextSwitches, &wgContextSwitch{
+		wg:         wg,
+		isSave:     true,
+		preemptReq: req,
+		addr:       req.ContextAddr,
+	})
+
+	cu.TickLater(now)
+
+	return true
+}
+
+func (cu *ComputeUnit) findWGByMapReqID(id string) *wavefront.WorkGroup {
+	for _, pool := range cu.WfPools {
+		for _, wf := range pool.wfs {
+			if wf.WG != nil && wf.WG.MapReq != nil && wf.WG.MapReq.ID == id {
+				return wf.WG
+			}
+		}
+	}
+
+	return nil
+}
+
+func (cu *ComputeUnit) startRestoringWG(wg *wavefront.WorkGroup) {
+	for _, wf := range wg.Wfs {
+		wf.State = wavefront.WfDispatching
+	}
+
+	cs := &wgContextSwitch{
+		wg:      wg,
+		addr:    wg.MapReq.ContextAddr,
+		image:   make([]byte, kernels.WGContextByteSize(wg.CodeObject, wg.Packet)),
+		started: true,
+	}
+	cs.split(contextSwitchLineSize)
+
+	cu.contextSwitches = append(cu.contextSwitches, cs)
+}
+
+// switchContexts moves the contexts of the work-groups that are being
+// preempted or restored. Similar to the trap handler that saves the context
+// on real GPUs, the compute unit issues at most one memory access per cycle.
+func (cu *ComputeUnit) switchContexts(now sim.VTimeInSec) bool {
+	if cu.isPaused {
+		return false
+	}
+
+	madeProgress := false
+	sent := false
+
+	for i := 0; i < len(cu.contextSwitches); i++ {
+		cs := cu.contextSwitches[i]
+
+		if !cs.started {
+			if !cu.isWGStopped(cs.wg) {
+				continue
+			}
+
+			if cu.isAllWfInWGCompleted(cs.wg) {
+				cu.removeContextSwitch(i)
+				i--
+				tracing.TraceReqComplete(cs.preemptReq, cu)
+				madeProgress = true
+
+				continue
+			}
+
+			cu.startSavingWG(cs)
+			madeProgress = true
+		}
+
+		if cs.done() {
+			if !cu.finishContextSwitch(now, cs) {
+				continue
+			}
+
+			cu.removeContextSwitch(i)
+			i--
+			madeProgress = true
+
+			continue
+		}
+
+		if !sent && len(cs.toSend) > 0 {
+			sent = cu.sendContextAccess(now, cs)
+			madeProgress = sent || madeProgress
+		}
+	}
+
+	return madeProgress
+}
+
+func (cu *ComputeUnit) removeContextSwitch(i int) {
+	cu.contextSwitches = append(
+		cu.contextSwitches[:i], cu.contextSwitches[i+1:]...)
+}
+
+// isWGStopped tells if none of the wavefronts of the work-group has an
+// instruction or a memory access in flight.
+func (cu *ComputeUnit) isWGStopped(wg *wavefront.WorkGroup) bool {
+	for _, wf := range wg.Wfs {
+		switch wf.State {
+		case wavefront.WfReady, wavefront.WfAtBarrier, wavefront.WfCompleted:
+		default:
+			return false
+		}
+
+		if wf.OutstandingVectorMemAccess > 0 ||
+			wf.OutstandingScalarMemAccess > 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (cu *ComputeUnit) startSavingWG(cs *wgContextSwitch) {
+	cs.image = cu.buildContextImage(cs.wg)
+	cs.started = true
+	cs.split(contextSwitchLineSize)
+
+	for _, wf := range cs.wg.Wfs {
+		wf.InstToIssue = nil
+	}
+}
+
+func (cu *ComputeUnit) sendContextAccess(
+	now sim.VTimeInSec,
+	cs *wgContextSwitch,
+) bool {
+	chunk := cs.toSend[0]
+	addr := cs.addr + chunk.offset
+	pid := cs.wg.MapReq.PID
+
+	var req sim.Msg
+	var id string
+	if cs.isSave {
+		write := mem.WriteReqBuilder{}.
+			WithSendTime(now).
+			WithSrc(cu.ToVectorMem).
+			WithDst(cu.VectorMemModules.Find(addr)).
+			WithPID(pid).
+			WithAddress(addr).
+			WithData(cs.image[chunk.offset : chunk.offset+chunk.size]).
+			Build()
+		req, id = write, write.ID
+	} else {
+		read := mem.ReadReqBuilder{}.
+			WithSendTime(now).
+			WithSrc(cu.ToVectorMem).
+			WithDst(cu.VectorMemModules.Find(addr)).
+			WithPID(pid).
+			WithAddress(addr).
+			WithByteSize(chunk.size).
+			Build()
+		req, id = read, read.ID
+	}
+
+	err := cu.ToVectorMem.Send(req)
+	if err != nil {
+		return false
+	}
+
+	cs.toSend = cs.toSend[1:]
+	cs.inflight[id] = chunk
+	cu.contextAccesses[id] = cs
+
+	return true
+}
+
+// handleContextAccessRsp processes the response if it responds to a context
+// access. It returns false if the response belongs to a memory instruction.
+func (cu *ComputeUnit) handleContextAccessRsp(rsp sim.Msg) bool {
+	var rspTo string
+	var data []byte
+
+	switch rsp := rsp.(type) {
+	case *mem.DataReadyRsp:
+		rspTo, data = rsp.RespondTo, rsp.Data
+	case *mem.WriteDoneRsp:
+		rspTo = rsp.RespondTo
+	default:
+		return false
+	}
+
+	cs, found := cu.contextAccesses[rspTo]
+	if !found {
+		return false
+	}
+
+	delete(cu.contextAccesses, rspTo)
+
+	chunk, inflight := cs.inflight[rspTo]
+	if !inflight {
+		// The access was sent again after a pipeline flush.
+		return true
+	}
+
+	delete(cs.inflight, rspTo)
+	cs.numDoneChunks++
+
+	if !cs.isSave {
+		copy(cs.image[chunk.offset:chunk.offset+chunk.size], data)
+	}
+
+	return true
+}
+
+// requeueContextAccesses sends the in-flight context accesses again, as the
+// responses are lost when the pipeline is flushed.
+func (cu *ComputeUnit) requeueContextAccesses() {
+	for _, cs := range cu.contextSwitches {
+		for _, chunk := range cs.inflight {
+			cs.toSend = append(cs.toSend, chunk)
+		}
+
+		cs.inflight = make(map[string]contextChunk)
+
+		sort.Slice(cs.toSend, func(i, j int) bool {
+			return cs.toSend[i].offset < cs.toSend[j].offset
+		})
+	}
+}
+
+func (cu *ComputeUnit) finishContextSwitch(
+	now sim.VTimeInSec,
+	cs *wgContextSwitch,
+) bool {
+	if !cs.isSave {
+		cu.applyContextImage(cs.wg, cs.image)
+		return true
+	}
+
+	msg := protocol.WGPreemptedMsgBuilder{}.
+		WithSendTime(now).
+		WithSrc(cu.ToACE).
+		WithDst(cs.wg.MapReq.Src).
+		WithRspTo(cs.wg.MapReq.ID).
+		Build()
+
+	if !cu.toACESender.CanSend(1) {
+		return false
+	}
+
+	cu.toACESender.Send(msg)
+
+	cu.clearWGResource(cs.wg)
+	cu.Scheduler.RemoveWG(cs.wg)
+
+	for _, wf := range cs.wg.Wfs {
+		tracing.EndTask(wf.UID, cu)
+	}
+
+	tracing.TraceReqComplete(cs.preemptReq, cu)
+	tracing.TraceReqComplete(cs.wg.MapReq, cu)
+
+	return true
+}
+
+func (cu *ComputeUnit) buildContextImage(wg *wavefront.WorkGroup) []byte {
+	co := wg.CodeObject
+	image := make([]byte, kernels.WGContextByteSize(co, wg.Packet))
+	wfSize := kernels.WfContextByteSize(co)
+
+	for i, wf := range wg.Wfs {
+		wfImage := image[uint64(i)*wfSize : uint64(i+1)*wfSize]
+
+		putWfState(wfImage, wf)
+
+		if co.WFSgprCount > 0 {
+			cu.SRegFile.Read(RegisterAccess{
+				Reg:        insts.SReg(0),
+				RegCount:   int(co.WFSgprCount),
+				WaveOffset: wf.SRegOffset,
+				Data:       wfImage[kernels.WfStateByteSize:],
+			})
+		}
+
+		if co.WIVgprCount > 0 {
+			for lane := 0; lane < 64; lane++ {
+				cu.VRegFile[wf.SIMDID].Read(RegisterAccess{
+					Reg:        insts.VReg(0),
+					RegCount:   int(co.WIVgprCount),
+					LaneID:     lane,
+					WaveOffset: wf.VRegOffset,
+					Data:       wfImage[kernels.WfVGPRContextOffset(co, lane):],
+				})
+			}
+		}
+	}
+
+	copy(image[kernels.WGLDSContextOffset(co, wg.Packet):], wg.LDS)
+
+	return image
+}
+
+func (cu *ComputeUnit) applyContextImage(
+	wg *wavefront.WorkGroup,
+	image []byte,
+) {
+	co := wg.CodeObject
+	wfSize := kernels.WfContextByteSize(co)
+
+	for i, wf := range wg.Wfs {
+		wfImage := image[uint64(i)*wfSize : uint64(i+1)*wfSize]
+
+		if co.WFSgprCount > 0 {
+			cu.SRegFile.Write(RegisterAccess{
+				Reg:        insts.SReg(0),
+				RegCount:   int(co.WFSgprCount),
+				WaveOffset: wf.SRegOffset,
+				Data:       wfImage[kernels.WfStateByteSize:],
+			})
+		}
+
+		if co.WIVgprCount > 0 {
+			for lane := 0; lane < 64; lane++ {
+				cu.VRegFile[wf.SIMDID].Write(RegisterAccess{
+					Reg:        insts.VReg(0),
+					RegCount:   int(co.WIVgprCount),
+					LaneID:     lane,
+					WaveOffset: wf.VRegOffset,
+					Data:       wfImage[kernels.WfVGPRContextOffset(co, lane):],
+				})
+			}
+		}
+
+		getWfState(wfImage, wf)
+		cu.resetInstBuffer(wf)
+
+		if wf.State == wavefront.WfCompleted {
+			tracing.EndTask(wf.UID, cu)
+		}
+	}
+
+	copy(wg.LDS, image[kernels.WGLDSContextOffset(co, wg.Packet):])
+}
+
+// resetInstBuffer makes the wavefront fetch from its restored PC. The
+// instructions fetched before the restore, including the ones still in
+// flight, start at the kernel entry and are dropped.
+func (cu *ComputeUnit) resetInstBuffer(wf *wavefront.Wavefront) {
+	wf.InstBuffer = wf.InstBuffer[:0]
+	wf.InstBufferStartPC = wf.PC &^ 63
+	wf.InstToIssue = nil
+
+	if !wf.IsFetching {
+		return
+	}
+
+	inflight := cu.InFlightInstFetch[:0]
+	for _, info := range cu.InFlightInstFetch {
+		if info.Wavefront != wf {
+			inflight = append(inflight, info)
+		}
+	}
+	cu.InFlightInstFetch = inflight
+	wf.IsFetching = false
+}
+
+// The state of a wavefront is saved as PC (8 bytes), EXEC (8 bytes), VCC (8
+// bytes), M0 (4 bytes), SCC (1 byte), whether the wavefront has completed (1
+// byte), and, from byte 32, MODE (4 bytes). A wavefront that waits at a barrier is saved with the PC
+// of the barrier instruction, so that it waits again after restoring.
+func putWfState(buf []byte, wf *wavefront.Wavefront) {
+	binary.LittleEndian.PutUint64(buf[0:], wf.PC)
+	binary.LittleEndian.PutUint64(buf[8:], wf.EXEC)
+	binary.LittleEndian.PutUint64(buf[16:], wf.VCC)
+	binary.LittleEndian.PutUint32(buf[24:], wf.M0)
+	buf[28] = wf.SCC
+	binary.LittleEndian.PutUint32(buf[32:], wf.MODE)
+
+	if wf.State == wavefront.WfCompleted {
+		buf[29] = 1
+	}
+}
+
+func getWfState(buf []byte, wf *wavefront.Wavefront) {
+	wf.PC = binary.LittleEndian.Uint64(buf[0:])
+	wf.EXEC = binary.LittleEndian.Uint64(buf[8:])
+	wf.VCC = binary.LittleEndian.Uint64(buf[16:])
+	wf.M0 = binary.LittleEndian.Uint32(buf[24:])
+	wf.SCC = buf[28]
+	wf.MODE = binary.LittleEndian.Uint32(buf[32:])
+
+	if buf[29] == 1 {
+		wf.State = wavefront.WfCompleted
+	} else {
+		wf.State = wavefront.WfReady
+	}
+}
@@ -0,0 +1,238 @@
+package cu
+
+import (
+	"github.com/sarchlab/mgpusim/v3/timing/wavefront"
+)
+
+// A CriticalityIssueArbiter implements a criticality-aware policy. The
+// wavefronts of a work-group wait for the slowest one at barriers and at the
+// end of the work-group, so the wavefront that has issued the fewest
+// instructions in its work-group is the most critical. In each SIMD, the most
+// critical wavefronts are selected first, and the oldest wavefront wins ties.
+//
+// The arbiter keeps the wavefronts of each SIMD ordered by criticality. Only
+// the SIMDs whose wavefronts change criticality are reordered, and the pools
+// are only scanned when wavefronts are added or removed.
+type CriticalityIssueArbiter struct {
+	lastSIMDID int
+	nextAge    uint64
+
+	wfs          map[*wavefront.Wavefront]*criticalityWf
+	wgs          map[*wavefront.WorkGroup]*criticalityWG
+	orders       [][]*criticalityWf
+	dirty        []bool
+	poolVersions []uint64
+	orderBuf     []*wavefront.Wavefront
+}
+
+// criticalityWf is what the arbiter knows about a wavefront.
+type criticalityWf struct {
+	wf        *wavefront.Wavefront
+	wg        *criticalityWG
+	simdID    int
+	age       uint64
+	numIssued uint64
+}
+
+// lag returns how many instructions the wavefront is behind the wavefront
+// that has made the most progress in the same work-group.
+func (w *criticalityWf) lag() uint64 {
+	return w.wg.progress - w.numIssued
+}
+
+// moreCritical checks if w should issue before other.
+func (w *criticalityWf) moreCritical(other *criticalityWf) bool {
+	if w.lag() != other.lag() {
+		return w.lag() > other.lag()
+	}
+
+	return w.age < other.age
+}
+
+// criticalityWG tracks the number of instructions that the most advanced
+// wavefront of a work-group has issued.
+type criticalityWG struct {
+	progress uint64
+	wfs      []*criticalityWf
+}
+
+// NewCriticalityIssueArbiter returns a newly created
+// CriticalityIssueArbiter.
+func NewCriticalityIssueArbiter() *CriticalityIssueArbiter {
+	return &CriticalityIssueArbiter{
+		wfs: make(map[*wavefront.Wavefront]*criticalityWf),
+		wgs: make(map[*wavefront.WorkGroup]*criticalityWG),
+	}
+}
+
+// Arbitrate selects the wavefronts to issue.
+func (a *CriticalityIssueArbiter) Arbitrate(
+	wfPools []*WavefrontPool,
+) []*wavefront.Wavefront {
+	a.syncPools(wfPools)
+
+	wfToIssue, simdID := arbitrateSIMDs(wfPools, a.lastSIMDID, a.order)
+	if len(wfToIssue) != 0 {
+		a.lastSIMDID = simdID
+	}
+
+	for _, wf := range wfToIssue {
+		a.countIssue(a.wfs[wf])
+	}
+
+	return wfToIssue
+}
+
+// Criticality returns how many instructions the wavefront is behind the
+// wavefront that has made the most progress in the same work-group.
+func (a *CriticalityIssueArbiter) Criticality(wf *wavefront.Wavefront) uint64 {
+	if w, ok := a.wfs[wf]; ok {
+		return w.lag()
+	}
+
+	if wg, ok := a.wgs[wf.WG]; ok {
+		return wg.progress
+	}
+
+	return 0
+}
+
+func (a *CriticalityIssueArbiter) order(
+	simdID int,
+	_ []*wavefront.Wavefront,
+) []*wavefront.Wavefront {
+	order := a.orders[simdID]
+	if a.dirty[simdID] {
+		sortByCriticality(order)
+		a.dirty[simdID] = false
+	}
+
+	a.orderBuf = a.orderBuf[:0]
+	for _, w := range order {
+		a.orderBuf = append(a.orderBuf, w.wf)
+	}
+
+	return a.orderBuf
+}
+
+// sortByCriticality is an insertion sort, which takes linear time because
+// an issue only moves a few wavefronts out of order.
+func sortByCriticality(order []*criticalityWf) {
+	for i := 1; i < len(order); i++ {
+		w := order[i]
+		j := i - 1
+		for ; j >= 0 && w.moreCritical(order[j]); j-- {
+			order[j+1] = order[j]
+		}
+		order[j+1] = w
+	}
+}
+
+// countIssue updates the criticality after the wavefront issues an
+// instruction.
+func (a *CriticalityIssueArbiter) countIssue(w *criticalityWf) {
+	w.numIssued++
+	a.dirty[w.simdID] = true
+
+	if w.numIssued <= w.wg.progress {
+		return
+	}
+
+	w.wg.progress = w.numIssued
+	for _, other := range w.wg.wfs {
+		a.dirty[other.simdID] = true
+	}
+}
+
+// syncPools starts tracking the new wavefronts and forgets the retired ones
+// in the pools that have changed since the last time.
+func (a *CriticalityIssueArbiter) syncPools(wfPools []*WavefrontPool) {
+	for len(a.orders) < len(wfPools) {
+		a.orders = append(a.orders, nil)
+		a.dirty = append(a.dirty, false)
+		a.poolVersions = append(a.poolVersions, 0)
+	}
+
+	for simdID, wfPool := range wfPools {
+		if wfPool.version != a.poolVersions[simdID] {
+			a.syncPool(simdID, wfPool)
+		}
+	}
+}
+
+func (a *CriticalityIssueArbiter) syncPool(simdID int, wfPool *WavefrontPool) {
+	inPool := make(map[*wavefront.Wavefront]bool, len(wfPool.wfs))
+	for _, wf := range wfPool.wfs {
+		inPool[wf] = true
+	}
+
+	order := make([]*criticalityWf, 0, len(wfPool.wfs))
+	for _, w := range a.orders[simdID] {
+		if inPool[w.wf] {
+			order = append(order, w)
+		} else {
+			a.untrack(w)
+		}
+	}
+
+	for _, wf := range wfPool.wfs {
+		if _, ok := a.wfs[wf]; !ok {
+			order = append(order, a.track(wf, simdID))
+		}
+	}
+
+	a.orders[simdID] = order
+	a.dirty[simdID] = true
+	a.poolVersions[simdID] = wfPool.version
+}
+
+func (a *CriticalityIssueArbiter) track(
+	wf *wavefront.Wavefront,
+	simdID int,
+) *criticalityWf {
+	wg, ok := a.wgs[wf.WG]
+	if !ok {
+		wg = &criticalityWG{}
+		a.wgs[wf.WG] = wg
+	}
+
+	w := &criticalityWf{
+		wf:     wf,
+		wg:     wg,
+		simdID: simdID,
+		age:    a.nextAge,
+	}
+	a.nextAge++
+
+	wg.wfs = append(wg.wfs, w)
+	a.wfs[wf] = w
+
+	return w
+}
+
+// untrack forgets a retired wavefront. The progress of its work-group is
+// recomputed from the remaining wavefronts.
+func (a *CriticalityIssueArbiter) untrack(w *criticalityWf) {
+	delete(a.wfs, w.wf)
+
+	wg := w.wg
+	for i, other := range wg.wfs {
+		if other == w {
+			wg.wfs = append(wg.wfs[:i], wg.wfs[i+1:]...)
+			break
+		}
+	}
+
+	if len(wg.wfs) == 0 {
+		delete(a.wgs, w.wf.WG)
+		return
+	}
+
+	wg.progress = 0
+	for _, other := range wg.wfs {
+		if other.numIssued > wg.progress {
+			wg.progress = other.numIssued
+		}
+		a.dirty[other.simdID] = true
+	}
+}
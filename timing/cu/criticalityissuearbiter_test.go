@@ -0,0 +1,100 @@
+package cu
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/mgpusim/v3/insts"
+	"github.com/sarchlab/mgpusim/v3/timing/wavefront"
+)
+
+var _ = Describe("CriticalityIssueArbiter", func() {
+	var (
+		arbiter *CriticalityIssueArbiter
+		wfPools []*WavefrontPool
+		wg      *wavefront.WorkGroup
+		wfs     []*wavefront.Wavefront
+	)
+
+	BeforeEach(func() {
+		arbiter = NewCriticalityIssueArbiter()
+		wfPools = make([]*WavefrontPool, 0, 4)
+		for i := 0; i < 4; i++ {
+			wfPools = append(wfPools, NewWavefrontPool(10))
+		}
+
+		wg = new(wavefront.WorkGroup)
+		wfs = nil
+		for i := 0; i < 2; i++ {
+			wf := newReadyWf(insts.ExeUnitVALU)
+			wf.WG = wg
+			wfs = append(wfs, wf)
+			wfPools[0].AddWf(wf)
+		}
+	})
+
+	It("should prefer the wf that falls behind in its work-group", func() {
+		wfs[1].State = wavefront.WfRunning
+		arbiter.Arbitrate(wfPools)
+		arbiter.Arbitrate(wfPools)
+
+		wfs[1].State = wavefront.WfReady
+
+		Expect(arbiter.Criticality(wfs[1])).To(Equal(uint64(2)))
+		Expect(arbiter.Arbitrate(wfPools)).
+			To(ConsistOf(BeIdenticalTo(wfs[1])))
+	})
+
+	It("should select the oldest wf when the criticality ties", func() {
+		Expect(arbiter.Arbitrate(wfPools)).
+			To(ConsistOf(BeIdenticalTo(wfs[0])))
+	})
+
+	It("should forget the wfs that leave the pools", func() {
+		wfs[1].State = wavefront.WfRunning
+		arbiter.Arbitrate(wfPools)
+		wfPools[0].RemoveWf(wfs[0])
+		arbiter.Arbitrate(wfPools)
+
+		Expect(arbiter.Criticality(wfs[1])).To(Equal(uint64(0)))
+	})
+
+	It("should reorder the other SIMDs when the work-group advances", func() {
+		other := newReadyWf(insts.ExeUnitVALU)
+		other.WG = new(wavefront.WorkGroup)
+		wfPools[1].AddWf(other)
+		lagging := newReadyWf(insts.ExeUnitVALU)
+		lagging.WG = wg
+		wfPools[1].AddWf(lagging)
+
+		other.State = wavefront.WfRunning
+		lagging.State = wavefront.WfRunning
+		wfs[0].State = wavefront.WfRunning
+		wfs[1].State = wavefront.WfRunning
+		Expect(arbiter.Arbitrate(wfPools)).To(BeEmpty())
+
+		wfs[0].State = wavefront.WfReady
+		arbiter.Arbitrate(wfPools)
+		arbiter.Arbitrate(wfPools)
+
+		wfs[0].State = wavefront.WfRunning
+		other.State = wavefront.WfReady
+		lagging.State = wavefront.WfReady
+
+		Expect(arbiter.Arbitrate(wfPools)).
+			To(ConsistOf(BeIdenticalTo(lagging)))
+	})
+
+	It("should track the wfs that join the pools later", func() {
+		wfs[1].State = wavefront.WfRunning
+		arbiter.Arbitrate(wfPools)
+
+		late := newReadyWf(insts.ExeUnitVALU)
+		late.WG = wg
+		wfPools[0].AddWf(late)
+		wfs[0].State = wavefront.WfRunning
+		arbiter.Arbitrate(wfPools)
+
+		Expect(arbiter.Criticality(late)).To(Equal(uint64(0)))
+		Expect(arbiter.Criticality(wfs[1])).To(Equal(uint64(1)))
+	})
+})
@@ -19,6 +19,8 @@ type Builder struct {
 	vgprCount         []int
 	sgprCount         int
 	log2CachelineSize uint64
+	issuePolicy       IssuePolicy
+	latencyTable      *LatencyTable
 
 	decoder            emu.Decoder
 	scratchpadPreparer ScratchpadPreparer
@@ -26,6 +28,9 @@ type Builder struct {
 
 	visTracer        tracing.Tracer
 	enableVisTracing bool
+
+	addressChecker     emu.AddressChecker
+	memoryFaultHandler emu.MemoryFaultHandler
 }
 
 // MakeBuilder returns a default builder object
@@ -36,6 +41,8 @@ func MakeBuilder() Builder {
 	b.sgprCount = 3200
 	b.vgprCount = []int{16384, 16384, 16384, 16384}
 	b.log2CachelineSize = 6
+	b.issuePolicy = IssuePolicyOldestFirst
+	b.latencyTable = NewLatencyTable()
 
 	return b
 }
@@ -80,6 +87,20 @@ func (b Builder) WithLog2CachelineSize(n uint64) Builder {
 	return b
 }
 
+// WithIssuePolicy sets the policy that the scheduler uses to select the
+// wavefronts that issue instructions.
+func (b Builder) WithIssuePolicy(policy IssuePolicy) Builder {
+	b.issuePolicy = policy
+	return b
+}
+
+// WithLatencyTable sets the timing of the instructions that the SIMD units and
+// the scalar unit execute.
+func (b Builder) WithLatencyTable(t *LatencyTable) Builder {
+	b.latencyTable = t
+	return b
+}
+
 // WithVisTracer adds a tracer to the builder.
 func (b Builder) WithVisTracer(t tracing.Tracer) Builder {
 	b.enableVisTracing = true
@@ -87,6 +108,20 @@ func (b Builder) WithVisTracer(t tracing.Tracer) Builder {
 	return b
 }
 
+// WithAddressChecker makes the vector memory unit check the address that every
+// lane accesses.
+func (b Builder) WithAddressChecker(c emu.AddressChecker) Builder {
+	b.addressChecker = c
+	return b
+}
+
+// WithMemoryFaultHandler sets the handler that is notified of the accesses
+// that the address checker rejects.
+func (b Builder) WithMemoryFaultHandler(h emu.MemoryFaultHandler) Builder {
+	b.memoryFaultHandler = h
+	return b
+}
+
 // Build returns a newly constructed compute unit according to the
 // configuration.
 func (b *Builder) Build(name string) *ComputeUnit {
@@ -117,7 +152,7 @@ func (b *Builder) Build(name string) *ComputeUnit {
 func (b *Builder) equipScheduler(cu *ComputeUnit) {
 	fetchArbitor := new(FetchArbiter)
 	fetchArbitor.InstBufByteSize = 256
-	issueArbitor := new(IssueArbiter)
+	issueArbitor := NewIssueArbiterWithPolicy(b.issuePolicy)
 	scheduler := NewScheduler(cu, fetchArbitor, issueArbitor)
 	cu.Scheduler = scheduler
 }
@@ -129,6 +164,7 @@ func (b *Builder) equipScalarUnits(cu *ComputeUnit) {
 	cu.ScalarDecoder = scalarDecoder
 	scalarUnit := NewScalarUnit(cu, b.scratchpadPreparer, b.alu)
 	scalarUnit.log2CachelineSize = b.log2CachelineSize
+	scalarUnit.LatencyTable = b.latencyTable
 	cu.ScalarUnit = scalarUnit
 	for i := 0; i < b.simdCount; i++ {
 		scalarDecoder.AddExecutionUnit(scalarUnit)
@@ -141,6 +177,7 @@ func (b *Builder) equipSIMDUnits(cu *ComputeUnit) {
 	for i := 0; i < b.simdCount; i++ {
 		name := fmt.Sprintf(b.name+".SIMD%d", i)
 		simdUnit := NewSIMDUnit(cu, name, b.scratchpadPreparer, b.alu)
+		simdUnit.LatencyTable = b.latencyTable
 		if b.enableVisTracing {
 			tracing.CollectTrace(simdUnit, b.visTracer)
 		}
@@ -169,6 +206,8 @@ func (b *Builder) equipVectorMemoryUnit(cu *ComputeUnit) {
 		log2CacheLineSize: b.log2CachelineSize,
 	}
 	vectorMemoryUnit := NewVectorMemoryUnit(cu, b.scratchpadPreparer, coalescer)
+	vectorMemoryUnit.addressChecker = b.addressChecker
+	vectorMemoryUnit.memoryFaultHandler = b.memoryFaultHandler
 	cu.VectorMemUnit = vectorMemoryUnit
 
 	vectorMemoryUnit.postInstructionPipelineBuffer = sim.NewBuffer(
@@ -2,7 +2,9 @@ package cu
 
 import (
 	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/mgpusim/v3/emu"
 	"github.com/sarchlab/mgpusim/v3/insts"
+	"github.com/sarchlab/mgpusim/v3/timing/atomics"
 	"github.com/sarchlab/mgpusim/v3/timing/wavefront"
 )
 
@@ -13,7 +15,12 @@ type defaultCoalescer struct {
 func (c defaultCoalescer) generateMemTransactions(
 	wf *wavefront.Wavefront,
 ) []VectorMemAccessInfo {
-	c.mustBeAFlatLoadOrStore(wf)
+	if c.isAtomicInst(wf.Inst()) {
+		reqs := c.generateReadReqs(wf)
+		return c.generateAtomicTransactions(wf, reqs)
+	}
+
+	c.mustBeALoadOrStore(wf)
 	var transactions []VectorMemAccessInfo
 	if c.isLoadInst(wf.Inst()) {
 		reqs := c.generateReadReqs(wf)
@@ -25,15 +32,40 @@ func (c defaultCoalescer) generateMemTransactions(
 	return transactions
 }
 
-func (c defaultCoalescer) mustBeAFlatLoadOrStore(
+func (c defaultCoalescer) mustBeALoadOrStore(
 	wf *wavefront.Wavefront,
 ) {
-	if wf.Inst().FormatType != insts.FLAT {
-		panic("must be a flat instruction")
+	inst := wf.Inst()
+	switch inst.FormatType {
+	case insts.FLAT:
+		if inst.Opcode < 16 || inst.Opcode > 37 {
+			panic("must be a load or store instruction")
+		}
+	case insts.MUBUF:
+		if inst.Opcode > 7 && (inst.Opcode < 16 || inst.Opcode > 31) {
+			panic("must be a load or store instruction")
+		}
+	case insts.MTBUF:
+		if inst.Opcode > 7 {
+			panic("must be a load or store instruction")
+		}
+	default:
+		panic("must be a flat or buffer instruction")
 	}
+}
 
-	if wf.Inst().Opcode < 16 || wf.Inst().Opcode > 31 {
-		panic("must be a load or store instruction")
+// laneAccesses returns the lanes that access memory, together with the
+// address and the data of each lane.
+func (c defaultCoalescer) laneAccesses(
+	wf *wavefront.Wavefront,
+) (exec uint64, addrs *[64]uint64, data *[256]uint32) {
+	switch wf.Inst().FormatType {
+	case insts.MUBUF, insts.MTBUF:
+		sp := wf.Scratchpad().AsMUBUF()
+		return sp.EXEC & sp.InRange, &sp.ADDR, &sp.DATA
+	default:
+		sp := wf.Scratchpad().AsFlat()
+		return sp.EXEC, &sp.ADDR, &sp.DATA
 	}
 }
 
@@ -50,11 +82,9 @@ func (c defaultCoalescer) mustBeAFlatLoadOrStore(
 func (c defaultCoalescer) generateReadReqs(
 	wf *wavefront.Wavefront,
 ) []*mem.ReadReq {
-	sp := wf.Scratchpad().AsFlat()
-	exec := sp.EXEC
-	addrs := sp.ADDR
+	exec, addrs, _ := c.laneAccesses(wf)
 	reqs := []*mem.ReadReq{}
-	regCount := c.instRegCount(wf.Inst())
+	regCount := c.instRegCount(wf)
 
 	for i := uint(0); i < 64; i++ {
 		if !laneMasked(exec, i) {
@@ -73,11 +103,8 @@ func (c defaultCoalescer) generateReadReqs(
 func (c defaultCoalescer) generateWriteReqs(
 	wf *wavefront.Wavefront,
 ) []*mem.WriteReq {
-	sp := wf.Scratchpad().AsFlat()
-	exec := sp.EXEC
-	addrs := sp.ADDR
+	exec, addrs, data := c.laneAccesses(wf)
 	reqs := []*mem.WriteReq{}
-	data := sp.DATA
 
 	for i := uint(0); i < 64; i++ {
 		if !laneMasked(exec, i) {
@@ -85,7 +112,13 @@ func (c defaultCoalescer) generateWriteReqs(
 		}
 
 		addr := addrs[i]
-		regCount := uint(c.instRegCount(wf.Inst()))
+		if c.isFlatSubDWordStore(wf.Inst()) {
+			c.findOrCreateWriteReq(&reqs, addr,
+				emu.FlatStoreBytes(wf.Inst(), data[i*4]))
+			continue
+		}
+
+		regCount := uint(c.instRegCount(wf))
 		for j := uint(0); j < regCount; j++ {
 			reqData := data[i*4+j]
 			c.findOrCreateWriteReq(&reqs, addr+uint64(j*4),
@@ -132,6 +165,26 @@ func (c defaultCoalescer) generateWriteTransactions(
 	return transactions
 }
 
+// generateAtomicTransactions creates one atomic request per cache line. The
+// lanes that access the cache line are applied in order by the memory system.
+func (c defaultCoalescer) generateAtomicTransactions(
+	wf *wavefront.Wavefront,
+	reqs []*mem.ReadReq,
+) []VectorMemAccessInfo {
+	transactions := []VectorMemAccessInfo{}
+	for _, req := range reqs {
+		transaction := VectorMemAccessInfo{
+			Wavefront: wf,
+			Inst:      wf.DynamicInst(),
+		}
+
+		transaction.Atomic = c.createAtomicReq(&transaction, wf, req.Address)
+
+		transactions = append(transactions, transaction)
+	}
+	return transactions
+}
+
 func (c defaultCoalescer) findOrCreateReadReq(
 	reqs *[]*mem.ReadReq,
 	addr uint64,
@@ -205,11 +258,9 @@ func (c defaultCoalescer) addLaneInfo(
 	transaction *VectorMemAccessInfo,
 	wf *wavefront.Wavefront,
 ) {
-	sp := wf.Scratchpad().AsFlat()
-	exec := sp.EXEC
-	addrs := sp.ADDR
+	exec, addrs, _ := c.laneAccesses(wf)
 	req := transaction.Read
-	regCount := c.instRegCount(wf.Inst())
+	regCount := c.instRegCount(wf)
 
 	for i := uint(0); i < 64; i++ {
 		if !laneMasked(exec, i) {
@@ -232,6 +283,48 @@ func (c defaultCoalescer) addLaneInfo(
 	}
 }
 
+func (c defaultCoalescer) createAtomicReq(
+	transaction *VectorMemAccessInfo,
+	wf *wavefront.Wavefront,
+	lineAddr uint64,
+) *atomics.AtomicReq {
+	exec, addrs, data := c.laneAccesses(wf)
+	atomic, _ := emu.VMemAtomicInfo(wf.Inst())
+	lanes := []atomics.LaneOp{}
+
+	for i := uint(0); i < 64; i++ {
+		if !laneMasked(exec, i) || !c.isInSameCacheLine(addrs[i], lineAddr) {
+			continue
+		}
+
+		offset := c.addrOffsetInCacheLine(addrs[i])
+		src, cmp := emu.VMemAtomicOperands(atomic, data[i*4:i*4+4])
+		lanes = append(lanes, atomics.LaneOp{
+			Offset: offset,
+			Src:    src,
+			Cmp:    cmp,
+		})
+
+		laneInfo := vectorMemAccessLaneInfo{
+			laneID:                int(i),
+			reg:                   wf.Inst().Dst.Register,
+			regCount:              c.instRegCount(wf),
+			addrOffsetInCacheLine: offset,
+		}
+		transaction.laneInfo = append(transaction.laneInfo, laneInfo)
+	}
+
+	builder := atomics.AtomicReqBuilder{}.
+		WithAddress(lineAddr).
+		WithOp(atomic.Op).
+		WithLanes(lanes)
+	if atomic.Is64Bit {
+		builder = builder.With64Bit()
+	}
+
+	return builder.Build()
+}
+
 func (c defaultCoalescer) isInSameCacheLine(addr1, addr2 uint64) bool {
 	return c.cacheLineID(addr1) == c.cacheLineID(addr2)
 }
@@ -245,12 +338,36 @@ func (c defaultCoalescer) addrOffsetInCacheLine(addr uint64) uint64 {
 }
 
 func (c defaultCoalescer) isLoadInst(inst *insts.Inst) bool {
-	return inst.Opcode >= 6 && inst.Opcode <= 23
+	if inst.FormatType == insts.FLAT {
+		return (inst.Opcode >= 6 && inst.Opcode <= 23) ||
+			emu.IsFlatD16Load(inst)
+	}
+
+	return emu.IsBufferLoad(inst)
+}
+
+// isFlatSubDWordStore checks if a FLAT instruction stores a byte or a short,
+// which must not overwrite the other bytes of the dword.
+func (c defaultCoalescer) isFlatSubDWordStore(inst *insts.Inst) bool {
+	return inst.FormatType == insts.FLAT &&
+		inst.Opcode >= 24 && inst.Opcode <= 27
 }
 
-func (c defaultCoalescer) instRegCount(inst *insts.Inst) int {
+func (c defaultCoalescer) isAtomicInst(inst *insts.Inst) bool {
+	_, isAtomic := emu.VMemAtomicInfo(inst)
+	return inst.FormatType == insts.FLAT && isAtomic
+}
+
+// instRegCount returns the number of registers that each lane reads from or
+// writes to the memory.
+func (c defaultCoalescer) instRegCount(wf *wavefront.Wavefront) int {
+	inst := wf.Inst()
+	if inst.FormatType == insts.MUBUF || inst.FormatType == insts.MTBUF {
+		return emu.BufferAccessDWords(inst, wf.Scratchpad().AsMUBUF().SRSRC)
+	}
+
 	switch inst.Opcode {
-	case 16, 17, 18, 19, 20:
+	case 16, 17, 18, 19, 20, 32, 33, 34, 35, 36, 37:
 		return 1
 	case 24, 25, 26, 27, 28:
 		return 1
@@ -260,6 +377,10 @@ func (c defaultCoalescer) instRegCount(inst *insts.Inst) int {
 		return 3
 	case 23, 31:
 		return 4
+	case 64, 65, 66, 67, 68, 69, 70, 71, 72, 73, 74, 75, 76:
+		return 1
+	case 96, 97, 98, 99, 100, 101, 102, 103, 104, 105, 106, 107, 108:
+		return 2
 	default:
 		panic("not supported opcode")
 	}
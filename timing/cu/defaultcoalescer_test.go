@@ -3,7 +3,9 @@ package cu
 import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/sarchlab/mgpusim/v3/emu"
 	"github.com/sarchlab/mgpusim/v3/insts"
+	"github.com/sarchlab/mgpusim/v3/timing/atomics"
 	"github.com/sarchlab/mgpusim/v3/timing/wavefront"
 )
 
@@ -101,4 +103,101 @@ var _ = Describe("Default Coalescer", func() {
 
 		Expect(memTransactions).To(HaveLen(4))
 	})
+
+	It("should only write the bytes that byte and short stores write", func() {
+		inst := insts.NewInst()
+		inst.FormatType = insts.FLAT
+		inst.Opcode = 25 // flat_store_byte_d16_hi
+		wf.SetDynamicInst(wavefront.NewInst(inst))
+
+		sp := wf.Scratchpad().AsFlat()
+		sp.EXEC = 0x1
+		sp.ADDR[0] = 0x1005
+		sp.DATA[0] = 0x11335577
+
+		memTransactions := c.generateMemTransactions(wf)
+
+		Expect(memTransactions).To(HaveLen(1))
+		write := memTransactions[0].Write
+		Expect(write.Data[5]).To(Equal(byte(0x33)))
+		for i, dirty := range write.DirtyMask {
+			Expect(dirty).To(Equal(i == 5))
+		}
+	})
+
+	It("should skip out-of-range lanes of buffer instructions", func() {
+		inst := insts.NewInst()
+		inst.FormatType = insts.MUBUF
+		inst.Opcode = 20 // buffer_load_dword
+		inst.Dst = insts.NewVRegOperand(0, 0, 1)
+		wf.SetDynamicInst(wavefront.NewInst(inst))
+
+		sp := wf.Scratchpad().AsMUBUF()
+		sp.EXEC = 0xffffffffffffffff
+		sp.InRange = 0x00000000ffffffff
+		for i := 0; i < 64; i++ {
+			sp.ADDR[i] = uint64(0x1000 + i*4)
+		}
+
+		memTransactions := c.generateMemTransactions(wf)
+
+		Expect(memTransactions).To(HaveLen(2))
+		Expect(memTransactions[0].laneInfo).To(HaveLen(16))
+		Expect(memTransactions[1].laneInfo).To(HaveLen(16))
+	})
+
+	It("should only read the components that the data format has", func() {
+		inst := insts.NewInst()
+		inst.FormatType = insts.MTBUF
+		inst.Opcode = 3 // tbuffer_load_format_xyzw
+		inst.DataFormat = 11
+		inst.Dst = insts.NewVRegOperand(0, 0, 4)
+		wf.SetDynamicInst(wavefront.NewInst(inst))
+
+		sp := wf.Scratchpad().AsMUBUF()
+		sp.EXEC = 0x1
+		sp.InRange = 0x1
+		sp.ADDR[0] = 0x1038
+
+		memTransactions := c.generateMemTransactions(wf)
+
+		Expect(memTransactions).To(HaveLen(1))
+		Expect(memTransactions[0].laneInfo).To(HaveLen(2))
+	})
+
+	It("should generate atomic transactions", func() {
+		inst := insts.NewInst()
+		inst.FormatType = insts.FLAT
+		inst.Opcode = 97 // flat_atomic_cmpswap_x2
+		inst.Dst = insts.NewVRegOperand(2, 2, 2)
+		wf.SetDynamicInst(wavefront.NewInst(inst))
+
+		sp := wf.Scratchpad().AsFlat()
+		sp.EXEC = 0x3
+		sp.ADDR[0] = 0x1000
+		sp.ADDR[1] = 0x1040
+		sp.DATA[4] = 1
+		sp.DATA[5] = 2
+		sp.DATA[6] = 3
+		sp.DATA[7] = 4
+
+		memTransactions := c.generateMemTransactions(wf)
+
+		Expect(memTransactions).To(HaveLen(2))
+		atomic := memTransactions[1].Atomic
+		Expect(atomic.Address).To(Equal(uint64(0x1040)))
+		Expect(atomic.Op).To(Equal(emu.AtomicCmpSwap))
+		Expect(atomic.Is64Bit).To(BeTrue())
+		Expect(atomic.Lanes).To(Equal([]atomics.LaneOp{{
+			Offset: 0,
+			Src:    0x0000000200000001,
+			Cmp:    0x0000000400000003,
+		}}))
+		Expect(memTransactions[1].laneInfo).To(Equal(
+			[]vectorMemAccessLaneInfo{{
+				laneID:   1,
+				reg:      insts.VReg(2),
+				regCount: 2,
+			}}))
+	})
 })
@@ -48,7 +48,14 @@ func (a *FetchArbiter) canFetchFromWF(wf *wavefront.Wavefront) bool {
 		return false
 	}
 
-	if wf.State == wavefront.WfCompleted {
+	switch wf.State {
+	case wavefront.WfCompleted, wavefront.WfDispatching:
+		// Dispatching wavefronts, including the ones whose context is being
+		// restored, do not know their PC yet.
+		return false
+	}
+
+	if isPreempting(wf) {
 		return false
 	}
 
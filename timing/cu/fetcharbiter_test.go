@@ -50,4 +50,15 @@ var _ = Describe("FetchArbiter", func() {
 		Expect(len(wfs)).To(Equal(1))
 		Expect(wfs[0].LastFetchTime).To(Equal(sim.VTimeInSec(9.5)))
 	})
+
+	It("should not fetch for dispatching wavefronts", func() {
+		wf := new(wavefront.Wavefront)
+		wf.Wavefront = new(kernels.Wavefront)
+		wf.State = wavefront.WfDispatching
+		wfPools[0].AddWf(wf)
+
+		wfs := arbiter.Arbitrate(wfPools)
+
+		Expect(wfs).To(BeEmpty())
+	})
 })
@@ -0,0 +1,61 @@
+package cu
+
+import "github.com/sarchlab/mgpusim/v3/timing/wavefront"
+
+// A GTOIssueArbiter implements the greedy-then-oldest policy. In each SIMD,
+// the wavefront that issued last time has the highest priority. When it
+// cannot issue, the oldest wavefront that can issue is selected.
+type GTOIssueArbiter struct {
+	lastSIMDID int
+	greedyWfs  map[int]*wavefront.Wavefront
+}
+
+// NewGTOIssueArbiter returns a newly created GTOIssueArbiter.
+func NewGTOIssueArbiter() *GTOIssueArbiter {
+	return &GTOIssueArbiter{
+		greedyWfs: make(map[int]*wavefront.Wavefront),
+	}
+}
+
+// Arbitrate selects the wavefronts to issue.
+func (a *GTOIssueArbiter) Arbitrate(
+	wfPools []*WavefrontPool,
+) []*wavefront.Wavefront {
+	wfToIssue, simdID := arbitrateSIMDs(wfPools, a.lastSIMDID, a.order)
+	if len(wfToIssue) != 0 {
+		a.lastSIMDID = simdID
+		a.greedyWfs[simdID] = wfToIssue[0]
+	}
+
+	return wfToIssue
+}
+
+func (a *GTOIssueArbiter) order(
+	simdID int,
+	wfs []*wavefront.Wavefront,
+) []*wavefront.Wavefront {
+	greedy, ok := a.greedyWfs[simdID]
+	if !ok {
+		return wfs
+	}
+
+	ordered := make([]*wavefront.Wavefront, 0, len(wfs))
+	for _, wf := range wfs {
+		if wf == greedy {
+			ordered = append(ordered, wf)
+		}
+	}
+
+	if len(ordered) == 0 {
+		delete(a.greedyWfs, simdID)
+		return wfs
+	}
+
+	for _, wf := range wfs {
+		if wf != greedy {
+			ordered = append(ordered, wf)
+		}
+	}
+
+	return ordered
+}
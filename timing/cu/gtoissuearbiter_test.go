@@ -0,0 +1,65 @@
+package cu
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/mgpusim/v3/insts"
+	"github.com/sarchlab/mgpusim/v3/timing/wavefront"
+)
+
+func newReadyWf(exeUnit insts.ExeUnit) *wavefront.Wavefront {
+	wf := new(wavefront.Wavefront)
+	wf.State = wavefront.WfReady
+	wf.InstToIssue = wavefront.NewInst(insts.NewInst())
+	wf.InstToIssue.ExeUnit = exeUnit
+	return wf
+}
+
+var _ = Describe("GTOIssueArbiter", func() {
+	var (
+		arbiter *GTOIssueArbiter
+		wfPools []*WavefrontPool
+		wfs     []*wavefront.Wavefront
+	)
+
+	BeforeEach(func() {
+		arbiter = NewGTOIssueArbiter()
+		wfPools = make([]*WavefrontPool, 0, 4)
+		for i := 0; i < 4; i++ {
+			wfPools = append(wfPools, NewWavefrontPool(10))
+		}
+
+		wfs = nil
+		for i := 0; i < 3; i++ {
+			wf := newReadyWf(insts.ExeUnitVALU)
+			wfs = append(wfs, wf)
+			wfPools[0].AddWf(wf)
+		}
+	})
+
+	It("should select the oldest wf first", func() {
+		issueCandidate := arbiter.Arbitrate(wfPools)
+
+		Expect(issueCandidate).To(ConsistOf(BeIdenticalTo(wfs[0])))
+	})
+
+	It("should keep selecting the same wf", func() {
+		wfs[1].State = wavefront.WfRunning
+		wfs[0].State = wavefront.WfRunning
+		arbiter.Arbitrate(wfPools)
+
+		wfs[0].State = wavefront.WfReady
+		issueCandidate := arbiter.Arbitrate(wfPools)
+
+		Expect(issueCandidate).To(ConsistOf(BeIdenticalTo(wfs[2])))
+	})
+
+	It("should fall back to the oldest wf when the greedy wf stalls", func() {
+		arbiter.Arbitrate(wfPools)
+
+		wfs[0].State = wavefront.WfRunning
+		issueCandidate := arbiter.Arbitrate(wfPools)
+
+		Expect(issueCandidate).To(ConsistOf(BeIdenticalTo(wfs[1])))
+	})
+})
@@ -23,27 +23,12 @@ func (a *IssueArbiter) Arbitrate(
 		return []*wavefront.Wavefront{}
 	}
 
-	wfToIssue := make([]*wavefront.Wavefront, 0)
-	for i := 0; i < len(wfPools); i++ {
-		simdID := (a.lastSIMDID + i) % len(wfPools)
-
-		typeMask := make([]bool, 7)
-		wfPool := wfPools[simdID]
-		for _, wf := range wfPool.wfs {
-			if wf.State != wavefront.WfReady || wf.InstToIssue == nil {
-				continue
-			}
-
-			if typeMask[wf.InstToIssue.ExeUnit] == false {
-				wfToIssue = append(wfToIssue, wf)
-				typeMask[wf.InstToIssue.ExeUnit] = true
-			}
-		}
-
-		if len(wfToIssue) != 0 {
-			a.lastSIMDID = simdID
-			break
-		}
+	wfToIssue, simdID := arbitrateSIMDs(wfPools, a.lastSIMDID,
+		func(_ int, wfs []*wavefront.Wavefront) []*wavefront.Wavefront {
+			return wfs
+		})
+	if len(wfToIssue) != 0 {
+		a.lastSIMDID = simdID
 	}
 
 	return wfToIssue
@@ -64,3 +49,56 @@ func (a *IssueArbiter) isAllWfPoolsEmpty(wfPools []*WavefrontPool) bool {
 	}
 	return true
 }
+
+// arbitrateSIMDs visits the wavefront pools in a round-robin fashion, starting
+// from the given SIMD. In each pool, the wavefronts are considered in the
+// order that the order function returns, and at most one wavefront is
+// selected for each execution unit. It returns the wavefronts selected from
+// the first pool that has any wavefront to issue, together with the ID of the
+// pool.
+func arbitrateSIMDs(
+	wfPools []*WavefrontPool,
+	startSIMDID int,
+	order func(simdID int, wfs []*wavefront.Wavefront) []*wavefront.Wavefront,
+) ([]*wavefront.Wavefront, int) {
+	for i := 0; i < len(wfPools); i++ {
+		simdID := (startSIMDID + i) % len(wfPools)
+
+		wfToIssue := pickOnePerExeUnit(order(simdID, wfPools[simdID].wfs))
+		if len(wfToIssue) != 0 {
+			return wfToIssue, simdID
+		}
+	}
+
+	return []*wavefront.Wavefront{}, startSIMDID
+}
+
+// pickOnePerExeUnit selects the first ready wavefront for each execution
+// unit.
+func pickOnePerExeUnit(wfs []*wavefront.Wavefront) []*wavefront.Wavefront {
+	wfToIssue := make([]*wavefront.Wavefront, 0)
+	typeMask := make([]bool, 7)
+
+	for _, wf := range wfs {
+		if wf.State != wavefront.WfReady || wf.InstToIssue == nil {
+			continue
+		}
+
+		if isPreempting(wf) {
+			continue
+		}
+
+		if !typeMask[wf.InstToIssue.ExeUnit] {
+			wfToIssue = append(wfToIssue, wf)
+			typeMask[wf.InstToIssue.ExeUnit] = true
+		}
+	}
+
+	return wfToIssue
+}
+
+// isPreempting tells if the wavefront belongs to a work-group that is being
+// preempted, which must not start any new instruction.
+func isPreempting(wf *wavefront.Wavefront) bool {
+	return wf.WG != nil && wf.WG.Preempting
+}
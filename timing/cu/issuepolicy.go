@@ -0,0 +1,51 @@
+package cu
+
+import "log"
+
+// IssuePolicy decides how the scheduler selects the wavefronts that issue
+// instructions.
+type IssuePolicy string
+
+// All the supported issue policies.
+const (
+	// IssuePolicyOldestFirst rotates across the SIMDs and, within a SIMD,
+	// prefers the oldest wavefront.
+	IssuePolicyOldestFirst IssuePolicy = "oldest-first"
+
+	// IssuePolicyGTO keeps issuing from the same wavefront until it stalls
+	// and then falls back to the oldest wavefront.
+	IssuePolicyGTO IssuePolicy = "gto"
+
+	// IssuePolicyLRR rotates the priority across the wavefronts of a SIMD
+	// after every issue.
+	IssuePolicyLRR IssuePolicy = "lrr"
+
+	// IssuePolicyTwoLevel only issues from a small active set of wavefronts.
+	// Wavefronts that wait for memory are moved out of the active set.
+	IssuePolicyTwoLevel IssuePolicy = "two-level"
+
+	// IssuePolicyCriticality prefers the wavefronts that fall behind the
+	// other wavefronts of the same work-group.
+	IssuePolicyCriticality IssuePolicy = "criticality"
+)
+
+// NewIssueArbiterWithPolicy creates an issue arbiter that implements the
+// given policy.
+func NewIssueArbiterWithPolicy(policy IssuePolicy) WfArbiter {
+	switch policy {
+	case IssuePolicyOldestFirst, "":
+		return NewIssueArbiter()
+	case IssuePolicyGTO:
+		return NewGTOIssueArbiter()
+	case IssuePolicyLRR:
+		return NewLRRIssueArbiter()
+	case IssuePolicyTwoLevel:
+		return NewTwoLevelIssueArbiter(defaultActiveSetSize)
+	case IssuePolicyCriticality:
+		return NewCriticalityIssueArbiter()
+	default:
+		log.Panicf("unknown issue policy %s", policy)
+	}
+
+	return nil
+}
@@ -0,0 +1,218 @@
+package cu
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/sarchlab/mgpusim/v3/insts"
+)
+
+// InstTiming is the timing of an instruction in an execution unit.
+type InstTiming struct {
+	// Latency is the number of cycles from when the instruction starts to
+	// execute until its results can be read by the following instructions.
+	Latency int `json:"latency"`
+
+	// IssueInterval is the number of cycles that the execution unit is
+	// occupied by the instruction. The wavefront can issue its next
+	// instruction after the interval, as long as the next instruction does not
+	// depend on the results that are still not available.
+	IssueInterval int `json:"issue_interval"`
+}
+
+type latencyTableKey struct {
+	format insts.FormatType
+	opcode insts.Opcode
+}
+
+// A LatencyTable provides the timing of the instructions executed by the
+// SIMD units and the scalar units. The timing is looked up by the format and
+// the opcode of the instruction. Instructions that are not in the table use
+// the default timing of their instruction class (e.g., transcendental,
+// double-precision, or 32-bit integer multiplication). The cycles of the
+// vector instructions are for a SIMD unit with 16 lanes.
+type LatencyTable struct {
+	entries map[latencyTableKey]InstTiming
+
+	VALU            InstTiming
+	Transcendental  InstTiming
+	DoublePrecision InstTiming
+	IntMul32        InstTiming
+	Scalar          InstTiming
+}
+
+// NewLatencyTable returns a latency table that models the R9 Nano GPU. Most
+// VALU instructions take 4 cycles. Transcendental instructions and 32-bit
+// integer multiplications run at quarter rate, and double-precision
+// instructions run at 1/16 rate. Scalar instructions take 1 cycle.
+func NewLatencyTable() *LatencyTable {
+	return &LatencyTable{
+		entries:         make(map[latencyTableKey]InstTiming),
+		VALU:            InstTiming{Latency: 4, IssueInterval: 4},
+		Transcendental:  InstTiming{Latency: 16, IssueInterval: 16},
+		DoublePrecision: InstTiming{Latency: 64, IssueInterval: 64},
+		IntMul32:        InstTiming{Latency: 16, IssueInterval: 16},
+		Scalar:          InstTiming{Latency: 1, IssueInterval: 1},
+	}
+}
+
+// Set sets the timing of the instructions of the given format and opcode.
+func (t *LatencyTable) Set(
+	format insts.FormatType,
+	opcode insts.Opcode,
+	timing InstTiming,
+) {
+	t.entries[latencyTableKey{format, opcode}] = timing
+}
+
+// Lookup returns the timing of an instruction.
+func (t *LatencyTable) Lookup(inst *insts.Inst) InstTiming {
+	timing, ok := t.entries[latencyTableKey{inst.FormatType, inst.Opcode}]
+	if ok {
+		return timing
+	}
+
+	if inst.ExeUnit == insts.ExeUnitScalar {
+		return t.Scalar
+	}
+
+	name := strings.TrimSuffix(inst.InstName, "_e32")
+	name = strings.TrimSuffix(name, "_e64")
+	switch {
+	case strings.Contains(name, "f64"):
+		return t.DoublePrecision
+	case isTranscendental(name):
+		return t.Transcendental
+	case isIntMul32(name):
+		return t.IntMul32
+	default:
+		return t.VALU
+	}
+}
+
+func isTranscendental(name string) bool {
+	prefixes := []string{
+		"v_exp_", "v_log_", "v_rcp_", "v_rsq_", "v_sqrt_", "v_sin_", "v_cos_",
+	}
+	for _, p := range prefixes {
+		if strings.HasPrefix(name, p) {
+			return true
+		}
+	}
+	return false
+}
+
+func isIntMul32(name string) bool {
+	switch name {
+	case "v_mul_lo_u32", "v_mul_hi_u32", "v_mul_lo_i32", "v_mul_hi_i32",
+		"v_mad_u64_u32", "v_mad_i64_i32":
+		return true
+	}
+	return false
+}
+
+type latencyTableFile struct {
+	VALU            *InstTiming             `json:"valu"`
+	Transcendental  *InstTiming             `json:"transcendental"`
+	DoublePrecision *InstTiming             `json:"double_precision"`
+	IntMul32        *InstTiming             `json:"int_mul32"`
+	Scalar          *InstTiming             `json:"scalar"`
+	Insts           []latencyTableFileEntry `json:"insts"`
+}
+
+type latencyTableFileEntry struct {
+	Format string       `json:"format"`
+	Opcode insts.Opcode `json:"opcode"`
+	InstTiming
+}
+
+// LoadLatencyTable reads a latency table from a JSON file. The file can
+// override the timing of the instruction classes and list the timing of
+// individual instructions, for example:
+//
+//	{
+//	  "transcendental": {"latency": 20, "issue_interval": 16},
+//	  "insts": [
+//	    {"format": "vop2", "opcode": 5, "latency": 8, "issue_interval": 4}
+//	  ]
+//	}
+//
+// The format is the lower-case name of the instruction format. Anything that
+// the file does not mention keeps the timing of NewLatencyTable.
+func LoadLatencyTable(path string) (*LatencyTable, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var f latencyTableFile
+	err = json.Unmarshal(data, &f)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse latency table %s: %w", path, err)
+	}
+
+	t := NewLatencyTable()
+	classes := []struct {
+		src *InstTiming
+		dst *InstTiming
+	}{
+		{f.VALU, &t.VALU},
+		{f.Transcendental, &t.Transcendental},
+		{f.DoublePrecision, &t.DoublePrecision},
+		{f.IntMul32, &t.IntMul32},
+		{f.Scalar, &t.Scalar},
+	}
+	for _, c := range classes {
+		if c.src != nil {
+			*c.dst = *c.src
+		}
+	}
+
+	for _, e := range f.Insts {
+		format, ok := formatTypeByName(e.Format)
+		if !ok {
+			return nil, fmt.Errorf("unknown instruction format %q in %s",
+				e.Format, path)
+		}
+
+		t.Set(format, e.Opcode, e.InstTiming)
+	}
+
+	err = t.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (t *LatencyTable) validate() error {
+	timings := []InstTiming{
+		t.VALU, t.Transcendental, t.DoublePrecision, t.IntMul32, t.Scalar,
+	}
+	for _, timing := range t.entries {
+		timings = append(timings, timing)
+	}
+
+	for _, timing := range timings {
+		if timing.IssueInterval < 1 || timing.Latency < timing.IssueInterval {
+			return fmt.Errorf(
+				"invalid instruction timing %+v, the issue interval must be "+
+					"positive and the latency must not be shorter than "+
+					"the issue interval", timing)
+		}
+	}
+
+	return nil
+}
+
+func formatTypeByName(name string) (insts.FormatType, bool) {
+	for formatType, format := range insts.FormatTable {
+		if format.FormatName == strings.ToLower(name) {
+			return formatType, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,105 @@
+package cu
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/mgpusim/v3/insts"
+)
+
+var _ = Describe("LatencyTable", func() {
+	var (
+		table *LatencyTable
+	)
+
+	newInst := func(
+		format insts.FormatType,
+		opcode insts.Opcode,
+		name string,
+	) *insts.Inst {
+		inst := insts.NewInst()
+		inst.FormatType = format
+		inst.Opcode = opcode
+		inst.InstName = name
+		return inst
+	}
+
+	BeforeEach(func() {
+		table = NewLatencyTable()
+	})
+
+	It("should look up the timing by instruction class", func() {
+		Expect(table.Lookup(newInst(insts.VOP2, 1, "v_add_f32"))).
+			To(Equal(table.VALU))
+		Expect(table.Lookup(newInst(insts.VOP1, 39, "v_sqrt_f32"))).
+			To(Equal(table.Transcendental))
+		Expect(table.Lookup(newInst(insts.VOP3a, 641, "v_mul_f64"))).
+			To(Equal(table.DoublePrecision))
+		Expect(table.Lookup(newInst(insts.VOP3a, 645, "v_mul_lo_u32"))).
+			To(Equal(table.IntMul32))
+		Expect(table.Lookup(newInst(insts.VOP2, 6, "v_mul_u32_u24"))).
+			To(Equal(table.VALU))
+
+		scalar := newInst(insts.SOP2, 0, "s_add_u32")
+		scalar.ExeUnit = insts.ExeUnitScalar
+		Expect(table.Lookup(scalar)).To(Equal(table.Scalar))
+	})
+
+	It("should prefer the timing of the opcode", func() {
+		timing := InstTiming{Latency: 12, IssueInterval: 8}
+		table.Set(insts.VOP2, 1, timing)
+
+		Expect(table.Lookup(newInst(insts.VOP2, 1, "v_add_f32"))).
+			To(Equal(timing))
+		Expect(table.Lookup(newInst(insts.VOP1, 1, "v_mov_b32"))).
+			To(Equal(table.VALU))
+	})
+
+	It("should load from a file", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "latency.json")
+		err := os.WriteFile(path, []byte(`{
+			"transcendental": {"latency": 20, "issue_interval": 16},
+			"insts": [
+				{"format": "vop2", "opcode": 1, "latency": 8, "issue_interval": 4}
+			]
+		}`), 0644)
+		Expect(err).NotTo(HaveOccurred())
+
+		table, err = LoadLatencyTable(path)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(table.Transcendental).
+			To(Equal(InstTiming{Latency: 20, IssueInterval: 16}))
+		Expect(table.Lookup(newInst(insts.VOP2, 1, "v_add_f32"))).
+			To(Equal(InstTiming{Latency: 8, IssueInterval: 4}))
+		Expect(table.VALU).To(Equal(NewLatencyTable().VALU))
+	})
+
+	It("should reject unknown formats", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "latency.json")
+		err := os.WriteFile(path, []byte(`{
+			"insts": [
+				{"format": "vop4", "opcode": 1, "latency": 8, "issue_interval": 4}
+			]
+		}`), 0644)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = LoadLatencyTable(path)
+
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject latency shorter than the issue interval", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "latency.json")
+		err := os.WriteFile(path, []byte(`{
+			"valu": {"latency": 2, "issue_interval": 4}
+		}`), 0644)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = LoadLatencyTable(path)
+
+		Expect(err).To(HaveOccurred())
+	})
+})
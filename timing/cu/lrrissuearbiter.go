@@ -0,0 +1,61 @@
+package cu
+
+import "github.com/sarchlab/mgpusim/v3/timing/wavefront"
+
+// An LRRIssueArbiter implements the loose round-robin policy. Both the SIMDs
+// and the wavefronts in each SIMD take turns. The wavefront that follows the
+// one that issued last time has the highest priority. Wavefronts that cannot
+// issue are skipped.
+type LRRIssueArbiter struct {
+	lastSIMDID int
+	lastWfs    map[int]*wavefront.Wavefront
+}
+
+// NewLRRIssueArbiter returns a newly created LRRIssueArbiter.
+func NewLRRIssueArbiter() *LRRIssueArbiter {
+	return &LRRIssueArbiter{
+		lastSIMDID: -1,
+		lastWfs:    make(map[int]*wavefront.Wavefront),
+	}
+}
+
+// Arbitrate selects the wavefronts to issue.
+func (a *LRRIssueArbiter) Arbitrate(
+	wfPools []*WavefrontPool,
+) []*wavefront.Wavefront {
+	if len(wfPools) == 0 {
+		return []*wavefront.Wavefront{}
+	}
+
+	start := (a.lastSIMDID + 1) % len(wfPools)
+	wfToIssue, simdID := arbitrateSIMDs(wfPools, start, a.order)
+	if len(wfToIssue) != 0 {
+		a.lastSIMDID = simdID
+		a.lastWfs[simdID] = wfToIssue[len(wfToIssue)-1]
+	}
+
+	return wfToIssue
+}
+
+func (a *LRRIssueArbiter) order(
+	simdID int,
+	wfs []*wavefront.Wavefront,
+) []*wavefront.Wavefront {
+	last, ok := a.lastWfs[simdID]
+	if !ok {
+		return wfs
+	}
+
+	for i, wf := range wfs {
+		if wf == last {
+			ordered := make([]*wavefront.Wavefront, 0, len(wfs))
+			ordered = append(ordered, wfs[i+1:]...)
+			ordered = append(ordered, wfs[:i+1]...)
+			return ordered
+		}
+	}
+
+	delete(a.lastWfs, simdID)
+
+	return wfs
+}
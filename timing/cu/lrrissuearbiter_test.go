@@ -0,0 +1,62 @@
+package cu
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/mgpusim/v3/insts"
+	"github.com/sarchlab/mgpusim/v3/timing/wavefront"
+)
+
+var _ = Describe("LRRIssueArbiter", func() {
+	var (
+		arbiter *LRRIssueArbiter
+		wfPools []*WavefrontPool
+		wfs     []*wavefront.Wavefront
+	)
+
+	BeforeEach(func() {
+		arbiter = NewLRRIssueArbiter()
+		wfPools = make([]*WavefrontPool, 0, 4)
+		for i := 0; i < 4; i++ {
+			wfPools = append(wfPools, NewWavefrontPool(10))
+		}
+
+		wfs = nil
+		for i := 0; i < 3; i++ {
+			wf := newReadyWf(insts.ExeUnitVALU)
+			wfs = append(wfs, wf)
+			wfPools[0].AddWf(wf)
+		}
+	})
+
+	It("should rotate across the wfs of a SIMD", func() {
+		Expect(arbiter.Arbitrate(wfPools)).
+			To(ConsistOf(BeIdenticalTo(wfs[0])))
+		Expect(arbiter.Arbitrate(wfPools)).
+			To(ConsistOf(BeIdenticalTo(wfs[1])))
+		Expect(arbiter.Arbitrate(wfPools)).
+			To(ConsistOf(BeIdenticalTo(wfs[2])))
+		Expect(arbiter.Arbitrate(wfPools)).
+			To(ConsistOf(BeIdenticalTo(wfs[0])))
+	})
+
+	It("should skip the wfs that are not ready", func() {
+		arbiter.Arbitrate(wfPools)
+		wfs[1].State = wavefront.WfRunning
+
+		Expect(arbiter.Arbitrate(wfPools)).
+			To(ConsistOf(BeIdenticalTo(wfs[2])))
+	})
+
+	It("should rotate across the SIMDs", func() {
+		wf := newReadyWf(insts.ExeUnitVALU)
+		wfPools[1].AddWf(wf)
+
+		Expect(arbiter.Arbitrate(wfPools)).
+			To(ConsistOf(BeIdenticalTo(wfs[0])))
+		Expect(arbiter.Arbitrate(wfPools)).
+			To(ConsistOf(BeIdenticalTo(wf)))
+		Expect(arbiter.Arbitrate(wfPools)).
+			To(ConsistOf(BeIdenticalTo(wfs[1])))
+	})
+})
@@ -3,6 +3,7 @@ package cu
 import (
 	"github.com/sarchlab/akita/v3/mem/mem"
 	"github.com/sarchlab/mgpusim/v3/insts"
+	"github.com/sarchlab/mgpusim/v3/timing/atomics"
 	"github.com/sarchlab/mgpusim/v3/timing/wavefront"
 )
 
@@ -18,6 +19,7 @@ type VectorMemAccessInfo struct {
 	ID        string
 	Read      *mem.ReadReq
 	Write     *mem.WriteReq
+	Atomic    *atomics.AtomicReq
 	Wavefront *wavefront.Wavefront
 	Inst      *wavefront.Inst
 	laneInfo  []vectorMemAccessLaneInfo
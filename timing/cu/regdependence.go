@@ -0,0 +1,77 @@
+package cu
+
+import (
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/emu"
+	"github.com/sarchlab/mgpusim/v3/insts"
+	"github.com/sarchlab/mgpusim/v3/timing/wavefront"
+)
+
+// operandRegs returns the first register that an operand covers and the
+// number of consecutive registers that it covers.
+func operandRegs(o *insts.Operand) (insts.RegType, int) {
+	if o == nil || o.OperandType != insts.RegOperand || o.Register == nil {
+		return 0, 0
+	}
+
+	reg := o.Register
+	if o.RegCount <= 1 || reg.RegIndex() < 0 {
+		return reg.RegType, 1
+	}
+
+	return reg.RegType, o.RegCount
+}
+
+// markRegsPending records that the results of the instruction are available
+// at the given time.
+func markRegsPending(
+	wf *wavefront.Wavefront,
+	inst *insts.Inst,
+	readyTime sim.VTimeInSec,
+) {
+	if wf.RegReadyTime == nil {
+		wf.RegReadyTime = make([]sim.VTimeInSec, len(insts.Regs))
+	}
+
+	_, dst := emu.MovRelOperands(inst, wf.M0)
+	for _, o := range [...]*insts.Operand{dst, inst.SDst} {
+		first, count := operandRegs(o)
+		for i := 0; i < count; i++ {
+			wf.RegReadyTime[int(first)+i] = readyTime
+		}
+	}
+}
+
+// hasRegDependence checks if the instruction explicitly reads or writes any
+// register that is still waiting for the result of an earlier instruction.
+// The M0-relative moves also wait for M0, which selects their registers.
+func hasRegDependence(
+	wf *wavefront.Wavefront,
+	inst *insts.Inst,
+	now sim.VTimeInSec,
+) bool {
+	if wf.RegReadyTime == nil {
+		return false
+	}
+
+	if emu.IsMovRel(inst) && wf.RegReadyTime[insts.M0] > now {
+		return true
+	}
+
+	src0, dst := emu.MovRelOperands(inst, wf.M0)
+	operands := [...]*insts.Operand{
+		src0, inst.Src1, inst.Src2, dst, inst.SDst,
+		inst.Addr, inst.Data, inst.Data1, inst.Base, inst.Offset,
+	}
+	for _, o := range operands {
+		first, count := operandRegs(o)
+		for i := 0; i < count; i++ {
+			index := int(first) + i
+			if index < len(wf.RegReadyTime) && wf.RegReadyTime[index] > now {
+				return true
+			}
+		}
+	}
+
+	return false
+}
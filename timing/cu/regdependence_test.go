@@ -0,0 +1,69 @@
+package cu
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/mgpusim/v3/insts"
+	"github.com/sarchlab/mgpusim/v3/timing/wavefront"
+)
+
+var _ = Describe("Register dependence", func() {
+	var (
+		wf     *wavefront.Wavefront
+		writer *insts.Inst
+	)
+
+	BeforeEach(func() {
+		wf = new(wavefront.Wavefront)
+		writer = insts.NewInst()
+		writer.Dst = insts.NewVRegOperand(2, 2, 2)
+	})
+
+	readerOf := func(index int) *insts.Inst {
+		inst := insts.NewInst()
+		inst.Src0 = insts.NewVRegOperand(index, index, 1)
+		return inst
+	}
+
+	It("should not depend on anything before any write", func() {
+		Expect(hasRegDependence(wf, readerOf(2), 10)).To(BeFalse())
+	})
+
+	It("should depend on every register of a pending result", func() {
+		markRegsPending(wf, writer, 12)
+
+		Expect(hasRegDependence(wf, readerOf(2), 10)).To(BeTrue())
+		Expect(hasRegDependence(wf, readerOf(3), 10)).To(BeTrue())
+		Expect(hasRegDependence(wf, readerOf(4), 10)).To(BeFalse())
+	})
+
+	It("should not depend on results that are ready", func() {
+		markRegsPending(wf, writer, 12)
+
+		Expect(hasRegDependence(wf, readerOf(3), 12)).To(BeFalse())
+	})
+
+	It("should wait for M0 before an M0-relative move", func() {
+		setM0 := insts.NewInst()
+		setM0.Dst = insts.NewRegOperand(0, insts.M0, 1)
+		markRegsPending(wf, setM0, 12)
+
+		movrels := readerOf(0)
+		movrels.FormatType = insts.VOP1
+		movrels.Opcode = 55
+
+		Expect(hasRegDependence(wf, movrels, 10)).To(BeTrue())
+		Expect(hasRegDependence(wf, readerOf(0), 10)).To(BeFalse())
+	})
+
+	It("should depend on the register that M0 selects", func() {
+		markRegsPending(wf, writer, 12)
+		wf.M0 = 3
+
+		movrels := readerOf(0)
+		movrels.FormatType = insts.VOP1
+		movrels.Opcode = 55
+
+		Expect(hasRegDependence(wf, movrels, 10)).To(BeTrue())
+	})
+})
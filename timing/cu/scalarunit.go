@@ -22,10 +22,14 @@ type ScalarUnit struct {
 	toExec  *wavefront.Wavefront
 	toWrite *wavefront.Wavefront
 
+	cycleLeft int
+	timing    InstTiming
+
 	readBufSize int
 	readBuf     []*mem.ReadReq
 
 	log2CachelineSize uint64
+	LatencyTable      *LatencyTable
 
 	isIdle bool
 }
@@ -43,6 +47,7 @@ func NewScalarUnit(
 	u.alu = alu
 	u.readBufSize = 16
 	u.readBuf = make([]*mem.ReadReq, 0, u.readBufSize)
+	u.LatencyTable = NewLatencyTable()
 	return u
 }
 
@@ -82,6 +87,10 @@ func (u *ScalarUnit) runReadStage(now sim.VTimeInSec) bool {
 
 		u.toExec = u.toRead
 		u.toRead = nil
+		if inst := u.toExec.Inst(); inst != nil {
+			u.timing = u.LatencyTable.Lookup(inst)
+			u.cycleLeft = u.timing.IssueInterval
+		}
 		return true
 	}
 	return false
@@ -91,6 +100,11 @@ func (u *ScalarUnit) runExecStage(now sim.VTimeInSec) bool {
 	if u.toExec == nil {
 		return false
 	}
+	if u.cycleLeft > 1 {
+		u.cycleLeft--
+		return true
+	}
+
 	if u.toWrite == nil {
 		if u.toExec.Inst().FormatType == insts.SMEM {
 			u.executeSMEMInst(now)
@@ -218,6 +232,12 @@ func (u *ScalarUnit) runWriteStage(now sim.VTimeInSec) bool {
 
 	u.scratchpadPreparer.Commit(u.toWrite, u.toWrite)
 
+	extraCycles := u.timing.Latency - u.timing.IssueInterval
+	if extraCycles > 0 {
+		markRegsPending(u.toWrite, u.toWrite.Inst(),
+			u.cu.Freq.NCyclesLater(extraCycles, now))
+	}
+
 	u.cu.logInstTask(now, u.toWrite, u.toWrite.DynamicInst(), true)
 
 	u.cu.UpdatePCAndSetReady(u.toWrite)
@@ -245,4 +265,5 @@ func (u *ScalarUnit) Flush() {
 	u.toExec = nil
 	u.toWrite = nil
 	u.readBuf = nil
+	u.cycleLeft = 0
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/sarchlab/akita/v3/mem/mem"
 	"github.com/sarchlab/akita/v3/sim"
 	"github.com/sarchlab/akita/v3/tracing"
+	"github.com/sarchlab/mgpusim/v3/emu"
 	"github.com/sarchlab/mgpusim/v3/insts"
 	"github.com/sarchlab/mgpusim/v3/protocol"
 	"github.com/sarchlab/mgpusim/v3/timing/wavefront"
@@ -17,6 +18,7 @@ type Scheduler interface {
 	Pause()
 	Resume()
 	Flush()
+	RemoveWG(wg *wavefront.WorkGroup)
 }
 
 // SchedulerImpl implements scheduler
@@ -100,7 +102,7 @@ func (s *SchedulerImpl) DecodeNextInst(now sim.VTimeInSec) bool {
 				continue
 			}
 
-			inst, err := s.cu.Decoder.Decode(
+			inst, err := emu.DecodeForCodeObject(s.cu.Decoder, wf.CodeObject,
 				wf.InstBuffer[wf.PC-wf.InstBufferStartPC:])
 			if err == nil {
 				wf.InstToIssue = wavefront.NewInst(inst)
@@ -173,6 +175,10 @@ func (s *SchedulerImpl) DoIssue(now sim.VTimeInSec) bool {
 				continue
 			}
 
+			if hasRegDependence(wf, wf.InstToIssue.Inst, now) {
+				continue
+			}
+
 			unit := s.getUnitToIssueTo(wf.InstToIssue.ExeUnit)
 			if unit.CanAcceptWave() {
 				wf.SetDynamicInst(wf.InstToIssue)
@@ -501,3 +507,9 @@ func (s *SchedulerImpl) Flush() {
 	s.barrierBuffer = nil
 	s.internalExecuting = nil
 }
+
+// RemoveWG forgets a work-group that leaves the compute unit before it
+// completes.
+func (s *SchedulerImpl) RemoveWG(wg *wavefront.WorkGroup) {
+	s.removeAllWfFromBarrierBuffer(wg)
+}
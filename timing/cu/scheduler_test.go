@@ -210,6 +210,30 @@ var _ = Describe("Scheduler", func() {
 
 	})
 
+	It("should not issue if the source registers are not ready", func() {
+		vectorDecoder.canAccept = true
+
+		wf := new(wavefront.Wavefront)
+		wf.Wavefront = kernels.NewWavefront()
+		wf.State = wavefront.WfReady
+		wf.InstToIssue = wavefront.NewInst(insts.NewInst())
+		wf.InstToIssue.ExeUnit = insts.ExeUnitVALU
+		wf.InstToIssue.Src0 = insts.NewVRegOperand(2, 2, 1)
+		wf.RegReadyTime = make([]sim.VTimeInSec, len(insts.Regs))
+		wf.RegReadyTime[insts.VReg(2).RegType] = 12
+		issueArbitor.wfsToReturn = append(issueArbitor.wfsToReturn,
+			[]*wavefront.Wavefront{wf}, []*wavefront.Wavefront{wf})
+
+		scheduler.DoIssue(10)
+
+		Expect(vectorDecoder.acceptedWave).To(BeEmpty())
+		Expect(wf.State).To(Equal(wavefront.WfReady))
+
+		scheduler.DoIssue(12)
+
+		Expect(vectorDecoder.acceptedWave).To(HaveLen(1))
+	})
+
 	It("should issue internal instruction", func() {
 		wfs := make([]*wavefront.Wavefront, 0)
 		wf := new(wavefront.Wavefront)
@@ -52,10 +52,14 @@ func (p *ScratchpadPreparerImpl) Prepare(
 		p.prepareVOP3a(instEmuState, wf)
 	case insts.VOP3b:
 		p.prepareVOP3b(instEmuState, wf)
+	case insts.VOP3P:
+		p.prepareVOP3P(instEmuState, wf)
 	case insts.VOPC:
 		p.prepareVOPC(instEmuState, wf)
 	case insts.FLAT:
 		p.prepareFlat(instEmuState, wf)
+	case insts.MUBUF, insts.MTBUF:
+		p.prepareMUBUF(instEmuState, wf)
 	case insts.SMEM:
 		p.prepareSMEM(instEmuState, wf)
 	case insts.SOPP:
@@ -77,9 +81,17 @@ func (p *ScratchpadPreparerImpl) prepareSOP1(
 	scratchPad := instEmuState.Scratchpad()
 	layout := scratchPad.AsSOP1()
 
-	p.readOperand(inst.Src0, wf, 0, scratchPad[0:8])
+	src0, _ := emu.MovRelOperands(inst, wf.M0)
+	p.readOperand(src0, wf, 0, scratchPad[0:8])
+	if emu.ReadsDst(inst) {
+		p.readOperand(inst.Dst, wf, 0, scratchPad[8:16])
+	}
 	layout.SCC = wf.SCC
 	layout.EXEC = wf.EXEC
+
+	// The instructions see the address of the next instruction, as the
+	// emulator does.
+	layout.PC = wf.PC + uint64(inst.ByteSize)
 }
 
 func (p *ScratchpadPreparerImpl) prepareSOP2(
@@ -107,9 +119,10 @@ func (p *ScratchpadPreparerImpl) prepareVOP1(
 	layout.EXEC = wf.EXEC
 	layout.VCC = wf.VCC
 
+	src0, _ := emu.MovRelOperands(inst, wf.M0)
 	offset := 528
 	for i := 0; i < 64; i++ {
-		p.readOperand(inst.Src0, wf, i, sp[offset:offset+8])
+		p.readOperand(src0, wf, i, sp[offset:offset+8])
 		offset += 8
 	}
 }
@@ -151,11 +164,18 @@ func (p *ScratchpadPreparerImpl) prepareVOP3a(
 	layout.EXEC = wf.EXEC
 	layout.VCC = wf.VCC
 
+	readsDst := emu.ReadsDst(inst)
+	src0, _ := emu.MovRelOperands(inst, wf.M0)
+	dstOffset := 8
 	src0Offset := 528
 	src1Offset := 1040
 	src2Offset := 1552
 	for i := 0; i < 64; i++ {
-		p.readOperand(inst.Src0, wf, i, sp[src0Offset:src0Offset+8])
+		if readsDst {
+			p.readOperand(inst.Dst, wf, i, sp[dstOffset:dstOffset+8])
+			dstOffset += 8
+		}
+		p.readOperand(src0, wf, i, sp[src0Offset:src0Offset+8])
 		src0Offset += 8
 		p.readOperand(inst.Src1, wf, i, sp[src1Offset:src1Offset+8])
 		src1Offset += 8
@@ -192,6 +212,41 @@ func (p *ScratchpadPreparerImpl) prepareVOP3b(
 	}
 }
 
+// prepareVOP3P uses the VOP3a layout, as the packed instructions have the
+// same operands.
+func (p *ScratchpadPreparerImpl) prepareVOP3P(
+	instEmuState emu.InstEmuState,
+	wf *wavefront.Wavefront,
+) {
+	inst := instEmuState.Inst()
+	sp := instEmuState.Scratchpad()
+	layout := sp.AsVOP3A()
+
+	layout.EXEC = wf.EXEC
+
+	readsDst := emu.ReadsDst(inst)
+	srcs := []*insts.Operand{inst.Src0, inst.Src1, inst.Src2}
+	for i := 0; i < 64; i++ {
+		if readsDst {
+			p.readOperand(inst.Dst, wf, i, sp[8+i*8:16+i*8])
+		}
+
+		for n, src := range srcs {
+			if src == nil {
+				continue
+			}
+
+			offset := 528 + n*512 + i*8
+			if value, ok := emu.VOP3PInlineConstant(inst, n, src); ok {
+				copy(sp[offset:offset+8], insts.Uint64ToBytes(value))
+				continue
+			}
+
+			p.readOperand(src, wf, i, sp[offset:offset+8])
+		}
+	}
+}
+
 func (p *ScratchpadPreparerImpl) prepareVOPC(
 	instEmuState emu.InstEmuState,
 	wf *wavefront.Wavefront,
@@ -221,10 +276,43 @@ func (p *ScratchpadPreparerImpl) prepareFlat(
 
 	layout.EXEC = wf.EXEC
 
+	var sAddr uint64
+	if inst.SAddr != nil {
+		buf := make([]byte, 8)
+		p.readOperand(inst.SAddr, wf, 0, buf)
+		sAddr = insts.BytesToUint64(buf)
+	}
+
+	for i := 0; i < 64; i++ {
+		var vAddr uint64
+		if inst.Addr != nil {
+			p.readOperand(inst.Addr, wf, i, sp[8+i*8:8+i*8+8])
+			vAddr = layout.ADDR[i]
+		}
+
+		layout.ADDR[i] = emu.FlatAddress(inst, vAddr, sAddr)
+		p.readOperand(inst.Data, wf, i, sp[520+i*16:520+i*16+16])
+	}
+}
+
+func (p *ScratchpadPreparerImpl) prepareMUBUF(
+	instEmuState emu.InstEmuState, wf *wavefront.Wavefront,
+) {
+	inst := instEmuState.Inst()
+	sp := instEmuState.Scratchpad()
+	layout := sp.AsMUBUF()
+
+	layout.EXEC = wf.EXEC
+
 	for i := 0; i < 64; i++ {
-		p.readOperand(inst.Addr, wf, i, sp[8+i*8:8+i*8+8])
+		if inst.IdxEn || inst.OffEn {
+			p.readOperand(inst.Addr, wf, i, sp[2568+i*8:2568+i*8+8])
+		}
 		p.readOperand(inst.Data, wf, i, sp[520+i*16:520+i*16+16])
 	}
+
+	p.readOperand(inst.Base, wf, 0, sp[3080:3096])
+	p.readOperand(inst.Offset, wf, 0, sp[3096:3100])
 }
 
 func (p *ScratchpadPreparerImpl) prepareSMEM(
@@ -240,6 +328,12 @@ func (p *ScratchpadPreparerImpl) prepareSMEM(
 
 	p.readOperand(inst.Offset, wf, 0, scratchpad[16:24])
 	p.readOperand(inst.Base, wf, 0, scratchpad[24:32])
+
+	// GFX9 can add an SGPR to the immediate offset.
+	if inst.SOffset != nil {
+		layout := scratchpad.AsSMEM()
+		layout.Offset += uint64(p.readRegAsUint32(inst.SOffset.Register, wf, 0))
+	}
 }
 
 func (p *ScratchpadPreparerImpl) prepareSOPP(
@@ -265,8 +359,13 @@ func (p *ScratchpadPreparerImpl) prepareSOPK(
 	scratchPad := instEmuState.Scratchpad()
 	layout := scratchPad.AsSOPK()
 	layout.SCC = wf.SCC
+	layout.PC = wf.PC + uint64(inst.ByteSize)
+	layout.MODE = wf.MODE
 	p.readOperand(inst.Dst, wf, 0, scratchPad[0:8])
 	p.readOperand(inst.SImm16, wf, 0, scratchPad[8:16])
+	if inst.Src0 != nil {
+		p.readOperand(inst.Src0, wf, 0, scratchPad[32:40])
+	}
 }
 
 func (p *ScratchpadPreparerImpl) prepareSOPC(
@@ -331,10 +430,14 @@ func (p *ScratchpadPreparerImpl) Commit(
 		p.commitVOP3a(instEmuState, wf)
 	case insts.VOP3b:
 		p.commitVOP3b(instEmuState, wf)
+	case insts.VOP3P:
+		p.commitVOP3P(instEmuState, wf)
 	case insts.VOPC:
 		p.commitVOPC(instEmuState, wf)
 	case insts.FLAT:
 		p.commitFlat(instEmuState, wf)
+	case insts.MUBUF, insts.MTBUF:
+		p.commitMUBUF(instEmuState, wf)
 	case insts.SMEM:
 		p.commitSMEM(instEmuState, wf)
 	case insts.SOPP:
@@ -358,9 +461,11 @@ func (p *ScratchpadPreparerImpl) commitSOP1(
 	scratchpad := instEmuState.Scratchpad()
 	layout := scratchpad.AsSOP1()
 
-	p.writeOperand(inst.Dst, wf, 0, scratchpad[8:16])
+	_, dst := emu.MovRelOperands(inst, wf.M0)
+	p.writeOperand(dst, wf, 0, scratchpad[8:16])
 	wf.EXEC = layout.EXEC
 	wf.SCC = layout.SCC
+	p.commitBranchTarget(inst, wf, layout.PC)
 }
 
 func (p *ScratchpadPreparerImpl) commitSOP2(
@@ -384,12 +489,13 @@ func (p *ScratchpadPreparerImpl) commitVOP1(
 	exec := layout.EXEC
 	wf.VCC = layout.VCC
 
+	_, dst := emu.MovRelOperands(inst, wf.M0)
 	for i := 63; i >= 0; i-- {
 		if !laneMasked(exec, uint(i)) {
 			continue
 		}
 		offset := 8 + i*8
-		p.writeOperand(inst.Dst, wf, i, scratchpad[offset:offset+8])
+		p.writeOperand(dst, wf, i, scratchpad[offset:offset+8])
 	}
 }
 
@@ -428,12 +534,13 @@ func (p *ScratchpadPreparerImpl) commitVOP3a(
 	exec := layout.EXEC
 	wf.VCC = layout.VCC
 
+	_, dst := emu.MovRelOperands(inst, wf.M0)
 	for i := 63; i >= 0; i-- {
 		if !laneMasked(exec, uint(i)) {
 			continue
 		}
 		offset := 8 + i*8
-		p.writeOperand(inst.Dst, wf, i, sp[offset:offset+8])
+		p.writeOperand(dst, wf, i, sp[offset:offset+8])
 	}
 }
 
@@ -445,6 +552,9 @@ func (p *ScratchpadPreparerImpl) commitVOP3aCmp(
 	sp := instEmuState.Scratchpad()
 
 	p.writeOperand(inst.Dst, wf, 0, sp[8:16])
+
+	// The v_cmpx instructions also write EXEC.
+	wf.EXEC = sp.AsVOP3A().EXEC
 }
 
 func (p *ScratchpadPreparerImpl) commitVOP3b(
@@ -469,6 +579,24 @@ func (p *ScratchpadPreparerImpl) commitVOP3b(
 	p.writeOperand(inst.SDst, wf, 0, insts.Uint64ToBytes(layout.SDST))
 }
 
+func (p *ScratchpadPreparerImpl) commitVOP3P(
+	instEmuState emu.InstEmuState,
+	wf *wavefront.Wavefront,
+) {
+	inst := instEmuState.Inst()
+	sp := instEmuState.Scratchpad()
+	exec := sp.AsVOP3A().EXEC
+
+	for i := 63; i >= 0; i-- {
+		if !laneMasked(exec, uint(i)) {
+			continue
+		}
+
+		offset := 8 + i*8
+		p.writeOperand(inst.Dst, wf, i, sp[offset:offset+8])
+	}
+}
+
 func (p *ScratchpadPreparerImpl) commitVOPC(
 	instEmuState emu.InstEmuState,
 	wf *wavefront.Wavefront,
@@ -486,6 +614,10 @@ func (p *ScratchpadPreparerImpl) commitFlat(
 	scratchpad := instEmuState.Scratchpad()
 	exec := scratchpad.AsFlat().EXEC
 
+	if atomic, isAtomic := emu.VMemAtomicInfo(inst); isAtomic && !atomic.ReturnPreOp {
+		return
+	}
+
 	if inst.Opcode < 24 || inst.Opcode > 31 { // Skip store instructions
 		for i := 0; i < 64; i++ {
 			if !laneMasked(exec, uint(i)) {
@@ -496,6 +628,27 @@ func (p *ScratchpadPreparerImpl) commitFlat(
 	}
 }
 
+func (p *ScratchpadPreparerImpl) commitMUBUF(
+	instEmuState emu.InstEmuState,
+	wf *wavefront.Wavefront,
+) {
+	inst := instEmuState.Inst()
+	scratchpad := instEmuState.Scratchpad()
+	exec := scratchpad.AsMUBUF().EXEC
+
+	if !emu.IsBufferLoad(inst) {
+		return
+	}
+
+	for i := 0; i < 64; i++ {
+		if !laneMasked(exec, uint(i)) {
+			continue
+		}
+
+		p.writeOperand(inst.Dst, wf, i, scratchpad[1544+i*16:1544+i*16+16])
+	}
+}
+
 func (p *ScratchpadPreparerImpl) commitSMEM(
 	instEmuState emu.InstEmuState,
 	wf *wavefront.Wavefront,
@@ -516,6 +669,24 @@ func (p *ScratchpadPreparerImpl) commitSOPK(
 	scratchpad := instEmuState.Scratchpad()
 	p.writeOperand(inst.Dst, wf, 0, scratchpad[0:8])
 	wf.SCC = scratchpad.AsSOPK().SCC
+	wf.MODE = scratchpad.AsSOPK().MODE
+	p.commitBranchTarget(inst, wf, scratchpad.AsSOPK().PC)
+}
+
+// commitBranchTarget moves the wavefront to the target of the scalar
+// instructions that jump, such as s_setpc_b64. The branch unit moves the PC
+// past the instruction after the commit, so the target is set one
+// instruction earlier.
+func (p *ScratchpadPreparerImpl) commitBranchTarget(
+	inst *insts.Inst,
+	wf *wavefront.Wavefront,
+	target uint64,
+) {
+	if inst.ExeUnit != insts.ExeUnitBranch {
+		return
+	}
+
+	wf.PC = target - uint64(inst.ByteSize)
 }
 
 func (p *ScratchpadPreparerImpl) commitSOPC(
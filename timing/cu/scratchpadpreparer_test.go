@@ -53,6 +53,21 @@ var _ = Describe("ScratchpadPreparer", func() {
 		Expect(sp.SCC).To(Equal(byte(1)))
 	})
 
+	It("should prepare for s_movrels with the source moved by M0", func() {
+		inst := insts.NewInst()
+		inst.FormatType = insts.SOP1
+		inst.Opcode = 42
+		inst.Src0 = insts.NewSRegOperand(4, 4, 1)
+		wf.SetDynamicInst(wavefront.NewInst(inst))
+
+		sp.writeReg(insts.SReg(7), 1, wf, 0, insts.Uint32ToBytes(517))
+		wf.M0 = 3
+
+		sp.Prepare(wf, wf)
+
+		Expect(wf.Scratchpad().AsSOP1().SRC0).To(Equal(uint64(517)))
+	})
+
 	It("should prepare for SOP2", func() {
 		inst := insts.NewInst()
 		inst.FormatType = insts.SOP2
@@ -298,6 +313,26 @@ var _ = Describe("ScratchpadPreparer", func() {
 		Expect(layout.SCC).To(Equal(byte(1)))
 	})
 
+	It("should prepare for s_setreg_imm32_b32", func() {
+		inst := insts.NewInst()
+		inst.FormatType = insts.SOPK
+		inst.Opcode = 20
+		inst.Dst = insts.NewSRegOperand(0, 0, 1)
+		inst.SImm16 = insts.NewIntOperand(0x801, 0x801)
+		inst.Src0 = &insts.Operand{
+			OperandType:     insts.LiteralConstant,
+			LiteralConstant: 3,
+		}
+		wf.SetDynamicInst(wavefront.NewInst(inst))
+		wf.MODE = 0x2f0
+
+		sp.Prepare(wf, wf)
+
+		layout := wf.Scratchpad().AsSOPK()
+		Expect(layout.SRC0).To(Equal(uint64(3)))
+		Expect(layout.MODE).To(Equal(uint32(0x2f0)))
+	})
+
 	It("should prepare for SOPC", func() {
 		inst := insts.NewInst()
 		inst.FormatType = insts.SOPC
@@ -363,6 +398,48 @@ var _ = Describe("ScratchpadPreparer", func() {
 		Expect(sp.readRegAsUint32(insts.SReg(0), wf, 0)).To(Equal(uint32(517)))
 	})
 
+	It("should commit for v_movreld_b32 with the destination moved by M0",
+		func() {
+			inst := insts.NewInst()
+			inst.FormatType = insts.VOP1
+			inst.Opcode = 54
+			inst.Dst = insts.NewVRegOperand(10, 10, 1)
+			wf.SetDynamicInst(wavefront.NewInst(inst))
+			wf.M0 = 2
+
+			layout := wf.Scratchpad().AsVOP1()
+			layout.EXEC = 0x1
+			layout.DST[0] = 517
+
+			sp.Commit(wf, wf)
+
+			Expect(sp.readRegAsUint32(insts.VReg(10), wf, 0)).
+				To(Equal(uint32(0)))
+			Expect(sp.readRegAsUint32(insts.VReg(12), wf, 0)).
+				To(Equal(uint32(517)))
+		})
+
+	It("should commit the branch target of SOP1 branch instructions", func() {
+		inst := insts.NewInst()
+		inst.FormatType = insts.SOP1
+		inst.Opcode = 29
+		inst.ExeUnit = insts.ExeUnitBranch
+		inst.ByteSize = 4
+		inst.Dst = insts.NewSRegOperand(0, 0, 2)
+		inst.Src0 = insts.NewSRegOperand(0, 0, 2)
+		wf.SetDynamicInst(wavefront.NewInst(inst))
+		wf.PC = 0x100
+
+		sp.Prepare(wf, wf)
+		layout := wf.Scratchpad().AsSOP1()
+		Expect(layout.PC).To(Equal(uint64(0x104)))
+
+		layout.PC = 0x200
+		sp.Commit(wf, wf)
+
+		Expect(wf.PC).To(Equal(uint64(0x1fc)))
+	})
+
 	It("should commit for SOP2", func() {
 		inst := insts.NewInst()
 		inst.FormatType = insts.SOP2
@@ -594,11 +671,13 @@ var _ = Describe("ScratchpadPreparer", func() {
 		layout := wf.Scratchpad().AsSOPK()
 		layout.SCC = 1
 		layout.DST = 517
+		layout.MODE = 0x3c0
 
 		sp.Commit(wf, wf)
 
 		Expect(wf.SCC).To(Equal(byte(1)))
 		Expect(sp.readRegAsUint32(insts.SReg(0), wf, 0)).To(Equal(uint32(517)))
+		Expect(wf.MODE).To(Equal(uint32(0x3c0)))
 	})
 
 	It("should commit for SOPP", func() {
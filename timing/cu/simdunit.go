@@ -20,8 +20,10 @@ type SIMDUnit struct {
 
 	toExec    *wavefront.Wavefront
 	cycleLeft int
+	timing    InstTiming
 
 	NumSinglePrecisionUnit int
+	LatencyTable           *LatencyTable
 
 	isIdle bool
 }
@@ -41,6 +43,7 @@ func NewSIMDUnit(
 	u.alu = alu
 
 	u.NumSinglePrecisionUnit = 16
+	u.LatencyTable = NewLatencyTable()
 
 	return u
 }
@@ -60,7 +63,8 @@ func (u *SIMDUnit) IsIdle() bool {
 func (u *SIMDUnit) AcceptWave(wave *wavefront.Wavefront, now sim.VTimeInSec) {
 	u.toExec = wave
 
-	u.cycleLeft = 64 / u.NumSinglePrecisionUnit
+	u.timing = u.scaleTiming(u.LatencyTable.Lookup(wave.Inst()))
+	u.cycleLeft = u.timing.IssueInterval
 	u.logPipelineTask(now, u.toExec.DynamicInst(), false)
 }
 
@@ -83,6 +87,7 @@ func (u *SIMDUnit) runExecStage(now sim.VTimeInSec) bool {
 	u.scratchpadPreparer.Prepare(u.toExec, u.toExec)
 	u.alu.Run(u.toExec)
 	u.scratchpadPreparer.Commit(u.toExec, u.toExec)
+	u.markResultsPending(now)
 	u.cu.UpdatePCAndSetReady(u.toExec)
 
 	u.logPipelineTask(now, u.toExec.DynamicInst(), true)
@@ -92,6 +97,34 @@ func (u *SIMDUnit) runExecStage(now sim.VTimeInSec) bool {
 	return true
 }
 
+// scaleTiming converts the timing in the latency table, which assumes 16 lanes,
+// to the number of lanes of the SIMD unit.
+func (u *SIMDUnit) scaleTiming(t InstTiming) InstTiming {
+	t.Latency = t.Latency * 16 / u.NumSinglePrecisionUnit
+	t.IssueInterval = t.IssueInterval * 16 / u.NumSinglePrecisionUnit
+	if t.IssueInterval < 1 {
+		t.IssueInterval = 1
+	}
+	if t.Latency < t.IssueInterval {
+		t.Latency = t.IssueInterval
+	}
+	return t
+}
+
+// markResultsPending records when the results become available if the
+// latency is longer than the issue interval. The instruction retires from the
+// SIMD unit at the end of the issue interval, so the following instructions
+// that depend on the results still need to wait.
+func (u *SIMDUnit) markResultsPending(now sim.VTimeInSec) {
+	extraCycles := u.timing.Latency - u.timing.IssueInterval
+	if extraCycles <= 0 {
+		return
+	}
+
+	markRegsPending(u.toExec, u.toExec.Inst(),
+		u.cu.Freq.NCyclesLater(extraCycles, now))
+}
+
 // Flush flushes
 func (u *SIMDUnit) Flush() {
 	u.toExec = nil
@@ -3,6 +3,7 @@ package cu
 import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/sarchlab/akita/v3/sim"
 	"github.com/sarchlab/mgpusim/v3/insts"
 	"github.com/sarchlab/mgpusim/v3/timing/wavefront"
 )
@@ -80,6 +81,42 @@ var _ = Describe("SIMD Unit", func() {
 
 	})
 
+	It("should use the latency table", func() {
+		wave := new(wavefront.Wavefront)
+		inst := wavefront.NewInst(insts.NewInst())
+		inst.InstName = "v_sqrt_f32"
+		wave.SetDynamicInst(inst)
+
+		bu.AcceptWave(wave, 10)
+
+		Expect(bu.cycleLeft).To(Equal(16))
+	})
+
+	It("should delay the dependent instructions", func() {
+		cu.Freq = 1
+		bu.LatencyTable = NewLatencyTable()
+		bu.LatencyTable.VALU = InstTiming{Latency: 8, IssueInterval: 4}
+
+		wave := new(wavefront.Wavefront)
+		inst := wavefront.NewInst(insts.NewInst())
+		inst.FormatType = insts.VOP2
+		inst.Dst = insts.NewVRegOperand(3, 3, 1)
+		inst.ByteSize = 4
+		wave.InstBuffer = make([]byte, 256)
+		wave.InstBufferStartPC = 0x100
+		wave.PC = 0x100
+		wave.SetDynamicInst(inst)
+
+		bu.AcceptWave(wave, 10)
+		for i := 0; i < 4; i++ {
+			bu.Run(sim.VTimeInSec(10 + i))
+		}
+
+		Expect(wave.State).To(Equal(wavefront.WfReady))
+		Expect(wave.RegReadyTime[insts.VReg(3).RegType]).
+			To(Equal(sim.VTimeInSec(17)))
+	})
+
 	It("should flush SIMD", func() {
 		wave := new(wavefront.Wavefront)
 		inst := wavefront.NewInst(insts.NewInst())
@@ -0,0 +1,139 @@
+package cu
+
+import "github.com/sarchlab/mgpusim/v3/timing/wavefront"
+
+const defaultActiveSetSize = 4
+
+// A TwoLevelIssueArbiter implements the two-level policy. Each SIMD only
+// issues from a small active set of wavefronts, in a round-robin fashion. A
+// wavefront that waits for memory leaves the active set, and the oldest
+// pending wavefronts that do not wait for memory take its place.
+type TwoLevelIssueArbiter struct {
+	lastSIMDID    int
+	activeSetSize int
+	activeSets    map[int][]*wavefront.Wavefront
+	poolVersions  map[int]uint64
+	rotateBuf     []*wavefront.Wavefront
+}
+
+// NewTwoLevelIssueArbiter returns a newly created TwoLevelIssueArbiter that
+// keeps at most activeSetSize wavefronts in the active set of each SIMD.
+func NewTwoLevelIssueArbiter(activeSetSize int) *TwoLevelIssueArbiter {
+	if activeSetSize <= 0 {
+		panic("active set size must be positive")
+	}
+
+	return &TwoLevelIssueArbiter{
+		activeSetSize: activeSetSize,
+		activeSets:    make(map[int][]*wavefront.Wavefront),
+		poolVersions:  make(map[int]uint64),
+		rotateBuf:     make([]*wavefront.Wavefront, 0, activeSetSize),
+	}
+}
+
+// Arbitrate selects the wavefronts to issue.
+func (a *TwoLevelIssueArbiter) Arbitrate(
+	wfPools []*WavefrontPool,
+) []*wavefront.Wavefront {
+	for simdID, wfPool := range wfPools {
+		a.updateActiveSet(simdID, wfPool)
+	}
+
+	wfToIssue, simdID := arbitrateSIMDs(wfPools, a.lastSIMDID,
+		func(simdID int, _ []*wavefront.Wavefront) []*wavefront.Wavefront {
+			return a.activeSets[simdID]
+		})
+	if len(wfToIssue) != 0 {
+		a.lastSIMDID = simdID
+		a.rotate(simdID, wfToIssue)
+	}
+
+	return wfToIssue
+}
+
+// ActiveSet returns the wavefronts in the active set of a SIMD.
+func (a *TwoLevelIssueArbiter) ActiveSet(simdID int) []*wavefront.Wavefront {
+	return a.activeSets[simdID]
+}
+
+// updateActiveSet updates the active set in place. The retired wavefronts are
+// only looked for when the pool has changed, and the pool is only scanned
+// when the active set has room for more wavefronts.
+func (a *TwoLevelIssueArbiter) updateActiveSet(
+	simdID int,
+	wfPool *WavefrontPool,
+) {
+	active, ok := a.activeSets[simdID]
+	if !ok {
+		active = make([]*wavefront.Wavefront, 0, a.activeSetSize)
+	}
+
+	poolChanged := wfPool.version != a.poolVersions[simdID]
+	a.poolVersions[simdID] = wfPool.version
+
+	n := 0
+	for _, wf := range active {
+		if isWaitingForMemory(wf) {
+			continue
+		}
+
+		if poolChanged && !containsWf(wfPool.wfs, wf) {
+			continue
+		}
+
+		active[n] = wf
+		n++
+	}
+	active = active[:n]
+
+	for _, wf := range wfPool.wfs {
+		if len(active) >= a.activeSetSize {
+			break
+		}
+
+		if !isWaitingForMemory(wf) && !containsWf(active, wf) {
+			active = append(active, wf)
+		}
+	}
+
+	a.activeSets[simdID] = active
+}
+
+// rotate moves the wavefronts that issue to the end of the active set so that
+// the other active wavefronts have a higher priority next time.
+func (a *TwoLevelIssueArbiter) rotate(
+	simdID int,
+	issued []*wavefront.Wavefront,
+) {
+	active := a.activeSets[simdID]
+
+	rotated := a.rotateBuf[:0]
+	for _, wf := range active {
+		if !containsWf(issued, wf) {
+			rotated = append(rotated, wf)
+		}
+	}
+	for _, wf := range active {
+		if containsWf(issued, wf) {
+			rotated = append(rotated, wf)
+		}
+	}
+
+	copy(active, rotated)
+	a.rotateBuf = rotated
+}
+
+func containsWf(wfs []*wavefront.Wavefront, wf *wavefront.Wavefront) bool {
+	for _, w := range wfs {
+		if w == wf {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isWaitingForMemory(wf *wavefront.Wavefront) bool {
+	return wf.OutstandingVectorMemAccess > 0 ||
+		wf.OutstandingScalarMemAccess > 0
+}
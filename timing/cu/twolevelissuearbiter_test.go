@@ -0,0 +1,70 @@
+package cu
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/mgpusim/v3/insts"
+	"github.com/sarchlab/mgpusim/v3/timing/wavefront"
+)
+
+var _ = Describe("TwoLevelIssueArbiter", func() {
+	var (
+		arbiter *TwoLevelIssueArbiter
+		wfPools []*WavefrontPool
+		wfs     []*wavefront.Wavefront
+	)
+
+	BeforeEach(func() {
+		arbiter = NewTwoLevelIssueArbiter(2)
+		wfPools = make([]*WavefrontPool, 0, 4)
+		for i := 0; i < 4; i++ {
+			wfPools = append(wfPools, NewWavefrontPool(10))
+		}
+
+		wfs = nil
+		for i := 0; i < 4; i++ {
+			wf := newReadyWf(insts.ExeUnitVALU)
+			wfs = append(wfs, wf)
+			wfPools[0].AddWf(wf)
+		}
+	})
+
+	It("should only issue from the active set", func() {
+		Expect(arbiter.Arbitrate(wfPools)).
+			To(ConsistOf(BeIdenticalTo(wfs[0])))
+		Expect(arbiter.Arbitrate(wfPools)).
+			To(ConsistOf(BeIdenticalTo(wfs[1])))
+		Expect(arbiter.Arbitrate(wfPools)).
+			To(ConsistOf(BeIdenticalTo(wfs[0])))
+		Expect(arbiter.ActiveSet(0)).To(HaveLen(2))
+	})
+
+	It("should replace the wfs that wait for memory", func() {
+		arbiter.Arbitrate(wfPools)
+		wfs[0].OutstandingVectorMemAccess = 1
+
+		arbiter.Arbitrate(wfPools)
+
+		Expect(arbiter.ActiveSet(0)).
+			NotTo(ContainElement(BeIdenticalTo(wfs[0])))
+		Expect(arbiter.ActiveSet(0)).
+			To(ContainElement(BeIdenticalTo(wfs[2])))
+	})
+
+	It("should issue nothing if the active wfs are not ready", func() {
+		wfs[0].State = wavefront.WfRunning
+		wfs[1].State = wavefront.WfRunning
+
+		Expect(arbiter.Arbitrate(wfPools)).To(BeEmpty())
+	})
+
+	It("should replace the wfs that leave the pool", func() {
+		arbiter.Arbitrate(wfPools)
+		wfPools[0].RemoveWf(wfs[0])
+
+		arbiter.Arbitrate(wfPools)
+
+		Expect(arbiter.ActiveSet(0)).
+			To(ConsistOf(BeIdenticalTo(wfs[1]), BeIdenticalTo(wfs[2])))
+	})
+})
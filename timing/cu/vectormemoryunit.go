@@ -6,6 +6,7 @@ import (
 	"github.com/sarchlab/akita/v3/pipelining"
 	"github.com/sarchlab/akita/v3/sim"
 	"github.com/sarchlab/akita/v3/tracing"
+	"github.com/sarchlab/mgpusim/v3/emu"
 	"github.com/sarchlab/mgpusim/v3/insts"
 	"github.com/sarchlab/mgpusim/v3/timing/wavefront"
 )
@@ -36,6 +37,9 @@ type VectorMemoryUnit struct {
 	transactionPipeline           pipelining.Pipeline
 	postTransactionPipelineBuffer sim.Buffer
 
+	addressChecker     emu.AddressChecker
+	memoryFaultHandler emu.MemoryFaultHandler
+
 	isIdle bool
 }
 
@@ -122,6 +126,11 @@ func (u *VectorMemoryUnit) execute(now sim.VTimeInSec) (madeProgress bool) {
 		if !ok {
 			return false
 		}
+	case insts.MUBUF, insts.MTBUF:
+		ok := u.executeBufferInsts(now, wave)
+		if !ok {
+			return false
+		}
 	default:
 		log.Panicf("running inst %s in vector memory unit is not supported", inst.String(nil))
 	}
@@ -138,11 +147,21 @@ func (u *VectorMemoryUnit) executeFlatInsts(
 	wavefront *wavefront.Wavefront,
 ) bool {
 	inst := wavefront.DynamicInst()
+	// The scratch segment needs the private memory of the work-items, which
+	// is not modeled. The kernel loader rejects the kernels that use it.
+	if inst.Seg == insts.FlatSegmentScratch {
+		log.Panicf("Scratch instruction %s is not supported.", inst.InstName)
+	}
+
 	switch inst.Opcode {
-	case 16, 17, 18, 19, 20, 21, 22, 23: // FLAT_LOAD_BYTE
+	case 16, 17, 18, 19, 20, 21, 22, 23, // FLAT_LOAD_BYTE
+		32, 33, 34, 35, 36, 37: // The GFX9 d16 loads
 		return u.executeFlatLoad(now, wavefront)
 	case 24, 25, 26, 27, 28, 29, 30, 31:
 		return u.executeFlatStore(now, wavefront)
+	case 64, 65, 66, 67, 68, 69, 70, 71, 72, 73, 74, 75, 76,
+		96, 97, 98, 99, 100, 101, 102, 103, 104, 105, 106, 107, 108:
+		return u.executeFlatAtomic(now, wavefront)
 	default:
 		log.Panicf("Opcode %d for format FLAT is not supported.", inst.Opcode)
 	}
@@ -155,6 +174,95 @@ func (u *VectorMemoryUnit) executeFlatLoad(
 	wave *wavefront.Wavefront,
 ) bool {
 	u.scratchpadPreparer.Prepare(wave, wave)
+	return u.issueLoadTransactions(now, wave)
+}
+
+func (u *VectorMemoryUnit) executeFlatStore(
+	now sim.VTimeInSec,
+	wave *wavefront.Wavefront,
+) bool {
+	u.scratchpadPreparer.Prepare(wave, wave)
+	return u.issueStoreTransactions(now, wave)
+}
+
+func (u *VectorMemoryUnit) executeFlatAtomic(
+	now sim.VTimeInSec,
+	wave *wavefront.Wavefront,
+) bool {
+	u.scratchpadPreparer.Prepare(wave, wave)
+	return u.issueAtomicTransactions(now, wave)
+}
+
+func (u *VectorMemoryUnit) executeBufferInsts(
+	now sim.VTimeInSec,
+	wave *wavefront.Wavefront,
+) bool {
+	inst := wave.DynamicInst()
+
+	if inst.FormatType == insts.MUBUF && (inst.Opcode == 62 || inst.Opcode == 63) {
+		// BUFFER_WBINVL1 and BUFFER_WBINVL1_VOL do not generate transactions.
+		u.cu.logInstTask(now, wave, inst, true)
+		return true
+	}
+
+	if inst.LDS {
+		log.Panicf("MUBUF loads into LDS are not supported")
+	}
+
+	u.scratchpadPreparer.Prepare(wave, wave)
+	emu.CalculateBufferAddresses(inst.Inst, wave.Scratchpad().AsMUBUF())
+
+	if emu.IsBufferLoad(inst.Inst) {
+		u.fillUnloadedRegisters(wave)
+		return u.issueLoadTransactions(now, wave)
+	}
+
+	return u.issueStoreTransactions(now, wave)
+}
+
+// fillUnloadedRegisters writes the destination registers that a buffer load
+// does not read from the memory. The active lanes whose accesses fall outside
+// of the buffer get 0. For format loads, the components that the data format
+// does not have are filled as well.
+func (u *VectorMemoryUnit) fillUnloadedRegisters(wave *wavefront.Wavefront) {
+	inst := wave.Inst()
+	sp := wave.Scratchpad().AsMUBUF()
+	fill := emu.BufferLoadFill(inst, sp.SRSRC)
+	numRead := inst.Dst.RegCount - len(fill)
+
+	for i := 0; i < 64; i++ {
+		if !laneMasked(sp.EXEC, uint(i)) {
+			continue
+		}
+
+		access := RegisterAccess{}
+		access.WaveOffset = wave.VRegOffset
+		access.Reg = inst.Dst.Register
+		access.RegCount = inst.Dst.RegCount
+		access.LaneID = i
+		access.Data = make([]byte, 4*inst.Dst.RegCount)
+
+		if laneMasked(sp.InRange, uint(i)) {
+			if len(fill) == 0 {
+				continue
+			}
+
+			access.Reg = insts.VReg(inst.Dst.Register.RegIndex() + numRead)
+			access.RegCount = len(fill)
+			access.Data = make([]byte, 4*len(fill))
+			for j, v := range fill {
+				copy(access.Data[j*4:], insts.Uint32ToBytes(v))
+			}
+		}
+
+		u.cu.VRegFile[wave.SIMDID].Write(access)
+	}
+}
+
+func (u *VectorMemoryUnit) issueLoadTransactions(
+	now sim.VTimeInSec,
+	wave *wavefront.Wavefront,
+) bool {
 	transactions := u.coalescer.generateMemTransactions(wave)
 
 	if len(transactions) == 0 {
@@ -172,8 +280,12 @@ func (u *VectorMemoryUnit) executeFlatLoad(
 		return false
 	}
 
+	u.checkAddresses(now, wave)
+
 	wave.OutstandingVectorMemAccess++
-	wave.OutstandingScalarMemAccess++
+	if wave.Inst().FormatType == insts.FLAT {
+		wave.OutstandingScalarMemAccess++
+	}
 
 	for i, t := range transactions {
 		u.cu.InFlightVectorMemAccess = append(u.cu.InFlightVectorMemAccess, t)
@@ -191,11 +303,10 @@ func (u *VectorMemoryUnit) executeFlatLoad(
 	return true
 }
 
-func (u *VectorMemoryUnit) executeFlatStore(
+func (u *VectorMemoryUnit) issueStoreTransactions(
 	now sim.VTimeInSec,
 	wave *wavefront.Wavefront,
 ) bool {
-	u.scratchpadPreparer.Prepare(wave, wave)
 	transactions := u.coalescer.generateMemTransactions(wave)
 
 	if len(transactions) == 0 {
@@ -213,8 +324,12 @@ func (u *VectorMemoryUnit) executeFlatStore(
 		return false
 	}
 
+	u.checkAddresses(now, wave)
+
 	wave.OutstandingVectorMemAccess++
-	wave.OutstandingScalarMemAccess++
+	if wave.Inst().FormatType == insts.FLAT {
+		wave.OutstandingScalarMemAccess++
+	}
 
 	for i, t := range transactions {
 		u.cu.InFlightVectorMemAccess = append(u.cu.InFlightVectorMemAccess, t)
@@ -231,6 +346,112 @@ func (u *VectorMemoryUnit) executeFlatStore(
 	return true
 }
 
+func (u *VectorMemoryUnit) issueAtomicTransactions(
+	now sim.VTimeInSec,
+	wave *wavefront.Wavefront,
+) bool {
+	transactions := u.coalescer.generateMemTransactions(wave)
+
+	if len(transactions) == 0 {
+		u.cu.logInstTask(
+			now,
+			wave,
+			wave.DynamicInst(),
+			true,
+		)
+		return true
+	}
+
+	if len(transactions)+len(u.cu.InFlightVectorMemAccess) >
+		u.cu.InFlightVectorMemAccessLimit {
+		return false
+	}
+
+	u.checkAddresses(now, wave)
+
+	wave.OutstandingVectorMemAccess++
+	wave.OutstandingScalarMemAccess++
+
+	for i, t := range transactions {
+		u.cu.InFlightVectorMemAccess = append(u.cu.InFlightVectorMemAccess, t)
+		if i != len(transactions)-1 {
+			t.Atomic.CanWaitForCoalesce = true
+		}
+
+		lowModule := u.cu.VectorMemModules.Find(t.Atomic.Address)
+		t.Atomic.Dst = lowModule
+		t.Atomic.Src = u.cu.ToVectorMem
+		t.Atomic.PID = wave.PID()
+		u.transactionsWaiting = append(u.transactionsWaiting, t)
+	}
+
+	return true
+}
+
+// checkAddresses reports the lanes whose accesses the address checker
+// rejects. The accesses are still sent to the memory.
+func (u *VectorMemoryUnit) checkAddresses(
+	now sim.VTimeInSec,
+	wave *wavefront.Wavefront,
+) {
+	if u.addressChecker == nil {
+		return
+	}
+
+	inst := wave.Inst()
+	exec, addrs := u.laneAddresses(wave)
+	byteSize := emu.VMemLaneByteSize(inst)
+	_, isAtomic := emu.VMemAtomicInfo(inst)
+	isWrite := isAtomic || !isVectorMemLoad(inst)
+
+	for i := 0; i < 64; i++ {
+		if !laneMasked(exec, uint(i)) {
+			continue
+		}
+
+		err := u.addressChecker.CheckAccess(wave.PID(), addrs[i], byteSize)
+		if err == nil {
+			continue
+		}
+
+		fault := emu.NewMemoryFault(u.cu.Name(), wave.Wavefront, wave.PC,
+			inst)
+		fault.Lane = i
+		fault.VAddr = addrs[i]
+		fault.IsWrite = isWrite
+		fault.Err = err
+
+		if u.memoryFaultHandler == nil {
+			log.Panic(fault)
+		}
+
+		u.memoryFaultHandler.HandleMemoryFault(now, fault)
+	}
+}
+
+// laneAddresses returns the lanes that access memory and their addresses.
+func (u *VectorMemoryUnit) laneAddresses(
+	wave *wavefront.Wavefront,
+) (exec uint64, addrs *[64]uint64) {
+	switch wave.Inst().FormatType {
+	case insts.MUBUF, insts.MTBUF:
+		sp := wave.Scratchpad().AsMUBUF()
+		return sp.EXEC & sp.InRange, &sp.ADDR
+	default:
+		sp := wave.Scratchpad().AsFlat()
+		return sp.EXEC, &sp.ADDR
+	}
+}
+
+func isVectorMemLoad(inst *insts.Inst) bool {
+	if inst.FormatType == insts.FLAT {
+		return (inst.Opcode >= 16 && inst.Opcode <= 23) ||
+			emu.IsFlatD16Load(inst)
+	}
+
+	return emu.IsBufferLoad(inst)
+}
+
 func (u *VectorMemoryUnit) sendRequest(now sim.VTimeInSec) bool {
 	item := u.postTransactionPipelineBuffer.Peek()
 	if item == nil {
@@ -239,9 +460,12 @@ func (u *VectorMemoryUnit) sendRequest(now sim.VTimeInSec) bool {
 
 	var req sim.Msg
 	info := item.(VectorMemAccessInfo)
-	if info.Read != nil {
+	switch {
+	case info.Read != nil:
 		req = info.Read
-	} else {
+	case info.Atomic != nil:
+		req = info.Atomic
+	default:
 		req = info.Write
 	}
 
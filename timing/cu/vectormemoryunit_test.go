@@ -1,13 +1,18 @@
 package cu
 
 import (
+	"errors"
+
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/mem/vm"
 	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/emu"
 	"github.com/sarchlab/mgpusim/v3/insts"
 	"github.com/sarchlab/mgpusim/v3/kernels"
+	"github.com/sarchlab/mgpusim/v3/timing/atomics"
 	"github.com/sarchlab/mgpusim/v3/timing/wavefront"
 )
 
@@ -138,6 +143,115 @@ var _ = Describe("Vector Memory Unit", func() {
 		Expect(vecMemUnit.transactionsWaiting).To(HaveLen(4))
 	})
 
+	It("should report the lanes that the address checker rejects", func() {
+		checker := &rangeChecker{start: 0x100, end: 0x140}
+		handler := &faultRecorder{}
+		vecMemUnit.addressChecker = checker
+		vecMemUnit.memoryFaultHandler = handler
+
+		kernelWave := kernels.NewWavefront()
+		wave := wavefront.NewWavefront(kernelWave)
+		wave.PC = 0x2000
+		inst := wavefront.NewInst(insts.NewInst())
+		inst.FormatType = insts.FLAT
+		inst.Format = insts.FormatTable[insts.FLAT]
+		inst.Opcode = 28
+		inst.InstName = "flat_store_dword"
+		inst.Addr = insts.NewVRegOperand(0, 0, 2)
+		inst.Data = insts.NewVRegOperand(2, 2, 1)
+		wave.SetDynamicInst(inst)
+
+		layout := wave.Scratchpad().AsFlat()
+		layout.EXEC = 0x3
+		layout.ADDR[0] = 0x13c
+		layout.ADDR[1] = 0x140
+
+		write := mem.WriteReqBuilder{}.WithAddress(0x100).Build()
+		coalescer.EXPECT().generateMemTransactions(wave).
+			Return([]VectorMemAccessInfo{{Write: write}})
+		instBuffer.EXPECT().Peek().Return(vectorMemInst{wavefront: wave})
+		instBuffer.EXPECT().Pop().Return(vectorMemInst{wavefront: wave})
+
+		vecMemUnit.instToTransaction(10)
+
+		Expect(handler.faults).To(HaveLen(1))
+		fault := handler.faults[0].(*emu.MemoryFault)
+		Expect(fault.Lane).To(Equal(1))
+		Expect(fault.VAddr).To(Equal(uint64(0x140)))
+		Expect(fault.PC).To(Equal(uint64(0x2000)))
+		Expect(fault.IsWrite).To(BeTrue())
+		Expect(fault.Inst).To(Equal("flat_store_dword v[0:1], v2"))
+		Expect(vecMemUnit.transactionsWaiting).To(HaveLen(1))
+	})
+
+	It("should run buffer_load_dword", func() {
+		kernelWave := kernels.NewWavefront()
+		wave := wavefront.NewWavefront(kernelWave)
+		inst := wavefront.NewInst(insts.NewInst())
+		inst.Format = insts.FormatTable[insts.MUBUF]
+		inst.Opcode = 20
+		inst.OffEn = true
+		inst.Dst = insts.NewVRegOperand(0, 0, 1)
+		wave.SetDynamicInst(inst)
+
+		layout := wave.Scratchpad().AsMUBUF()
+		layout.EXEC = 0xffffffffffffffff
+		layout.SRSRC = [4]uint32{0x1000, 0, 256, 0}
+		for i := 0; i < 64; i++ {
+			layout.VADDR[i*2] = uint32(i * 4)
+		}
+
+		transactions := make([]VectorMemAccessInfo, 4)
+		for i := 0; i < 4; i++ {
+			read := mem.ReadReqBuilder{}.
+				WithAddress(0x1000).
+				WithByteSize(64).
+				Build()
+			transactions[i].Read = read
+		}
+		coalescer.EXPECT().generateMemTransactions(wave).Return(transactions)
+		instBuffer.EXPECT().Peek().Return(vectorMemInst{wavefront: wave})
+		instBuffer.EXPECT().Pop().Return(vectorMemInst{wavefront: wave})
+
+		madeProgress := vecMemUnit.instToTransaction(10)
+
+		Expect(madeProgress).To(BeTrue())
+		Expect(layout.ADDR[1]).To(Equal(uint64(0x1004)))
+		Expect(layout.InRange).To(Equal(uint64(0xffffffffffffffff)))
+		Expect(wave.OutstandingVectorMemAccess).To(Equal(1))
+		Expect(wave.OutstandingScalarMemAccess).To(Equal(0))
+		Expect(vecMemUnit.transactionsWaiting).To(HaveLen(4))
+	})
+
+	It("should run flat_atomic_add", func() {
+		kernelWave := kernels.NewWavefront()
+		wave := wavefront.NewWavefront(kernelWave)
+		inst := wavefront.NewInst(insts.NewInst())
+		inst.Format = insts.FormatTable[insts.FLAT]
+		inst.Opcode = 66
+		inst.Dst = insts.NewVRegOperand(0, 0, 1)
+		wave.SetDynamicInst(inst)
+
+		atomic := atomics.AtomicReqBuilder{}.
+			WithAddress(0x100).
+			WithOp(emu.AtomicAdd).
+			Build()
+		transactions := []VectorMemAccessInfo{{Atomic: atomic}}
+		coalescer.EXPECT().generateMemTransactions(wave).Return(transactions)
+		instBuffer.EXPECT().Peek().Return(vectorMemInst{wavefront: wave})
+		instBuffer.EXPECT().Pop().Return(vectorMemInst{wavefront: wave})
+
+		madeProgress := vecMemUnit.instToTransaction(10)
+
+		Expect(madeProgress).To(BeTrue())
+		Expect(wave.OutstandingVectorMemAccess).To(Equal(1))
+		Expect(wave.OutstandingScalarMemAccess).To(Equal(1))
+		Expect(cu.InFlightVectorMemAccess).To(HaveLen(1))
+		Expect(vecMemUnit.transactionsWaiting).To(HaveLen(1))
+		Expect(atomic.Src).To(BeIdenticalTo(cu.ToVectorMem))
+		Expect(atomic.CanWaitForCoalesce).To(BeFalse())
+	})
+
 	It("should add transactions to pipeline", func() {
 		transactions := make([]VectorMemAccessInfo, 4)
 		for i := 0; i < 4; i++ {
@@ -201,3 +315,27 @@ var _ = Describe("Vector Memory Unit", func() {
 		Expect(vecMemUnit.transactionsWaiting).To(BeEmpty())
 	})
 })
+
+// rangeChecker accepts the accesses within [start, end).
+type rangeChecker struct {
+	start, end uint64
+}
+
+func (c *rangeChecker) CheckAccess(
+	pid vm.PID,
+	vAddr, byteSize uint64,
+) error {
+	if vAddr < c.start || vAddr+byteSize > c.end {
+		return errors.New("out of range")
+	}
+
+	return nil
+}
+
+type faultRecorder struct {
+	faults []error
+}
+
+func (r *faultRecorder) HandleMemoryFault(now sim.VTimeInSec, fault error) {
+	r.faults = append(r.faults, fault)
+}
@@ -11,6 +11,10 @@ type WavefrontPool struct {
 	Capacity int
 	wfs      []*wavefront.Wavefront
 	VRegFile sim.Component
+
+	// version changes whenever a wavefront is added or removed, so that the
+	// issue arbiters can tell if what they know about the pool is outdated.
+	version uint64
 }
 
 // NewWavefrontPool creates and returns a new WavefrontPool
@@ -26,6 +30,7 @@ func NewWavefrontPool(capacity int) *WavefrontPool {
 // AddWf will add an wavefront to the wavefront pool
 func (wfp *WavefrontPool) AddWf(wf *wavefront.Wavefront) {
 	wfp.wfs = append(wfp.wfs, wf)
+	wfp.version++
 }
 
 // Availability returns the number of extra Wavefront that the wavefront pool
@@ -39,6 +44,7 @@ func (wfp *WavefrontPool) RemoveWf(wf *wavefront.Wavefront) {
 	for i, wfToRemove := range wfp.wfs {
 		if wfToRemove == wf {
 			wfp.wfs = append(wfp.wfs[:i], wfp.wfs[i+1:]...)
+			wfp.version++
 			return
 		}
 	}
@@ -4,6 +4,7 @@ import (
 	"log"
 
 	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/emu"
 	"github.com/sarchlab/mgpusim/v3/insts"
 	"github.com/sarchlab/mgpusim/v3/protocol"
 	"github.com/sarchlab/mgpusim/v3/timing/wavefront"
@@ -54,6 +55,7 @@ func (d *WfDispatcherImpl) setWfInfo(
 	wf.LDSOffset = location.LDSOffset
 	wf.PC = wf.Packet.KernelObject + wf.CodeObject.KernelCodeEntryByteOffset
 	wf.EXEC = wf.InitExecMask
+	wf.MODE = emu.InitialMode(wf.CodeObject)
 }
 
 //nolint:gocyclo,funlen
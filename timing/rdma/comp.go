@@ -8,6 +8,7 @@ import (
 	"github.com/sarchlab/akita/v3/mem/mem"
 	"github.com/sarchlab/akita/v3/sim"
 	"github.com/sarchlab/akita/v3/tracing"
+	"github.com/sarchlab/mgpusim/v3/timing/atomics"
 )
 
 type transaction struct {
@@ -34,6 +35,7 @@ type Comp struct {
 	currentDrainReq         *DrainReq
 
 	localModules           mem.LowModuleFinder
+	localAtomicUnit        sim.Port
 	RemoteRDMAAddressTable mem.LowModuleFinder
 
 	transactionsFromOutside []transaction
@@ -45,6 +47,12 @@ func (c *Comp) SetLocalModuleFinder(lmf mem.LowModuleFinder) {
 	c.localModules = lmf
 }
 
+// SetLocalAtomicUnit sets the port that executes the atomic requests from
+// other GPUs.
+func (c *Comp) SetLocalAtomicUnit(port sim.Port) {
+	c.localAtomicUnit = port
+}
+
 // Tick checks if make progress
 func (c *Comp) Tick(now sim.VTimeInSec) bool {
 	madeProgress := false
@@ -236,6 +244,13 @@ func (c *Comp) processReqFromOutside(
 	req mem.AccessReq,
 ) bool {
 	dst := c.localModules.Find(req.GetAddress())
+	if _, isAtomic := req.(*atomics.AtomicReq); isAtomic {
+		if c.localAtomicUnit == nil {
+			log.Panicf("%s cannot process atomic requests", c.Name())
+		}
+
+		dst = c.localAtomicUnit
+	}
 
 	cloned := c.cloneReq(req)
 	cloned.Meta().Src = c.ToL2
@@ -363,6 +378,19 @@ func (c *Comp) cloneReq(origin mem.AccessReq) mem.AccessReq {
 			WithDirtyMask(origin.DirtyMask).
 			Build()
 		return write
+	case *atomics.AtomicReq:
+		builder := atomics.AtomicReqBuilder{}.
+			WithSendTime(origin.SendTime).
+			WithSrc(origin.Src).
+			WithDst(origin.Dst).
+			WithPID(origin.PID).
+			WithAddress(origin.Address).
+			WithOp(origin.Op).
+			WithLanes(origin.Lanes)
+		if origin.Is64Bit {
+			builder = builder.With64Bit()
+		}
+		return builder.Build()
 	default:
 		log.Panicf("cannot clone request of type %s",
 			reflect.TypeOf(origin))
@@ -389,6 +417,15 @@ func (c *Comp) cloneRsp(origin mem.AccessRsp, rspTo string) mem.AccessRsp {
 			WithRspTo(rspTo).
 			Build()
 		return rsp
+	case *atomics.AtomicRsp:
+		rsp := atomics.AtomicRspBuilder{}.
+			WithSendTime(origin.SendTime).
+			WithSrc(origin.Src).
+			WithDst(origin.Dst).
+			WithRspTo(rspTo).
+			WithPreOpValues(origin.PreOpValues).
+			Build()
+		return rsp
 	default:
 		log.Panicf("cannot clone request of type %s",
 			reflect.TypeOf(origin))
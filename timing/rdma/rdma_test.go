@@ -9,6 +9,8 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/sarchlab/akita/v3/mem/mem"
 	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/emu"
+	"github.com/sarchlab/mgpusim/v3/timing/atomics"
 )
 
 //go:generate mockgen -destination "mock_sim_test.go" -package $GOPACKAGE -write_package_comment=false github.com/sarchlab/akita/v3/sim Port,Engine
@@ -146,6 +148,44 @@ var _ = Describe("Engine", func() {
 		})
 	})
 
+	Context("Atomic from outside", func() {
+		var (
+			atomicUnit *MockPort
+			atomic     *atomics.AtomicReq
+		)
+
+		BeforeEach(func() {
+			atomicUnit = NewMockPort(mockCtrl)
+			rdmaEngine.SetLocalAtomicUnit(atomicUnit)
+			atomic = atomics.AtomicReqBuilder{}.
+				WithSendTime(6).
+				WithSrc(remoteGPU).
+				WithDst(rdmaEngine.ToOutside).
+				WithAddress(0x100).
+				WithOp(emu.AtomicAdd).
+				WithLanes([]atomics.LaneOp{{Offset: 4, Src: 1}}).
+				Build()
+		})
+
+		It("should send atomic to the atomic unit", func() {
+			toOutside.EXPECT().Peek().Return(atomic)
+			toOutside.EXPECT().Peek().Return(nil)
+			toL2.EXPECT().
+				Send(gomock.AssignableToTypeOf(&atomics.AtomicReq{})).
+				Do(func(req *atomics.AtomicReq) {
+					Expect(req.Dst).To(BeIdenticalTo(atomicUnit))
+					Expect(req.Op).To(Equal(emu.AtomicAdd))
+					Expect(req.Lanes).To(Equal(atomic.Lanes))
+				}).
+				Return(nil)
+			toOutside.EXPECT().Retrieve(sim.VTimeInSec(10)).Return(atomic)
+
+			rdmaEngine.processFromOutside(10)
+
+			Expect(rdmaEngine.transactionsFromOutside).To(HaveLen(1))
+		})
+	})
+
 	Context("DataReady from outside", func() {
 		var (
 			readFromInside *mem.ReadReq
@@ -12,6 +12,7 @@ type Builder struct {
 	freq           sim.Freq
 	numReqPerCycle int
 	bufferSize     int
+	log2BlockSize  uint64
 }
 
 // MakeBuilder creates a builder with default parameters.
@@ -20,6 +21,7 @@ func MakeBuilder() Builder {
 		freq:           1 * sim.GHz,
 		numReqPerCycle: 4,
 		bufferSize:     128,
+		log2BlockSize:  6,
 	}
 }
 
@@ -48,6 +50,13 @@ func (b Builder) WithBufferSize(n int) Builder {
 	return b
 }
 
+// WithLog2BlockSize sets the cache line size of the caches below, as a power
+// of 2.
+func (b Builder) WithLog2BlockSize(n uint64) Builder {
+	b.log2BlockSize = n
+	return b
+}
+
 // Build creates a ReorderBuffer with the given parameters.
 func (b Builder) Build(name string) *ReorderBuffer {
 	rb := &ReorderBuffer{}
@@ -60,6 +69,8 @@ func (b Builder) Build(name string) *ReorderBuffer {
 
 	rb.bufferSize = b.bufferSize
 	rb.numReqPerCycle = b.numReqPerCycle
+	rb.log2BlockSize = b.log2BlockSize
+	rb.atomicLines = make(map[lineID]bool)
 
 	b.createPorts(name, rb)
 
@@ -81,6 +92,13 @@ func (b *Builder) createPorts(name string, rb *ReorderBuffer) {
 	)
 	rb.AddPort("Bottom", rb.bottomPort)
 
+	rb.atomicPort = sim.NewLimitNumMsgPort(
+		rb,
+		2*b.numReqPerCycle,
+		name+".AtomicPort",
+	)
+	rb.AddPort("Atomic", rb.atomicPort)
+
 	rb.controlPort = sim.NewLimitNumMsgPort(
 		rb,
 		1,
@@ -5,8 +5,10 @@ import (
 	"container/list"
 
 	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/mem/vm"
 	"github.com/sarchlab/akita/v3/sim"
 	"github.com/sarchlab/akita/v3/tracing"
+	"github.com/sarchlab/mgpusim/v3/timing/atomics"
 )
 
 type transaction struct {
@@ -15,6 +17,12 @@ type transaction struct {
 	rspFromBottom mem.AccessRsp
 }
 
+// lineID identifies a cache line in the address space of a process.
+type lineID struct {
+	pid  vm.PID
+	addr uint64
+}
+
 // ReorderBuffer can maintain the returning order of memory transactions.
 type ReorderBuffer struct {
 	*sim.TickingComponent
@@ -22,11 +30,22 @@ type ReorderBuffer struct {
 	topPort     sim.Port
 	bottomPort  sim.Port
 	controlPort sim.Port
+	atomicPort  sim.Port
 
 	BottomUnit sim.Port
 
+	// AtomicUnit is the port that atomic requests are sent to. Atomic
+	// requests bypass the BottomUnit, but still return in order. Since the
+	// BottomUnit does not observe the updates, the reads and writes to the
+	// cache lines that atomic requests have updated are also sent to the
+	// AtomicUnit, so that they never hit stale lines in the BottomUnit.
+	AtomicUnit sim.Port
+
 	bufferSize     int
 	numReqPerCycle int
+	log2BlockSize  uint64
+
+	atomicLines map[lineID]bool
 
 	toBottomReqIDToTransactionTable map[string]*list.Element
 	transactions                    *list.List
@@ -108,12 +127,19 @@ func (b *ReorderBuffer) restart(
 	b.toBottomReqIDToTransactionTable = make(map[string]*list.Element)
 	b.transactions.Init()
 
+	// The caches are invalidated before the ReorderBuffer restarts, so they
+	// no longer hold the lines that the atomic requests have updated.
+	b.atomicLines = make(map[lineID]bool)
+
 	for b.topPort.Retrieve(now) != nil {
 	}
 
 	for b.bottomPort.Retrieve(now) != nil {
 	}
 
+	for b.atomicPort.Retrieve(now) != nil {
+	}
+
 	b.controlPort.Retrieve(now)
 
 	// fmt.Printf("%.10f, %s, rob restarted\n", now, b.Name())
@@ -128,6 +154,7 @@ func (b *ReorderBuffer) runPipeline(now sim.VTimeInSec) (madeProgress bool) {
 
 	for i := 0; i < b.numReqPerCycle; i++ {
 		madeProgress = b.parseBottom(now) || madeProgress
+		madeProgress = b.parseAtomic(now) || madeProgress
 	}
 
 	for i := 0; i < b.numReqPerCycle; i++ {
@@ -150,9 +177,15 @@ func (b *ReorderBuffer) topDown(now sim.VTimeInSec) bool {
 	req := item.(mem.AccessReq)
 	trans := b.createTransaction(req)
 
-	trans.reqToBottom.Meta().Src = b.bottomPort
+	port := b.bottomPort
+	if b.sendsToAtomicUnit(req) {
+		port = b.atomicPort
+		trans.reqToBottom.Meta().Dst = b.AtomicUnit
+	}
+
+	trans.reqToBottom.Meta().Src = port
 	trans.reqToBottom.Meta().SendTime = now
-	err := b.bottomPort.Send(trans.reqToBottom)
+	err := port.Send(trans.reqToBottom)
 	if err != nil {
 		return false
 	}
@@ -160,6 +193,10 @@ func (b *ReorderBuffer) topDown(now sim.VTimeInSec) bool {
 	b.addTransaction(trans)
 	b.topPort.Retrieve(now)
 
+	if _, isAtomic := req.(*atomics.AtomicReq); isAtomic {
+		b.atomicLines[b.lineOf(req)] = true
+	}
+
 	tracing.TraceReqReceive(req, b)
 	tracing.TraceReqInitiate(trans.reqToBottom, b,
 		tracing.MsgIDAtReceiver(req, b))
@@ -167,8 +204,35 @@ func (b *ReorderBuffer) topDown(now sim.VTimeInSec) bool {
 	return true
 }
 
+// sendsToAtomicUnit decides if a request is sent to the AtomicUnit. Besides
+// the atomic requests, the reads and writes to the cache lines that atomic
+// requests have updated are sent around the BottomUnit, which may hold stale
+// copies of the lines.
+func (b *ReorderBuffer) sendsToAtomicUnit(req mem.AccessReq) bool {
+	if _, isAtomic := req.(*atomics.AtomicReq); isAtomic {
+		return true
+	}
+
+	return b.atomicLines[b.lineOf(req)]
+}
+
+func (b *ReorderBuffer) lineOf(req mem.AccessReq) lineID {
+	return lineID{
+		pid:  req.GetPID(),
+		addr: req.GetAddress() >> b.log2BlockSize << b.log2BlockSize,
+	}
+}
+
 func (b *ReorderBuffer) parseBottom(now sim.VTimeInSec) bool {
-	item := b.bottomPort.Peek()
+	return b.parseRsp(now, b.bottomPort)
+}
+
+func (b *ReorderBuffer) parseAtomic(now sim.VTimeInSec) bool {
+	return b.parseRsp(now, b.atomicPort)
+}
+
+func (b *ReorderBuffer) parseRsp(now sim.VTimeInSec, port sim.Port) bool {
+	item := port.Peek()
 	if item == nil {
 		return false
 	}
@@ -184,7 +248,7 @@ func (b *ReorderBuffer) parseBottom(now sim.VTimeInSec) bool {
 		tracing.TraceReqFinalize(trans.reqToBottom, b)
 	}
 
-	b.bottomPort.Retrieve(now)
+	port.Retrieve(now)
 
 	return true
 }
@@ -245,6 +309,8 @@ func (b *ReorderBuffer) duplicateReq(req mem.AccessReq) mem.AccessReq {
 		return b.duplicateReadReq(req)
 	case *mem.WriteReq:
 		return b.duplicateWriteReq(req)
+	case *atomics.AtomicReq:
+		return b.duplicateAtomicReq(req)
 	default:
 		panic("unsupported type")
 	}
@@ -269,6 +335,23 @@ func (b *ReorderBuffer) duplicateWriteReq(req *mem.WriteReq) *mem.WriteReq {
 		Build()
 }
 
+func (b *ReorderBuffer) duplicateAtomicReq(
+	req *atomics.AtomicReq,
+) *atomics.AtomicReq {
+	builder := atomics.AtomicReqBuilder{}.
+		WithAddress(req.Address).
+		WithPID(req.PID).
+		WithOp(req.Op).
+		WithLanes(req.Lanes).
+		WithDst(b.AtomicUnit)
+
+	if req.Is64Bit {
+		builder = builder.With64Bit()
+	}
+
+	return builder.Build()
+}
+
 func (b *ReorderBuffer) duplicateRsp(
 	rsp mem.AccessRsp,
 	rspTo string,
@@ -278,6 +361,11 @@ func (b *ReorderBuffer) duplicateRsp(
 		return b.duplicateDataReadyRsp(rsp, rspTo)
 	case *mem.WriteDoneRsp:
 		return b.duplicateWriteDoneRsp(rsp, rspTo)
+	case *atomics.AtomicRsp:
+		return atomics.AtomicRspBuilder{}.
+			WithPreOpValues(rsp.PreOpValues).
+			WithRspTo(rspTo).
+			Build()
 	default:
 		panic("type not supported")
 	}
@@ -6,6 +6,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/sarchlab/akita/v3/mem/mem"
 	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/timing/atomics"
 )
 
 var _ = Describe("Reorder Buffer", func() {
@@ -15,6 +16,7 @@ var _ = Describe("Reorder Buffer", func() {
 		topPort    *MockPort
 		bottomPort *MockPort
 		ctrlPort   *MockPort
+		atomicPort *MockPort
 	)
 
 	BeforeEach(func() {
@@ -23,6 +25,7 @@ var _ = Describe("Reorder Buffer", func() {
 		topPort = NewMockPort(mockCtrl)
 		bottomPort = NewMockPort(mockCtrl)
 		ctrlPort = NewMockPort(mockCtrl)
+		atomicPort = NewMockPort(mockCtrl)
 
 		rob = MakeBuilder().
 			WithBufferSize(10).
@@ -30,7 +33,9 @@ var _ = Describe("Reorder Buffer", func() {
 		rob.topPort = topPort
 		rob.bottomPort = bottomPort
 		rob.controlPort = ctrlPort
+		rob.atomicPort = atomicPort
 		rob.BottomUnit = NewMockPort(mockCtrl)
+		rob.AtomicUnit = NewMockPort(mockCtrl)
 	})
 
 	AfterEach(func() {
@@ -95,6 +100,75 @@ var _ = Describe("Reorder Buffer", func() {
 			Expect(rob.transactions.Len()).To(Equal(1))
 			Expect(rob.toBottomReqIDToTransactionTable).To(HaveLen(1))
 		})
+
+		It("should forward atomic requests to the atomic unit", func() {
+			atomic := atomics.AtomicReqBuilder{}.
+				WithAddress(0x100).
+				With64Bit().
+				Build()
+
+			topPort.EXPECT().Peek().Return(atomic)
+			topPort.EXPECT().Retrieve(sim.VTimeInSec(10))
+			atomicPort.EXPECT().
+				Send(gomock.Any()).
+				Do(func(req *atomics.AtomicReq) {
+					Expect(req.Src).To(BeIdenticalTo(rob.atomicPort))
+					Expect(req.Dst).To(BeIdenticalTo(rob.AtomicUnit))
+					Expect(req.Address).To(Equal(uint64(0x100)))
+					Expect(req.Is64Bit).To(BeTrue())
+				}).
+				Return(nil)
+
+			madeProgress := rob.topDown(10)
+
+			Expect(madeProgress).To(BeTrue())
+			Expect(rob.transactions.Len()).To(Equal(1))
+		})
+
+		It("should send the reads to the lines that atomics updated to the "+
+			"atomic unit", func() {
+			atomic := atomics.AtomicReqBuilder{}.
+				WithPID(1).
+				WithAddress(0x100).
+				Build()
+			read = mem.ReadReqBuilder{}.
+				WithPID(1).
+				WithAddress(0x108).
+				WithByteSize(4).
+				Build()
+			other := mem.ReadReqBuilder{}.
+				WithPID(1).
+				WithAddress(0x140).
+				WithByteSize(4).
+				Build()
+
+			topPort.EXPECT().Peek().Return(atomic)
+			topPort.EXPECT().Peek().Return(read)
+			topPort.EXPECT().Peek().Return(other)
+			topPort.EXPECT().Retrieve(sim.VTimeInSec(10)).Times(3)
+			atomicPort.EXPECT().Send(gomock.Any()).Return(nil)
+			atomicPort.EXPECT().
+				Send(gomock.Any()).
+				Do(func(req *mem.ReadReq) {
+					Expect(req.Src).To(BeIdenticalTo(rob.atomicPort))
+					Expect(req.Dst).To(BeIdenticalTo(rob.AtomicUnit))
+					Expect(req.Address).To(Equal(uint64(0x108)))
+				}).
+				Return(nil)
+			bottomPort.EXPECT().
+				Send(gomock.Any()).
+				Do(func(req *mem.ReadReq) {
+					Expect(req.Dst).To(BeIdenticalTo(rob.BottomUnit))
+					Expect(req.Address).To(Equal(uint64(0x140)))
+				}).
+				Return(nil)
+
+			rob.topDown(10)
+			rob.topDown(10)
+			rob.topDown(10)
+
+			Expect(rob.transactions.Len()).To(Equal(3))
+		})
 	})
 
 	Context("parse bottom", func() {
@@ -132,6 +206,33 @@ var _ = Describe("Reorder Buffer", func() {
 		})
 	})
 
+	Context("parse atomic", func() {
+		var (
+			atomicFromTop *atomics.AtomicReq
+			transaction   *transaction
+		)
+
+		BeforeEach(func() {
+			atomicFromTop = atomics.AtomicReqBuilder{}.Build()
+			transaction = rob.createTransaction(atomicFromTop)
+			rob.addTransaction(transaction)
+		})
+
+		It("should attach response to transaction", func() {
+			rsp := atomics.AtomicRspBuilder{}.
+				WithRspTo(transaction.reqToBottom.Meta().ID).
+				Build()
+
+			atomicPort.EXPECT().Peek().Return(rsp)
+			atomicPort.EXPECT().Retrieve(sim.VTimeInSec(10))
+
+			madeProgress := rob.parseAtomic(10)
+
+			Expect(madeProgress).To(BeTrue())
+			Expect(transaction.rspFromBottom).To(BeIdenticalTo(rsp))
+		})
+	})
+
 	Context("bottom up", func() {
 		var (
 			topModule     sim.Port
@@ -224,11 +325,15 @@ var _ = Describe("Reorder Buffer", func() {
 			ctrlPort.EXPECT().Send(gomock.Any()).Return(nil)
 			topPort.EXPECT().Retrieve(sim.VTimeInSec(10)).AnyTimes()
 			bottomPort.EXPECT().Retrieve(sim.VTimeInSec(10)).AnyTimes()
+			atomicPort.EXPECT().Retrieve(sim.VTimeInSec(10)).AnyTimes()
+
+			rob.atomicLines[lineID{addr: 0x100}] = true
 
 			madeProgress := rob.processControlMsg(10)
 
 			Expect(madeProgress).To(BeTrue())
 			Expect(rob.isFlushing).To(BeFalse())
+			Expect(rob.atomicLines).To(BeEmpty())
 		})
 	})
 
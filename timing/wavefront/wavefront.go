@@ -52,9 +52,15 @@ type Wavefront struct {
 	VCC  uint64
 	M0   uint32
 	SCC  uint8
+	MODE uint32
 
 	OutstandingScalarMemAccess int
 	OutstandingVectorMemAccess int
+
+	// RegReadyTime records when the results of the executed instructions can
+	// be read. It is indexed by the register type, and the registers beyond
+	// its end are ready.
+	RegReadyTime []sim.VTimeInSec
 }
 
 // NewWavefront creates a new Wavefront of the timing package, wrapping the
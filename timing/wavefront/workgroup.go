@@ -12,6 +12,11 @@ type WorkGroup struct {
 	Wfs    []*Wavefront
 	MapReq *protocol.MapWGReq
 	LDS    []byte
+
+	// Preempting is set when the wavefronts of the work-group have to stop
+	// at the next instruction boundary so that the context of the
+	// work-group can be saved.
+	Preempting bool
 }
 
 // NewWorkGroup returns a newly constructed WorkGroup